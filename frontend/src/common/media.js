@@ -50,6 +50,7 @@ export const FormatGif = "gif";
 export const FormatSVG = "svg";
 
 // Content type strings for common media formats, see https://tools.woolyss.com/html5-canplaytype-tester/:
+export const ContentTypeM3u8 = "application/vnd.apple.mpegurl"; // HTTP Live Streaming (HLS) playlist
 export const ContentTypeMp4 = "video/mp4";
 export const ContentTypeMp4AvcMain = ContentTypeMp4 + '; codecs="avc1.4d0028"'; // AVC High Profile Level 4
 export const ContentTypeMp4AvcHigh = ContentTypeMp4 + '; codecs="avc1.640028"'; // MPEG-4 AVC (H.264), High Level 4.0
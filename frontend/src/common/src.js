@@ -6,6 +6,7 @@ export const Estimate = "estimate";
 export const File = "file";
 export const Name = "name";
 export const Meta = "meta";
+export const Track = "track";
 export const Xmp = "xmp";
 export const Yaml = "yaml";
 export const Marker = "marker";
@@ -802,6 +802,8 @@ export default class $util {
         return $gettext("Keyword");
       case "meta":
         return $gettext("Metadata");
+      case "track":
+        return $gettext("GPS Track");
       case "subject":
         return $gettext("Subject");
       case "title":
@@ -900,7 +902,19 @@ export default class $util {
       return media.FormatTheora;
     }
 
-    return media.FormatAvc;
+    return this.videoTranscodeFormat();
+  }
+
+  // videoTranscodeFormat returns the preferred transcoding format if the browser supports it, or AVC otherwise.
+  static videoTranscodeFormat() {
+    switch ($config.values?.videoCodec) {
+      case media.FormatAv1:
+        return can.useMp4Av1 ? media.FormatAv1 : media.FormatAvc;
+      case "hevc":
+        return can.useMp4Hvc ? media.FormatHvc : media.FormatAvc;
+      default:
+        return media.FormatAvc;
+    }
   }
 
   // videoFormatUrl builds the signed video URL for a specific format.
@@ -916,6 +930,16 @@ export default class $util {
     return `${$config.videoUri}/videos/${hash}/${$config.previewToken}/${format}`;
   }
 
+  // videoHlsUrl builds the signed HLS master playlist URL for adaptive streaming,
+  // or returns an empty string if the browser or server does not support it.
+  static videoHlsUrl(hash) {
+    if (!hash || !can.useHls || $config.values?.disable?.hls) {
+      return "";
+    }
+
+    return `${$config.videoUri}/videos/${hash}/${$config.previewToken}/hls/master.m3u8`;
+  }
+
   // videoUrl resolves the best playable video URL for given codec hints.
   static videoUrl(hash, codec, mime) {
     return this.videoFormatUrl(hash, this.videoFormat(codec, mime));
@@ -930,6 +954,8 @@ export default class $util {
         return media.ContentTypeMp4HvcMain;
       case media.FormatHev:
         return media.ContentTypeMp4HevMain;
+      case media.FormatAv1:
+        return media.ContentTypeMp4Av1Main;
       case media.FormatVvc:
         return media.ContentTypeMp4Vvc;
       case media.FormatVp8:
@@ -15,6 +15,7 @@ export class Service extends RestModel {
       AccKey: "",
       AccUser: "",
       AccPass: "",
+      AccHostKey: "",
       AccTimeout: "",
       AccError: "",
       AccErrors: 0,
@@ -31,7 +32,14 @@ export class Service extends RestModel {
       SyncFilenames: true,
       SyncUpload: false,
       SyncDownload: !$config.get("readonly"),
+      SyncDelete: false,
+      SyncConflict: "keep",
       SyncRaw: true,
+      SyncInclude: "",
+      SyncExclude: "",
+      SyncTypes: "",
+      SyncMaxSize: 0,
+      SyncSince: null,
       CreatedAt: "",
       UpdatedAt: "",
       DeletedAt: null,
@@ -391,6 +391,48 @@ export const Timeouts = () => [
   },
 ];
 
+export const SyncConflicts = () => [
+  {
+    text: $gettext("Keep both"),
+    value: "keep",
+  },
+  {
+    text: $gettext("Newest wins"),
+    value: "newest",
+  },
+  {
+    text: $gettext("Remote wins"),
+    value: "remote",
+  },
+  {
+    text: $gettext("Local wins"),
+    value: "local",
+  },
+  {
+    text: $gettext("Manual"),
+    value: "manual",
+  },
+];
+
+export const SyncTypes = () => [
+  {
+    text: $gettext("Photos"),
+    value: "image",
+  },
+  {
+    text: $gettext("Videos"),
+    value: "video",
+  },
+  {
+    text: $gettext("RAW"),
+    value: "raw",
+  },
+  {
+    text: $gettext("Sidecar"),
+    value: "sidecar",
+  },
+];
+
 export const RetryLimits = () => [
   {
     text: "None",
@@ -502,4 +544,9 @@ export const Orientations = () => [
   { value: 8, text: "270°" },
 ];
 
-export const AccountTypes = () => [{ value: "webdav", text: $gettext("WebDAV") }];
+export const AccountTypes = () => [
+  { value: "webdav", text: $gettext("WebDAV") },
+  { value: "s3", text: "S3" },
+  { value: "sftp", text: "SFTP" },
+  { value: "local", text: $gettext("Local Folder") },
+];
@@ -176,6 +176,9 @@ describe("common/util", () => {
       expect(webm).toBe("avc");
     }
   });
+  it("should return the transcoding video format", () => {
+    expect($util.videoTranscodeFormat()).toBe("avc");
+  });
   it("should convert -1 to roman", () => {
     const roman = $util.arabicToRoman(-1);
     expect(roman).toBe("");
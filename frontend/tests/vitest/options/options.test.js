@@ -19,6 +19,8 @@ import {
   RetryLimits,
   SetDefaultLocale,
   StartPages,
+  SyncConflicts,
+  SyncTypes,
   ThumbFilters,
   ThumbSizes,
   Timeouts,
@@ -215,6 +217,16 @@ describe("options/options", () => {
     expect(Timeouts()[1].value).toBe("high");
   });
 
+  it("should return sync conflict policies", () => {
+    expect(SyncConflicts()[0].value).toBe("keep");
+    expect(SyncConflicts().length).toBe(5);
+  });
+
+  it("should return sync media types", () => {
+    expect(SyncTypes()[0].value).toBe("image");
+    expect(SyncTypes().length).toBe(4);
+  });
+
   it("should return retry limits", () => {
     expect(RetryLimits()[1].value).toBe(1);
   });
@@ -255,6 +267,6 @@ describe("options/options", () => {
 
   it("should return service account type options", () => {
     expect(AccountTypes()[0].value).toBe("webdav");
-    expect(AccountTypes().length).toBe(1);
+    expect(AccountTypes().length).toBe(4);
   });
 });
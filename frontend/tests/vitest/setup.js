@@ -78,6 +78,8 @@ config.global.mocks = {
     formatRemainingSeconds: () => "0",
     videoFormat: () => "avc",
     videoFormatUrl: () => "/v.mp4",
+    videoHlsUrl: () => "",
+    videoTranscodeFormat: () => "avc",
     thumb: () => ({ src: "/t.jpg", w: 100, h: 100 }),
   },
   $api: { post: vi.fn(), delete: vi.fn(), get: vi.fn() },
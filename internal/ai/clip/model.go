@@ -0,0 +1,203 @@
+package clip
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"path/filepath"
+	"sync"
+
+	"github.com/disintegration/imaging"
+	tf "github.com/wamuir/graft/tensorflow"
+
+	"github.com/photoprism/photoprism/internal/ai/tensorflow"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/http/scheme"
+	"github.com/photoprism/photoprism/pkg/media"
+	"github.com/photoprism/photoprism/pkg/vector"
+)
+
+// Model uses a TensorFlow image encoder to compute normalized image embeddings.
+type Model struct {
+	model     *tf.SavedModel
+	modelPath string
+	meta      *tensorflow.ModelInfo
+	disabled  bool
+	mutex     sync.Mutex
+}
+
+// NewModel returns a new image encoder instance.
+func NewModel(modelPath string, resolution int, meta *tensorflow.ModelInfo, disabled bool) *Model {
+	if resolution <= 0 {
+		resolution = DefaultResolution
+	}
+
+	if meta == nil {
+		meta = new(tensorflow.ModelInfo)
+	}
+
+	if meta.Input == nil {
+		meta.Input = new(tensorflow.PhotoInput)
+	}
+
+	if meta.Input.Resolution() <= 0 {
+		meta.Input.SetResolution(resolution)
+	}
+
+	return &Model{
+		modelPath: modelPath,
+		meta:      meta,
+		disabled:  disabled,
+	}
+}
+
+// File returns the normalized embedding of the specified image file.
+func (m *Model) File(fileName string) (result vector.Vector, err error) {
+	if m.disabled {
+		return result, errors.New("model is disabled")
+	}
+
+	img, err := imaging.Open(fileName)
+
+	if err != nil {
+		return result, err
+	}
+
+	return m.Run(img)
+}
+
+// Url returns the normalized embedding of the image from the specified https or data URL.
+func (m *Model) Url(imgUrl string) (result vector.Vector, err error) {
+	if m.disabled {
+		return result, errors.New("model is disabled")
+	}
+
+	var data []byte
+
+	if data, err = media.ReadUrl(imgUrl, scheme.HttpsData); err != nil {
+		return result, err
+	}
+
+	img, err := imaging.Decode(bytes.NewReader(data))
+
+	if err != nil {
+		return result, err
+	}
+
+	return m.Run(img)
+}
+
+// Run returns the normalized embedding of the specified image.
+func (m *Model) Run(img image.Image) (result vector.Vector, err error) {
+	if loadErr := m.loadModel(); loadErr != nil {
+		return result, loadErr
+	}
+
+	res := m.meta.Input.Resolution()
+
+	// Resize the image only if its resolution does not match the model.
+	if img.Bounds().Dx() != res || img.Bounds().Dy() != res {
+		img = imaging.Fill(img, res, res, imaging.Center, imaging.Lanczos)
+	}
+
+	input, err := tensorflow.Image(img, m.meta.Input, nil)
+
+	if err != nil {
+		return result, err
+	}
+
+	// Run inference.
+	output, err := m.model.Session.Run(
+		map[tf.Output]*tf.Tensor{
+			m.model.Graph.Operation(m.meta.Input.Name).Output(m.meta.Input.OutputIndex): input,
+		},
+		[]tf.Output{
+			m.model.Graph.Operation(m.meta.Output.Name).Output(m.meta.Output.OutputIndex),
+		},
+		nil)
+
+	if err != nil {
+		return result, fmt.Errorf("%s (run inference)", err.Error())
+	} else if len(output) < 1 {
+		return result, fmt.Errorf("inference failed, no output")
+	}
+
+	values, ok := output[0].Value().([][]float32)
+
+	if !ok || len(values) < 1 || len(values[0]) == 0 {
+		return result, fmt.Errorf("inference failed, invalid output")
+	}
+
+	if result, err = vector.NewVector(values[0]); err != nil {
+		return result, err
+	}
+
+	return result.Normalize(), nil
+}
+
+// Init initializes tensorflow models if not disabled.
+func (m *Model) Init() (err error) {
+	if m.disabled {
+		return nil
+	}
+
+	return m.loadModel()
+}
+
+func (m *Model) loadModel() error {
+	// Use mutex to prevent the model from being loaded and
+	// initialized twice by different indexing workers.
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.model != nil {
+		// Already loaded
+		return nil
+	}
+
+	log.Infof("clip: loading %s", clean.Log(filepath.Base(m.modelPath)))
+
+	if len(m.meta.Tags) == 0 {
+		infos, err := tensorflow.GetModelTagsInfo(m.modelPath)
+
+		switch {
+		case err != nil:
+			log.Errorf("clip: could not get the model info at %s (%s)", clean.Log(m.modelPath), clean.Error(err))
+		case len(infos) == 1:
+			log.Debugf("clip: model info: %+v", infos[0])
+			m.meta.Merge(&infos[0])
+		case len(infos) > 1:
+			log.Warnf("clip: found %d metagraphs... that's too many", len(infos))
+		default:
+			log.Warnf("clip: no metagraphs found in %s", clean.Log(m.modelPath))
+		}
+	}
+
+	// Load saved TensorFlow model from the specified path.
+	model, err := tensorflow.SavedModel(m.modelPath, m.meta.Tags)
+
+	if err != nil {
+		return err
+	}
+
+	if !m.meta.IsComplete() || m.meta.Input.Name == "" || m.meta.Output == nil || m.meta.Output.Name == "" {
+		input, output, infoErr := tensorflow.GetInputAndOutputFromSavedModel(model)
+
+		if infoErr != nil {
+			log.Errorf("clip: could not get info from signatures (%s)", clean.Error(infoErr))
+			if input, output, infoErr = tensorflow.GuessInputAndOutput(model); infoErr != nil {
+				return fmt.Errorf("clip: %w", infoErr)
+			}
+		}
+
+		m.meta.Merge(&tensorflow.ModelInfo{
+			Input:  input,
+			Output: output,
+		})
+	}
+
+	m.model = model
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package clip
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/ai/tensorflow"
+)
+
+func TestNewModel(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		m := NewModel("testdata/clip", 0, nil, false)
+
+		assert.NotNil(t, m)
+		assert.Equal(t, DefaultResolution, m.meta.Input.Resolution())
+		assert.Equal(t, "testdata/clip", m.modelPath)
+	})
+	t.Run("Resolution", func(t *testing.T) {
+		m := NewModel("testdata/clip", 336, nil, false)
+		assert.Equal(t, 336, m.meta.Input.Resolution())
+	})
+	t.Run("InputResolution", func(t *testing.T) {
+		meta := &tensorflow.ModelInfo{Input: &tensorflow.PhotoInput{Name: "pixel_values", Width: 384, Height: 384}}
+		m := NewModel("testdata/clip", 224, meta, false)
+		assert.Equal(t, 384, m.meta.Input.Resolution())
+		assert.Equal(t, "pixel_values", m.meta.Input.Name)
+	})
+}
+
+func TestModel_File(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		m := NewModel("testdata/clip", 0, nil, true)
+
+		result, err := m.File(filepath.Join("..", "nsfw", "testdata", "dog.jpg"))
+
+		assert.Error(t, err)
+		assert.Empty(t, result)
+	})
+	t.Run("FileNotFound", func(t *testing.T) {
+		m := NewModel("testdata/clip", 0, nil, false)
+
+		result, err := m.File("testdata/missing.jpg")
+
+		assert.Error(t, err)
+		assert.Empty(t, result)
+	})
+}
+
+func TestModel_Init(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		m := NewModel("testdata/clip", 0, nil, true)
+		assert.NoError(t, m.Init())
+	})
+}
+
+func TestModel_Url(t *testing.T) {
+	t.Run("Disabled", func(t *testing.T) {
+		m := NewModel("testdata/clip", 0, nil, true)
+
+		result, err := m.Url("https://www.photoprism.app/images/logo.png")
+
+		assert.Error(t, err)
+		assert.Empty(t, result)
+	})
+	t.Run("InvalidScheme", func(t *testing.T) {
+		m := NewModel("testdata/clip", 0, nil, false)
+
+		result, err := m.Url("file:///etc/passwd")
+
+		assert.Error(t, err)
+		assert.Empty(t, result)
+	})
+}
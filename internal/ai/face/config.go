@@ -4,6 +4,12 @@ import (
 	"github.com/photoprism/photoprism/internal/thumb/crop"
 )
 
+// Supported face clustering algorithms.
+const (
+	ClusterDBSCAN = "dbscan"
+	ClusterOPTICS = "optics"
+)
+
 var (
 	// CropSize is the face image crop size used when generating FaceNet embeddings.
 	CropSize = crop.Sizes[crop.Tile160]
@@ -32,6 +38,12 @@ var (
 	CollisionDist = 0.05
 	// ClusterCore is the minimum number of faces required to seed a cluster core.
 	ClusterCore = 4
+	// ClusterAlgorithm is the algorithm used to group unclustered face embeddings, see ClusterDBSCAN and ClusterOPTICS.
+	ClusterAlgorithm = ClusterDBSCAN
+	// ClusterXi is the reachability steepness threshold used by OPTICS to extract clusters.
+	ClusterXi = 0.05
+	// SuggestDist is the maximum distance between an unknown face cluster and a known face for suggesting a merge.
+	SuggestDist = 0.9
 	// SampleThreshold is the number of faces required before automatic clustering begins.
 	SampleThreshold = 2 * ClusterCore
 	// Epsilon is the numeric tolerance used during cluster comparisons.
@@ -51,6 +63,8 @@ var (
 	LandmarkQualityScaleMax = 90
 	// LandmarkQualitySlack is the maximum allowed difference between the quality threshold and the detected score.
 	LandmarkQualitySlack = float32(4.0)
+	// LandmarkSizeMin is the minimum face size in pixels for locating eyes and other landmarks.
+	LandmarkSizeMin = 50
 )
 
 // PigoQualityThreshold returns the scale-adjusted minimum Pigo quality score threshold for the provided detection scale.
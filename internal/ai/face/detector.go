@@ -247,7 +247,7 @@ func (d *pigoDetector) Faces(det []pigo.Detection, params pigo.CascadeParams, fi
 		var landmarkCoords []Area
 		var eyesFound bool
 
-		needLandmarks := (findLandmarks || fallbackCandidate) && scale > 50
+		needLandmarks := (findLandmarks || fallbackCandidate) && scale > LandmarkSizeMin
 
 		if needLandmarks {
 			if findLandmarks {
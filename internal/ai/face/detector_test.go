@@ -210,3 +210,13 @@ func BenchmarkDetectorFacesLandmarks(b *testing.B) {
 		benchmarkFacesCount = faces.Count()
 	}
 }
+
+func TestDetectEyes(t *testing.T) {
+	faces, err := DetectEyes("testdata/18.jpg", 20)
+	require.NoError(t, err)
+	require.Equal(t, 2, faces.Count())
+
+	assert.True(t, faces[0].HasLandmarks())
+	assert.True(t, faces[0].EyesFound())
+	assert.False(t, faces[1].EyesFound())
+}
@@ -129,6 +129,12 @@ func ActiveEngineName() EngineName {
 	return EngineNone
 }
 
+// DetectEyes returns the faces detected in the provided file including their eyes and landmarks.
+// It always uses the bundled Pigo cascades, as other engines do not locate pupils.
+func DetectEyes(fileName string, minSize int) (Faces, error) {
+	return newPigoEngine().Detect(fileName, true, minSize)
+}
+
 // Detect runs the active engine on the provided file and returns the detected faces.
 func Detect(fileName string, findLandmarks bool, minSize int) (Faces, error) {
 	engine := ActiveEngine()
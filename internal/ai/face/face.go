@@ -44,6 +44,16 @@ type Face struct {
 	Embeddings Embeddings `json:"embeddings,omitempty"`
 }
 
+// HasLandmarks checks if the face is large enough for locating eyes and other landmarks.
+func (f *Face) HasLandmarks() bool {
+	return f.Area.Scale > LandmarkSizeMin
+}
+
+// EyesFound checks if both pupils were located, which usually means that the eyes are open.
+func (f *Face) EyesFound() bool {
+	return len(f.Eyes) >= 2
+}
+
 // Size returns the absolute face size in pixels.
 func (f *Face) Size() int {
 	return f.Area.Scale
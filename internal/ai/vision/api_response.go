@@ -57,6 +57,7 @@ type ApiResult struct {
 	Nsfw       []nsfw.Result     `yaml:"Nsfw,omitempty" json:"nsfw,omitempty"`
 	Embeddings []face.Embeddings `yaml:"Embeddings,omitempty" json:"embeddings,omitempty"`
 	Caption    *CaptionResult    `yaml:"Caption,omitempty" json:"caption,omitempty"`
+	Embedding  *EmbeddingResult  `yaml:"Embedding,omitempty" json:"embedding,omitempty"`
 }
 
 // IsEmpty checks if there is no result in the response data.
@@ -65,7 +66,7 @@ func (r *ApiResult) IsEmpty() bool {
 		return false
 	}
 
-	return len(r.Labels) == 0 && len(r.Nsfw) == 0 && len(r.Embeddings) == 0 && r.Caption == nil
+	return len(r.Labels) == 0 && len(r.Nsfw) == 0 && len(r.Embeddings) == 0 && r.Caption == nil && r.Embedding.IsEmpty()
 }
 
 // CaptionResult represents the result generated by a caption generation model.
@@ -186,3 +187,13 @@ func NewCaptionResponse(id string, model *Model, result *CaptionResult) ApiRespo
 		Result: ApiResult{Caption: result},
 	}
 }
+
+// NewEmbeddingResponse generates a new Vision API embedding service response.
+func NewEmbeddingResponse(id string, model *Model, result *EmbeddingResult) ApiResponse {
+	return ApiResponse{
+		Id:     clean.Type(id),
+		Code:   http.StatusOK,
+		Model:  &Model{Type: ModelTypeEmbedding, Name: model.Name, Version: model.Version, Resolution: model.Resolution},
+		Result: ApiResult{Embedding: result},
+	}
+}
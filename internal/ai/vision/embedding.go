@@ -0,0 +1,122 @@
+package vision
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/media"
+	"github.com/photoprism/photoprism/pkg/vector"
+)
+
+// EmbeddingResult represents an image or text embedding generated by an embedding model.
+type EmbeddingResult struct {
+	Values []float64 `yaml:"Values,omitempty" json:"values,omitempty"`
+	Model  string    `yaml:"Model,omitempty" json:"model,omitempty"`
+}
+
+// IsEmpty checks if the result contains no embedding values.
+func (r *EmbeddingResult) IsEmpty() bool {
+	return r == nil || len(r.Values) == 0
+}
+
+// Dim returns the number of embedding dimensions.
+func (r *EmbeddingResult) Dim() int {
+	if r == nil {
+		return 0
+	}
+
+	return len(r.Values)
+}
+
+// Vector returns the embedding as a normalized vector.
+func (r *EmbeddingResult) Vector() vector.Vector {
+	if r.IsEmpty() {
+		return vector.Vector{}
+	}
+
+	return vector.Vector(r.Values).Normalize()
+}
+
+var embeddingFunc = embeddingInternal
+
+// SetEmbeddingFunc overrides the embedding generator. Intended for tests.
+func SetEmbeddingFunc(fn func(Files, string, media.Src) (*EmbeddingResult, *Model, error)) {
+	if fn == nil {
+		embeddingFunc = embeddingInternal
+		return
+	}
+
+	embeddingFunc = fn
+}
+
+// GenerateEmbedding returns the embedding of the specified images.
+func GenerateEmbedding(images Files, mediaSrc media.Src) (*EmbeddingResult, *Model, error) {
+	if len(images) == 0 {
+		return nil, nil, errors.New("missing images")
+	}
+
+	return embeddingFunc(images, "", mediaSrc)
+}
+
+// GenerateTextEmbedding returns the embedding of the specified text, e.g. a search query.
+func GenerateTextEmbedding(text string) (*EmbeddingResult, *Model, error) {
+	if text = strings.TrimSpace(text); text == "" {
+		return nil, nil, errors.New("missing text")
+	}
+
+	return embeddingFunc(nil, text, media.SrcLocal)
+}
+
+func embeddingInternal(images Files, text string, mediaSrc media.Src) (result *EmbeddingResult, model *Model, err error) {
+	// Return if there is no configuration or no embedding model is configured.
+	if Config == nil {
+		return result, model, errors.New("vision service is not configured")
+	} else if model = Config.Model(ModelTypeEmbedding); model == nil {
+		return result, model, errors.New("missing embedding model")
+	}
+
+	modelName, _, _ := model.GetModel()
+
+	// Use remote service API if a server endpoint has been configured.
+	if uri, method := model.Endpoint(); uri != "" && method != "" {
+		if result, err = performEmbeddingRequest(model, images, text, uri, method); err != nil {
+			return result, model, err
+		} else if result.IsEmpty() {
+			return result, model, errors.New("invalid embedding model response")
+		}
+	} else if model.TensorFlow != nil {
+		if text != "" {
+			return result, model, errors.New("text embeddings require an embedding service")
+		}
+
+		tf := model.ClipModel()
+
+		if tf == nil {
+			return result, model, errors.New("invalid embedding model configuration")
+		}
+
+		var values vector.Vector
+
+		switch mediaSrc {
+		case media.SrcLocal:
+			values, err = tf.File(images[0])
+		case media.SrcRemote:
+			values, err = tf.Url(images[0])
+		default:
+			return result, model, errors.New("invalid media source")
+		}
+
+		if err != nil {
+			return result, model, err
+		}
+
+		result = &EmbeddingResult{Values: values}
+	} else {
+		return result, model, errors.New("invalid embedding model configuration")
+	}
+
+	// Use the configured model name so that image and text embeddings can be matched.
+	result.Model = modelName
+
+	return result, model, nil
+}
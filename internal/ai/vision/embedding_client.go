@@ -0,0 +1,163 @@
+package vision
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/ai/vision/ollama"
+	"github.com/photoprism/photoprism/internal/ai/vision/openai"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/http/header"
+	"github.com/photoprism/photoprism/pkg/http/scheme"
+)
+
+// performEmbeddingRequest requests an image or text embedding from the configured
+// service endpoint. Embedding APIs do not share the request format of generative
+// models, so the payloads are built here instead of the engine request builders.
+func performEmbeddingRequest(model *Model, images Files, text, uri, method string) (result *EmbeddingResult, err error) {
+	if model == nil {
+		return result, ErrInvalidModel
+	}
+
+	var payload any
+
+	format := model.EndpointRequestFormat()
+	modelName, name, version := model.GetModel()
+
+	switch format {
+	case ApiFormatOllama, ApiFormatOpenAI:
+		var input []string
+
+		if text != "" {
+			input = []string{text}
+		} else if format == ApiFormatOllama {
+			return result, errors.New("ollama only supports text embeddings")
+		} else if dataReq, dataErr := NewApiRequestImages(images[:1], scheme.Data); dataErr != nil {
+			return result, dataErr
+		} else {
+			input = dataReq.Images
+		}
+
+		if format == ApiFormatOllama {
+			payload = ollama.EmbedRequest{Model: modelName, Input: input, Truncate: true}
+		} else {
+			payload = openai.EmbeddingsRequest{Model: modelName, Input: input, EncodingFormat: "float"}
+		}
+	default:
+		var apiRequest *ApiRequest
+
+		if text != "" {
+			apiRequest = &ApiRequest{Prompt: text}
+		} else if apiRequest, err = NewApiRequest(format, images, model.EndpointFileScheme()); err != nil {
+			return result, err
+		}
+
+		apiRequest.Model = name
+		apiRequest.Version = version
+		apiRequest.GetId()
+
+		payload = apiRequest
+	}
+
+	data, err := json.Marshal(payload)
+
+	if err != nil {
+		return result, err
+	}
+
+	req, err := http.NewRequest(method, uri, bytes.NewReader(data))
+
+	if err != nil {
+		return result, err
+	}
+
+	header.SetContentType(req, header.ContentTypeJson)
+
+	// Add an authentication header if an access token is provided.
+	if key := model.EndpointKey(); key != "" {
+		header.SetAuthorization(req, key)
+	}
+
+	// Add custom OpenAI organization and project headers.
+	if format == ApiFormatOpenAI {
+		header.SetOpenAIOrg(req, model.Service.EndpointOrg())
+		header.SetOpenAIProject(req, model.Service.EndpointProject())
+	}
+
+	client := http.Client{Timeout: ServiceTimeout}
+	resp, err := client.Do(req)
+
+	if err != nil {
+		return result, err
+	}
+
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	body, err := io.ReadAll(resp.Body)
+
+	if err != nil {
+		return result, err
+	}
+
+	return parseEmbeddingResponse(format, body, resp.StatusCode)
+}
+
+// parseEmbeddingResponse extracts the first embedding from a service response.
+func parseEmbeddingResponse(format ApiFormat, body []byte, status int) (result *EmbeddingResult, err error) {
+	switch format {
+	case ApiFormatOllama:
+		var resp ollama.EmbedResponse
+
+		if err = json.Unmarshal(body, &resp); err != nil {
+			return result, err
+		} else if resp.Error != "" {
+			return result, fmt.Errorf("ollama: %s", clean.Log(resp.Error))
+		} else if status >= 300 {
+			return result, fmt.Errorf("ollama: status %d", status)
+		} else if len(resp.Embeddings) == 0 {
+			return result, errors.New("ollama: no embeddings")
+		}
+
+		return &EmbeddingResult{Values: resp.Embeddings[0], Model: resp.Model}, nil
+	case ApiFormatOpenAI:
+		if status >= 300 {
+			if msg := openai.ParseErrorMessage(body); msg != "" {
+				return nil, fmt.Errorf("openai: %s", msg)
+			}
+
+			return nil, fmt.Errorf("openai: status %d", status)
+		}
+
+		var resp openai.EmbeddingsResponse
+
+		if err = json.Unmarshal(body, &resp); err != nil {
+			return result, err
+		} else if resp.Error != nil && resp.Error.Message != "" {
+			return result, errors.New(resp.Error.Message)
+		} else if len(resp.Data) == 0 {
+			return result, errors.New("openai: no embeddings")
+		}
+
+		return &EmbeddingResult{Values: resp.Data[0].Embedding, Model: strings.TrimSpace(resp.Model)}, nil
+	default:
+		apiResponse := &ApiResponse{}
+
+		if err = json.Unmarshal(body, apiResponse); err != nil {
+			return result, err
+		} else if status >= 300 {
+			log.Debugf("vision: %s (status code %d)", body, status)
+			return result, fmt.Errorf("error %d", status)
+		} else if apiResponse.Result.Embedding.IsEmpty() {
+			return result, errors.New("no embedding")
+		}
+
+		return apiResponse.Result.Embedding, nil
+	}
+}
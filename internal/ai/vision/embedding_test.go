@@ -0,0 +1,228 @@
+package vision
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/ai/tensorflow"
+	"github.com/photoprism/photoprism/internal/ai/vision/ollama"
+	"github.com/photoprism/photoprism/internal/ai/vision/openai"
+	"github.com/photoprism/photoprism/pkg/media"
+)
+
+// withEmbeddingModel temporarily replaces the vision config with a single embedding model.
+func withEmbeddingModel(t *testing.T, model *Model) {
+	prev := Config
+	model.ApplyEngineDefaults()
+	Config = &ConfigValues{Models: Models{model}, Thresholds: DefaultThresholds}
+	t.Cleanup(func() { Config = prev })
+}
+
+func TestEmbeddingResult(t *testing.T) {
+	t.Run("Nil", func(t *testing.T) {
+		var r *EmbeddingResult
+		assert.True(t, r.IsEmpty())
+		assert.Equal(t, 0, r.Dim())
+		assert.Empty(t, r.Vector())
+	})
+	t.Run("Vector", func(t *testing.T) {
+		r := &EmbeddingResult{Values: []float64{3, 4}}
+		assert.False(t, r.IsEmpty())
+		assert.Equal(t, 2, r.Dim())
+		assert.InDelta(t, 0.6, r.Vector()[0], 0.0001)
+		assert.InDelta(t, 0.8, r.Vector()[1], 0.0001)
+		assert.Equal(t, float64(3), r.Values[0])
+	})
+}
+
+func TestSetEmbeddingFunc(t *testing.T) {
+	SetEmbeddingFunc(func(images Files, text string, mediaSrc media.Src) (*EmbeddingResult, *Model, error) {
+		if text == "" {
+			return nil, nil, errors.New("text expected")
+		}
+
+		return &EmbeddingResult{Values: []float64{1, 0}, Model: "test"}, &Model{Type: ModelTypeEmbedding}, nil
+	})
+
+	t.Cleanup(func() { SetEmbeddingFunc(nil) })
+
+	result, model, err := GenerateTextEmbedding("cat")
+
+	assert.NoError(t, err)
+	assert.Equal(t, ModelTypeEmbedding, model.Type)
+	assert.Equal(t, "test", result.Model)
+
+	_, _, err = GenerateTextEmbedding(" ")
+	assert.Error(t, err)
+
+	_, _, err = GenerateEmbedding(nil, media.SrcLocal)
+	assert.Error(t, err)
+}
+
+func TestGenerateTextEmbedding(t *testing.T) {
+	t.Run("Ollama", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req ollama.EmbedRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "nomic-embed-text:latest", req.Model)
+			assert.Equal(t, []string{"sunset at the beach"}, req.Input)
+			assert.NoError(t, json.NewEncoder(w).Encode(ollama.EmbedResponse{
+				Model:      req.Model,
+				Embeddings: [][]float64{{0.1, 0.2, 0.3}},
+			}))
+		}))
+		defer server.Close()
+
+		withEmbeddingModel(t, &Model{
+			Type:    ModelTypeEmbedding,
+			Name:    "nomic-embed-text",
+			Engine:  ollama.EngineName,
+			Service: Service{Uri: server.URL},
+		})
+
+		result, model, err := GenerateTextEmbedding("sunset at the beach")
+
+		assert.NoError(t, err)
+		assert.NotNil(t, model)
+		assert.Equal(t, []float64{0.1, 0.2, 0.3}, result.Values)
+		assert.Equal(t, "nomic-embed-text:latest", result.Model)
+	})
+	t.Run("OpenAI", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req openai.EmbeddingsRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "text-embedding-3-small", req.Model)
+			assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+			assert.NoError(t, json.NewEncoder(w).Encode(openai.EmbeddingsResponse{
+				Model: req.Model,
+				Data:  []openai.EmbeddingData{{Embedding: []float64{0.5, 0.5}}},
+			}))
+		}))
+		defer server.Close()
+
+		withEmbeddingModel(t, &Model{
+			Type:    ModelTypeEmbedding,
+			Name:    "text-embedding-3-small",
+			Engine:  openai.EngineName,
+			Service: Service{Uri: server.URL, Key: "secret"},
+		})
+
+		result, _, err := GenerateTextEmbedding("dog")
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, result.Dim())
+		assert.Equal(t, "text-embedding-3-small", result.Model)
+	})
+	t.Run("OpenAIError", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusUnauthorized)
+			_, _ = w.Write([]byte(`{"error":{"message":"invalid api key"}}`))
+		}))
+		defer server.Close()
+
+		withEmbeddingModel(t, &Model{
+			Type:    ModelTypeEmbedding,
+			Name:    "text-embedding-3-small",
+			Engine:  openai.EngineName,
+			Service: Service{Uri: server.URL},
+		})
+
+		result, _, err := GenerateTextEmbedding("dog")
+
+		assert.EqualError(t, err, "openai: invalid api key")
+		assert.Nil(t, result)
+	})
+	t.Run("Vision", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req ApiRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Equal(t, "mountains", req.Prompt)
+			assert.NoError(t, json.NewEncoder(w).Encode(NewEmbeddingResponse(req.Id, &Model{Name: "clip"}, &EmbeddingResult{Values: []float64{1, 2}})))
+		}))
+		defer server.Close()
+
+		withEmbeddingModel(t, &Model{
+			Type:    ModelTypeEmbedding,
+			Name:    "clip",
+			Service: Service{Uri: server.URL},
+		})
+
+		result, _, err := GenerateTextEmbedding("mountains")
+
+		assert.NoError(t, err)
+		assert.Equal(t, []float64{1, 2}, result.Values)
+		assert.Equal(t, "clip", result.Model)
+	})
+	t.Run("TensorFlow", func(t *testing.T) {
+		withEmbeddingModel(t, &Model{
+			Type:       ModelTypeEmbedding,
+			Name:       "clip",
+			TensorFlow: &tensorflow.ModelInfo{},
+		})
+
+		result, _, err := GenerateTextEmbedding("mountains")
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+	t.Run("NoModel", func(t *testing.T) {
+		prev := Config
+		Config = &ConfigValues{}
+		t.Cleanup(func() { Config = prev })
+
+		result, model, err := GenerateTextEmbedding("mountains")
+
+		assert.EqualError(t, err, "missing embedding model")
+		assert.Nil(t, model)
+		assert.Nil(t, result)
+	})
+}
+
+func TestGenerateEmbedding(t *testing.T) {
+	t.Run("OllamaImage", func(t *testing.T) {
+		withEmbeddingModel(t, &Model{
+			Type:    ModelTypeEmbedding,
+			Name:    "nomic-embed-text",
+			Engine:  ollama.EngineName,
+			Service: Service{Uri: "http://ollama:11434/api/embed"},
+		})
+
+		result, _, err := GenerateEmbedding(Files{examplesPath + "/chameleon_lime.jpg"}, media.SrcLocal)
+
+		assert.EqualError(t, err, "ollama only supports text embeddings")
+		assert.Nil(t, result)
+	})
+	t.Run("VisionImage", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req ApiRequest
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+			assert.Len(t, req.Images, 1)
+			assert.NoError(t, json.NewEncoder(w).Encode(NewEmbeddingResponse(req.Id, &Model{Name: "clip"}, &EmbeddingResult{Values: []float64{0, 1}})))
+		}))
+		defer server.Close()
+
+		withEmbeddingModel(t, &Model{
+			Type:    ModelTypeEmbedding,
+			Name:    "clip",
+			Service: Service{Uri: server.URL, FileScheme: "data"},
+		})
+
+		result, _, err := GenerateEmbedding(Files{examplesPath + "/chameleon_lime.jpg"}, media.SrcLocal)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []float64{0, 1}, result.Values)
+	})
+}
+
+func TestModel_EmbedsImages(t *testing.T) {
+	assert.False(t, (*Model)(nil).EmbedsImages())
+	assert.False(t, (&Model{Type: ModelTypeEmbedding, Engine: ollama.EngineName}).EmbedsImages())
+	assert.False(t, (&Model{Type: ModelTypeEmbedding, Engine: openai.EngineName}).EmbedsImages())
+	assert.True(t, (&Model{Type: ModelTypeEmbedding, TensorFlow: &tensorflow.ModelInfo{}}).EmbedsImages())
+	assert.True(t, (&Model{Type: ModelTypeEmbedding, Service: Service{Uri: "http://vision:5000/api/v1/vision/embedding"}}).EmbedsImages())
+}
@@ -7,6 +7,7 @@ import (
 	"sync"
 
 	"github.com/photoprism/photoprism/internal/ai/classify"
+	"github.com/photoprism/photoprism/internal/ai/clip"
 	"github.com/photoprism/photoprism/internal/ai/face"
 	"github.com/photoprism/photoprism/internal/ai/nsfw"
 	"github.com/photoprism/photoprism/internal/ai/tensorflow"
@@ -53,6 +54,7 @@ type Model struct {
 	classifyModel *classify.Model
 	faceModel     *face.Model
 	nsfwModel     *nsfw.Model
+	clipModel     *clip.Model
 	schemaOnce    sync.Once
 	schema        string
 }
@@ -472,7 +474,9 @@ func (m *Model) ApplyEngineDefaults() {
 	}
 
 	if info, ok := EngineInfoFor(engine); ok {
-		if m.Service.Uri == "" {
+		if m.Service.Uri == "" && m.Type == ModelTypeEmbedding && engine == openai.EngineName {
+			m.Service.Uri = openai.EmbeddingsUri
+		} else if m.Service.Uri == "" {
 			m.Service.Uri = info.Uri
 		}
 
@@ -781,6 +785,71 @@ func (m *Model) NsfwModel() *nsfw.Model {
 	return m.nsfwModel
 }
 
+// ClipModel returns the matching TensorFlow image embedding model instance, if any.
+// Nil receivers return nil.
+func (m *Model) ClipModel() *clip.Model {
+	if m == nil {
+		return nil
+	}
+
+	// Use mutex to prevent models from being loaded and
+	// initialized twice by different indexing workers.
+	modelMutex.Lock()
+	defer modelMutex.Unlock()
+
+	// Return the existing model instance if it has already been created.
+	if m.clipModel != nil {
+		return m.clipModel
+	}
+
+	if m.Name == "" {
+		log.Warnf("vision: missing name, model instance cannot be created")
+		return nil
+	}
+
+	// Set model path from model name if no path is configured.
+	if m.Path == "" {
+		m.Path = clean.Path(clean.TypeLowerUnderscore(m.Name))
+	}
+
+	// Set default thumbnail resolution if no tags are configured.
+	if m.Resolution <= 0 {
+		m.Resolution = clip.DefaultResolution
+	}
+
+	if m.TensorFlow == nil {
+		m.TensorFlow = &tensorflow.ModelInfo{}
+	}
+
+	// Try to load custom model based on the configuration values.
+	if model := clip.NewModel(GetModelPath(m.Path), m.Resolution, m.TensorFlow, m.Disabled); model == nil {
+		return nil
+	} else if err := model.Init(); err != nil {
+		log.Errorf("vision: %s (init %s)", err, m.Path)
+		return nil
+	} else {
+		m.clipModel = model
+	}
+
+	return m.clipModel
+}
+
+// EmbedsImages reports whether the model computes embeddings from image data.
+// The Ollama and OpenAI embedding APIs only accept text, so photo embeddings
+// are generated from descriptive metadata when one of these engines is used.
+func (m *Model) EmbedsImages() bool {
+	if m == nil {
+		return false
+	}
+
+	switch m.EngineName() {
+	case ollama.EngineName, openai.EngineName:
+		return false
+	default:
+		return true
+	}
+}
+
 // Clone returns a shallow copy of the model. Nil receivers return nil.
 func (m *Model) Clone() *Model {
 	if m == nil {
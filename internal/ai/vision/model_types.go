@@ -22,6 +22,8 @@ const (
 	ModelTypeCaption ModelType = "caption"
 	// ModelTypeGenerate produces new content (e.g., text-to-image), when supported.
 	ModelTypeGenerate ModelType = "generate"
+	// ModelTypeEmbedding computes image and text embeddings for semantic search.
+	ModelTypeEmbedding ModelType = "embedding"
 )
 
 // ParseModelTypes parses a model type string.
@@ -36,7 +38,7 @@ func ParseModelTypes(s string) (types ModelTypes) {
 	for _, t := range strings.Split(s, ",") {
 		t = strings.TrimSpace(t)
 		switch t {
-		case ModelTypeLabels, ModelTypeNsfw, ModelTypeFace, ModelTypeCaption, ModelTypeGenerate:
+		case ModelTypeLabels, ModelTypeNsfw, ModelTypeFace, ModelTypeCaption, ModelTypeGenerate, ModelTypeEmbedding:
 			if !slices.Contains(types, t) {
 				types = append(types, t)
 			}
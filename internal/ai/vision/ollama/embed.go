@@ -0,0 +1,15 @@
+package ollama
+
+// EmbedRequest represents an Ollama embed API request, see https://docs.ollama.com/api/embed.
+type EmbedRequest struct {
+	Model    string   `json:"model"`
+	Input    []string `json:"input"`
+	Truncate bool     `json:"truncate,omitempty"`
+}
+
+// EmbedResponse represents an Ollama embed API response.
+type EmbedResponse struct {
+	Model      string      `json:"model,omitempty"`
+	Embeddings [][]float64 `json:"embeddings,omitempty"`
+	Error      string      `json:"error,omitempty"`
+}
@@ -5,4 +5,6 @@ const (
 	EngineName = "openai"
 	// ApiFormat identifies OpenAI-compatible request and response payloads.
 	ApiFormat = "openai"
+	// EmbeddingsUri is the default endpoint for OpenAI embedding models.
+	EmbeddingsUri = "https://api.openai.com/v1/embeddings"
 )
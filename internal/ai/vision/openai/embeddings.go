@@ -0,0 +1,25 @@
+package openai
+
+// EmbeddingsRequest represents an OpenAI embeddings API request, see https://platform.openai.com/docs/api-reference/embeddings.
+type EmbeddingsRequest struct {
+	Model          string   `json:"model"`
+	Input          []string `json:"input"`
+	EncodingFormat string   `json:"encoding_format,omitempty"`
+	Dimensions     int      `json:"dimensions,omitempty"`
+}
+
+// EmbeddingsResponse mirrors the subset of the embeddings API response we need.
+type EmbeddingsResponse struct {
+	Model string          `json:"model"`
+	Data  []EmbeddingData `json:"data"`
+	Error *struct {
+		Message string `json:"message"`
+		Type    string `json:"type"`
+	} `json:"error,omitempty"`
+}
+
+// EmbeddingData contains a single embedding vector.
+type EmbeddingData struct {
+	Index     int       `json:"index"`
+	Embedding []float64 `json:"embedding"`
+}
@@ -139,6 +139,18 @@ func AbortBadRequest(c *gin.Context, errs ...error) {
 	Abort(c, http.StatusBadRequest, i18n.ErrBadRequest)
 }
 
+// AbortBadQuery responds with status 400 and includes the search query syntax error in the details.
+func AbortBadQuery(c *gin.Context, err error) {
+	resp := i18n.NewResponse(http.StatusBadRequest, i18n.ErrBadRequest)
+
+	if err != nil {
+		resp.Details = err.Error()
+		log.Debugf("api: %s in search query", clean.Error(err))
+	}
+
+	c.AbortWithStatusJSON(http.StatusBadRequest, resp)
+}
+
 // AbortFeatureDisabled aborts with a forbidden response when a feature is disabled.
 func AbortFeatureDisabled(c *gin.Context) {
 	Abort(c, http.StatusForbidden, i18n.ErrFeatureDisabled)
@@ -149,6 +161,11 @@ func AbortQuotaExceeded(c *gin.Context) {
 	Abort(c, http.StatusForbidden, i18n.ErrQuotaExceeded)
 }
 
+// AbortStorageQuotaExceeded aborts with HTTP 507 and the usage details when the storage quota of a user would be exceeded.
+func AbortStorageQuotaExceeded(c *gin.Context, err error) {
+	Error(c, http.StatusInsufficientStorage, err, i18n.ErrQuotaExceeded)
+}
+
 // AbortBusy responds with HTTP 429 to signal temporary overload.
 func AbortBusy(c *gin.Context) {
 	Abort(c, http.StatusTooManyRequests, i18n.ErrBusy)
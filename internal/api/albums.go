@@ -117,15 +117,15 @@ func GetAlbum(router *gin.RouterGroup) {
 // CreateAlbum creates a new album.
 //
 //	@Summary		creates a new album
-//	@Description	Posting a title that matches a soft-deleted manual album restores it (including existing photo assignments). Use DELETE with `force=true` to purge an album before recreating it from scratch.
+//	@Description	Posting a title that matches a soft-deleted manual album restores it (including existing photo assignments). Use DELETE with `force=true` to purge an album before recreating it from scratch. Smart albums are created with Type `smart` and a search Filter, e.g. `label:beach year:2024`.
 //	@Id				CreateAlbum
 //	@Tags			Albums
 //	@Accept			json
 //	@Produce		json
 //	@Success		200					{object}	entity.Album
 //	@Success		201					{object}	entity.Album
-//	@Failure		400,401,403,429,500	{object}	i18n.Response
-//	@Param			album				body		form.Album	true	"properties of the album to be created (currently supports Title and Favorite)"
+//	@Failure		400,401,403,409,429,500	{object}	i18n.Response
+//	@Param			album				body		form.Album	true	"properties of the album to be created (currently supports Title, Favorite, Type, Filter, and Order)"
 //	@Router			/api/v1/albums [post]
 func CreateAlbum(router *gin.RouterGroup) {
 	router.POST("/albums", func(c *gin.Context) {
@@ -143,18 +143,41 @@ func CreateAlbum(router *gin.RouterGroup) {
 			return
 		}
 
-		albumMutex.Lock()
-		defer albumMutex.Unlock()
+		var album *entity.Album
 
-		conf := get.Config()
+		// Smart albums select pictures based on a saved search filter.
+		if frm.AlbumType == entity.AlbumSmart {
+			if err := frm.ValidateFilter(); err != nil {
+				AbortBadRequest(c, err)
+				return
+			}
+
+			album = entity.NewSmartAlbum(frm.AlbumTitle, frm.AlbumFilter, frm.AlbumOrder, s.UserUID)
+		} else {
+			album = entity.NewUserAlbum(frm.AlbumTitle, entity.AlbumManual, get.Config().Settings().Albums.Order.Album, s.UserUID)
+		}
 
-		album := entity.NewUserAlbum(frm.AlbumTitle, entity.AlbumManual, conf.Settings().Albums.Order.Album, s.UserUID)
 		album.AlbumFavorite = frm.AlbumFavorite
 
+		albumMutex.Lock()
+		defer albumMutex.Unlock()
+
 		code := http.StatusOK
 
-		// Existing album?
-		if found := album.Find(); found == nil {
+		if album.AlbumType == entity.AlbumSmart {
+			// Smart albums are only matched by filter and owner, as other albums may have the same title.
+			if found := entity.FindSmartAlbum(album.AlbumFilter, s.UserUID); found != nil {
+				Abort(c, http.StatusConflict, i18n.ErrAlreadyExists, clean.Log(found.AlbumTitle))
+				return
+			} else if err := album.Create(); err != nil {
+				// Report unexpected error.
+				log.Errorf("album: %s (create)", err)
+				AbortUnexpectedError(c)
+				return
+			}
+
+			code = http.StatusCreated
+		} else if found := album.Find(); found == nil {
 			// Not found, create new album.
 			if err := album.Create(); err != nil {
 				// Report unexpected error.
@@ -243,6 +266,22 @@ func UpdateAlbum(router *gin.RouterGroup) {
 			return
 		}
 
+		// Only regular albums and smart albums can be converted into each other.
+		if frm.AlbumType != album.AlbumType && !(album.IsDefault() || album.IsSmart()) ||
+			frm.AlbumType != entity.AlbumManual && frm.AlbumType != entity.AlbumSmart {
+			frm.AlbumType = album.AlbumType
+		}
+
+		// Smart albums require a valid search filter, while regular albums must not have one.
+		if frm.AlbumType == entity.AlbumSmart {
+			if err = frm.ValidateFilter(); err != nil {
+				AbortBadRequest(c, err)
+				return
+			}
+		} else if frm.AlbumType == entity.AlbumManual {
+			frm.AlbumFilter = ""
+		}
+
 		albumMutex.Lock()
 		defer albumMutex.Unlock()
 
@@ -616,7 +655,7 @@ func AddPhotosToAlbum(router *gin.RouterGroup) {
 						log.Errorf("approve: %s", err)
 					} else {
 						approved = append(approved, p)
-						SaveSidecarYaml(p)
+						SaveSidecarFiles(p)
 					}
 				}
 
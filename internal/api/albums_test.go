@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/entity/query"
 
 	"github.com/stretchr/testify/assert"
@@ -51,6 +52,33 @@ func TestCreateAlbum(t *testing.T) {
 		r := PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": 333, "Description": "Created via unit test", "Notes": "", "Favorite": true}`)
 		assert.Equal(t, http.StatusBadRequest, r.Code)
 	})
+	t.Run("Smart", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateAlbum(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": "Smart Beach", "Type": "smart", "Filter": "label:beach favorite:true"}`)
+		assert.Equal(t, http.StatusCreated, r.Code)
+		assert.Equal(t, entity.AlbumSmart, gjson.Get(r.Body.String(), "Type").String())
+		assert.Equal(t, "label:beach favorite:true", gjson.Get(r.Body.String(), "Filter").String())
+		assert.Equal(t, entity.DefaultOrderSmart, gjson.Get(r.Body.String(), "Order").String())
+	})
+	t.Run("SmartExistingTitle", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateAlbum(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": "Christmas 2030", "Type": "smart", "Filter": "label:christmas year:2030"}`)
+		assert.Equal(t, http.StatusCreated, r.Code)
+		assert.Equal(t, "label:christmas year:2030", gjson.Get(r.Body.String(), "Filter").String())
+		assert.NotEqual(t, "as6sg6bxpogaaba7", gjson.Get(r.Body.String(), "UID").String())
+		r = PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": "Christmas Again", "Type": "smart", "Filter": "label:christmas year:2030"}`)
+		assert.Equal(t, http.StatusConflict, r.Code)
+	})
+	t.Run("SmartInvalidFilter", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateAlbum(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": "Smart Invalid", "Type": "smart", "Filter": "foo:bar"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		r = PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": "Smart Empty", "Type": "smart", "Filter": ""}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
 }
 func TestUpdateAlbum(t *testing.T) {
 	app, router, _ := NewApiTest()
@@ -71,6 +99,27 @@ func TestUpdateAlbum(t *testing.T) {
 		assert.Equal(t, "false", val2.String())
 		assert.Equal(t, http.StatusOK, r.Code)
 	})
+	t.Run("ConvertToSmart", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateAlbum(router)
+		UpdateAlbum(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums", `{"Title": "Convert To Smart"}`)
+		assert.Equal(t, http.StatusCreated, r.Code)
+		smartUid := gjson.Get(r.Body.String(), "UID").String()
+		r = PerformRequestWithBody(app, "PUT", "/api/v1/albums/"+smartUid, `{"Type": "smart", "Filter": "foo:bar"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		r = PerformRequestWithBody(app, "PUT", "/api/v1/albums/"+smartUid, `{"Type": "smart", "Filter": "country:es year:2024"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, entity.AlbumSmart, gjson.Get(r.Body.String(), "Type").String())
+		assert.Equal(t, "country:es year:2024", gjson.Get(r.Body.String(), "Filter").String())
+		r = PerformRequestWithBody(app, "PUT", "/api/v1/albums/"+smartUid, `{"Type": "moment"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, entity.AlbumSmart, gjson.Get(r.Body.String(), "Type").String())
+		r = PerformRequestWithBody(app, "PUT", "/api/v1/albums/"+smartUid, `{"Type": "album"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, entity.AlbumManual, gjson.Get(r.Body.String(), "Type").String())
+		assert.Equal(t, "", gjson.Get(r.Body.String(), "Filter").String())
+	})
 	t.Run("Invalid", func(t *testing.T) {
 		app, router, _ := NewApiTest()
 		UpdateAlbum(router)
@@ -3,6 +3,8 @@ package api
 import (
 	"github.com/gin-gonic/gin"
 
+	"github.com/photoprism/photoprism/internal/auth/acl"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/entity/search"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/form"
@@ -64,3 +66,12 @@ func PublishSubjectEvent(ev Event, uid string, c *gin.Context) {
 		event.PublishEntities("subjects", string(ev), result)
 	}
 }
+
+// PublishCommentEvent notifies the owner of a photo or album and users with access to all comments after a comment has been changed.
+func PublishCommentEvent(ev Event, m *entity.Comment, ownerUid string) {
+	if m == nil {
+		return
+	}
+
+	event.PublishUserEntities(acl.ChannelComments.String(), ev.String(), []*entity.Comment{m}, ownerUid)
+}
@@ -69,7 +69,7 @@ func BatchPhotosArchive(router *gin.RouterGroup) {
 				if archiveErr := p.Archive(); archiveErr != nil {
 					log.Errorf("archive: %s", archiveErr)
 				} else {
-					SaveSidecarYaml(p)
+					SaveSidecarFiles(p)
 				}
 			}
 		} else if err := entity.Db().Where("photo_uid IN (?)", frm.Photos).Delete(&entity.Photo{}).Error; err != nil {
@@ -140,7 +140,7 @@ func BatchPhotosRestore(router *gin.RouterGroup) {
 				if err = p.Restore(); err != nil {
 					log.Errorf("restore: %s", err)
 				} else {
-					SaveSidecarYaml(p)
+					SaveSidecarFiles(p)
 				}
 			}
 		} else if err := entity.Db().Unscoped().Model(&entity.Photo{}).Where("photo_uid IN (?)", frm.Photos).
@@ -212,7 +212,7 @@ func BatchPhotosApprove(router *gin.RouterGroup) {
 				log.Errorf("approve: %s", err)
 			} else {
 				approved = append(approved, p)
-				SaveSidecarYaml(p)
+				SaveSidecarFiles(p)
 			}
 		}
 
@@ -270,7 +270,7 @@ func BatchPhotosPrivate(router *gin.RouterGroup) {
 		// Fetch selection from index.
 		if photos, err := query.SelectedPhotos(frm); err == nil {
 			for _, p := range photos {
-				SaveSidecarYaml(p)
+				SaveSidecarFiles(p)
 			}
 
 			event.EntitiesUpdated("photos", photos)
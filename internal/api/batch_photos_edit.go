@@ -134,7 +134,7 @@ func BatchPhotosEdit(router *gin.RouterGroup) {
 				}
 
 				// PublishPhotoEvent(StatusUpdated, photo.PhotoUID, c)
-				SaveSidecarYaml(photo)
+				SaveSidecarFiles(photo)
 			}
 
 			if savedAny {
@@ -0,0 +1,405 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/auth/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism/get"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/log/status"
+)
+
+// commentSubject represents a photo or album that can be commented on.
+type commentSubject struct {
+	Resource acl.Resource
+	UID      string
+	Owner    string
+	Shares   []string
+	photo    *entity.Photo
+	album    *entity.Album
+}
+
+// GetPhotoComments returns the comments on a photo, oldest first.
+//
+//	@Summary	returns the comments on a photo, oldest first
+//	@Id			GetPhotoComments
+//	@Tags		Photos
+//	@Produce	json
+//	@Success	200				{object}	entity.Comments
+//	@Failure	401,403,404,429	{object}	i18n.Response
+//	@Param		uid				path		string	true	"Photo UID"
+//	@Router		/api/v1/photos/{uid}/comments [get]
+func GetPhotoComments(router *gin.RouterGroup) {
+	router.GET("/photos/:uid/comments", func(c *gin.Context) {
+		getComments(c, acl.ResourcePhotos)
+	})
+}
+
+// CreatePhotoComment adds a comment to a photo.
+//
+//	@Summary	adds a comment to a photo
+//	@Id			CreatePhotoComment
+//	@Tags		Photos
+//	@Accept		json
+//	@Produce	json
+//	@Success	200						{object}	entity.Comment
+//	@Failure	400,401,403,404,429,500	{object}	i18n.Response
+//	@Param		uid						path		string			true	"Photo UID"
+//	@Param		comment					body		form.Comment	true	"comment text"
+//	@Router		/api/v1/photos/{uid}/comments [post]
+func CreatePhotoComment(router *gin.RouterGroup) {
+	router.POST("/photos/:uid/comments", func(c *gin.Context) {
+		createComment(c, acl.ResourcePhotos)
+	})
+}
+
+// UpdatePhotoComment changes the text of a comment on a photo.
+//
+//	@Summary	changes the text of a comment on a photo
+//	@Id			UpdatePhotoComment
+//	@Tags		Photos
+//	@Accept		json
+//	@Produce	json
+//	@Success	200						{object}	entity.Comment
+//	@Failure	400,401,403,404,429,500	{object}	i18n.Response
+//	@Param		uid						path		string			true	"Photo UID"
+//	@Param		comment					path		string			true	"Comment UID"
+//	@Param		text					body		form.Comment	true	"comment text"
+//	@Router		/api/v1/photos/{uid}/comments/{comment} [put]
+func UpdatePhotoComment(router *gin.RouterGroup) {
+	router.PUT("/photos/:uid/comments/:comment", func(c *gin.Context) {
+		updateComment(c, acl.ResourcePhotos)
+	})
+}
+
+// DeletePhotoComment removes a comment from a photo.
+//
+//	@Summary	removes a comment from a photo
+//	@Id			DeletePhotoComment
+//	@Tags		Photos
+//	@Produce	json
+//	@Success	200					{object}	entity.Comment
+//	@Failure	401,403,404,429,500	{object}	i18n.Response
+//	@Param		uid					path		string	true	"Photo UID"
+//	@Param		comment				path		string	true	"Comment UID"
+//	@Router		/api/v1/photos/{uid}/comments/{comment} [delete]
+func DeletePhotoComment(router *gin.RouterGroup) {
+	router.DELETE("/photos/:uid/comments/:comment", func(c *gin.Context) {
+		deleteComment(c, acl.ResourcePhotos)
+	})
+}
+
+// GetAlbumComments returns the comments on an album, oldest first.
+//
+//	@Summary	returns the comments on an album, oldest first
+//	@Id			GetAlbumComments
+//	@Tags		Albums
+//	@Produce	json
+//	@Success	200				{object}	entity.Comments
+//	@Failure	401,403,404,429	{object}	i18n.Response
+//	@Param		uid				path		string	true	"Album UID"
+//	@Router		/api/v1/albums/{uid}/comments [get]
+func GetAlbumComments(router *gin.RouterGroup) {
+	router.GET("/albums/:uid/comments", func(c *gin.Context) {
+		getComments(c, acl.ResourceAlbums)
+	})
+}
+
+// CreateAlbumComment adds a comment to an album.
+//
+//	@Summary	adds a comment to an album
+//	@Id			CreateAlbumComment
+//	@Tags		Albums
+//	@Accept		json
+//	@Produce	json
+//	@Success	200						{object}	entity.Comment
+//	@Failure	400,401,403,404,429,500	{object}	i18n.Response
+//	@Param		uid						path		string			true	"Album UID"
+//	@Param		comment					body		form.Comment	true	"comment text"
+//	@Router		/api/v1/albums/{uid}/comments [post]
+func CreateAlbumComment(router *gin.RouterGroup) {
+	router.POST("/albums/:uid/comments", func(c *gin.Context) {
+		createComment(c, acl.ResourceAlbums)
+	})
+}
+
+// UpdateAlbumComment changes the text of a comment on an album.
+//
+//	@Summary	changes the text of a comment on an album
+//	@Id			UpdateAlbumComment
+//	@Tags		Albums
+//	@Accept		json
+//	@Produce	json
+//	@Success	200						{object}	entity.Comment
+//	@Failure	400,401,403,404,429,500	{object}	i18n.Response
+//	@Param		uid						path		string			true	"Album UID"
+//	@Param		comment					path		string			true	"Comment UID"
+//	@Param		text					body		form.Comment	true	"comment text"
+//	@Router		/api/v1/albums/{uid}/comments/{comment} [put]
+func UpdateAlbumComment(router *gin.RouterGroup) {
+	router.PUT("/albums/:uid/comments/:comment", func(c *gin.Context) {
+		updateComment(c, acl.ResourceAlbums)
+	})
+}
+
+// DeleteAlbumComment removes a comment from an album.
+//
+//	@Summary	removes a comment from an album
+//	@Id			DeleteAlbumComment
+//	@Tags		Albums
+//	@Produce	json
+//	@Success	200					{object}	entity.Comment
+//	@Failure	401,403,404,429,500	{object}	i18n.Response
+//	@Param		uid					path		string	true	"Album UID"
+//	@Param		comment				path		string	true	"Comment UID"
+//	@Router		/api/v1/albums/{uid}/comments/{comment} [delete]
+func DeleteAlbumComment(router *gin.RouterGroup) {
+	router.DELETE("/albums/:uid/comments/:comment", func(c *gin.Context) {
+		deleteComment(c, acl.ResourceAlbums)
+	})
+}
+
+// getComments handles requests to list the comments on a photo or album.
+func getComments(c *gin.Context, resource acl.Resource) {
+	s := Auth(c, acl.ResourceComments, acl.ActionView)
+
+	if s.Abort(c) {
+		return
+	}
+
+	subject := findCommentSubject(c, resource)
+
+	if subject == nil {
+		return
+	}
+
+	// Visitors and other restricted users can only see comments on shared content.
+	if perm, _ := commentPerm(s, subject); perm == entity.PermDefault {
+		AbortForbidden(c)
+		return
+	}
+
+	c.JSON(http.StatusOK, entity.FindComments(subject.UID))
+}
+
+// createComment handles requests to add a comment to a photo or album.
+func createComment(c *gin.Context, resource acl.Resource) {
+	s := Auth(c, acl.ResourceComments, acl.ActionCreate)
+
+	if s.Abort(c) {
+		return
+	}
+
+	subject := findCommentSubject(c, resource)
+
+	if subject == nil {
+		return
+	}
+
+	// Visitors and other restricted users need the comment permission for shared content.
+	perm, linkUid := commentPerm(s, subject)
+
+	if perm&(entity.PermComment|entity.PermAll) == 0 {
+		event.AuditWarn([]string{ClientIP(c), "session %s", "comment on %s", status.Denied}, s.RefID, clean.Log(subject.UID))
+		AbortForbidden(c)
+		return
+	}
+
+	var frm form.Comment
+
+	// Assign and validate request form values.
+	if err := c.BindJSON(&frm); err != nil {
+		AbortBadRequest(c, err)
+		return
+	}
+
+	m := entity.NewComment(subject.UID, frm.Text).SetAuthor(s.GetUser(), linkUid)
+
+	if err := m.Create(); err != nil {
+		AbortBadRequest(c, err)
+		return
+	}
+
+	event.AuditInfo([]string{ClientIP(c), "session %s", "comment %s on %s", status.Created}, s.RefID, clean.Log(m.CommentUID), clean.Log(subject.UID))
+
+	subject.changed(StatusCreated, m)
+
+	c.JSON(http.StatusOK, m)
+}
+
+// updateComment handles requests to change the text of a comment on a photo or album.
+func updateComment(c *gin.Context, resource acl.Resource) {
+	s := Auth(c, acl.ResourceComments, acl.ActionUpdate)
+
+	if s.Abort(c) {
+		return
+	}
+
+	subject := findCommentSubject(c, resource)
+
+	if subject == nil {
+		return
+	}
+
+	m := findComment(c, s, subject)
+
+	if m == nil {
+		return
+	}
+
+	var frm form.Comment
+
+	// Assign and validate request form values.
+	if err := c.BindJSON(&frm); err != nil {
+		AbortBadRequest(c, err)
+		return
+	}
+
+	if err := m.SetText(frm.Text); err != nil {
+		AbortBadRequest(c, err)
+		return
+	} else if err = m.Save(); err != nil {
+		log.Errorf("comments: %s", clean.Error(err))
+		AbortSaveFailed(c)
+		return
+	}
+
+	subject.changed(StatusUpdated, m)
+
+	c.JSON(http.StatusOK, m)
+}
+
+// deleteComment handles requests to remove a comment from a photo or album.
+func deleteComment(c *gin.Context, resource acl.Resource) {
+	s := Auth(c, acl.ResourceComments, acl.ActionDelete)
+
+	if s.Abort(c) {
+		return
+	}
+
+	subject := findCommentSubject(c, resource)
+
+	if subject == nil {
+		return
+	}
+
+	m := findComment(c, s, subject)
+
+	if m == nil {
+		return
+	}
+
+	if err := m.Delete(); err != nil {
+		log.Errorf("comments: %s", clean.Error(err))
+		AbortDeleteFailed(c)
+		return
+	}
+
+	event.AuditInfo([]string{ClientIP(c), "session %s", "comment %s on %s", status.Deleted}, s.RefID, clean.Log(m.CommentUID), clean.Log(subject.UID))
+
+	subject.changed(StatusDeleted, m)
+
+	c.JSON(http.StatusOK, m)
+}
+
+// findCommentSubject finds the photo or album specified in the request path and aborts with an error if it was not found.
+func findCommentSubject(c *gin.Context, resource acl.Resource) *commentSubject {
+	uid := clean.UID(c.Param("uid"))
+
+	switch resource {
+	case acl.ResourceAlbums:
+		album, err := query.AlbumByUID(uid)
+
+		if err != nil {
+			AbortAlbumNotFound(c)
+			return nil
+		}
+
+		return &commentSubject{Resource: resource, UID: album.AlbumUID, Owner: album.CreatedBy, Shares: []string{album.AlbumUID}, album: &album}
+	case acl.ResourcePhotos:
+		photo, err := query.PhotoByUID(uid)
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return nil
+		}
+
+		// Photos are shared either directly or as part of an album.
+		albums, err := query.PhotoAlbumUIDs(photo.PhotoUID)
+
+		if err != nil {
+			log.Errorf("comments: %s", clean.Error(err))
+		}
+
+		return &commentSubject{Resource: resource, UID: photo.PhotoUID, Owner: photo.CreatedBy, Shares: append([]string{photo.PhotoUID}, albums...), photo: &photo}
+	default:
+		AbortEntityNotFound(c)
+		return nil
+	}
+}
+
+// findComment finds the comment specified in the request path and aborts with an error if it was not found
+// or the session is not allowed to change it.
+func findComment(c *gin.Context, s *entity.Session, subject *commentSubject) *entity.Comment {
+	m := entity.FindComment(clean.UID(c.Param("comment")))
+
+	if m == nil || m.SubjectUID != subject.UID {
+		AbortEntityNotFound(c)
+		return nil
+	}
+
+	// Users who are not allowed to manage comments can only change their own.
+	if acl.Rules.Allow(acl.ResourceComments, s.GetUserRole(), acl.ActionManage) {
+		return m
+	} else if perm, _ := commentPerm(s, subject); perm&(entity.PermComment|entity.PermAll) == 0 || !m.IsAuthor(s.GetUser()) {
+		event.AuditWarn([]string{ClientIP(c), "session %s", "change comment %s", status.Denied}, s.RefID, clean.Log(m.CommentUID))
+		AbortForbidden(c)
+		return nil
+	}
+
+	return m
+}
+
+// commentPerm returns the permissions of the session for the comments on the photo or album, and the UID
+// of the share link visitor comments should be attributed to.
+func commentPerm(s *entity.Session, subject *commentSubject) (perm uint, linkUid string) {
+	// Users with access to the library can comment on all content, others on their own and shared content.
+	if s.IsRegistered() && (!s.GetUser().HasSharedAccessOnly(subject.Resource) || subject.Owner == s.UserUID) {
+		return entity.PermAll, ""
+	}
+
+	for _, uid := range subject.Shares {
+		if !s.HasShare(uid) {
+			continue
+		}
+
+		p, l := s.SharePerm(uid)
+		perm |= p | entity.PermView
+
+		if linkUid == "" {
+			linkUid = l
+		}
+	}
+
+	return perm, linkUid
+}
+
+// changed updates the YAML file of the photo or album and notifies its owner after a comment has been changed.
+func (subject *commentSubject) changed(ev Event, m *entity.Comment) {
+	conf := get.Config()
+
+	switch {
+	case subject.album != nil:
+		SaveAlbumYaml(subject.album)
+	case subject.photo != nil && conf.SidecarYaml():
+		_ = subject.photo.SaveSidecarYaml(conf.OriginalsPath(), conf.SidecarPath())
+	}
+
+	PublishCommentEvent(ev, m, subject.Owner)
+}
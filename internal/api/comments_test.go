@@ -0,0 +1,100 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestGetPhotoComments(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoComments(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/ps6sg6be2lvl0yh8/comments")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "kt2hdkz1vgdb3ta2", gjson.Get(r.Body.String(), "0.UID").String())
+		assert.Equal(t, "What a beautiful sunset!", gjson.Get(r.Body.String(), "0.Text").String())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoComments(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/ps6sg6be2lvl0xxx/comments")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestPhotoComments(t *testing.T) {
+	t.Run("CreateUpdateDelete", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreatePhotoComment(router)
+		UpdatePhotoComment(router)
+		DeletePhotoComment(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/ps6sg6be2lvl0yh8/comments", `{"Text": "Lovely light."}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		uid := gjson.Get(r.Body.String(), "UID").String()
+		assert.Equal(t, "ps6sg6be2lvl0yh8", gjson.Get(r.Body.String(), "SubjectUID").String())
+		assert.Equal(t, "Lovely light.", gjson.Get(r.Body.String(), "Text").String())
+
+		r = PerformRequestWithBody(app, "PUT", "/api/v1/photos/ps6sg6be2lvl0yh8/comments/"+uid, `{"Text": "Lovely light!"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Lovely light!", gjson.Get(r.Body.String(), "Text").String())
+
+		r = PerformRequest(app, "DELETE", "/api/v1/photos/ps6sg6be2lvl0yh8/comments/"+uid)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Nil(t, entity.FindComment(uid))
+	})
+	t.Run("EmptyText", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreatePhotoComment(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/ps6sg6be2lvl0yh8/comments", `{"Text": " "}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("WrongSubject", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		UpdatePhotoComment(router)
+		r := PerformRequestWithBody(app, "PUT", "/api/v1/photos/ps6sg6be2lvl0yh7/comments/kt2hdkz1vgdb3ta2", `{"Text": "Changed"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestAlbumComments(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetAlbumComments(router)
+		r := PerformRequest(app, "GET", "/api/v1/albums/as6sg6bxpogaaba9/comments")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "kt2hdl31ydq9yw0k", gjson.Get(r.Body.String(), "0.UID").String())
+		assert.Equal(t, "ss62xpryd1ob3gtf", gjson.Get(r.Body.String(), "0.LinkUID").String())
+	})
+	t.Run("AlbumNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		CreateAlbumComment(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/as6sg6bxpogaxxxx/comments", `{"Text": "Hello"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("VisitorWithoutCommentPermission", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+		GetAlbumComments(router)
+		CreateAlbumComment(router)
+
+		authToken := entity.SessionFixtures.Pointer("visitor").AuthToken()
+
+		r := AuthenticatedRequest(app, "GET", "/api/v1/albums/as6sg6bxpogaaba8/comments", authToken)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		r = AuthenticatedRequestWithBody(app, "POST", "/api/v1/albums/as6sg6bxpogaaba8/comments", `{"Text": "Hello"}`, authToken)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+
+		r = AuthenticatedRequest(app, "GET", "/api/v1/albums/as6sg6bxpogaaba9/comments", authToken)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
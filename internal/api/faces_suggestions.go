@@ -0,0 +1,151 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/auth/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/i18n"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// SearchFaceSuggestions returns unknown faces that probably belong to a known person.
+//
+//	@Summary	returns suggested face merges waiting for review
+//	@Id			SearchFaceSuggestions
+//	@Tags		Faces
+//	@Produce	json
+//	@Success	200				{object}	entity.FaceSuggestions
+//	@Failure	400,401,403,429	{object}	i18n.Response
+//	@Param		count			query		int		false	"maximum number of results"	minimum(1)	maximum(100000)
+//	@Param		offset			query		int		false	"result offset"				minimum(0)	maximum(100000)
+//	@Param		status			query		string	false	"suggestion status"			Enums(pending, confirmed, rejected)
+//	@Router		/api/v1/faces/suggestions [get]
+func SearchFaceSuggestions(router *gin.RouterGroup) {
+	router.GET("/faces/suggestions", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePeople, acl.ActionSearch)
+
+		// Abort if permission is not granted.
+		if s.Abort(c) {
+			return
+		}
+
+		limit := txt.Int(c.Query("count"))
+		offset := txt.Int(c.Query("offset"))
+		status := clean.TypeLower(c.Query("status"))
+
+		// Show pending suggestions by default.
+		if status == "" {
+			status = entity.SuggestionPending
+		}
+
+		result, err := query.FaceSuggestions(status, limit, offset)
+
+		if err != nil {
+			AbortBadRequest(c, err)
+			return
+		}
+
+		AddCountHeader(c, len(result))
+		AddLimitHeader(c, limit)
+		AddOffsetHeader(c, offset)
+		AddTokenHeaders(c, s)
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// ConfirmFaceSuggestions assigns the suggested people to the faces.
+//
+//	@Summary	confirms suggested face merges and assigns the people to the faces
+//	@Id			ConfirmFaceSuggestions
+//	@Tags		Faces
+//	@Accept		json
+//	@Produce	json
+//	@Success	200					{object}	entity.FaceSuggestions
+//	@Failure	400,401,403,404,429	{object}	i18n.Response
+//	@Param		suggestions			body		form.FaceSuggestions	true	"suggestion ids"
+//	@Router		/api/v1/faces/suggestions/confirm [post]
+func ConfirmFaceSuggestions(router *gin.RouterGroup) {
+	router.POST("/faces/suggestions/confirm", func(c *gin.Context) {
+		reviewFaceSuggestions(c, true)
+	})
+}
+
+// RejectFaceSuggestions rejects suggested face merges so that they are not suggested again.
+//
+//	@Summary	rejects suggested face merges so that they are not suggested again
+//	@Id			RejectFaceSuggestions
+//	@Tags		Faces
+//	@Accept		json
+//	@Produce	json
+//	@Success	200					{object}	entity.FaceSuggestions
+//	@Failure	400,401,403,404,429	{object}	i18n.Response
+//	@Param		suggestions			body		form.FaceSuggestions	true	"suggestion ids"
+//	@Router		/api/v1/faces/suggestions/reject [post]
+func RejectFaceSuggestions(router *gin.RouterGroup) {
+	router.POST("/faces/suggestions/reject", func(c *gin.Context) {
+		reviewFaceSuggestions(c, false)
+	})
+}
+
+// reviewFaceSuggestions confirms or rejects the suggestions specified in the request body.
+func reviewFaceSuggestions(c *gin.Context, confirm bool) {
+	s := Auth(c, acl.ResourcePeople, acl.ActionUpdate)
+
+	// Abort if permission is not granted.
+	if s.Abort(c) {
+		return
+	}
+
+	var frm form.FaceSuggestions
+
+	// Assign and validate request form values.
+	if err := c.BindJSON(&frm); err != nil {
+		AbortBadRequest(c, err)
+		return
+	} else if len(frm.Suggestions) == 0 {
+		Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+		return
+	}
+
+	result := make(entity.FaceSuggestions, 0, len(frm.Suggestions))
+
+	for _, id := range frm.Suggestions {
+		m := entity.FindFaceSuggestion(id)
+
+		if m == nil {
+			continue
+		}
+
+		var err error
+
+		if confirm {
+			err = m.Confirm(s.UserUID)
+		} else {
+			err = m.Reject(s.UserUID)
+		}
+
+		if err != nil {
+			log.Warnf("faces: %s (review suggestion %d)", clean.Error(err), id)
+			continue
+		}
+
+		result = append(result, *m)
+	}
+
+	if len(result) == 0 {
+		AbortEntityNotFound(c)
+		return
+	}
+
+	event.SuccessMsg(i18n.MsgChangesSaved)
+
+	c.JSON(http.StatusOK, result)
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/ai/face"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestSearchFaceSuggestions(t *testing.T) {
+	t.Run("Pending", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchFaceSuggestions(router)
+		r := PerformRequest(app, "GET", "/api/v1/faces/suggestions?count=10")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.GreaterOrEqual(t, gjson.Get(r.Body.String(), "#").Int(), int64(1))
+		assert.Equal(t, entity.SuggestionPending, gjson.Get(r.Body.String(), "0.Status").String())
+	})
+	t.Run("Rejected", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchFaceSuggestions(router)
+		r := PerformRequest(app, "GET", "/api/v1/faces/suggestions?count=10&status=rejected")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Contains(t, r.Body.String(), entity.FaceFixtures.Get("fa-gr").ID)
+	})
+}
+
+func TestConfirmFaceSuggestions(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ConfirmFaceSuggestions(router)
+
+		f := entity.NewFace("", entity.SrcAuto, face.RandomEmbeddings(2, face.RegularFace))
+
+		if err := f.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		subjUid := entity.SubjectFixtures.Get("actor-1").SubjUID
+		m := entity.NewFaceSuggestion(f.ID, subjUid, entity.FaceFixtures.Get("actor-1").ID, 0.5)
+
+		if _, err := m.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/faces/suggestions/confirm", fmt.Sprintf(`{"Suggestions": [%d]}`, m.ID))
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, entity.SuggestionConfirmed, gjson.Get(r.Body.String(), "0.Status").String())
+		assert.Equal(t, subjUid, entity.FindFace(f.ID).SubjUID)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ConfirmFaceSuggestions(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/faces/suggestions/confirm", `{"Suggestions": [123456789]}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ConfirmFaceSuggestions(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/faces/suggestions/confirm", `{"Suggestions": []}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestRejectFaceSuggestions(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		RejectFaceSuggestions(router)
+
+		m := entity.NewFaceSuggestion("REJECTAPISUGGESTIONTESTFACE00001", entity.SubjectFixtures.Get("jane-doe").SubjUID, "", 0.5)
+
+		if _, err := m.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/faces/suggestions/reject", fmt.Sprintf(`{"Suggestions": [%d]}`, m.ID))
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, entity.SuggestionRejected, entity.FindFaceSuggestion(m.ID).Status)
+	})
+	t.Run("InvalidRequest", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		RejectFaceSuggestions(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/faces/suggestions/reject", `{"Suggestions": "xxx"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
@@ -1,6 +1,7 @@
 package api
 
 import (
+	"errors"
 	"net/http"
 	"os"
 	"path"
@@ -37,7 +38,7 @@ const (
 //	@Accept		json
 //	@Produce	json
 //	@Success	200			{object}	i18n.Response
-//	@Failure	400,401,403	{object}	i18n.Response
+//	@Failure	400,401,403,507	{object}	i18n.Response
 //	@Param		options		body		form.ImportOptions	true	"import options"
 //	@Router		/api/v1/import/ [post]
 func StartImport(router *gin.RouterGroup) {
@@ -63,6 +64,15 @@ func StartImport(router *gin.RouterGroup) {
 			return
 		}
 
+		// Abort if the storage quota of the user has already been reached.
+		if quotaErr := query.CheckUserQuota(s.GetUser(), 1, 1); errors.Is(quotaErr, entity.ErrQuotaExceeded) {
+			event.AuditErr([]string{ClientIP(c), "session %s", "import files", clean.Error(quotaErr)}, s.RefID)
+			AbortStorageQuotaExceeded(c, quotaErr)
+			return
+		} else if quotaErr != nil {
+			log.Warnf("import: %s (check quota)", quotaErr)
+		}
+
 		start := time.Now()
 
 		var frm form.ImportOptions
@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/pkg/i18n"
 )
 
@@ -49,4 +50,46 @@ func TestStartImport(t *testing.T) {
 		assert.Equal(t, http.StatusInsufficientStorage, r.Code)
 		config.Options().FilesQuota = 0
 	})
+	t.Run("UserQuotaExceeded", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		StartImport(router)
+		authToken := AuthenticateAdmin(app, router)
+
+		photo := entity.NewUserPhoto(false, entity.Admin.UserUID)
+
+		if err := photo.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		file := entity.File{
+			PhotoID:     photo.ID,
+			PhotoUID:    photo.PhotoUID,
+			FileName:    "import-quota-test.jpg",
+			FileRoot:    entity.RootOriginals,
+			FileSize:    1024,
+			FileHash:    "import-quota-test-hash",
+			FileType:    "jpg",
+			FilePrimary: true,
+		}
+
+		if err := file.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		quota, err := entity.SetQuota(entity.QuotaUser, entity.Admin.UserUID, 1024, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer func() {
+			_ = quota.Delete()
+			entity.UnscopedDb().Delete(&file)
+			entity.UnscopedDb().Delete(&photo)
+		}()
+
+		r := AuthenticatedRequestWithBody(app, "POST", "/api/v1/import/test", "{}", authToken)
+
+		assert.Equal(t, http.StatusInsufficientStorage, r.Code)
+	})
 }
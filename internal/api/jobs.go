@@ -0,0 +1,410 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/auth/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism/get"
+	"github.com/photoprism/photoprism/internal/workers"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/http/header"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// SearchJobs finds scheduled jobs and returns them as JSON.
+//
+//	@Summary	finds scheduled jobs and returns them as JSON
+//	@Id			SearchJobs
+//	@Tags		Jobs
+//	@Produce	json
+//	@Success	200				{object}	entity.Jobs
+//	@Failure	400,401,403,429	{object}	i18n.Response
+//	@Param		count			query		int		false	"maximum number of results"	minimum(1)	maximum(100000)
+//	@Param		offset			query		int		false	"search result offset"		minimum(0)	maximum(100000)
+//	@Param		q				query		string	false	"search query"
+//	@Router		/api/v1/jobs [get]
+func SearchJobs(router *gin.RouterGroup) {
+	router.GET("/jobs", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceJobs, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+
+		if conf.Demo() || conf.DisableSettings() {
+			AbortForbidden(c)
+			return
+		}
+
+		limit := txt.Int(c.Query("count"))
+		offset := txt.Int(c.Query("offset"))
+
+		result, err := query.Jobs(limit, offset, c.Query("q"))
+
+		if err != nil {
+			AbortBadRequest(c, err)
+			return
+		}
+
+		AddCountHeader(c, len(result))
+		AddLimitHeader(c, limit)
+		AddOffsetHeader(c, offset)
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// GetJob returns the specified job as JSON.
+//
+//	@Summary	returns the specified job as JSON
+//	@Id			GetJob
+//	@Tags		Jobs
+//	@Produce	json
+//	@Success	200				{object}	entity.Job
+//	@Failure	401,403,404,429	{object}	i18n.Response
+//	@Param		uid				path		string	true	"job uid"
+//	@Router		/api/v1/jobs/{uid} [get]
+func GetJob(router *gin.RouterGroup) {
+	router.GET("/jobs/:uid", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceJobs, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+
+		if conf.Demo() || conf.DisableSettings() {
+			AbortForbidden(c)
+			return
+		}
+
+		m := entity.FindJob(clean.UID(c.Param("uid")))
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// AddJob creates a new job that runs the specified actions, either manually or on a schedule.
+//
+//	@Summary	creates a new job that runs the specified actions, either manually or on a schedule
+//	@Id			AddJob
+//	@Tags		Jobs
+//	@Accept		json
+//	@Produce	json
+//	@Success	201				{object}	entity.Job
+//	@Failure	400,401,403,429	{object}	i18n.Response
+//	@Param		job				body		form.Job	true	"properties of the job to be created"
+//	@Router		/api/v1/jobs [post]
+func AddJob(router *gin.RouterGroup) {
+	router.POST("/jobs", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceJobs, acl.ActionCreate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+
+		if conf.Demo() || conf.DisableSettings() {
+			AbortForbidden(c)
+			return
+		}
+
+		var frm form.Job
+
+		// Assign and validate request form values.
+		if err := c.BindJSON(&frm); err != nil {
+			AbortBadRequest(c, err)
+			return
+		}
+
+		m, err := entity.AddJob(frm)
+
+		if err != nil {
+			AbortBadRequest(c, err)
+			return
+		}
+
+		log.Infof("jobs: added %s", clean.Log(m.JobUID))
+
+		workers.ScheduleJobs(conf)
+
+		// Return new job with location header.
+		header.SetLocation(c, c.FullPath(), m.JobUID)
+		c.JSON(http.StatusCreated, m)
+	})
+}
+
+// UpdateJob updates the settings of a job.
+//
+//	@Summary	updates the settings of a job
+//	@Id			UpdateJob
+//	@Tags		Jobs
+//	@Accept		json
+//	@Produce	json
+//	@Success	200					{object}	entity.Job
+//	@Failure	400,401,403,404,429	{object}	i18n.Response
+//	@Param		uid					path		string		true	"job uid"
+//	@Param		job					body		form.Job	true	"properties to be updated (only submit values that should be changed)"
+//	@Router		/api/v1/jobs/{uid} [put]
+func UpdateJob(router *gin.RouterGroup) {
+	router.PUT("/jobs/:uid", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceJobs, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+
+		if conf.Demo() || conf.DisableSettings() {
+			AbortForbidden(c)
+			return
+		}
+
+		m := entity.FindJob(clean.UID(c.Param("uid")))
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		// 1) Init form with model values
+		frm, err := form.NewJob(m)
+
+		if err != nil {
+			log.Error(err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		// 2) Update form with values from request
+		if err = c.BindJSON(&frm); err != nil {
+			AbortBadRequest(c, err)
+			return
+		}
+
+		// 3) Save model with values from form
+		if err = m.SaveForm(frm); err != nil {
+			AbortBadRequest(c, err)
+			return
+		}
+
+		workers.ScheduleJobs(conf)
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// DeleteJob removes a job, its run history is kept.
+//
+//	@Summary	removes a job
+//	@Id			DeleteJob
+//	@Tags		Jobs
+//	@Produce	json
+//	@Success	200					{object}	entity.Job
+//	@Failure	401,403,404,429,500	{object}	i18n.Response
+//	@Param		uid					path		string	true	"job uid"
+//	@Router		/api/v1/jobs/{uid} [delete]
+func DeleteJob(router *gin.RouterGroup) {
+	router.DELETE("/jobs/:uid", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceJobs, acl.ActionDelete)
+
+		if s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+
+		if conf.Demo() || conf.DisableSettings() {
+			AbortForbidden(c)
+			return
+		}
+
+		m := entity.FindJob(clean.UID(c.Param("uid")))
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		if err := m.Delete(); err != nil {
+			log.Errorf("jobs: %s", clean.Error(err))
+			AbortDeleteFailed(c)
+			return
+		}
+
+		workers.ScheduleJobs(conf)
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// RunJob triggers a run of the specified job in the background.
+//
+//	@Summary	triggers a run of the specified job in the background
+//	@Id			RunJob
+//	@Tags		Jobs
+//	@Produce	json
+//	@Success	202				{object}	entity.Job
+//	@Failure	401,403,404,429	{object}	i18n.Response
+//	@Param		uid				path		string	true	"job uid"
+//	@Router		/api/v1/jobs/{uid}/run [post]
+func RunJob(router *gin.RouterGroup) {
+	router.POST("/jobs/:uid/run", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceJobs, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+
+		if conf.Demo() || conf.DisableSettings() {
+			AbortForbidden(c)
+			return
+		}
+
+		m := entity.FindJob(clean.UID(c.Param("uid")))
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		workers.RunJob(conf, m)
+
+		c.JSON(http.StatusAccepted, m)
+	})
+}
+
+// PauseJob stops a job from running on schedule until it is resumed.
+//
+//	@Summary	stops a job from running on schedule until it is resumed
+//	@Id			PauseJob
+//	@Tags		Jobs
+//	@Produce	json
+//	@Success	200					{object}	entity.Job
+//	@Failure	401,403,404,429,500	{object}	i18n.Response
+//	@Param		uid					path		string	true	"job uid"
+//	@Router		/api/v1/jobs/{uid}/pause [post]
+func PauseJob(router *gin.RouterGroup) {
+	router.POST("/jobs/:uid/pause", func(c *gin.Context) {
+		setJobPaused(c, true)
+	})
+}
+
+// ResumeJob lets a paused job run on schedule again.
+//
+//	@Summary	lets a paused job run on schedule again
+//	@Id			ResumeJob
+//	@Tags		Jobs
+//	@Produce	json
+//	@Success	200					{object}	entity.Job
+//	@Failure	401,403,404,429,500	{object}	i18n.Response
+//	@Param		uid					path		string	true	"job uid"
+//	@Router		/api/v1/jobs/{uid}/resume [post]
+func ResumeJob(router *gin.RouterGroup) {
+	router.POST("/jobs/:uid/resume", func(c *gin.Context) {
+		setJobPaused(c, false)
+	})
+}
+
+// setJobPaused pauses or resumes the job specified in the request.
+func setJobPaused(c *gin.Context, paused bool) {
+	s := Auth(c, acl.ResourceJobs, acl.ActionUpdate)
+
+	if s.Abort(c) {
+		return
+	}
+
+	conf := get.Config()
+
+	if conf.Demo() || conf.DisableSettings() {
+		AbortForbidden(c)
+		return
+	}
+
+	m := entity.FindJob(clean.UID(c.Param("uid")))
+
+	if m == nil {
+		AbortEntityNotFound(c)
+		return
+	}
+
+	if err := m.SetPaused(paused); err != nil {
+		log.Errorf("jobs: %s", clean.Error(err))
+		AbortSaveFailed(c)
+		return
+	}
+
+	workers.ScheduleJobs(conf)
+
+	c.JSON(http.StatusOK, m)
+}
+
+// GetJobRuns returns the run history of a job as JSON, newest first.
+//
+//	@Summary	returns the run history of a job as JSON
+//	@Id			GetJobRuns
+//	@Tags		Jobs
+//	@Produce	json
+//	@Success	200					{object}	entity.JobRuns
+//	@Failure	400,401,403,404,429	{object}	i18n.Response
+//	@Param		uid					path		string	true	"job uid"
+//	@Param		status				query		string	false	"run status"	Enums(running, succeeded, failed)
+//	@Param		count				query		int		false	"maximum number of results"	minimum(1)	maximum(100000)
+//	@Param		offset				query		int		false	"search result offset"		minimum(0)	maximum(100000)
+//	@Router		/api/v1/jobs/{uid}/runs [get]
+func GetJobRuns(router *gin.RouterGroup) {
+	router.GET("/jobs/:uid/runs", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceJobs, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+
+		if conf.Demo() || conf.DisableSettings() {
+			AbortForbidden(c)
+			return
+		}
+
+		m := entity.FindJob(clean.UID(c.Param("uid")))
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		limit := txt.Int(c.Query("count"))
+		offset := txt.Int(c.Query("offset"))
+
+		result, err := query.JobRuns(m.JobUID, clean.TypeLowerUnderscore(c.Query("status")), limit, offset)
+
+		if err != nil {
+			AbortBadRequest(c, err)
+			return
+		}
+
+		AddCountHeader(c, len(result))
+		AddLimitHeader(c, limit)
+		AddOffsetHeader(c, offset)
+
+		c.JSON(http.StatusOK, result)
+	})
+}
@@ -0,0 +1,111 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestSearchJobs(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchJobs(router)
+		r := PerformRequest(app, "GET", "/api/v1/jobs?count=10&q=Nightly")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "rt2hdkz1vgdb3ta2", gjson.Get(r.Body.String(), "0.UID").String())
+		assert.Equal(t, "import,faces", gjson.Get(r.Body.String(), "0.Actions").String())
+	})
+}
+
+func TestGetJob(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetJob(router)
+		r := PerformRequest(app, "GET", "/api/v1/jobs/rt2hdl31ydq9yw0k")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Weekly Cleanup", gjson.Get(r.Body.String(), "Name").String())
+		assert.True(t, gjson.Get(r.Body.String(), "Paused").Bool())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetJob(router)
+		r := PerformRequest(app, "GET", "/api/v1/jobs/rt2hdl31ydq9yxxx")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestAddJob(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AddJob(router)
+		UpdateJob(router)
+		PauseJob(router)
+		ResumeJob(router)
+		DeleteJob(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/jobs", `{"Name": "API Test", "Actions": "import, faces", "Schedule": "0 2 * * *"}`)
+		assert.Equal(t, http.StatusCreated, r.Code)
+
+		uid := gjson.Get(r.Body.String(), "UID").String()
+
+		assert.Equal(t, "import,faces", gjson.Get(r.Body.String(), "Actions").String())
+		assert.Equal(t, "/api/v1/jobs/"+uid, r.Header().Get("Location"))
+
+		r = PerformRequestWithBody(app, "PUT", "/api/v1/jobs/"+uid, `{"Actions": "index", "Path": "2024"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "index", gjson.Get(r.Body.String(), "Actions").String())
+		assert.Equal(t, "2024", gjson.Get(r.Body.String(), "Path").String())
+		assert.Equal(t, "API Test", gjson.Get(r.Body.String(), "Name").String())
+
+		r = PerformRequestWithBody(app, "PUT", "/api/v1/jobs/"+uid, `{"Schedule": "at noon"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+
+		r = PerformRequest(app, "POST", "/api/v1/jobs/"+uid+"/pause")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.True(t, gjson.Get(r.Body.String(), "Paused").Bool())
+
+		r = PerformRequest(app, "POST", "/api/v1/jobs/"+uid+"/resume")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.False(t, gjson.Get(r.Body.String(), "Paused").Bool())
+
+		r = PerformRequest(app, "DELETE", "/api/v1/jobs/"+uid)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Nil(t, entity.FindJob(uid))
+	})
+	t.Run("BadRequest", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AddJob(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/jobs", `{"Actions": "reboot"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestRunJob(t *testing.T) {
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		RunJob(router)
+		r := PerformRequest(app, "POST", "/api/v1/jobs/rt2hdl31ydq9yxxx/run")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestGetJobRuns(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetJobRuns(router)
+		r := PerformRequest(app, "GET", "/api/v1/jobs/rt2hdl31ydq9yw0k/runs?status=failed")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "index is busy", gjson.Get(r.Body.String(), "0.Error").String())
+		assert.Equal(t, "manual", gjson.Get(r.Body.String(), "0.Trigger").String())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetJobRuns(router)
+		r := PerformRequest(app, "GET", "/api/v1/jobs/rt2hdl31ydq9yxxx/runs")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -38,6 +38,10 @@ func UpdateLink(c *gin.Context) {
 	link.SetSlug(frm.ShareSlug)
 	link.MaxViews = frm.MaxViews
 	link.LinkExpires = frm.LinkExpires
+	link.SetCanComment(frm.CanComment)
+	link.SetCanUpload(frm.CanUpload)
+	link.SetUploadLimits(frm.MaxUploads, frm.MaxUploadSize)
+	link.ModerateUploads = frm.ModerateUploads
 
 	if frm.LinkToken != "" {
 		link.LinkToken = strings.TrimSpace(strings.ToLower(frm.LinkToken))
@@ -117,6 +121,10 @@ func CreateLink(c *gin.Context) {
 	link.SetSlug(frm.ShareSlug)
 	link.MaxViews = frm.MaxViews
 	link.LinkExpires = frm.LinkExpires
+	link.SetCanComment(frm.CanComment)
+	link.SetCanUpload(frm.CanUpload)
+	link.SetUploadLimits(frm.MaxUploads, frm.MaxUploadSize)
+	link.ModerateUploads = frm.ModerateUploads
 
 	if frm.Password != "" {
 		if err := link.SetPassword(frm.Password); err != nil {
@@ -14,6 +14,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/auth/acl"
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/entity/query"
 	"github.com/photoprism/photoprism/internal/photoprism/get"
 	reg "github.com/photoprism/photoprism/internal/service/cluster/registry"
@@ -53,6 +54,7 @@ func GetMetrics(router *gin.RouterGroup) {
 			registerCountMetrics(factory, counts)
 			registerBuildInfoMetric(factory, conf.ClientPublic())
 			registerUsageMetrics(factory, usage)
+			registerUserUsageMetrics(factory)
 			registerClusterMetrics(factory, conf)
 
 			var metrics []*dto.MetricFamily
@@ -188,6 +190,53 @@ func registerUsageMetrics(factory promauto.Factory, usage config.Usage) {
 	accountsPercent.With(prometheus.Labels{"state": "free"}).Set(float64(usage.UsersFreePct))
 }
 
+// registerUserUsageMetrics registers storage usage and quota metrics for each user account that owns original files.
+func registerUserUsageMetrics(factory promauto.Factory) {
+	usage, err := query.UsersStorageUsage()
+
+	if err != nil {
+		logErr("metrics", err)
+		return
+	}
+
+	userBytes := factory.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "photoprism",
+			Subsystem: "usage",
+			Name:      "user_bytes",
+			Help:      "storage usage and quota in bytes for original files owned by each user account",
+		}, []string{"user", "state"},
+	)
+
+	userFiles := factory.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "photoprism",
+			Subsystem: "usage",
+			Name:      "user_files",
+			Help:      "number of original files owned by each user account and the file quota",
+		}, []string{"user", "state"},
+	)
+
+	for _, u := range usage {
+		userBytes.With(prometheus.Labels{"user": u.UserName, "state": "used"}).Set(float64(u.Size))
+		userFiles.With(prometheus.Labels{"user": u.UserName, "state": "used"}).Set(float64(u.Files))
+
+		quota := entity.UserQuota(entity.FindUserByUID(u.UserUID))
+
+		if quota.Unlimited() {
+			continue
+		}
+
+		if quota.MaxSize > 0 {
+			userBytes.With(prometheus.Labels{"user": u.UserName, "state": "quota"}).Set(float64(quota.MaxSize))
+		}
+
+		if quota.MaxFiles > 0 {
+			userFiles.With(prometheus.Labels{"user": u.UserName, "state": "quota"}).Set(float64(quota.MaxFiles))
+		}
+	}
+}
+
 // registerClusterMetrics exports cluster-specific metrics when running as a portal instance.
 func registerClusterMetrics(factory promauto.Factory, conf *config.Config) {
 	if !conf.Portal() {
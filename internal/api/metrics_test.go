@@ -7,6 +7,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/service/cluster"
 	reg "github.com/photoprism/photoprism/internal/service/cluster/registry"
 	"github.com/photoprism/photoprism/pkg/http/header"
@@ -92,6 +93,58 @@ func TestGetMetrics(t *testing.T) {
 		assert.Regexp(t, regexp.MustCompile(`photoprism_usage_accounts_percent{state="used"} `+floatPattern), body)
 		assert.Regexp(t, regexp.MustCompile(`photoprism_usage_accounts_percent{state="free"} `+floatPattern), body)
 	})
+	t.Run("ExposeUserUsageMetrics", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+
+		GetMetrics(router)
+
+		user := entity.UserFixtures.Pointer("friend")
+		photo := entity.NewUserPhoto(false, user.UserUID)
+
+		if err := photo.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		file := entity.File{
+			PhotoID:     photo.ID,
+			PhotoUID:    photo.PhotoUID,
+			FileName:    "metrics-quota-test.jpg",
+			FileRoot:    entity.RootOriginals,
+			FileSize:    2048,
+			FileHash:    "metrics-quota-test-hash",
+			FileType:    "jpg",
+			FilePrimary: true,
+		}
+
+		if err := file.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		quota, err := entity.SetQuota(entity.QuotaUser, user.UserUID, 4096, 10)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer func() {
+			_ = quota.Delete()
+			entity.UnscopedDb().Delete(&file)
+			entity.UnscopedDb().Delete(&photo)
+		}()
+
+		resp := PerformRequestWithStream(app, "GET", "/api/v1/metrics")
+
+		if resp.Code != http.StatusOK {
+			t.Fatal(resp.Body.String())
+		}
+
+		body := resp.Body.String()
+
+		assert.Contains(t, body, `photoprism_usage_user_bytes{state="used",user="friend"} 2048`)
+		assert.Contains(t, body, `photoprism_usage_user_bytes{state="quota",user="friend"} 4096`)
+		assert.Contains(t, body, `photoprism_usage_user_files{state="used",user="friend"} 1`)
+		assert.Contains(t, body, `photoprism_usage_user_files{state="quota",user="friend"} 10`)
+	})
 	t.Run("ExposeClusterMetricsForPortal", func(t *testing.T) {
 		app, router, conf := NewApiTest()
 		conf.Options().NodeRole = cluster.RolePortal
@@ -0,0 +1,319 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/auth/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/log/status"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// GetAlbumPermissions returns the users and teams an album has been shared with.
+//
+//	@Summary	returns the users and teams an album has been shared with
+//	@Id			GetAlbumPermissions
+//	@Tags		Albums
+//	@Produce	json
+//	@Success	200				{object}	entity.AlbumUsers
+//	@Failure	401,403,404,429	{object}	i18n.Response
+//	@Param		uid				path		string	true	"Album UID"
+//	@Router		/api/v1/albums/{uid}/permissions [get]
+func GetAlbumPermissions(router *gin.RouterGroup) {
+	router.GET("/albums/:uid/permissions", func(c *gin.Context) {
+		s, uid, _ := authGrant(c, acl.ResourceAlbums)
+
+		if s == nil {
+			return
+		}
+
+		c.JSON(http.StatusOK, entity.FindAlbumUsers(uid))
+	})
+}
+
+// GrantAlbumPermissions shares an album with a registered user or team.
+//
+//	@Summary	shares an album with a registered user or team
+//	@Id			GrantAlbumPermissions
+//	@Tags		Albums
+//	@Accept		json
+//	@Produce	json
+//	@Success	200						{object}	entity.AlbumUser
+//	@Failure	400,401,403,404,429,500	{object}	i18n.Response
+//	@Param		uid						path		string		true	"Album UID"
+//	@Param		grant					body		form.Grant	true	"user or team UID and permissions"
+//	@Router		/api/v1/albums/{uid}/permissions [post]
+func GrantAlbumPermissions(router *gin.RouterGroup) {
+	router.POST("/albums/:uid/permissions", func(c *gin.Context) {
+		s, uid, allowed := authGrant(c, acl.ResourceAlbums)
+
+		if s == nil {
+			return
+		}
+
+		principal, perm := bindGrant(c, allowed)
+
+		if principal == "" {
+			return
+		}
+
+		m, err := entity.ShareAlbum(uid, principal, perm)
+
+		if err != nil {
+			log.Errorf("album: %s", clean.Error(err))
+			AbortSaveFailed(c)
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "share %s with %s", status.Succeeded}, s.RefID, clean.Log(uid), clean.Log(principal))
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// RevokeAlbumPermissions stops sharing an album with a user or team.
+//
+//	@Summary	stops sharing an album with a user or team
+//	@Id			RevokeAlbumPermissions
+//	@Tags		Albums
+//	@Produce	json
+//	@Success	200					{object}	entity.AlbumUsers
+//	@Failure	401,403,404,429,500	{object}	i18n.Response
+//	@Param		uid					path		string	true	"Album UID"
+//	@Param		principal			path		string	true	"User or Team UID"
+//	@Router		/api/v1/albums/{uid}/permissions/{principal} [delete]
+func RevokeAlbumPermissions(router *gin.RouterGroup) {
+	router.DELETE("/albums/:uid/permissions/:principal", func(c *gin.Context) {
+		s, uid, allowed := authGrant(c, acl.ResourceAlbums)
+
+		if s == nil {
+			return
+		}
+
+		m := entity.NewAlbumUser(uid, clean.UID(c.Param("principal")), "", entity.PermDefault)
+
+		// Users cannot revoke more permissions than they have themselves.
+		for _, grant := range entity.FindAlbumUsers(uid) {
+			if grant.UserUID == m.UserUID && !canRevoke(grant.Perm, allowed) {
+				AbortForbidden(c)
+				return
+			}
+		}
+
+		if err := m.Delete(); err != nil {
+			log.Errorf("album: %s", clean.Error(err))
+			AbortDeleteFailed(c)
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "stop sharing %s with %s", status.Succeeded}, s.RefID, clean.Log(uid), clean.Log(m.UserUID))
+
+		c.JSON(http.StatusOK, entity.FindAlbumUsers(uid))
+	})
+}
+
+// GetPhotoPermissions returns the users and teams a photo has been shared with.
+//
+//	@Summary	returns the users and teams a photo has been shared with
+//	@Id			GetPhotoPermissions
+//	@Tags		Photos
+//	@Produce	json
+//	@Success	200				{object}	entity.PhotoUsers
+//	@Failure	401,403,404,429	{object}	i18n.Response
+//	@Param		uid				path		string	true	"Photo UID"
+//	@Router		/api/v1/photos/{uid}/permissions [get]
+func GetPhotoPermissions(router *gin.RouterGroup) {
+	router.GET("/photos/:uid/permissions", func(c *gin.Context) {
+		s, uid, _ := authGrant(c, acl.ResourcePhotos)
+
+		if s == nil {
+			return
+		}
+
+		c.JSON(http.StatusOK, entity.FindPhotoUsers(uid))
+	})
+}
+
+// GrantPhotoPermissions shares a photo with a registered user or team.
+//
+//	@Summary	shares a photo with a registered user or team
+//	@Id			GrantPhotoPermissions
+//	@Tags		Photos
+//	@Accept		json
+//	@Produce	json
+//	@Success	200						{object}	entity.PhotoUser
+//	@Failure	400,401,403,404,429,500	{object}	i18n.Response
+//	@Param		uid						path		string		true	"Photo UID"
+//	@Param		grant					body		form.Grant	true	"user or team UID and permissions"
+//	@Router		/api/v1/photos/{uid}/permissions [post]
+func GrantPhotoPermissions(router *gin.RouterGroup) {
+	router.POST("/photos/:uid/permissions", func(c *gin.Context) {
+		s, uid, allowed := authGrant(c, acl.ResourcePhotos)
+
+		if s == nil {
+			return
+		}
+
+		principal, perm := bindGrant(c, allowed)
+
+		if principal == "" {
+			return
+		}
+
+		m, err := entity.SharePhoto(uid, principal, perm)
+
+		if err != nil {
+			log.Errorf("photo: %s", clean.Error(err))
+			AbortSaveFailed(c)
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "share %s with %s", status.Succeeded}, s.RefID, clean.Log(uid), clean.Log(principal))
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// RevokePhotoPermissions stops sharing a photo with a user or team.
+//
+//	@Summary	stops sharing a photo with a user or team
+//	@Id			RevokePhotoPermissions
+//	@Tags		Photos
+//	@Produce	json
+//	@Success	200					{object}	entity.PhotoUsers
+//	@Failure	401,403,404,429,500	{object}	i18n.Response
+//	@Param		uid					path		string	true	"Photo UID"
+//	@Param		principal			path		string	true	"User or Team UID"
+//	@Router		/api/v1/photos/{uid}/permissions/{principal} [delete]
+func RevokePhotoPermissions(router *gin.RouterGroup) {
+	router.DELETE("/photos/:uid/permissions/:principal", func(c *gin.Context) {
+		s, uid, allowed := authGrant(c, acl.ResourcePhotos)
+
+		if s == nil {
+			return
+		}
+
+		m := entity.NewPhotoUser(uid, clean.UID(c.Param("principal")), "", entity.PermDefault)
+
+		// Users cannot revoke more permissions than they have themselves.
+		for _, grant := range entity.FindPhotoUsers(uid) {
+			if grant.UserUID == m.UserUID && !canRevoke(grant.Perm, allowed) {
+				AbortForbidden(c)
+				return
+			}
+		}
+
+		if err := m.Delete(); err != nil {
+			log.Errorf("photo: %s", clean.Error(err))
+			AbortDeleteFailed(c)
+			return
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "stop sharing %s with %s", status.Succeeded}, s.RefID, clean.Log(uid), clean.Log(m.UserUID))
+
+		c.JSON(http.StatusOK, entity.FindPhotoUsers(uid))
+	})
+}
+
+// authGrant finds the photo or album specified in the request path and returns the session, the UID, and the
+// permissions the session may grant if it is allowed to share it with other users and teams, or aborts with an
+// error otherwise.
+func authGrant(c *gin.Context, resource acl.Resource) (*entity.Session, string, uint) {
+	s := Auth(c, resource, acl.ActionView)
+
+	if s.Abort(c) {
+		return nil, "", entity.PermDefault
+	}
+
+	var uid, owner string
+
+	switch resource {
+	case acl.ResourceAlbums:
+		album, err := query.AlbumByUID(clean.UID(c.Param("uid")))
+
+		if err != nil {
+			AbortAlbumNotFound(c)
+			return nil, "", entity.PermDefault
+		}
+
+		uid, owner = album.AlbumUID, album.CreatedBy
+	default:
+		photo, err := query.PhotoByUID(clean.UID(c.Param("uid")))
+
+		if err != nil {
+			AbortEntityNotFound(c)
+			return nil, "", entity.PermDefault
+		}
+
+		uid, owner = photo.PhotoUID, photo.CreatedBy
+	}
+
+	// Users who are not allowed to share all content can only share their own
+	// and content that was shared with them with the share permission.
+	if acl.Rules.Allow(resource, s.GetUserRole(), acl.ActionShare) || s.IsRegistered() && owner == s.UserUID {
+		return s, uid, entity.PermAll
+	} else if perm := s.GetUser().ItemPerm(uid); perm&entity.PermAll != 0 {
+		return s, uid, entity.PermAll
+	} else if perm&entity.PermShare != 0 {
+		return s, uid, perm
+	}
+
+	event.AuditWarn([]string{ClientIP(c), "session %s", "share %s", status.Denied}, s.RefID, clean.Log(uid))
+	AbortForbidden(c)
+
+	return nil, "", entity.PermDefault
+}
+
+// bindGrant assigns and validates the request form values, and returns the UID of the user or team to share
+// with and the permissions to grant, or aborts with an error and returns an empty UID.
+func bindGrant(c *gin.Context, allowed uint) (principal string, perm uint) {
+	var frm form.Grant
+
+	if err := c.BindJSON(&frm); err != nil {
+		AbortBadRequest(c, err)
+		return "", perm
+	}
+
+	principal = frm.Principal()
+
+	switch {
+	case rnd.IsUID(principal, entity.TeamUID):
+		if entity.FindTeam(principal) == nil {
+			AbortEntityNotFound(c)
+			return "", perm
+		}
+	case rnd.IsUID(principal, entity.UserUID):
+		if u := entity.FindUserByUID(principal); u == nil || !u.IsRegistered() {
+			AbortEntityNotFound(c)
+			return "", perm
+		}
+	default:
+		AbortBadRequest(c, errors.New("invalid user or team uid"))
+		return "", perm
+	}
+
+	// Editing and uploading are controlled by the user role and cannot be granted per item.
+	if frm.Perm&^entity.PermGrantable != 0 {
+		AbortBadRequest(c, errors.New("edit and upload permissions cannot be granted"))
+		return "", perm
+	}
+
+	// Users cannot grant more permissions than they have themselves.
+	if perm = frm.Perm; allowed&entity.PermAll == 0 {
+		perm &= allowed
+	}
+
+	return principal, perm
+}
+
+// canRevoke checks if the specified permissions may be revoked by a session that may grant the allowed permissions.
+func canRevoke(perm, allowed uint) bool {
+	return allowed&entity.PermAll != 0 || perm&^allowed == 0
+}
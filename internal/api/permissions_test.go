@@ -0,0 +1,102 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestAlbumPermissions(t *testing.T) {
+	t.Run("GrantAndRevoke", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetAlbumPermissions(router)
+		GrantAlbumPermissions(router)
+		RevokeAlbumPermissions(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/as6sg6bxpogaaba7/permissions", `{"TeamUID": "tt2hdkz1vgdb3ta2", "Perm": 10}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "tt2hdkz1vgdb3ta2", gjson.Get(r.Body.String(), "UserUID").String())
+		assert.Equal(t, "tt2hdkz1vgdb3ta2", gjson.Get(r.Body.String(), "TeamUID").String())
+		assert.Equal(t, int64(entity.PermView|entity.PermComment), gjson.Get(r.Body.String(), "Perm").Int())
+
+		r = PerformRequest(app, "GET", "/api/v1/albums/as6sg6bxpogaaba7/permissions")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "#").Int())
+
+		r = PerformRequest(app, "DELETE", "/api/v1/albums/as6sg6bxpogaaba7/permissions/tt2hdkz1vgdb3ta2")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(0), gjson.Get(r.Body.String(), "#").Int())
+	})
+	t.Run("NotGrantable", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GrantAlbumPermissions(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/as6sg6bxpogaaba7/permissions", `{"TeamUID": "tt2hdkz1vgdb3ta2", "Perm": 32}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("InvalidPrincipal", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GrantAlbumPermissions(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/as6sg6bxpogaaba7/permissions", `{"UserUID": "ps6sg6be2lvl0yh8"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("TeamNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GrantAlbumPermissions(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/as6sg6bxpogaaba7/permissions", `{"TeamUID": "tt2hdkz1vgdb3xxx"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("AlbumNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetAlbumPermissions(router)
+		r := PerformRequest(app, "GET", "/api/v1/albums/as6sg6bxpogaaxxx/permissions")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("VisitorForbidden", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+		GetAlbumPermissions(router)
+		r := AuthenticatedRequest(app, "GET", "/api/v1/albums/as6sg6bxpogaaba8/permissions", entity.SessionFixtures.Pointer("visitor").AuthToken())
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
+
+func TestPhotoPermissions(t *testing.T) {
+	t.Run("GrantAndRevoke", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoPermissions(router)
+		GrantPhotoPermissions(router)
+		RevokePhotoPermissions(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/ps6sg6be2lvl0yh8/permissions", `{"UserUID": "uqxc08w3d0ej2283"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "uqxc08w3d0ej2283", gjson.Get(r.Body.String(), "UserUID").String())
+		assert.Equal(t, "", gjson.Get(r.Body.String(), "TeamUID").String())
+		assert.Equal(t, int64(entity.PermView), gjson.Get(r.Body.String(), "Perm").Int())
+
+		r = PerformRequest(app, "GET", "/api/v1/photos/ps6sg6be2lvl0yh8/permissions")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "uqxc08w3d0ej2283", gjson.Get(r.Body.String(), "0.UserUID").String())
+
+		r = PerformRequest(app, "DELETE", "/api/v1/photos/ps6sg6be2lvl0yh8/permissions/uqxc08w3d0ej2283")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(0), gjson.Get(r.Body.String(), "#").Int())
+	})
+	t.Run("PhotoNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetPhotoPermissions(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/ps6sg6be2lvl0xxx/permissions")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestCanRevoke(t *testing.T) {
+	assert.True(t, canRevoke(entity.PermAll, entity.PermAll))
+	assert.True(t, canRevoke(entity.PermView, entity.PermView|entity.PermShare))
+	assert.False(t, canRevoke(entity.PermAll, entity.PermView|entity.PermShare))
+}
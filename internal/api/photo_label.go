@@ -95,6 +95,8 @@ func AddPhotoLabel(router *gin.RouterGroup) {
 			return
 		}
 
+		SaveSidecarFiles(&p)
+
 		PublishPhotoEvent(StatusUpdated, c.Param("uid"), c)
 
 		event.Success("label updated")
@@ -174,6 +176,8 @@ func RemovePhotoLabel(router *gin.RouterGroup) {
 			return
 		}
 
+		SaveSidecarFiles(&p)
+
 		PublishPhotoEvent(StatusUpdated, clean.UID(c.Param("uid")), c)
 
 		event.Success("label removed")
@@ -258,6 +262,8 @@ func UpdatePhotoLabel(router *gin.RouterGroup) {
 			return
 		}
 
+		SaveSidecarFiles(&p)
+
 		PublishPhotoEvent(StatusUpdated, clean.UID(c.Param("uid")), c)
 
 		event.Success("label saved")
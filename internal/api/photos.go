@@ -17,25 +17,34 @@ import (
 	"github.com/photoprism/photoprism/pkg/i18n"
 )
 
-// SaveSidecarYaml saves the photo metadata to a YAML sidecar file.
-func SaveSidecarYaml(photo *entity.Photo) {
+// SaveSidecarFiles saves the photo metadata to YAML and XMP sidecar files, depending on the config options.
+func SaveSidecarFiles(photo *entity.Photo) {
 	if photo == nil {
-		log.Debugf("api: photo is nil (update yaml)")
+		log.Debugf("api: photo is nil (update sidecar files)")
 		return
 	} else if !photo.HasID() {
-		log.Debugf("api: photo has no ID (update yaml)")
+		log.Debugf("api: photo has no ID (update sidecar files)")
 		return
 	}
 
 	conf := get.Config()
 
-	// Check if saving YAML sidecar files is enabled.
-	if !conf.SidecarYaml() {
-		return
+	// Write photo metadata to YAML sidecar file, if enabled.
+	if conf.SidecarYaml() {
+		_ = photo.SaveSidecarYaml(conf.OriginalsPath(), conf.SidecarPath())
 	}
 
-	// Write photo metadata to YAML sidecar file.
-	_ = photo.SaveSidecarYaml(conf.OriginalsPath(), conf.SidecarPath())
+	// Write photo metadata to XMP sidecar file, if enabled. New files are created
+	// next to the original, unless the originals folder is read-only.
+	if conf.SidecarXmp() {
+		sidecarPath := ""
+
+		if conf.ReadOnly() {
+			sidecarPath = conf.SidecarPath()
+		}
+
+		_ = photo.SaveSidecarXmp(conf.OriginalsPath(), sidecarPath)
+	}
 }
 
 // GetPhoto returns picture details as JSON.
@@ -128,7 +137,7 @@ func UpdatePhoto(router *gin.RouterGroup) {
 			return
 		}
 
-		SaveSidecarYaml(&p)
+		SaveSidecarFiles(&p)
 
 		UpdateClientConfig()
 
@@ -249,7 +258,7 @@ func ApprovePhoto(router *gin.RouterGroup) {
 			return
 		}
 
-		SaveSidecarYaml(&m)
+		SaveSidecarFiles(&m)
 
 		PublishPhotoEvent(StatusUpdated, id, c)
 
@@ -0,0 +1,144 @@
+package api
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/auth/acl"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/photoprism/get"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/geo/track"
+	"github.com/photoprism/photoprism/pkg/i18n"
+)
+
+// GeotagMaxFileSize is the maximum size of uploaded track files in bytes.
+const GeotagMaxFileSize = 50000000
+
+// PostGeotag sets the location of pictures based on uploaded GPS tracks.
+//
+//	@Summary		sets the location of pictures based on uploaded GPS tracks
+//	@Description	Accepts one or more GPX, KML, or GeoJSON files (max 50 MB each) in a multipart form field named "files",
+//	@Description	matches pictures by the time they were taken, and interpolates their position.
+//	@Description	Locations that have been set manually or from metadata are not changed.
+//	@Id				PostGeotag
+//	@Tags			Photos
+//	@Accept			multipart/form-data
+//	@Produce		json
+//	@Param			files				formData	file	true	"track files (gpx, kml, or geojson)"
+//	@Param			offset				formData	string	false	"camera clock offset, e.g. -1h30m"
+//	@Param			timezone			formData	string	false	"time zone of pictures without a known time zone"
+//	@Param			maxGap				formData	string	false	"maximum time between track points and pictures, e.g. 30m"
+//	@Param			dryRun				formData	bool	false	"report matching pictures without updating them"
+//	@Success		200					{array}		photoprism.GeotagResult
+//	@Failure		400,401,403,429,500	{object}	i18n.Response
+//	@Router			/api/v1/photos/geotag [post]
+func PostGeotag(router *gin.RouterGroup) {
+	router.POST("/photos/geotag", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var frm form.Geotag
+
+		// Parse form values and uploaded files.
+		if err := c.ShouldBind(&frm); err != nil {
+			AbortBadRequest(c, err)
+			return
+		}
+
+		f, err := c.MultipartForm()
+
+		if err != nil {
+			log.Debugf("geotag: %s", clean.Error(err))
+			Abort(c, http.StatusBadRequest, i18n.ErrUploadFailed)
+			return
+		}
+
+		files := f.File["files"]
+
+		if len(files) == 0 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		}
+
+		opt := photoprism.GeotagOptions{
+			TimeZone: frm.TimeZone,
+			DryRun:   frm.DryRun,
+		}
+
+		if opt.Offset, err = frm.OffsetDuration(); err != nil {
+			AbortBadRequest(c, err)
+			return
+		} else if opt.MaxGap, err = frm.MaxGapDuration(); err != nil {
+			AbortBadRequest(c, err)
+			return
+		}
+
+		tracks := make([]track.Track, 0, len(files))
+
+		// Read and parse the uploaded track files.
+		for _, file := range files {
+			format := track.FileFormat(file.Filename)
+
+			if format == "" {
+				log.Debugf("geotag: %s has an unsupported format", clean.Log(file.Filename))
+				Abort(c, http.StatusBadRequest, i18n.ErrUnsupportedFormat)
+				return
+			} else if file.Size > GeotagMaxFileSize {
+				log.Debugf("geotag: %s is too large", clean.Log(file.Filename))
+				Abort(c, http.StatusBadRequest, i18n.ErrFileTooLarge)
+				return
+			}
+
+			r, openErr := file.Open()
+
+			if openErr != nil {
+				log.Errorf("geotag: %s", clean.Error(openErr))
+				Abort(c, http.StatusBadRequest, i18n.ErrUploadFailed)
+				return
+			}
+
+			data, readErr := io.ReadAll(io.LimitReader(r, GeotagMaxFileSize))
+			_ = r.Close()
+
+			if readErr != nil {
+				log.Errorf("geotag: %s", clean.Error(readErr))
+				Abort(c, http.StatusBadRequest, i18n.ErrUploadFailed)
+				return
+			}
+
+			trk, parseErr := track.Parse(data, format, file.Filename)
+
+			if parseErr != nil {
+				log.Debugf("geotag: %s in %s", clean.Error(parseErr), clean.Log(file.Filename))
+				Abort(c, http.StatusBadRequest, i18n.ErrUnsupportedFormat)
+				return
+			}
+
+			tracks = append(tracks, trk)
+		}
+
+		// Pictures cannot be updated while the index is being updated.
+		if !opt.DryRun && mutex.IndexWorker.Running() {
+			AbortBusy(c)
+			return
+		}
+
+		results, err := photoprism.NewGeotag(get.Config()).Start(track.Merge("", tracks...), opt)
+
+		if err != nil {
+			log.Errorf("geotag: %s", clean.Error(err))
+			AbortBadRequest(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, results)
+	})
+}
@@ -0,0 +1,84 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPostGeotag(t *testing.T) {
+	t.Run("DryRun", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		PostGeotag(router)
+
+		data, err := os.ReadFile("../../pkg/geo/track/testdata/track.gpx")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		body, ctype, err := buildMultipart(map[string][]byte{"track.gpx": data})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/photos/geotag?dryRun=true", body)
+		req.Header.Set("Content-Type", ctype)
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+		assert.Equal(t, "[]", w.Body.String())
+	})
+	t.Run("UnsupportedFormat", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		PostGeotag(router)
+
+		body, ctype, err := buildMultipart(map[string][]byte{"track.txt": []byte("foo")})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/photos/geotag", body)
+		req.Header.Set("Content-Type", ctype)
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("InvalidTrack", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		PostGeotag(router)
+
+		body, ctype, err := buildMultipart(map[string][]byte{"track.gpx": []byte("<gpx></gpx>")})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/photos/geotag", body)
+		req.Header.Set("Content-Type", ctype)
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+	t.Run("NoFiles", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		PostGeotag(router)
+
+		body, ctype, err := buildMultipart(map[string][]byte{})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/photos/geotag", body)
+		req.Header.Set("Content-Type", ctype)
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
@@ -28,7 +28,7 @@ import (
 //	@Failure		400,401,403,404	{object}	i18n.Response
 //	@Param			count			query		int		true	"maximum number of files"	minimum(1)	maximum(100000)
 //	@Param			offset			query		int		false	"file offset"				minimum(0)	maximum(100000)
-//	@Param			order			query		string	false	"sort order"				Enums(name, title, added, edited, newest, oldest, size, random, duration, relevance)
+//	@Param			order			query		string	false	"sort order"				Enums(name, title, added, edited, newest, oldest, size, random, duration, relevance, rank)
 //	@Param			merged			query		bool	false	"groups consecutive files that belong to the same photo"
 //	@Param			public			query		bool	false	"excludes private pictures"
 //	@Param			quality			query		int		false	"minimum quality score (1-7)"	Enums(0, 1, 2, 3, 4, 5, 6, 7)
@@ -86,7 +86,13 @@ func SearchPhotos(router *gin.RouterGroup) {
 		// Ok?
 		if err != nil {
 			event.AuditWarn([]string{ClientIP(c), "session %s", string(acl.ResourcePhotos), "search", status.Error(err)}, s.RefID)
-			AbortBadRequest(c, err)
+
+			if form.IsQueryError(err) {
+				AbortBadQuery(c, err)
+			} else {
+				AbortBadRequest(c, err)
+			}
+
 			return
 		}
 
@@ -115,7 +121,13 @@ func SearchPhotos(router *gin.RouterGroup) {
 
 		if err != nil {
 			event.AuditWarn([]string{ClientIP(c), "session %s", string(acl.ResourcePhotos), "view", status.Error(err)}, s.RefID)
-			AbortBadRequest(c, err)
+
+			if form.IsQueryError(err) {
+				AbortBadQuery(c, err)
+			} else {
+				AbortBadRequest(c, err)
+			}
+
 			return
 		}
 
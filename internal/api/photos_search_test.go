@@ -36,4 +36,19 @@ func TestSearchPhotos(t *testing.T) {
 		result := PerformRequest(app, "GET", "/api/v1/photos?xxx=10")
 		assert.Equal(t, http.StatusBadRequest, result.Code)
 	})
+	t.Run("BooleanQuery", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchPhotos(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos?count=10&q=label%3Acake+OR+label%3Aflower")
+		count := gjson.Get(r.Body.String(), "#")
+		assert.LessOrEqual(t, int64(1), count.Int())
+		assert.Equal(t, http.StatusOK, r.Code)
+	})
+	t.Run("QuerySyntaxError", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchPhotos(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos?count=10&q=%28label%3Acake+OR+label%3Aflower")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+		assert.Equal(t, "missing closing parenthesis at position 1", gjson.Get(r.Body.String(), "details").String())
+	})
 }
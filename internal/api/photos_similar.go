@@ -0,0 +1,165 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+
+	"github.com/photoprism/photoprism/internal/auth/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/photoprism/get"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/i18n"
+)
+
+// SearchSimilarPhotos finds groups of visually similar pictures, e.g. resized or re-encoded copies and burst shots.
+//
+//	@Summary		finds groups of visually similar pictures and returns them as JSON
+//	@Description	Requires access to all pictures. Groups are found again when the first page is requested, and cached for the following pages.
+//	@Id				SearchSimilarPhotos
+//	@Tags			Photos
+//	@Produce		json
+//	@Success		200				{array}		photoprism.SimilarGroup
+//	@Failure		400,401,403,429	{object}	i18n.Response
+//	@Param			count			query		int	true	"maximum number of groups"						minimum(1)	maximum(100000)
+//	@Param			offset			query		int	false	"group offset"									minimum(0)	maximum(100000)
+//	@Param			distance		query		int	false	"maximum perceptual hash distance (1-32)"	minimum(0)	maximum(32)
+//	@Router			/api/v1/photos/similar [get]
+func SearchSimilarPhotos(router *gin.RouterGroup) {
+	router.GET("/photos/similar", func(c *gin.Context) {
+		// Groups include all pictures in the library, so only users with full access may search them.
+		s := Auth(c, acl.ResourcePhotos, acl.AccessAll)
+
+		// Abort if permission is not granted.
+		if s.Abort(c) {
+			return
+		}
+
+		var frm form.SearchSimilar
+
+		if err := c.MustBindWith(&frm, binding.Form); err != nil {
+			AbortBadRequest(c, err)
+			return
+		}
+
+		if frm.Distance < 0 || frm.Distance > 32 {
+			Abort(c, http.StatusBadRequest, i18n.ErrBadRequest)
+			return
+		}
+
+		var groups []photoprism.SimilarGroup
+		var err error
+
+		// Find groups again when the first page is requested, use the cached result otherwise.
+		if w := photoprism.NewSimilar(get.Config()); frm.Offset == 0 {
+			groups, err = w.Groups(frm.Distance)
+		} else {
+			groups, err = w.CachedGroups(frm.Distance)
+		}
+
+		if err != nil {
+			log.Errorf("similar: %s", clean.Error(err))
+			AbortUnexpectedError(c)
+			return
+		}
+
+		// Apply offset and limit.
+		if frm.Offset >= len(groups) {
+			groups = []photoprism.SimilarGroup{}
+		} else {
+			groups = groups[frm.Offset:]
+		}
+
+		if frm.Count > 0 && frm.Count < len(groups) {
+			groups = groups[:frm.Count]
+		}
+
+		AddCountHeader(c, len(groups))
+		AddLimitHeader(c, frm.Count)
+		AddOffsetHeader(c, frm.Offset)
+		AddTokenHeaders(c, s)
+
+		c.JSON(http.StatusOK, groups)
+	})
+}
+
+// ResolveSimilarPhotos archives or stacks a group of visually similar pictures, except the one to keep.
+//
+//	@Summary		archives or stacks a group of visually similar pictures, except the one to keep
+//	@Description	If no picture to keep is specified, the one with the highest resolution and quality is kept.
+//	@Id				ResolveSimilarPhotos
+//	@Tags			Photos
+//	@Accept			json
+//	@Produce		json
+//	@Success		200					{object}	entity.Photo
+//	@Failure		400,401,403,404,429	{object}	i18n.Response
+//	@Param			group				body		form.ResolveSimilar	true	"photo UIDs, the UID to keep, and the action (archive, stack)"
+//	@Router			/api/v1/photos/similar/resolve [post]
+func ResolveSimilarPhotos(router *gin.RouterGroup) {
+	router.POST("/photos/similar/resolve", func(c *gin.Context) {
+		s := Auth(c, acl.ResourcePhotos, acl.ActionDelete)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var frm form.ResolveSimilar
+
+		// Assign and validate request form values.
+		if err := c.BindJSON(&frm); err != nil {
+			AbortBadRequest(c, err)
+			return
+		}
+
+		if len(frm.Photos) < 2 {
+			Abort(c, http.StatusBadRequest, i18n.ErrNoItemsSelected)
+			return
+		} else if frm.Action != photoprism.SimilarArchive && frm.Action != photoprism.SimilarStack {
+			Abort(c, http.StatusBadRequest, i18n.ErrBadRequest)
+			return
+		}
+
+		kept, resolved, err := photoprism.NewSimilar(get.Config()).Resolve(frm.Photos, clean.UID(frm.Keep), frm.Action)
+
+		if kept == nil {
+			log.Debugf("similar: %s", clean.Error(err))
+			AbortEntityNotFound(c)
+			return
+		} else if err != nil {
+			log.Errorf("similar: %s", clean.Error(err))
+			AbortSaveFailed(c)
+			return
+		}
+
+		uids := make([]string, 0, len(resolved))
+
+		for _, p := range resolved {
+			uids = append(uids, p.PhotoUID)
+
+			if frm.Action == photoprism.SimilarArchive {
+				SaveSidecarFiles(p)
+			}
+		}
+
+		// Update precalculated photo and file counts.
+		entity.UpdateCountsAsync()
+
+		// Update album, subject, and label cover thumbs.
+		query.UpdateCoversAsync()
+
+		UpdateClientConfig()
+
+		event.EntitiesArchived("photos", uids)
+
+		if frm.Action == photoprism.SimilarStack {
+			PublishPhotoEvent(StatusUpdated, kept.PhotoUID, c)
+		}
+
+		c.JSON(http.StatusOK, kept)
+	})
+}
@@ -0,0 +1,72 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+func TestSearchSimilarPhotos(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchSimilarPhotos(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/similar?count=10")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.NotEmpty(t, r.Header().Get("X-Count"))
+	})
+	t.Run("Paged", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchSimilarPhotos(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/similar?count=1&offset=1")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "1", r.Header().Get("X-Offset"))
+	})
+	t.Run("Guest", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+
+		SearchSimilarPhotos(router)
+
+		sessId := AuthenticateUser(app, router, "gandalf", "Gandalf123!")
+		r := AuthenticatedRequest(app, "GET", "/api/v1/photos/similar?count=10", sessId)
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+	t.Run("InvalidDistance", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchSimilarPhotos(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/similar?count=10&distance=65")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("MissingCount", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchSimilarPhotos(router)
+		r := PerformRequest(app, "GET", "/api/v1/photos/similar")
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestResolveSimilarPhotos(t *testing.T) {
+	t.Run("NoItemsSelected", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ResolveSimilarPhotos(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/similar/resolve", `{"photos": ["ps6sg6be2lvl0yh7"], "action": "archive"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("InvalidAction", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ResolveSimilarPhotos(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/similar/resolve", `{"photos": ["ps6sg6be2lvl0yh7", "ps6sg6be2lvl0yh8"], "action": "delete"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ResolveSimilarPhotos(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/photos/similar/resolve", `{"photos": ["ps6sg6be2lvl0yh7", "ps6sg6be2lvl0yh8"], "keep": "ps6sg6be2lvl0yx9", "action": "archive"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
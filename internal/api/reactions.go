@@ -52,7 +52,7 @@ func LikePhoto(router *gin.RouterGroup) {
 				return
 			}
 
-			SaveSidecarYaml(&m)
+			SaveSidecarFiles(&m)
 			PublishPhotoEvent(StatusUpdated, id, c)
 		}
 
@@ -100,7 +100,7 @@ func DislikePhoto(router *gin.RouterGroup) {
 				return
 			}
 
-			SaveSidecarYaml(&m)
+			SaveSidecarFiles(&m)
 			PublishPhotoEvent(StatusUpdated, id, c)
 		}
 
@@ -0,0 +1,140 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/auth/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism/get"
+	"github.com/photoprism/photoprism/internal/workers"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/i18n"
+)
+
+// GetServiceConflicts returns files that have been changed both locally and remotely.
+//
+//	@Summary	returns files that have been changed both locally and on the remote service
+//	@Id			GetServiceConflicts
+//	@Tags		Services
+//	@Produce	json
+//	@Success	200				{object}	[]entity.FileSync
+//	@Failure	401,403,404,429	{object}	i18n.Response
+//	@Param		id				path		string	true	"service id"
+//	@Router		/api/v1/services/{id}/conflicts [get]
+func GetServiceConflicts(router *gin.RouterGroup) {
+	router.GET("/services/:id/conflicts", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceServices, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+
+		if conf.Demo() || conf.DisableSettings() {
+			AbortForbidden(c)
+			return
+		}
+
+		id := clean.IdUint(c.Param("id"))
+
+		m, err := query.AccountByID(id)
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrAccountNotFound)
+			return
+		}
+
+		files, err := query.FileSyncs(m.ID, entity.FileSyncConflict, 0)
+
+		if err != nil {
+			log.Errorf("sync: %s", err)
+			AbortUnexpectedError(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, files)
+	})
+}
+
+// ResolveServiceConflicts sets the resolution policy for one or all conflicting files.
+//
+//	@Summary	sets the resolution policy for one or all conflicting files
+//	@Id			ResolveServiceConflicts
+//	@Tags		Services
+//	@Accept		json
+//	@Produce	json
+//	@Success	200					{object}	[]entity.FileSync
+//	@Failure	400,401,403,404,429	{object}	i18n.Response
+//	@Param		id					path		string					true	"service id"
+//	@Param		resolution			body		form.ServiceConflict	true	"remote file name (optional) and resolution policy: keep, newest, remote, local"
+//	@Router		/api/v1/services/{id}/conflicts [post]
+func ResolveServiceConflicts(router *gin.RouterGroup) {
+	router.POST("/services/:id/conflicts", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceServices, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+
+		if conf.Demo() || conf.DisableSettings() {
+			AbortForbidden(c)
+			return
+		}
+
+		var frm form.ServiceConflict
+
+		// Assign and validate request form values.
+		if err := c.BindJSON(&frm); err != nil {
+			AbortBadRequest(c, err)
+			return
+		}
+
+		id := clean.IdUint(c.Param("id"))
+
+		m, err := query.AccountByID(id)
+
+		if err != nil {
+			Abort(c, http.StatusNotFound, i18n.ErrAccountNotFound)
+			return
+		}
+
+		files, err := query.FileSyncs(m.ID, entity.FileSyncConflict, 0)
+
+		if err != nil {
+			log.Errorf("sync: %s", err)
+			AbortUnexpectedError(c)
+			return
+		}
+
+		result := make([]entity.FileSync, 0, len(files))
+
+		for _, file := range files {
+			if frm.RemoteName != "" && file.RemoteName != frm.RemoteName {
+				continue
+			} else if err = file.Resolve(frm.Resolution); err != nil {
+				AbortBadRequest(c, err)
+				return
+			}
+
+			result = append(result, file)
+		}
+
+		if frm.RemoteName != "" && len(result) == 0 {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		if m.AccSync {
+			workers.RunSync(conf)
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
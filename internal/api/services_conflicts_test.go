@@ -0,0 +1,88 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/i18n"
+)
+
+func TestGetServiceConflicts(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		conflict := entity.NewFileSync(1000001, "/conflict-list.jpg")
+		conflict.Status = entity.FileSyncConflict
+
+		if err := conflict.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer entity.UnscopedDb().Delete(conflict)
+
+		app, router, _ := NewApiTest()
+		GetServiceConflicts(router)
+		r := PerformRequest(app, "GET", "/api/v1/services/1000001/conflicts")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "#").Int())
+		assert.Equal(t, "/conflict-list.jpg", gjson.Get(r.Body.String(), "0.RemoteName").String())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetServiceConflicts(router)
+		r := PerformRequest(app, "GET", "/api/v1/services/999000/conflicts")
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, i18n.Msg(i18n.ErrAccountNotFound), val.String())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestResolveServiceConflicts(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		conflict := entity.NewFileSync(1000001, "/conflict-resolve.jpg")
+		conflict.Status = entity.FileSyncConflict
+
+		if err := conflict.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer entity.UnscopedDb().Delete(conflict)
+
+		app, router, _ := NewApiTest()
+		ResolveServiceConflicts(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/services/1000001/conflicts", `{"RemoteName": "/conflict-resolve.jpg", "Resolution": "remote"}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "remote", gjson.Get(r.Body.String(), "0.Resolution").String())
+	})
+	t.Run("InvalidResolution", func(t *testing.T) {
+		conflict := entity.NewFileSync(1000001, "/conflict-invalid.jpg")
+		conflict.Status = entity.FileSyncConflict
+
+		if err := conflict.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer entity.UnscopedDb().Delete(conflict)
+
+		app, router, _ := NewApiTest()
+		ResolveServiceConflicts(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/services/1000001/conflicts", `{"Resolution": "foo"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+	t.Run("FileNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ResolveServiceConflicts(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/services/1000001/conflicts", `{"RemoteName": "/missing.jpg", "Resolution": "keep"}`)
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ResolveServiceConflicts(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/services/999000/conflicts", `{"Resolution": "keep"}`)
+		val := gjson.Get(r.Body.String(), "error")
+		assert.Equal(t, i18n.Msg(i18n.ErrAccountNotFound), val.String())
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
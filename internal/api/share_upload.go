@@ -0,0 +1,559 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/auth/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/photoprism/get"
+	"github.com/photoprism/photoprism/internal/server/limiter"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/i18n"
+	"github.com/photoprism/photoprism/pkg/log/status"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// ShareUploadFile represents a file that has been uploaded with a share link and awaits approval.
+type ShareUploadFile struct {
+	Name    string    `json:"Name"`
+	Size    int64     `json:"Size"`
+	ModTime time.Time `json:"ModTime"`
+}
+
+// ShareUpload adds files to a shared album using a share link that grants upload permission.
+//
+//	@Summary	uploads files to a shared album using a share link that grants upload permission
+//	@Id			ShareUpload
+//	@Tags		Sharing, Files
+//	@Accept		multipart/form-data
+//	@Produce	json
+//	@Param		token					path		string	true	"share token"
+//	@Param		shared					path		string	true	"shared album uid or slug"
+//	@Param		files					formData	file	true	"one or more files to upload (repeat the field for multiple files)"
+//	@Success	200						{object}	i18n.Response
+//	@Failure	400,403,404,413,429,507	{object}	i18n.Response
+//	@Router		/s/{token}/{shared}/upload [post]
+func ShareUpload(router *gin.RouterGroup) {
+	router.POST("/:token/:shared/upload", func(c *gin.Context) {
+		conf := get.Config()
+
+		// Abort in read-only mode or when the upload feature is disabled.
+		if conf.ReadOnly() || !conf.Settings().Features.Upload {
+			Abort(c, http.StatusForbidden, i18n.ErrReadOnly)
+			return
+		}
+
+		clientIp := ClientIP(c)
+
+		// Abort if the failure rate limit has been exceeded.
+		if limiter.Auth.Reject(clientIp) {
+			limiter.AbortJSON(c)
+			return
+		}
+
+		token := clean.Token(c.Param("token"))
+		link := findUploadLink(token, clean.Token(c.Param("shared")))
+
+		// Abort if the link does not exist, has expired, or does not grant upload permission.
+		if link == nil {
+			limiter.Auth.Reserve(clientIp)
+			event.AuditWarn([]string{clientIp, "token %s", "upload files", status.Denied}, clean.Log(token))
+			AbortForbidden(c)
+			return
+		}
+
+		// Abort if there is not enough free storage to upload new files.
+		if conf.FilesQuotaReached() {
+			event.AuditErr([]string{clientIp, "link %s", "upload files", status.InsufficientStorage}, link.RefID)
+			Abort(c, http.StatusInsufficientStorage, i18n.ErrInsufficientStorage)
+			return
+		}
+
+		start := time.Now()
+
+		f, err := c.MultipartForm()
+
+		if err != nil {
+			log.Errorf("upload: %s", err)
+			Abort(c, http.StatusBadRequest, i18n.ErrUploadFailed)
+			return
+		}
+
+		files := f.File["files"]
+		uploadSize := UploadSize(files)
+
+		// Reserve the uploaded files before saving them, and abort if they would exceed the upload limits of the link.
+		if limitErr := link.ReserveUploads(len(files), uploadSize); limitErr != nil {
+			event.AuditErr([]string{clientIp, "link %s", "upload files", clean.Error(limitErr)}, link.RefID)
+			Error(c, http.StatusRequestEntityTooLarge, limitErr, i18n.ErrQuotaExceeded)
+			return
+		}
+
+		var uploads []string
+		var savedSize int64
+
+		// Release the reserved files that have not been saved when done.
+		defer func() {
+			link.ReleaseUploads(len(files)-len(uploads), uploadSize-savedSize)
+		}()
+
+		// Abort if the uploaded files would exceed the storage quota of the link owner.
+		if quotaErr := query.CheckUserQuota(shareUploadOwner(link), uploadSize, len(files)); errors.Is(quotaErr, entity.ErrQuotaExceeded) {
+			event.AuditErr([]string{clientIp, "link %s", "upload files", clean.Error(quotaErr)}, link.RefID)
+			AbortStorageQuotaExceeded(c, quotaErr)
+			return
+		} else if quotaErr != nil {
+			log.Warnf("upload: %s (check quota)", quotaErr)
+		}
+
+		// Stage uploaded files in a new subfolder of the link upload path.
+		stagingPath, err := conf.ShareUploadPath(link.LinkUID)
+
+		if err != nil {
+			log.Errorf("upload: failed to create storage folder (%s)", err)
+			Abort(c, http.StatusBadRequest, i18n.ErrUploadFailed)
+			return
+		}
+
+		uploadDir := filepath.Join(stagingPath, time.Now().UTC().Format("20060102-150405")+"-"+rnd.Base36(6))
+
+		if err = fs.MkdirAll(uploadDir); err != nil {
+			log.Errorf("upload: failed to create storage folder (%s)", err)
+			Abort(c, http.StatusBadRequest, i18n.ErrUploadFailed)
+			return
+		}
+
+		allowedExt := conf.UploadAllow()
+		rejectRaw := conf.DisableRaw()
+		fileSizeLimit := conf.OriginalsLimitBytes()
+		totalSizeLimit := conf.UploadLimitBytes()
+
+		// Save uploaded files and append their names
+		// to "uploads" if they pass all checks.
+		for _, file := range files {
+			baseName := filepath.Base(file.Filename)
+			destName := path.Join(uploadDir, baseName)
+			fileType := fs.FileType(baseName)
+
+			// Reject archives, unsupported files, and files with extensions that aren't allowed.
+			switch {
+			case fs.ArchiveExt(baseName) != "":
+				log.Errorf("upload: rejected %s because archives cannot be uploaded with share links", clean.Log(baseName))
+				continue
+			case fileType == fs.TypeUnknown:
+				log.Errorf("upload: rejected %s because it has an unsupported file extension", clean.Log(baseName))
+				continue
+			case allowedExt.Excludes(fileType.DefaultExt()):
+				log.Errorf("upload: rejected %s because its extension is not allowed", clean.Log(baseName))
+				continue
+			case fileSizeLimit > 0 && file.Size > fileSizeLimit:
+				log.Errorf("upload: rejected %s because its size exceeds the file size limit", clean.Log(baseName))
+				continue
+			}
+
+			if err = c.SaveUploadedFile(file, destName); err != nil {
+				log.Debugf("upload: %s in %s", clean.Error(err), clean.Log(baseName))
+				log.Errorf("upload: failed to save %s", clean.Log(baseName))
+				uploads = nil
+				logWarn("upload", os.RemoveAll(uploadDir))
+				Abort(c, http.StatusBadRequest, i18n.ErrUploadFailed)
+				return
+			} else if totalSizeLimit, err = UploadCheckFile(destName, rejectRaw, totalSizeLimit); err != nil {
+				log.Errorf("upload: %s", err)
+			} else {
+				uploads = append(uploads, destName)
+			}
+		}
+
+		// Check if the uploaded files may contain inappropriate content.
+		if len(uploads) > 0 && !conf.UploadNSFW() && UploadRemoveNSFW(uploads) {
+			uploads = nil
+			logWarn("upload", os.RemoveAll(uploadDir))
+			Abort(c, http.StatusForbidden, i18n.ErrOffensiveUpload)
+			return
+		}
+
+		if len(uploads) == 0 {
+			logWarn("upload", os.RemoveAll(uploadDir))
+			Abort(c, http.StatusBadRequest, i18n.ErrUploadFailed)
+			return
+		}
+
+		savedSize = UploadPathsSize(uploads)
+
+		event.AuditInfo([]string{clientIp, "link %s", "upload files", "%s", status.Succeeded}, link.RefID, english.Plural(len(uploads), "file", "files"))
+
+		msg := i18n.Msg(i18n.MsgFilesUploadedIn, len(uploads), int(time.Since(start).Seconds()))
+
+		// Keep files in the staging folder until they have been approved by the link owner.
+		if link.ModerateUploads {
+			event.Publish("upload.pending", event.Data{"uid": link.CreatedBy, "link": link.LinkUID, "album": link.ShareUID, "count": len(uploads)})
+			c.JSON(http.StatusOK, i18n.Response{Code: http.StatusOK, Msg: msg, Details: "awaiting approval"})
+			return
+		}
+
+		importShareUploads(c, link, uploadDir)
+
+		c.JSON(http.StatusOK, i18n.Response{Code: http.StatusOK, Msg: msg})
+	})
+}
+
+// GetAlbumLinkUploads returns the files uploaded with an album share link that await approval.
+//
+//	@Summary	returns the files uploaded with an album share link that await approval
+//	@Id			GetAlbumLinkUploads
+//	@Tags		Links, Albums
+//	@Produce	json
+//	@Success	200				{object}	[]ShareUploadFile
+//	@Failure	401,403,404,429	{object}	i18n.Response
+//	@Param		uid				path		string	true	"album uid"
+//	@Param		linkuid			path		string	true	"link uid"
+//	@Router		/api/v1/albums/{uid}/links/{linkuid}/uploads [get]
+func GetAlbumLinkUploads(router *gin.RouterGroup) {
+	router.GET("/albums/:uid/links/:link/uploads", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceAlbums, acl.ActionShare)
+
+		if s.Abort(c) {
+			return
+		}
+
+		link := findAlbumLink(c)
+
+		if link == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		result, err := ShareUploadFiles(link)
+
+		if err != nil {
+			AbortUnexpectedError(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// ApproveAlbumLinkUploads imports the selected files uploaded with an album share link and adds them to the album.
+//
+//	@Summary	imports the selected files uploaded with an album share link and adds them to the album
+//	@Id			ApproveAlbumLinkUploads
+//	@Tags		Links, Albums
+//	@Accept		json
+//	@Produce	json
+//	@Success	200					{object}	i18n.Response
+//	@Failure	400,401,403,404,429	{object}	i18n.Response
+//	@Param		uid					path		string			true	"album uid"
+//	@Param		linkuid				path		string			true	"link uid"
+//	@Param		selection			body		form.Selection	true	"file names to approve, or all"
+//	@Router		/api/v1/albums/{uid}/links/{linkuid}/uploads [post]
+func ApproveAlbumLinkUploads(router *gin.RouterGroup) {
+	router.POST("/albums/:uid/links/:link/uploads", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceAlbums, acl.ActionShare)
+
+		if s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+
+		if conf.ReadOnly() || !conf.Settings().Features.Import {
+			AbortFeatureDisabled(c)
+			return
+		}
+
+		var frm form.Selection
+
+		// Assign and validate request form values.
+		if err := c.BindJSON(&frm); err != nil {
+			AbortBadRequest(c, err)
+			return
+		}
+
+		link := findAlbumLink(c)
+
+		if link == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		stagingPath, err := conf.ShareUploadPath(link.LinkUID)
+
+		if err != nil {
+			AbortUnexpectedError(c)
+			return
+		}
+
+		importPath := stagingPath
+
+		// Move the selected files to a separate folder so that only they are imported.
+		if !frm.All {
+			if len(frm.Files) == 0 {
+				AbortBadRequest(c)
+				return
+			}
+
+			importPath = filepath.Join(stagingPath, "approved-"+rnd.Base36(6))
+
+			for _, name := range frm.Files {
+				fileName, nameErr := shareUploadFileName(stagingPath, name)
+
+				if nameErr != nil {
+					log.Warnf("upload: %s", nameErr)
+					continue
+				}
+
+				destName := filepath.Join(importPath, strings.TrimPrefix(fileName, stagingPath))
+
+				if err = fs.MkdirAll(filepath.Dir(destName)); err != nil {
+					log.Errorf("upload: %s", err)
+				} else if err = os.Rename(fileName, destName); err != nil {
+					log.Errorf("upload: failed to approve %s (%s)", clean.Log(name), err)
+				}
+			}
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "link %s", "approve uploads", status.Succeeded}, s.RefID, link.RefID)
+
+		n := importShareUploads(c, link, importPath)
+
+		c.JSON(http.StatusOK, i18n.Response{Code: http.StatusOK, Msg: i18n.Msg(i18n.MsgUploadProcessed), Details: english.Plural(n, "file", "files")})
+	})
+}
+
+// RejectAlbumLinkUploads deletes the selected files uploaded with an album share link.
+//
+//	@Summary	deletes the selected files uploaded with an album share link
+//	@Id			RejectAlbumLinkUploads
+//	@Tags		Links, Albums
+//	@Accept		json
+//	@Produce	json
+//	@Success	200					{object}	[]ShareUploadFile
+//	@Failure	400,401,403,404,429	{object}	i18n.Response
+//	@Param		uid					path		string			true	"album uid"
+//	@Param		linkuid				path		string			true	"link uid"
+//	@Param		selection			body		form.Selection	true	"file names to reject, or all"
+//	@Router		/api/v1/albums/{uid}/links/{linkuid}/uploads [delete]
+func RejectAlbumLinkUploads(router *gin.RouterGroup) {
+	router.DELETE("/albums/:uid/links/:link/uploads", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceAlbums, acl.ActionShare)
+
+		if s.Abort(c) {
+			return
+		}
+
+		var frm form.Selection
+
+		// Assign and validate request form values.
+		if err := c.BindJSON(&frm); err != nil {
+			AbortBadRequest(c, err)
+			return
+		}
+
+		link := findAlbumLink(c)
+
+		if link == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		stagingPath, err := get.Config().ShareUploadPath(link.LinkUID)
+
+		if err != nil {
+			AbortUnexpectedError(c)
+			return
+		}
+
+		if frm.All {
+			logWarn("upload", os.RemoveAll(stagingPath))
+		} else if len(frm.Files) == 0 {
+			AbortBadRequest(c)
+			return
+		} else {
+			for _, name := range frm.Files {
+				if fileName, nameErr := shareUploadFileName(stagingPath, name); nameErr != nil {
+					log.Warnf("upload: %s", nameErr)
+				} else {
+					logWarn("upload", os.Remove(fileName))
+				}
+			}
+		}
+
+		event.AuditInfo([]string{ClientIP(c), "session %s", "link %s", "reject uploads", status.Succeeded}, s.RefID, link.RefID)
+
+		result, err := ShareUploadFiles(link)
+
+		if err != nil {
+			AbortUnexpectedError(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// ShareUploadFiles returns the files uploaded with the share link that await approval.
+func ShareUploadFiles(link *entity.Link) (result []ShareUploadFile, err error) {
+	result = []ShareUploadFile{}
+
+	stagingPath, err := get.Config().ShareUploadPath(link.LinkUID)
+
+	if err != nil {
+		return result, err
+	}
+
+	err = filepath.Walk(stagingPath, func(fileName string, info os.FileInfo, walkErr error) error {
+		if walkErr != nil || info.IsDir() {
+			return walkErr
+		}
+
+		result = append(result, ShareUploadFile{
+			Name:    filepath.ToSlash(strings.TrimPrefix(fileName, stagingPath+string(os.PathSeparator))),
+			Size:    info.Size(),
+			ModTime: info.ModTime().UTC(),
+		})
+
+		return nil
+	})
+
+	return result, err
+}
+
+// UploadPathsSize returns the total size of the specified files in bytes.
+func UploadPathsSize(fileNames []string) (size int64) {
+	for _, fileName := range fileNames {
+		if info, err := os.Stat(fileName); err == nil {
+			size += info.Size()
+		}
+	}
+
+	return size
+}
+
+// findUploadLink returns the first valid link for the token and shared album that grants upload permission.
+func findUploadLink(token, shared string) *entity.Link {
+	if token == "" || shared == "" {
+		return nil
+	}
+
+	for _, link := range entity.FindValidLinks(token, shared) {
+		if !link.CanUpload() {
+			continue
+		} else if _, err := query.AlbumByUID(link.ShareUID); err != nil {
+			continue
+		}
+
+		return &link
+	}
+
+	return nil
+}
+
+// findAlbumLink returns the share link specified in the request if it belongs to the album.
+func findAlbumLink(c *gin.Context) *entity.Link {
+	link := entity.FindLink(clean.UID(c.Param("link")))
+
+	if link == nil || link.ShareUID != clean.UID(c.Param("uid")) {
+		return nil
+	}
+
+	return link
+}
+
+// shareUploadOwner returns the user account that owns the share link, or the default admin.
+func shareUploadOwner(link *entity.Link) *entity.User {
+	if owner := entity.FindUserByUID(link.CreatedBy); owner != nil {
+		return owner
+	}
+
+	return &entity.Admin
+}
+
+// shareUploadFileName returns the absolute name of a staged file and makes sure it is within the staging path.
+func shareUploadFileName(stagingPath, name string) (string, error) {
+	fileName := filepath.Join(stagingPath, filepath.FromSlash(clean.UserPath(name)))
+
+	if !strings.HasPrefix(fileName, stagingPath+string(os.PathSeparator)) || !fs.FileExists(fileName) {
+		return "", errors.New("invalid file name " + clean.Log(name))
+	}
+
+	return fileName, nil
+}
+
+// importShareUploads imports the files in the upload path on behalf of the link owner
+// and adds them to the shared album, returning the number of processed files.
+func importShareUploads(c *gin.Context, link *entity.Link, uploadPath string) int {
+	conf := get.Config()
+	owner := shareUploadOwner(link)
+	start := time.Now()
+
+	// Get destination folder.
+	var destFolder string
+	if destFolder = owner.GetUploadPath(); destFolder == "" {
+		destFolder = conf.ImportDest()
+	}
+
+	// Move uploaded files to the destination folder and add them to the shared album.
+	opt := photoprism.ImportOptionsUpload(uploadPath, destFolder)
+	opt.SetUser(owner)
+	opt.Albums = []string{link.ShareUID}
+
+	imported := get.Import().Start(opt)
+
+	// Delete empty import directory.
+	if fs.DirIsEmpty(uploadPath) {
+		logWarn("upload", os.Remove(uploadPath))
+	}
+
+	n := imported.Processed()
+
+	if n == 0 {
+		log.Infof("upload: found no new files to import from %s", clean.Log(uploadPath))
+		return 0
+	}
+
+	log.Infof("upload: imported %s uploaded with link %s", english.Plural(n, "file", "files"), clean.Log(link.LinkUID))
+
+	if moments := get.Moments(); moments == nil {
+		log.Warnf("upload: moments service not set - you may have found a bug")
+	} else if workerErr := moments.Start(); workerErr != nil {
+		log.Warnf("moments: %s", workerErr)
+	}
+
+	elapsed := int(time.Since(start).Seconds())
+
+	event.Publish("import.completed", event.Data{"uid": opt.UID, "path": uploadPath, "seconds": elapsed})
+	event.Publish("index.completed", event.Data{"uid": opt.UID, "path": uploadPath, "seconds": elapsed})
+	event.Publish("upload.completed", event.Data{"uid": opt.UID, "path": uploadPath, "seconds": elapsed})
+
+	// Update album YAML backup and notify clients of the changes.
+	if a := entity.FindAlbum(entity.AlbumSearch(link.ShareUID, link.ShareUID, entity.AlbumManual)); a != nil {
+		SaveAlbumYaml(a)
+		PublishAlbumEvent(StatusUpdated, a.AlbumUID, c)
+	}
+
+	// Update the user interface.
+	UpdateClientConfig()
+
+	// Update album, label, and subject cover thumbs.
+	if coversErr := query.UpdateCovers(); coversErr != nil {
+		log.Warnf("upload: %s (update covers)", coversErr)
+	}
+
+	return n
+}
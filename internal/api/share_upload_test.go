@@ -0,0 +1,197 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestShareUpload(t *testing.T) {
+	t.Run("InvalidToken", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ShareUpload(router)
+
+		body, ctype, err := buildMultipart(map[string][]byte{"example.jpg": []byte("data")})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/xxxxxxxxxx/as6sg6bxpogaaba9/upload", body)
+		req.Header.Set("Content-Type", ctype)
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+	t.Run("NoUploadPermission", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ShareUpload(router)
+
+		body, ctype, err := buildMultipart(map[string][]byte{"example.jpg": []byte("data")})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/1jxf3jfn2k/as6sg6bxpogaaba8/upload", body)
+		req.Header.Set("Content-Type", ctype)
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+	t.Run("UploadLimitExceeded", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ShareUpload(router)
+
+		files := make(map[string][]byte, 11)
+
+		for i := 0; i < 11; i++ {
+			files[fmt.Sprintf("file%d.jpg", i)] = []byte("data")
+		}
+
+		body, ctype, err := buildMultipart(files)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/8jxf3jfn2k/berlin-2019/upload", body)
+		req.Header.Set("Content-Type", ctype)
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusRequestEntityTooLarge, w.Code)
+	})
+	t.Run("ReleaseRejectedFiles", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ShareUpload(router)
+
+		before := entity.FindLink("ss62xpryd1ob3gtf")
+
+		if before == nil {
+			t.Fatal("link not found")
+		}
+
+		body, ctype, err := buildMultipart(map[string][]byte{"unsupported.xyz": []byte("data")})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/8jxf3jfn2k/berlin-2019/upload", body)
+		req.Header.Set("Content-Type", ctype)
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+
+		after := entity.FindLink("ss62xpryd1ob3gtf")
+
+		if after == nil {
+			t.Fatal("link not found")
+		}
+
+		assert.Equal(t, before.LinkUploads, after.LinkUploads)
+		assert.Equal(t, before.LinkUploadSize, after.LinkUploadSize)
+	})
+	t.Run("ReadOnlyMode", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.Options().ReadOnly = true
+		ShareUpload(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/8jxf3jfn2k/berlin-2019/upload", "{}")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+		conf.Options().ReadOnly = false
+	})
+}
+
+func TestAlbumLinkUploads(t *testing.T) {
+	t.Run("ModerateUploads", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.Options().UploadAllow = "jpg"
+		ShareUpload(router)
+		GetAlbumLinkUploads(router)
+		RejectAlbumLinkUploads(router)
+
+		link := entity.LinkFixtures["9jxf3jfn2k"]
+		stagingPath, err := conf.ShareUploadPath(link.LinkUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer os.RemoveAll(stagingPath)
+
+		data, err := os.ReadFile(filepath.Clean("../../pkg/fs/testdata/directory/example.jpg"))
+
+		if err != nil {
+			t.Skipf("missing example.jpg: %v", err)
+		}
+
+		body, ctype, err := buildMultipart(map[string][]byte{"example.jpg": data})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/9jxf3jfn2k/as6sg6bxpogaaba9/upload", body)
+		req.Header.Set("Content-Type", ctype)
+		w := httptest.NewRecorder()
+		app.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatal(w.Body.String())
+		}
+
+		assert.Equal(t, "awaiting approval", gjson.Get(w.Body.String(), "details").String())
+
+		uploadsUrl := "/api/v1/albums/as6sg6bxpogaaba9/links/" + link.LinkUID + "/uploads"
+
+		r := PerformRequest(app, "GET", uploadsUrl)
+		assert.Equal(t, http.StatusOK, r.Code)
+
+		pending := gjson.Parse(r.Body.String()).Array()
+
+		if assert.Len(t, pending, 1) {
+			assert.Contains(t, pending[0].Get("Name").String(), "/example.jpg")
+			assert.Equal(t, int64(len(data)), pending[0].Get("Size").Int())
+		}
+
+		if found := entity.FindLink(link.LinkUID); found != nil {
+			assert.GreaterOrEqual(t, found.LinkUploads, uint(1))
+		}
+
+		r = PerformRequestWithBody(app, "DELETE", uploadsUrl, `{"files": ["../../config/options.yml"]}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Len(t, gjson.Parse(r.Body.String()).Array(), 1)
+
+		r = PerformRequestWithBody(app, "DELETE", uploadsUrl, `{"all": true}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Len(t, gjson.Parse(r.Body.String()).Array(), 0)
+	})
+	t.Run("LinkNotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetAlbumLinkUploads(router)
+		r := PerformRequest(app, "GET", "/api/v1/albums/as6sg6bxpogaaba8/links/ss62xpryd1ob4gtf/uploads")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("ApproveEmptySelection", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ApproveAlbumLinkUploads(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/albums/as6sg6bxpogaaba9/links/ss62xpryd1ob4gtf/uploads", `{}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestUploadPathsSize(t *testing.T) {
+	dir := t.TempDir()
+	fileName := filepath.Join(dir, "a.txt")
+	assert.NoError(t, os.WriteFile(fileName, bytes.Repeat([]byte("a"), 100), 0o600))
+	assert.Equal(t, int64(100), UploadPathsSize([]string{fileName, filepath.Join(dir, "missing.txt")}))
+}
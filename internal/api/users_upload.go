@@ -1,7 +1,9 @@
 package api
 
 import (
+	"errors"
 	"fmt"
+	"mime/multipart"
 	"net/http"
 	"os"
 	"path"
@@ -85,11 +87,20 @@ func UploadUserFiles(router *gin.RouterGroup) {
 			return
 		}
 
+		files := f.File["files"]
+
+		// Abort if the uploaded files would exceed the storage quota of the user.
+		if quotaErr := query.CheckUserQuota(s.GetUser(), UploadSize(files), len(files)); errors.Is(quotaErr, entity.ErrQuotaExceeded) {
+			event.AuditErr([]string{ClientIP(c), "session %s", "upload files", clean.Error(quotaErr)}, s.RefID)
+			AbortStorageQuotaExceeded(c, quotaErr)
+			return
+		} else if quotaErr != nil {
+			log.Warnf("upload: %s (check quota)", quotaErr)
+		}
+
 		// Publish upload start event.
 		event.Publish("upload.start", event.Data{"uid": s.UserUID, "time": start})
 
-		files := f.File["files"]
-
 		var uploads []string
 
 		// Compose upload path.
@@ -198,38 +209,9 @@ func UploadUserFiles(router *gin.RouterGroup) {
 		}
 
 		// Check if the uploaded file may contain inappropriate content.
-		if len(uploads) > 0 && !conf.UploadNSFW() {
-			containsNSFW := false
-
-			for _, filename := range uploads {
-				labels, nsfwErr := vision.DetectNSFW([]string{filename}, media.SrcLocal)
-
-				switch {
-				case nsfwErr != nil:
-					log.Debug(nsfwErr)
-					continue
-				case len(labels) < 1:
-					log.Errorf("nsfw: model returned no result")
-					continue
-				case labels[0].IsSafe():
-					continue
-				}
-
-				log.Infof("nsfw: %s might be offensive", clean.Log(filename))
-
-				containsNSFW = true
-			}
-
-			if containsNSFW {
-				for _, filename := range uploads {
-					if err := os.Remove(filename); err != nil {
-						log.Errorf("nsfw: could not delete %s", clean.Log(filename))
-					}
-				}
-
-				Abort(c, http.StatusForbidden, i18n.ErrOffensiveUpload)
-				return
-			}
+		if len(uploads) > 0 && !conf.UploadNSFW() && UploadRemoveNSFW(uploads) {
+			Abort(c, http.StatusForbidden, i18n.ErrOffensiveUpload)
+			return
 		}
 
 		elapsed := int(time.Since(start).Seconds())
@@ -243,6 +225,51 @@ func UploadUserFiles(router *gin.RouterGroup) {
 	})
 }
 
+// UploadSize returns the total size of the uploaded files in bytes.
+func UploadSize(files []*multipart.FileHeader) (size int64) {
+	for _, file := range files {
+		size += file.Size
+	}
+
+	return size
+}
+
+// UploadRemoveNSFW deletes all uploaded files and returns true if any of them might contain inappropriate content.
+func UploadRemoveNSFW(uploads []string) bool {
+	containsNSFW := false
+
+	for _, filename := range uploads {
+		labels, nsfwErr := vision.DetectNSFW([]string{filename}, media.SrcLocal)
+
+		switch {
+		case nsfwErr != nil:
+			log.Debug(nsfwErr)
+			continue
+		case len(labels) < 1:
+			log.Errorf("nsfw: model returned no result")
+			continue
+		case labels[0].IsSafe():
+			continue
+		}
+
+		log.Infof("nsfw: %s might be offensive", clean.Log(filename))
+
+		containsNSFW = true
+	}
+
+	if !containsNSFW {
+		return false
+	}
+
+	for _, filename := range uploads {
+		if err := os.Remove(filename); err != nil {
+			log.Errorf("nsfw: could not delete %s", clean.Log(filename))
+		}
+	}
+
+	return true
+}
+
 // UploadCheckFile checks if the file is supported and has the correct extension.
 func UploadCheckFile(destName string, rejectRaw bool, totalSizeLimit int64) (remainingSizeLimit int64, err error) {
 	baseName := filepath.Base(destName)
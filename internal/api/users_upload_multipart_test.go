@@ -321,6 +321,36 @@ func TestUploadUserFiles_Multipart_TotalLimitExceeded(t *testing.T) {
 	assert.LessOrEqual(t, len(files), 1)
 }
 
+func TestUploadUserFiles_Multipart_UserQuotaExceeded(t *testing.T) {
+	app, router, conf := NewApiTest()
+	conf.Options().UploadAllow = "jpg"
+	UploadUserFiles(router)
+	token := AuthenticateAdmin(app, router)
+
+	adminUid := entity.Admin.UserUID
+	defer removeUploadDirsForToken(t, filepath.Join(conf.UserStoragePath(adminUid), "upload"), "quota1")
+
+	quota, err := entity.SetQuota(entity.QuotaUser, adminUid, 1024, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = quota.Delete() }()
+
+	body, ctype, err := buildMultipart(map[string][]byte{"big.jpg": bytes.Repeat([]byte("A"), 4096)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users/"+adminUid+"/upload/quota1", body)
+	req.Header.Set("Content-Type", ctype)
+	header.SetAuthorization(req, token)
+	w := httptest.NewRecorder()
+	app.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusInsufficientStorage, w.Code)
+	// Ensure nothing saved
+	files := findUploadedFilesForToken(t, filepath.Join(conf.UserStoragePath(adminUid), "upload"), "quota1")
+	assert.Empty(t, files)
+}
+
 func TestUploadUserFiles_Multipart_ZipPartialExtraction(t *testing.T) {
 	app, router, conf := NewApiTest()
 	conf.Options().UploadArchives = true
@@ -2,6 +2,7 @@ package api
 
 import (
 	"fmt"
+	"mime/multipart"
 	"net/http"
 	"os"
 	"path/filepath"
@@ -106,3 +107,8 @@ func TestUploadCheckFile_SizeAccounting(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, int64(1), rem)
 }
+
+func TestUploadSize(t *testing.T) {
+	assert.Equal(t, int64(0), UploadSize(nil))
+	assert.Equal(t, int64(3072), UploadSize([]*multipart.FileHeader{{Size: 1024}, {Size: 2048}}))
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/internal/ffmpeg/encode"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/photoprism/get"
 	"github.com/photoprism/photoprism/pkg/clean"
@@ -29,7 +30,7 @@ import (
 //	@Failure		403		{object}	i18n.Response
 //	@Param			thumb	path		string	true	"SHA1 video file hash"
 //	@Param			token	path		string	true	"user-specific security token provided with session"
-//	@Param			format	path		string	true	"video format, e.g. mp4"
+//	@Param			format	path		string	true	"video format, e.g. mp4, or avc, hvc, and av1 to negotiate the transcoding codec"
 //	@Router			/api/v1/videos/{hash}/{token}/{format} [get]
 func GetVideo(router *gin.RouterGroup) {
 	router.GET("/videos/:hash/:token/:format", func(c *gin.Context) {
@@ -164,14 +165,29 @@ func GetVideo(router *gin.RouterGroup) {
 
 			conv := get.Convert()
 
-			if avcFile, avcErr := conv.ToAvc(mediaFile, get.Config().FFmpegEncoder(), false, false); avcFile != nil && avcErr == nil {
-				videoFileName = avcFile.FileName()
-				AddContentTypeHeader(c, header.ContentTypeMp4AvcMain)
-			} else {
-				// Log error and default to 404.mp4
-				log.Errorf("video: failed to transcode %s", clean.Log(f.FileName))
-				AbortVideo(c)
-				return
+			transcoded := false
+
+			// Transcode to the smallest format supported by the client, and fall back to AVC if it fails.
+			if codec := conf.FFmpegTranscodeCodec(formatName); codec != encode.CodecAvc {
+				if videoFile, videoErr := conv.ToVideo(mediaFile, f.FileHash, codec, false); videoFile != nil && videoErr == nil {
+					videoFileName = videoFile.FileName()
+					transcoded = true
+					AddContentTypeHeader(c, codec.ContentType())
+				} else {
+					log.Warnf("video: failed to transcode %s to %s, using %s instead", clean.Log(f.FileName), codec, encode.CodecAvc)
+				}
+			}
+
+			if !transcoded {
+				if avcFile, avcErr := conv.ToAvc(mediaFile, conf.FFmpegEncoder(), false, false); avcFile != nil && avcErr == nil {
+					videoFileName = avcFile.FileName()
+					AddContentTypeHeader(c, header.ContentTypeMp4AvcMain)
+				} else {
+					// Log error and default to 404.mp4
+					log.Errorf("video: failed to transcode %s", clean.Log(f.FileName))
+					AbortVideo(c)
+					return
+				}
 			}
 		} else {
 			var contentType string
@@ -0,0 +1,197 @@
+package api
+
+import (
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/internal/ffmpeg/encode"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/photoprism/get"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/http/header"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// GetVideoHls returns the HLS master playlist of a video for adaptive streaming.
+//
+//	@Summary	returns the HLS master playlist of a video for adaptive streaming
+//	@Id			GetVideoHls
+//	@Produce	application/vnd.apple.mpegurl
+//	@Tags		Files, Videos
+//	@Failure	403,404	{object}	i18n.Response
+//	@Param		hash	path		string	true	"SHA1 video file hash"
+//	@Param		token	path		string	true	"user-specific security token provided with session"
+//	@Router		/api/v1/videos/{hash}/{token}/hls/master.m3u8 [get]
+func GetVideoHls(router *gin.RouterGroup) {
+	router.GET("/videos/:hash/:token/hls/"+encode.HlsMasterPlaylist, func(c *gin.Context) {
+		f := findHlsVideo(c)
+
+		if f == nil {
+			return
+		}
+
+		data, err := photoprism.NewHls(get.Config()).MasterPlaylist(f.FileHash, f.FileWidth, f.FileHeight)
+
+		if err != nil {
+			log.Errorf("video: %s", err)
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+
+		header.SetCacheControl(c, -1, false)
+		c.Data(http.StatusOK, header.ContentTypeM3u8, data)
+	})
+}
+
+// GetVideoHlsFile returns an HLS stream variant playlist or segment, which are created on demand.
+//
+//	@Summary	returns an HLS stream variant playlist or segment, which are created on demand
+//	@Id			GetVideoHlsFile
+//	@Produce	application/vnd.apple.mpegurl,video/mp2t
+//	@Tags		Files, Videos
+//	@Failure	403,404	{object}	i18n.Response
+//	@Param		hash		path		string	true	"SHA1 video file hash"
+//	@Param		token		path		string	true	"user-specific security token provided with session"
+//	@Param		rendition	path		string	true	"stream variant size, e.g. 1280"
+//	@Param		file		path		string	true	"playlist or segment filename, e.g. index.m3u8"
+//	@Router		/api/v1/videos/{hash}/{token}/hls/{rendition}/{file} [get]
+func GetVideoHlsFile(router *gin.RouterGroup) {
+	router.GET("/videos/:hash/:token/hls/:rendition/:file", func(c *gin.Context) {
+		rendition, ok := encode.FindHlsRendition(clean.Token(c.Param("rendition")))
+
+		if !ok {
+			log.Debugf("video: invalid hls stream %s", clean.Log(c.Param("rendition")))
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+
+		f := findHlsVideo(c)
+
+		if f == nil {
+			return
+		}
+
+		conf := get.Config()
+		hls := photoprism.NewHls(conf)
+		fileName := c.Param("file")
+
+		// Return the stream variant playlist, and start segmenting the video if needed.
+		if fileName == encode.HlsPlaylist {
+			playlistName, err := hls.Playlist(c.Request.Context(), photoprism.FileName(f.FileRoot, f.FileName), f.FileHash, rendition)
+
+			// Nothing to respond if the client has gone away while waiting.
+			if c.Request.Context().Err() != nil {
+				return
+			} else if err != nil {
+				log.Errorf("video: %s", err)
+				c.AbortWithStatus(http.StatusNotFound)
+				return
+			}
+
+			data, err := os.ReadFile(playlistName) //nolint:gosec // path derived from cache directory
+
+			if err != nil {
+				log.Errorf("video: %s", err)
+				c.AbortWithStatus(http.StatusNotFound)
+				return
+			}
+
+			// Clients must reload the playlist until all segments have been created.
+			if strings.Contains(string(data), encode.HlsPlaylistEnd) {
+				AddVideoCacheHeader(c, conf.CdnVideo())
+			} else {
+				header.SetCacheControl(c, -1, false)
+			}
+
+			c.Data(http.StatusOK, header.ContentTypeM3u8, data)
+			return
+		}
+
+		// Return the requested segment.
+		segmentName, err := hls.Segment(c.Request.Context(), f.FileHash, rendition, fileName)
+
+		if c.Request.Context().Err() != nil {
+			return
+		} else if err != nil {
+			log.Debugf("video: %s", err)
+			c.AbortWithStatus(http.StatusNotFound)
+			return
+		}
+
+		AddVideoCacheHeader(c, conf.CdnVideo())
+		AddContentTypeHeader(c, header.ContentTypeM2TS)
+		c.File(segmentName)
+	})
+}
+
+// findHlsVideo returns the indexed video file for an HLS request, or aborts the request and returns nil.
+func findHlsVideo(c *gin.Context) *entity.File {
+	fileHash := clean.Token(c.Param("hash"))
+
+	// Check if a valid security token was provided.
+	if InvalidPreviewToken(c) {
+		c.AbortWithStatus(http.StatusForbidden)
+		return nil
+	}
+
+	// Check if a valid file hash was provided.
+	if !rnd.IsSHA(fileHash) {
+		log.Debugf("video: invalid file hash %s", clean.Log(fileHash))
+		c.AbortWithStatus(http.StatusNotFound)
+		return nil
+	}
+
+	// Check if adaptive streaming is enabled.
+	if get.Config().DisableHLS() {
+		log.Debugf("video: hls streaming is disabled")
+		c.AbortWithStatus(http.StatusNotFound)
+		return nil
+	}
+
+	// Find media file by SHA hash.
+	f, err := query.FileByHash(fileHash)
+
+	if err != nil {
+		log.Errorf("video: requested file not found (%s)", err)
+		c.AbortWithStatus(http.StatusNotFound)
+		return nil
+	}
+
+	// If file is not a video, try to find the related video file.
+	if !f.FileVideo {
+		if f, err = query.VideoByPhotoUID(f.PhotoUID); err != nil {
+			log.Errorf("video: no playable file found (%s)", err)
+			c.AbortWithStatus(http.StatusNotFound)
+			return nil
+		}
+	}
+
+	// Videos embedded in live photos are too short to benefit from adaptive streaming.
+	if f.MediaType == entity.MediaLive {
+		log.Debugf("video: hls streaming is not supported for live photos")
+		c.AbortWithStatus(http.StatusNotFound)
+		return nil
+	} else if f.FileError != "" {
+		log.Errorf("video: file has error %s", f.FileError)
+		c.AbortWithStatus(http.StatusNotFound)
+		return nil
+	} else if f.FileHash == "" {
+		log.Errorf("video: file hash missing in index")
+		c.AbortWithStatus(http.StatusNotFound)
+		return nil
+	}
+
+	// Videos that can be streamed directly are not segmented, so that clients fall back to the original.
+	if !photoprism.NewHls(get.Config()).Needed(f.ContentType(), f.Bitrate()) {
+		log.Debugf("video: %s can be streamed without hls", clean.Log(f.FileName))
+		c.AbortWithStatus(http.StatusNotFound)
+		return nil
+	}
+
+	return f
+}
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+func TestGetVideoHls(t *testing.T) {
+	t.Run("InvalidHash", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetVideo(router)
+		GetVideoHls(router)
+		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6/"+conf.PreviewToken()+"/hls/master.m3u8")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetVideoHls(router)
+		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6acc5c5c2965ddf6ec465ca42fd831/"+conf.PreviewToken()+"/hls/master.m3u8")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("InvalidToken", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+		GetVideoHls(router)
+		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6acc5c5c2965ddf6ec465ca42fd832/xxx/hls/master.m3u8")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
+
+func TestGetVideoHlsFile(t *testing.T) {
+	t.Run("InvalidRendition", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetVideo(router)
+		GetVideoHls(router)
+		GetVideoHlsFile(router)
+		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6acc5c5c2965ddf6ec465ca42fd831/"+conf.PreviewToken()+"/hls/1000/index.m3u8")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		GetVideoHlsFile(router)
+		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6acc5c5c2965ddf6ec465ca42fd831/"+conf.PreviewToken()+"/hls/720/segment_00000.ts")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+	t.Run("InvalidToken", func(t *testing.T) {
+		app, router, conf := NewApiTest()
+		conf.SetAuthMode(config.AuthModePasswd)
+		defer conf.SetAuthMode(config.AuthModePublic)
+		GetVideoHlsFile(router)
+		r := PerformRequest(app, "GET", "/api/v1/videos/acad9168fa6acc5c5c2965ddf6ec465ca42fd832/xxx/hls/720/index.m3u8")
+		assert.Equal(t, http.StatusForbidden, r.Code)
+	})
+}
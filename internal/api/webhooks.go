@@ -0,0 +1,368 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/auth/acl"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/photoprism/get"
+	"github.com/photoprism/photoprism/internal/workers"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/http/header"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// WebhookResponse represents a newly registered webhook including its signing secret,
+// which is only returned once and cannot be retrieved later.
+type WebhookResponse struct {
+	entity.Webhook
+	Secret string `json:"Secret"`
+}
+
+// SearchWebhooks finds registered webhooks and returns them as JSON.
+//
+//	@Summary	finds registered webhooks and returns them as JSON
+//	@Id			SearchWebhooks
+//	@Tags		Webhooks
+//	@Produce	json
+//	@Success	200				{object}	entity.Webhooks
+//	@Failure	400,401,403,429	{object}	i18n.Response
+//	@Param		count			query		int		false	"maximum number of results"	minimum(1)	maximum(100000)
+//	@Param		offset			query		int		false	"search result offset"		minimum(0)	maximum(100000)
+//	@Param		q				query		string	false	"search query"
+//	@Router		/api/v1/webhooks [get]
+func SearchWebhooks(router *gin.RouterGroup) {
+	router.GET("/webhooks", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceWebhooks, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+
+		if conf.Demo() || conf.DisableSettings() {
+			AbortForbidden(c)
+			return
+		}
+
+		limit := txt.Int(c.Query("count"))
+		offset := txt.Int(c.Query("offset"))
+
+		result, err := query.Webhooks(limit, offset, c.Query("q"))
+
+		if err != nil {
+			AbortBadRequest(c, err)
+			return
+		}
+
+		AddCountHeader(c, len(result))
+		AddLimitHeader(c, limit)
+		AddOffsetHeader(c, offset)
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// GetWebhook returns the specified webhook as JSON.
+//
+//	@Summary	returns the specified webhook as JSON
+//	@Id			GetWebhook
+//	@Tags		Webhooks
+//	@Produce	json
+//	@Success	200				{object}	entity.Webhook
+//	@Failure	401,403,404,429	{object}	i18n.Response
+//	@Param		uid				path		string	true	"webhook uid"
+//	@Router		/api/v1/webhooks/{uid} [get]
+func GetWebhook(router *gin.RouterGroup) {
+	router.GET("/webhooks/:uid", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceWebhooks, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+
+		if conf.Demo() || conf.DisableSettings() {
+			AbortForbidden(c)
+			return
+		}
+
+		m := entity.FindWebhook(clean.UID(c.Param("uid")))
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// AddWebhook registers a new webhook endpoint.
+//
+//	@Summary	registers a new webhook endpoint, the response includes the signing secret
+//	@Id			AddWebhook
+//	@Tags		Webhooks
+//	@Accept		json
+//	@Produce	json
+//	@Success	201				{object}	api.WebhookResponse
+//	@Failure	400,401,403,429	{object}	i18n.Response
+//	@Param		webhook			body		form.Webhook	true	"properties of the webhook to be created"
+//	@Router		/api/v1/webhooks [post]
+func AddWebhook(router *gin.RouterGroup) {
+	router.POST("/webhooks", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceWebhooks, acl.ActionCreate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+
+		if conf.Demo() || conf.DisableSettings() {
+			AbortForbidden(c)
+			return
+		}
+
+		var frm form.Webhook
+
+		// Assign and validate request form values.
+		if err := c.BindJSON(&frm); err != nil {
+			AbortBadRequest(c, err)
+			return
+		}
+
+		m, err := entity.AddWebhook(frm)
+
+		if err != nil {
+			AbortBadRequest(c, err)
+			return
+		}
+
+		log.Infof("webhooks: registered %s", clean.Log(m.WebhookUID))
+
+		// Return new webhook with location header.
+		header.SetLocation(c, c.FullPath(), m.WebhookUID)
+		c.JSON(http.StatusCreated, WebhookResponse{Webhook: *m, Secret: m.WebhookSecret})
+	})
+}
+
+// UpdateWebhook updates the settings of a webhook.
+//
+//	@Summary	updates the settings of a webhook
+//	@Id			UpdateWebhook
+//	@Tags		Webhooks
+//	@Accept		json
+//	@Produce	json
+//	@Success	200					{object}	entity.Webhook
+//	@Failure	400,401,403,404,429	{object}	i18n.Response
+//	@Param		uid					path		string			true	"webhook uid"
+//	@Param		webhook				body		form.Webhook	true	"properties to be updated (only submit values that should be changed)"
+//	@Router		/api/v1/webhooks/{uid} [put]
+func UpdateWebhook(router *gin.RouterGroup) {
+	router.PUT("/webhooks/:uid", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceWebhooks, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+
+		if conf.Demo() || conf.DisableSettings() {
+			AbortForbidden(c)
+			return
+		}
+
+		m := entity.FindWebhook(clean.UID(c.Param("uid")))
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		// 1) Init form with model values
+		frm, err := form.NewWebhook(m)
+
+		if err != nil {
+			log.Error(err)
+			AbortSaveFailed(c)
+			return
+		}
+
+		// 2) Update form with values from request
+		if err = c.BindJSON(&frm); err != nil {
+			AbortBadRequest(c, err)
+			return
+		}
+
+		// 3) Save model with values from form
+		if err = m.SaveForm(frm); err != nil {
+			AbortBadRequest(c, err)
+			return
+		}
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// DeleteWebhook removes a webhook, its delivery log is kept.
+//
+//	@Summary	removes a webhook
+//	@Id			DeleteWebhook
+//	@Tags		Webhooks
+//	@Produce	json
+//	@Success	200					{object}	entity.Webhook
+//	@Failure	401,403,404,429,500	{object}	i18n.Response
+//	@Param		uid					path		string	true	"webhook uid"
+//	@Router		/api/v1/webhooks/{uid} [delete]
+func DeleteWebhook(router *gin.RouterGroup) {
+	router.DELETE("/webhooks/:uid", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceWebhooks, acl.ActionDelete)
+
+		if s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+
+		if conf.Demo() || conf.DisableSettings() {
+			AbortForbidden(c)
+			return
+		}
+
+		m := entity.FindWebhook(clean.UID(c.Param("uid")))
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		if err := m.Delete(); err != nil {
+			log.Errorf("webhooks: %s", clean.Error(err))
+			AbortDeleteFailed(c)
+			return
+		}
+
+		c.JSON(http.StatusOK, m)
+	})
+}
+
+// GetWebhookDeliveries returns the delivery log of a webhook as JSON, newest first.
+//
+//	@Summary	returns the delivery log of a webhook as JSON
+//	@Id			GetWebhookDeliveries
+//	@Tags		Webhooks
+//	@Produce	json
+//	@Success	200					{object}	entity.WebhookDeliveries
+//	@Failure	400,401,403,404,429	{object}	i18n.Response
+//	@Param		uid					path		string	true	"webhook uid"
+//	@Param		status				query		string	false	"delivery status"	Enums(pending, delivered, failed)
+//	@Param		count				query		int		false	"maximum number of results"	minimum(1)	maximum(100000)
+//	@Param		offset				query		int		false	"search result offset"		minimum(0)	maximum(100000)
+//	@Router		/api/v1/webhooks/{uid}/deliveries [get]
+func GetWebhookDeliveries(router *gin.RouterGroup) {
+	router.GET("/webhooks/:uid/deliveries", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceWebhooks, acl.ActionView)
+
+		if s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+
+		if conf.Demo() || conf.DisableSettings() {
+			AbortForbidden(c)
+			return
+		}
+
+		m := entity.FindWebhook(clean.UID(c.Param("uid")))
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		limit := txt.Int(c.Query("count"))
+		offset := txt.Int(c.Query("offset"))
+
+		result, err := query.WebhookDeliveries(m.WebhookUID, clean.TypeLowerUnderscore(c.Query("status")), limit, offset)
+
+		if err != nil {
+			AbortBadRequest(c, err)
+			return
+		}
+
+		AddCountHeader(c, len(result))
+		AddLimitHeader(c, limit)
+		AddOffsetHeader(c, offset)
+
+		c.JSON(http.StatusOK, result)
+	})
+}
+
+// ReplayWebhookDeliveries resends failed deliveries of a webhook.
+//
+//	@Summary	resends the specified failed deliveries of a webhook, or all failed deliveries if none are specified
+//	@Id			ReplayWebhookDeliveries
+//	@Tags		Webhooks
+//	@Accept		json
+//	@Produce	json
+//	@Success	200					{object}	entity.WebhookDeliveries
+//	@Failure	400,401,403,404,429	{object}	i18n.Response
+//	@Param		uid					path		string				true	"webhook uid"
+//	@Param		deliveries			body		form.WebhookReplay	false	"delivery ids"
+//	@Router		/api/v1/webhooks/{uid}/replay [post]
+func ReplayWebhookDeliveries(router *gin.RouterGroup) {
+	router.POST("/webhooks/:uid/replay", func(c *gin.Context) {
+		s := Auth(c, acl.ResourceWebhooks, acl.ActionUpdate)
+
+		if s.Abort(c) {
+			return
+		}
+
+		conf := get.Config()
+
+		if conf.Demo() || conf.DisableSettings() {
+			AbortForbidden(c)
+			return
+		}
+
+		m := entity.FindWebhook(clean.UID(c.Param("uid")))
+
+		if m == nil {
+			AbortEntityNotFound(c)
+			return
+		}
+
+		var frm form.WebhookReplay
+
+		// The request body is optional.
+		if c.Request.ContentLength > 0 {
+			if err := c.BindJSON(&frm); err != nil {
+				AbortBadRequest(c, err)
+				return
+			}
+		}
+
+		result, err := m.Replay(frm.Deliveries)
+
+		if err != nil {
+			log.Errorf("webhooks: %s", clean.Error(err))
+			AbortSaveFailed(c)
+			return
+		}
+
+		if len(result) > 0 {
+			workers.RunWebhooks(conf)
+		}
+
+		c.JSON(http.StatusOK, result)
+	})
+}
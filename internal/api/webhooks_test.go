@@ -0,0 +1,118 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tidwall/gjson"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/service/webhook"
+)
+
+func TestSearchWebhooks(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		SearchWebhooks(router)
+		r := PerformRequest(app, "GET", "/api/v1/webhooks?count=10&q=Automation")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "wt2hdkz1vgdb3ta2", gjson.Get(r.Body.String(), "0.UID").String())
+		assert.False(t, gjson.Get(r.Body.String(), "0.Secret").Exists())
+	})
+}
+
+func TestGetWebhook(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetWebhook(router)
+		r := PerformRequest(app, "GET", "/api/v1/webhooks/wt2hdkz1vgdb3ta2")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "Automation", gjson.Get(r.Body.String(), "Name").String())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetWebhook(router)
+		r := PerformRequest(app, "GET", "/api/v1/webhooks/wt2hdkz1vgdb3xxx")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestAddWebhook(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AddWebhook(router)
+		UpdateWebhook(router)
+		DeleteWebhook(router)
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/webhooks", `{"Name": "API Test", "URL": "https://example.com/api-test", "Events": "photos.created", "Enabled": true}`)
+		assert.Equal(t, http.StatusCreated, r.Code)
+
+		uid := gjson.Get(r.Body.String(), "UID").String()
+
+		assert.Contains(t, gjson.Get(r.Body.String(), "Secret").String(), "whsec_")
+		assert.Equal(t, "/api/v1/webhooks/"+uid, r.Header().Get("Location"))
+
+		r = PerformRequestWithBody(app, "PUT", "/api/v1/webhooks/"+uid, `{"Events": "albums.*", "Enabled": false}`)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, "albums.created,albums.updated,albums.deleted", gjson.Get(r.Body.String(), "Events").String())
+		assert.Equal(t, "API Test", gjson.Get(r.Body.String(), "Name").String())
+		assert.False(t, gjson.Get(r.Body.String(), "Enabled").Bool())
+
+		r = PerformRequestWithBody(app, "PUT", "/api/v1/webhooks/"+uid, `{"URL": "file:///etc/passwd"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+
+		r = PerformRequest(app, "DELETE", "/api/v1/webhooks/"+uid)
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Nil(t, entity.FindWebhook(uid))
+	})
+	t.Run("BadRequest", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		AddWebhook(router)
+		r := PerformRequestWithBody(app, "POST", "/api/v1/webhooks", `{"URL": "example.com"}`)
+		assert.Equal(t, http.StatusBadRequest, r.Code)
+	})
+}
+
+func TestGetWebhookDeliveries(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetWebhookDeliveries(router)
+		r := PerformRequest(app, "GET", "/api/v1/webhooks/wt2hdkz1vgdb3ta2/deliveries?status=delivered")
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.LessOrEqual(t, int64(1), gjson.Get(r.Body.String(), "#").Int())
+		assert.Equal(t, "delivered", gjson.Get(r.Body.String(), "0.Status").String())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		GetWebhookDeliveries(router)
+		r := PerformRequest(app, "GET", "/api/v1/webhooks/wt2hdkz1vgdb3xxx/deliveries")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
+
+func TestReplayWebhookDeliveries(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ReplayWebhookDeliveries(router)
+
+		m := entity.NewWebhookDelivery("wt2hdl31ydq9yw0k", webhook.AlbumsDeleted, []byte(`{}`))
+		m.Status = entity.WebhookFailed
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		r := PerformRequestWithBody(app, "POST", "/api/v1/webhooks/wt2hdl31ydq9yw0k/replay", fmt.Sprintf(`{"Deliveries": [%d]}`, m.ID))
+		assert.Equal(t, http.StatusOK, r.Code)
+		assert.Equal(t, int64(1), gjson.Get(r.Body.String(), "#").Int())
+		assert.Equal(t, entity.WebhookPending, entity.FindWebhookDelivery(m.ID).Status)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		app, router, _ := NewApiTest()
+		ReplayWebhookDeliveries(router)
+		r := PerformRequest(app, "POST", "/api/v1/webhooks/wt2hdkz1vgdb3xxx/replay")
+		assert.Equal(t, http.StatusNotFound, r.Code)
+	})
+}
@@ -56,6 +56,7 @@ const (
 	ResourceVideos    Resource = "videos"
 	ResourceFavorites Resource = "favorites"
 	ResourceAlbums    Resource = "albums"
+	ResourceComments  Resource = "comments"
 	ResourceCalendar  Resource = "calendar"
 	ResourceMoments   Resource = "moments"
 	ResourcePeople    Resource = "people"
@@ -72,6 +73,7 @@ const (
 	ResourceApi       Resource = "api"
 	ResourceWebDAV    Resource = "webdav"
 	ResourceWebhooks  Resource = "webhooks"
+	ResourceJobs      Resource = "jobs"
 	ResourceMetrics   Resource = "metrics"
 	ResourceVision    Resource = "vision"
 	ResourceCluster   Resource = "cluster"
@@ -96,6 +98,7 @@ const (
 	ChannelLenses    Resource = "lenses"
 	ChannelCountries Resource = "countries"
 	ChannelAlbums    Resource = "albums"
+	ChannelComments  Resource = "comments"
 	ChannelLabels    Resource = "labels"
 	ChannelSubjects  Resource = "subjects"
 	ChannelPeople    Resource = "people"
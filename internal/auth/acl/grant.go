@@ -107,6 +107,14 @@ var (
 		ActionDownload: true,
 		ActionReact:    true,
 	}
+	GrantCommentShared = Grant{
+		AccessShared: true,
+		AccessOwn:    true,
+		ActionView:   true,
+		ActionCreate: true,
+		ActionUpdate: true,
+		ActionDelete: true,
+	}
 	GrantSearchShared = Grant{
 		AccessShared:   true,
 		ActionSearch:   true,
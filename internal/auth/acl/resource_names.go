@@ -9,6 +9,7 @@ var ResourceNames = []Resource{
 	ResourceVideos,
 	ResourceFavorites,
 	ResourceAlbums,
+	ResourceComments,
 	ResourceMoments,
 	ResourceCalendar,
 	ResourcePeople,
@@ -25,6 +26,7 @@ var ResourceNames = []Resource{
 	ResourceApi,
 	ResourceWebDAV,
 	ResourceWebhooks,
+	ResourceJobs,
 	ResourceMetrics,
 	ResourceVision,
 	ResourceCluster,
@@ -28,6 +28,15 @@ var Rules = ACL{
 		RoleClient: GrantFullAccess,
 	},
 	ResourceAlbums: GrantDefaults,
+	ResourceComments: Roles{
+		RoleAdmin:   GrantFullAccess,
+		RoleGuest:   GrantCommentShared,
+		RoleVisitor: GrantCommentShared,
+		RoleApp:     GrantViewShared,
+		RoleService: GrantViewShared,
+		RolePortal:  GrantFullAccess,
+		RoleClient:  GrantFullAccess,
+	},
 	ResourceMoments: Roles{
 		RoleAdmin:   GrantFullAccess,
 		RoleGuest:   GrantSearchShared,
@@ -115,6 +124,10 @@ var Rules = ACL{
 		RoleAdmin:  GrantFullAccess,
 		RoleClient: GrantPublishOwn,
 	},
+	ResourceJobs: Roles{
+		RoleAdmin:  GrantFullAccess,
+		RolePortal: GrantFullAccess,
+	},
 	ResourceMetrics: Roles{
 		RoleAdmin:   GrantFullAccess,
 		RoleApp:     GrantNone,
@@ -12,6 +12,7 @@ var ScopeDescriptions = map[string]string{
 	ResourceVideos.String():    "Operate on video library items and metadata.",
 	ResourceFavorites.String(): "Manage the favorites collection.",
 	ResourceAlbums.String():    "Create and manage albums.",
+	ResourceComments.String():  "View and write comments on photos and albums.",
 	ResourceMoments.String():   "Access automatically grouped moments and events.",
 	ResourceCalendar.String():  "Access calendar-based timelines.",
 	ResourcePeople.String():    "Manage people records and face assignments.",
@@ -28,6 +29,7 @@ var ScopeDescriptions = map[string]string{
 	ResourceApi.String():       "Call generic API endpoints outside other scopes.",
 	ResourceWebDAV.String():    "Access the WebDAV interface for syncing files.",
 	ResourceWebhooks.String():  "Manage webhook subscriptions and deliveries.",
+	ResourceJobs.String():      "Manage scheduled jobs and inspect their run history.",
 	ResourceMetrics.String():   "Read operational metrics for monitoring.",
 	ResourceVision.String():    "Use AI vision endpoints and related queues.",
 	ResourceCluster.String():   "Manage cluster registration and node state.",
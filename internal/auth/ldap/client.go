@@ -0,0 +1,141 @@
+package ldap
+
+import (
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	goldap "github.com/go-ldap/ldap/v3"
+
+	"github.com/photoprism/photoprism/pkg/authn"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+var (
+	// ErrNotConfigured is returned if no directory server has been configured.
+	ErrNotConfigured = errors.New("ldap server not configured")
+	// ErrAmbiguousUser is returned if the search filter matches more than one directory entry.
+	ErrAmbiguousUser = errors.New("ldap search returned multiple users")
+)
+
+// Identity represents a directory user whose password has been verified.
+type Identity struct {
+	DN       string
+	Username string
+	Name     string
+	Email    string
+	Groups   []string
+}
+
+// Dial connects to the directory server and, if configured, upgrades the connection with StartTLS.
+func Dial(conf *Config) (*goldap.Conn, error) {
+	if !conf.Enabled() {
+		return nil, ErrNotConfigured
+	}
+
+	timeout := conf.NetTimeout()
+
+	tlsConfig := &tls.Config{
+		ServerName:         conf.Host(),
+		InsecureSkipVerify: conf.Insecure, //nolint:gosec // explicitly enabled for self-signed certificates
+		MinVersion:         tls.VersionTLS12,
+	}
+
+	conn, err := goldap.DialURL(conf.Uri, goldap.DialWithTLSDialer(tlsConfig, &net.Dialer{Timeout: timeout}))
+
+	if err != nil {
+		return nil, err
+	}
+
+	conn.SetTimeout(timeout)
+
+	if conf.StartTLS && conf.Scheme() == "ldap" {
+		if err = conn.StartTLS(tlsConfig); err != nil {
+			conn.Close()
+			return nil, err
+		}
+	}
+
+	return conn, nil
+}
+
+// Authenticate looks up the user in the directory and verifies the password by binding as the user.
+func Authenticate(conf *Config, username, password string) (*Identity, error) {
+	// Empty passwords must be rejected, since servers may accept them as unauthenticated binds.
+	if username = strings.TrimSpace(username); username == "" {
+		return nil, authn.ErrUsernameRequired
+	} else if password == "" {
+		return nil, authn.ErrPasswordRequired
+	}
+
+	conn, err := Dial(conf)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer conn.Close()
+
+	// Bind with the service account, if any, to search for the user.
+	if conf.BindDN != "" {
+		if err = conn.Bind(conf.BindDN, conf.BindPassword); err != nil {
+			return nil, fmt.Errorf("service account bind failed (%s)", err)
+		}
+	}
+
+	groupAttr := conf.GroupAttribute()
+
+	req := goldap.NewSearchRequest(
+		conf.BaseDN,
+		goldap.ScopeWholeSubtree, goldap.NeverDerefAliases, 2, int(conf.NetTimeout().Seconds()), false,
+		conf.SearchFilter(username),
+		[]string{"uid", "sAMAccountName", "userPrincipalName", "cn", "displayName", "mail", groupAttr},
+		nil,
+	)
+
+	result, err := conn.Search(req)
+
+	if err != nil && !goldap.IsErrorWithCode(err, goldap.LDAPResultSizeLimitExceeded) {
+		return nil, err
+	} else if result == nil || len(result.Entries) == 0 {
+		return nil, authn.ErrAccountNotFound
+	} else if len(result.Entries) > 1 {
+		return nil, ErrAmbiguousUser
+	}
+
+	entry := result.Entries[0]
+
+	// Verify the password by binding as the user.
+	if err = conn.Bind(entry.DN, password); goldap.IsErrorWithCode(err, goldap.LDAPResultInvalidCredentials) {
+		return nil, authn.ErrInvalidPassword
+	} else if err != nil {
+		return nil, err
+	}
+
+	identity := &Identity{
+		DN:       entry.DN,
+		Username: firstValue(entry, "uid", "sAMAccountName", "userPrincipalName"),
+		Name:     firstValue(entry, "displayName", "cn"),
+		Email:    clean.Email(entry.GetAttributeValue("mail")),
+		Groups:   GroupsFromValues(entry.GetAttributeValues(groupAttr)),
+	}
+
+	if identity.Username == "" {
+		identity.Username = username
+	}
+
+	return identity, nil
+}
+
+// firstValue returns the first non-empty value of the specified attributes.
+func firstValue(entry *goldap.Entry, attrs ...string) string {
+	for _, attr := range attrs {
+		if v := strings.TrimSpace(entry.GetAttributeValue(attr)); v != "" {
+			return v
+		}
+	}
+
+	return ""
+}
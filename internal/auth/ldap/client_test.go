@@ -0,0 +1,101 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/authn"
+)
+
+func TestAuthenticate(t *testing.T) {
+	srv := newTestServer(t, testEntries()...)
+
+	conf := &Config{
+		Uri:          srv.Uri(),
+		BindDN:       "cn=service,dc=example,dc=com",
+		BindPassword: "service-secret",
+		BaseDN:       "dc=example,dc=com",
+	}
+
+	t.Run("Success", func(t *testing.T) {
+		identity, err := Authenticate(conf, "alice", "alice-secret")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "uid=alice,ou=people,dc=example,dc=com", identity.DN)
+		assert.Equal(t, "alice", identity.Username)
+		assert.Equal(t, "Alice", identity.Name)
+		assert.Equal(t, "alice@example.com", identity.Email)
+		assert.Equal(t, []string{
+			"cn=photoprism admins,ou=groups,dc=example,dc=com",
+			"photoprism admins",
+			"cn=staff,ou=groups,dc=example,dc=com",
+			"staff",
+		}, identity.Groups)
+	})
+	t.Run("ActiveDirectory", func(t *testing.T) {
+		identity, err := Authenticate(conf, "bob", "bob-secret")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "cn=Bob Smith,ou=people,dc=example,dc=com", identity.DN)
+		assert.Equal(t, "bob", identity.Username)
+		assert.Equal(t, "Bob Smith", identity.Name)
+		assert.Equal(t, "", identity.Email)
+		assert.Empty(t, identity.Groups)
+	})
+	t.Run("InvalidPassword", func(t *testing.T) {
+		_, err := Authenticate(conf, "alice", "wrong")
+		assert.ErrorIs(t, err, authn.ErrInvalidPassword)
+	})
+	t.Run("PasswordRequired", func(t *testing.T) {
+		_, err := Authenticate(conf, "alice", "")
+		assert.ErrorIs(t, err, authn.ErrPasswordRequired)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := Authenticate(conf, "mallory", "alice-secret")
+		assert.ErrorIs(t, err, authn.ErrAccountNotFound)
+	})
+	t.Run("FilterInjection", func(t *testing.T) {
+		_, err := Authenticate(conf, "*)(uid=alice", "alice-secret")
+		assert.ErrorIs(t, err, authn.ErrAccountNotFound)
+	})
+	t.Run("InvalidServiceAccount", func(t *testing.T) {
+		c := *conf
+		c.BindPassword = "wrong"
+
+		_, err := Authenticate(&c, "alice", "alice-secret")
+		assert.Error(t, err)
+	})
+	t.Run("StartTLS", func(t *testing.T) {
+		c := *conf
+		c.StartTLS = true
+		c.Insecure = true
+
+		identity, err := Authenticate(&c, "alice", "alice-secret")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "alice", identity.Username)
+	})
+	t.Run("StartTLSUntrusted", func(t *testing.T) {
+		c := *conf
+		c.StartTLS = true
+
+		_, err := Authenticate(&c, "alice", "alice-secret")
+		assert.Error(t, err)
+	})
+	t.Run("NotConfigured", func(t *testing.T) {
+		_, err := Authenticate(&Config{}, "alice", "alice-secret")
+		assert.ErrorIs(t, err, ErrNotConfigured)
+	})
+
+	assert.Contains(t, srv.Binds(), "uid=alice,ou=people,dc=example,dc=com")
+}
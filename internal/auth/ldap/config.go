@@ -0,0 +1,101 @@
+package ldap
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	goldap "github.com/go-ldap/ldap/v3"
+
+	"github.com/photoprism/photoprism/internal/auth/acl"
+)
+
+const (
+	// DefaultFilter matches the username against common user name attributes of OpenLDAP and Active Directory.
+	DefaultFilter = "(|(uid={username})(sAMAccountName={username})(userPrincipalName={username}))"
+	// DefaultGroupAttr is the user attribute that contains the distinguished names of the user's groups.
+	DefaultGroupAttr = "memberOf"
+	// DefaultTimeout is the default network timeout for directory requests.
+	DefaultTimeout = 15 * time.Second
+	// UsernamePlaceholder is replaced with the escaped username in search filters.
+	UsernamePlaceholder = "{username}"
+)
+
+// Config represents the directory server settings and the rules for mapping directory users to accounts.
+type Config struct {
+	Uri          string              // Server URI, e.g. "ldaps://dc.example.com" or "ldap://ldap.example.com:389".
+	StartTLS     bool                // Upgrade unencrypted "ldap://" connections with StartTLS.
+	Insecure     bool                // Skip verification of the server certificate.
+	BindDN       string              // Service account used to search for users, anonymous if empty.
+	BindPassword string              // Service account password.
+	BaseDN       string              // Search base, e.g. "dc=example,dc=com".
+	Filter       string              // User search filter, see DefaultFilter.
+	GroupAttr    string              // User attribute with group memberships, see DefaultGroupAttr.
+	Groups       []string            // Required groups, a user must be a member of at least one if not empty.
+	GroupRoles   map[string]acl.Role // Maps normalized group identifiers to user roles.
+	Role         acl.Role            // Default role of new users if no group role matches.
+	Register     bool                // Create accounts for directory users who sign in for the first time.
+	WebDAV       bool                // Allow new users to use WebDAV if their role permits.
+	Timeout      time.Duration       // Network timeout, see DefaultTimeout.
+}
+
+// Enabled checks if a directory server has been configured.
+func (c *Config) Enabled() bool {
+	if c == nil || c.Uri == "" {
+		return false
+	}
+
+	switch c.Scheme() {
+	case "ldap", "ldaps":
+		return true
+	default:
+		return false
+	}
+}
+
+// Scheme returns the lowercase URI scheme, e.g. "ldaps".
+func (c *Config) Scheme() string {
+	if u, err := url.Parse(c.Uri); err != nil {
+		return ""
+	} else {
+		return strings.ToLower(u.Scheme)
+	}
+}
+
+// Host returns the server hostname, which is used to verify its certificate.
+func (c *Config) Host() string {
+	if u, err := url.Parse(c.Uri); err != nil {
+		return ""
+	} else {
+		return u.Hostname()
+	}
+}
+
+// SearchFilter returns the user search filter for the specified username.
+func (c *Config) SearchFilter(username string) string {
+	filter := strings.TrimSpace(c.Filter)
+
+	if filter == "" {
+		filter = DefaultFilter
+	}
+
+	return strings.ReplaceAll(filter, UsernamePlaceholder, goldap.EscapeFilter(username))
+}
+
+// GroupAttribute returns the name of the user attribute that contains the group memberships.
+func (c *Config) GroupAttribute() string {
+	if attr := strings.TrimSpace(c.GroupAttr); attr != "" {
+		return attr
+	}
+
+	return DefaultGroupAttr
+}
+
+// NetTimeout returns the network timeout for directory requests.
+func (c *Config) NetTimeout() time.Duration {
+	if c.Timeout > 0 {
+		return c.Timeout
+	}
+
+	return DefaultTimeout
+}
@@ -0,0 +1,39 @@
+package ldap
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfig_Enabled(t *testing.T) {
+	var conf *Config
+
+	assert.False(t, conf.Enabled())
+	assert.False(t, (&Config{}).Enabled())
+	assert.False(t, (&Config{Uri: "https://ldap.example.com"}).Enabled())
+	assert.True(t, (&Config{Uri: "ldap://ldap.example.com"}).Enabled())
+	assert.True(t, (&Config{Uri: "LDAPS://dc.example.com:636"}).Enabled())
+}
+
+func TestConfig_Host(t *testing.T) {
+	assert.Equal(t, "dc.example.com", (&Config{Uri: "ldaps://dc.example.com:636"}).Host())
+	assert.Equal(t, "", (&Config{}).Host())
+}
+
+func TestConfig_SearchFilter(t *testing.T) {
+	assert.Equal(t, "(|(uid=alice)(sAMAccountName=alice)(userPrincipalName=alice))", (&Config{}).SearchFilter("alice"))
+	assert.Equal(t, "(&(objectClass=person)(mail=alice@example.com))", (&Config{Filter: "(&(objectClass=person)(mail={username}))"}).SearchFilter("alice@example.com"))
+	assert.Equal(t, "(uid=\\2a\\29\\28uid=\\2a)", (&Config{Filter: "(uid={username})"}).SearchFilter("*)(uid=*"))
+}
+
+func TestConfig_GroupAttribute(t *testing.T) {
+	assert.Equal(t, "memberOf", (&Config{}).GroupAttribute())
+	assert.Equal(t, "groups", (&Config{GroupAttr: " groups "}).GroupAttribute())
+}
+
+func TestConfig_NetTimeout(t *testing.T) {
+	assert.Equal(t, DefaultTimeout, (&Config{}).NetTimeout())
+	assert.Equal(t, time.Second, (&Config{Timeout: time.Second}).NetTimeout())
+}
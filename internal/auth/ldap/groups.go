@@ -0,0 +1,97 @@
+package ldap
+
+import (
+	"strings"
+
+	goldap "github.com/go-ldap/ldap/v3"
+
+	"github.com/photoprism/photoprism/internal/auth/acl"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// NormalizeGroupID lowercases and sanitizes a group identifier (distinguished name or common name).
+func NormalizeGroupID(id string) string {
+	return strings.ToLower(clean.Auth(id))
+}
+
+// GroupsFromValues returns the normalized group identifiers for the distinguished names in a user's
+// group attribute, e.g. "cn=photoprism admins,ou=groups,dc=example,dc=com" and "photoprism admins",
+// so that groups can be configured either by name or by DN.
+func GroupsFromValues(values []string) (groups []string) {
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v == "" {
+			continue
+		}
+
+		if dn, err := goldap.ParseDN(v); err == nil && len(dn.RDNs) > 0 {
+			groups = append(groups, dn.String())
+
+			if attrs := dn.RDNs[0].Attributes; len(attrs) > 0 {
+				groups = append(groups, attrs[0].Value)
+			}
+		} else {
+			groups = append(groups, v)
+		}
+	}
+
+	return uniqueGroups(groups)
+}
+
+// MapGroupsToRole returns the first matching role for the provided groups using the supplied mapping.
+func MapGroupsToRole(groups []string, mapping map[string]acl.Role) (acl.Role, bool) {
+	if len(groups) == 0 || len(mapping) == 0 {
+		return acl.RoleNone, false
+	}
+
+	for _, g := range uniqueGroups(groups) {
+		if role, ok := mapping[g]; ok && role != acl.RoleNone {
+			return role, true
+		}
+	}
+
+	return acl.RoleNone, false
+}
+
+// HasAnyGroup returns true when at least one of the user's groups matches a required group.
+func HasAnyGroup(groups []string, required []string) bool {
+	if len(required) == 0 {
+		return true
+	}
+
+	normalized := make(map[string]struct{}, len(groups))
+
+	for _, g := range uniqueGroups(groups) {
+		normalized[g] = struct{}{}
+	}
+
+	for _, r := range required {
+		if _, ok := normalized[NormalizeGroupID(r)]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// uniqueGroups returns a deduplicated, normalized list of group identifiers.
+func uniqueGroups(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]struct{}, len(values))
+	result := make([]string, 0, len(values))
+
+	for _, v := range values {
+		if v = NormalizeGroupID(v); v == "" {
+			continue
+		} else if _, ok := seen[v]; ok {
+			continue
+		}
+
+		seen[v] = struct{}{}
+		result = append(result, v)
+	}
+
+	return result
+}
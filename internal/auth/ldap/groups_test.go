@@ -0,0 +1,47 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/auth/acl"
+)
+
+func TestGroupsFromValues(t *testing.T) {
+	assert.Nil(t, GroupsFromValues(nil))
+	assert.Equal(t, []string{
+		"cn=admins,ou=groups,dc=example,dc=com",
+		"admins",
+		"editors",
+	}, GroupsFromValues([]string{"CN=Admins,OU=Groups,DC=example,DC=com", " ", "Editors", "admins"}))
+}
+
+func TestMapGroupsToRole(t *testing.T) {
+	mapping := map[string]acl.Role{
+		"admins":                                 acl.RoleAdmin,
+		"cn=viewers,ou=groups,dc=example,dc=com": acl.RoleViewer,
+	}
+
+	role, ok := MapGroupsToRole(GroupsFromValues([]string{"cn=Staff,dc=example,dc=com", "cn=Admins,dc=example,dc=com"}), mapping)
+	assert.True(t, ok)
+	assert.Equal(t, acl.RoleAdmin, role)
+
+	role, ok = MapGroupsToRole(GroupsFromValues([]string{"cn=Viewers,ou=Groups,dc=example,dc=com"}), mapping)
+	assert.True(t, ok)
+	assert.Equal(t, acl.RoleViewer, role)
+
+	role, ok = MapGroupsToRole([]string{"staff"}, mapping)
+	assert.False(t, ok)
+	assert.Equal(t, acl.RoleNone, role)
+}
+
+func TestHasAnyGroup(t *testing.T) {
+	groups := GroupsFromValues([]string{"cn=Staff,ou=groups,dc=example,dc=com"})
+
+	assert.True(t, HasAnyGroup(groups, nil))
+	assert.True(t, HasAnyGroup(groups, []string{"STAFF"}))
+	assert.True(t, HasAnyGroup(groups, []string{"cn=staff,ou=groups,dc=example,dc=com"}))
+	assert.False(t, HasAnyGroup(groups, []string{"admins"}))
+	assert.False(t, HasAnyGroup(nil, []string{"admins"}))
+}
@@ -0,0 +1,268 @@
+package ldap
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	goldap "github.com/go-ldap/ldap/v3"
+)
+
+// testEntry represents a directory entry of the in-process test server.
+type testEntry struct {
+	DN       string
+	Password string
+	Attrs    map[string][]string
+}
+
+// testServer is a minimal in-process LDAP server that supports simple binds, StartTLS, and
+// searches with equality filters, which is sufficient to test the client.
+type testServer struct {
+	t         *testing.T
+	listener  net.Listener
+	tlsConfig *tls.Config
+	entries   []testEntry
+	mu        sync.Mutex
+	binds     []string
+}
+
+// newTestServer starts a new test server on a random local port.
+func newTestServer(t *testing.T, entries ...testEntry) *testServer {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	s := &testServer{t: t, listener: listener, tlsConfig: testTLSConfig(t), entries: entries}
+
+	go s.serve()
+
+	t.Cleanup(func() { _ = listener.Close() })
+
+	return s
+}
+
+// Uri returns the server URI.
+func (s *testServer) Uri() string {
+	return "ldap://" + s.listener.Addr().String()
+}
+
+// Binds returns the distinguished names of all bind requests.
+func (s *testServer) Binds() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string{}, s.binds...)
+}
+
+func (s *testServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+
+		if err != nil {
+			return
+		}
+
+		go s.handle(conn)
+	}
+}
+
+func (s *testServer) handle(conn net.Conn) {
+	defer func() { _ = conn.Close() }()
+
+	for {
+		packet, err := ber.ReadPacket(conn)
+
+		if err != nil || len(packet.Children) < 2 {
+			return
+		}
+
+		id := packet.Children[0].Value.(int64)
+		op := packet.Children[1]
+
+		switch op.Tag {
+		case goldap.ApplicationBindRequest:
+			name := op.Children[1].Value.(string)
+			password := string(op.Children[2].Data.Bytes())
+
+			s.mu.Lock()
+			s.binds = append(s.binds, name)
+			s.mu.Unlock()
+
+			code := uint16(goldap.LDAPResultInvalidCredentials)
+
+			for _, e := range s.entries {
+				if strings.EqualFold(e.DN, name) && e.Password == password {
+					code = goldap.LDAPResultSuccess
+				}
+			}
+
+			s.write(conn, testResult(id, goldap.ApplicationBindResponse, code))
+		case goldap.ApplicationSearchRequest:
+			filter, filterErr := goldap.DecompileFilter(op.Children[6])
+
+			if filterErr != nil {
+				s.write(conn, testResult(id, goldap.ApplicationSearchResultDone, goldap.LDAPResultProtocolError))
+				continue
+			}
+
+			for _, e := range s.entries {
+				if e.Match(filter) {
+					s.write(conn, e.Packet(id))
+				}
+			}
+
+			s.write(conn, testResult(id, goldap.ApplicationSearchResultDone, goldap.LDAPResultSuccess))
+		case goldap.ApplicationExtendedRequest:
+			s.write(conn, testResult(id, goldap.ApplicationExtendedResponse, goldap.LDAPResultSuccess))
+
+			tlsConn := tls.Server(conn, s.tlsConfig)
+
+			if err = tlsConn.Handshake(); err != nil {
+				return
+			}
+
+			conn = tlsConn
+		case goldap.ApplicationUnbindRequest:
+			return
+		default:
+			return
+		}
+	}
+}
+
+func (s *testServer) write(conn net.Conn, packet *ber.Packet) {
+	if _, err := conn.Write(packet.Bytes()); err != nil {
+		s.t.Log(err)
+	}
+}
+
+// Match checks if the entry matches any of the equality assertions in a search filter.
+func (e testEntry) Match(filter string) bool {
+	for attr, values := range e.Attrs {
+		for _, v := range values {
+			if strings.Contains(strings.ToLower(filter), strings.ToLower("("+attr+"="+goldap.EscapeFilter(v)+")")) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// Packet returns the entry as search result.
+func (e testEntry) Packet(id int64) *ber.Packet {
+	packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, "MessageID"))
+
+	entry := ber.Encode(ber.ClassApplication, ber.TypeConstructed, goldap.ApplicationSearchResultEntry, nil, "Entry")
+	entry.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, e.DN, "DN"))
+
+	attrs := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attributes")
+
+	for name, values := range e.Attrs {
+		attr := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attribute")
+		attr.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, name, "Type"))
+
+		vals := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSet, nil, "Values")
+
+		for _, v := range values {
+			vals.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, v, "Value"))
+		}
+
+		attr.AppendChild(vals)
+		attrs.AppendChild(attr)
+	}
+
+	entry.AppendChild(attrs)
+	packet.AppendChild(entry)
+
+	return packet
+}
+
+// testResult returns an LDAP result packet with the specified result code.
+func testResult(id int64, tag ber.Tag, code uint16) *ber.Packet {
+	packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, id, "MessageID"))
+
+	result := ber.Encode(ber.ClassApplication, ber.TypeConstructed, tag, nil, "Result")
+	result.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(code), "Result Code"))
+	result.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	result.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Diagnostic Message"))
+
+	packet.AppendChild(result)
+
+	return packet
+}
+
+// testTLSConfig returns a server TLS config with a self-signed certificate.
+func testTLSConfig(t *testing.T) *tls.Config {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "127.0.0.1"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &key.PublicKey, key)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &tls.Config{
+		Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}},
+		MinVersion:   tls.VersionTLS12,
+	}
+}
+
+// testEntries returns the directory entries used in tests.
+func testEntries() []testEntry {
+	return []testEntry{
+		{
+			DN:       "cn=service,dc=example,dc=com",
+			Password: "service-secret",
+		},
+		{
+			DN:       "uid=alice,ou=people,dc=example,dc=com",
+			Password: "alice-secret",
+			Attrs: map[string][]string{
+				"uid":         {"alice"},
+				"cn":          {"Alice Liddell"},
+				"displayName": {"Alice"},
+				"mail":        {"alice@example.com"},
+				"memberOf":    {"cn=PhotoPrism Admins,ou=groups,dc=example,dc=com", "cn=Staff,ou=groups,dc=example,dc=com"},
+			},
+		},
+		{
+			DN:       "cn=Bob Smith,ou=people,dc=example,dc=com",
+			Password: "bob-secret",
+			Attrs: map[string][]string{
+				"sAMAccountName": {"bob"},
+				"cn":             {"Bob Smith"},
+			},
+		},
+	}
+}
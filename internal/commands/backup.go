@@ -17,7 +17,8 @@ import (
 const backupDescription = `A custom filename for the database backup (or - to send the backup to stdout) can optionally be passed as argument.
 The --database flag can be omitted in this case. When using Docker, please run the docker command with the -T flag
 to prevent log messages from being sent to stdout. If nothing else is specified, the database and album backup paths
-will be automatically determined based on the current configuration.`
+will be automatically determined based on the current configuration. Backup archives include a manifest with checksums
+and row counts, and can be checked with the "photoprism backup verify" command.`
 
 // BackupCommand configures the command name, flags, and action.
 var BackupCommand = &cli.Command{
@@ -27,6 +28,9 @@ var BackupCommand = &cli.Command{
 	ArgsUsage:   "[filename]",
 	Flags:       backupFlags,
 	Action:      backupAction,
+	Subcommands: []*cli.Command{
+		BackupVerifyCommand,
+	},
 }
 
 var backupFlags = []cli.Flag{
@@ -56,6 +60,18 @@ var backupFlags = []cli.Flag{
 		Usage:     "custom database backup `PATH`",
 		TakesFile: true,
 	},
+	&cli.BoolFlag{
+		Name:  "archive",
+		Usage: "creates an archive with a manifest, table dumps, and album and sidecar YAML files instead of a plain SQL dump",
+	},
+	&cli.StringFlag{
+		Name:  "compression",
+		Usage: "archive `COMPRESSION` (none, gzip, zstd)",
+	},
+	&cli.BoolFlag{
+		Name:  "incremental",
+		Usage: "stores only the tables that have changed since the last full backup archive",
+	},
 	&cli.IntFlag{
 		Name:    "retain",
 		Aliases: []string{"r"},
@@ -69,7 +85,7 @@ func backupAction(ctx *cli.Context) error {
 	// Use command argument as backup file name.
 	fileName := ctx.Args().First()
 	databasePath := ctx.String("database-path")
-	backupDatabase := ctx.Bool("database") || fileName != "" || databasePath != ""
+	backupDatabase := ctx.Bool("database") || ctx.Bool("archive") || ctx.Bool("incremental") || fileName != "" || databasePath != ""
 	albumsPath := ctx.String("albums-path")
 	backupAlbums := ctx.Bool("albums") || albumsPath != ""
 	force := ctx.Bool("force")
@@ -92,7 +108,40 @@ func backupAction(ctx *cli.Context) error {
 
 	defer conf.Shutdown()
 
+	// Create album backups first, so they can be included in database backup archives.
+	if backupAlbums {
+		if !fs.PathWritable(albumsPath) {
+			if albumsPath != "" {
+				log.Warnf("backup: specified albums backup path is not writable, using default directory instead")
+			}
+
+			albumsPath = conf.BackupAlbumsPath()
+		}
+
+		if count, backupErr := backup.Albums(albumsPath, true); backupErr != nil {
+			return backupErr
+		} else {
+			log.Infof("backup: saved %s", english.Plural(count, "album backup", "album backups"))
+		}
+	}
+
 	if backupDatabase {
+		archive := ctx.Bool("archive") || ctx.Bool("incremental") || backup.IsArchive(fileName) || fileName == "" && conf.BackupArchive()
+		compression := conf.BackupCompression()
+
+		if archive && fileName == "-" {
+			return fmt.Errorf("backup archives cannot be sent to stdout")
+		}
+
+		switch c := ctx.String("compression"); c {
+		case "":
+			// Use configured default.
+		case config.BackupCompressionNone, config.BackupCompressionGzip, config.BackupCompressionZstd:
+			compression = c
+		default:
+			return fmt.Errorf("unsupported compression %s", c)
+		}
+
 		// Use default if no explicit filename was provided.
 		if fileName == "" {
 			if !fs.PathWritable(databasePath) {
@@ -103,30 +152,36 @@ func backupAction(ctx *cli.Context) error {
 				databasePath = conf.BackupDatabasePath()
 			}
 
-			backupFile := time.Now().UTC().Format("2006-01-02") + ".sql"
+			backupFile := time.Now().UTC().Format("2006-01-02")
+
+			if archive {
+				backupFile += backup.ArchiveExt(compression)
+			} else {
+				backupFile += ".sql"
+			}
+
 			fileName = filepath.Join(databasePath, backupFile)
 		} else {
 			retain = 0
 		}
 
-		if err = backup.Database(databasePath, fileName, fileName == "-", force, retain); err != nil {
-			return fmt.Errorf("failed to create database backup: %w", err)
-		}
-	}
-
-	if backupAlbums {
-		if !fs.PathWritable(albumsPath) {
-			if albumsPath != "" {
-				log.Warnf("backup: specified albums backup path is not writable, using default directory instead")
+		if !archive {
+			if err = backup.Database(databasePath, fileName, fileName == "-", force, retain); err != nil {
+				return fmt.Errorf("failed to create database backup: %w", err)
 			}
-
-			albumsPath = conf.BackupAlbumsPath()
-		}
-
-		if count, backupErr := backup.Albums(albumsPath, true); backupErr != nil {
-			return backupErr
+		} else if manifest, archiveErr := backup.Archive(databasePath, fileName, backup.ArchiveOptions{
+			Compression: compression,
+			Incremental: ctx.Bool("incremental") || conf.BackupIncremental(),
+			Albums:      backupAlbums,
+			AlbumsPath:  albumsPath,
+			Sidecars:    conf.SidecarYaml(),
+			SidecarPath: conf.SidecarPath(),
+			Force:       force,
+			Retain:      retain,
+		}); archiveErr != nil {
+			return fmt.Errorf("failed to create database backup: %w", archiveErr)
 		} else {
-			log.Infof("backup: saved %s", english.Plural(count, "album backup", "album backups"))
+			log.Infof("backup: archived %s and %s", english.Plural(manifest.Changed(), "table", "tables"), english.Plural(len(manifest.Files), "YAML file", "YAML files"))
 		}
 	}
 
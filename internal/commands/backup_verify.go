@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism/backup"
+	"github.com/photoprism/photoprism/pkg/txt/report"
+)
+
+// BackupVerifyCommand configures the command name, flags, and action.
+var BackupVerifyCommand = &cli.Command{
+	Name:      "verify",
+	Usage:     "Checks if a database backup can be restored into a scratch database",
+	ArgsUsage: "[filename]",
+	Flags: append(report.CliFlags, &cli.PathFlag{
+		Name:      "database-path",
+		Aliases:   []string{"index-path"},
+		Usage:     "custom database backup `PATH`",
+		TakesFile: true,
+	}),
+	Action: backupVerifyAction,
+}
+
+// backupVerifyAction restores a database backup into a scratch database and compares the row counts.
+func backupVerifyAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		cols := []string{"Table", "Rows", "Restored", "Verified"}
+
+		tables, verifyErr := backup.Verify(ctx.String("database-path"), ctx.Args().First())
+
+		if len(tables) == 0 {
+			return verifyErr
+		}
+
+		rows := make([][]string, len(tables))
+
+		for i, t := range tables {
+			expected := "-"
+
+			if t.Rows >= 0 {
+				expected = strconv.FormatInt(t.Rows, 10)
+			}
+
+			rows[i] = []string{
+				t.Table,
+				expected,
+				strconv.FormatInt(t.Restored, 10),
+				report.Bool(t.OK(), report.Yes, report.No),
+			}
+		}
+
+		result, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
+
+		fmt.Printf("\n%s\n", result)
+
+		if verifyErr != nil {
+			return verifyErr
+		}
+
+		return err
+	})
+}
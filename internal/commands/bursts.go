@@ -0,0 +1,104 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli/v2"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/pkg/txt/report"
+)
+
+// BurstsCommand configures the command name, flags, and action.
+var BurstsCommand = &cli.Command{
+	Name:  "bursts",
+	Usage: "Selects the best shot in bursts of similar pictures",
+	Description: "Groups visually similar pictures taken with the same camera in quick succession, " +
+		"scores their sharpness, exposure, and whether eyes are open, and then stacks or archives " +
+		"all pictures except the best shot, depending on the policy.",
+	Flags: append(report.CliFlags,
+		&cli.StringFlag{
+			Name:    "policy",
+			Aliases: []string{"p"},
+			Usage:   "best shot `POLICY`: off (report only), stack, or archive (default: configured policy)",
+		},
+		&cli.DurationFlag{
+			Name:    "interval",
+			Aliases: []string{"i"},
+			Usage:   "maximum `TIME` between consecutive pictures of a burst (default: configured interval)",
+		},
+		&cli.IntFlag{
+			Name:    "distance",
+			Aliases: []string{"d"},
+			Usage:   "maximum perceptual hash `DISTANCE` of pictures in a burst (default: configured distance)",
+		},
+		&cli.BoolFlag{
+			Name:    "dry-run",
+			Aliases: []string{"n"},
+			Usage:   "shows the best shots without changing any pictures",
+		},
+	),
+	Action: burstsAction,
+}
+
+// burstsAction selects the best shot in bursts of similar pictures.
+func burstsAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		start := time.Now()
+
+		opt := photoprism.BestShotOptions{
+			Policy:   conf.BestShot(),
+			Interval: ctx.Duration("interval"),
+			Distance: ctx.Int("distance"),
+		}
+
+		if ctx.IsSet("policy") {
+			opt.Policy = ctx.String("policy")
+		}
+
+		if ctx.Bool("dry-run") {
+			opt.Policy = config.BestShotOff
+		}
+
+		results, err := photoprism.NewBestShot(conf).Start(opt)
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("bestshot: found %s [%s]", english.Plural(len(results), "burst", "bursts"), time.Since(start))
+
+		if len(results) == 0 {
+			return nil
+		}
+
+		cols := []string{"Burst", "UID", "Title", "Taken At", "Sharpness", "Exposure", "Eyes", "Score", "Best Shot", "Action"}
+		rows := make([][]string, 0, len(results)*2)
+
+		for i, r := range results {
+			for _, p := range r.Photos {
+				rows = append(rows, []string{
+					fmt.Sprintf("%d", i+1),
+					p.PhotoUID,
+					p.PhotoTitle,
+					p.TakenAt.Format(time.DateTime),
+					fmt.Sprintf("%.2f", p.Score.Sharpness),
+					fmt.Sprintf("%.2f", p.Score.Exposure),
+					fmt.Sprintf("%.2f", p.Score.Eyes),
+					fmt.Sprintf("%.2f", p.Score.Total),
+					report.Bool(p.PhotoUID == r.Best, report.Yes, report.No),
+					r.Action,
+				})
+			}
+		}
+
+		result, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
+
+		fmt.Printf("\n%s\n", result)
+
+		return err
+	})
+}
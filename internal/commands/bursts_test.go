@@ -0,0 +1,22 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBurstsCommand(t *testing.T) {
+	t.Run("DryRun", func(t *testing.T) {
+		// Run command with test context.
+		_, err := RunWithTestContext(BurstsCommand, []string{"bursts", "--dry-run"})
+
+		assert.NoError(t, err)
+	})
+	t.Run("UnknownPolicy", func(t *testing.T) {
+		// Run command with test context.
+		_, err := RunWithTestContext(BurstsCommand, []string{"bursts", "--policy", "delete"})
+
+		assert.Error(t, err)
+	})
+}
@@ -56,12 +56,15 @@ var PhotoPrism = []*cli.Command{
 	StatusCommand,
 	IndexCommand,
 	FindCommand,
+	SearchIndexCommands,
 	ImportCommand,
 	CopyCommand,
 	DownloadCommand,
 	VisionCommands,
 	FacesCommands,
 	PlacesCommands,
+	GeotagCommand,
+	BurstsCommand,
 	PurgeCommand,
 	CleanUpCommand,
 	OptimizeCommand,
@@ -76,6 +79,9 @@ var PhotoPrism = []*cli.Command{
 	PasswdCommand,
 	UsersCommands,
 	ClientsCommands,
+	WebhooksCommands,
+	JobsCommands,
+	TeamsCommands,
 	ClusterCommands,
 	AuthCommands,
 	ShowCommands,
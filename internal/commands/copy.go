@@ -13,6 +13,7 @@ import (
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/photoprism/get"
 	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt/report"
 )
 
 // CopyCommand configures the command name, flags, and action.
@@ -21,13 +22,14 @@ var CopyCommand = &cli.Command{
 	Aliases:   []string{"copy"},
 	Usage:     "Copies media files to originals",
 	ArgsUsage: "[source]",
-	Flags: []cli.Flag{
+	Flags: append(report.CliFlags,
 		&cli.StringFlag{
 			Name:    "dest",
 			Aliases: []string{"d"},
 			Usage:   "relative originals `PATH` in which new files should be imported",
 		},
-	},
+		DryRunFlag("shows where files would be copied to without importing them"),
+	),
 	Action: copyAction,
 }
 
@@ -45,7 +47,7 @@ func copyAction(ctx *cli.Context) error {
 	}
 
 	// very if copy directory exist and is writable
-	if conf.ReadOnly() {
+	if conf.ReadOnly() && !ctx.Bool("dry-run") {
 		return config.ErrReadOnly
 	}
 
@@ -83,6 +85,11 @@ func copyAction(ctx *cli.Context) error {
 	w := get.Import()
 	opt := photoprism.ImportOptionsCopy(sourcePath, destFolder)
 
+	// Only show where the files would be imported to?
+	if ctx.Bool("dry-run") {
+		return importPreview(ctx, w, opt)
+	}
+
 	w.Start(opt)
 
 	elapsed := time.Since(start)
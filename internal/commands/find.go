@@ -20,6 +20,9 @@ var FindCommand = &cli.Command{
 	Aliases:   []string{"search"},
 	Usage:     "Finds indexed files that match the specified search filters",
 	ArgsUsage: "[filter]...",
+	Description: "Filters can be negated with - or NOT, combined with OR, grouped with parentheses, " +
+		"and specify ranges, e.g. label:dog OR subject:anna, NOT label:cat, iso:100..400, taken:2020-01..2020-06. " +
+		"Use -- before the filters if the first one starts with -, e.g. find -- -label:cat.",
 	Flags: append(report.CliFlags, &cli.UintFlag{
 		Name:    "count",
 		Aliases: []string{"n"},
@@ -42,7 +45,7 @@ func findAction(ctx *cli.Context) error {
 
 	defer conf.Shutdown()
 
-	filter := strings.TrimSpace(strings.Join(ctx.Args().Slice(), " "))
+	filter := findQuery(ctx.Args().Slice())
 
 	frm := form.SearchPhotos{
 		Query:   filter,
@@ -92,3 +95,25 @@ func findAction(ctx *cli.Context) error {
 
 	return nil
 }
+
+// findQuery joins the command arguments to a search query and quotes values that contain whitespace,
+// since the shell has already removed the quotes around phrases such as title:"Lake View".
+func findQuery(args []string) string {
+	terms := make([]string, 0, len(args))
+
+	for _, arg := range args {
+		arg = strings.TrimSpace(arg)
+
+		if arg == "" {
+			continue
+		} else if !strings.ContainsAny(arg, " \t") || strings.Contains(arg, "\"") {
+			terms = append(terms, arg)
+		} else if key, value, found := strings.Cut(arg, ":"); found && key != "" && !strings.ContainsAny(key, " \t") {
+			terms = append(terms, fmt.Sprintf("%s:\"%s\"", key, value))
+		} else {
+			terms = append(terms, fmt.Sprintf("\"%s\"", arg))
+		}
+	}
+
+	return strings.Join(terms, " ")
+}
@@ -16,4 +16,29 @@ func TestFindCommand(t *testing.T) {
 		assert.NoError(t, err)
 		assert.Contains(t, output, "File Name;Mime Type;")
 	})
+	t.Run("BooleanQuery", func(t *testing.T) {
+		// Run command with test context.
+		output, err := RunWithTestContext(FindCommand, []string{"find", "--csv", "--", "-label:cake", "OR", "(", "iso:100..400", ")"})
+
+		// Check command output for plausibility.
+		assert.NoError(t, err)
+		assert.Contains(t, output, "File Name;Mime Type;")
+	})
+	t.Run("SyntaxError", func(t *testing.T) {
+		// Run command with test context.
+		_, err := RunWithTestContext(FindCommand, []string{"find", "(label:cake", "OR"})
+
+		// Check the error message.
+		if assert.Error(t, err) {
+			assert.Equal(t, "missing search term after OR at position 13", err.Error())
+		}
+	})
+}
+
+func TestFindQuery(t *testing.T) {
+	assert.Equal(t, "", findQuery(nil))
+	assert.Equal(t, "label:dog OR subject:anna", findQuery([]string{"label:dog", "OR", "subject:anna"}))
+	assert.Equal(t, "title:\"Lake View\" -label:cat", findQuery([]string{"title:Lake View", " -label:cat "}))
+	assert.Equal(t, "\"golden gate\" iso:100..400", findQuery([]string{"golden gate", "iso:100..400"}))
+	assert.Equal(t, "title:\"Lake View\"", findQuery([]string{"title:\"Lake View\""}))
 }
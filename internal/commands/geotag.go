@@ -0,0 +1,115 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli/v2"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/geo/track"
+	"github.com/photoprism/photoprism/pkg/txt/report"
+)
+
+// GeotagCommand configures the command name, flags, and action.
+var GeotagCommand = &cli.Command{
+	Name:      "geotag",
+	Usage:     "Sets the location of pictures based on GPS tracks",
+	ArgsUsage: "<track.gpx|track.kml|track.geojson>...",
+	Description: "Matches pictures with one or more GPX, KML, or GeoJSON tracks by the time they were taken " +
+		"and interpolates their position. Locations that have been set manually or from metadata are not changed.",
+	Flags: append(report.CliFlags,
+		&cli.DurationFlag{
+			Name:    "offset",
+			Aliases: []string{"o"},
+			Usage:   "camera clock `OFFSET` that is added to the time pictures were taken, e.g. -1h30m",
+		},
+		&cli.StringFlag{
+			Name:    "timezone",
+			Aliases: []string{"tz"},
+			Usage:   "time `ZONE` of pictures without a known time zone, e.g. Europe/Berlin (default: default timezone)",
+		},
+		&cli.DurationFlag{
+			Name:    "max-gap",
+			Aliases: []string{"g"},
+			Usage:   "maximum `TIME` between track points and pictures",
+			Value:   track.DefaultMaxGap,
+		},
+		&cli.BoolFlag{
+			Name:    "dry-run",
+			Aliases: []string{"n"},
+			Usage:   "shows matching pictures without updating them",
+		},
+	),
+	Action: geotagAction,
+}
+
+// geotagAction sets the location of pictures based on GPS tracks.
+func geotagAction(ctx *cli.Context) error {
+	if ctx.Args().Len() == 0 {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		start := time.Now()
+
+		tracks := make([]track.Track, 0, ctx.Args().Len())
+
+		for _, fileName := range ctx.Args().Slice() {
+			trk, err := track.ReadFile(fs.Abs(fileName))
+
+			if err != nil {
+				return fmt.Errorf("failed to read %s (%s)", clean.Log(fileName), err)
+			}
+
+			log.Infof("geotag: found %s in %s", english.Plural(trk.Len(), "track point", "track points"), clean.Log(trk.Name))
+
+			tracks = append(tracks, trk)
+		}
+
+		opt := photoprism.GeotagOptions{
+			Offset:   ctx.Duration("offset"),
+			TimeZone: ctx.String("timezone"),
+			MaxGap:   ctx.Duration("max-gap"),
+			DryRun:   ctx.Bool("dry-run"),
+		}
+
+		results, err := photoprism.NewGeotag(conf).Start(track.Merge("", tracks...), opt)
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("geotag: found %s [%s]", english.Plural(len(results), "matching picture", "matching pictures"), time.Since(start))
+
+		if len(results) == 0 {
+			return nil
+		}
+
+		cols := []string{"UID", "Name", "Taken At", "Latitude", "Longitude", "Altitude", "Source", "Status"}
+		rows := make([][]string, len(results))
+
+		for i, r := range results {
+			rows[i] = []string{
+				r.UID,
+				r.Name,
+				r.TakenAt.Format(time.DateTime),
+				fmt.Sprintf("%f", r.Lat),
+				fmt.Sprintf("%f", r.Lng),
+				fmt.Sprintf("%.1f m", r.Altitude),
+				r.Src,
+				r.Status,
+			}
+		}
+
+		result, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
+
+		fmt.Printf("\n%s\n", result)
+
+		return err
+	})
+}
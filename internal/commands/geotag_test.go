@@ -0,0 +1,22 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeotagCommand(t *testing.T) {
+	t.Run("DryRun", func(t *testing.T) {
+		// Run command with test context.
+		_, err := RunWithTestContext(GeotagCommand, []string{"geotag", "--dry-run", "../../pkg/geo/track/testdata/track.gpx"})
+
+		assert.NoError(t, err)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		// Run command with test context.
+		_, err := RunWithTestContext(GeotagCommand, []string{"geotag", "--dry-run", "testdata/missing.gpx"})
+
+		assert.Error(t, err)
+	})
+}
@@ -3,6 +3,7 @@ package commands
 import (
 	"context"
 	"errors"
+	"fmt"
 	"path/filepath"
 	"strings"
 	"time"
@@ -13,6 +14,7 @@ import (
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/photoprism/get"
 	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt/report"
 )
 
 // ImportCommand configures the command name, flags, and action.
@@ -21,13 +23,14 @@ var ImportCommand = &cli.Command{
 	Aliases:   []string{"import"},
 	Usage:     "Moves media files to originals",
 	ArgsUsage: "[source]",
-	Flags: []cli.Flag{
+	Flags: append(report.CliFlags,
 		&cli.StringFlag{
 			Name:    "dest",
 			Aliases: []string{"d"},
 			Usage:   "relative originals `PATH` in which new files should be imported",
 		},
-	},
+		DryRunFlag("shows where files would be moved to without importing them"),
+	),
 	Action: importAction,
 }
 
@@ -45,7 +48,7 @@ func importAction(ctx *cli.Context) error {
 	}
 
 	// very if copy directory exist and is writable
-	if conf.ReadOnly() {
+	if conf.ReadOnly() && !ctx.Bool("dry-run") {
 		return config.ErrReadOnly
 	}
 
@@ -83,6 +86,11 @@ func importAction(ctx *cli.Context) error {
 	w := get.Import()
 	opt := photoprism.ImportOptionsMove(sourcePath, destFolder)
 
+	// Only show where the files would be imported to?
+	if ctx.Bool("dry-run") {
+		return importPreview(ctx, w, opt)
+	}
+
 	w.Start(opt)
 
 	elapsed := time.Since(start)
@@ -91,3 +99,25 @@ func importAction(ctx *cli.Context) error {
 
 	return nil
 }
+
+// importPreview shows where the files in the import folder would be imported to.
+func importPreview(ctx *cli.Context, w *photoprism.Import, opt photoprism.ImportOptions) error {
+	results, err := w.Preview(opt)
+
+	if err != nil {
+		return err
+	}
+
+	cols := []string{"Source", "Destination", "Skipped"}
+	rows := make([][]string, len(results))
+
+	for i, r := range results {
+		rows[i] = []string{r.Source, r.Dest, r.Skip}
+	}
+
+	result, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
+
+	fmt.Printf("\n%s\n", result)
+
+	return err
+}
@@ -0,0 +1,106 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// Usage hints for the job management subcommands.
+const (
+	JobNameUsage     = "`NAME` to help identify the job"
+	JobPathUsage     = "optional sub-folder `PATH` for the index, import, purge, and thumbs actions"
+	JobScheduleUsage = "cron `SCHEDULE` for running the job, e.g. \"0 2 * * *\" for every night at 2am, or empty to run it manually only"
+	JobPause         = "stop running the job on schedule"
+	JobResume        = "run the job on schedule again if paused"
+)
+
+var (
+	// JobActionsUsage describes the supported job actions for CLI help.
+	JobActionsUsage = fmt.Sprintf("job `ACTIONS` that run in sequence, e.g. %s", strings.Join(entity.JobActions, ", "))
+)
+
+// JobsCommands configures the job management subcommands.
+var JobsCommands = &cli.Command{
+	Name:    "jobs",
+	Aliases: []string{"job"},
+	Usage:   "Scheduled job management subcommands",
+	Subcommands: []*cli.Command{
+		JobsListCommand,
+		JobsAddCommand,
+		JobsShowCommand,
+		JobsModCommand,
+		JobsRemoveCommand,
+		JobsRunCommand,
+		JobsPauseCommand,
+		JobsResumeCommand,
+		JobsHistoryCommand,
+	},
+}
+
+// JobAddFlags specifies the "photoprism jobs add" command flags.
+var JobAddFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:    "name",
+		Aliases: []string{"n"},
+		Usage:   JobNameUsage,
+	},
+	&cli.StringFlag{
+		Name:    "schedule",
+		Aliases: []string{"s"},
+		Usage:   JobScheduleUsage,
+	},
+	&cli.StringFlag{
+		Name:    "path",
+		Aliases: []string{"p"},
+		Usage:   JobPathUsage,
+	},
+	&cli.BoolFlag{
+		Name:  "pause",
+		Usage: JobPause,
+	},
+}
+
+// JobModFlags specifies the "photoprism jobs mod" command flags.
+var JobModFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:    "name",
+		Aliases: []string{"n"},
+		Usage:   JobNameUsage,
+	},
+	&cli.StringSliceFlag{
+		Name:    "actions",
+		Aliases: []string{"a"},
+		Usage:   JobActionsUsage,
+	},
+	&cli.StringFlag{
+		Name:    "schedule",
+		Aliases: []string{"s"},
+		Usage:   JobScheduleUsage,
+	},
+	&cli.StringFlag{
+		Name:    "path",
+		Aliases: []string{"p"},
+		Usage:   JobPathUsage,
+	},
+	&cli.BoolFlag{
+		Name:  "pause",
+		Usage: JobPause,
+	},
+	&cli.BoolFlag{
+		Name:  "resume",
+		Usage: JobResume,
+	},
+}
+
+// jobRunAt returns the start time of the last run as formatted string, or an empty string if it has never run.
+func jobRunAt(m entity.Job) string {
+	if m.RunAt == nil {
+		return ""
+	}
+
+	return m.RunAt.Format("2006-01-02 15:04:05")
+}
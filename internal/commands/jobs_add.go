@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt/report"
+)
+
+// JobsAddCommand configures the command name, flags, and action.
+var JobsAddCommand = &cli.Command{
+	Name:        "add",
+	Usage:       "Adds a new job that runs the specified actions in sequence",
+	Description: fmt.Sprintf("Supported actions: %s. Example: photoprism jobs add --name=\"Nightly Import\" --schedule=\"0 2 * * *\" import faces", JobActionsUsage),
+	ArgsUsage:   "[actions]",
+	Flags:       JobAddFlags,
+	Action:      jobsAddAction,
+}
+
+// jobsAddAction adds a new job.
+func jobsAddAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		conf.MigrateDb(false, nil)
+
+		frm := form.AddJobFromCli(ctx)
+
+		// Actions provided?
+		if frm.JobActions == "" {
+			log.Infof("no job actions specified")
+			return cli.ShowSubcommandHelp(ctx)
+		}
+
+		m, err := entity.AddJob(frm)
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("successfully added new job %s", clean.Log(m.JobUID))
+
+		// Display job details.
+		cols := []string{"Job ID", "Name", "Actions", "Path", "Schedule", "Paused", "Created At"}
+		rows := [][]string{{
+			m.JobUID,
+			m.JobName,
+			m.JobActions,
+			m.JobPath,
+			m.JobSchedule,
+			report.Bool(m.JobPaused, report.Yes, report.No),
+			m.CreatedAt.Format("2006-01-02 15:04:05"),
+		}}
+
+		result, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
+
+		fmt.Printf("\n%s\n", result)
+
+		return err
+	})
+}
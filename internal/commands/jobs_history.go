@@ -0,0 +1,99 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli/v2"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt/report"
+)
+
+// JobsHistoryCommand configures the command name, flags, and action.
+var JobsHistoryCommand = &cli.Command{
+	Name:      "history",
+	Aliases:   []string{"log"},
+	Usage:     "Shows the run history of a job",
+	ArgsUsage: "[job id]",
+	Flags: append(report.CliFlags, CountFlag,
+		&cli.StringFlag{
+			Name:    "status",
+			Aliases: []string{"s"},
+			Usage:   fmt.Sprintf("only show runs with the specified `STATUS`, e.g. %s, %s, or %s", entity.JobRunning, entity.JobSucceeded, entity.JobFailed),
+		},
+		&cli.BoolFlag{
+			Name:    "verbose",
+			Aliases: []string{"v"},
+			Usage:   "show the log output of each run",
+		}),
+	Action: jobsHistoryAction,
+}
+
+// jobsHistoryAction displays the run history of a job.
+func jobsHistoryAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		id := clean.UID(ctx.Args().First())
+
+		// UID provided?
+		if id == "" {
+			return cli.ShowSubcommandHelp(ctx)
+		}
+
+		cols := []string{"ID", "Actions", "Trigger", "Status", "Duration", "Error", "Started At"}
+
+		if ctx.Bool("verbose") {
+			cols = append(cols, "Log")
+		}
+
+		// Fetch runs from database.
+		runs, err := query.JobRuns(id, clean.TypeLowerUnderscore(ctx.String("status")), ctx.Int("count"), 0)
+
+		if err != nil {
+			return err
+		}
+
+		if len(runs) == 0 {
+			log.Warnf("no runs found")
+			return nil
+		}
+
+		// Show log message.
+		log.Infof("found %s", english.Plural(len(runs), "run", "runs"))
+
+		rows := make([][]string, len(runs))
+
+		// Display report.
+		for i, r := range runs {
+			var duration string
+
+			if !r.Running() {
+				duration = (time.Duration(r.Duration) * time.Millisecond).String()
+			}
+
+			rows[i] = []string{
+				fmt.Sprintf("%d", r.ID),
+				r.Actions,
+				r.Trigger,
+				r.Status,
+				duration,
+				r.Error,
+				r.StartedAt.Format("2006-01-02 15:04:05"),
+			}
+
+			if ctx.Bool("verbose") {
+				rows[i] = append(rows[i], r.Log)
+			}
+		}
+
+		result, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
+
+		fmt.Printf("\n%s\n", result)
+
+		return err
+	})
+}
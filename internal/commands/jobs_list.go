@@ -0,0 +1,65 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli/v2"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/pkg/txt/report"
+)
+
+// JobsListCommand configures the command name, flags, and action.
+var JobsListCommand = &cli.Command{
+	Name:      "ls",
+	Usage:     "Lists scheduled jobs",
+	ArgsUsage: "[search]",
+	Flags:     append(report.CliFlags, CountFlag),
+	Action:    jobsListAction,
+}
+
+// jobsListAction lists scheduled jobs.
+func jobsListAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		cols := []string{"Job ID", "Name", "Actions", "Path", "Schedule", "Paused", "Status", "Run At"}
+
+		// Fetch jobs from database.
+		jobs, err := query.Jobs(ctx.Int("count"), 0, ctx.Args().First())
+
+		if err != nil {
+			return err
+		}
+
+		rows := make([][]string, len(jobs))
+
+		if len(jobs) == 0 {
+			log.Warnf("no jobs found")
+			return nil
+		}
+
+		// Show log message.
+		log.Infof("found %s", english.Plural(len(jobs), "job", "jobs"))
+
+		// Display report.
+		for i, m := range jobs {
+			rows[i] = []string{
+				m.JobUID,
+				m.JobName,
+				m.JobActions,
+				m.JobPath,
+				m.JobSchedule,
+				report.Bool(m.JobPaused, report.Yes, report.No),
+				m.JobStatus,
+				jobRunAt(m),
+			}
+		}
+
+		result, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
+
+		fmt.Printf("\n%s\n", result)
+
+		return err
+	})
+}
@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// JobsModCommand configures the command name, flags, and action.
+var JobsModCommand = &cli.Command{
+	Name:      "mod",
+	Usage:     "Updates job settings",
+	ArgsUsage: "[job id]",
+	Flags:     JobModFlags,
+	Action:    jobsModAction,
+}
+
+// jobsModAction updates job settings.
+func jobsModAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		conf.MigrateDb(false, nil)
+
+		id := clean.UID(ctx.Args().First())
+
+		// UID provided?
+		if id == "" {
+			log.Infof("no valid job id specified")
+			return cli.ShowSubcommandHelp(ctx)
+		}
+
+		// Find job record.
+		m := entity.FindJob(id)
+
+		if m == nil {
+			return fmt.Errorf("job %s not found", clean.Log(id))
+		}
+
+		frm, err := form.NewJob(m)
+
+		if err != nil {
+			return err
+		}
+
+		// Update job from form values.
+		frm.ModJobFromCli(ctx)
+
+		if err = m.SaveForm(frm); err != nil {
+			return fmt.Errorf("invalid values: %s", err)
+		} else {
+			log.Infof("job %s has been updated", clean.Log(m.JobUID))
+		}
+
+		if m.JobSchedule == "" {
+			log.Infof("job runs manually only")
+		} else if m.JobPaused {
+			log.Warnf("job is paused")
+		} else {
+			log.Infof("job runs on schedule %s", clean.Log(m.JobSchedule))
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,63 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// JobsPauseCommand configures the command name, flags, and action.
+var JobsPauseCommand = &cli.Command{
+	Name:      "pause",
+	Usage:     "Stops the specified job from running on schedule",
+	ArgsUsage: "[job id]",
+	Action: func(ctx *cli.Context) error {
+		return jobsPauseAction(ctx, true)
+	},
+}
+
+// JobsResumeCommand configures the command name, flags, and action.
+var JobsResumeCommand = &cli.Command{
+	Name:      "resume",
+	Usage:     "Lets the specified job run on schedule again",
+	ArgsUsage: "[job id]",
+	Action: func(ctx *cli.Context) error {
+		return jobsPauseAction(ctx, false)
+	},
+}
+
+// jobsPauseAction pauses or resumes a job.
+func jobsPauseAction(ctx *cli.Context, paused bool) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		conf.MigrateDb(false, nil)
+
+		id := clean.UID(ctx.Args().First())
+
+		// UID provided?
+		if id == "" {
+			log.Infof("no valid job id specified")
+			return cli.ShowSubcommandHelp(ctx)
+		}
+
+		// Find job record.
+		m := entity.FindJob(id)
+
+		if m == nil {
+			return fmt.Errorf("job %s not found", clean.Log(id))
+		}
+
+		if err := m.SetPaused(paused); err != nil {
+			return err
+		} else if paused {
+			log.Infof("job %s has been paused", m.JobUID)
+		} else {
+			log.Infof("job %s has been resumed", m.JobUID)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/manifoldco/promptui"
+	"github.com/urfave/cli/v2"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// JobsRemoveCommand configures the command name, flags, and action.
+var JobsRemoveCommand = &cli.Command{
+	Name:      "rm",
+	Usage:     "Deletes the specified job",
+	ArgsUsage: "[job id]",
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:    "force",
+			Aliases: []string{"f"},
+			Usage:   "skips asking for confirmation",
+		},
+	},
+	Action: jobsRemoveAction,
+}
+
+// jobsRemoveAction deletes a job.
+func jobsRemoveAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		conf.MigrateDb(false, nil)
+
+		id := clean.UID(ctx.Args().First())
+
+		// UID provided?
+		if id == "" {
+			log.Infof("no valid job id specified")
+			return cli.ShowSubcommandHelp(ctx)
+		}
+
+		// Find job record.
+		m := entity.FindJob(id)
+
+		if m == nil {
+			return fmt.Errorf("job %s not found", clean.Log(id))
+		}
+
+		if !ctx.Bool("force") && !RunNonInteractively(false) {
+			actionPrompt := promptui.Prompt{
+				Label:     fmt.Sprintf("Delete job %s?", m.JobUID),
+				IsConfirm: true,
+			}
+
+			if _, err := actionPrompt.Run(); err != nil {
+				log.Infof("job %s was not deleted", m.JobUID)
+				return nil
+			}
+		}
+
+		if err := m.Delete(); err != nil {
+			return err
+		}
+
+		log.Infof("job %s has been deleted", m.JobUID)
+
+		return nil
+	})
+}
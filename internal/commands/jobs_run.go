@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/workers"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// JobsRunCommand configures the command name, flags, and action.
+var JobsRunCommand = &cli.Command{
+	Name:      "run",
+	Usage:     "Runs the specified job now and records the result in its history",
+	ArgsUsage: "[job id]",
+	Action:    jobsRunAction,
+}
+
+// jobsRunAction runs a job once.
+func jobsRunAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		conf.MigrateDb(false, nil)
+
+		id := clean.UID(ctx.Args().First())
+
+		// UID provided?
+		if id == "" {
+			log.Infof("no valid job id specified")
+			return cli.ShowSubcommandHelp(ctx)
+		}
+
+		// Find job record.
+		m := entity.FindJob(id)
+
+		if m == nil {
+			return fmt.Errorf("job %s not found", clean.Log(id))
+		}
+
+		run, err := workers.NewJobRunner(conf).Run(m, entity.JobManual)
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("job %s completed in %s", clean.Log(m.JobUID), time.Duration(run.Duration)*time.Millisecond)
+
+		return nil
+	})
+}
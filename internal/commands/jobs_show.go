@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt/report"
+)
+
+// JobsShowCommand configures the command name, flags, and action.
+var JobsShowCommand = &cli.Command{
+	Name:      "show",
+	Usage:     "Shows job configuration details",
+	ArgsUsage: "[job id]",
+	Flags:     report.CliFlags,
+	Action:    jobsShowAction,
+}
+
+// jobsShowAction displays the current job settings.
+func jobsShowAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		id := clean.UID(ctx.Args().First())
+
+		// UID provided?
+		if id == "" {
+			return cli.ShowSubcommandHelp(ctx)
+		}
+
+		// Find job record.
+		m := entity.FindJob(id)
+
+		if m == nil {
+			return fmt.Errorf("job %s not found", clean.Log(id))
+		}
+
+		// Get job information.
+		rows, cols := m.Report(true)
+
+		// Sort values by name.
+		report.Sort(rows)
+
+		// Show job information.
+		result, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
+
+		fmt.Printf("\n%s\n", result)
+
+		return err
+	})
+}
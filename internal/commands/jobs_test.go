@@ -0,0 +1,78 @@
+package commands
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobsCommands(t *testing.T) {
+	t.Run("AddModRunRemove", func(t *testing.T) {
+		output, err := RunWithTestContext(JobsAddCommand, []string{"add", "--name=CLI Test", "--schedule=0 2 * * *", "backup"})
+
+		assert.NoError(t, err)
+		assert.Contains(t, output, "CLI Test")
+		assert.Contains(t, output, "0 2 * * *")
+
+		uid := regexp.MustCompile(`rt[0-9a-z]{14}`).FindString(output)
+
+		if uid == "" {
+			t.Fatal("job uid not found")
+		}
+
+		_, err = RunWithTestContext(JobsModCommand, []string{"mod", "--path=2024", "--schedule=30 4 * * *", uid})
+
+		assert.NoError(t, err)
+
+		_, err = RunWithTestContext(JobsPauseCommand, []string{"pause", uid})
+
+		assert.NoError(t, err)
+
+		output, err = RunWithTestContext(JobsShowCommand, []string{"show", uid})
+
+		assert.NoError(t, err)
+		assert.Contains(t, output, "30 4 * * *")
+		assert.Contains(t, output, "2024")
+		assert.Contains(t, output, "true")
+
+		_, err = RunWithTestContext(JobsResumeCommand, []string{"resume", uid})
+
+		assert.NoError(t, err)
+
+		_, err = RunWithTestContext(JobsRunCommand, []string{"run", uid})
+
+		assert.NoError(t, err)
+
+		output, err = RunWithTestContext(JobsHistoryCommand, []string{"history", uid})
+
+		assert.NoError(t, err)
+		assert.Contains(t, output, "succeeded")
+		assert.Contains(t, output, "manual")
+
+		_, err = RunWithTestContext(JobsRemoveCommand, []string{"rm", "--force", uid})
+
+		assert.NoError(t, err)
+
+		_, err = RunWithTestContext(JobsShowCommand, []string{"show", uid})
+
+		assert.Error(t, err)
+	})
+	t.Run("AddInvalidAction", func(t *testing.T) {
+		_, err := RunWithTestContext(JobsAddCommand, []string{"add", "reboot"})
+		assert.Error(t, err)
+	})
+	t.Run("List", func(t *testing.T) {
+		output, err := RunWithTestContext(JobsListCommand, []string{"ls"})
+
+		assert.NoError(t, err)
+		assert.Contains(t, output, "rt2hdkz1vgdb3ta2")
+		assert.Contains(t, output, "Nightly Import")
+	})
+	t.Run("History", func(t *testing.T) {
+		output, err := RunWithTestContext(JobsHistoryCommand, []string{"history", "--status=failed", "rt2hdl31ydq9yw0k"})
+
+		assert.NoError(t, err)
+		assert.Contains(t, output, "index is busy")
+	})
+}
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"context"
+	"fmt"
 	"time"
 
 	"github.com/manifoldco/promptui"
@@ -10,6 +11,8 @@ import (
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/entity/query"
 	"github.com/photoprism/photoprism/internal/photoprism/get"
+	"github.com/photoprism/photoprism/internal/service/geonames"
+	"github.com/photoprism/photoprism/pkg/fs"
 )
 
 // PlacesCommands configures the command name, flags, and action.
@@ -35,6 +38,7 @@ var PlacesCommands = &cli.Command{
 			},
 			Action: placesUpdateAction,
 		},
+		PlacesImportCommand,
 	},
 }
 
@@ -53,8 +57,15 @@ func placesUpdateAction(ctx *cli.Context) error {
 	// Force update of all locations?
 	force := ctx.Bool("force")
 
+	// The local dataset must be imported before it can be used.
+	localDataset := conf.GeoApi() == geonames.ApiName
+
+	if localDataset && !fs.FileExistsNotEmpty(conf.GeoNamesFile()) {
+		return fmt.Errorf("local places dataset not found, please run the places import command first")
+	}
+
 	// Show info in case the force option is used without support.
-	if force && !conf.Sponsor() && !conf.Test() {
+	if force && !localDataset && !conf.Sponsor() && !conf.Test() {
 		log.Errorf("Since updating the location details of all pictures puts a high load on our infrastructure, this option cannot be used with our Community Edition.")
 		return nil
 	}
@@ -0,0 +1,76 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli/v2"
+
+	"github.com/photoprism/photoprism/internal/service/geonames"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// PlacesImportCommand configures the command name, flags, and action.
+var PlacesImportCommand = &cli.Command{
+	Name:      "import",
+	Usage:     "Imports or updates the local dataset for offline reverse geocoding",
+	ArgsUsage: "<cities.txt|cities.zip> [admin1CodesASCII.txt]",
+	Description: "Reads a GeoNames cities dump, e.g. cities1000.zip from https://download.geonames.org/export/dump/, " +
+		"and optionally resolves state names with admin1CodesASCII.txt. " +
+		"To use the dataset, set the places provider to geonames.",
+	Flags: []cli.Flag{
+		&cli.IntFlag{
+			Name:    "min-population",
+			Aliases: []string{"p"},
+			Usage:   "skips places with a population below `NUMBER`",
+			Value:   geonames.MinPopulation,
+		},
+	},
+	Action: placesImportAction,
+}
+
+// placesImportAction imports a GeoNames cities dump for offline reverse geocoding.
+func placesImportAction(ctx *cli.Context) error {
+	citiesFile := ctx.Args().First()
+
+	if citiesFile == "" {
+		return cli.ShowSubcommandHelp(ctx)
+	}
+
+	conf, err := InitConfig(ctx)
+
+	if err != nil {
+		return err
+	}
+
+	defer conf.Shutdown()
+
+	start := time.Now()
+
+	admin1File := ctx.Args().Get(1)
+
+	if admin1File != "" {
+		admin1File = fs.Abs(admin1File)
+	}
+
+	cities, err := geonames.ReadDump(fs.Abs(citiesFile), admin1File, ctx.Int("min-population"))
+
+	if err != nil {
+		return err
+	}
+
+	fileName := conf.GeoNamesFile()
+
+	if err = geonames.SaveDataset(fileName, cities); err != nil {
+		return err
+	}
+
+	log.Infof("places: imported %s to %s [%s]", english.Plural(len(cities), "city", "cities"), clean.Log(fileName), time.Since(start))
+
+	if conf.PlacesProvider() != geonames.ApiName {
+		log.Infof("places: set the places provider to %s to use the local dataset", geonames.ApiName)
+	}
+
+	return nil
+}
@@ -2,6 +2,7 @@ package commands
 
 import (
 	"context"
+	"path/filepath"
 	"time"
 
 	"github.com/dustin/go-humanize/english"
@@ -92,6 +93,29 @@ func restoreAction(ctx *cli.Context) error {
 
 	conf.InitDb()
 
+	// Find the backup archive from which YAML files can be extracted, if any.
+	var archiveFile string
+
+	if backup.IsArchive(databaseFile) {
+		switch {
+		case fs.FileExists(databaseFile):
+			archiveFile = databaseFile
+		case databasePath != "":
+			archiveFile = filepath.Join(databasePath, databaseFile)
+		default:
+			archiveFile = filepath.Join(conf.BackupDatabasePath(), databaseFile)
+		}
+	}
+
+	// Extract photo YAML sidecar files that do not exist yet, so that their metadata can be restored when indexing.
+	if restoreDatabase && archiveFile != "" {
+		if count, extractErr := backup.ExtractSidecars(archiveFile, conf.SidecarPath()); extractErr != nil {
+			log.Warnf("restore: %s (extract sidecar files)", extractErr)
+		} else if count > 0 {
+			log.Infof("restore: extracted %s from %s", english.Plural(count, "sidecar file", "sidecar files"), clean.Log(filepath.Base(databaseFile)))
+		}
+	}
+
 	// Restore albums from YAML backup files?
 	if restoreAlbums {
 		get.SetConfig(conf)
@@ -100,6 +124,15 @@ func restoreAction(ctx *cli.Context) error {
 			albumsPath = conf.BackupAlbumsPath()
 		}
 
+		// Extract album YAML files from backup archives first.
+		if archiveFile != "" {
+			if count, extractErr := backup.ExtractAlbums(archiveFile, albumsPath); extractErr != nil {
+				log.Warnf("restore: %s (extract albums)", extractErr)
+			} else if count > 0 {
+				log.Infof("restore: extracted %s from %s", english.Plural(count, "album file", "album files"), clean.Log(filepath.Base(databaseFile)))
+			}
+		}
+
 		if !fs.PathExists(albumsPath) {
 			log.Warnf("restore: failed to open %s, album backups cannot be restored", clean.Log(albumsPath))
 		} else {
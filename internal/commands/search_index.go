@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli/v2"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// SearchIndexCommands configures the full-text search index subcommands.
+var SearchIndexCommands = &cli.Command{
+	Name:  "search-index",
+	Usage: "Full-text search index subcommands",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "status",
+			Usage:  "Shows which full-text search engine is used",
+			Action: searchIndexStatusAction,
+		},
+		{
+			Name:   "rebuild",
+			Usage:  "Recreates the full-text search index for all photos",
+			Action: searchIndexRebuildAction,
+		},
+	},
+}
+
+// searchIndexStatusAction shows which full-text search engine is used.
+func searchIndexStatusAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		if engine := entity.SearchIndexEngine(); engine == entity.SearchIndexNone {
+			log.Warnf("search index does not exist, run \"photoprism search-index rebuild\" to create it")
+		} else {
+			log.Infof("search index uses %s", engine)
+		}
+
+		return nil
+	})
+}
+
+// searchIndexRebuildAction recreates the full-text search index.
+func searchIndexRebuildAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		start := time.Now()
+
+		conf.MigrateDb(false, nil)
+
+		indexed, err := entity.RebuildSearchIndex()
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("indexed %s in %s", english.Plural(indexed, "photo", "photos"), time.Since(start))
+
+		return nil
+	})
+}
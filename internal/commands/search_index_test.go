@@ -0,0 +1,23 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestSearchIndexCommands(t *testing.T) {
+	t.Run("Rebuild", func(t *testing.T) {
+		_, err := RunWithTestContext(SearchIndexCommands.Subcommands[1], []string{"rebuild"})
+
+		assert.NoError(t, err)
+		assert.NotEqual(t, entity.SearchIndexNone, entity.SearchIndexEngine())
+	})
+	t.Run("Status", func(t *testing.T) {
+		_, err := RunWithTestContext(SearchIndexCommands.Subcommands[0], []string{"status"})
+
+		assert.NoError(t, err)
+	})
+}
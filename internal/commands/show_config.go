@@ -29,6 +29,9 @@ var ConfigReports = []Report{
 	{Title: "OpenID Connect (OIDC)", NoWrap: true, Report: func(conf *config.Config) ([][]string, []string) {
 		return conf.OIDCReport()
 	}},
+	{Title: "LDAP / Active Directory", NoWrap: true, Report: func(conf *config.Config) ([][]string, []string) {
+		return conf.LDAPReport()
+	}},
 }
 
 // showConfigAction displays global config option names and values.
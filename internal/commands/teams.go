@@ -0,0 +1,56 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// TeamsCommands configures the team management subcommands.
+var TeamsCommands = &cli.Command{
+	Name:    "teams",
+	Aliases: []string{"team"},
+	Usage:   "Team management subcommands",
+	Subcommands: []*cli.Command{
+		TeamsListCommand,
+		TeamsAddCommand,
+		TeamsShowCommand,
+		TeamsRemoveCommand,
+		TeamsAssignCommand,
+		TeamsUnassignCommand,
+	},
+}
+
+// findTeam returns the team with the specified UID or slug, or an error if it was not found.
+func findTeam(id string) (*entity.Team, error) {
+	if id == "" {
+		return nil, fmt.Errorf("no team specified")
+	} else if m := entity.FindTeam(id); m != nil {
+		return m, nil
+	}
+
+	return nil, fmt.Errorf("team %s not found", clean.LogQuote(id))
+}
+
+// findTeamUser returns the registered user with the specified name or UID, or an error if it was not found.
+func findTeamUser(id string) (*entity.User, error) {
+	var m *entity.User
+
+	if id = clean.Username(id); id == "" {
+		return nil, fmt.Errorf("no user specified")
+	} else if rnd.IsUID(id, entity.UserUID) {
+		m = entity.FindUserByUID(id)
+	} else {
+		m = entity.FindUserByName(id)
+	}
+
+	if m == nil || !m.IsRegistered() {
+		return nil, fmt.Errorf("user %s not found", clean.LogQuote(id))
+	}
+
+	return m, nil
+}
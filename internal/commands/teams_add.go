@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt/report"
+)
+
+// TeamsAddCommand configures the command name, flags, and action.
+var TeamsAddCommand = &cli.Command{
+	Name:        "add",
+	Usage:       "Adds a new team whose members can share photos and albums",
+	Description: "Example: photoprism teams add Smith Household",
+	ArgsUsage:   "[name]",
+	Flags:       report.CliFlags,
+	Action:      teamsAddAction,
+}
+
+// teamsAddAction adds a new team.
+func teamsAddAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		conf.MigrateDb(false, nil)
+
+		name := strings.TrimSpace(strings.Join(ctx.Args().Slice(), " "))
+
+		// Name provided?
+		if name == "" {
+			log.Infof("no team name specified")
+			return cli.ShowSubcommandHelp(ctx)
+		}
+
+		m, err := entity.AddTeam(name)
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("successfully added new team %s", clean.Log(m.TeamUID))
+
+		// Display team details.
+		cols := []string{"Team ID", "Slug", "Name", "Created At"}
+		rows := [][]string{{
+			m.TeamUID,
+			m.TeamSlug,
+			m.TeamName,
+			m.CreatedAt.Format("2006-01-02 15:04:05"),
+		}}
+
+		result, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
+
+		fmt.Printf("\n%s\n", result)
+
+		return err
+	})
+}
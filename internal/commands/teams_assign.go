@@ -0,0 +1,87 @@
+package commands
+
+import (
+	"github.com/urfave/cli/v2"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// TeamsAssignCommand configures the command name, flags, and action.
+var TeamsAssignCommand = &cli.Command{
+	Name:        "assign",
+	Usage:       "Adds users to a team",
+	Description: "Example: photoprism teams assign smith-household alice bob",
+	ArgsUsage:   "[team] [usernames]",
+	Action:      teamsAssignAction,
+}
+
+// TeamsUnassignCommand configures the command name, flags, and action.
+var TeamsUnassignCommand = &cli.Command{
+	Name:      "unassign",
+	Usage:     "Removes users from a team",
+	ArgsUsage: "[team] [usernames]",
+	Action:    teamsUnassignAction,
+}
+
+// teamsAssignAction adds users to a team.
+func teamsAssignAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		conf.MigrateDb(false, nil)
+
+		if ctx.Args().Len() < 2 {
+			return cli.ShowSubcommandHelp(ctx)
+		}
+
+		m, err := findTeam(ctx.Args().First())
+
+		if err != nil {
+			return err
+		}
+
+		for _, id := range ctx.Args().Tail() {
+			u, findErr := findTeamUser(id)
+
+			if findErr != nil {
+				return findErr
+			} else if err = m.AddMember(u); err != nil {
+				return err
+			}
+
+			log.Infof("user %s has been added to team %s", clean.Log(u.Username()), m.String())
+		}
+
+		return nil
+	})
+}
+
+// teamsUnassignAction removes users from a team.
+func teamsUnassignAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		conf.MigrateDb(false, nil)
+
+		if ctx.Args().Len() < 2 {
+			return cli.ShowSubcommandHelp(ctx)
+		}
+
+		m, err := findTeam(ctx.Args().First())
+
+		if err != nil {
+			return err
+		}
+
+		for _, id := range ctx.Args().Tail() {
+			u, findErr := findTeamUser(id)
+
+			if findErr != nil {
+				return findErr
+			} else if err = m.RemoveMember(u); err != nil {
+				return err
+			}
+
+			log.Infof("user %s has been removed from team %s", clean.Log(u.Username()), m.String())
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,57 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli/v2"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/txt/report"
+)
+
+// TeamsListCommand configures the command name, flags, and action.
+var TeamsListCommand = &cli.Command{
+	Name:   "ls",
+	Usage:  "Lists teams and the number of members",
+	Flags:  report.CliFlags,
+	Action: teamsListAction,
+}
+
+// teamsListAction lists teams.
+func teamsListAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		cols := []string{"Team ID", "Slug", "Name", "Members", "Created At"}
+
+		// Fetch teams from database.
+		teams := entity.FindTeams()
+
+		if len(teams) == 0 {
+			log.Warnf("no teams found")
+			return nil
+		}
+
+		// Show log message.
+		log.Infof("found %s", english.Plural(len(teams), "team", "teams"))
+
+		rows := make([][]string, len(teams))
+
+		// Display report.
+		for i, m := range teams {
+			rows[i] = []string{
+				m.TeamUID,
+				m.TeamSlug,
+				m.TeamName,
+				fmt.Sprintf("%d", len(m.Members())),
+				m.CreatedAt.Format("2006-01-02 15:04:05"),
+			}
+		}
+
+		result, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
+
+		fmt.Printf("\n%s\n", result)
+
+		return err
+	})
+}
@@ -0,0 +1,66 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/manifoldco/promptui"
+	"github.com/urfave/cli/v2"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+// TeamsRemoveCommand configures the command name, flags, and action.
+var TeamsRemoveCommand = &cli.Command{
+	Name:      "rm",
+	Usage:     "Deletes the specified team and the permissions granted to it",
+	ArgsUsage: "[team]",
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:    "force",
+			Aliases: []string{"f"},
+			Usage:   "skips asking for confirmation",
+		},
+	},
+	Action: teamsRemoveAction,
+}
+
+// teamsRemoveAction deletes a team.
+func teamsRemoveAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		conf.MigrateDb(false, nil)
+
+		id := ctx.Args().First()
+
+		// Name or UID provided?
+		if id == "" {
+			log.Infof("no team specified")
+			return cli.ShowSubcommandHelp(ctx)
+		}
+
+		m, err := findTeam(id)
+
+		if err != nil {
+			return err
+		}
+
+		if !ctx.Bool("force") && !RunNonInteractively(false) {
+			actionPrompt := promptui.Prompt{
+				Label:     fmt.Sprintf("Delete team %s?", m.String()),
+				IsConfirm: true,
+			}
+
+			if _, err = actionPrompt.Run(); err != nil {
+				log.Infof("team %s was not deleted", m.String())
+				return nil
+			}
+		}
+
+		if err = m.Delete(); err != nil {
+			return err
+		}
+
+		log.Infof("team %s has been deleted", m.String())
+
+		return nil
+	})
+}
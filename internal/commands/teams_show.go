@@ -0,0 +1,55 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli/v2"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/txt/report"
+)
+
+// TeamsShowCommand configures the command name, flags, and action.
+var TeamsShowCommand = &cli.Command{
+	Name:      "show",
+	Usage:     "Shows the members of a team",
+	ArgsUsage: "[team]",
+	Flags:     report.CliFlags,
+	Action:    teamsShowAction,
+}
+
+// teamsShowAction shows the members of a team.
+func teamsShowAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		id := ctx.Args().First()
+
+		// Name or UID provided?
+		if id == "" {
+			return cli.ShowSubcommandHelp(ctx)
+		}
+
+		m, err := findTeam(id)
+
+		if err != nil {
+			return err
+		}
+
+		members := m.Members()
+
+		log.Infof("team %s has %s", m.String(), english.Plural(len(members), "member", "members"))
+
+		cols := []string{"User ID", "Username", "Display Name", "Role"}
+		rows := make([][]string, len(members))
+
+		for i, u := range members {
+			rows[i] = []string{u.UserUID, u.Username(), u.DisplayName, u.AclRole().String()}
+		}
+
+		result, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
+
+		fmt.Printf("\n%s\n", result)
+
+		return err
+	})
+}
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTeamsCommands(t *testing.T) {
+	t.Run("AddAssignRemove", func(t *testing.T) {
+		output, err := RunWithTestContext(TeamsAddCommand, []string{"add", "CLI", "Test", "Team"})
+
+		assert.NoError(t, err)
+		assert.Contains(t, output, "cli-test-team")
+		assert.Contains(t, output, "CLI Test Team")
+
+		uid := regexp.MustCompile(`tt[0-9a-z]{14}`).FindString(output)
+
+		if uid == "" {
+			t.Fatal("team uid not found")
+		}
+
+		_, err = RunWithTestContext(TeamsAssignCommand, []string{"assign", "cli-test-team", "alice", "uqxc08w3d0ej2283"})
+
+		assert.NoError(t, err)
+
+		output, err = RunWithTestContext(TeamsShowCommand, []string{"show", uid})
+
+		assert.NoError(t, err)
+		assert.Contains(t, output, "alice")
+		assert.Contains(t, output, "bob")
+
+		_, err = RunWithTestContext(TeamsUnassignCommand, []string{"unassign", uid, "bob"})
+
+		assert.NoError(t, err)
+
+		output, err = RunWithTestContext(TeamsShowCommand, []string{"show", uid})
+
+		assert.NoError(t, err)
+		assert.Contains(t, output, "alice")
+		assert.NotContains(t, output, "bob")
+
+		_, err = RunWithTestContext(TeamsRemoveCommand, []string{"rm", "--force", uid})
+
+		assert.NoError(t, err)
+
+		_, err = RunWithTestContext(TeamsShowCommand, []string{"show", uid})
+
+		assert.Error(t, err)
+	})
+	t.Run("AddExisting", func(t *testing.T) {
+		_, err := RunWithTestContext(TeamsAddCommand, []string{"add", "Smith Household"})
+		assert.Error(t, err)
+	})
+	t.Run("AssignUnknownUser", func(t *testing.T) {
+		_, err := RunWithTestContext(TeamsAssignCommand, []string{"assign", "smith-household", "nobody"})
+		assert.Error(t, err)
+	})
+	t.Run("List", func(t *testing.T) {
+		output, err := RunWithTestContext(TeamsListCommand, []string{"ls"})
+
+		assert.NoError(t, err)
+		assert.Contains(t, output, "tt2hdkz1vgdb3ta2")
+		assert.Contains(t, output, "Smith Household")
+	})
+}
@@ -40,6 +40,7 @@ var UsersCommands = &cli.Command{
 		UsersModCommand,
 		UsersRemoveCommand,
 		UsersResetCommand,
+		UsersQuotaCommand,
 	},
 }
 
@@ -0,0 +1,323 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/dustin/go-humanize"
+	"github.com/dustin/go-humanize/english"
+	"github.com/manifoldco/promptui"
+	"github.com/urfave/cli/v2"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt/report"
+)
+
+// Usage hints for the storage quota subcommands.
+const (
+	QuotaRoleUsage  = "applies the quota to all accounts with the specified `ROLE` instead of a single user"
+	QuotaSizeUsage  = "maximum storage `SIZE` of original files, e.g. 500MB or 20GiB (0 for unlimited)"
+	QuotaFilesUsage = "maximum `NUMBER` of original files (0 for unlimited)"
+	QuotaUnlimited  = "unlimited"
+)
+
+// UsersQuotaCommand configures the storage quota subcommands.
+var UsersQuotaCommand = &cli.Command{
+	Name:  "quota",
+	Usage: "Storage quota subcommands",
+	Subcommands: []*cli.Command{
+		UsersQuotaListCommand,
+		UsersQuotaShowCommand,
+		UsersQuotaSetCommand,
+		UsersQuotaRemoveCommand,
+	},
+}
+
+// UsersQuotaListCommand configures the command name, flags, and action.
+var UsersQuotaListCommand = &cli.Command{
+	Name:   "ls",
+	Usage:  "Lists user and role quotas",
+	Flags:  report.CliFlags,
+	Action: usersQuotaListAction,
+}
+
+// UsersQuotaShowCommand configures the command name, flags, and action.
+var UsersQuotaShowCommand = &cli.Command{
+	Name:      "show",
+	Usage:     "Shows the storage usage and quota of a user account",
+	ArgsUsage: "[username]",
+	Flags:     report.CliFlags,
+	Action:    usersQuotaShowAction,
+}
+
+// UsersQuotaSetCommand configures the command name, flags, and action.
+var UsersQuotaSetCommand = &cli.Command{
+	Name:      "set",
+	Usage:     "Sets the storage quota of a user account or role",
+	ArgsUsage: "[username]",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:    "role",
+			Aliases: []string{"r"},
+			Usage:   QuotaRoleUsage,
+		},
+		&cli.StringFlag{
+			Name:    "size",
+			Aliases: []string{"s"},
+			Usage:   QuotaSizeUsage,
+		},
+		&cli.IntFlag{
+			Name:    "files",
+			Aliases: []string{"n"},
+			Usage:   QuotaFilesUsage,
+		},
+	},
+	Action: usersQuotaSetAction,
+}
+
+// UsersQuotaRemoveCommand configures the command name, flags, and action.
+var UsersQuotaRemoveCommand = &cli.Command{
+	Name:      "rm",
+	Usage:     "Removes the storage quota of a user account or role",
+	ArgsUsage: "[username]",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:    "role",
+			Aliases: []string{"r"},
+			Usage:   QuotaRoleUsage,
+		},
+		&cli.BoolFlag{
+			Name:    "force",
+			Aliases: []string{"f"},
+			Usage:   "skips asking for confirmation",
+		},
+	},
+	Action: usersQuotaRemoveAction,
+}
+
+// usersQuotaListAction lists all storage quotas.
+func usersQuotaListAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		cols := []string{"Type", "Subject", "Max Size", "Max Files", "Updated At"}
+
+		quotas, err := query.Quotas()
+
+		if err != nil {
+			return err
+		}
+
+		if len(quotas) == 0 {
+			log.Warnf("no quotas found")
+			return nil
+		}
+
+		// Show log message.
+		log.Infof("found %s", english.Plural(len(quotas), "quota", "quotas"))
+
+		rows := make([][]string, len(quotas))
+
+		for i, m := range quotas {
+			rows[i] = []string{
+				m.SubjectType,
+				quotaSubjectName(m),
+				quotaSize(m.MaxSize),
+				quotaFiles(m.MaxFiles),
+				report.DateTime(&m.UpdatedAt),
+			}
+		}
+
+		result, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
+
+		fmt.Printf("\n%s\n", result)
+
+		return err
+	})
+}
+
+// usersQuotaShowAction shows the storage usage and quota of a user account.
+func usersQuotaShowAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		id := clean.Username(ctx.Args().First())
+
+		// Name or UID provided?
+		if id == "" {
+			return cli.ShowSubcommandHelp(ctx)
+		}
+
+		user := quotaFindUser(id)
+
+		if user == nil {
+			return fmt.Errorf("user %s not found", clean.LogQuote(id))
+		}
+
+		size, files, err := query.UserStorageUsage(user.UserUID)
+
+		if err != nil {
+			return err
+		}
+
+		source := QuotaUnlimited
+		quota := entity.UserQuota(user)
+
+		if quota == nil {
+			quota = &entity.Quota{}
+		} else {
+			source = fmt.Sprintf("%s %s", quota.SubjectType, quotaSubjectName(*quota))
+		}
+
+		cols := []string{"Name", "Value"}
+		rows := [][]string{
+			{"Username", user.Username()},
+			{"Role", user.AclRole().String()},
+			{"Used Size", humanize.IBytes(uint64(size))},
+			{"Used Files", fmt.Sprintf("%d", files)},
+			{"Max Size", quotaSize(quota.MaxSize)},
+			{"Max Files", quotaFiles(quota.MaxFiles)},
+			{"Quota", source},
+		}
+
+		result, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
+
+		fmt.Printf("\n%s\n", result)
+
+		return err
+	})
+}
+
+// usersQuotaSetAction sets the storage quota of a user account or role.
+func usersQuotaSetAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		conf.MigrateDb(false, nil)
+
+		subjectType, subject, err := quotaSubject(ctx)
+
+		if err != nil {
+			return err
+		} else if subject == "" {
+			return cli.ShowSubcommandHelp(ctx)
+		}
+
+		var maxSize uint64
+
+		if s := ctx.String("size"); s != "" {
+			if maxSize, err = humanize.ParseBytes(s); err != nil {
+				return fmt.Errorf("invalid size %s", clean.LogQuote(s))
+			}
+		}
+
+		m, err := entity.SetQuota(subjectType, subject, int64(maxSize), ctx.Int("files"))
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("quota for %s %s has been set to %s and %s files", m.SubjectType, clean.LogQuote(quotaSubjectName(*m)), quotaSize(m.MaxSize), quotaFiles(m.MaxFiles))
+
+		return nil
+	})
+}
+
+// usersQuotaRemoveAction removes the storage quota of a user account or role.
+func usersQuotaRemoveAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		conf.MigrateDb(false, nil)
+
+		subjectType, subject, err := quotaSubject(ctx)
+
+		if err != nil {
+			return err
+		} else if subject == "" {
+			return cli.ShowSubcommandHelp(ctx)
+		}
+
+		m := entity.FindQuota(subjectType, subject)
+
+		if m == nil {
+			return fmt.Errorf("quota for %s %s not found", subjectType, clean.LogQuote(subject))
+		}
+
+		name := quotaSubjectName(*m)
+
+		if !ctx.Bool("force") && !RunNonInteractively(false) {
+			actionPrompt := promptui.Prompt{
+				Label:     fmt.Sprintf("Remove quota for %s %s?", m.SubjectType, clean.LogQuote(name)),
+				IsConfirm: true,
+			}
+
+			if _, err = actionPrompt.Run(); err != nil {
+				log.Infof("quota for %s %s was not removed", m.SubjectType, clean.LogQuote(name))
+				return nil
+			}
+		}
+
+		if err = m.Delete(); err != nil {
+			return err
+		}
+
+		log.Infof("quota for %s %s has been removed", m.SubjectType, clean.LogQuote(name))
+
+		return nil
+	})
+}
+
+// quotaSubject returns the quota subject type and UID or role specified in the command context.
+func quotaSubject(ctx *cli.Context) (subjectType, subject string, err error) {
+	if role := clean.Role(ctx.String("role")); role != "" {
+		return entity.QuotaRole, role, nil
+	}
+
+	id := clean.Username(ctx.Args().First())
+
+	if id == "" {
+		return entity.QuotaUser, "", nil
+	}
+
+	user := quotaFindUser(id)
+
+	if user == nil {
+		return entity.QuotaUser, "", fmt.Errorf("user %s not found", clean.LogQuote(id))
+	}
+
+	return entity.QuotaUser, user.UserUID, nil
+}
+
+// quotaFindUser finds a user account by name or UID.
+func quotaFindUser(id string) *entity.User {
+	if rnd.IsUID(id, entity.UserUID) {
+		return entity.FindUserByUID(id)
+	}
+
+	return entity.FindUserByName(id)
+}
+
+// quotaSubjectName returns the username or role name of the quota subject.
+func quotaSubjectName(m entity.Quota) string {
+	if m.SubjectType == entity.QuotaUser {
+		if user := entity.FindUserByUID(m.SubjectUID); user != nil {
+			return user.Username()
+		}
+	}
+
+	return m.SubjectUID
+}
+
+// quotaSize returns the quota size in human-readable form.
+func quotaSize(size int64) string {
+	if size <= 0 {
+		return QuotaUnlimited
+	}
+
+	return humanize.IBytes(uint64(size))
+}
+
+// quotaFiles returns the maximum number of files in human-readable form.
+func quotaFiles(files int) string {
+	if files <= 0 {
+		return QuotaUnlimited
+	}
+
+	return fmt.Sprintf("%d", files)
+}
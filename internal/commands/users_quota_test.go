@@ -0,0 +1,61 @@
+package commands
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUsersQuotaCommand(t *testing.T) {
+	t.Run("SetShowRemoveUser", func(t *testing.T) {
+		_, err := RunWithTestContext(UsersQuotaSetCommand, []string{"set", "--size=2GiB", "--files=500", "bob"})
+
+		assert.NoError(t, err)
+
+		output, err := RunWithTestContext(UsersQuotaShowCommand, []string{"show", "bob"})
+
+		assert.NoError(t, err)
+		assert.Contains(t, output, "2.0 GiB")
+		assert.Contains(t, output, "500")
+		assert.Contains(t, output, "user bob")
+
+		output, err = RunWithTestContext(UsersQuotaListCommand, []string{"ls"})
+
+		assert.NoError(t, err)
+		assert.Contains(t, output, "bob")
+		assert.Contains(t, output, "2.0 GiB")
+
+		_, err = RunWithTestContext(UsersQuotaRemoveCommand, []string{"rm", "--force", "bob"})
+
+		assert.NoError(t, err)
+
+		output, err = RunWithTestContext(UsersQuotaShowCommand, []string{"show", "bob"})
+
+		assert.NoError(t, err)
+		assert.Contains(t, output, "unlimited")
+	})
+	t.Run("SetRemoveRole", func(t *testing.T) {
+		_, err := RunWithTestContext(UsersQuotaSetCommand, []string{"set", "--role=guest", "--files=10"})
+
+		assert.NoError(t, err)
+
+		output, err := RunWithTestContext(UsersQuotaListCommand, []string{"ls"})
+
+		assert.NoError(t, err)
+		assert.Contains(t, output, "guest")
+
+		_, err = RunWithTestContext(UsersQuotaRemoveCommand, []string{"rm", "--force", "--role=guest"})
+
+		assert.NoError(t, err)
+	})
+	t.Run("InvalidSize", func(t *testing.T) {
+		_, err := RunWithTestContext(UsersQuotaSetCommand, []string{"set", "--size=lots", "bob"})
+
+		assert.Error(t, err)
+	})
+	t.Run("UserNotFound", func(t *testing.T) {
+		_, err := RunWithTestContext(UsersQuotaShowCommand, []string{"show", "notexisting"})
+
+		assert.Error(t, err)
+	})
+}
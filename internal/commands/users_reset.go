@@ -57,7 +57,7 @@ func usersResetAction(ctx *cli.Context) error {
 		db := conf.Db()
 
 		// Drop existing user management tables.
-		if err := db.DropTableIfExists(entity.User{}, entity.UserDetails{}, entity.UserSettings{}, entity.UserShare{}, entity.Passcode{}, entity.Session{}).Error; err != nil {
+		if err := db.DropTableIfExists(entity.User{}, entity.UserDetails{}, entity.UserSettings{}, entity.UserShare{}, entity.Team{}, entity.TeamUser{}, entity.Passcode{}, entity.Session{}).Error; err != nil {
 			return err
 		}
 
@@ -81,6 +81,16 @@ func usersResetAction(ctx *cli.Context) error {
 			return err
 		}
 
+		// Re-create auth_teams.
+		if err := db.CreateTable(entity.Team{}).Error; err != nil {
+			return err
+		}
+
+		// Re-create auth_teams_users.
+		if err := db.CreateTable(entity.TeamUser{}).Error; err != nil {
+			return err
+		}
+
 		// Re-create passcodes.
 		if err := db.CreateTable(entity.Passcode{}).Error; err != nil {
 			return err
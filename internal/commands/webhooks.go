@@ -0,0 +1,90 @@
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/photoprism/photoprism/internal/service/webhook"
+)
+
+// Usage hints for the webhook management subcommands.
+const (
+	WebhookNameUsage        = "`NAME` to help identify the webhook"
+	WebhookUrlUsage         = "endpoint `URL` that receives the event notifications"
+	WebhookRegenerateSecret = "set a new randomly generated signing secret"
+	WebhookEnable           = "enable event notifications if disabled"
+	WebhookDisable          = "disable event notifications"
+	WebhookSecretInfo       = "\nPLEASE WRITE DOWN THE %s SIGNING SECRET, AS YOU WILL NOT BE ABLE TO SEE IT AGAIN:" //nolint:gosec // informational message only
+)
+
+var (
+	// WebhookEventsUsage describes the supported event topics for CLI help.
+	WebhookEventsUsage = fmt.Sprintf("subscribed event `TOPICS`, e.g. %s, or '*' for all events", strings.Join(webhook.Events, ", "))
+)
+
+// WebhooksCommands configures the webhook management subcommands.
+var WebhooksCommands = &cli.Command{
+	Name:    "webhooks",
+	Aliases: []string{"webhook"},
+	Usage:   "Webhook management subcommands",
+	Subcommands: []*cli.Command{
+		WebhooksListCommand,
+		WebhooksAddCommand,
+		WebhooksShowCommand,
+		WebhooksModCommand,
+		WebhooksRemoveCommand,
+		WebhooksDeliveriesCommand,
+		WebhooksReplayCommand,
+	},
+}
+
+// WebhookAddFlags specifies the "photoprism webhooks add" command flags.
+var WebhookAddFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:    "name",
+		Aliases: []string{"n"},
+		Usage:   WebhookNameUsage,
+	},
+	&cli.StringSliceFlag{
+		Name:    "events",
+		Aliases: []string{"e"},
+		Usage:   WebhookEventsUsage,
+	},
+	&cli.BoolFlag{
+		Name:  "disable",
+		Usage: WebhookDisable,
+	},
+}
+
+// WebhookModFlags specifies the "photoprism webhooks mod" command flags.
+var WebhookModFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:    "name",
+		Aliases: []string{"n"},
+		Usage:   WebhookNameUsage,
+	},
+	&cli.StringFlag{
+		Name:    "url",
+		Aliases: []string{"u"},
+		Usage:   WebhookUrlUsage,
+	},
+	&cli.StringSliceFlag{
+		Name:    "events",
+		Aliases: []string{"e"},
+		Usage:   WebhookEventsUsage,
+	},
+	&cli.BoolFlag{
+		Name:  "regenerate",
+		Usage: WebhookRegenerateSecret,
+	},
+	&cli.BoolFlag{
+		Name:  "enable",
+		Usage: WebhookEnable,
+	},
+	&cli.BoolFlag{
+		Name:  "disable",
+		Usage: WebhookDisable,
+	},
+}
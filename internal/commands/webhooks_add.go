@@ -0,0 +1,68 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt/report"
+)
+
+// WebhooksAddCommand configures the command name, flags, and action.
+var WebhooksAddCommand = &cli.Command{
+	Name:        "add",
+	Usage:       "Registers a new webhook endpoint",
+	Description: "The endpoint receives a signed POST request for each subscribed event, see https://www.standardwebhooks.com/.",
+	ArgsUsage:   "[url]",
+	Flags:       WebhookAddFlags,
+	Action:      webhooksAddAction,
+}
+
+// webhooksAddAction registers a new webhook endpoint.
+func webhooksAddAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		conf.MigrateDb(false, nil)
+
+		frm := form.AddWebhookFromCli(ctx)
+
+		// URL provided?
+		if frm.WebhookURL == "" {
+			log.Infof("no endpoint url specified")
+			return cli.ShowSubcommandHelp(ctx)
+		}
+
+		m, err := entity.AddWebhook(frm)
+
+		if err != nil {
+			return err
+		}
+
+		log.Infof("successfully registered new webhook %s", clean.Log(m.WebhookUID))
+
+		// Display webhook details.
+		cols := []string{"Webhook ID", "Name", "URL", "Events", "Enabled", "Created At"}
+		rows := [][]string{{
+			m.WebhookUID,
+			m.WebhookName,
+			m.WebhookURL,
+			m.WebhookEvents,
+			report.Bool(m.WebhookEnabled, report.Yes, report.No),
+			m.CreatedAt.Format("2006-01-02 15:04:05"),
+		}}
+
+		if result, renderErr := report.RenderFormat(rows, cols, report.CliFormat(ctx)); renderErr == nil {
+			fmt.Printf("\n%s", result)
+		}
+
+		// Show signing secret.
+		fmt.Printf(WebhookSecretInfo+"\n", "FOLLOWING RANDOMLY GENERATED")
+		result := report.Credentials("Webhook ID", m.WebhookUID, "Signing Secret", m.WebhookSecret)
+		fmt.Printf("\n%s\n", result)
+
+		return nil
+	})
+}
@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli/v2"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt/report"
+)
+
+// WebhooksDeliveriesCommand configures the command name, flags, and action.
+var WebhooksDeliveriesCommand = &cli.Command{
+	Name:      "deliveries",
+	Aliases:   []string{"log"},
+	Usage:     "Shows the delivery log of a webhook",
+	ArgsUsage: "[webhook id]",
+	Flags: append(report.CliFlags, CountFlag, &cli.StringFlag{
+		Name:    "status",
+		Aliases: []string{"s"},
+		Usage:   fmt.Sprintf("only show deliveries with the specified `STATUS`, e.g. %s, %s, or %s", entity.WebhookPending, entity.WebhookDelivered, entity.WebhookFailed),
+	}),
+	Action: webhooksDeliveriesAction,
+}
+
+// webhooksDeliveriesAction displays the delivery log of a webhook.
+func webhooksDeliveriesAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		id := clean.UID(ctx.Args().First())
+
+		// UID provided?
+		if id == "" {
+			return cli.ShowSubcommandHelp(ctx)
+		}
+
+		cols := []string{"ID", "Event", "Status", "Attempts", "Response", "Error", "Next Attempt", "Created At"}
+
+		// Fetch deliveries from database.
+		deliveries, err := query.WebhookDeliveries(id, clean.TypeLowerUnderscore(ctx.String("status")), ctx.Int("count"), 0)
+
+		if err != nil {
+			return err
+		}
+
+		if len(deliveries) == 0 {
+			log.Warnf("no deliveries found")
+			return nil
+		}
+
+		// Show log message.
+		log.Infof("found %s", english.Plural(len(deliveries), "delivery", "deliveries"))
+
+		rows := make([][]string, len(deliveries))
+
+		// Display report.
+		for i, d := range deliveries {
+			var response, nextAttempt string
+
+			if d.ResponseCode > 0 {
+				response = fmt.Sprintf("%d", d.ResponseCode)
+			}
+
+			if d.Status == entity.WebhookPending {
+				nextAttempt = d.NextAttempt.Format("2006-01-02 15:04:05")
+			}
+
+			rows[i] = []string{
+				fmt.Sprintf("%d", d.ID),
+				d.Event,
+				d.Status,
+				fmt.Sprintf("%d", d.Attempts),
+				response,
+				d.Error,
+				nextAttempt,
+				d.CreatedAt.Format("2006-01-02 15:04:05"),
+			}
+		}
+
+		result, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
+
+		fmt.Printf("\n%s\n", result)
+
+		return err
+	})
+}
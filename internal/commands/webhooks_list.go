@@ -0,0 +1,64 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli/v2"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/pkg/txt/report"
+)
+
+// WebhooksListCommand configures the command name, flags, and action.
+var WebhooksListCommand = &cli.Command{
+	Name:      "ls",
+	Usage:     "Lists registered webhooks",
+	ArgsUsage: "[search]",
+	Flags:     append(report.CliFlags, CountFlag),
+	Action:    webhooksListAction,
+}
+
+// webhooksListAction lists registered webhooks.
+func webhooksListAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		cols := []string{"Webhook ID", "Name", "URL", "Events", "Enabled", "Errors", "Created At"}
+
+		// Fetch webhooks from database.
+		webhooks, err := query.Webhooks(ctx.Int("count"), 0, ctx.Args().First())
+
+		if err != nil {
+			return err
+		}
+
+		rows := make([][]string, len(webhooks))
+
+		if len(webhooks) == 0 {
+			log.Warnf("no webhooks registered")
+			return nil
+		}
+
+		// Show log message.
+		log.Infof("found %s", english.Plural(len(webhooks), "webhook", "webhooks"))
+
+		// Display report.
+		for i, m := range webhooks {
+			rows[i] = []string{
+				m.WebhookUID,
+				m.WebhookName,
+				m.WebhookURL,
+				m.WebhookEvents,
+				report.Bool(m.WebhookEnabled, report.Yes, report.No),
+				fmt.Sprintf("%d", m.WebhookErrors),
+				m.CreatedAt.Format("2006-01-02 15:04:05"),
+			}
+		}
+
+		result, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
+
+		fmt.Printf("\n%s\n", result)
+
+		return err
+	})
+}
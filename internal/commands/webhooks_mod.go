@@ -0,0 +1,80 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt/report"
+)
+
+// WebhooksModCommand configures the command name, flags, and action.
+var WebhooksModCommand = &cli.Command{
+	Name:      "mod",
+	Usage:     "Updates webhook settings",
+	ArgsUsage: "[webhook id]",
+	Flags:     WebhookModFlags,
+	Action:    webhooksModAction,
+}
+
+// webhooksModAction updates webhook settings.
+func webhooksModAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		conf.MigrateDb(false, nil)
+
+		id := clean.UID(ctx.Args().First())
+
+		// UID provided?
+		if id == "" {
+			log.Infof("no valid webhook id specified")
+			return cli.ShowSubcommandHelp(ctx)
+		}
+
+		// Find webhook record.
+		m := entity.FindWebhook(id)
+
+		if m == nil {
+			return fmt.Errorf("webhook %s not found", clean.Log(id))
+		}
+
+		frm, err := form.NewWebhook(m)
+
+		if err != nil {
+			return err
+		}
+
+		// Update webhook from form values.
+		frm.ModWebhookFromCli(ctx)
+
+		if err = m.SaveForm(frm); err != nil {
+			return fmt.Errorf("invalid values: %s", err)
+		} else {
+			log.Infof("webhook %s has been updated", clean.Log(m.WebhookUID))
+		}
+
+		if m.WebhookEnabled {
+			log.Infof("event notifications are enabled")
+		} else {
+			log.Warnf("event notifications are disabled")
+		}
+
+		// Change signing secret if requested.
+		if ctx.Bool("regenerate") {
+			secret, secretErr := m.NewSecret()
+
+			if secretErr != nil {
+				return fmt.Errorf("failed to regenerate signing secret: %s", secretErr)
+			}
+
+			fmt.Printf(WebhookSecretInfo+"\n", "FOLLOWING RANDOMLY GENERATED")
+			result := report.Credentials("Webhook ID", m.WebhookUID, "Signing Secret", secret)
+			fmt.Printf("\n%s\n", result)
+		}
+
+		return nil
+	})
+}
@@ -0,0 +1,69 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/manifoldco/promptui"
+	"github.com/urfave/cli/v2"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// WebhooksRemoveCommand configures the command name, flags, and action.
+var WebhooksRemoveCommand = &cli.Command{
+	Name:      "rm",
+	Usage:     "Deletes the specified webhook",
+	ArgsUsage: "[webhook id]",
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:    "force",
+			Aliases: []string{"f"},
+			Usage:   "skips asking for confirmation",
+		},
+	},
+	Action: webhooksRemoveAction,
+}
+
+// webhooksRemoveAction deletes a registered webhook.
+func webhooksRemoveAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		conf.MigrateDb(false, nil)
+
+		id := clean.UID(ctx.Args().First())
+
+		// UID provided?
+		if id == "" {
+			log.Infof("no valid webhook id specified")
+			return cli.ShowSubcommandHelp(ctx)
+		}
+
+		// Find webhook record.
+		m := entity.FindWebhook(id)
+
+		if m == nil {
+			return fmt.Errorf("webhook %s not found", clean.Log(id))
+		}
+
+		if !ctx.Bool("force") && !RunNonInteractively(false) {
+			actionPrompt := promptui.Prompt{
+				Label:     fmt.Sprintf("Delete webhook %s?", m.WebhookUID),
+				IsConfirm: true,
+			}
+
+			if _, err := actionPrompt.Run(); err != nil {
+				log.Infof("webhook %s was not deleted", m.WebhookUID)
+				return nil
+			}
+		}
+
+		if err := m.Delete(); err != nil {
+			return err
+		}
+
+		log.Infof("webhook %s has been deleted", m.WebhookUID)
+
+		return nil
+	})
+}
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/urfave/cli/v2"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// WebhooksReplayCommand configures the command name, flags, and action.
+var WebhooksReplayCommand = &cli.Command{
+	Name:        "replay",
+	Usage:       "Resends failed webhook deliveries",
+	Description: "Resends all failed deliveries of the webhook, unless specific delivery IDs are passed as arguments.",
+	ArgsUsage:   "[webhook id] [delivery id]...",
+	Action:      webhooksReplayAction,
+}
+
+// webhooksReplayAction schedules failed webhook deliveries to be sent again.
+func webhooksReplayAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		conf.MigrateDb(false, nil)
+
+		id := clean.UID(ctx.Args().First())
+
+		// UID provided?
+		if id == "" {
+			log.Infof("no valid webhook id specified")
+			return cli.ShowSubcommandHelp(ctx)
+		}
+
+		// Find webhook record.
+		m := entity.FindWebhook(id)
+
+		if m == nil {
+			return fmt.Errorf("webhook %s not found", clean.Log(id))
+		}
+
+		// Parse delivery IDs, if any.
+		var ids []uint
+
+		for _, arg := range ctx.Args().Tail() {
+			if n, err := strconv.ParseUint(arg, 10, 32); err != nil || n == 0 {
+				return fmt.Errorf("invalid delivery id %s", clean.Log(arg))
+			} else {
+				ids = append(ids, uint(n))
+			}
+		}
+
+		deliveries, err := m.Replay(ids)
+
+		if err != nil {
+			return err
+		} else if len(deliveries) == 0 {
+			log.Infof("found no failed deliveries to resend")
+		} else {
+			log.Infof("%s will be resent", english.Plural(len(deliveries), "delivery", "deliveries"))
+		}
+
+		return nil
+	})
+}
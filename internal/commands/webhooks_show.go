@@ -0,0 +1,53 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/urfave/cli/v2"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt/report"
+)
+
+// WebhooksShowCommand configures the command name, flags, and action.
+var WebhooksShowCommand = &cli.Command{
+	Name:      "show",
+	Usage:     "Shows webhook configuration details",
+	ArgsUsage: "[webhook id]",
+	Flags:     report.CliFlags,
+	Action:    webhooksShowAction,
+}
+
+// webhooksShowAction displays the current webhook settings.
+func webhooksShowAction(ctx *cli.Context) error {
+	return CallWithDependencies(ctx, func(conf *config.Config) error {
+		id := clean.UID(ctx.Args().First())
+
+		// UID provided?
+		if id == "" {
+			return cli.ShowSubcommandHelp(ctx)
+		}
+
+		// Find webhook record.
+		m := entity.FindWebhook(id)
+
+		if m == nil {
+			return fmt.Errorf("webhook %s not found", clean.Log(id))
+		}
+
+		// Get webhook information.
+		rows, cols := m.Report(true)
+
+		// Sort values by name.
+		report.Sort(rows)
+
+		// Show webhook information.
+		result, err := report.RenderFormat(rows, cols, report.CliFormat(ctx))
+
+		fmt.Printf("\n%s\n", result)
+
+		return err
+	})
+}
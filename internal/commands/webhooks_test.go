@@ -0,0 +1,83 @@
+package commands
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhooksCommands(t *testing.T) {
+	t.Run("AddModRemove", func(t *testing.T) {
+		output, err := RunWithTestContext(WebhooksAddCommand, []string{"add", "--name=CLI Test", "--events=albums.*", "https://example.com/cli-test"})
+
+		assert.NoError(t, err)
+		assert.Contains(t, output, "Signing Secret")
+		assert.Contains(t, output, "whsec_")
+		assert.Contains(t, output, "albums.created,albums.updated,albums.deleted")
+
+		uid := regexp.MustCompile(`wt[0-9a-z]{14}`).FindString(output)
+
+		if uid == "" {
+			t.Fatal("webhook uid not found")
+		}
+
+		output, err = RunWithTestContext(WebhooksModCommand, []string{"mod", "--disable", "--events=photos.created", uid})
+
+		assert.NoError(t, err)
+		assert.NotContains(t, output, "Signing Secret")
+
+		output, err = RunWithTestContext(WebhooksShowCommand, []string{"show", uid})
+
+		assert.NoError(t, err)
+		assert.Contains(t, output, "photos.created")
+		assert.Contains(t, output, "https://example.com/cli-test")
+		assert.NotContains(t, output, "albums.created")
+
+		output, err = RunWithTestContext(WebhooksModCommand, []string{"mod", "--regenerate", uid})
+
+		assert.NoError(t, err)
+		assert.Contains(t, output, "Signing Secret")
+
+		_, err = RunWithTestContext(WebhooksRemoveCommand, []string{"rm", "--force", uid})
+
+		assert.NoError(t, err)
+
+		_, err = RunWithTestContext(WebhooksShowCommand, []string{"show", uid})
+
+		assert.Error(t, err)
+	})
+	t.Run("AddInvalidURL", func(t *testing.T) {
+		_, err := RunWithTestContext(WebhooksAddCommand, []string{"add", "example.com"})
+		assert.Error(t, err)
+	})
+	t.Run("List", func(t *testing.T) {
+		output, err := RunWithTestContext(WebhooksListCommand, []string{"ls"})
+
+		assert.NoError(t, err)
+		assert.Contains(t, output, "wt2hdkz1vgdb3ta2")
+		assert.Contains(t, output, "Automation")
+	})
+	t.Run("Show", func(t *testing.T) {
+		output, err := RunWithTestContext(WebhooksShowCommand, []string{"show", "wt2hdkz1vgdb3ta2"})
+
+		assert.NoError(t, err)
+		assert.Contains(t, output, "WebhookURL")
+		assert.NotContains(t, output, "WebhookSecret")
+	})
+	t.Run("ShowNotFound", func(t *testing.T) {
+		_, err := RunWithTestContext(WebhooksShowCommand, []string{"show", "wt2hdkz1vgdb3xxx"})
+		assert.Error(t, err)
+	})
+	t.Run("Deliveries", func(t *testing.T) {
+		output, err := RunWithTestContext(WebhooksDeliveriesCommand, []string{"deliveries", "--status=failed", "wt2hdl31ydq9yw0k"})
+
+		assert.NoError(t, err)
+		assert.Contains(t, output, "1000001")
+		assert.Contains(t, output, "albums.updated")
+	})
+	t.Run("ReplayInvalidID", func(t *testing.T) {
+		_, err := RunWithTestContext(WebhooksReplayCommand, []string{"replay", "wt2hdl31ydq9yw0k", "foo"})
+		assert.Error(t, err)
+	})
+}
@@ -41,6 +41,7 @@ type ClientConfig struct {
 	ApiUri           string              `json:"apiUri"`
 	ContentUri       string              `json:"contentUri"`
 	VideoUri         string              `json:"videoUri"`
+	VideoCodec       string              `json:"videoCodec"`
 	SiteUrl          string              `json:"siteUrl"`
 	SiteDomain       string              `json:"siteDomain"`
 	SiteAuthor       string              `json:"siteAuthor"`
@@ -134,6 +135,7 @@ type ClientDisable struct {
 	Faces          bool `json:"faces"`
 	Classification bool `json:"classification"`
 	FFmpeg         bool `json:"ffmpeg"`
+	HLS            bool `json:"hls"`
 	ExifTool       bool `json:"exiftool"`
 	Vips           bool `json:"vips"`
 	Sips           bool `json:"sips"`
@@ -268,6 +270,7 @@ func (c *Config) ClientPublic() *ClientConfig {
 			Classification: true,
 			Sips:           true,
 			FFmpeg:         true,
+			HLS:            true,
 			ExifTool:       true,
 			Darktable:      true,
 			RawTherapee:    true,
@@ -290,6 +293,7 @@ func (c *Config) ClientPublic() *ClientConfig {
 		ApiUri:           c.ApiUri(),
 		ContentUri:       c.ContentUri(),
 		VideoUri:         c.VideoUri(),
+		VideoCodec:       c.FFmpegCodec().String(),
 		SiteUrl:          c.SiteUrl(),
 		SiteDomain:       c.SiteDomain(),
 		SiteAuthor:       c.SiteAuthor(),
@@ -364,6 +368,7 @@ func (c *Config) ClientShare() *ClientConfig {
 			Classification: c.DisableClassification(),
 			Sips:           true,
 			FFmpeg:         true,
+			HLS:            true,
 			ExifTool:       true,
 			Darktable:      true,
 			RawTherapee:    true,
@@ -386,6 +391,7 @@ func (c *Config) ClientShare() *ClientConfig {
 		ApiUri:           c.ApiUri(),
 		ContentUri:       c.ContentUri(),
 		VideoUri:         c.VideoUri(),
+		VideoCodec:       c.FFmpegCodec().String(),
 		SiteUrl:          c.SiteUrl(),
 		SiteDomain:       c.SiteDomain(),
 		SiteAuthor:       c.SiteAuthor(),
@@ -466,6 +472,7 @@ func (c *Config) ClientUser(withSettings bool) *ClientConfig {
 			Faces:          c.DisableFaces(),
 			Classification: c.DisableClassification(),
 			FFmpeg:         c.DisableFFmpeg(),
+			HLS:            c.DisableHLS(),
 			ExifTool:       c.DisableExifTool(),
 			Vips:           c.DisableVips(),
 			Sips:           c.DisableSips(),
@@ -490,6 +497,7 @@ func (c *Config) ClientUser(withSettings bool) *ClientConfig {
 		ApiUri:           c.ApiUri(),
 		ContentUri:       c.ContentUri(),
 		VideoUri:         c.VideoUri(),
+		VideoCodec:       c.FFmpegCodec().String(),
 		SiteUrl:          c.SiteUrl(),
 		SiteDomain:       c.SiteDomain(),
 		SiteAuthor:       c.SiteAuthor(),
@@ -636,29 +644,29 @@ func (c *Config) ClientUser(withSettings bool) *ClientConfig {
 	if hidePrivate {
 		c.Db().
 			Table("albums").
-			Select("SUM(album_type = ?) AS albums, "+
+			Select("SUM(album_type IN (?, ?)) AS albums, "+
 				"SUM(album_type = ?) AS moments, "+
 				"SUM(album_type = ?) AS months, "+
 				"SUM(album_type = ?) AS states, "+
 				"SUM(album_type = ?) AS folders, "+
-				"SUM(album_type = ? AND album_private = 1) AS private_albums, "+
+				"SUM(album_type IN (?, ?) AND album_private = 1) AS private_albums, "+
 				"SUM(album_type = ? AND album_private = 1) AS private_moments, "+
 				"SUM(album_type = ? AND album_private = 1) AS private_months, "+
 				"SUM(album_type = ? AND album_private = 1) AS private_states, "+
 				"SUM(album_type = ? AND album_private = 1) AS private_folders",
-				entity.AlbumManual, entity.AlbumMoment, entity.AlbumMonth, entity.AlbumState, entity.AlbumFolder,
-				entity.AlbumManual, entity.AlbumMoment, entity.AlbumMonth, entity.AlbumState, entity.AlbumFolder).
+				entity.AlbumManual, entity.AlbumSmart, entity.AlbumMoment, entity.AlbumMonth, entity.AlbumState, entity.AlbumFolder,
+				entity.AlbumManual, entity.AlbumSmart, entity.AlbumMoment, entity.AlbumMonth, entity.AlbumState, entity.AlbumFolder).
 			Where("deleted_at IS NULL AND (albums.album_type <> 'folder' OR albums.album_path IN (SELECT photos.photo_path FROM photos WHERE photos.photo_private = 0 AND photos.deleted_at IS NULL))").
 			Take(&cfg.Count)
 	} else {
 		c.Db().
 			Table("albums").
-			Select("SUM(album_type = ?) AS albums, "+
+			Select("SUM(album_type IN (?, ?)) AS albums, "+
 				"SUM(album_type = ?) AS moments, "+
 				"SUM(album_type = ?) AS months, "+
 				"SUM(album_type = ?) AS states, "+
 				"SUM(album_type = ?) AS folders",
-				entity.AlbumManual, entity.AlbumMoment, entity.AlbumMonth, entity.AlbumState, entity.AlbumFolder).
+				entity.AlbumManual, entity.AlbumSmart, entity.AlbumMoment, entity.AlbumMonth, entity.AlbumState, entity.AlbumFolder).
 			Where("deleted_at IS NULL AND (albums.album_type <> 'folder' OR albums.album_path IN (SELECT photos.photo_path FROM photos WHERE photos.deleted_at IS NULL))").
 			Take(&cfg.Count)
 	}
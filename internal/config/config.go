@@ -56,8 +56,10 @@ import (
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/photoprism/dl"
+	"github.com/photoprism/photoprism/internal/service/geonames"
 	"github.com/photoprism/photoprism/internal/service/hub"
 	"github.com/photoprism/photoprism/internal/service/hub/places"
+	"github.com/photoprism/photoprism/internal/service/local"
 	"github.com/photoprism/photoprism/internal/thumb"
 	"github.com/photoprism/photoprism/pkg/checksum"
 	"github.com/photoprism/photoprism/pkg/clean"
@@ -331,6 +333,16 @@ func (c *Config) Propagate() {
 		c.ThumbCachePath(),
 	}
 
+	// Set folders in which local services can be added.
+	local.Roots = c.MountPaths()
+	local.Protected = []string{
+		c.ConfigPath(),
+		c.StoragePath(),
+		c.OriginalsPath(),
+		c.SidecarPath(),
+		c.CachePath(),
+	}
+
 	// Set cache expiration defaults.
 	ttl.CacheDefault = c.HttpCacheMaxAge()
 	ttl.CacheVideo = c.HttpVideoMaxAge()
@@ -338,6 +350,7 @@ func (c *Config) Propagate() {
 	// Set geocoding parameters.
 	places.UserAgent = c.UserAgent()
 	places.DefaultLocale = c.PlacesLocale()
+	geonames.FileName = c.GeoNamesFile()
 	entity.GeoApi = c.GeoApi()
 
 	// Set session cache duration.
@@ -349,6 +362,12 @@ func (c *Config) Propagate() {
 	// Set path for user assets.
 	entity.UsersPath = c.UsersPath()
 
+	// Set path for files uploaded with share links.
+	entity.SharesPath = c.SharesPath()
+
+	// Set directory server for LDAP authentication.
+	entity.LDAP = c.LDAP()
+
 	// Set API preview and download default tokens.
 	entity.PreviewToken.Set(c.PreviewToken(), entity.TokenConfig)
 	entity.DownloadToken.Set(c.DownloadToken(), entity.TokenConfig)
@@ -364,6 +383,9 @@ func (c *Config) Propagate() {
 	face.Epsilon = c.FaceEpsilonDist()
 	face.ClusterRadius = c.FaceClusterRadius()
 	face.ClusterDist = c.FaceClusterDist()
+	face.ClusterAlgorithm = c.FaceClusterAlgorithm()
+	face.ClusterXi = c.FaceClusterXi()
+	face.SuggestDist = c.FaceSuggestDist()
 	face.MatchDist = c.FaceMatchDist()
 	face.SkipChildren = c.FaceSkipChildren()
 	face.IgnoreBackground = !c.FaceAllowBackground()
@@ -664,6 +686,13 @@ func (c *Config) Shutdown() {
 	// Shutdown thumbnail library.
 	thumb.Shutdown()
 
+	// Update the search index of photos that have changed since the metadata worker last ran.
+	if c.db != nil {
+		if _, err := entity.UpdateQueuedSearchIndex(); err != nil {
+			log.Warnf("search: %s (update index)", err)
+		}
+	}
+
 	// Close database connection.
 	if err := c.CloseDb(); err != nil {
 		log.Errorf("could not close database connection: %s", err)
@@ -762,6 +791,34 @@ func (c *Config) AutoImport() time.Duration {
 	return time.Duration(c.options.AutoImport) * time.Second
 }
 
+// SimilarDistance returns the maximum Hamming distance between the perceptual hashes of visually similar pictures.
+func (c *Config) SimilarDistance() int {
+	if c.options.SimilarDistance <= 0 || c.options.SimilarDistance > 32 {
+		return DefaultSimilarDistance
+	}
+
+	return c.options.SimilarDistance
+}
+
+// BestShot returns the policy for selecting the best shot in bursts and similar pictures.
+func (c *Config) BestShot() string {
+	switch c.options.BestShot {
+	case BestShotStack, BestShotArchive:
+		return c.options.BestShot
+	default:
+		return BestShotOff
+	}
+}
+
+// BestShotInterval returns the maximum time between consecutive pictures of a burst.
+func (c *Config) BestShotInterval() time.Duration {
+	if c.options.BestShotInterval <= 0 || c.options.BestShotInterval > 3600 {
+		return time.Duration(DefaultBestShotInterval) * time.Second
+	}
+
+	return time.Duration(c.options.BestShotInterval) * time.Second
+}
+
 // OriginalsLimit returns the maximum size of originals in MB.
 func (c *Config) OriginalsLimit() int {
 	if c.options.OriginalsLimit <= 0 || c.options.OriginalsLimit > 100000 {
@@ -12,6 +12,15 @@ const (
 	DefaultBackupSchedule = "daily"
 	// DefaultBackupRetain sets how many backup sets are kept by default.
 	DefaultBackupRetain = 3
+	// DefaultBackupCompression defines the default compression of backup archives.
+	DefaultBackupCompression = BackupCompressionGzip
+)
+
+// Supported backup archive compression formats.
+const (
+	BackupCompressionNone = "none"
+	BackupCompressionGzip = "gzip"
+	BackupCompressionZstd = "zstd"
 )
 
 // DisableBackups checks if database and album backups as well as YAML sidecar files should not be created.
@@ -32,6 +41,15 @@ func (c *Config) SidecarYaml() bool {
 	return c.options.SidecarYaml
 }
 
+// SidecarXmp checks if metadata changes should be written to XMP sidecar files.
+func (c *Config) SidecarXmp() bool {
+	if !c.SidecarWritable() {
+		return false
+	}
+
+	return c.options.SidecarXmp
+}
+
 // BackupPath returns the backup storage path based on the specified type, or the base path if none is specified.
 func (c *Config) BackupPath(backupType string) string {
 	if s := clean.TypeLowerUnderscore(backupType); s == "" {
@@ -101,3 +119,35 @@ func (c *Config) BackupAlbumsPath() string {
 
 	return c.BackupPath(fs.AlbumsDir)
 }
+
+// BackupArchive checks if index backups should be created as archives that include a manifest
+// with checksums and row counts as well as the album YAML backup files.
+func (c *Config) BackupArchive() bool {
+	if c.DisableBackups() {
+		return false
+	}
+
+	return c.options.BackupArchive
+}
+
+// BackupCompression returns the compression format of backup archives, i.e. none, gzip, or zstd.
+func (c *Config) BackupCompression() string {
+	switch clean.TypeLower(c.options.BackupCompression) {
+	case BackupCompressionNone, "tar", "false", "off":
+		return BackupCompressionNone
+	case BackupCompressionZstd, "zst":
+		return BackupCompressionZstd
+	default:
+		return BackupCompressionGzip
+	}
+}
+
+// BackupIncremental checks if backup archives should only include the tables that have
+// changed since the last full backup.
+func (c *Config) BackupIncremental() bool {
+	if !c.BackupArchive() {
+		return false
+	}
+
+	return c.options.BackupIncremental
+}
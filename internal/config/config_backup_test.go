@@ -87,3 +87,37 @@ func TestConfig_DisableBackups(t *testing.T) {
 	c.options.DisableBackups = false
 	assert.False(t, c.DisableBackups())
 }
+
+func TestConfig_BackupArchive(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.False(t, c.BackupArchive())
+	c.options.BackupArchive = true
+	assert.True(t, c.BackupArchive())
+	c.options.DisableBackups = true
+	assert.False(t, c.BackupArchive())
+	c.options.DisableBackups = false
+	c.options.BackupArchive = false
+}
+
+func TestConfig_BackupCompression(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, BackupCompressionGzip, c.BackupCompression())
+	c.options.BackupCompression = "ZSTD"
+	assert.Equal(t, BackupCompressionZstd, c.BackupCompression())
+	c.options.BackupCompression = "none"
+	assert.Equal(t, BackupCompressionNone, c.BackupCompression())
+	c.options.BackupCompression = "invalid"
+	assert.Equal(t, BackupCompressionGzip, c.BackupCompression())
+	c.options.BackupCompression = ""
+}
+
+func TestConfig_BackupIncremental(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.False(t, c.BackupIncremental())
+	c.options.BackupIncremental = true
+	assert.False(t, c.BackupIncremental())
+	c.options.BackupArchive = true
+	assert.True(t, c.BackupIncremental())
+	c.options.BackupArchive = false
+	c.options.BackupIncremental = false
+}
@@ -32,6 +32,22 @@ const DefaultAutoIndexDelay = 300 // 5 Minutes
 // DefaultAutoImportDelay sets the default delay (in seconds) before background imports start (-1 disables).
 const DefaultAutoImportDelay = -1 // Disabled
 
+// DefaultSimilarDistance is the default maximum Hamming distance between the perceptual hashes of similar pictures.
+const DefaultSimilarDistance = 6
+
+// DefaultBestShotInterval is the default maximum time in seconds between consecutive pictures of a burst.
+const DefaultBestShotInterval = 3
+
+// Best shot policies for bursts and similar pictures.
+const (
+	// BestShotOff only reports the best shots without changing any pictures (default).
+	BestShotOff = "off"
+	// BestShotStack stacks the pictures of a burst so that the best shot becomes the primary picture.
+	BestShotStack = "stack"
+	// BestShotArchive archives all pictures of a burst except the best shot.
+	BestShotArchive = "archive"
+)
+
 // MinWakeupInterval is the minimum allowed interval for the background worker.
 const MinWakeupInterval = time.Minute // 1 Minute
 // MaxWakeupInterval is the maximum allowed interval for the background worker.
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 
 	"github.com/photoprism/photoprism/internal/ai/face"
 	"github.com/photoprism/photoprism/internal/ai/vision"
@@ -233,6 +234,34 @@ func (c *Config) FaceClusterRadius() float64 {
 	return c.options.FaceClusterRadius
 }
 
+// FaceClusterAlgorithm returns the algorithm used to group unknown faces into clusters.
+func (c *Config) FaceClusterAlgorithm() string {
+	switch strings.ToLower(strings.TrimSpace(c.options.FaceClusterAlgorithm)) {
+	case face.ClusterOPTICS:
+		return face.ClusterOPTICS
+	default:
+		return face.ClusterDBSCAN
+	}
+}
+
+// FaceClusterXi returns the reachability steepness threshold for extracting OPTICS clusters.
+func (c *Config) FaceClusterXi() float64 {
+	if c.options.FaceClusterXi < 0.001 || c.options.FaceClusterXi > 0.5 {
+		return face.ClusterXi
+	}
+
+	return c.options.FaceClusterXi
+}
+
+// FaceSuggestDist returns the maximum distance for suggesting that an unknown face belongs to a known person.
+func (c *Config) FaceSuggestDist() float64 {
+	if c.options.FaceSuggestDist < c.FaceCollisionDist() || c.options.FaceSuggestDist > 1.5 {
+		return face.SuggestDist
+	}
+
+	return c.options.FaceSuggestDist
+}
+
 // FaceCollisionDist returns the minimum distance used to differentiate embeddings.
 func (c *Config) FaceCollisionDist() float64 {
 	if c.options.FaceCollisionDist <= 0 || c.options.FaceCollisionDist > 1 {
@@ -312,3 +312,30 @@ func TestConfig_FaceAngles(t *testing.T) {
 	c.options.FaceAngles = []float64{math.Pi + 0.1, math.NaN(), 4}
 	assert.Equal(t, face.DefaultAngles, c.FaceAngles())
 }
+
+func TestConfig_FaceClusterAlgorithm(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, face.ClusterDBSCAN, c.FaceClusterAlgorithm())
+	c.options.FaceClusterAlgorithm = "OPTICS"
+	assert.Equal(t, face.ClusterOPTICS, c.FaceClusterAlgorithm())
+	c.options.FaceClusterAlgorithm = "kmeans"
+	assert.Equal(t, face.ClusterDBSCAN, c.FaceClusterAlgorithm())
+}
+
+func TestConfig_FaceClusterXi(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, face.ClusterXi, c.FaceClusterXi())
+	c.options.FaceClusterXi = 0.1
+	assert.Equal(t, 0.1, c.FaceClusterXi())
+	c.options.FaceClusterXi = 0.9
+	assert.Equal(t, face.ClusterXi, c.FaceClusterXi())
+}
+
+func TestConfig_FaceSuggestDist(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, face.SuggestDist, c.FaceSuggestDist())
+	c.options.FaceSuggestDist = 0.8
+	assert.Equal(t, 0.8, c.FaceSuggestDist())
+	c.options.FaceSuggestDist = 2
+	assert.Equal(t, face.SuggestDist, c.FaceSuggestDist())
+}
@@ -91,6 +91,11 @@ func (c *Config) DisableFFmpeg() bool {
 	return c.options.DisableFFmpeg
 }
 
+// DisableHLS checks if adaptive video streaming with HLS is disabled.
+func (c *Config) DisableHLS() bool {
+	return c.options.DisableHLS || c.DisableFFmpeg()
+}
+
 // DisableDarktable checks if conversion of RAW images with Darktable is disabled.
 func (c *Config) DisableDarktable() bool {
 	if c.DisableRaw() || c.options.DisableDarktable {
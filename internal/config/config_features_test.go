@@ -74,6 +74,16 @@ func TestConfig_ExifToolEnabled(t *testing.T) {
 	assert.False(t, c.ExifToolEnabled())
 }
 
+func TestConfig_DisableHLS(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, c.DisableFFmpeg(), c.DisableHLS())
+	c.options.DisableHLS = true
+	assert.True(t, c.DisableHLS())
+	c.options.DisableHLS = false
+	c.options.DisableFFmpeg = true
+	assert.True(t, c.DisableHLS())
+}
+
 func TestConfig_DisableFaces(t *testing.T) {
 	c := NewConfig(CliTestContext())
 	assert.False(t, c.DisableFaces())
@@ -45,6 +45,38 @@ func (c *Config) FFmpegEncoder() encode.Encoder {
 	return encode.FindEncoder(c.options.FFmpegEncoder)
 }
 
+// FFmpegCodec returns the preferred video transcoding codec for browsers that support it.
+func (c *Config) FFmpegCodec() encode.Codec {
+	if codec := encode.ParseCodec(c.options.FFmpegCodec); codec != "" {
+		return codec
+	}
+
+	return encode.CodecAvc
+}
+
+// FFmpegCodecs returns the enabled video transcoding codecs, sorted by preference.
+func (c *Config) FFmpegCodecs() []encode.Codec {
+	if codec := c.FFmpegCodec(); codec != encode.CodecAvc {
+		return []encode.Codec{codec, encode.CodecAvc}
+	}
+
+	return []encode.Codec{encode.CodecAvc}
+}
+
+// FFmpegTranscodeCodec returns the codec to transcode videos to if the client supports the requested
+// format, or AVC as it is supported by all browsers.
+func (c *Config) FFmpegTranscodeCodec(format string) encode.Codec {
+	requested := encode.ParseCodec(format)
+
+	for _, codec := range c.FFmpegCodecs() {
+		if codec == requested {
+			return codec
+		}
+	}
+
+	return encode.CodecAvc
+}
+
 // FFmpegSize returns the maximum ffmpeg video encoding size in pixels (720-7680).
 func (c *Config) FFmpegSize() int {
 	return thumb.VideoSize(c.options.FFmpegSize).Width
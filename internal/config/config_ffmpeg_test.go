@@ -24,6 +24,28 @@ func TestConfig_FFmpegEncoder(t *testing.T) {
 	assert.Equal(t, encode.DefaultAvcEncoder(), c.FFmpegEncoder())
 }
 
+func TestConfig_FFmpegCodec(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, encode.CodecAvc, c.FFmpegCodec())
+	assert.Equal(t, []encode.Codec{encode.CodecAvc}, c.FFmpegCodecs())
+	assert.Equal(t, encode.CodecAvc, c.FFmpegTranscodeCodec("hvc"))
+
+	c.options.FFmpegCodec = "hevc"
+	assert.Equal(t, encode.CodecHevc, c.FFmpegCodec())
+	assert.Equal(t, []encode.Codec{encode.CodecHevc, encode.CodecAvc}, c.FFmpegCodecs())
+	assert.Equal(t, encode.CodecHevc, c.FFmpegTranscodeCodec("hvc"))
+	assert.Equal(t, encode.CodecAvc, c.FFmpegTranscodeCodec("av1"))
+	assert.Equal(t, encode.CodecAvc, c.FFmpegTranscodeCodec(""))
+
+	c.options.FFmpegCodec = "av1"
+	assert.Equal(t, encode.CodecAv1, c.FFmpegCodec())
+	assert.Equal(t, encode.CodecAv1, c.FFmpegTranscodeCodec("av01"))
+
+	c.options.FFmpegCodec = "xxx"
+	assert.Equal(t, encode.CodecAvc, c.FFmpegCodec())
+	c.options.FFmpegCodec = ""
+}
+
 func TestConfig_FFmpegEnabled(t *testing.T) {
 	c := NewConfig(CliTestContext())
 	assert.Equal(t, true, c.FFmpegEnabled())
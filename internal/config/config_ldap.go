@@ -0,0 +1,218 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/photoprism/photoprism/internal/auth/acl"
+	"github.com/photoprism/photoprism/internal/auth/ldap"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// LDAPEnabled checks if password authentication via LDAP / Active Directory is configured and enabled.
+func (c *Config) LDAPEnabled() bool {
+	if c.options.DisableLDAP {
+		return false
+	}
+
+	conf := ldap.Config{Uri: c.options.LDAPUri}
+
+	return conf.Enabled()
+}
+
+// LDAPUri returns the LDAP server URI, e.g. ldaps://dc.example.com.
+func (c *Config) LDAPUri() string {
+	return strings.TrimSpace(c.options.LDAPUri)
+}
+
+// LDAPStartTLS checks if unencrypted LDAP connections should be upgraded with StartTLS.
+func (c *Config) LDAPStartTLS() bool {
+	return c.options.LDAPStartTLS
+}
+
+// LDAPInsecure checks if the LDAP server certificate should not be verified.
+func (c *Config) LDAPInsecure() bool {
+	return c.options.LDAPInsecure
+}
+
+// LDAPBindDN returns the distinguished name of the service account used to search for users.
+func (c *Config) LDAPBindDN() string {
+	return strings.TrimSpace(c.options.LDAPBindDN)
+}
+
+// LDAPBindPassword returns the password of the LDAP service account.
+func (c *Config) LDAPBindPassword() string {
+	// Try to read password from file if c.options.LDAPBindPassword is not set.
+	if c.options.LDAPBindPassword != "" {
+		return clean.Password(c.options.LDAPBindPassword)
+	} else if fileName := FlagFilePath("LDAP_BIND_PASSWORD"); fileName == "" {
+		// No password set, this is not an error.
+		return ""
+	} else if b, err := os.ReadFile(fileName); err != nil || len(b) == 0 { //nolint:gosec // path derived from config directory
+		log.Warnf("config: failed to read LDAP bind password from %s (%s)", fileName, err)
+		return ""
+	} else {
+		return clean.Password(string(b))
+	}
+}
+
+// LDAPBaseDN returns the base DN for user searches.
+func (c *Config) LDAPBaseDN() string {
+	return strings.TrimSpace(c.options.LDAPBaseDN)
+}
+
+// LDAPFilter returns the user search filter.
+func (c *Config) LDAPFilter() string {
+	if filter := strings.TrimSpace(c.options.LDAPFilter); filter != "" {
+		return filter
+	}
+
+	return ldap.DefaultFilter
+}
+
+// LDAPGroupAttr returns the name of the user attribute that contains group memberships.
+func (c *Config) LDAPGroupAttr() string {
+	if attr := strings.TrimSpace(c.options.LDAPGroupAttr); attr != "" {
+		return attr
+	}
+
+	return ldap.DefaultGroupAttr
+}
+
+// LDAPGroup returns the normalized list of required groups; empty means no group check.
+func (c *Config) LDAPGroup() []string {
+	if len(c.options.LDAPGroup) == 0 {
+		return nil
+	}
+
+	result := make([]string, 0, len(c.options.LDAPGroup))
+
+	for _, g := range c.options.LDAPGroup {
+		if n := ldap.NormalizeGroupID(g); n != "" {
+			result = append(result, n)
+		}
+	}
+
+	return result
+}
+
+// LDAPGroupRoles maps normalized group names or distinguished names to roles.
+func (c *Config) LDAPGroupRoles() map[string]acl.Role {
+	result := make(map[string]acl.Role, len(c.options.LDAPGroupRole))
+
+	for _, entry := range c.options.LDAPGroupRole {
+		entry = strings.TrimSpace(entry)
+
+		if entry == "" {
+			continue
+		}
+
+		// Use the last separator, since distinguished names contain "=".
+		sep := strings.LastIndexAny(entry, "=:")
+
+		if sep < 1 || sep >= len(entry)-1 {
+			continue
+		}
+
+		group := ldap.NormalizeGroupID(entry[:sep])
+		role := acl.ParseRole(entry[sep+1:])
+
+		if group == "" || role == acl.RoleNone {
+			continue
+		}
+
+		result[group] = role
+	}
+
+	return result
+}
+
+// LDAPRole returns the default user role when signing up via LDAP.
+func (c *Config) LDAPRole() acl.Role {
+	if c.options.LDAPRole == "" {
+		return acl.RoleGuest
+	}
+
+	return acl.UserRoles[clean.Role(c.options.LDAPRole)]
+}
+
+// LDAPRegister checks if accounts should be created for directory users who sign in for the first time.
+func (c *Config) LDAPRegister() bool {
+	return c.options.LDAPRegister
+}
+
+// LDAPWebDAV checks if newly registered accounts should be allowed to use WebDAV if their role allows.
+func (c *Config) LDAPWebDAV() bool {
+	return c.options.LDAPWebDAV
+}
+
+// DisableLDAP checks if password authentication via LDAP should be disabled.
+func (c *Config) DisableLDAP() bool {
+	return c.options.DisableLDAP
+}
+
+// LDAP returns the directory settings for password authentication, or nil if LDAP is disabled.
+func (c *Config) LDAP() *ldap.Config {
+	if !c.LDAPEnabled() {
+		return nil
+	}
+
+	return &ldap.Config{
+		Uri:          c.LDAPUri(),
+		StartTLS:     c.LDAPStartTLS(),
+		Insecure:     c.LDAPInsecure(),
+		BindDN:       c.LDAPBindDN(),
+		BindPassword: c.LDAPBindPassword(),
+		BaseDN:       c.LDAPBaseDN(),
+		Filter:       c.LDAPFilter(),
+		GroupAttr:    c.LDAPGroupAttr(),
+		Groups:       c.LDAPGroup(),
+		GroupRoles:   c.LDAPGroupRoles(),
+		Role:         c.LDAPRole(),
+		Register:     c.LDAPRegister(),
+		WebDAV:       c.LDAPWebDAV(),
+	}
+}
+
+// LDAPReport returns the LDAP / Active Directory config values as a table for reporting.
+func (c *Config) LDAPReport() (rows [][]string, cols []string) {
+	cols = []string{"Name", "Value"}
+
+	rows = [][]string{
+		{"ldap-uri", c.LDAPUri()},
+		{"ldap-starttls", fmt.Sprintf("%t", c.LDAPStartTLS())},
+		{"ldap-insecure", fmt.Sprintf("%t", c.LDAPInsecure())},
+		{"ldap-bind-dn", c.LDAPBindDN()},
+		{"ldap-bind-password", strings.Repeat("*", utf8.RuneCountInString(c.LDAPBindPassword()))},
+		{"ldap-base-dn", c.LDAPBaseDN()},
+		{"ldap-filter", c.LDAPFilter()},
+		{"ldap-group-attr", c.LDAPGroupAttr()},
+	}
+
+	if groups := c.LDAPGroup(); len(groups) > 0 {
+		rows = append(rows, []string{"ldap-group", strings.Join(groups, ";")})
+	}
+
+	if roles := c.LDAPGroupRoles(); len(roles) > 0 {
+		pairs := make([]string, 0, len(roles))
+
+		for g, r := range roles {
+			pairs = append(pairs, fmt.Sprintf("%s=%s", g, r))
+		}
+
+		sort.Strings(pairs)
+		rows = append(rows, []string{"ldap-group-role", strings.Join(pairs, ";")})
+	}
+
+	rows = append(rows, [][]string{
+		{"ldap-role", c.LDAPRole().String()},
+		{"ldap-register", fmt.Sprintf("%t", c.LDAPRegister())},
+		{"ldap-webdav", fmt.Sprintf("%t", c.LDAPWebDAV())},
+		{"disable-ldap", fmt.Sprintf("%t", c.DisableLDAP())},
+	}...)
+
+	return rows, cols
+}
@@ -0,0 +1,137 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/auth/acl"
+	"github.com/photoprism/photoprism/internal/auth/ldap"
+)
+
+func TestConfig_LDAPEnabled(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.False(t, c.LDAPEnabled())
+	assert.Nil(t, c.LDAP())
+
+	c.options.LDAPUri = "ldaps://dc.example.com"
+
+	assert.True(t, c.LDAPEnabled())
+	assert.NotNil(t, c.LDAP())
+
+	c.options.DisableLDAP = true
+
+	assert.False(t, c.LDAPEnabled())
+	assert.Nil(t, c.LDAP())
+
+	c.options.DisableLDAP = false
+	c.options.LDAPUri = "https://dc.example.com"
+
+	assert.False(t, c.LDAPEnabled())
+}
+
+func TestConfig_LDAPFilter(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, ldap.DefaultFilter, c.LDAPFilter())
+
+	c.options.LDAPFilter = "(mail={username})"
+
+	assert.Equal(t, "(mail={username})", c.LDAPFilter())
+}
+
+func TestConfig_LDAPGroupAttr(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, ldap.DefaultGroupAttr, c.LDAPGroupAttr())
+
+	c.options.LDAPGroupAttr = "groups"
+
+	assert.Equal(t, "groups", c.LDAPGroupAttr())
+}
+
+func TestConfig_LDAPGroup(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Nil(t, c.LDAPGroup())
+
+	c.options.LDAPGroup = []string{"PhotoPrism", " ", "CN=Staff,DC=example,DC=com"}
+
+	assert.Equal(t, []string{"photoprism", "cn=staff,dc=example,dc=com"}, c.LDAPGroup())
+}
+
+func TestConfig_LDAPGroupRoles(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.options.LDAPGroupRole = []string{
+		"PhotoPrism Admins=admin",
+		"CN=Guests,OU=Groups,DC=example,DC=com=guest",
+		"staff:guest",
+		"invalid",
+		"=none",
+		"viewers=unknown",
+	}
+
+	roles := c.LDAPGroupRoles()
+
+	assert.Equal(t, acl.RoleAdmin, roles["photoprism admins"])
+	assert.Equal(t, acl.RoleGuest, roles["cn=guests,ou=groups,dc=example,dc=com"])
+	assert.Equal(t, acl.RoleGuest, roles["staff"])
+	assert.Len(t, roles, 3)
+}
+
+func TestConfig_LDAPRole(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, acl.RoleGuest, c.LDAPRole())
+
+	c.options.LDAPRole = "admin"
+
+	assert.Equal(t, acl.RoleAdmin, c.LDAPRole())
+
+	c.options.LDAPRole = "invalid"
+
+	assert.Equal(t, acl.RoleNone, c.LDAPRole())
+}
+
+func TestConfig_LDAP(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.options.LDAPUri = "ldap://ldap.example.com"
+	c.options.LDAPStartTLS = true
+	c.options.LDAPBindDN = "cn=service,dc=example,dc=com"
+	c.options.LDAPBindPassword = "secret"
+	c.options.LDAPBaseDN = "dc=example,dc=com"
+	c.options.LDAPRegister = true
+
+	conf := c.LDAP()
+
+	if conf == nil {
+		t.Fatal("config must not be nil")
+	}
+
+	assert.Equal(t, "ldap://ldap.example.com", conf.Uri)
+	assert.True(t, conf.StartTLS)
+	assert.False(t, conf.Insecure)
+	assert.Equal(t, "cn=service,dc=example,dc=com", conf.BindDN)
+	assert.Equal(t, "secret", conf.BindPassword)
+	assert.Equal(t, "dc=example,dc=com", conf.BaseDN)
+	assert.Equal(t, ldap.DefaultFilter, conf.Filter)
+	assert.Equal(t, acl.RoleGuest, conf.Role)
+	assert.True(t, conf.Register)
+	assert.False(t, conf.WebDAV)
+}
+
+func TestConfig_LDAPReport(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	c.options.LDAPBindPassword = "secret"
+	c.options.LDAPGroupRole = []string{"admins=admin"}
+
+	r, _ := c.LDAPReport()
+
+	assert.GreaterOrEqual(t, len(r), 12)
+	assert.Contains(t, r, []string{"ldap-bind-password", "******"})
+	assert.Contains(t, r, []string{"ldap-group-role", "admins=admin"})
+}
@@ -1,20 +1,38 @@
 package config
 
 import (
+	"path/filepath"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/service/geonames"
 	"github.com/photoprism/photoprism/internal/service/hub/places"
 	"github.com/photoprism/photoprism/pkg/clean"
 )
 
-// GeoApi returns the preferred geocoding api (places, or none).
+// GeoApi returns the preferred geocoding api (places, geonames, or none).
 func (c *Config) GeoApi() string {
 	if c.options.DisablePlaces {
 		return ""
 	}
 
-	return "places"
+	return c.PlacesProvider()
+}
+
+// PlacesProvider returns the reverse geocoding provider name, either places or geonames.
+func (c *Config) PlacesProvider() string {
+	if strings.EqualFold(strings.TrimSpace(c.options.PlacesProvider), geonames.ApiName) {
+		return geonames.ApiName
+	}
+
+	return places.ApiName
 }
 
 // PlacesLocale returns the locale name used for geocoding.
 func (c *Config) PlacesLocale() string {
 	return clean.WebLocale(c.options.PlacesLocale, places.LocalLocale)
 }
+
+// GeoNamesFile returns the filename of the local dataset used for offline reverse geocoding.
+func (c *Config) GeoNamesFile() string {
+	return filepath.Join(c.ConfigPath(), "geonames.tsv")
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -10,10 +11,29 @@ func TestConfig_GeoApi(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
 	assert.Equal(t, "places", c.GeoApi())
+	c.options.PlacesProvider = "GeoNames"
+	assert.Equal(t, "geonames", c.GeoApi())
 	c.options.DisablePlaces = true
 	assert.Equal(t, "", c.GeoApi())
 }
 
+func TestConfig_PlacesProvider(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, "places", c.PlacesProvider())
+	c.options.PlacesProvider = "geonames"
+	assert.Equal(t, "geonames", c.PlacesProvider())
+	c.options.PlacesProvider = "xxx"
+	assert.Equal(t, "places", c.PlacesProvider())
+	c.options.PlacesProvider = ""
+}
+
+func TestConfig_GeoNamesFile(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, filepath.Join(c.ConfigPath(), "geonames.tsv"), c.GeoNamesFile())
+}
+
 func TestConfig_PlacesLocale(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
@@ -405,6 +405,17 @@ func (c *Config) ImportAllow() fs.ExtList {
 	return fs.NewExtList(c.options.ImportAllow)
 }
 
+// MountPaths returns the absolute paths in which local services can be added, e.g. mounted network shares.
+func (c *Config) MountPaths() (result []string) {
+	for _, dir := range c.options.MountPaths {
+		if dir = fs.Abs(dir); dir != "" {
+			result = append(result, dir)
+		}
+	}
+
+	return result
+}
+
 // SidecarPath returns the storage path for generated sidecar files (relative or absolute).
 func (c *Config) SidecarPath() string {
 	if c.options.SidecarPath == "" {
@@ -474,6 +485,26 @@ func (c *Config) UserUploadPath(userUid, token string) (string, error) {
 	return dir, nil
 }
 
+// SharesPath returns the storage path for files uploaded via share links.
+func (c *Config) SharesPath() string {
+	return filepath.Join(c.StoragePath(), fs.SharesDir)
+}
+
+// ShareUploadPath returns the staging path for files uploaded via the specified share link.
+func (c *Config) ShareUploadPath(linkUid string) (string, error) {
+	if !rnd.IsUID(linkUid, 0) {
+		return "", fmt.Errorf("invalid uid")
+	}
+
+	dir := filepath.Join(c.SharesPath(), linkUid, fs.UploadDir)
+
+	if err := fs.MkdirAll(dir); err != nil {
+		return "", err
+	}
+
+	return dir, nil
+}
+
 // TempPath returns the cached temporary directory name e.g. for uploads and downloads.
 func (c *Config) TempPath() string {
 	// Return cached value?
@@ -585,6 +616,11 @@ func (c *Config) MediaFileCachePath(hash string) string {
 	return dir
 }
 
+// HlsCachePath returns the cache path for HLS video stream segments.
+func (c *Config) HlsCachePath() string {
+	return filepath.Join(c.CachePath(), fs.HlsDir)
+}
+
 // ThumbCachePath returns the thumbnail storage path.
 func (c *Config) ThumbCachePath() string {
 	return filepath.Join(c.CachePath(), fs.ThumbnailsDir)
@@ -57,6 +57,22 @@ func TestConfig_SidecarYaml(t *testing.T) {
 	assert.Equal(t, c.DisableBackups(), !c.SidecarYaml())
 }
 
+func TestConfig_SidecarXmp(t *testing.T) {
+	c := NewConfig(NewTestContext(nil))
+
+	assert.False(t, c.SidecarXmp())
+
+	c.options.SidecarXmp = true
+	assert.True(t, c.SidecarXmp())
+
+	c.options.ReadOnly = true
+	assert.Equal(t, c.SidecarPathIsAbs(), c.SidecarXmp())
+
+	c.options.ReadOnly = false
+	c.options.SidecarXmp = false
+	assert.False(t, c.SidecarXmp())
+}
+
 func TestConfig_UsersPath(t *testing.T) {
 	c := NewConfig(CliTestContext())
 	assert.Contains(t, c.UsersPath(), "users")
@@ -103,6 +119,25 @@ func TestConfig_UserUploadPath(t *testing.T) {
 	}
 }
 
+func TestConfig_SharesPath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	assert.Equal(t, filepath.Join(c.StoragePath(), "shares"), c.SharesPath())
+}
+
+func TestConfig_ShareUploadPath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+	if dir, err := c.ShareUploadPath(""); err == nil {
+		t.Error("error expected")
+	} else {
+		assert.Equal(t, "", dir)
+	}
+	if dir, err := c.ShareUploadPath("ss62xpryd1ob7gtf"); err != nil {
+		t.Fatal(err)
+	} else {
+		assert.Contains(t, dir, "shares/ss62xpryd1ob7gtf/upload")
+	}
+}
+
 func TestConfig_SidecarPathIsAbs(t *testing.T) {
 	c := NewConfig(CliTestContext())
 	assert.Equal(t, true, c.SidecarPathIsAbs())
@@ -216,6 +216,13 @@ func TestConfig_MediaFileCachePath(t *testing.T) {
 	assert.Equal(t, filepath.Join(c.MediaCachePath(), "0", "b", "5"), c.MediaFileCachePath("0b57b50fe3f6d12bbbf5f1abda3ebcc8bb5ebcee"))
 }
 
+func TestConfig_HlsCachePath(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.True(t, strings.HasPrefix(c.HlsCachePath(), "/"))
+	assert.True(t, strings.HasSuffix(c.HlsCachePath(), "storage/testdata/cache/hls"))
+}
+
 func TestConfig_ThumbCachePath(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
@@ -360,6 +367,39 @@ func TestConfig_AutoImport(t *testing.T) {
 	assert.Equal(t, 2*time.Hour, c.AutoImport())
 }
 
+func TestConfig_SimilarDistance(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, DefaultSimilarDistance, c.SimilarDistance())
+	c.options.SimilarDistance = 10
+	assert.Equal(t, 10, c.SimilarDistance())
+	c.options.SimilarDistance = 64
+	assert.Equal(t, DefaultSimilarDistance, c.SimilarDistance())
+}
+
+func TestConfig_BestShot(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, BestShotOff, c.BestShot())
+	c.options.BestShot = BestShotStack
+	assert.Equal(t, BestShotStack, c.BestShot())
+	c.options.BestShot = BestShotArchive
+	assert.Equal(t, BestShotArchive, c.BestShot())
+	c.options.BestShot = "delete"
+	assert.Equal(t, BestShotOff, c.BestShot())
+	c.options.BestShot = ""
+}
+
+func TestConfig_BestShotInterval(t *testing.T) {
+	c := NewConfig(CliTestContext())
+
+	assert.Equal(t, 3*time.Second, c.BestShotInterval())
+	c.options.BestShotInterval = 10
+	assert.Equal(t, 10*time.Second, c.BestShotInterval())
+	c.options.BestShotInterval = 7200
+	assert.Equal(t, 3*time.Second, c.BestShotInterval())
+}
+
 func TestConfig_OriginalsLimit(t *testing.T) {
 	c := NewConfig(CliTestContext())
 
@@ -3,6 +3,7 @@ package customize
 import (
 	"path/filepath"
 	"regexp"
+	"slices"
 	"strings"
 
 	"github.com/photoprism/photoprism/pkg/clean"
@@ -10,9 +11,10 @@ import (
 
 // ImportSettings represents import settings.
 type ImportSettings struct {
-	Path string `json:"path" yaml:"Path"`
-	Move bool   `json:"move" yaml:"Move"`
-	Dest string `json:"dest" yaml:"Dest,omitempty"`
+	Path     string `json:"path" yaml:"Path"`
+	Move     bool   `json:"move" yaml:"Move"`
+	Dest     string `json:"dest" yaml:"Dest,omitempty"`
+	Template string `json:"template" yaml:"Template,omitempty"`
 }
 
 // DefaultImportDest specifies the default import destination file path in the Originals folder.
@@ -22,6 +24,18 @@ var DefaultImportDest = "2006/01/20060102_150405_82F63B78.jpg"
 // ImportDestRegexp matches valid destination patterns that include timestamp, checksum, and extension parts.
 var ImportDestRegexp = regexp.MustCompile(`^(?P<name>\D*\d{2,14}[-/_].*\d{2,14}.*)(?P<checksum>[0-9a-fA-F]{8})(?P<count>\.\d{1,6}|\.COUNT)?(?P<ext>\.[0-9a-zA-Z]{2,8})$`)
 
+// ImportPlaceholders lists the placeholders that can be used in import destination path templates.
+var ImportPlaceholders = []string{
+	"year", "month", "day", "date",
+	"make", "model", "camera",
+	"country", "state", "city",
+	"album", "folder", "user", "type", "hash",
+}
+
+// ImportTemplateRegexp matches placeholders in import destination path templates, e.g. "{city}",
+// "{date:2006-01}", "{hash:6}", or "{album|Unsorted}" with an optional argument and fallback value.
+var ImportTemplateRegexp = regexp.MustCompile(`\{(?P<name>[a-z]+)(?::(?P<arg>[^{}|]+))?(?:\|(?P<fallback>[^{}]*))?\}`)
+
 // GetPath returns the default import source path, or a custom path if set.
 func (s *ImportSettings) GetPath() string {
 	if s.Path != "" {
@@ -67,3 +81,31 @@ func (s *ImportSettings) GetDestName() (pathName, fileName string) {
 
 	return strings.Trim(pathName, "/. "), fileName
 }
+
+// GetTemplate returns the import destination path template, or an empty string if no valid template is set.
+// If a template is set, it replaces the date-based path of the destination pattern, while file names
+// are still generated as configured in Dest.
+func (s *ImportSettings) GetTemplate() string {
+	tpl := strings.Trim(strings.TrimSpace(s.Template), "/")
+
+	if tpl == "" {
+		return ""
+	}
+
+	valid := true
+
+	// Check placeholders and replace them with a dummy value to validate the remaining path.
+	literal := ImportTemplateRegexp.ReplaceAllStringFunc(tpl, func(match string) string {
+		if name := ImportTemplateRegexp.FindStringSubmatch(match)[1]; !slices.Contains(ImportPlaceholders, name) {
+			valid = false
+		}
+
+		return "x"
+	})
+
+	if !valid || literal != clean.UserPath(literal) || strings.ContainsAny(literal, "{}") {
+		return ""
+	}
+
+	return tpl
+}
@@ -147,3 +147,26 @@ func TestImportDestRegexp(t *testing.T) {
 		assert.False(t, ImportDestRegexp.MatchString("2006/01_02_150405 CHECKSUM.ext"))
 	})
 }
+
+func TestImportSettings_GetTemplate(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		s := ImportSettings{}
+		assert.Equal(t, "", s.GetTemplate())
+	})
+	t.Run("Valid", func(t *testing.T) {
+		s := ImportSettings{Template: "/Clients/{album|Unsorted}/{date:2006-01}_{city}/"}
+		assert.Equal(t, "Clients/{album|Unsorted}/{date:2006-01}_{city}", s.GetTemplate())
+	})
+	t.Run("UnknownPlaceholder", func(t *testing.T) {
+		s := ImportSettings{Template: "{foo}/{year}"}
+		assert.Equal(t, "", s.GetTemplate())
+	})
+	t.Run("ParentFolder", func(t *testing.T) {
+		s := ImportSettings{Template: "../{year}"}
+		assert.Equal(t, "", s.GetTemplate())
+	})
+	t.Run("UnclosedBrace", func(t *testing.T) {
+		s := ImportSettings{Template: "{year}/{month"}
+		assert.Equal(t, "", s.GetTemplate())
+	})
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/ai/face"
 	"github.com/photoprism/photoprism/internal/auth/acl"
+	"github.com/photoprism/photoprism/internal/auth/ldap"
 	"github.com/photoprism/photoprism/internal/config/ttl"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/ffmpeg/encode"
@@ -147,6 +148,83 @@ var Flags = CliFlags{
 			Usage:   "disables single sign-on via OpenID Connect, even if an identity provider has been configured",
 			EnvVars: EnvVars("DISABLE_OIDC"),
 		}}, {
+		Flag: &cli.StringFlag{
+			Name:    "ldap-uri",
+			Usage:   "LDAP or Active Directory server `URI` for password authentication, e.g. ldaps://dc.example.com",
+			Value:   "",
+			EnvVars: EnvVars("LDAP_URI"),
+		}}, {
+		Flag: &cli.BoolFlag{
+			Name:    "ldap-starttls",
+			Usage:   "upgrades unencrypted ldap:// connections with StartTLS",
+			EnvVars: EnvVars("LDAP_STARTTLS"),
+		}}, {
+		Flag: &cli.BoolFlag{
+			Name:    "ldap-insecure",
+			Usage:   "skips verification of the LDAP server certificate",
+			EnvVars: EnvVars("LDAP_INSECURE"),
+		}}, {
+		Flag: &cli.StringFlag{
+			Name:    "ldap-bind-dn",
+			Usage:   "distinguished `NAME` of the service account used to search for users (anonymous if empty)",
+			Value:   "",
+			EnvVars: EnvVars("LDAP_BIND_DN"),
+		}}, {
+		Flag: &cli.StringFlag{
+			Name:    "ldap-bind-password",
+			Usage:   "`PASSWORD` of the LDAP service account",
+			Value:   "",
+			EnvVars: EnvVars("LDAP_BIND_PASSWORD"),
+		}}, {
+		Flag: &cli.StringFlag{
+			Name:    "ldap-base-dn",
+			Usage:   "base `DN` for user searches, e.g. dc=example,dc=com",
+			Value:   "",
+			EnvVars: EnvVars("LDAP_BASE_DN"),
+		}}, {
+		Flag: &cli.StringFlag{
+			Name:    "ldap-filter",
+			Usage:   "user search `FILTER`, {username} is replaced with the login name",
+			Value:   ldap.DefaultFilter,
+			EnvVars: EnvVars("LDAP_FILTER"),
+		}}, {
+		Flag: &cli.StringFlag{
+			Name:    "ldap-group-attr",
+			Usage:   "user `ATTRIBUTE` that contains group memberships",
+			Value:   ldap.DefaultGroupAttr,
+			EnvVars: EnvVars("LDAP_GROUP_ATTR"),
+		}}, {
+		Flag: &cli.StringSliceFlag{
+			Name:    "ldap-group",
+			Usage:   "require membership in at least one group `NAME` or DN (repeat flag to add multiple)",
+			EnvVars: EnvVars("LDAP_GROUP"),
+		}}, {
+		Flag: &cli.StringSliceFlag{
+			Name:    "ldap-group-role",
+			Usage:   "map `GROUP=ROLE`; repeat to add more (roles: " + acl.UserRoles.CliUsageString() + ")",
+			EnvVars: EnvVars("LDAP_GROUP_ROLE"),
+		}}, {
+		Flag: &cli.StringFlag{
+			Name:    "ldap-role",
+			Usage:   "default user `ROLE` of new LDAP users (" + acl.UserRoles.CliUsageString() + ")",
+			Value:   acl.RoleGuest.String(),
+			EnvVars: EnvVars("LDAP_ROLE"),
+		}}, {
+		Flag: &cli.BoolFlag{
+			Name:    "ldap-register",
+			Usage:   "creates accounts for directory users when they sign in for the first time",
+			EnvVars: EnvVars("LDAP_REGISTER"),
+		}}, {
+		Flag: &cli.BoolFlag{
+			Name:    "ldap-webdav",
+			Usage:   "allows new LDAP users to use WebDAV when they have a role that allows it",
+			EnvVars: EnvVars("LDAP_WEBDAV"),
+		}}, {
+		Flag: &cli.BoolFlag{
+			Name:    "disable-ldap",
+			Usage:   "disables password authentication via LDAP, even if a server has been configured",
+			EnvVars: EnvVars("DISABLE_LDAP"),
+		}}, {
 		Flag: &cli.Int64Flag{
 			Name:    "session-maxage",
 			Value:   DefaultSessionMaxAge,
@@ -287,6 +365,11 @@ var Flags = CliFlags{
 			Usage:   "restricts imports to these file types (comma-separated list of `EXTENSIONS`; leave blank to allow all)",
 			EnvVars: EnvVars("IMPORT_ALLOW"),
 		}}, {
+		Flag: &cli.StringSliceFlag{
+			Name:    "mount-path",
+			Usage:   "mounted network share or other folder `PATH` in which local services can be added (repeat flag to add multiple)",
+			EnvVars: EnvVars("MOUNT_PATH"),
+		}}, {
 		Flag: &cli.BoolFlag{
 			Name:    "upload-nsfw",
 			Aliases: []string{"n"},
@@ -354,6 +437,11 @@ var Flags = CliFlags{
 			Usage:   "creates YAML sidecar files to back up picture metadata",
 			EnvVars: EnvVars("SIDECAR_YAML"),
 		}, DocDefault: "true"}, {
+		Flag: &cli.BoolFlag{
+			Name:    "sidecar-xmp",
+			Usage:   "writes metadata changes to XMP sidecar files without modifying originals",
+			EnvVars: EnvVars("SIDECAR_XMP"),
+		}}, {
 		Flag: &cli.BoolFlag{
 			Name:    "usage-info",
 			Usage:   "displays storage usage information in the user interface",
@@ -393,6 +481,22 @@ var Flags = CliFlags{
 			Usage:   "enables the use of YAML files for backing up album metadata",
 			EnvVars: EnvVars("BACKUP_ALBUMS"),
 		}, DocDefault: "true"}, {
+		Flag: &cli.BoolFlag{
+			Name:    "backup-archive",
+			Usage:   "creates index backups as archives with a manifest, checksums, and album YAML files",
+			EnvVars: EnvVars("BACKUP_ARCHIVE"),
+		}}, {
+		Flag: &cli.StringFlag{
+			Name:    "backup-compression",
+			Usage:   "backup archive `COMPRESSION` (none, gzip, zstd)",
+			Value:   DefaultBackupCompression,
+			EnvVars: EnvVars("BACKUP_COMPRESSION"),
+		}}, {
+		Flag: &cli.BoolFlag{
+			Name:    "backup-incremental",
+			Usage:   "stores only the tables that have changed since the last full backup archive",
+			EnvVars: EnvVars("BACKUP_INCREMENTAL"),
+		}}, {
 		Flag: &cli.IntFlag{
 			Name:    "index-workers",
 			Aliases: []string{"workers"},
@@ -425,6 +529,24 @@ var Flags = CliFlags{
 			Value:   DefaultAutoImportDelay,
 			EnvVars: EnvVars("AUTO_IMPORT"),
 		}}, {
+		Flag: &cli.IntFlag{
+			Name:    "similar-distance",
+			Usage:   "maximum perceptual hash `DISTANCE` of visually similar pictures (1-32)",
+			Value:   DefaultSimilarDistance,
+			EnvVars: EnvVars("SIMILAR_DISTANCE"),
+		}}, {
+		Flag: &cli.StringFlag{
+			Name:    "best-shot",
+			Usage:   "best shot `POLICY` for bursts and similar pictures: off (report only), stack (make the best shot primary), or archive (archive the rest)",
+			Value:   BestShotOff,
+			EnvVars: EnvVars("BEST_SHOT"),
+		}}, {
+		Flag: &cli.IntFlag{
+			Name:    "best-shot-interval",
+			Usage:   "maximum time in `SECONDS` between consecutive pictures of a burst (1-3600)",
+			Value:   DefaultBestShotInterval,
+			EnvVars: EnvVars("BEST_SHOT_INTERVAL"),
+		}}, {
 		Flag: &cli.BoolFlag{
 			Name:    "read-only",
 			Aliases: []string{"r"},
@@ -487,6 +609,11 @@ var Flags = CliFlags{
 			Usage:   "disables video transcoding and thumbnail extraction with FFmpeg",
 			EnvVars: EnvVars("DISABLE_FFMPEG"),
 		}}, {
+		Flag: &cli.BoolFlag{
+			Name:    "disable-hls",
+			Usage:   "disables adaptive video streaming with HLS, which requires FFmpeg",
+			EnvVars: EnvVars("DISABLE_HLS"),
+		}}, {
 		Flag: &cli.BoolFlag{
 			Name:    "disable-exiftool",
 			Usage:   "disables metadata extraction with ExifTool (required for full Video, Live Photo, and XMP support)",
@@ -567,6 +694,12 @@ var Flags = CliFlags{
 			Value:   places.LocalLocale,
 			EnvVars: EnvVars("PLACES_LOCALE"),
 		}}, {
+		Flag: &cli.StringFlag{
+			Name:    "places-provider",
+			Usage:   "reverse geocoding `PROVIDER`, either places or geonames to use a local dataset without internet access",
+			Value:   places.ApiName,
+			EnvVars: EnvVars("PLACES_PROVIDER"),
+		}}, {
 		Flag: &cli.StringFlag{
 			Name:    "app-name",
 			Usage:   "app `NAME` when installed as a Progressive Web App (PWA)",
@@ -977,6 +1110,12 @@ var Flags = CliFlags{
 			Value:   "libx264",
 			EnvVars: EnvVars("FFMPEG_ENCODER"),
 		}}, {
+		Flag: &cli.StringFlag{
+			Name:    "ffmpeg-codec",
+			Usage:   "preferred video transcoding `CODEC` for browsers that support it (avc, hevc, av1)",
+			Value:   "avc",
+			EnvVars: EnvVars("FFMPEG_CODEC"),
+		}}, {
 		Flag: &cli.IntFlag{
 			Name:    "ffmpeg-size",
 			Usage:   "encoding resolution limit in `PIXELS` (720-7680)",
@@ -1271,6 +1410,24 @@ var Flags = CliFlags{
 			Value:   face.ClusterRadius,
 			EnvVars: EnvVars("FACE_CLUSTER_RADIUS"),
 		}}, {
+		Flag: &cli.StringFlag{
+			Name:    "face-cluster-algorithm",
+			Usage:   "`ALGORITHM` for grouping unknown faces into clusters (dbscan, optics)",
+			Value:   face.ClusterAlgorithm,
+			EnvVars: EnvVars("FACE_CLUSTER_ALGORITHM"),
+		}}, {
+		Flag: &cli.Float64Flag{
+			Name:    "face-cluster-xi",
+			Usage:   "reachability `STEEPNESS` for extracting OPTICS face clusters (0.001-0.5)",
+			Value:   face.ClusterXi,
+			EnvVars: EnvVars("FACE_CLUSTER_XI"),
+		}}, {
+		Flag: &cli.Float64Flag{
+			Name:    "face-suggest-dist",
+			Usage:   "maximum `DISTANCE` for suggesting that an unknown face belongs to a known person (0.1-1.5)",
+			Value:   face.SuggestDist,
+			EnvVars: EnvVars("FACE_SUGGEST_DIST"),
+		}}, {
 		Flag: &cli.Float64Flag{
 			Name:    "face-collision-dist",
 			Usage:   "minimum collision discrimination `DISTANCE` (0.01-1)",
@@ -52,6 +52,20 @@ type Options struct {
 	OIDCRole                  string        `yaml:"-" json:"-" flag:"oidc-role" tags:"pro"`
 	OIDCWebDAV                bool          `yaml:"OIDCWebDAV" json:"-" flag:"oidc-webdav"`
 	DisableOIDC               bool          `yaml:"DisableOIDC" json:"DisableOIDC" flag:"disable-oidc"`
+	LDAPUri                   string        `yaml:"LDAPUri" json:"-" flag:"ldap-uri"`
+	LDAPStartTLS              bool          `yaml:"LDAPStartTLS" json:"-" flag:"ldap-starttls"`
+	LDAPInsecure              bool          `yaml:"LDAPInsecure" json:"-" flag:"ldap-insecure"`
+	LDAPBindDN                string        `yaml:"LDAPBindDN" json:"-" flag:"ldap-bind-dn"`
+	LDAPBindPassword          string        `yaml:"LDAPBindPassword" json:"-" flag:"ldap-bind-password"`
+	LDAPBaseDN                string        `yaml:"LDAPBaseDN" json:"-" flag:"ldap-base-dn"`
+	LDAPFilter                string        `yaml:"LDAPFilter" json:"-" flag:"ldap-filter"`
+	LDAPGroupAttr             string        `yaml:"LDAPGroupAttr" json:"-" flag:"ldap-group-attr"`
+	LDAPGroup                 []string      `yaml:"LDAPGroup" json:"-" flag:"ldap-group"`
+	LDAPGroupRole             []string      `yaml:"LDAPGroupRole" json:"-" flag:"ldap-group-role"`
+	LDAPRole                  string        `yaml:"LDAPRole" json:"-" flag:"ldap-role"`
+	LDAPRegister              bool          `yaml:"LDAPRegister" json:"-" flag:"ldap-register"`
+	LDAPWebDAV                bool          `yaml:"LDAPWebDAV" json:"-" flag:"ldap-webdav"`
+	DisableLDAP               bool          `yaml:"DisableLDAP" json:"-" flag:"disable-ldap"`
 	SessionMaxAge             int64         `yaml:"SessionMaxAge" json:"-" flag:"session-maxage"`
 	SessionTimeout            int64         `yaml:"SessionTimeout" json:"-" flag:"session-timeout"`
 	SessionCache              int64         `yaml:"SessionCache" json:"-" flag:"session-cache"`
@@ -74,6 +88,7 @@ type Options struct {
 	ImportPath                string        `yaml:"ImportPath" json:"-" flag:"import-path"`
 	ImportDest                string        `yaml:"ImportDest" json:"-" flag:"import-dest"`
 	ImportAllow               string        `yaml:"ImportAllow" json:"ImportAllow" flag:"import-allow"`
+	MountPaths                []string      `yaml:"MountPaths" json:"-" flag:"mount-path"`
 	UploadNSFW                bool          `yaml:"UploadNSFW" json:"-" flag:"upload-nsfw"`
 	UploadAllow               string        `yaml:"UploadAllow" json:"-" flag:"upload-allow"`
 	UploadArchives            bool          `yaml:"UploadArchives" json:"-" flag:"upload-archives"`
@@ -86,6 +101,7 @@ type Options struct {
 	ModelsPath                string        `yaml:"ModelsPath" json:"-" flag:"models-path"`
 	SidecarPath               string        `yaml:"SidecarPath" json:"-" flag:"sidecar-path"`
 	SidecarYaml               bool          `yaml:"SidecarYaml" json:"SidecarYaml" flag:"sidecar-yaml" default:"true"`
+	SidecarXmp                bool          `yaml:"SidecarXmp" json:"SidecarXmp" flag:"sidecar-xmp"`
 	UsageInfo                 bool          `yaml:"UsageInfo" json:"UsageInfo" flag:"usage-info"`
 	FilesQuota                uint64        `yaml:"FilesQuota" json:"-" flag:"files-quota"`
 	UsersQuota                int           `yaml:"UsersQuota" json:"-" flag:"users-quota" tags:"pro"`
@@ -94,11 +110,17 @@ type Options struct {
 	BackupRetain              int           `yaml:"BackupRetain" json:"BackupRetain" flag:"backup-retain"`
 	BackupDatabase            bool          `yaml:"BackupDatabase" json:"BackupDatabase" flag:"backup-database" default:"true"`
 	BackupAlbums              bool          `yaml:"BackupAlbums" json:"BackupAlbums" flag:"backup-albums" default:"true"`
+	BackupArchive             bool          `yaml:"BackupArchive" json:"-" flag:"backup-archive"`
+	BackupCompression         string        `yaml:"BackupCompression" json:"-" flag:"backup-compression"`
+	BackupIncremental         bool          `yaml:"BackupIncremental" json:"-" flag:"backup-incremental"`
 	IndexWorkers              int           `yaml:"IndexWorkers" json:"IndexWorkers" flag:"index-workers"`
 	IndexSchedule             string        `yaml:"IndexSchedule" json:"IndexSchedule" flag:"index-schedule"`
 	WakeupInterval            time.Duration `yaml:"WakeupInterval" json:"WakeupInterval" flag:"wakeup-interval"`
 	AutoIndex                 int           `yaml:"AutoIndex" json:"AutoIndex" flag:"auto-index"`
 	AutoImport                int           `yaml:"AutoImport" json:"AutoImport" flag:"auto-import"`
+	SimilarDistance           int           `yaml:"SimilarDistance" json:"SimilarDistance" flag:"similar-distance"`
+	BestShot                  string        `yaml:"BestShot" json:"BestShot" flag:"best-shot"`
+	BestShotInterval          int           `yaml:"BestShotInterval" json:"BestShotInterval" flag:"best-shot-interval"`
 	ReadOnly                  bool          `yaml:"ReadOnly" json:"ReadOnly" flag:"read-only"`
 	Experimental              bool          `yaml:"Experimental" json:"Experimental" flag:"experimental"`
 	DisableFrontend           bool          `yaml:"DisableFrontend" json:"-" flag:"disable-frontend"`
@@ -111,6 +133,7 @@ type Options struct {
 	DisableFaces              bool          `yaml:"DisableFaces" json:"DisableFaces" flag:"disable-faces"`
 	DisableClassification     bool          `yaml:"DisableClassification" json:"DisableClassification" flag:"disable-classification"`
 	DisableFFmpeg             bool          `yaml:"DisableFFmpeg" json:"DisableFFmpeg" flag:"disable-ffmpeg"`
+	DisableHLS                bool          `yaml:"DisableHLS" json:"DisableHLS" flag:"disable-hls"`
 	DisableExifTool           bool          `yaml:"DisableExifTool" json:"DisableExifTool" flag:"disable-exiftool"`
 	DisableVips               bool          `yaml:"DisableVips" json:"DisableVips" flag:"disable-vips"`
 	DisableSips               bool          `yaml:"DisableSips" json:"DisableSips" flag:"disable-sips"`
@@ -127,6 +150,7 @@ type Options struct {
 	DefaultTimezone           string        `yaml:"DefaultTimezone" json:"DefaultTimezone" flag:"default-timezone"`
 	DefaultTheme              string        `yaml:"DefaultTheme" json:"DefaultTheme" flag:"default-theme"`
 	PlacesLocale              string        `yaml:"PlacesLocale" json:"PlacesLocale" flag:"places-locale"`
+	PlacesProvider            string        `yaml:"PlacesProvider" json:"PlacesProvider" flag:"places-provider"`
 	AppName                   string        `yaml:"AppName" json:"AppName" flag:"app-name"`
 	AppMode                   string        `yaml:"AppMode" json:"AppMode" flag:"app-mode"`
 	AppIcon                   string        `yaml:"AppIcon" json:"AppIcon" flag:"app-icon"`
@@ -196,6 +220,7 @@ type Options struct {
 	DatabaseProvisionProxyDSN string        `yaml:"DatabaseProvisionProxyDSN" json:"-" flag:"database-provision-proxy-dsn"`
 	FFmpegBin                 string        `yaml:"FFmpegBin" json:"-" flag:"ffmpeg-bin"`
 	FFmpegEncoder             string        `yaml:"FFmpegEncoder" json:"FFmpegEncoder" flag:"ffmpeg-encoder"`
+	FFmpegCodec               string        `yaml:"FFmpegCodec" json:"FFmpegCodec" flag:"ffmpeg-codec"`
 	FFmpegSize                int           `yaml:"FFmpegSize" json:"FFmpegSize" flag:"ffmpeg-size"`
 	FFmpegQuality             int           `yaml:"FFmpegQuality" json:"FFmpegQuality" flag:"ffmpeg-quality"`
 	FFmpegBitrate             int           `yaml:"FFmpegBitrate" json:"FFmpegBitrate" flag:"ffmpeg-bitrate"`
@@ -247,6 +272,9 @@ type Options struct {
 	FaceClusterCore           int           `yaml:"-" json:"-" flag:"face-cluster-core"`
 	FaceClusterDist           float64       `yaml:"-" json:"-" flag:"face-cluster-dist"`
 	FaceClusterRadius         float64       `yaml:"-" json:"-" flag:"face-cluster-radius"`
+	FaceClusterAlgorithm      string        `yaml:"-" json:"-" flag:"face-cluster-algorithm"`
+	FaceClusterXi             float64       `yaml:"-" json:"-" flag:"face-cluster-xi"`
+	FaceSuggestDist           float64       `yaml:"-" json:"-" flag:"face-suggest-dist"`
 	FaceCollisionDist         float64       `yaml:"-" json:"-" flag:"face-collision-dist"`
 	FaceEpsilonDist           float64       `yaml:"-" json:"-" flag:"face-epsilon-dist"`
 	FaceMatchDist             float64       `yaml:"-" json:"-" flag:"face-match-dist"`
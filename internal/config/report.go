@@ -66,6 +66,7 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"import-path", c.ImportPath()},
 		{"import-dest", c.ImportDest()},
 		{"import-allow", c.ImportAllow().String()},
+		{"mount-path", strings.Join(c.MountPaths(), ", ")},
 		{"upload-nsfw", fmt.Sprintf("%t", c.UploadNSFW())},
 		{"upload-allow", c.UploadAllow().String()},
 		{"upload-archives", fmt.Sprintf("%t", c.UploadArchives())},
@@ -74,6 +75,7 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"cmd-cache-path", c.CmdCachePath()},
 		{"media-cache-path", c.MediaCachePath()},
 		{"thumb-cache-path", c.ThumbCachePath()},
+		{"hls-cache-path", c.HlsCachePath()},
 		{"temp-path", c.TempPath()},
 		{"assets-path", c.AssetsPath()},
 		{"models-path", c.ModelsPath()},
@@ -85,6 +87,7 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		// Sidecar Files.
 		{"sidecar-path", c.SidecarPath()},
 		{"sidecar-yaml", fmt.Sprintf("%t", c.SidecarYaml())},
+		{"sidecar-xmp", fmt.Sprintf("%t", c.SidecarXmp())},
 
 		// Usage.
 		{"usage-info", fmt.Sprintf("%t", c.UsageInfo())},
@@ -99,6 +102,9 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"backup-database-path", c.BackupDatabasePath()},
 		{"backup-albums", fmt.Sprintf("%t", c.BackupAlbums())},
 		{"backup-albums-path", c.BackupAlbumsPath()},
+		{"backup-archive", fmt.Sprintf("%t", c.BackupArchive())},
+		{"backup-compression", c.BackupCompression()},
+		{"backup-incremental", fmt.Sprintf("%t", c.BackupIncremental())},
 
 		// Indexing.
 		{"index-workers", fmt.Sprintf("%d", c.IndexWorkers())},
@@ -106,6 +112,9 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"wakeup-interval", c.WakeupInterval().String()},
 		{"auto-index", fmt.Sprintf("%d", c.AutoIndex()/time.Second)},
 		{"auto-import", fmt.Sprintf("%d", c.AutoImport()/time.Second)},
+		{"similar-distance", fmt.Sprintf("%d", c.SimilarDistance())},
+		{"best-shot", c.BestShot()},
+		{"best-shot-interval", fmt.Sprintf("%d", c.BestShotInterval()/time.Second)},
 
 		// Feature Flags.
 		{"read-only", fmt.Sprintf("%t", c.ReadOnly())},
@@ -121,6 +130,7 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"disable-faces", fmt.Sprintf("%t", c.DisableFaces())},
 		{"disable-classification", fmt.Sprintf("%t", c.DisableClassification())},
 		{"disable-ffmpeg", fmt.Sprintf("%t", c.DisableFFmpeg())},
+		{"disable-hls", fmt.Sprintf("%t", c.DisableHLS())},
 		{"disable-exiftool", fmt.Sprintf("%t", c.DisableExifTool())},
 		{"disable-vips", fmt.Sprintf("%t", c.DisableVips())},
 		{"disable-sips", fmt.Sprintf("%t", c.DisableSips())},
@@ -142,6 +152,7 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"default-timezone", c.DefaultTimezone().String()},
 		{"default-theme", c.DefaultTheme()},
 		{"places-locale", c.PlacesLocale()},
+		{"places-provider", c.PlacesProvider()},
 		{"app-name", c.AppName()},
 		{"app-mode", c.AppMode()},
 		{"app-icon", c.AppIcon()},
@@ -257,6 +268,7 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		// File Converters.
 		{"ffmpeg-bin", c.FFmpegBin()},
 		{"ffmpeg-encoder", c.FFmpegEncoder().String()},
+		{"ffmpeg-codec", c.FFmpegCodec().String()},
 		{"ffmpeg-size", fmt.Sprintf("%d", c.FFmpegSize())},
 		{"ffmpeg-quality", fmt.Sprintf("%d", c.FFmpegQuality())},
 		{"ffmpeg-bitrate", fmt.Sprintf("%d", c.FFmpegBitrate())},
@@ -337,6 +349,9 @@ func (c *Config) Report() (rows [][]string, cols []string) {
 		{"face-cluster-core", fmt.Sprintf("%d", c.FaceClusterCore())},
 		{"face-cluster-dist", fmt.Sprintf("%f", c.FaceClusterDist())},
 		{"face-cluster-radius", fmt.Sprintf("%f", c.FaceClusterRadius())},
+		{"face-cluster-algorithm", c.FaceClusterAlgorithm()},
+		{"face-cluster-xi", fmt.Sprintf("%f", c.FaceClusterXi())},
+		{"face-suggest-dist", fmt.Sprintf("%f", c.FaceSuggestDist())},
 		{"face-collision-dist", fmt.Sprintf("%f", c.FaceCollisionDist())},
 		{"face-epsilon-dist", fmt.Sprintf("%f", c.FaceEpsilonDist())},
 		{"face-match-dist", fmt.Sprintf("%f", c.FaceMatchDist())},
@@ -28,6 +28,7 @@ const (
 	AlbumMoment = "moment"
 	AlbumMonth  = "month"
 	AlbumState  = "state"
+	AlbumSmart  = "smart"
 )
 
 var (
@@ -36,6 +37,7 @@ var (
 	DefaultOrderMoment = sortby.Oldest
 	DefaultOrderState  = sortby.Newest
 	DefaultOrderMonth  = sortby.Oldest
+	DefaultOrderSmart  = sortby.Newest
 )
 
 var (
@@ -78,6 +80,7 @@ type Album struct {
 	PublishedAt      *time.Time  `sql:"index" json:"PublishedAt,omitempty" yaml:"PublishedAt,omitempty"`
 	DeletedAt        *time.Time  `sql:"index" json:"DeletedAt" yaml:"DeletedAt,omitempty"`
 	Photos           PhotoAlbums `gorm:"foreignkey:AlbumUID;association_foreignkey:AlbumUID;" json:"-" yaml:"Photos,omitempty"`
+	Comments         Comments    `gorm:"-" json:"-" yaml:"Comments,omitempty"`
 }
 
 // AfterUpdate flushes the album cache when an album is updated.
@@ -236,6 +239,18 @@ func NewUserAlbum(albumTitle, albumType, sortOrder, userUid string) *Album {
 	return result
 }
 
+// NewSmartAlbum creates a new user album whose pictures are selected by a saved search filter.
+func NewSmartAlbum(albumTitle, albumFilter, sortOrder, userUid string) *Album {
+	if sortOrder == "" {
+		sortOrder = DefaultOrderSmart
+	}
+
+	result := NewUserAlbum(albumTitle, AlbumSmart, sortOrder, userUid)
+	result.AlbumFilter = strings.TrimSpace(albumFilter)
+
+	return result
+}
+
 // NewFolderAlbum creates a new album representing a filesystem folder.
 func NewFolderAlbum(albumTitle, albumPath, albumFilter string) *Album {
 	albumSlug := txt.Slug(albumPath)
@@ -501,6 +516,21 @@ func FindAlbum(find Album) *Album {
 	return &m
 }
 
+// FindSmartAlbum returns the smart album of the specified user with the same search filter, if any.
+func FindSmartAlbum(albumFilter, userUid string) *Album {
+	if albumFilter = strings.TrimSpace(albumFilter); albumFilter == "" {
+		return nil
+	}
+
+	m := Album{}
+
+	if Db().Where("album_type = ? AND album_filter = ? AND created_by = ?", AlbumSmart, albumFilter, userUid).First(&m).Error != nil {
+		return nil
+	}
+
+	return &m
+}
+
 // HasID tests if the album has a valid id and uid.
 func (m *Album) HasID() bool {
 	if m == nil {
@@ -557,6 +587,11 @@ func (m *Album) IsState() bool {
 	return m.AlbumType == AlbumState
 }
 
+// IsSmart tests if the album is of type smart.
+func (m *Album) IsSmart() bool {
+	return m.AlbumType == AlbumSmart
+}
+
 // IsDefault tests if the album is a regular album.
 func (m *Album) IsDefault() bool {
 	return m.AlbumType == AlbumManual
@@ -783,6 +818,9 @@ func (m *Album) Create() error {
 		return err
 	}
 
+	// Grant the user who created the album full access.
+	SetAlbumOwner(m.AlbumUID, m.CreatedBy)
+
 	m.PublishCountChange(1)
 	event.PublishUserEntities("albums", event.EntityCreated, []*Album{m}, m.CreatedBy)
 
@@ -843,6 +881,14 @@ func (m *Album) DeletePermanently() error {
 		return err
 	}
 
+	if err := UnscopedDb().Delete(AlbumUser{}, "uid = ?", m.AlbumUID).Error; err != nil {
+		log.Errorf("album: %s (remove permissions)", err)
+	}
+
+	if err := DeleteComments(m.AlbumUID); err != nil {
+		log.Errorf("album: %s (remove comments)", err)
+	}
+
 	if !wasDeleted {
 		m.PublishCountChange(-1)
 		event.EntitiesDeleted("albums", []string{m.AlbumUID})
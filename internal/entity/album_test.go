@@ -301,6 +301,45 @@ func TestNewMomentsAlbum(t *testing.T) {
 	})
 }
 
+// TestNewSmartAlbum exercises the related album behavior.
+func TestNewSmartAlbum(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		album := NewSmartAlbum("Beach 2024", " label:beach year:2024 ", "", "uqxetse3cy5eo9z2")
+		assert.Equal(t, "Beach 2024", album.AlbumTitle)
+		assert.Equal(t, "beach-2024", album.AlbumSlug)
+		assert.Equal(t, AlbumSmart, album.AlbumType)
+		assert.Equal(t, sortby.Newest, album.AlbumOrder)
+		assert.Equal(t, "label:beach year:2024", album.AlbumFilter)
+		assert.Equal(t, "uqxetse3cy5eo9z2", album.CreatedBy)
+		assert.True(t, album.IsSmart())
+		assert.False(t, album.IsDefault())
+	})
+	t.Run("SortOrder", func(t *testing.T) {
+		album := NewSmartAlbum("Beach", "label:beach", sortby.Oldest, OwnerUnknown)
+		assert.Equal(t, sortby.Oldest, album.AlbumOrder)
+	})
+}
+
+func TestFindSmartAlbum(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		album := NewSmartAlbum("Find Smart", "label:find-smart", "", "uqxetse3cy5eo9z2")
+
+		if err := album.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		if found := FindSmartAlbum(" label:find-smart ", "uqxetse3cy5eo9z2"); assert.NotNil(t, found) {
+			assert.Equal(t, album.AlbumUID, found.AlbumUID)
+		}
+
+		assert.Nil(t, FindSmartAlbum("label:find-smart", "uqxc08w3d0ej2283"))
+		assert.Nil(t, FindSmartAlbum("label:find-smart year:2024", "uqxetse3cy5eo9z2"))
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.Nil(t, FindSmartAlbum("", "uqxetse3cy5eo9z2"))
+	})
+}
+
 // TestNewStateAlbum exercises the related album behavior.
 func TestNewStateAlbum(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
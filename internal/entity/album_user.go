@@ -1,11 +1,19 @@
 package entity
 
 import (
+	"fmt"
+
 	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/log/status"
+	"github.com/photoprism/photoprism/pkg/rnd"
 )
 
+// AlbumUsers represents a list of album users and teams.
+type AlbumUsers []AlbumUser
+
 // AlbumUser maps an album to a user or team and stores the associated permissions.
+// Permissions granted to a team are stored with both UserUID and TeamUID set to the team UID.
 type AlbumUser struct {
 	UID     string `gorm:"type:VARBINARY(42);primary_key;auto_increment:false" json:"UID" yaml:"UID"`
 	UserUID string `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;index" json:"UserUID,omitempty" yaml:"UserUID,omitempty"`
@@ -44,7 +52,7 @@ func (m *AlbumUser) Save() error {
 func FirstOrCreateAlbumUser(m *AlbumUser) *AlbumUser {
 	found := AlbumUser{}
 
-	if err := Db().Where("uid = ?", m.UID).First(&found).Error; err == nil {
+	if err := Db().Where("uid = ? AND user_uid = ?", m.UID, m.UserUID).First(&found).Error; err == nil {
 		return &found
 	} else if err = m.Create(); err != nil {
 		event.AuditErr([]string{"album %s", "failed to set owner and permissions", status.Error(err)}, m.UID)
@@ -53,3 +61,62 @@ func FirstOrCreateAlbumUser(m *AlbumUser) *AlbumUser {
 
 	return m
 }
+
+// Delete removes the record from the database.
+func (m *AlbumUser) Delete() error {
+	if m.UID == "" || m.UserUID == "" {
+		return fmt.Errorf("album and user uid are required")
+	}
+
+	return UnscopedDb().Delete(&AlbumUser{}, "uid = ? AND user_uid = ?", m.UID, m.UserUID).Error
+}
+
+// IsTeam checks if the permissions were granted to a team rather than a single user.
+func (m *AlbumUser) IsTeam() bool {
+	return m.TeamUID != "" && m.TeamUID == m.UserUID
+}
+
+// FindAlbumUsers returns the users and teams who have been granted permissions for the specified album.
+func FindAlbumUsers(uid string) (result AlbumUsers) {
+	result = AlbumUsers{}
+
+	if rnd.InvalidUID(uid, AlbumUID) {
+		return result
+	}
+
+	if err := Db().Where("uid = ?", uid).Order("user_uid").Find(&result).Error; err != nil {
+		log.Errorf("album: %s (find users)", err)
+	}
+
+	return result
+}
+
+// SetAlbumOwner grants the user who created a album full access to it.
+func SetAlbumOwner(uid, userUid string) *AlbumUser {
+	if rnd.InvalidUID(uid, AlbumUID) || rnd.InvalidUID(userUid, UserUID) {
+		return nil
+	}
+
+	return FirstOrCreateAlbumUser(NewAlbumUser(uid, userUid, "", PermAll))
+}
+
+// ShareAlbum grants a registered user or a team the specified permissions for a album.
+func ShareAlbum(uid, principalUid string, perm uint) (*AlbumUser, error) {
+	if rnd.InvalidUID(uid, AlbumUID) {
+		return nil, fmt.Errorf("invalid album uid %s", clean.Log(uid))
+	} else if perm&^PermGrantable != 0 {
+		return nil, fmt.Errorf("edit and upload permissions cannot be granted")
+	} else if perm == PermDefault {
+		perm = PermView
+	}
+
+	m := NewAlbumUser(uid, principalUid, "", perm)
+
+	if rnd.IsUID(principalUid, TeamUID) {
+		m.TeamUID = principalUid
+	} else if rnd.InvalidUID(principalUid, UserUID) {
+		return nil, fmt.Errorf("invalid user or team uid %s", clean.Log(principalUid))
+	}
+
+	return m, m.Save()
+}
@@ -0,0 +1,75 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity/sortby"
+)
+
+func TestShareAlbum(t *testing.T) {
+	t.Run("User", func(t *testing.T) {
+		m, err := ShareAlbum("as6sg6bxpogaaba7", "uqxc08w3d0ej2283", PermView)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "", m.TeamUID)
+		assert.Contains(t, FindAlbumUsers("as6sg6bxpogaaba7"), *m)
+
+		// Update permissions.
+		if m, err = ShareAlbum("as6sg6bxpogaaba7", "uqxc08w3d0ej2283", PermComment); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, FindAlbumUsers("as6sg6bxpogaaba7"), 1)
+		assert.Equal(t, PermComment, FindAlbumUsers("as6sg6bxpogaaba7")[0].Perm)
+
+		assert.NoError(t, m.Delete())
+		assert.Len(t, FindAlbumUsers("as6sg6bxpogaaba7"), 0)
+	})
+	t.Run("NotGrantable", func(t *testing.T) {
+		_, err := ShareAlbum("as6sg6bxpogaaba7", "uqxc08w3d0ej2283", PermView|PermEdit)
+		assert.Error(t, err)
+		_, err = ShareAlbum("as6sg6bxpogaaba7", "uqxc08w3d0ej2283", PermUpload)
+		assert.Error(t, err)
+		assert.Len(t, FindAlbumUsers("as6sg6bxpogaaba7"), 0)
+	})
+	t.Run("InvalidUID", func(t *testing.T) {
+		_, err := ShareAlbum("ps6sg6be2lvl0yh8", "uqxc08w3d0ej2283", PermView)
+		assert.Error(t, err)
+		_, err = ShareAlbum("as6sg6bxpogaaba7", "ps6sg6be2lvl0yh8", PermView)
+		assert.Error(t, err)
+	})
+}
+
+func TestSetAlbumOwner(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		m := SetAlbumOwner("as6sg6bxpogaaba7", "uqxetse3cy5eo9z2")
+
+		if m == nil {
+			t.Fatal("result must not be nil")
+		}
+
+		assert.Equal(t, PermAll, m.Perm)
+		assert.Equal(t, m, SetAlbumOwner("as6sg6bxpogaaba7", "uqxetse3cy5eo9z2"))
+		assert.NoError(t, m.Delete())
+	})
+	t.Run("NoOwner", func(t *testing.T) {
+		assert.Nil(t, SetAlbumOwner("as6sg6bxpogaaba7", ""))
+	})
+}
+
+func TestAlbum_CreateOwner(t *testing.T) {
+	m := NewUserAlbum("Owner Test", AlbumManual, sortby.Added, "uqxc08w3d0ej2283")
+
+	if err := m.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, PermAll, UserFixtures.Pointer("bob").ItemPerm(m.AlbumUID))
+	assert.NoError(t, m.DeletePermanently())
+	assert.Empty(t, FindAlbumUsers(m.AlbumUID))
+}
@@ -25,6 +25,10 @@ func (m *Album) Yaml() (out []byte, err error) {
 		return out, err
 	}
 
+	// Include comments, so they can be restored from the backup file.
+	m.Comments = FindComments(m.AlbumUID)
+	defer func() { m.Comments = nil }()
+
 	return yaml.Marshal(m)
 }
 
@@ -0,0 +1,140 @@
+package entity
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize"
+
+	"github.com/photoprism/photoprism/internal/auth/acl"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// Quota subject types.
+const (
+	QuotaUser = "user"
+	QuotaRole = "role"
+)
+
+// ErrQuotaExceeded is returned if adding files would exceed the storage quota of a user.
+var ErrQuotaExceeded = errors.New("storage quota exceeded")
+
+// Quotas represents a list of storage quotas.
+type Quotas []Quota
+
+// Quota represents the maximum storage size in bytes and number of original files that a user account,
+// or all accounts with a role, may own. A value of 0 means unlimited, so a user quota can lift a role quota.
+type Quota struct {
+	SubjectType string    `gorm:"type:VARBINARY(8);primary_key;auto_increment:false;" json:"SubjectType" yaml:"SubjectType"`
+	SubjectUID  string    `gorm:"type:VARBINARY(64);primary_key;auto_increment:false;" json:"SubjectUID" yaml:"SubjectUID"`
+	MaxSize     int64     `json:"MaxSize" yaml:"MaxSize,omitempty"`
+	MaxFiles    int       `json:"MaxFiles" yaml:"MaxFiles,omitempty"`
+	CreatedAt   time.Time `json:"CreatedAt" yaml:"-"`
+	UpdatedAt   time.Time `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns the entity table name.
+func (Quota) TableName() string {
+	return "auth_quotas"
+}
+
+// FindQuota returns the quota of the specified user UID or role, or nil if none was set.
+func FindQuota(subjectType, subject string) *Quota {
+	if subjectType == "" || subject == "" {
+		return nil
+	}
+
+	m := &Quota{}
+
+	if err := Db().First(m, "subject_type = ? AND subject_uid = ?", subjectType, subject).Error; err != nil {
+		return nil
+	}
+
+	return m
+}
+
+// UserQuota returns the quota that applies to the user account, or nil if there is none.
+// Quotas set for the account take precedence over quotas set for its role.
+func UserQuota(user *User) *Quota {
+	if user == nil || user.UserUID == "" {
+		return nil
+	} else if m := FindQuota(QuotaUser, user.UserUID); m != nil {
+		return m
+	}
+
+	return FindQuota(QuotaRole, user.AclRole().String())
+}
+
+// SetQuota sets the quota of the specified user UID or role and returns it.
+func SetQuota(subjectType, subject string, maxSize int64, maxFiles int) (*Quota, error) {
+	switch subjectType {
+	case QuotaUser:
+		if rnd.InvalidUID(subject, UserUID) {
+			return nil, fmt.Errorf("invalid user uid %s", clean.Log(subject))
+		}
+	case QuotaRole:
+		if subject = clean.Role(subject); acl.UserRoles[subject] == "" || acl.UserRoles[subject] == acl.RoleNone {
+			return nil, fmt.Errorf("invalid role %s", clean.Log(subject))
+		}
+	default:
+		return nil, fmt.Errorf("invalid quota type %s", clean.Log(subjectType))
+	}
+
+	if maxSize < 0 || maxFiles < 0 {
+		return nil, errors.New("quota must not be negative")
+	}
+
+	m := &Quota{SubjectType: subjectType, SubjectUID: subject}
+
+	if found := FindQuota(subjectType, subject); found != nil {
+		m = found
+	}
+
+	m.MaxSize = maxSize
+	m.MaxFiles = maxFiles
+
+	return m, m.Save()
+}
+
+// Unlimited checks if neither the storage size nor the number of files is limited.
+func (m *Quota) Unlimited() bool {
+	return m == nil || m.MaxSize <= 0 && m.MaxFiles <= 0
+}
+
+// Check returns ErrQuotaExceeded if adding the specified number of bytes and files to the current usage would exceed the quota.
+func (m *Quota) Check(usedSize int64, usedFiles int, addSize int64, addFiles int) error {
+	if m.Unlimited() {
+		return nil
+	}
+
+	if m.MaxSize > 0 && usedSize+addSize > m.MaxSize {
+		return fmt.Errorf("%w: %s of %s used", ErrQuotaExceeded, humanize.IBytes(uint64(usedSize)), humanize.IBytes(uint64(m.MaxSize)))
+	}
+
+	if m.MaxFiles > 0 && usedFiles+addFiles > m.MaxFiles {
+		return fmt.Errorf("%w: %d of %d files used", ErrQuotaExceeded, usedFiles, m.MaxFiles)
+	}
+
+	return nil
+}
+
+// Delete removes the quota.
+func (m *Quota) Delete() error {
+	if m.SubjectType == "" || m.SubjectUID == "" {
+		return errors.New("quota subject is missing")
+	}
+
+	return Db().Delete(m, "subject_type = ? AND subject_uid = ?", m.SubjectType, m.SubjectUID).Error
+}
+
+// Save updates the record in the database or inserts a new record if it does not already exist.
+func (m *Quota) Save() error {
+	return Db().Save(m).Error
+}
+
+// Create inserts a new row to the database.
+func (m *Quota) Create() error {
+	return Db().Create(m).Error
+}
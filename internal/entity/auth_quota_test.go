@@ -0,0 +1,129 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/auth/acl"
+)
+
+func TestSetQuota(t *testing.T) {
+	t.Run("User", func(t *testing.T) {
+		uid := UserFixtures.Get("friend").UserUID
+
+		m, err := SetQuota(QuotaUser, uid, 1024, 10)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, int64(1024), m.MaxSize)
+		assert.Equal(t, 10, m.MaxFiles)
+
+		// Update existing quota.
+		m, err = SetQuota(QuotaUser, uid, 2048, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		found := FindQuota(QuotaUser, uid)
+
+		if found == nil {
+			t.Fatal("quota not found")
+		}
+
+		assert.Equal(t, int64(2048), found.MaxSize)
+		assert.Equal(t, 0, found.MaxFiles)
+		assert.NoError(t, found.Delete())
+		assert.Nil(t, FindQuota(QuotaUser, uid))
+	})
+	t.Run("Role", func(t *testing.T) {
+		m, err := SetQuota(QuotaRole, "Guest", 0, 100)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, acl.RoleGuest.String(), m.SubjectUID)
+		assert.NoError(t, m.Delete())
+	})
+	t.Run("InvalidUser", func(t *testing.T) {
+		_, err := SetQuota(QuotaUser, "alice", 1024, 0)
+		assert.Error(t, err)
+	})
+	t.Run("InvalidRole", func(t *testing.T) {
+		_, err := SetQuota(QuotaRole, "none", 1024, 0)
+		assert.Error(t, err)
+	})
+	t.Run("InvalidType", func(t *testing.T) {
+		_, err := SetQuota("team", "admin", 1024, 0)
+		assert.Error(t, err)
+	})
+	t.Run("Negative", func(t *testing.T) {
+		_, err := SetQuota(QuotaRole, "admin", -1, 0)
+		assert.Error(t, err)
+	})
+}
+
+func TestUserQuota(t *testing.T) {
+	user := UserFixtures.Pointer("friend")
+
+	assert.Nil(t, UserQuota(nil))
+	assert.Nil(t, UserQuota(user))
+
+	roleQuota, err := SetQuota(QuotaRole, user.AclRole().String(), 1000, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer roleQuota.Delete()
+
+	if m := UserQuota(user); assert.NotNil(t, m) {
+		assert.Equal(t, QuotaRole, m.SubjectType)
+		assert.Equal(t, int64(1000), m.MaxSize)
+	}
+
+	// Quotas set for the account take precedence.
+	userQuota, err := SetQuota(QuotaUser, user.UserUID, 0, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer userQuota.Delete()
+
+	if m := UserQuota(user); assert.NotNil(t, m) {
+		assert.Equal(t, QuotaUser, m.SubjectType)
+		assert.True(t, m.Unlimited())
+	}
+}
+
+func TestQuota_Check(t *testing.T) {
+	t.Run("Unlimited", func(t *testing.T) {
+		var m *Quota
+		assert.NoError(t, m.Check(1000, 10, 1000, 10))
+		assert.NoError(t, (&Quota{}).Check(1000, 10, 1000, 10))
+	})
+	t.Run("Size", func(t *testing.T) {
+		m := &Quota{MaxSize: 2048}
+		assert.NoError(t, m.Check(1024, 100, 1024, 1))
+
+		err := m.Check(1024, 100, 1025, 1)
+
+		assert.True(t, errors.Is(err, ErrQuotaExceeded))
+		assert.Equal(t, "storage quota exceeded: 1.0 KiB of 2.0 KiB used", err.Error())
+	})
+	t.Run("Files", func(t *testing.T) {
+		m := &Quota{MaxFiles: 10}
+		assert.NoError(t, m.Check(1024, 9, 1024, 1))
+
+		err := m.Check(1024, 9, 0, 2)
+
+		assert.True(t, errors.Is(err, ErrQuotaExceeded))
+		assert.Equal(t, "storage quota exceeded: 9 of 10 files used", err.Error())
+	})
+}
@@ -0,0 +1,133 @@
+package entity
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// SharesPath is the storage path for files uploaded with share links that have not been imported yet.
+var SharesPath = ""
+
+// PendingUpload represents a file that was uploaded by a user, e.g. via WebDAV, and has not been indexed yet.
+type PendingUpload struct {
+	UserUID string
+	Size    int64
+}
+
+// pendingUploads maps the absolute names of uploaded files to the users who uploaded them.
+var pendingUploads = struct {
+	sync.Mutex
+	files map[string]PendingUpload
+}{files: make(map[string]PendingUpload)}
+
+// AddPendingUpload remembers the user who uploaded a file, so that it counts towards the
+// storage quota of the account and the user becomes the owner once the file is indexed.
+func AddPendingUpload(fileName, userUID string, size int64) {
+	if fileName == "" || userUID == "" {
+		return
+	} else if size < 0 {
+		size = 0
+	}
+
+	pendingUploads.Lock()
+	defer pendingUploads.Unlock()
+
+	pendingUploads.files[filepath.Clean(fileName)] = PendingUpload{UserUID: userUID, Size: size}
+}
+
+// RemovePendingUpload forgets an uploaded file, e.g. after it has been indexed or deleted,
+// and returns the UID of the user who uploaded it, or an empty string if it is unknown.
+func RemovePendingUpload(fileName string) (userUID string) {
+	if fileName == "" {
+		return ""
+	}
+
+	fileName = filepath.Clean(fileName)
+
+	pendingUploads.Lock()
+	defer pendingUploads.Unlock()
+
+	if upload, ok := pendingUploads.files[fileName]; ok {
+		delete(pendingUploads.files, fileName)
+		return upload.UserUID
+	}
+
+	return ""
+}
+
+// RemovePendingUploads forgets the uploaded file or all uploaded files in the folder, e.g. after it has been deleted.
+func RemovePendingUploads(fileName string) {
+	if fileName == "" {
+		return
+	}
+
+	fileName = filepath.Clean(fileName)
+	dir := fileName + string(os.PathSeparator)
+
+	pendingUploads.Lock()
+	defer pendingUploads.Unlock()
+
+	for name := range pendingUploads.files {
+		if name == fileName || strings.HasPrefix(name, dir) {
+			delete(pendingUploads.files, name)
+		}
+	}
+}
+
+// PendingUploadUsage returns the size in bytes and the number of files that the user has uploaded
+// and that have not been indexed yet.
+func PendingUploadUsage(userUID string) (size int64, files int) {
+	if userUID == "" {
+		return 0, 0
+	}
+
+	pendingUploads.Lock()
+	defer pendingUploads.Unlock()
+
+	for _, upload := range pendingUploads.files {
+		if upload.UserUID == userUID {
+			size += upload.Size
+			files++
+		}
+	}
+
+	return size, files
+}
+
+// ShareUploadUsage returns the size in bytes and the number of files that have been uploaded with share
+// links created by the user and are still staged in the shares path, e.g. because they await approval.
+func ShareUploadUsage(userUID string) (size int64, files int) {
+	if userUID == "" || SharesPath == "" {
+		return 0, 0
+	}
+
+	var links Links
+
+	if err := Db().Where("created_by = ?", userUID).Find(&links).Error; err != nil {
+		log.Warnf("quota: %s (find share links)", err)
+		return 0, 0
+	}
+
+	for _, link := range links {
+		uploadPath := filepath.Join(SharesPath, link.LinkUID, fs.UploadDir)
+
+		_ = filepath.WalkDir(uploadPath, func(fileName string, d os.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return nil
+			}
+
+			if info, infoErr := d.Info(); infoErr == nil {
+				size += info.Size()
+				files++
+			}
+
+			return nil
+		})
+	}
+
+	return size, files
+}
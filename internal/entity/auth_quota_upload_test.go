@@ -0,0 +1,88 @@
+package entity
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+func TestPendingUploads(t *testing.T) {
+	AddPendingUpload("/originals/upload/a.jpg", "uqxc08w3d0ej2283", 100)
+	AddPendingUpload("/originals/upload/b.jpg", "uqxc08w3d0ej2283", 200)
+	AddPendingUpload("/originals/upload/sub/c.jpg", "uqxc08w3d0ej2283", 300)
+	AddPendingUpload("/originals/other.jpg", "uqxetse3cy5eo9z2", 400)
+	AddPendingUpload("", "uqxc08w3d0ej2283", 500)
+	AddPendingUpload("/originals/anonymous.jpg", "", 500)
+
+	defer RemovePendingUploads("/originals")
+
+	size, files := PendingUploadUsage("uqxc08w3d0ej2283")
+	assert.Equal(t, int64(600), size)
+	assert.Equal(t, 3, files)
+
+	assert.Equal(t, "uqxc08w3d0ej2283", RemovePendingUpload("/originals/upload/a.jpg"))
+	assert.Equal(t, "", RemovePendingUpload("/originals/upload/a.jpg"))
+	assert.Equal(t, "", RemovePendingUpload("/originals/anonymous.jpg"))
+
+	RemovePendingUploads("/originals/upload")
+
+	size, files = PendingUploadUsage("uqxc08w3d0ej2283")
+	assert.Equal(t, int64(0), size)
+	assert.Equal(t, 0, files)
+
+	size, files = PendingUploadUsage("uqxetse3cy5eo9z2")
+	assert.Equal(t, int64(400), size)
+	assert.Equal(t, 1, files)
+
+	size, files = PendingUploadUsage("")
+	assert.Equal(t, int64(0), size)
+	assert.Equal(t, 0, files)
+}
+
+func TestShareUploadUsage(t *testing.T) {
+	userUID := "uqxc08w3d0ej2283"
+	link := NewUserLink(rnd.GenerateUID(AlbumUID), userUID)
+
+	if err := link.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if err := link.Delete(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	sharesPath := SharesPath
+	SharesPath = t.TempDir()
+	defer func() { SharesPath = sharesPath }()
+
+	size, files := ShareUploadUsage(userUID)
+	assert.Equal(t, int64(0), size)
+	assert.Equal(t, 0, files)
+
+	uploadDir := filepath.Join(SharesPath, link.LinkUID, fs.UploadDir, "20261018-120000-abcdef")
+
+	if err := fs.MkdirAll(uploadDir); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, data := range map[string]string{"a.jpg": "12345", "b.jpg": "123"} {
+		if err := os.WriteFile(filepath.Join(uploadDir, name), []byte(data), fs.ModeFile); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	size, files = ShareUploadUsage(userUID)
+	assert.Equal(t, int64(8), size)
+	assert.Equal(t, 2, files)
+
+	size, files = ShareUploadUsage("uqxetse3cy5eo9z2")
+	assert.Equal(t, int64(0), size)
+	assert.Equal(t, 0, files)
+}
@@ -841,6 +841,17 @@ func (m *Session) HasShare(uid string) bool {
 	}
 }
 
+// SharePerm returns the permissions granted for the specified share and the UID of the link granting them.
+func (m *Session) SharePerm(uid string) (perm uint, linkUid string) {
+	if user := m.GetUser(); user.IsRegistered() {
+		return user.SharePerm(uid)
+	} else if data := m.GetData(); data == nil {
+		return PermDefault, ""
+	} else {
+		return data.SharePerm(uid)
+	}
+}
+
 // SharedUIDs returns shared entity UIDs.
 func (m *Session) SharedUIDs() UIDs {
 	if user := m.GetUser(); user.IsRegistered() {
@@ -95,6 +95,29 @@ func (data SessionData) HasShare(uid string) bool {
 	return false
 }
 
+// SharePerm returns the permissions granted for the specified share and the UID of the link granting them.
+func (data SessionData) SharePerm(uid string) (perm uint, linkUid string) {
+	if uid == "" || !data.HasShare(uid) {
+		return PermDefault, ""
+	}
+
+	for _, token := range data.Tokens {
+		for _, link := range FindValidLinks(token, "") {
+			if link.ShareUID != uid {
+				continue
+			}
+
+			perm |= link.Perm
+
+			if linkUid == "" {
+				linkUid = link.LinkUID
+			}
+		}
+	}
+
+	return perm, linkUid
+}
+
 // SharedUIDs returns shared entity UIDs.
 func (data SessionData) SharedUIDs() UIDs {
 	if len(data.Tokens) > 0 && len(data.Shares) == 0 {
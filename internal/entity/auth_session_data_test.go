@@ -22,6 +22,18 @@ func TestData_HasShare(t *testing.T) {
 	assert.False(t, data.HasShare("xxx"))
 }
 
+func TestSessionData_SharePerm(t *testing.T) {
+	data := SessionData{Tokens: []string{"8jxf3jfn2k"}, Shares: UIDs{"as6sg6bxpogaaba9"}}
+
+	perm, linkUid := data.SharePerm("as6sg6bxpogaaba9")
+	assert.Equal(t, PermView|PermComment|PermUpload, perm)
+	assert.Equal(t, "ss62xpryd1ob3gtf", linkUid)
+
+	perm, linkUid = data.SharePerm("as6sg6bxpogaaba8")
+	assert.Equal(t, PermDefault, perm)
+	assert.Empty(t, linkUid)
+}
+
 func TestSessionData_RedeemToken(t *testing.T) {
 	data := SessionData{Shares: []string{"abc123", "def444"}}
 	assert.True(t, data.HasShare("def444"))
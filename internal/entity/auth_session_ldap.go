@@ -0,0 +1,148 @@
+package entity
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/photoprism/photoprism/internal/auth/ldap"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/authn"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/http/header"
+	"github.com/photoprism/photoprism/pkg/i18n"
+	"github.com/photoprism/photoprism/pkg/log/status"
+)
+
+// LDAP contains the directory server settings for password authentication via LDAP / Active Directory,
+// or nil if it is disabled.
+var LDAP *ldap.Config
+
+// LdapAuthenticate verifies the credentials of a directory user, see ldap.Authenticate.
+var LdapAuthenticate = ldap.Authenticate
+
+// AuthLDAP authenticates a user against the configured LDAP / Active Directory server. Accounts are
+// created for new directory users if registration is enabled, and the role is updated based on the
+// group memberships if a matching group role has been configured.
+func AuthLDAP(user *User, frm form.Login, s *Session, c *gin.Context) (result *User, provider authn.ProviderType, method authn.MethodType, err error) {
+	// Set defaults.
+	provider = authn.ProviderNone
+	method = authn.MethodUndefined
+
+	// Get client IP from request context.
+	clientIp := header.ClientIP(c)
+
+	// Get sanitized username from login form.
+	username := frm.CleanUsername()
+
+	// Logs the reason why the login failed and returns a generic error.
+	failed := func(message string) error {
+		if s != nil {
+			event.AuditWarn([]string{clientIp, "session %s", "login as %s", "ldap", message}, s.RefID, clean.LogQuote(username))
+			event.LoginError(clientIp, "api", username, s.UserAgent, message)
+			s.Status = http.StatusUnauthorized
+		}
+
+		return i18n.Error(i18n.ErrInvalidCredentials)
+	}
+
+	if !LDAP.Enabled() {
+		return nil, provider, method, failed(authn.ErrAuthenticationDisabled.Error())
+	} else if user != nil && !user.CanLogIn() {
+		return nil, provider, method, failed(authn.ErrAccountDisabled.Error())
+	}
+
+	// Verify the credentials with the directory server.
+	identity, authErr := LdapAuthenticate(LDAP, username, frm.Password)
+
+	if authErr != nil {
+		return nil, provider, method, failed(clean.Error(authErr))
+	} else if !ldap.HasAnyGroup(identity.Groups, LDAP.Groups) {
+		return nil, provider, method, failed("missing required group membership")
+	}
+
+	mappedRole, hasMappedRole := ldap.MapGroupsToRole(identity.Groups, LDAP.GroupRoles)
+
+	// Find the account by distinguished name if the login name differs from the directory username.
+	if user == nil {
+		user = FindUser(LdapUser(identity.Username, identity.DN))
+	}
+
+	if user == nil {
+		if !LDAP.Register {
+			return nil, provider, method, failed(authn.ErrRegistrationDisabled.Error())
+		}
+
+		// Create a new account for the directory user.
+		newUser := LdapUser(identity.Username, identity.DN)
+
+		if newUser.UserName == "" {
+			return nil, provider, method, failed(authn.ErrUsernameRequiredToRegister.Error())
+		}
+
+		newUser.SetDisplayName(identity.Name, SrcLDAP)
+		newUser.UserEmail = identity.Email
+
+		if hasMappedRole {
+			newUser.SetRole(mappedRole.String())
+		} else {
+			newUser.SetRole(LDAP.Role.String())
+		}
+
+		newUser.CanLogin = true
+		newUser.WebDAV = LDAP.WebDAV
+
+		// Create the account, and then update the auth ID to make sure it is unique.
+		if err = newUser.Create(); err != nil {
+			return nil, provider, method, failed(authn.ErrAccountCreateFailed.Error())
+		} else if err = newUser.UpdateAuthID(identity.DN, LDAP.Uri); err != nil {
+			return nil, provider, method, failed(authn.ErrAccountUpdateFailed.Error())
+		}
+
+		event.AuditInfo([]string{clientIp, "ldap", "create user %s", status.Succeeded}, clean.LogQuote(newUser.UserName))
+
+		user = &newUser
+	} else {
+		switch {
+		case !user.CanLogIn():
+			return nil, provider, method, failed(authn.ErrAccountDisabled.Error())
+		case !user.HasProvider(authn.ProviderLDAP):
+			return nil, provider, method, failed(authn.ErrAuthProviderIsNotLDAP.Error())
+		case user.AuthID != "" && !strings.EqualFold(user.AuthID, identity.DN):
+			return nil, provider, method, failed(authn.ErrInvalidAuthID.Error())
+		}
+
+		// Update the profile with the directory information.
+		user.SetDisplayName(identity.Name, SrcLDAP)
+
+		if identity.Email != "" {
+			user.UserEmail = identity.Email
+		}
+
+		if hasMappedRole && !user.SuperAdmin && !user.HasRole(mappedRole) {
+			user.SetRole(mappedRole.String())
+		}
+
+		user.SetAuthID(identity.DN, LDAP.Uri)
+
+		if err = user.Save(); err != nil {
+			return nil, provider, method, failed(authn.ErrAccountUpdateFailed.Error())
+		}
+	}
+
+	provider = authn.ProviderLDAP
+
+	// Check two-factor authentication, if enabled.
+	if method, err = AuthPasscode(user, frm, s, c); err != nil {
+		return user, provider, method, err
+	}
+
+	if s != nil {
+		event.AuditInfo([]string{clientIp, "session %s", "login as %s", "ldap", status.Succeeded}, s.RefID, clean.LogQuote(username))
+		event.LoginInfo(clientIp, "api", username, s.UserAgent)
+	}
+
+	return user, provider, method, nil
+}
@@ -0,0 +1,222 @@
+package entity
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/auth/acl"
+	"github.com/photoprism/photoprism/internal/auth/ldap"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/authn"
+)
+
+// setLdapTest configures a test directory with a single user and restores the previous settings when the test ends.
+func setLdapTest(t *testing.T, conf *ldap.Config, identity ldap.Identity, password string) {
+	t.Helper()
+
+	prevConf, prevAuth := LDAP, LdapAuthenticate
+
+	LDAP = conf
+	LdapAuthenticate = func(conf *ldap.Config, username, pass string) (*ldap.Identity, error) {
+		if username != identity.Username && username != identity.Email {
+			return nil, authn.ErrAccountNotFound
+		} else if pass != password {
+			return nil, authn.ErrInvalidPassword
+		}
+
+		result := identity
+
+		return &result, nil
+	}
+
+	t.Cleanup(func() {
+		LDAP, LdapAuthenticate = prevConf, prevAuth
+	})
+}
+
+func TestAuthLDAP(t *testing.T) {
+	newContext := func(f form.Login) *gin.Context {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest(http.MethodPost, "/api/v1/session", form.AsReader(f))
+		c.Request.RemoteAddr = "1.2.3.4"
+		return c
+	}
+
+	t.Run("Register", func(t *testing.T) {
+		setLdapTest(t, &ldap.Config{
+			Uri:        "ldap://ldap.example.com",
+			Register:   true,
+			Role:       acl.RoleGuest,
+			GroupRoles: map[string]acl.Role{"editors": acl.RoleAdmin},
+			WebDAV:     true,
+		}, ldap.Identity{
+			DN:       "uid=ldap-carol,ou=people,dc=example,dc=com",
+			Username: "ldap-carol",
+			Name:     "Carol Smith",
+			Email:    "carol@example.com",
+			Groups:   []string{"cn=editors,ou=groups,dc=example,dc=com", "editors"},
+		}, "carol-secret")
+
+		f := form.Login{Username: "ldap-carol", Password: "carol-secret"}
+		s := NewSession(0, 0)
+
+		user, provider, method, err := Auth(f, s, newContext(f))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, authn.ProviderLDAP, provider)
+		assert.Equal(t, authn.MethodDefault, method)
+		assert.Equal(t, "ldap-carol", user.UserName)
+		assert.Equal(t, "Carol Smith", user.DisplayName)
+		assert.Equal(t, "carol@example.com", user.UserEmail)
+		assert.Equal(t, acl.RoleAdmin, user.AclRole())
+		assert.True(t, user.CanLogin)
+		assert.True(t, user.WebDAV)
+
+		found := FindUserByName("ldap-carol")
+
+		if found == nil {
+			t.Fatal("user should exist")
+		}
+
+		assert.Equal(t, authn.ProviderLDAP.String(), found.AuthProvider)
+		assert.Equal(t, "uid=ldap-carol,ou=people,dc=example,dc=com", found.AuthID)
+		assert.Equal(t, "ldap://ldap.example.com", found.AuthIssuer)
+	})
+	t.Run("UpdateRole", func(t *testing.T) {
+		setLdapTest(t, &ldap.Config{
+			Uri:        "ldap://ldap.example.com",
+			Register:   true,
+			Role:       acl.RoleGuest,
+			GroupRoles: map[string]acl.Role{"admins": acl.RoleAdmin},
+		}, ldap.Identity{
+			DN:       "uid=ldap-dave,ou=people,dc=example,dc=com",
+			Username: "ldap-dave",
+			Email:    "dave@example.com",
+		}, "dave-secret")
+
+		f := form.Login{Username: "ldap-dave", Password: "dave-secret"}
+
+		user, _, _, err := Auth(f, NewSession(0, 0), newContext(f))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, acl.RoleGuest, user.AclRole())
+
+		// Sign in again after the user has been added to a group.
+		setLdapTest(t, LDAP, ldap.Identity{
+			DN:       "uid=ldap-dave,ou=people,dc=example,dc=com",
+			Username: "ldap-dave",
+			Email:    "dave@example.com",
+			Groups:   []string{"admins"},
+		}, "dave-secret")
+
+		// Users can also sign in with their email if the search filter allows it.
+		f = form.Login{Username: "dave@example.com", Password: "dave-secret"}
+
+		user, _, _, err = Auth(f, NewSession(0, 0), newContext(f))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "ldap-dave", user.UserName)
+		assert.Equal(t, acl.RoleAdmin, user.AclRole())
+	})
+	t.Run("RegistrationDisabled", func(t *testing.T) {
+		setLdapTest(t, &ldap.Config{Uri: "ldap://ldap.example.com"}, ldap.Identity{
+			DN:       "uid=ldap-erin,ou=people,dc=example,dc=com",
+			Username: "ldap-erin",
+		}, "erin-secret")
+
+		f := form.Login{Username: "ldap-erin", Password: "erin-secret"}
+		s := NewSession(0, 0)
+
+		user, _, _, err := Auth(f, s, newContext(f))
+
+		assert.Error(t, err)
+		assert.Nil(t, user)
+		assert.Equal(t, http.StatusUnauthorized, s.Status)
+		assert.Nil(t, FindUserByName("ldap-erin"))
+	})
+	t.Run("InvalidPassword", func(t *testing.T) {
+		setLdapTest(t, &ldap.Config{Uri: "ldap://ldap.example.com", Register: true}, ldap.Identity{
+			DN:       "uid=ldap-frank,ou=people,dc=example,dc=com",
+			Username: "ldap-frank",
+		}, "frank-secret")
+
+		f := form.Login{Username: "ldap-frank", Password: "wrong"}
+
+		_, _, _, err := Auth(f, NewSession(0, 0), newContext(f))
+
+		assert.Error(t, err)
+		assert.Nil(t, FindUserByName("ldap-frank"))
+	})
+	t.Run("MissingGroup", func(t *testing.T) {
+		setLdapTest(t, &ldap.Config{Uri: "ldap://ldap.example.com", Register: true, Groups: []string{"photoprism"}}, ldap.Identity{
+			DN:       "uid=ldap-grace,ou=people,dc=example,dc=com",
+			Username: "ldap-grace",
+			Groups:   []string{"staff"},
+		}, "grace-secret")
+
+		f := form.Login{Username: "ldap-grace", Password: "grace-secret"}
+
+		_, _, _, err := Auth(f, NewSession(0, 0), newContext(f))
+
+		assert.Error(t, err)
+		assert.Nil(t, FindUserByName("ldap-grace"))
+	})
+	t.Run("LocalUser", func(t *testing.T) {
+		// Local accounts are not authenticated against the directory, even if the username matches.
+		setLdapTest(t, &ldap.Config{Uri: "ldap://ldap.example.com", Register: true}, ldap.Identity{
+			DN:       "uid=alice,ou=people,dc=example,dc=com",
+			Username: "alice",
+		}, "ldap-secret")
+
+		f := form.Login{Username: "alice", Password: "ldap-secret"}
+
+		_, _, _, err := Auth(f, NewSession(0, 0), newContext(f))
+
+		assert.Error(t, err)
+
+		f = form.Login{Username: "alice", Password: "Alice123!"}
+
+		user, provider, _, err := Auth(f, NewSession(0, 0), newContext(f))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "alice", user.UserName)
+		assert.Equal(t, authn.ProviderLocal, provider)
+	})
+	t.Run("ProviderMismatch", func(t *testing.T) {
+		// Directory users cannot take over local accounts with the same name.
+		setLdapTest(t, &ldap.Config{Uri: "ldap://ldap.example.com", Register: true}, ldap.Identity{
+			DN:       "uid=bob,ou=people,dc=example,dc=com",
+			Username: "bob",
+			Email:    "bob-ldap@example.com",
+		}, "ldap-secret")
+
+		f := form.Login{Username: "bob-ldap@example.com", Password: "ldap-secret"}
+
+		_, _, _, err := Auth(f, NewSession(0, 0), newContext(f))
+
+		assert.Error(t, err)
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		f := form.Login{Username: "ldap-carol", Password: "carol-secret"}
+
+		_, _, _, err := AuthLDAP(nil, f, NewSession(0, 0), newContext(f))
+
+		assert.Error(t, err)
+	})
+}
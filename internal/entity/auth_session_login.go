@@ -27,8 +27,13 @@ var Auth = func(frm form.Login, s *Session, c *gin.Context) (user *User, provide
 	// Find registered user account.
 	user = FindUserByName(nameName)
 
-	// Try local authentication.
-	provider, method, err = AuthLocal(user, frm, s, c)
+	// Authenticate directory users against the LDAP server, if configured, unless an app password is used.
+	if LDAP.Enabled() && (user == nil || user.HasProvider(authn.ProviderLDAP)) && !rnd.IsAppPassword(frm.Password, true) {
+		user, provider, method, err = AuthLDAP(user, frm, s, c)
+	} else {
+		// Try local authentication.
+		provider, method, err = AuthLocal(user, frm, s, c)
+	}
 
 	if err != nil {
 		return user, provider, method, err
@@ -193,6 +198,26 @@ func AuthLocal(user *User, frm form.Login, s *Session, c *gin.Context) (provider
 	provider = authn.ProviderLocal
 
 	// Check two-factor authentication, if enabled.
+	if method, err = AuthPasscode(user, frm, s, c); err != nil {
+		return provider, method, err
+	}
+
+	if s != nil {
+		event.AuditInfo([]string{clientIp, "session %s", "login as %s", status.Succeeded}, s.RefID, clean.LogQuote(username))
+		event.LoginInfo(clientIp, "api", username, s.UserAgent)
+	}
+
+	return provider, method, nil
+}
+
+// AuthPasscode checks the two-factor authentication passcode, if enabled for the user, and returns the auth method.
+func AuthPasscode(user *User, frm form.Login, s *Session, c *gin.Context) (method authn.MethodType, err error) {
+	// Get client IP from request context.
+	clientIp := header.ClientIP(c)
+
+	// Get sanitized username from login form.
+	username := frm.CleanUsername()
+
 	if method = user.Method(); method.Is(authn.Method2FA) {
 		if code := frm.Passcode(); code == "" {
 			err = authn.ErrPasscodeRequired
@@ -202,7 +227,7 @@ func AuthLocal(user *User, frm form.Login, s *Session, c *gin.Context) (provider
 				s.Status = http.StatusUnauthorized
 			}
 
-			return provider, method, err
+			return method, err
 		} else if valid, _, codeErr := user.VerifyPasscode(code); codeErr != nil {
 			if s != nil {
 				event.AuditWarn([]string{clientIp, "session %s", "login as %s", codeErr.Error()}, s.RefID, clean.LogQuote(username))
@@ -210,7 +235,7 @@ func AuthLocal(user *User, frm form.Login, s *Session, c *gin.Context) (provider
 				s.Status = http.StatusUnauthorized
 			}
 
-			return provider, method, codeErr
+			return method, codeErr
 		} else if !valid {
 			err = authn.ErrInvalidPasscode
 
@@ -220,18 +245,13 @@ func AuthLocal(user *User, frm form.Login, s *Session, c *gin.Context) (provider
 				s.Status = http.StatusUnauthorized
 			}
 
-			return provider, method, err
+			return method, err
 		}
 	} else if method == authn.MethodUndefined {
 		method = authn.MethodDefault
 	}
 
-	if s != nil {
-		event.AuditInfo([]string{clientIp, "session %s", "login as %s", status.Succeeded}, s.RefID, clean.LogQuote(username))
-		event.LoginInfo(clientIp, "api", username, s.UserAgent)
-	}
-
-	return provider, method, nil
+	return method, nil
 }
 
 // LogIn performs authentication checks against the specified login form.
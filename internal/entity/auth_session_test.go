@@ -910,6 +910,27 @@ func TestSession_HasShare(t *testing.T) {
 	assert.False(t, m.HasShare("as6sg6bxpogaaba9"))
 }
 
+func TestSession_SharePerm(t *testing.T) {
+	alice := FindSessionByRefID("sessxkkcabcd")
+	alice.RefreshUser()
+	alice.GetUser().RefreshShares()
+	share := FindUserShare(UserShare{UserUID: alice.UserUID, ShareUID: "as6sg6bxpogaaba9"})
+	perm, _ := alice.SharePerm("as6sg6bxpogaaba9")
+	if assert.NotNil(t, share) {
+		assert.Equal(t, share.Perm, perm)
+	}
+
+	visitor := SessionFixtures.Pointer("visitor")
+	perm, linkUid := visitor.SharePerm("as6sg6bxpogaaba8")
+	assert.Equal(t, PermDefault, perm)
+	assert.Equal(t, "ss62xpryd1ob7gtf", linkUid)
+
+	m := &Session{}
+	perm, linkUid = m.SharePerm("as6sg6bxpogaaba9")
+	assert.Equal(t, PermDefault, perm)
+	assert.Empty(t, linkUid)
+}
+
 func TestSession_SharedUIDs(t *testing.T) {
 	alice := FindSessionByRefID("sessxkkcabcd")
 	alice.RefreshUser()
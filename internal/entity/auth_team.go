@@ -0,0 +1,207 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// TeamUID is the unique ID prefix.
+const (
+	TeamUID = byte('t')
+)
+
+// Teams represents a list of teams.
+type Teams []Team
+
+// UIDs returns the team UIDs.
+func (m Teams) UIDs() UIDs {
+	result := make(UIDs, len(m))
+
+	for i, team := range m {
+		result[i] = team.TeamUID
+	}
+
+	return result
+}
+
+// Team represents a group of users, e.g. a household, that can own and share photos and albums.
+//
+// Field Descriptions:
+// - TeamSlug is a unique, URL-friendly name that can be used instead of the UID in CLI commands.
+// - TeamName is the display name of the team.
+type Team struct {
+	TeamUID   string    `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;" json:"UID" yaml:"UID"`
+	TeamSlug  string    `gorm:"type:VARBINARY(160);unique_index;" json:"Slug" yaml:"Slug"`
+	TeamName  string    `gorm:"size:160;" json:"Name" yaml:"Name"`
+	CreatedAt time.Time `json:"CreatedAt" yaml:"-"`
+	UpdatedAt time.Time `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns the entity table name.
+func (Team) TableName() string {
+	return "auth_teams"
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *Team) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsUID(m.TeamUID, TeamUID) {
+		return nil
+	}
+
+	m.TeamUID = rnd.GenerateUID(TeamUID)
+
+	return scope.SetColumn("TeamUID", m.TeamUID)
+}
+
+// NewTeam returns a new team with the specified name.
+func NewTeam(name string) *Team {
+	name = txt.Clip(name, txt.ClipName)
+
+	return &Team{
+		TeamSlug: txt.Slug(name),
+		TeamName: name,
+	}
+}
+
+// AddTeam creates a new team with the specified name.
+func AddTeam(name string) (m *Team, err error) {
+	m = NewTeam(name)
+
+	if m.TeamName == "" || m.TeamSlug == "" {
+		return m, fmt.Errorf("team name is empty")
+	} else if FindTeam(m.TeamSlug) != nil {
+		return m, fmt.Errorf("team %s already exists", clean.Log(m.TeamSlug))
+	}
+
+	return m, m.Create()
+}
+
+// FindTeam returns the team with the specified UID or slug, or nil if it was not found.
+func FindTeam(uidOrSlug string) *Team {
+	if uidOrSlug == "" {
+		return nil
+	}
+
+	m := &Team{}
+
+	if rnd.IsUID(uidOrSlug, TeamUID) {
+		if err := Db().First(m, "team_uid = ?", uidOrSlug).Error; err != nil {
+			return nil
+		}
+	} else if err := Db().First(m, "team_slug = ?", txt.Slug(uidOrSlug)).Error; err != nil {
+		return nil
+	}
+
+	return m
+}
+
+// FindTeams returns all teams sorted by name.
+func FindTeams() (result Teams) {
+	result = Teams{}
+
+	if err := Db().Order("team_name, team_uid").Find(&result).Error; err != nil {
+		log.Errorf("teams: %s (find)", err)
+	}
+
+	return result
+}
+
+// Create inserts a new team into the database.
+func (m *Team) Create() error {
+	return Db().Create(m).Error
+}
+
+// Delete permanently removes the team, its memberships, and the permissions granted to it.
+func (m *Team) Delete() error {
+	if rnd.InvalidUID(m.TeamUID, TeamUID) {
+		return fmt.Errorf("invalid team uid %s", clean.Log(m.TeamUID))
+	}
+
+	if err := UnscopedDb().Delete(TeamUser{}, "team_uid = ?", m.TeamUID).Error; err != nil {
+		return err
+	} else if err = UnscopedDb().Delete(AlbumUser{}, "user_uid = ? OR team_uid = ?", m.TeamUID, m.TeamUID).Error; err != nil {
+		return err
+	} else if err = UnscopedDb().Delete(PhotoUser{}, "user_uid = ? OR team_uid = ?", m.TeamUID, m.TeamUID).Error; err != nil {
+		return err
+	}
+
+	return UnscopedDb().Delete(m).Error
+}
+
+// String returns the team name or slug for logging.
+func (m *Team) String() string {
+	if m == nil {
+		return "<nil>"
+	} else if m.TeamName != "" {
+		return clean.Log(m.TeamName)
+	}
+
+	return clean.Log(m.TeamSlug)
+}
+
+// Members returns the users who belong to the team.
+func (m *Team) Members() (result Users) {
+	result = Users{}
+
+	if m.TeamUID == "" {
+		return result
+	}
+
+	if err := Db().Where("user_uid IN (SELECT user_uid FROM auth_teams_users WHERE team_uid = ?)", m.TeamUID).
+		Order("user_name").Find(&result).Error; err != nil {
+		log.Errorf("teams: %s (find members)", err)
+	}
+
+	return result
+}
+
+// HasMember checks if the specified user belongs to the team.
+func (m *Team) HasMember(user *User) bool {
+	if m.TeamUID == "" || user == nil || user.UserUID == "" {
+		return false
+	}
+
+	return Db().Where("team_uid = ? AND user_uid = ?", m.TeamUID, user.UserUID).First(&TeamUser{}).Error == nil
+}
+
+// AddMember adds a registered user to the team.
+func (m *Team) AddMember(user *User) error {
+	if rnd.InvalidUID(m.TeamUID, TeamUID) {
+		return fmt.Errorf("invalid team uid %s", clean.Log(m.TeamUID))
+	} else if user == nil || !user.IsRegistered() {
+		return fmt.Errorf("user is not registered")
+	} else if m.HasMember(user) {
+		return nil
+	}
+
+	return Db().Create(&TeamUser{TeamUID: m.TeamUID, UserUID: user.UserUID}).Error
+}
+
+// RemoveMember removes a user from the team.
+func (m *Team) RemoveMember(user *User) error {
+	if rnd.InvalidUID(m.TeamUID, TeamUID) {
+		return fmt.Errorf("invalid team uid %s", clean.Log(m.TeamUID))
+	} else if user == nil || user.UserUID == "" {
+		return fmt.Errorf("user not found")
+	}
+
+	return UnscopedDb().Delete(TeamUser{}, "team_uid = ? AND user_uid = ?", m.TeamUID, user.UserUID).Error
+}
+
+// TeamUser represents the membership of a user in a team.
+type TeamUser struct {
+	TeamUID   string    `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;" json:"TeamUID" yaml:"TeamUID"`
+	UserUID   string    `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;index;" json:"UserUID" yaml:"UserUID"`
+	CreatedAt time.Time `json:"CreatedAt" yaml:"-"`
+}
+
+// TableName returns the entity table name.
+func (TeamUser) TableName() string {
+	return "auth_teams_users"
+}
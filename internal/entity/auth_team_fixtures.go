@@ -0,0 +1,57 @@
+package entity
+
+import (
+	"time"
+)
+
+type TeamMap map[string]Team
+
+func (m TeamMap) Get(name string) Team {
+	if result, ok := m[name]; ok {
+		return result
+	}
+
+	return Team{}
+}
+
+func (m TeamMap) Pointer(name string) *Team {
+	if result, ok := m[name]; ok {
+		return &result
+	}
+
+	return &Team{}
+}
+
+var TeamFixtures = TeamMap{
+	"household": {
+		TeamUID:   "tt2hdkz1vgdb3ta2",
+		TeamSlug:  "smith-household",
+		TeamName:  "Smith Household",
+		CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	},
+	"club": {
+		TeamUID:   "tt2hdl31ydq9yw0k",
+		TeamSlug:  "photo-club",
+		TeamName:  "Photo Club",
+		CreatedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		UpdatedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+	},
+}
+
+// TeamUserFixtures specifies the team memberships used for testing.
+var TeamUserFixtures = []TeamUser{
+	{TeamUID: "tt2hdkz1vgdb3ta2", UserUID: "uqxetse3cy5eo9z2", CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+	{TeamUID: "tt2hdkz1vgdb3ta2", UserUID: "usg73p55zwgr1gbq", CreatedAt: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)},
+}
+
+// CreateTeamFixtures inserts known entities into the database for testing.
+func CreateTeamFixtures() {
+	for _, entity := range TeamFixtures {
+		Db().Create(&entity)
+	}
+
+	for _, entity := range TeamUserFixtures {
+		Db().Create(&entity)
+	}
+}
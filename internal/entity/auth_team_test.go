@@ -0,0 +1,136 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTeam_TableName(t *testing.T) {
+	assert.Equal(t, "auth_teams", Team{}.TableName())
+	assert.Equal(t, "auth_teams_users", TeamUser{}.TableName())
+}
+
+func TestFindTeam(t *testing.T) {
+	t.Run("UID", func(t *testing.T) {
+		m := FindTeam("tt2hdkz1vgdb3ta2")
+
+		if m == nil {
+			t.Fatal("team must not be nil")
+		}
+
+		assert.Equal(t, "Smith Household", m.TeamName)
+	})
+	t.Run("Slug", func(t *testing.T) {
+		m := FindTeam("photo-club")
+
+		if m == nil {
+			t.Fatal("team must not be nil")
+		}
+
+		assert.Equal(t, "tt2hdl31ydq9yw0k", m.TeamUID)
+	})
+	t.Run("Name", func(t *testing.T) {
+		m := FindTeam("Photo Club")
+
+		if m == nil {
+			t.Fatal("team must not be nil")
+		}
+
+		assert.Equal(t, "tt2hdl31ydq9yw0k", m.TeamUID)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		assert.Nil(t, FindTeam("tt2hdkz1vgdb3xxx"))
+		assert.Nil(t, FindTeam("no-such-team"))
+		assert.Nil(t, FindTeam(""))
+	})
+}
+
+func TestFindTeams(t *testing.T) {
+	result := FindTeams()
+
+	assert.GreaterOrEqual(t, len(result), 2)
+	assert.Contains(t, result.UIDs(), "tt2hdkz1vgdb3ta2")
+}
+
+func TestAddTeam(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		m, err := AddTeam("Miller Family")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, len(m.TeamUID) == 16 && m.TeamUID[0] == TeamUID)
+		assert.Equal(t, "miller-family", m.TeamSlug)
+		assert.Equal(t, "'Miller Family'", m.String())
+
+		if err = m.Delete(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Nil(t, FindTeam(m.TeamUID))
+	})
+	t.Run("Exists", func(t *testing.T) {
+		_, err := AddTeam("Smith Household")
+		assert.Error(t, err)
+	})
+	t.Run("EmptyName", func(t *testing.T) {
+		_, err := AddTeam("  ")
+		assert.Error(t, err)
+	})
+}
+
+func TestTeam_Members(t *testing.T) {
+	m := TeamFixtures.Pointer("household")
+	members := m.Members()
+
+	assert.Len(t, members, 2)
+	assert.True(t, m.HasMember(UserFixtures.Pointer("alice")))
+	assert.True(t, m.HasMember(UserFixtures.Pointer("guest")))
+	assert.False(t, m.HasMember(UserFixtures.Pointer("bob")))
+}
+
+func TestTeam_AddMember(t *testing.T) {
+	m, err := AddTeam("Member Test")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _ = m.Delete() }()
+
+	bob := UserFixtures.Pointer("bob")
+
+	assert.NoError(t, m.AddMember(bob))
+	assert.NoError(t, m.AddMember(bob))
+	assert.True(t, m.HasMember(bob))
+	assert.Len(t, m.Members(), 1)
+	assert.Error(t, m.AddMember(&Visitor))
+	assert.Error(t, m.AddMember(nil))
+
+	assert.NoError(t, m.RemoveMember(bob))
+	assert.False(t, m.HasMember(bob))
+	assert.Len(t, m.Members(), 0)
+}
+
+func TestTeam_Delete(t *testing.T) {
+	t.Run("RemovesGrants", func(t *testing.T) {
+		m, err := AddTeam("Delete Test")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err = ShareAlbum("as6sg6bxpogaaba7", m.TeamUID, PermView); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, FindAlbumUsers("as6sg6bxpogaaba7"), 1)
+		assert.NoError(t, m.Delete())
+		assert.Len(t, FindAlbumUsers("as6sg6bxpogaaba7"), 0)
+	})
+	t.Run("InvalidUID", func(t *testing.T) {
+		assert.Error(t, (&Team{}).Delete())
+	})
+}
@@ -340,6 +340,10 @@ func (m *User) Delete() (err error) {
 		event.AuditErr([]string{"user %s", "delete", "failed to remove sessions", status.Error(err)}, m.RefID)
 	}
 
+	if err = UnscopedDb().Delete(TeamUser{}, "user_uid = ?", m.UserUID).Error; err != nil {
+		event.AuditErr([]string{"user %s", "delete", "failed to remove team memberships", status.Error(err)}, m.RefID)
+	}
+
 	err = Db().Delete(m).Error
 
 	FlushSessionCache()
@@ -1241,14 +1245,35 @@ func (m *User) HasShares() bool {
 	return !m.NoShares()
 }
 
-// HasShare if an uid was shared with the user.
+// HasShare if an uid was shared with the user, either with a link or directly with the user or one of the user's teams.
 func (m *User) HasShare(uid string) bool {
-	if m.NotRegistered() || m.NoShares() {
+	if m.NotRegistered() {
 		return false
 	}
 
 	// Check if the share list contains the specified UID.
-	return m.UserShares.Contains(uid)
+	if m.UserShares.Contains(uid) {
+		return true
+	}
+
+	// Check if permissions were granted to the user or one of the user's teams.
+	return m.ItemPerm(uid) != PermDefault
+}
+
+// SharePerm returns the permissions granted for the specified share and the UID of the link it was redeemed with.
+func (m *User) SharePerm(uid string) (perm uint, linkUid string) {
+	if m.NotRegistered() {
+		return PermDefault, ""
+	}
+
+	for _, share := range m.UserShares {
+		if share.ShareUID == uid {
+			perm, linkUid = share.Perm, share.LinkUID
+			break
+		}
+	}
+
+	return perm | m.ItemPerm(uid), linkUid
 }
 
 // SharedUIDs returns shared entity UIDs.
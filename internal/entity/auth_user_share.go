@@ -23,6 +23,10 @@ const (
 	PermAll
 )
 
+// PermGrantable specifies the permissions that can be granted to users and teams for individual photos and albums.
+// Editing and uploading are controlled by the user role, so these permissions cannot be granted per item.
+const PermGrantable = PermView | PermReact | PermComment | PermShare | PermAll
+
 // SharePrefix for RefID.
 const (
 	SharePrefix = "share"
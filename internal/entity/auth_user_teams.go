@@ -0,0 +1,92 @@
+package entity
+
+import (
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// Teams returns the teams the user belongs to.
+func (m *User) Teams() (result Teams) {
+	result = Teams{}
+
+	if m.NotRegistered() {
+		return result
+	}
+
+	if err := Db().Where("team_uid IN (SELECT team_uid FROM auth_teams_users WHERE user_uid = ?)", m.UserUID).
+		Order("team_name, team_uid").Find(&result).Error; err != nil {
+		log.Errorf("user: %s (find teams)", err)
+	}
+
+	return result
+}
+
+// TeamUIDs returns the UIDs of the teams the user belongs to.
+func (m *User) TeamUIDs() UIDs {
+	result := UIDs{}
+
+	if m.NotRegistered() {
+		return result
+	}
+
+	if err := Db().Model(&TeamUser{}).Where("user_uid = ?", m.UserUID).Pluck("team_uid", &result).Error; err != nil {
+		log.Errorf("user: %s (find teams)", err)
+	}
+
+	return result
+}
+
+// GrantUIDs returns the user UID and the UIDs of the user's teams, i.e. all principals that
+// photos and albums can be shared with on behalf of the user.
+func (m *User) GrantUIDs() UIDs {
+	if m.NotRegistered() {
+		return UIDs{}
+	}
+
+	return append(UIDs{m.UserUID}, m.TeamUIDs()...)
+}
+
+// ItemPerm returns the permissions that were granted to the user or the user's teams for the
+// photo or album with the specified UID. Permissions granted for an album also apply to its photos.
+func (m *User) ItemPerm(uid string) (perm uint) {
+	if m.NotRegistered() {
+		return PermDefault
+	}
+
+	grants := m.GrantUIDs()
+
+	var perms []uint
+
+	switch {
+	case rnd.IsUID(uid, AlbumUID):
+		if err := Db().Model(&AlbumUser{}).
+			Where("uid = ? AND (user_uid IN (?) OR team_uid IN (?))", uid, grants, grants).
+			Pluck("perm", &perms).Error; err != nil {
+			log.Errorf("user: %s (find album permissions)", err)
+		}
+	case rnd.IsUID(uid, PhotoUID):
+		if err := Db().Model(&PhotoUser{}).
+			Where("uid = ? AND (user_uid IN (?) OR team_uid IN (?))", uid, grants, grants).
+			Pluck("perm", &perms).Error; err != nil {
+			log.Errorf("user: %s (find photo permissions)", err)
+		}
+
+		var albumPerms []uint
+
+		if err := Db().Model(&AlbumUser{}).
+			Where("uid IN (SELECT album_uid FROM photos_albums WHERE photo_uid = ? AND hidden = 0 AND missing = 0)", uid).
+			Where("user_uid IN (?) OR team_uid IN (?)", grants, grants).
+			Pluck("perm", &albumPerms).Error; err != nil {
+			log.Errorf("user: %s (find album permissions)", err)
+		}
+
+		perms = append(perms, albumPerms...)
+	default:
+		return PermDefault
+	}
+
+	for _, p := range perms {
+		perm |= p
+	}
+
+	return perm
+}
@@ -0,0 +1,81 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestUser_Teams(t *testing.T) {
+	t.Run("Alice", func(t *testing.T) {
+		m := UserFixtures.Pointer("alice")
+
+		assert.Equal(t, UIDs{"tt2hdkz1vgdb3ta2"}, m.Teams().UIDs())
+		assert.Equal(t, UIDs{"tt2hdkz1vgdb3ta2"}, m.TeamUIDs())
+		assert.Equal(t, UIDs{"uqxetse3cy5eo9z2", "tt2hdkz1vgdb3ta2"}, m.GrantUIDs())
+	})
+	t.Run("Bob", func(t *testing.T) {
+		m := UserFixtures.Pointer("bob")
+
+		assert.Empty(t, m.Teams())
+		assert.Empty(t, m.TeamUIDs())
+		assert.Equal(t, UIDs{"uqxc08w3d0ej2283"}, m.GrantUIDs())
+	})
+	t.Run("Visitor", func(t *testing.T) {
+		assert.Empty(t, Visitor.Teams())
+		assert.Empty(t, Visitor.GrantUIDs())
+	})
+}
+
+func TestUser_ItemPerm(t *testing.T) {
+	guest := UserFixtures.Pointer("guest")
+	bob := UserFixtures.Pointer("bob")
+
+	t.Run("Album", func(t *testing.T) {
+		albumUid := "as6sg6bxpogaaba7"
+
+		assert.Equal(t, PermDefault, guest.ItemPerm(albumUid))
+		assert.False(t, guest.HasShare(albumUid))
+
+		// Share the album with the team the guest belongs to.
+		grant, err := ShareAlbum(albumUid, "tt2hdkz1vgdb3ta2", PermView|PermComment)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer func() { _ = grant.Delete() }()
+
+		assert.True(t, grant.IsTeam())
+		assert.Equal(t, PermView|PermComment, guest.ItemPerm(albumUid))
+		assert.True(t, guest.HasShare(albumUid))
+		assert.Equal(t, PermDefault, bob.ItemPerm(albumUid))
+		assert.False(t, bob.HasShare(albumUid))
+
+		perm, linkUid := guest.SharePerm(albumUid)
+		assert.Equal(t, PermView|PermComment, perm)
+		assert.Equal(t, "", linkUid)
+	})
+	t.Run("Photo", func(t *testing.T) {
+		photoUid := "ps6sg6be2lvl0yh8"
+
+		assert.Equal(t, PermDefault, bob.ItemPerm(photoUid))
+
+		grant, err := SharePhoto(photoUid, bob.UserUID, PermDefault)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer func() { _ = grant.Delete() }()
+
+		assert.False(t, grant.IsTeam())
+		assert.Equal(t, PermView, bob.ItemPerm(photoUid))
+		assert.True(t, bob.HasShare(photoUid))
+		assert.Equal(t, PermDefault, guest.ItemPerm(photoUid))
+	})
+	t.Run("InvalidUID", func(t *testing.T) {
+		assert.Equal(t, PermDefault, guest.ItemPerm("ls6sg6b1wowuy3c2"))
+		assert.Equal(t, PermDefault, Visitor.ItemPerm("as6sg6bxpogaaba7"))
+	})
+}
@@ -1088,6 +1088,29 @@ func TestDeleteUser(t *testing.T) {
 		err := u.Delete()
 		assert.NoError(t, err)
 	})
+	t.Run("TeamMemberships", func(t *testing.T) {
+		u := FirstOrCreateUser(&User{
+			UserName:    "teamdel",
+			UserEmail:   "teamdel@example.com",
+			DisplayName: "Team Delete",
+			UserRole:    acl.RoleUser.String(),
+		})
+
+		team, err := AddTeam("Delete Member Test")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer func() { _ = team.Delete() }()
+
+		assert.NoError(t, team.AddMember(u))
+		assert.True(t, team.HasMember(u))
+
+		assert.NoError(t, u.Delete())
+		assert.Len(t, team.Members(), 0)
+		assert.Empty(t, u.TeamUIDs())
+	})
 	t.Run("DoesNotExist", func(t *testing.T) {
 		u := &User{
 			UserName:    "thomasdel2",
@@ -2259,6 +2282,21 @@ func TestUser_HasShare(t *testing.T) {
 	assert.False(t, Visitor.HasShare("as6sg6bxpogaaba8"))
 }
 
+func TestUser_SharePerm(t *testing.T) {
+	m := FindLocalUser("alice")
+	m.RefreshShares()
+	share := FindUserShare(UserShare{UserUID: m.UserUID, ShareUID: "as6sg6bxpogaaba9"})
+	perm, linkUid := m.SharePerm("as6sg6bxpogaaba9")
+	if assert.NotNil(t, share) {
+		assert.Equal(t, share.Perm, perm)
+		assert.Equal(t, share.LinkUID, linkUid)
+	}
+	perm, _ = m.SharePerm("as6sg6bxpogaaba8")
+	assert.Equal(t, PermDefault, perm)
+	perm, _ = Visitor.SharePerm("as6sg6bxpogaaba8")
+	assert.Equal(t, PermDefault, perm)
+}
+
 func TestUser_RedeemToken(t *testing.T) {
 	t.Run("Visitor", func(t *testing.T) {
 		assert.Equal(t, 0, Visitor.RedeemToken("1234"))
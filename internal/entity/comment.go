@@ -0,0 +1,198 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// CommentUID is the unique ID prefix.
+const (
+	CommentUID = byte('k')
+)
+
+// Comments represents a list of comments.
+type Comments []Comment
+
+// Comment represents a comment on a photo or album, written either by a registered user or by a
+// visitor who has been granted the comment permission with a share link.
+//
+// Field Descriptions:
+// - SubjectUID is the UID of the photo or album that was commented on.
+// - UserUID is the UID of the registered user who wrote the comment, if any.
+// - UserName contains the display name of the author at the time the comment was written.
+// - LinkUID is the UID of the share link a visitor used to write the comment, if any.
+type Comment struct {
+	CommentUID  string    `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;" json:"UID" yaml:"UID"`
+	SubjectUID  string    `gorm:"type:VARBINARY(42);index;" json:"SubjectUID" yaml:"-"`
+	UserUID     string    `gorm:"type:VARBINARY(42);index;" json:"UserUID,omitempty" yaml:"UserUID,omitempty"`
+	UserName    string    `gorm:"size:200;" json:"UserName,omitempty" yaml:"UserName,omitempty"`
+	LinkUID     string    `gorm:"type:VARBINARY(42);" json:"LinkUID,omitempty" yaml:"LinkUID,omitempty"`
+	CommentText string    `gorm:"type:VARCHAR(2048);" json:"Text" yaml:"Text"`
+	CreatedAt   time.Time `json:"CreatedAt" yaml:"CreatedAt"`
+	UpdatedAt   time.Time `json:"UpdatedAt" yaml:"UpdatedAt"`
+}
+
+// TableName returns the entity table name.
+func (Comment) TableName() string {
+	return "comments"
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *Comment) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsUID(m.CommentUID, CommentUID) {
+		return nil
+	}
+
+	m.CommentUID = rnd.GenerateUID(CommentUID)
+
+	return scope.SetColumn("CommentUID", m.CommentUID)
+}
+
+// NewComment returns a new comment on the photo or album with the specified UID.
+func NewComment(subjectUid, text string) *Comment {
+	return &Comment{
+		SubjectUID:  subjectUid,
+		CommentText: txt.Clip(text, txt.ClipText),
+	}
+}
+
+// FindComment returns the comment with the specified UID or nil if it was not found.
+func FindComment(uid string) *Comment {
+	if rnd.InvalidUID(uid, CommentUID) {
+		return nil
+	}
+
+	m := &Comment{}
+
+	if err := Db().First(m, "comment_uid = ?", uid).Error; err != nil {
+		return nil
+	}
+
+	return m
+}
+
+// FindComments returns the comments on the photo or album with the specified UID, oldest first.
+func FindComments(subjectUid string) (result Comments) {
+	result = Comments{}
+
+	if subjectUid == "" {
+		return result
+	}
+
+	if err := Db().Where("subject_uid = ?", subjectUid).
+		Order("created_at, comment_uid").Find(&result).Error; err != nil {
+		log.Errorf("comments: %s (find)", err)
+	}
+
+	return result
+}
+
+// DeleteComments permanently removes the comments on the photo or album with the specified UID.
+func DeleteComments(subjectUid string) error {
+	if subjectUid == "" {
+		return nil
+	}
+
+	return UnscopedDb().Delete(Comment{}, "subject_uid = ?", subjectUid).Error
+}
+
+// SetAuthor sets the user account and, for visitors, the share link that were used to write the comment.
+func (m *Comment) SetAuthor(user *User, linkUid string) *Comment {
+	if user == nil {
+		return m
+	}
+
+	if user.IsRegistered() {
+		m.UserUID = user.UserUID
+	}
+
+	m.UserName = txt.Clip(user.FullName(), txt.ClipLongName)
+	m.LinkUID = linkUid
+
+	return m
+}
+
+// IsAuthor checks if the comment was written by the specified registered user.
+func (m *Comment) IsAuthor(user *User) bool {
+	if m == nil || user == nil || m.UserUID == "" || !user.IsRegistered() {
+		return false
+	}
+
+	return m.UserUID == user.UserUID
+}
+
+// SetText changes the comment text.
+func (m *Comment) SetText(text string) error {
+	if text = txt.Clip(text, txt.ClipText); text == "" {
+		return fmt.Errorf("comment text is empty")
+	}
+
+	m.CommentText = text
+
+	return nil
+}
+
+// Create inserts a new comment into the database.
+func (m *Comment) Create() error {
+	if m.SubjectUID == "" {
+		return fmt.Errorf("comment subject is missing")
+	} else if m.CommentText == "" {
+		return fmt.Errorf("comment text is empty")
+	}
+
+	return Db().Create(m).Error
+}
+
+// Save updates the comment text in the database.
+func (m *Comment) Save() error {
+	if rnd.InvalidUID(m.CommentUID, CommentUID) {
+		return fmt.Errorf("invalid comment uid %s", clean.Log(m.CommentUID))
+	} else if m.CommentText == "" {
+		return fmt.Errorf("comment text is empty")
+	}
+
+	m.UpdatedAt = Now()
+
+	return Db().Model(m).UpdateColumns(Values{"comment_text": m.CommentText, "updated_at": m.UpdatedAt}).Error
+}
+
+// Delete permanently removes the comment from the database.
+func (m *Comment) Delete() error {
+	if rnd.InvalidUID(m.CommentUID, CommentUID) {
+		return fmt.Errorf("invalid comment uid %s", clean.Log(m.CommentUID))
+	}
+
+	return UnscopedDb().Delete(m).Error
+}
+
+// Restore adds comments loaded from a YAML file to the photo or album with the specified UID,
+// unless they already exist, and returns the number of restored comments.
+func (m Comments) Restore(subjectUid string) (restored int) {
+	if subjectUid == "" {
+		return 0
+	}
+
+	for i := range m {
+		c := m[i]
+
+		if rnd.InvalidUID(c.CommentUID, CommentUID) || FindComment(c.CommentUID) != nil {
+			continue
+		}
+
+		c.SubjectUID = subjectUid
+
+		if err := c.Create(); err != nil {
+			log.Warnf("comments: %s (restore %s)", err, clean.Log(c.CommentUID))
+		} else {
+			restored++
+		}
+	}
+
+	return restored
+}
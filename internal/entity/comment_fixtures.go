@@ -0,0 +1,54 @@
+package entity
+
+import (
+	"time"
+)
+
+type CommentMap map[string]Comment
+
+// Get returns a fixture for use in tests.
+func (m CommentMap) Get(name string) Comment {
+	if result, ok := m[name]; ok {
+		return result
+	}
+
+	return Comment{}
+}
+
+// Pointer returns a fixture pointer for use in tests.
+func (m CommentMap) Pointer(name string) *Comment {
+	if result, ok := m[name]; ok {
+		return &result
+	}
+
+	return &Comment{}
+}
+
+// CommentFixtures specifies fixtures for use in tests.
+var CommentFixtures = CommentMap{
+	"AlicePhoto01": {
+		CommentUID:  "kt2hdkz1vgdb3ta2",
+		SubjectUID:  "ps6sg6be2lvl0yh8",
+		UserUID:     "uqxetse3cy5eo9z2",
+		UserName:    "Alice",
+		CommentText: "What a beautiful sunset!",
+		CreatedAt:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	},
+	"VisitorBerlin2019": {
+		CommentUID:  "kt2hdl31ydq9yw0k",
+		SubjectUID:  "as6sg6bxpogaaba9",
+		UserName:    VisitorDisplayName,
+		LinkUID:     "ss62xpryd1ob3gtf",
+		CommentText: "Thanks for sharing!",
+		CreatedAt:   time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:   time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+	},
+}
+
+// CreateCommentFixtures inserts known entities into the database for testing.
+func CreateCommentFixtures() {
+	for _, entity := range CommentFixtures {
+		Db().Create(&entity)
+	}
+}
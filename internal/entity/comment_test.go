@@ -0,0 +1,129 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComment_TableName(t *testing.T) {
+	assert.Equal(t, "comments", Comment{}.TableName())
+}
+
+func TestFindComment(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		m := FindComment("kt2hdkz1vgdb3ta2")
+
+		if assert.NotNil(t, m) {
+			assert.Equal(t, "ps6sg6be2lvl0yh8", m.SubjectUID)
+			assert.Equal(t, "What a beautiful sunset!", m.CommentText)
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		assert.Nil(t, FindComment("kt2hdkz1vgdb3xxx"))
+	})
+	t.Run("InvalidUID", func(t *testing.T) {
+		assert.Nil(t, FindComment("ps6sg6be2lvl0yh8"))
+	})
+}
+
+func TestFindComments(t *testing.T) {
+	t.Run("Photo", func(t *testing.T) {
+		result := FindComments("ps6sg6be2lvl0yh8")
+
+		if assert.NotEmpty(t, result) {
+			assert.Equal(t, "kt2hdkz1vgdb3ta2", result[0].CommentUID)
+		}
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.Empty(t, FindComments(""))
+	})
+}
+
+func TestComment_SetAuthor(t *testing.T) {
+	t.Run("User", func(t *testing.T) {
+		m := NewComment("ps6sg6be2lvl0yh8", "Hello").SetAuthor(UserFixtures.Pointer("alice"), "")
+
+		assert.Equal(t, "uqxetse3cy5eo9z2", m.UserUID)
+		assert.NotEmpty(t, m.UserName)
+		assert.Empty(t, m.LinkUID)
+		assert.True(t, m.IsAuthor(UserFixtures.Pointer("alice")))
+		assert.False(t, m.IsAuthor(UserFixtures.Pointer("bob")))
+	})
+	t.Run("Visitor", func(t *testing.T) {
+		m := NewComment("as6sg6bxpogaaba9", "Hello").SetAuthor(&Visitor, "ss62xpryd1ob3gtf")
+
+		assert.Empty(t, m.UserUID)
+		assert.Equal(t, VisitorDisplayName, m.UserName)
+		assert.Equal(t, "ss62xpryd1ob3gtf", m.LinkUID)
+		assert.False(t, m.IsAuthor(&Visitor))
+	})
+}
+
+func TestComment_SetText(t *testing.T) {
+	m := NewComment("ps6sg6be2lvl0yh8", "Hello")
+
+	assert.Error(t, m.SetText("   "))
+	assert.Equal(t, "Hello", m.CommentText)
+	assert.NoError(t, m.SetText(" Hello World! "))
+	assert.Equal(t, "Hello World!", m.CommentText)
+}
+
+func TestComment_Create(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		m := NewComment("ps6sg6be2lvl0yh8", "Nice colors!").SetAuthor(UserFixtures.Pointer("bob"), "")
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, len(m.CommentUID) == 16 && m.CommentUID[0] == CommentUID)
+
+		assert.NoError(t, m.SetText("Nice colors and light!"))
+		assert.NoError(t, m.Save())
+
+		if found := FindComment(m.CommentUID); assert.NotNil(t, found) {
+			assert.Equal(t, "Nice colors and light!", found.CommentText)
+		}
+
+		assert.NoError(t, m.Delete())
+		assert.Nil(t, FindComment(m.CommentUID))
+	})
+	t.Run("EmptyText", func(t *testing.T) {
+		assert.Error(t, NewComment("ps6sg6be2lvl0yh8", "").Create())
+	})
+	t.Run("NoSubject", func(t *testing.T) {
+		assert.Error(t, NewComment("", "Hello").Create())
+	})
+}
+
+func TestDeleteComments(t *testing.T) {
+	subjectUid := "ps6sg6bexxvl0yh1"
+
+	for _, text := range []string{"First", "Second"} {
+		if err := NewComment(subjectUid, text).Create(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	assert.Len(t, FindComments(subjectUid), 2)
+	assert.NoError(t, DeleteComments(subjectUid))
+	assert.Empty(t, FindComments(subjectUid))
+	assert.NoError(t, DeleteComments(""))
+}
+
+func TestComments_Restore(t *testing.T) {
+	comments := Comments{
+		CommentFixtures.Get("AlicePhoto01"),
+		{CommentUID: "kt2hdm8z3v2n6pqa", UserName: "Bob", CommentText: "Restored from backup"},
+		{CommentUID: "invalid", CommentText: "Skipped"},
+	}
+
+	assert.Equal(t, 0, comments.Restore(""))
+	assert.Equal(t, 1, comments.Restore("ps6sg6bexxvl0yh0"))
+
+	if m := FindComment("kt2hdm8z3v2n6pqa"); assert.NotNil(t, m) {
+		assert.Equal(t, "ps6sg6bexxvl0yh0", m.SubjectUID)
+		assert.NoError(t, m.Delete())
+	}
+}
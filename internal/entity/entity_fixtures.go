@@ -34,5 +34,9 @@ func ResetTestFixtures() {
 
 	File{}.RegenerateIndex()
 
+	if _, err := RebuildSearchIndex(); err != nil {
+		log.Errorf("search: %s (rebuild index)", err)
+	}
+
 	log.Debugf("migrate: recreated test fixtures [%s]", time.Since(start))
 }
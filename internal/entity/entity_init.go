@@ -40,6 +40,9 @@ func InitDb(opt migrate.Options) {
 
 	CreateDefaultFixtures()
 
+	// Populate the full-text search index if needed.
+	InitSearchIndex()
+
 	ready()
 
 	log.Debugf("migrate: completed in %s", time.Since(start))
@@ -34,6 +34,8 @@ var Entities = Tables{
 	Photo{}.TableName():             &Photo{},
 	PhotoUser{}.TableName():         &PhotoUser{},
 	Details{}.TableName():           &Details{},
+	PhotoEmbedding{}.TableName():    &PhotoEmbedding{},
+	PhotoSearchQueue{}.TableName():  &PhotoSearchQueue{},
 	Place{}.TableName():             &Place{},
 	Cell{}.TableName():              &Cell{},
 	Camera{}.TableName():            &Camera{},
@@ -50,9 +52,18 @@ var Entities = Tables{
 	Link{}.TableName():              &Link{},
 	Subject{}.TableName():           &Subject{},
 	Face{}.TableName():              &Face{},
+	FaceSuggestion{}.TableName():    &FaceSuggestion{},
 	Marker{}.TableName():            &Marker{},
 	Reaction{}.TableName():          &Reaction{},
+	Comment{}.TableName():           &Comment{},
 	UserShare{}.TableName():         &UserShare{},
+	Team{}.TableName():              &Team{},
+	TeamUser{}.TableName():          &TeamUser{},
+	Quota{}.TableName():             &Quota{},
+	Webhook{}.TableName():           &Webhook{},
+	WebhookDelivery{}.TableName():   &WebhookDelivery{},
+	Job{}.TableName():               &Job{},
+	JobRun{}.TableName():            &JobRun{},
 }
 
 // WaitForMigration waits for the database migration to be successful and returns an error otherwise.
@@ -140,6 +151,15 @@ func (list Tables) Migrate(db *gorm.DB, opt migrate.Options) {
 	if err := migrate.Run(db, opt); err != nil {
 		log.Error(err)
 	}
+
+	// Create full-text search index, if needed.
+	if opt.AutoMigrate {
+		if engine, err := CreateSearchIndex(db); err != nil {
+			log.Warnf("migrate: %s (create search index)", err)
+		} else {
+			log.Tracef("migrate: using %s search index", engine)
+		}
+	}
 }
 
 // Drop drops all database tables of registered entities.
@@ -149,4 +169,8 @@ func (list Tables) Drop(db *gorm.DB) {
 			panic(err)
 		}
 	}
+
+	if err := DropSearchIndex(db); err != nil {
+		panic(err)
+	}
 }
@@ -350,6 +350,8 @@ func (m *Face) RefreshPhotos() error {
 		err = UnscopedDb().Exec(update, m.ID).Error
 	}
 
+	queueSearchIndexByMarker("m.face_id = ?", m.ID)
+
 	return err
 }
 
@@ -33,3 +33,29 @@ func TestFaceMap_Pointer(t *testing.T) {
 		assert.IsType(t, &Face{}, r)
 	})
 }
+
+func TestFaceSuggestionMap_Get(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		r := FaceSuggestionFixtures.Get("unknown-john-doe")
+		assert.Equal(t, uint(1000000), r.ID)
+		assert.IsType(t, FaceSuggestion{}, r)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		r := FaceSuggestionFixtures.Get("xxx")
+		assert.Equal(t, uint(0), r.ID)
+		assert.IsType(t, FaceSuggestion{}, r)
+	})
+}
+
+func TestFaceSuggestionMap_Pointer(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		r := FaceSuggestionFixtures.Pointer("fa-gr-jane-doe")
+		assert.Equal(t, uint(1000001), r.ID)
+		assert.IsType(t, &FaceSuggestion{}, r)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		r := FaceSuggestionFixtures.Pointer("xxx")
+		assert.Equal(t, uint(0), r.ID)
+		assert.IsType(t, &FaceSuggestion{}, r)
+	})
+}
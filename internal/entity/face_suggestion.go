@@ -0,0 +1,188 @@
+package entity
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/ai/face"
+)
+
+// Face suggestion status values.
+const (
+	SuggestionPending   = "pending"
+	SuggestionConfirmed = "confirmed"
+	SuggestionRejected  = "rejected"
+)
+
+// FaceSuggestions represents a list of suggested face merges.
+type FaceSuggestions []FaceSuggestion
+
+// FaceSuggestion represents an unknown face cluster that probably belongs to a known person
+// and is waiting to be confirmed or rejected in the review queue.
+//
+// Field Descriptions:
+// - FaceID is the unknown face cluster, SubjUID the suggested person.
+// - MatchID is the known face cluster of the person with the smallest distance.
+// - Confidence is a value between 0 and 100 derived from the distance, see face.SuggestDist.
+type FaceSuggestion struct {
+	ID         uint       `gorm:"primary_key" json:"ID" yaml:"-"`
+	FaceID     string     `gorm:"type:VARBINARY(64);unique_index:idx_faces_suggestions_face_subj;" json:"FaceID" yaml:"FaceID"`
+	SubjUID    string     `gorm:"type:VARBINARY(42);unique_index:idx_faces_suggestions_face_subj;" json:"SubjUID" yaml:"SubjUID"`
+	MatchID    string     `gorm:"type:VARBINARY(64);" json:"MatchID" yaml:"MatchID"`
+	Distance   float64    `json:"Distance" yaml:"Distance"`
+	Confidence int        `json:"Confidence" yaml:"Confidence"`
+	Status     string     `gorm:"type:VARBINARY(16);index;" json:"Status" yaml:"Status"`
+	ReviewedBy string     `gorm:"type:VARBINARY(42);" json:"ReviewedBy,omitempty" yaml:"ReviewedBy,omitempty"`
+	ReviewedAt *time.Time `json:"ReviewedAt,omitempty" yaml:"ReviewedAt,omitempty"`
+	CreatedAt  time.Time  `json:"CreatedAt" yaml:"-"`
+	UpdatedAt  time.Time  `json:"UpdatedAt" yaml:"-"`
+	Face       *Face      `gorm:"foreignkey:FaceID;association_foreignkey:ID;association_autoupdate:false;association_autocreate:false;association_save_reference:false" json:"Face,omitempty" yaml:"-"`
+	Subject    *Subject   `gorm:"foreignkey:SubjUID;association_foreignkey:SubjUID;association_autoupdate:false;association_autocreate:false;association_save_reference:false" json:"Subject,omitempty" yaml:"-"`
+}
+
+// TableName returns the entity table name.
+func (FaceSuggestion) TableName() string {
+	return "faces_suggestions"
+}
+
+// NewFaceSuggestion returns a new pending suggestion to assign the face cluster to a person.
+func NewFaceSuggestion(faceId, subjUid, matchId string, dist float64) *FaceSuggestion {
+	return &FaceSuggestion{
+		FaceID:     faceId,
+		SubjUID:    subjUid,
+		MatchID:    matchId,
+		Distance:   dist,
+		Confidence: SuggestionConfidence(dist),
+		Status:     SuggestionPending,
+	}
+}
+
+// SuggestionConfidence returns the confidence in percent for the specified face distance.
+func SuggestionConfidence(dist float64) int {
+	if dist < 0 || face.SuggestDist <= 0 || dist > face.SuggestDist {
+		return 0
+	}
+
+	return int(math.Round(100 * (1 - dist/face.SuggestDist)))
+}
+
+// FindFaceSuggestion returns the suggestion with the specified id or nil if it was not found.
+func FindFaceSuggestion(id uint) *FaceSuggestion {
+	if id == 0 {
+		return nil
+	}
+
+	m := &FaceSuggestion{}
+
+	if err := Db().First(m, "id = ?", id).Error; err != nil {
+		return nil
+	}
+
+	return m
+}
+
+// Pending checks if the suggestion has not been reviewed yet.
+func (m *FaceSuggestion) Pending() bool {
+	return m.Status == SuggestionPending
+}
+
+// Save inserts a new suggestion or loads the existing suggestion for the same face and person.
+// It returns true if a new suggestion was added.
+func (m *FaceSuggestion) Save() (created bool, err error) {
+	if m.FaceID == "" || m.SubjUID == "" {
+		return false, fmt.Errorf("face id and subject uid must not be empty")
+	}
+
+	existing := FaceSuggestion{}
+
+	if err = UnscopedDb().Where("face_id = ? AND subj_uid = ?", m.FaceID, m.SubjUID).First(&existing).Error; err != nil {
+		if m.Status == "" {
+			m.Status = SuggestionPending
+		}
+
+		return true, UnscopedDb().Create(m).Error
+	}
+
+	// Keep existing suggestions, so that rejected merges are not suggested again.
+	*m = existing
+
+	return false, nil
+}
+
+// Update the distance, match and confidence of a pending suggestion.
+func (m *FaceSuggestion) Update(matchId string, dist float64) error {
+	if m.ID == 0 {
+		return fmt.Errorf("empty id")
+	} else if !m.Pending() || m.MatchID == matchId && m.Distance == dist {
+		return nil
+	}
+
+	m.MatchID = matchId
+	m.Distance = dist
+	m.Confidence = SuggestionConfidence(dist)
+
+	return UnscopedDb().Model(m).UpdateColumns(Values{
+		"match_id":   m.MatchID,
+		"distance":   m.Distance,
+		"confidence": m.Confidence,
+		"updated_at": Now(),
+	}).Error
+}
+
+// Confirm assigns the suggested person to the face cluster and its markers.
+func (m *FaceSuggestion) Confirm(userUid string) error {
+	if m.ID == 0 {
+		return fmt.Errorf("empty id")
+	} else if m.Status == SuggestionConfirmed {
+		return nil
+	}
+
+	f := FindFace(m.FaceID)
+
+	if f == nil {
+		return fmt.Errorf("face not found")
+	} else if f.SubjUID != "" && f.SubjUID != m.SubjUID {
+		return fmt.Errorf("face has already been assigned to another person")
+	} else if FindSubject(m.SubjUID) == nil {
+		return fmt.Errorf("person not found")
+	}
+
+	if err := f.SetSubjectUID(m.SubjUID); err != nil {
+		return err
+	}
+
+	if err := m.review(SuggestionConfirmed, userUid); err != nil {
+		return err
+	}
+
+	// Remove other pending suggestions for the same face.
+	return UnscopedDb().
+		Where("face_id = ? AND id <> ? AND status = ?", m.FaceID, m.ID, SuggestionPending).
+		Delete(&FaceSuggestion{}).Error
+}
+
+// Reject marks the suggestion as rejected so that it is not suggested again.
+func (m *FaceSuggestion) Reject(userUid string) error {
+	if m.ID == 0 {
+		return fmt.Errorf("empty id")
+	} else if m.Status == SuggestionRejected {
+		return nil
+	}
+
+	return m.review(SuggestionRejected, userUid)
+}
+
+// review updates the suggestion status and reviewer.
+func (m *FaceSuggestion) review(status, userUid string) error {
+	m.Status = status
+	m.ReviewedBy = userUid
+	m.ReviewedAt = TimeStamp()
+
+	return UnscopedDb().Model(m).UpdateColumns(Values{
+		"status":      m.Status,
+		"reviewed_by": m.ReviewedBy,
+		"reviewed_at": m.ReviewedAt,
+		"updated_at":  Now(),
+	}).Error
+}
@@ -0,0 +1,56 @@
+package entity
+
+import (
+	"time"
+)
+
+type FaceSuggestionMap map[string]FaceSuggestion
+
+func (m FaceSuggestionMap) Get(name string) FaceSuggestion {
+	if result, ok := m[name]; ok {
+		return result
+	}
+
+	return FaceSuggestion{}
+}
+
+func (m FaceSuggestionMap) Pointer(name string) *FaceSuggestion {
+	if result, ok := m[name]; ok {
+		return &result
+	}
+
+	return &FaceSuggestion{}
+}
+
+var FaceSuggestionFixtures = FaceSuggestionMap{
+	"unknown-john-doe": {
+		ID:         1000000,
+		FaceID:     FaceFixtures.Get("unknown").ID,
+		SubjUID:    SubjectFixtures.Get("john-doe").SubjUID,
+		MatchID:    FaceFixtures.Get("john-doe").ID,
+		Distance:   0.72,
+		Confidence: 20,
+		Status:     SuggestionPending,
+		CreatedAt:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	},
+	"fa-gr-jane-doe": {
+		ID:         1000001,
+		FaceID:     FaceFixtures.Get("fa-gr").ID,
+		SubjUID:    SubjectFixtures.Get("jane-doe").SubjUID,
+		MatchID:    FaceFixtures.Get("jane-doe").ID,
+		Distance:   0.81,
+		Confidence: 10,
+		Status:     SuggestionRejected,
+		ReviewedBy: UserFixtures.Get("alice").UserUID,
+		CreatedAt:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:  time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+	},
+}
+
+// CreateFaceSuggestionFixtures inserts known entities into the database for testing.
+func CreateFaceSuggestionFixtures() {
+	for _, entity := range FaceSuggestionFixtures {
+		Db().Create(&entity)
+	}
+}
@@ -0,0 +1,172 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/ai/face"
+)
+
+func TestFaceSuggestion_TableName(t *testing.T) {
+	assert.Equal(t, "faces_suggestions", FaceSuggestion{}.TableName())
+}
+
+func TestSuggestionConfidence(t *testing.T) {
+	t.Run("Zero", func(t *testing.T) {
+		assert.Equal(t, 100, SuggestionConfidence(0))
+	})
+	t.Run("Half", func(t *testing.T) {
+		assert.Equal(t, 50, SuggestionConfidence(face.SuggestDist/2))
+	})
+	t.Run("Limit", func(t *testing.T) {
+		assert.Equal(t, 0, SuggestionConfidence(face.SuggestDist))
+	})
+	t.Run("TooFar", func(t *testing.T) {
+		assert.Equal(t, 0, SuggestionConfidence(face.SuggestDist+0.1))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Equal(t, 0, SuggestionConfidence(-1))
+	})
+}
+
+func TestFindFaceSuggestion(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		m := FindFaceSuggestion(FaceSuggestionFixtures.Get("unknown-john-doe").ID)
+
+		if m == nil {
+			t.Fatal("result should not be nil")
+		}
+
+		assert.Equal(t, FaceFixtures.Get("unknown").ID, m.FaceID)
+		assert.True(t, m.Pending())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		assert.Nil(t, FindFaceSuggestion(123456789))
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.Nil(t, FindFaceSuggestion(0))
+	})
+}
+
+func TestFaceSuggestion_Save(t *testing.T) {
+	t.Run("Existing", func(t *testing.T) {
+		fixture := FaceSuggestionFixtures.Get("fa-gr-jane-doe")
+		m := NewFaceSuggestion(fixture.FaceID, fixture.SubjUID, fixture.MatchID, 0.2)
+
+		created, err := m.Save()
+
+		assert.NoError(t, err)
+		assert.False(t, created)
+		assert.Equal(t, fixture.ID, m.ID)
+		assert.Equal(t, SuggestionRejected, m.Status)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		m := NewFaceSuggestion("", "", "", 0.5)
+
+		created, err := m.Save()
+
+		assert.Error(t, err)
+		assert.False(t, created)
+	})
+}
+
+func TestFaceSuggestion_Update(t *testing.T) {
+	t.Run("Pending", func(t *testing.T) {
+		m := NewFaceSuggestion("UPDATESUGGESTIONTESTFACE00000001", SubjectFixtures.Get("joe-biden").SubjUID, "", 0.8)
+
+		created, err := m.Save()
+
+		assert.NoError(t, err)
+		assert.True(t, created)
+
+		if err = m.Update(FaceFixtures.Get("joe-biden").ID, face.SuggestDist/2); err != nil {
+			t.Fatal(err)
+		}
+
+		found := FindFaceSuggestion(m.ID)
+
+		if found == nil {
+			t.Fatal("result should not be nil")
+		}
+
+		assert.Equal(t, FaceFixtures.Get("joe-biden").ID, found.MatchID)
+		assert.Equal(t, 50, found.Confidence)
+	})
+	t.Run("Rejected", func(t *testing.T) {
+		m := FaceSuggestionFixtures.Get("fa-gr-jane-doe")
+
+		assert.NoError(t, m.Update("", 0.1))
+		assert.Equal(t, 0.81, m.Distance)
+	})
+}
+
+func TestFaceSuggestion_Confirm(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		f := NewFace("", SrcAuto, face.RandomEmbeddings(2, face.RegularFace))
+
+		if err := f.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		subjUid := SubjectFixtures.Get("actor-1").SubjUID
+		m := NewFaceSuggestion(f.ID, subjUid, FaceFixtures.Get("actor-1").ID, 0.5)
+		other := NewFaceSuggestion(f.ID, SubjectFixtures.Get("joe-biden").SubjUID, FaceFixtures.Get("joe-biden").ID, 0.6)
+
+		if _, err := m.Save(); err != nil {
+			t.Fatal(err)
+		} else if _, err = other.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := m.Confirm(UserFixtures.Get("alice").UserUID); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, SuggestionConfirmed, m.Status)
+		assert.NotNil(t, m.ReviewedAt)
+		assert.Equal(t, subjUid, FindFace(f.ID).SubjUID)
+		assert.Nil(t, FindFaceSuggestion(other.ID))
+	})
+	t.Run("FaceNotFound", func(t *testing.T) {
+		m := NewFaceSuggestion("CONFIRMSUGGESTIONTESTFACE0000001", SubjectFixtures.Get("joe-biden").SubjUID, "", 0.5)
+
+		if _, err := m.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Error(t, m.Confirm(""))
+		assert.True(t, m.Pending())
+	})
+	t.Run("EmptyID", func(t *testing.T) {
+		m := FaceSuggestion{}
+		assert.Error(t, m.Confirm(""))
+	})
+}
+
+func TestFaceSuggestion_Reject(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		m := NewFaceSuggestion("REJECTSUGGESTIONTESTFACE00000001", SubjectFixtures.Get("jane-doe").SubjUID, "", 0.5)
+
+		if _, err := m.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		if err := m.Reject(UserFixtures.Get("alice").UserUID); err != nil {
+			t.Fatal(err)
+		}
+
+		found := FindFaceSuggestion(m.ID)
+
+		if found == nil {
+			t.Fatal("result should not be nil")
+		}
+
+		assert.Equal(t, SuggestionRejected, found.Status)
+		assert.Equal(t, UserFixtures.Get("alice").UserUID, found.ReviewedBy)
+	})
+	t.Run("EmptyID", func(t *testing.T) {
+		m := FaceSuggestion{}
+		assert.Error(t, m.Reject(""))
+	})
+}
@@ -81,6 +81,7 @@ type File struct {
 	FileLuminance      string        `gorm:"type:VARBINARY(18);" json:"Luminance" yaml:"Luminance,omitempty"`
 	FileDiff           int           `json:"Diff" yaml:"Diff,omitempty"`
 	FileChroma         int16         `json:"Chroma" yaml:"Chroma,omitempty"`
+	FilePhash          string        `gorm:"type:VARBINARY(16);" json:"Phash" yaml:"Phash,omitempty"`
 	FileSoftware       string        `gorm:"type:VARCHAR(64)" json:"Software" yaml:"Software,omitempty"`
 	FileError          string        `gorm:"type:VARBINARY(512);index;" json:"Error" yaml:"Error,omitempty"`
 	ModTime            int64         `json:"ModTime" yaml:"-"`
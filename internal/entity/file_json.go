@@ -44,6 +44,7 @@ func (m *File) MarshalJSON() ([]byte, error) {
 		Luminance      string        `json:",omitempty"`
 		Diff           int           `json:",omitempty"`
 		Chroma         int16         `json:",omitempty"`
+		Phash          string        `json:",omitempty"`
 		HDR            bool          `json:",omitempty"`
 		Watermark      bool          `json:",omitempty"`
 		Software       string        `json:",omitempty"`
@@ -92,6 +93,7 @@ func (m *File) MarshalJSON() ([]byte, error) {
 		Luminance:      m.FileLuminance,
 		Diff:           m.FileDiff,
 		Chroma:         m.FileChroma,
+		Phash:          m.FilePhash,
 		HDR:            m.FileHDR,
 		Watermark:      m.FileWatermark,
 		Software:       m.FileSoftware,
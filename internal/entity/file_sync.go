@@ -1,7 +1,10 @@
 package entity
 
 import (
+	"fmt"
 	"time"
+
+	"github.com/photoprism/photoprism/pkg/clean"
 )
 
 const (
@@ -11,15 +14,23 @@ const (
 	FileSyncFailed     = "failed"
 	FileSyncDownloaded = "downloaded"
 	FileSyncUploaded   = "uploaded"
+	FileSyncConflict   = "conflict"
+	FileSyncDeleted    = "deleted"
 )
 
 // FileSync tracks the synchronization status for a file on an external service.
+//
+// RemoteETag, RemoteDate, and RemoteSize describe the remote file as of the last synchronization,
+// while FileHash contains the checksum of the local file, so that changes can be detected on both sides.
 type FileSync struct {
 	RemoteName string    `gorm:"primary_key;auto_increment:false;type:VARBINARY(255)" json:"RemoteName" yaml:"RemoteName,omitempty"`
 	ServiceID  uint      `gorm:"primary_key;auto_increment:false" json:"ServiceID" yaml:"ServiceID,omitempty"`
 	FileID     uint      `gorm:"index;" json:"FileID" yaml:"FileID,omitempty"`
 	RemoteDate time.Time `json:"RemoteDate,omitempty" yaml:"RemoteDate,omitempty"`
 	RemoteSize int64     `json:"RemoteSize,omitempty" yaml:"RemoteSize,omitempty"`
+	RemoteETag string    `gorm:"type:VARBINARY(255);" json:"RemoteETag,omitempty" yaml:"RemoteETag,omitempty"`
+	FileHash   string    `gorm:"type:VARBINARY(128);" json:"FileHash,omitempty" yaml:"FileHash,omitempty"`
+	Resolution string    `gorm:"type:VARBINARY(16);" json:"Resolution,omitempty" yaml:"Resolution,omitempty"`
 	Status     string    `gorm:"type:VARBINARY(16);" json:"Status" yaml:"Status,omitempty"`
 	Error      string    `gorm:"type:VARBINARY(512);" json:"Error,omitempty" yaml:"Error,omitempty"`
 	Errors     int       `json:"Errors,omitempty" yaml:"Errors,omitempty"`
@@ -78,3 +89,50 @@ func FirstOrCreateFileSync(m *FileSync) *FileSync {
 
 	return m
 }
+
+// Synced tests if the file has been transferred successfully in either direction.
+func (m *FileSync) Synced() bool {
+	return m.Status == FileSyncDownloaded || m.Status == FileSyncUploaded
+}
+
+// RemoteChanged tests if the remote file has been modified since the last synchronization.
+// The ETag is compared if known, otherwise the modification time and size.
+func (m *FileSync) RemoteChanged(etag string, date time.Time, size int64) bool {
+	if m.RemoteETag != "" && etag != "" {
+		return m.RemoteETag != etag
+	}
+
+	return !m.RemoteDate.Equal(date) || m.RemoteSize != size
+}
+
+// LocalChanged tests if the local file has been modified since the last synchronization.
+func (m *FileSync) LocalChanged() bool {
+	if m.FileID == 0 || m.FileHash == "" {
+		return false
+	}
+
+	file := File{}
+
+	if err := UnscopedDb().Select("file_hash").Where("id = ?", m.FileID).First(&file).Error; err != nil {
+		return false
+	}
+
+	return file.FileHash != "" && file.FileHash != m.FileHash
+}
+
+// Resolve sets the policy for resolving a conflict between local and remote changes.
+func (m *FileSync) Resolve(policy string) error {
+	switch policy {
+	case SyncConflictKeepBoth, SyncConflictNewest, SyncConflictRemote, SyncConflictLocal:
+	default:
+		return fmt.Errorf("invalid conflict resolution %s", clean.Log(policy))
+	}
+
+	if m.Status != FileSyncConflict {
+		return fmt.Errorf("%s has no conflict", clean.Log(m.RemoteName))
+	}
+
+	m.Resolution = policy
+
+	return m.Update("Resolution", policy)
+}
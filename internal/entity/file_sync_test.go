@@ -2,6 +2,7 @@ package entity
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -103,3 +104,71 @@ func TestFileSync_Save(t *testing.T) {
 		assert.True(t, afterDate.After(initialDate))
 	})
 }
+
+func TestFileSync_Synced(t *testing.T) {
+	assert.True(t, (&FileSync{Status: FileSyncDownloaded}).Synced())
+	assert.True(t, (&FileSync{Status: FileSyncUploaded}).Synced())
+	assert.False(t, (&FileSync{Status: FileSyncNew}).Synced())
+	assert.False(t, (&FileSync{Status: FileSyncConflict}).Synced())
+}
+
+func TestFileSync_RemoteChanged(t *testing.T) {
+	date := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("ETag", func(t *testing.T) {
+		m := &FileSync{RemoteETag: `"abc"`, RemoteDate: date, RemoteSize: 100}
+		assert.False(t, m.RemoteChanged(`"abc"`, date.Add(time.Hour), 200))
+		assert.True(t, m.RemoteChanged(`"def"`, date, 100))
+	})
+	t.Run("DateAndSize", func(t *testing.T) {
+		m := &FileSync{RemoteDate: date, RemoteSize: 100}
+		assert.False(t, m.RemoteChanged(`"abc"`, date, 100))
+		assert.True(t, m.RemoteChanged("", date.Add(time.Hour), 100))
+		assert.True(t, m.RemoteChanged("", date, 200))
+	})
+}
+
+func TestFileSync_LocalChanged(t *testing.T) {
+	file := FileFixturesExampleJPG
+
+	t.Run("Unknown", func(t *testing.T) {
+		m := &FileSync{FileID: file.ID}
+		assert.False(t, m.LocalChanged())
+	})
+	t.Run("Unchanged", func(t *testing.T) {
+		m := &FileSync{FileID: file.ID, FileHash: file.FileHash}
+		assert.False(t, m.LocalChanged())
+	})
+	t.Run("Changed", func(t *testing.T) {
+		m := &FileSync{FileID: file.ID, FileHash: "0000000000000000000000000000000000000000"}
+		assert.True(t, m.LocalChanged())
+	})
+}
+
+func TestFileSync_Resolve(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		m := &FileSync{ServiceID: 1000000, RemoteName: "/conflict-resolve.jpg", Status: FileSyncConflict}
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NoError(t, m.Resolve(SyncConflictNewest))
+
+		result := FileSync{}
+
+		if err := Db().Where("service_id = ? AND remote_name = ?", m.ServiceID, m.RemoteName).First(&result).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, SyncConflictNewest, result.Resolution)
+	})
+	t.Run("InvalidPolicy", func(t *testing.T) {
+		m := &FileSync{Status: FileSyncConflict}
+		assert.Error(t, m.Resolve("foo"))
+	})
+	t.Run("NoConflict", func(t *testing.T) {
+		m := &FileSync{Status: FileSyncDownloaded}
+		assert.Error(t, m.Resolve(SyncConflictRemote))
+	})
+}
@@ -28,6 +28,7 @@ func CreateTestFixtures() {
 	CreateSubjectFixtures()
 	CreateMarkerFixtures()
 	CreateFaceFixtures()
+	CreateFaceSuggestionFixtures()
 	CreateUserFixtures()
 	CreateSessionFixtures()
 	CreateClientFixtures()
@@ -35,4 +36,11 @@ func CreateTestFixtures() {
 	CreatePasscodeFixtures()
 	CreatePasswordFixtures()
 	CreateUserShareFixtures()
+	CreateTeamFixtures()
+	CreateWebhookFixtures()
+	CreateWebhookDeliveryFixtures()
+	CreateJobFixtures()
+	CreateJobRunFixtures()
+	CreateCommentFixtures()
+	CreatePhotoEmbeddingFixtures()
 }
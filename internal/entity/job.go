@@ -0,0 +1,283 @@
+package entity
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/robfig/cron/v3"
+	"github.com/ulule/deepcopier"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// JobUID is the unique ID prefix.
+const (
+	JobUID = byte('r')
+)
+
+// Actions that can be run by jobs.
+const (
+	JobIndex   = "index"
+	JobImport  = "import"
+	JobFaces   = "faces"
+	JobCleanUp = "cleanup"
+	JobPurge   = "purge"
+	JobThumbs  = "thumbs"
+	JobBackup  = "backup"
+	JobVision  = "vision"
+)
+
+// JobActions contains all actions that can be run by jobs.
+var JobActions = []string{JobIndex, JobImport, JobFaces, JobCleanUp, JobPurge, JobThumbs, JobBackup, JobVision}
+
+// JobRunsLimit is the maximum number of runs kept in the history of each job.
+var JobRunsLimit = 100
+
+// Jobs represents a list of jobs.
+type Jobs []Job
+
+// Job represents a background task that runs one or more actions in sequence, either manually or on a schedule.
+//
+// Field Descriptions:
+// - JobActions contains the actions to run as comma-separated list, e.g. "import,faces", see JobActions.
+// - JobPath optionally limits the index, import, purge, and thumbs actions to a sub-folder.
+// - JobSchedule is a cron schedule, e.g. "0 2 * * *" for every night at 2am, or empty if the job only runs manually.
+// - JobPaused prevents the job from running on schedule, it can still be triggered manually.
+// - JobStatus and RunAt contain the status and start time of the last run.
+type Job struct {
+	JobUID      string     `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;" json:"UID" yaml:"UID"`
+	JobName     string     `gorm:"type:VARCHAR(160);" json:"Name" yaml:"Name,omitempty"`
+	JobActions  string     `gorm:"type:VARBINARY(255);" json:"Actions" yaml:"Actions"`
+	JobPath     string     `gorm:"type:VARCHAR(1024);" json:"Path" yaml:"Path,omitempty"`
+	JobSchedule string     `gorm:"type:VARBINARY(128);" json:"Schedule" yaml:"Schedule,omitempty"`
+	JobPaused   bool       `json:"Paused" yaml:"Paused,omitempty"`
+	JobStatus   string     `gorm:"type:VARBINARY(16);" json:"Status" yaml:"Status,omitempty"`
+	RunAt       *time.Time `json:"RunAt" yaml:"RunAt,omitempty"`
+	CreatedAt   time.Time  `json:"CreatedAt" yaml:"-"`
+	UpdatedAt   time.Time  `json:"UpdatedAt" yaml:"-"`
+	DeletedAt   *time.Time `sql:"index" json:"DeletedAt,omitempty" yaml:"-"`
+}
+
+// TableName returns the entity table name.
+func (Job) TableName() string {
+	return "jobs"
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *Job) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsUID(m.JobUID, JobUID) {
+		return nil
+	}
+
+	m.JobUID = rnd.GenerateUID(JobUID)
+
+	return scope.SetColumn("JobUID", m.JobUID)
+}
+
+// AddJob creates a new job with the specified form values.
+func AddJob(frm form.Job) (m *Job, err error) {
+	m = &Job{}
+
+	err = m.SaveForm(frm)
+
+	return m, err
+}
+
+// FindJob returns the matching job or nil if it was not found.
+func FindJob(uid string) *Job {
+	if rnd.InvalidUID(uid, JobUID) {
+		return nil
+	}
+
+	m := &Job{}
+
+	// Find matching record.
+	if err := Db().First(m, "job_uid = ?", uid).Error; err != nil {
+		return nil
+	}
+
+	return m
+}
+
+// ParseJobActions returns the job actions in the specified comma or space separated list.
+func ParseJobActions(s string) (actions []string, err error) {
+	actions = []string{}
+
+	for _, action := range strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return r == ',' || r == ' ' || r == ';'
+	}) {
+		if !slices.Contains(JobActions, action) {
+			return actions, fmt.Errorf("unknown job action %s", clean.Log(action))
+		}
+
+		actions = append(actions, action)
+	}
+
+	return actions, nil
+}
+
+// SaveForm validates the form values and saves them to the database.
+func (m *Job) SaveForm(frm form.Job) error {
+	if err := deepcopier.Copy(m).From(frm); err != nil {
+		return err
+	}
+
+	m.JobName = txt.Clip(m.JobName, txt.ClipName)
+	m.JobPath = clean.UserPath(m.JobPath)
+	m.JobSchedule = strings.TrimSpace(m.JobSchedule)
+
+	if actions, err := ParseJobActions(m.JobActions); err != nil {
+		return err
+	} else if len(actions) == 0 {
+		return fmt.Errorf("job has no actions")
+	} else {
+		m.JobActions = strings.Join(actions, ",")
+	}
+
+	// Example: "0 2 * * *" stands for every night at 2am.
+	if m.JobSchedule == "" {
+		// Jobs without schedule can only be run manually.
+	} else if _, err := cron.ParseStandard(m.JobSchedule); err != nil {
+		return fmt.Errorf("invalid job schedule %s", clean.Log(m.JobSchedule))
+	}
+
+	return m.Save()
+}
+
+// Actions returns the job actions in the order in which they are run.
+func (m *Job) Actions() []string {
+	if m.JobActions == "" {
+		return []string{}
+	}
+
+	return strings.Split(m.JobActions, ",")
+}
+
+// Scheduled checks if the job should run on schedule.
+func (m *Job) Scheduled() bool {
+	return m.JobSchedule != "" && !m.JobPaused && !m.Deleted()
+}
+
+// SetPaused pauses or resumes running the job on schedule.
+func (m *Job) SetPaused(paused bool) error {
+	m.JobPaused = paused
+
+	return m.Updates(Values{"job_paused": paused})
+}
+
+// Started records the start of a new run and returns it.
+func (m *Job) Started(trigger string) (run *JobRun, err error) {
+	if m.JobUID == "" {
+		return nil, fmt.Errorf("job uid is empty")
+	}
+
+	run = NewJobRun(m, trigger)
+
+	if err = run.Create(); err != nil {
+		return run, err
+	}
+
+	m.JobStatus = run.Status
+	m.RunAt = &run.StartedAt
+
+	return run, m.Updates(Values{"job_status": m.JobStatus, "run_at": m.RunAt})
+}
+
+// Finished records the result of a run and removes old runs from the history.
+func (m *Job) Finished(run *JobRun, runErr error, runLog string) error {
+	if run == nil {
+		return fmt.Errorf("job run is missing")
+	}
+
+	if err := run.Finish(runErr, runLog); err != nil {
+		return err
+	}
+
+	m.JobStatus = run.Status
+
+	if err := m.Updates(Values{"job_status": m.JobStatus}); err != nil {
+		return err
+	}
+
+	return m.PruneRuns(JobRunsLimit)
+}
+
+// PruneRuns removes all but the most recent runs from the history of the job.
+func (m *Job) PruneRuns(keep int) error {
+	if m.JobUID == "" || keep <= 0 {
+		return nil
+	}
+
+	var ids []uint
+
+	// Find the oldest run to keep.
+	if err := Db().Model(&JobRun{}).Where("job_uid = ?", m.JobUID).
+		Order("id DESC").Offset(keep-1).Limit(1).Pluck("id", &ids).Error; err != nil {
+		return err
+	} else if len(ids) == 0 {
+		return nil
+	}
+
+	return Db().Where("job_uid = ? AND id < ?", m.JobUID, ids[0]).Delete(&JobRun{}).Error
+}
+
+// Report returns the entity values as rows.
+func (m *Job) Report(skipEmpty bool) (rows [][]string, cols []string) {
+	cols = []string{"Name", "Value"}
+
+	// Extract model values.
+	values, _, err := ModelValues(m)
+
+	// Ok?
+	if err != nil {
+		return rows, cols
+	}
+
+	rows = make([][]string, 0, len(values))
+
+	for k, v := range values {
+		s := fmt.Sprintf("%#v", v)
+
+		// Skip empty values?
+		if !skipEmpty || s != "" {
+			rows = append(rows, []string{k, s})
+		}
+	}
+
+	return rows, cols
+}
+
+// Deleted checks if the job has been deleted.
+func (m *Job) Deleted() bool {
+	return m.DeletedAt != nil
+}
+
+// Delete marks the job as deleted, its run history is kept.
+func (m *Job) Delete() error {
+	if m.JobUID == "" {
+		return fmt.Errorf("job uid is empty")
+	}
+
+	return Db().Delete(m).Error
+}
+
+// Updates multiple columns in the database.
+func (m *Job) Updates(values interface{}) error {
+	return UnscopedDb().Model(m).UpdateColumns(values).Error
+}
+
+// Save updates the record in the database or inserts a new record if it does not already exist.
+func (m *Job) Save() error {
+	return Db().Save(m).Error
+}
+
+// Create inserts a new row to the database.
+func (m *Job) Create() error {
+	return Db().Create(m).Error
+}
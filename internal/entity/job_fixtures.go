@@ -0,0 +1,109 @@
+package entity
+
+import (
+	"time"
+)
+
+type JobMap map[string]Job
+
+func (m JobMap) Get(name string) Job {
+	if result, ok := m[name]; ok {
+		return result
+	}
+
+	return Job{}
+}
+
+func (m JobMap) Pointer(name string) *Job {
+	if result, ok := m[name]; ok {
+		return &result
+	}
+
+	return &Job{}
+}
+
+var JobFixtures = JobMap{
+	"nightly": {
+		JobUID:      "rt2hdkz1vgdb3ta2",
+		JobName:     "Nightly Import",
+		JobActions:  "import,faces",
+		JobPath:     "",
+		JobSchedule: "0 2 * * *",
+		JobPaused:   false,
+		JobStatus:   JobSucceeded,
+		RunAt:       TimeStamp(),
+		CreatedAt:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	},
+	"paused": {
+		JobUID:      "rt2hdl31ydq9yw0k",
+		JobName:     "Weekly Cleanup",
+		JobActions:  "cleanup,purge",
+		JobSchedule: "0 4 * * 0",
+		JobPaused:   true,
+		JobStatus:   JobFailed,
+		CreatedAt:   time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:   time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+	},
+}
+
+// CreateJobFixtures inserts known entities into the database for testing.
+func CreateJobFixtures() {
+	for _, entity := range JobFixtures {
+		Db().Create(&entity)
+	}
+}
+
+type JobRunMap map[string]JobRun
+
+func (m JobRunMap) Get(name string) JobRun {
+	if result, ok := m[name]; ok {
+		return result
+	}
+
+	return JobRun{}
+}
+
+func (m JobRunMap) Pointer(name string) *JobRun {
+	if result, ok := m[name]; ok {
+		return &result
+	}
+
+	return &JobRun{}
+}
+
+var JobRunFixtures = JobRunMap{
+	"succeeded": {
+		ID:         1000000,
+		JobUID:     JobFixtures.Pointer("nightly").JobUID,
+		Actions:    "import,faces",
+		Trigger:    JobSchedule,
+		Status:     JobSucceeded,
+		Log:        "import: completed in 5.2s\nfaces: completed in 1.1s",
+		Duration:   6300,
+		StartedAt:  time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC),
+		FinishedAt: TimeStamp(),
+		CreatedAt:  time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC),
+		UpdatedAt:  time.Date(2024, 1, 1, 2, 0, 0, 0, time.UTC),
+	},
+	"failed": {
+		ID:         1000001,
+		JobUID:     JobFixtures.Pointer("paused").JobUID,
+		Actions:    "cleanup,purge",
+		Trigger:    JobManual,
+		Status:     JobFailed,
+		Error:      "index is busy",
+		Duration:   15,
+		StartedAt:  time.Date(2024, 1, 2, 4, 0, 0, 0, time.UTC),
+		FinishedAt: TimeStamp(),
+		CreatedAt:  time.Date(2024, 1, 2, 4, 0, 0, 0, time.UTC),
+		UpdatedAt:  time.Date(2024, 1, 2, 4, 0, 0, 0, time.UTC),
+	},
+}
+
+// CreateJobRunFixtures inserts known entities into the database for testing.
+func CreateJobRunFixtures() {
+	for _, entity := range JobRunFixtures {
+		Db().Create(&entity)
+	}
+}
@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJobMap_Get(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		r := JobFixtures.Get("nightly")
+		assert.Equal(t, "rt2hdkz1vgdb3ta2", r.JobUID)
+		assert.IsType(t, Job{}, r)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		r := JobFixtures.Get("xxx")
+		assert.Equal(t, "", r.JobUID)
+		assert.IsType(t, Job{}, r)
+	})
+}
+
+func TestJobMap_Pointer(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		r := JobFixtures.Pointer("paused")
+		assert.Equal(t, "rt2hdl31ydq9yw0k", r.JobUID)
+		assert.IsType(t, &Job{}, r)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		r := JobFixtures.Pointer("xxx")
+		assert.Equal(t, "", r.JobUID)
+		assert.IsType(t, &Job{}, r)
+	})
+}
+
+func TestJobRunMap_Get(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		r := JobRunFixtures.Get("failed")
+		assert.Equal(t, uint(1000001), r.ID)
+		assert.IsType(t, JobRun{}, r)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		r := JobRunFixtures.Get("xxx")
+		assert.Equal(t, uint(0), r.ID)
+		assert.IsType(t, JobRun{}, r)
+	})
+}
+
+func TestJobRunMap_Pointer(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		r := JobRunFixtures.Pointer("succeeded")
+		assert.Equal(t, uint(1000000), r.ID)
+		assert.IsType(t, &JobRun{}, r)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		r := JobRunFixtures.Pointer("xxx")
+		assert.Equal(t, uint(0), r.ID)
+		assert.IsType(t, &JobRun{}, r)
+	})
+}
@@ -0,0 +1,119 @@
+package entity
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+	"github.com/photoprism/photoprism/pkg/txt/clip"
+)
+
+// Job run status values.
+const (
+	JobRunning   = "running"
+	JobSucceeded = "succeeded"
+	JobFailed    = "failed"
+)
+
+// Job run trigger values.
+const (
+	JobSchedule = "schedule"
+	JobManual   = "manual"
+)
+
+// JobLogLimit is the maximum number of characters kept from the log output of a job run.
+var JobLogLimit = 4096
+
+// JobRuns represents a list of job runs.
+type JobRuns []JobRun
+
+// JobRun represents a single run of a job, including its duration, outcome, and an excerpt of the log output.
+type JobRun struct {
+	ID         uint       `gorm:"primary_key" json:"ID" yaml:"-"`
+	JobUID     string     `gorm:"type:VARBINARY(42);index;" json:"JobUID" yaml:"JobUID"`
+	Actions    string     `gorm:"type:VARBINARY(255);" json:"Actions" yaml:"Actions"`
+	Trigger    string     `gorm:"type:VARBINARY(16);" json:"Trigger" yaml:"Trigger"`
+	Status     string     `gorm:"type:VARBINARY(16);index;" json:"Status" yaml:"Status"`
+	Error      string     `gorm:"type:VARBINARY(512);" json:"Error" yaml:"Error,omitempty"`
+	Log        string     `gorm:"type:TEXT;" json:"Log" yaml:"Log,omitempty"`
+	Duration   int64      `json:"Duration" yaml:"Duration,omitempty"`
+	StartedAt  time.Time  `json:"StartedAt" yaml:"StartedAt"`
+	FinishedAt *time.Time `json:"FinishedAt" yaml:"FinishedAt,omitempty"`
+	CreatedAt  time.Time  `json:"CreatedAt" yaml:"-"`
+	UpdatedAt  time.Time  `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns the entity table name.
+func (JobRun) TableName() string {
+	return "jobs_runs"
+}
+
+// NewJobRun returns a new run of the job that has just been started.
+func NewJobRun(job *Job, trigger string) *JobRun {
+	return &JobRun{
+		JobUID:    job.JobUID,
+		Actions:   job.JobActions,
+		Trigger:   trigger,
+		Status:    JobRunning,
+		StartedAt: time.Now().UTC(),
+	}
+}
+
+// FindJobRun returns the matching run or nil if it was not found.
+func FindJobRun(id uint) *JobRun {
+	if id == 0 {
+		return nil
+	}
+
+	m := &JobRun{}
+
+	if err := Db().First(m, "id = ?", id).Error; err != nil {
+		return nil
+	}
+
+	return m
+}
+
+// Finish updates the run with its outcome, duration, and the end of the log output.
+func (m *JobRun) Finish(err error, log string) error {
+	now := time.Now().UTC()
+
+	m.FinishedAt = &now
+	m.Duration = now.Sub(m.StartedAt).Milliseconds()
+
+	// Keep the end of the log output, as it usually contains the result.
+	if runes := []rune(log); len(runes) > JobLogLimit {
+		m.Log = string(runes[len(runes)-JobLogLimit:])
+	} else {
+		m.Log = log
+	}
+
+	if err == nil {
+		m.Status = JobSucceeded
+		m.Error = ""
+	} else {
+		m.Status = JobFailed
+		m.Error = clip.Chars(err.Error(), txt.ClipError)
+	}
+
+	return m.Save()
+}
+
+// Running checks if the run has not finished yet.
+func (m *JobRun) Running() bool {
+	return m.Status == JobRunning
+}
+
+// Failed checks if the run has failed.
+func (m *JobRun) Failed() bool {
+	return m.Status == JobFailed
+}
+
+// Save updates the record in the database or inserts a new record if it does not already exist.
+func (m *JobRun) Save() error {
+	return Db().Save(m).Error
+}
+
+// Create inserts a new row to the database.
+func (m *JobRun) Create() error {
+	return Db().Create(m).Error
+}
@@ -0,0 +1,55 @@
+package entity
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewJobRun(t *testing.T) {
+	run := NewJobRun(JobFixtures.Pointer("nightly"), JobSchedule)
+	assert.Equal(t, "rt2hdkz1vgdb3ta2", run.JobUID)
+	assert.Equal(t, "import,faces", run.Actions)
+	assert.Equal(t, JobSchedule, run.Trigger)
+	assert.True(t, run.Running())
+	assert.False(t, run.StartedAt.IsZero())
+}
+
+func TestFindJobRun(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		run := FindJobRun(JobRunFixtures.Get("succeeded").ID)
+
+		if run == nil {
+			t.Fatal("run not found")
+		}
+
+		assert.Equal(t, JobSucceeded, run.Status)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		assert.Nil(t, FindJobRun(0))
+		assert.Nil(t, FindJobRun(123456789))
+	})
+}
+
+func TestJobRun_Finish(t *testing.T) {
+	t.Run("Succeeded", func(t *testing.T) {
+		run := NewJobRun(JobFixtures.Pointer("nightly"), JobManual)
+		assert.NoError(t, run.Create())
+		assert.NoError(t, run.Finish(nil, "done"))
+		assert.Equal(t, JobSucceeded, run.Status)
+		assert.Equal(t, "done", run.Log)
+		assert.NotNil(t, run.FinishedAt)
+		assert.GreaterOrEqual(t, run.Duration, int64(0))
+	})
+	t.Run("Failed", func(t *testing.T) {
+		run := NewJobRun(JobFixtures.Pointer("nightly"), JobManual)
+		assert.NoError(t, run.Create())
+		assert.NoError(t, run.Finish(errors.New("index is busy"), strings.Repeat("x", JobLogLimit)+"end"))
+		assert.True(t, run.Failed())
+		assert.Equal(t, "index is busy", run.Error)
+		assert.Len(t, run.Log, JobLogLimit)
+		assert.True(t, strings.HasSuffix(run.Log, "end"))
+	})
+}
@@ -0,0 +1,178 @@
+package entity
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+func TestAddJob(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		m, err := AddJob(form.Job{
+			JobName:     "Test",
+			JobActions:  "Import, faces",
+			JobPath:     " /2024/ ",
+			JobSchedule: " 0 2 * * * ",
+		})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, rnd.IsUID(m.JobUID, JobUID))
+		assert.Equal(t, "import,faces", m.JobActions)
+		assert.Equal(t, []string{JobImport, JobFaces}, m.Actions())
+		assert.Equal(t, "2024", m.JobPath)
+		assert.Equal(t, "0 2 * * *", m.JobSchedule)
+		assert.True(t, m.Scheduled())
+
+		found := FindJob(m.JobUID)
+
+		if found == nil {
+			t.Fatal("job not found")
+		}
+
+		assert.Equal(t, m.JobActions, found.JobActions)
+		assert.NoError(t, found.Delete())
+		assert.Nil(t, FindJob(m.JobUID))
+	})
+	t.Run("Manual", func(t *testing.T) {
+		m, err := AddJob(form.Job{JobActions: "thumbs"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, m.Scheduled())
+		assert.NoError(t, m.Delete())
+	})
+	t.Run("NoActions", func(t *testing.T) {
+		_, err := AddJob(form.Job{JobSchedule: "0 2 * * *"})
+		assert.Error(t, err)
+	})
+	t.Run("InvalidAction", func(t *testing.T) {
+		_, err := AddJob(form.Job{JobActions: "index,reboot"})
+		assert.Error(t, err)
+	})
+	t.Run("InvalidSchedule", func(t *testing.T) {
+		_, err := AddJob(form.Job{JobActions: "index", JobSchedule: "every night"})
+		assert.Error(t, err)
+	})
+}
+
+func TestFindJob(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		m := FindJob(JobFixtures.Get("nightly").JobUID)
+
+		if m == nil {
+			t.Fatal("job not found")
+		}
+
+		assert.Equal(t, "Nightly Import", m.JobName)
+	})
+	t.Run("InvalidUID", func(t *testing.T) {
+		assert.Nil(t, FindJob("wt2hdkz1vgdb3ta2"))
+		assert.Nil(t, FindJob(""))
+	})
+}
+
+func TestParseJobActions(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		actions, err := ParseJobActions("import faces;cleanup")
+		assert.NoError(t, err)
+		assert.Equal(t, []string{JobImport, JobFaces, JobCleanUp}, actions)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		actions, err := ParseJobActions("")
+		assert.NoError(t, err)
+		assert.Empty(t, actions)
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		_, err := ParseJobActions("index,foo")
+		assert.Error(t, err)
+	})
+}
+
+func TestJob_SetPaused(t *testing.T) {
+	m, err := AddJob(form.Job{JobActions: "index", JobSchedule: "@daily"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, m.Scheduled())
+	assert.NoError(t, m.SetPaused(true))
+	assert.False(t, m.Scheduled())
+	assert.True(t, FindJob(m.JobUID).JobPaused)
+	assert.NoError(t, m.SetPaused(false))
+	assert.False(t, FindJob(m.JobUID).JobPaused)
+	assert.NoError(t, m.Delete())
+}
+
+func TestJob_Started(t *testing.T) {
+	m, err := AddJob(form.Job{JobActions: "cleanup"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	run, err := m.Started(JobManual)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, run.Running())
+	assert.Equal(t, JobRunning, FindJob(m.JobUID).JobStatus)
+	assert.NoError(t, m.Finished(run, errors.New("index is busy"), "cleanup: started"))
+	assert.True(t, FindJobRun(run.ID).Failed())
+	assert.Equal(t, JobFailed, FindJob(m.JobUID).JobStatus)
+	assert.Error(t, m.Finished(nil, nil, ""))
+	assert.NoError(t, m.Delete())
+}
+
+func TestJob_PruneRuns(t *testing.T) {
+	m, err := AddJob(form.Job{JobActions: "faces"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		run, startErr := m.Started(JobManual)
+
+		if startErr != nil {
+			t.Fatal(startErr)
+		}
+
+		assert.NoError(t, run.Finish(nil, ""))
+	}
+
+	assert.NoError(t, m.PruneRuns(1))
+
+	var count int
+
+	if err = Db().Model(&JobRun{}).Where("job_uid = ?", m.JobUID).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 1, count)
+	assert.NoError(t, m.Delete())
+}
+
+func TestJob_Report(t *testing.T) {
+	m := JobFixtures.Pointer("nightly")
+	rows, cols := m.Report(true)
+	assert.Equal(t, []string{"Name", "Value"}, cols)
+	assert.NotEmpty(t, rows)
+}
+
+func TestJob_Delete(t *testing.T) {
+	t.Run("EmptyUID", func(t *testing.T) {
+		assert.Error(t, (&Job{}).Delete())
+	})
+}
@@ -1,9 +1,11 @@
 package entity
 
 import (
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/dustin/go-humanize"
 	"github.com/jinzhu/gorm"
 
 	"github.com/photoprism/photoprism/internal/event"
@@ -13,6 +15,15 @@ import (
 	"github.com/photoprism/photoprism/pkg/txt"
 )
 
+// ErrUploadLimitExceeded is returned if an upload would exceed the limits of a share link.
+var ErrUploadLimitExceeded = errors.New("upload limit exceeded")
+
+// Default upload limits of new share links.
+const (
+	DefaultLinkMaxUploads    = 100
+	DefaultLinkMaxUploadSize = 1024 * 1024 * 1024
+)
+
 // LinkPrefix for RefID.
 const (
 	LinkUID    = byte('s')
@@ -23,20 +34,25 @@ type Links []Link
 
 // Link represents a link to share content.
 type Link struct {
-	LinkUID     string    `gorm:"type:VARBINARY(42);primary_key;" json:"UID,omitempty" yaml:"UID,omitempty"`
-	ShareUID    string    `gorm:"type:VARBINARY(42);unique_index:idx_links_uid_token;" json:"ShareUID" yaml:"ShareUID"`
-	ShareSlug   string    `gorm:"type:VARBINARY(160);index;" json:"Slug" yaml:"Slug,omitempty"`
-	LinkToken   string    `gorm:"type:VARBINARY(160);unique_index:idx_links_uid_token;" json:"Token" yaml:"Token,omitempty"`
-	LinkExpires int       `json:"Expires" yaml:"Expires,omitempty"`
-	LinkViews   uint      `json:"Views" yaml:"-"`
-	MaxViews    uint      `json:"MaxViews" yaml:"-"`
-	HasPassword bool      `json:"VerifyPassword" yaml:"VerifyPassword,omitempty"`
-	Comment     string    `gorm:"size:512;" json:"Comment,omitempty" yaml:"Comment,omitempty"`
-	Perm        uint      `json:"Perm,omitempty" yaml:"Perm,omitempty"`
-	RefID       string    `gorm:"type:VARBINARY(16);" json:"-" yaml:"-"`
-	CreatedBy   string    `gorm:"type:VARBINARY(42);index" json:"CreatedBy,omitempty" yaml:"CreatedBy,omitempty"`
-	CreatedAt   time.Time `deepcopier:"skip" json:"CreatedAt" yaml:"CreatedAt"`
-	ModifiedAt  time.Time `deepcopier:"skip" json:"ModifiedAt" yaml:"ModifiedAt"`
+	LinkUID         string    `gorm:"type:VARBINARY(42);primary_key;" json:"UID,omitempty" yaml:"UID,omitempty"`
+	ShareUID        string    `gorm:"type:VARBINARY(42);unique_index:idx_links_uid_token;" json:"ShareUID" yaml:"ShareUID"`
+	ShareSlug       string    `gorm:"type:VARBINARY(160);index;" json:"Slug" yaml:"Slug,omitempty"`
+	LinkToken       string    `gorm:"type:VARBINARY(160);unique_index:idx_links_uid_token;" json:"Token" yaml:"Token,omitempty"`
+	LinkExpires     int       `json:"Expires" yaml:"Expires,omitempty"`
+	LinkViews       uint      `json:"Views" yaml:"-"`
+	MaxViews        uint      `json:"MaxViews" yaml:"-"`
+	HasPassword     bool      `json:"VerifyPassword" yaml:"VerifyPassword,omitempty"`
+	Comment         string    `gorm:"size:512;" json:"Comment,omitempty" yaml:"Comment,omitempty"`
+	Perm            uint      `json:"Perm,omitempty" yaml:"Perm,omitempty"`
+	LinkUploads     uint      `json:"Uploads" yaml:"-"`
+	LinkUploadSize  int64     `json:"UploadSize" yaml:"-"`
+	MaxUploads      uint      `json:"MaxUploads" yaml:"MaxUploads,omitempty"`
+	MaxUploadSize   int64     `json:"MaxUploadSize" yaml:"MaxUploadSize,omitempty"`
+	ModerateUploads bool      `json:"ModerateUploads" yaml:"ModerateUploads,omitempty"`
+	RefID           string    `gorm:"type:VARBINARY(16);" json:"-" yaml:"-"`
+	CreatedBy       string    `gorm:"type:VARBINARY(42);index" json:"CreatedBy,omitempty" yaml:"CreatedBy,omitempty"`
+	CreatedAt       time.Time `deepcopier:"skip" json:"CreatedAt" yaml:"CreatedAt"`
+	ModifiedAt      time.Time `deepcopier:"skip" json:"ModifiedAt" yaml:"ModifiedAt"`
 }
 
 // TableName returns the entity table name.
@@ -68,12 +84,14 @@ func NewUserLink(shareUid, userUid string) Link {
 	now := Now()
 
 	result := Link{
-		LinkUID:    rnd.GenerateUID(LinkUID),
-		ShareUID:   shareUid,
-		LinkToken:  rnd.Base36(10),
-		CreatedBy:  userUid,
-		CreatedAt:  now,
-		ModifiedAt: now,
+		LinkUID:       rnd.GenerateUID(LinkUID),
+		ShareUID:      shareUid,
+		LinkToken:     rnd.Base36(10),
+		MaxUploads:    DefaultLinkMaxUploads,
+		MaxUploadSize: DefaultLinkMaxUploadSize,
+		CreatedBy:     userUid,
+		CreatedAt:     now,
+		ModifiedAt:    now,
 	}
 
 	return result
@@ -115,6 +133,135 @@ func (m *Link) Expired() bool {
 	}
 }
 
+// CanComment checks if visitors may use the share link to comment on shared content.
+func (m *Link) CanComment() bool {
+	return m.Perm&(PermComment|PermAll) != 0 && !m.Expired()
+}
+
+// SetCanComment grants or revokes the permission to comment with the share link.
+func (m *Link) SetCanComment(enabled bool) {
+	if enabled {
+		m.Perm |= PermComment
+	} else {
+		m.Perm &^= PermComment
+	}
+}
+
+// CanUpload checks if visitors may use the share link to upload files.
+func (m *Link) CanUpload() bool {
+	return m.Perm&(PermUpload|PermAll) != 0 && !m.Expired()
+}
+
+// SetCanUpload grants or revokes the permission to upload files with the share link.
+func (m *Link) SetCanUpload(enabled bool) {
+	if enabled {
+		m.Perm |= PermUpload
+	} else {
+		m.Perm &^= PermUpload
+	}
+}
+
+// SetUploadLimits sets the maximum number and size of files that can be uploaded with the share link,
+// keeping the current limits if zero is passed, so that links cannot be used for unlimited uploads.
+func (m *Link) SetUploadLimits(maxUploads uint, maxUploadSize int64) {
+	if maxUploads > 0 {
+		m.MaxUploads = maxUploads
+	}
+
+	if maxUploadSize > 0 {
+		m.MaxUploadSize = maxUploadSize
+	}
+}
+
+// CheckUpload returns ErrUploadLimitExceeded if adding the specified number of files and bytes
+// would exceed the upload limits of the share link.
+func (m *Link) CheckUpload(addSize int64, addFiles int) error {
+	if m.MaxUploads > 0 && m.LinkUploads+uint(addFiles) > m.MaxUploads {
+		return fmt.Errorf("%w: %d of %d files uploaded", ErrUploadLimitExceeded, m.LinkUploads, m.MaxUploads)
+	}
+
+	if m.MaxUploadSize > 0 && m.LinkUploadSize+addSize > m.MaxUploadSize {
+		return fmt.Errorf("%w: %s of %s uploaded", ErrUploadLimitExceeded, humanize.IBytes(uint64(m.LinkUploadSize)), humanize.IBytes(uint64(m.MaxUploadSize)))
+	}
+
+	return nil
+}
+
+// ReserveUploads increments the number and size of files uploaded with the share link in a single
+// conditional update, so that concurrent uploads cannot exceed its limits. It returns
+// ErrUploadLimitExceeded if the limits would be exceeded.
+func (m *Link) ReserveUploads(files int, size int64) error {
+	if files <= 0 {
+		return nil
+	} else if size < 0 {
+		size = 0
+	}
+
+	res := Db().Model(&Link{}).
+		Where("link_uid = ?", m.LinkUID).
+		Where("max_uploads = 0 OR link_uploads + ? <= max_uploads", files).
+		Where("max_upload_size = 0 OR link_upload_size + ? <= max_upload_size", size).
+		UpdateColumns(map[string]interface{}{
+			"link_uploads":     gorm.Expr("link_uploads + ?", files),
+			"link_upload_size": gorm.Expr("link_upload_size + ?", size),
+		})
+
+	if res.Error != nil {
+		return res.Error
+	} else if res.RowsAffected == 0 {
+		// Reload the upload counters to report the current usage.
+		if err := Db().First(m, "link_uid = ?", m.LinkUID).Error; err == nil {
+			if limitErr := m.CheckUpload(size, files); limitErr != nil {
+				return limitErr
+			}
+		}
+
+		return ErrUploadLimitExceeded
+	}
+
+	m.LinkUploads += uint(files)
+	m.LinkUploadSize += size
+
+	return nil
+}
+
+// ReleaseUploads decrements the number and size of files uploaded with the share link,
+// e.g. if reserved files have been rejected or could not be saved.
+func (m *Link) ReleaseUploads(files int, size int64) *Link {
+	if files < 0 {
+		files = 0
+	}
+
+	if size < 0 {
+		size = 0
+	}
+
+	if files == 0 && size == 0 {
+		return m
+	}
+
+	if uint(files) > m.LinkUploads {
+		m.LinkUploads = 0
+	} else {
+		m.LinkUploads -= uint(files)
+	}
+
+	if size > m.LinkUploadSize {
+		m.LinkUploadSize = 0
+	} else {
+		m.LinkUploadSize -= size
+	}
+
+	if err := Db().Model(m).UpdateColumns(map[string]interface{}{
+		"link_uploads":     gorm.Expr("CASE WHEN link_uploads > ? THEN link_uploads - ? ELSE 0 END", files, files),
+		"link_upload_size": gorm.Expr("CASE WHEN link_upload_size > ? THEN link_upload_size - ? ELSE 0 END", size, size),
+	}).Error; err != nil {
+		event.AuditWarn([]string{"link %s", "update uploads", status.Error(err)}, clean.Log(m.RefID))
+	}
+
+	return m
+}
+
 // SetSlug sets the URL slug of the link.
 func (m *Link) SetSlug(s string) {
 	m.ShareSlug = txt.Slug(s)
@@ -67,6 +67,37 @@ var LinkFixtures = LinkMap{
 		CreatedAt:   time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
 		ModifiedAt:  time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
 	},
+	"8jxf3jfn2k": {
+		LinkUID:       "ss62xpryd1ob3gtf",
+		ShareUID:      "as6sg6bxpogaaba9",
+		ShareSlug:     "berlin-2019",
+		LinkToken:     "8jxf3jfn2k",
+		LinkExpires:   0,
+		LinkViews:     0,
+		MaxViews:      0,
+		HasPassword:   false,
+		Perm:          PermView | PermComment | PermUpload,
+		MaxUploads:    10,
+		MaxUploadSize: 10485760,
+		CreatedBy:     "uqxetse3cy5eo9z2",
+		CreatedAt:     time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+		ModifiedAt:    time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+	},
+	"9jxf3jfn2k": {
+		LinkUID:         "ss62xpryd1ob4gtf",
+		ShareUID:        "as6sg6bxpogaaba9",
+		ShareSlug:       "berlin-2019",
+		LinkToken:       "9jxf3jfn2k",
+		LinkExpires:     0,
+		LinkViews:       0,
+		MaxViews:        0,
+		HasPassword:     false,
+		Perm:            PermView | PermUpload,
+		ModerateUploads: true,
+		CreatedBy:       "uqxetse3cy5eo9z2",
+		CreatedAt:       time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+		ModifiedAt:      time.Date(2020, 3, 6, 2, 6, 51, 0, time.UTC),
+	},
 }
 
 // CreateLinkFixtures inserts known entities into the database for testing.
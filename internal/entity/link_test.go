@@ -62,6 +62,129 @@ func TestLink_Redeem(t *testing.T) {
 	assert.Equal(t, uint(2), link.LinkViews)
 }
 
+func TestLink_CanUpload(t *testing.T) {
+	t.Run("Fixture", func(t *testing.T) {
+		link := LinkFixtures["8jxf3jfn2k"]
+		assert.True(t, link.CanUpload())
+	})
+	t.Run("NoPermission", func(t *testing.T) {
+		link := LinkFixtures["1jxf3jfn2k"]
+		assert.False(t, link.CanUpload())
+	})
+	t.Run("Expired", func(t *testing.T) {
+		link := NewLink(rnd.GenerateUID(AlbumUID), false, false)
+		link.SetCanUpload(true)
+		link.MaxViews = 1
+		link.LinkViews = 1
+		assert.False(t, link.CanUpload())
+	})
+}
+
+func TestLink_SetCanUpload(t *testing.T) {
+	link := NewLink(rnd.GenerateUID(AlbumUID), false, false)
+	link.Perm = PermView
+
+	link.SetCanUpload(true)
+	assert.Equal(t, PermView|PermUpload, link.Perm)
+	assert.True(t, link.CanUpload())
+
+	link.SetCanUpload(false)
+	assert.Equal(t, PermView, link.Perm)
+	assert.False(t, link.CanUpload())
+}
+
+func TestLink_CheckUpload(t *testing.T) {
+	link := NewLink(rnd.GenerateUID(AlbumUID), false, false)
+
+	assert.NoError(t, link.CheckUpload(DefaultLinkMaxUploadSize, DefaultLinkMaxUploads))
+	assert.ErrorIs(t, link.CheckUpload(DefaultLinkMaxUploadSize+1, 1), ErrUploadLimitExceeded)
+
+	link.MaxUploads = 0
+	link.MaxUploadSize = 0
+
+	assert.NoError(t, link.CheckUpload(1<<40, 1000))
+
+	link.MaxUploads = 3
+	link.MaxUploadSize = 1000
+	link.LinkUploads = 2
+	link.LinkUploadSize = 500
+
+	assert.NoError(t, link.CheckUpload(500, 1))
+
+	err := link.CheckUpload(100, 2)
+	assert.ErrorIs(t, err, ErrUploadLimitExceeded)
+	assert.Contains(t, err.Error(), "2 of 3 files uploaded")
+
+	err = link.CheckUpload(501, 1)
+	assert.ErrorIs(t, err, ErrUploadLimitExceeded)
+	assert.Contains(t, err.Error(), "500 B of 1000 B uploaded")
+}
+
+func TestLink_SetUploadLimits(t *testing.T) {
+	link := NewLink(rnd.GenerateUID(AlbumUID), false, false)
+
+	assert.Equal(t, uint(DefaultLinkMaxUploads), link.MaxUploads)
+	assert.Equal(t, int64(DefaultLinkMaxUploadSize), link.MaxUploadSize)
+
+	link.SetUploadLimits(5, 2048)
+
+	assert.Equal(t, uint(5), link.MaxUploads)
+	assert.Equal(t, int64(2048), link.MaxUploadSize)
+
+	link.SetUploadLimits(0, 0)
+
+	assert.Equal(t, uint(5), link.MaxUploads)
+	assert.Equal(t, int64(2048), link.MaxUploadSize)
+}
+
+func TestLink_ReserveUploads(t *testing.T) {
+	link := NewLink(rnd.GenerateUID(AlbumUID), false, false)
+	link.SetUploadLimits(3, 4096)
+
+	if err := link.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		if err := link.Delete(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+
+	assert.NoError(t, link.ReserveUploads(2, 2048))
+	assert.NoError(t, link.ReserveUploads(0, 100))
+
+	assert.Equal(t, uint(2), link.LinkUploads)
+	assert.Equal(t, int64(2048), link.LinkUploadSize)
+
+	// Simulate a concurrent upload with an outdated copy of the link.
+	stale := link
+	stale.LinkUploads = 0
+	stale.LinkUploadSize = 0
+
+	err := stale.ReserveUploads(2, 100)
+	assert.ErrorIs(t, err, ErrUploadLimitExceeded)
+	assert.Contains(t, err.Error(), "2 of 3 files uploaded")
+
+	assert.ErrorIs(t, link.ReserveUploads(1, 4096), ErrUploadLimitExceeded)
+
+	link.ReleaseUploads(1, 1024)
+
+	assert.Equal(t, uint(1), link.LinkUploads)
+	assert.Equal(t, int64(1024), link.LinkUploadSize)
+
+	link.ReleaseUploads(5, 5000)
+
+	found := FindLink(link.LinkUID)
+
+	if found == nil {
+		t.Fatal("link not found")
+	}
+
+	assert.Equal(t, uint(0), found.LinkUploads)
+	assert.Equal(t, int64(0), found.LinkUploadSize)
+}
+
 func TestLink_SetSlug(t *testing.T) {
 	link := Link{}
 	assert.Equal(t, "", link.ShareSlug)
@@ -208,3 +331,25 @@ func TestLink_String(t *testing.T) {
 		assert.Equal(t, uid, link.String())
 	})
 }
+
+func TestLink_CanComment(t *testing.T) {
+	t.Run("Allowed", func(t *testing.T) {
+		link := LinkFixtures["8jxf3jfn2k"]
+		assert.True(t, link.CanComment())
+	})
+	t.Run("NoPermission", func(t *testing.T) {
+		link := LinkFixtures["9jxf3jfn2k"]
+		assert.False(t, link.CanComment())
+	})
+}
+
+func TestLink_SetCanComment(t *testing.T) {
+	link := NewLink("as6sg6bxpogaaba9", false, false)
+	link.Perm = PermView
+
+	link.SetCanComment(true)
+	assert.Equal(t, PermView|PermComment, link.Perm)
+
+	link.SetCanComment(false)
+	assert.Equal(t, PermView, link.Perm)
+}
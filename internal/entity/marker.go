@@ -194,11 +194,15 @@ func (m *Marker) SaveForm(frm form.Marker) (changed bool, err error) {
 		changed = true
 	}
 
-	if changed {
-		return true, m.Save()
+	if !changed {
+		return false, nil
+	} else if err = m.Save(); err != nil {
+		return true, err
 	}
 
-	return false, nil
+	queueSearchIndexByMarker("m.marker_uid = ?", m.MarkerUID)
+
+	return true, nil
 }
 
 // HasFace tests if the marker already has the best matching face.
@@ -484,6 +488,8 @@ func (m *Marker) ClearSubject(src string) error {
 		log.Debugf("faces: marker %s resolved ambiguous subjects for face %s", clean.Log(m.MarkerUID), clean.Log(m.face.ID))
 	}
 
+	queueSearchIndexByMarker("m.marker_uid = ?", m.MarkerUID)
+
 	// Clear references.
 	m.MarkerName = ""
 	m.face = nil
@@ -586,6 +592,9 @@ func (m *Marker) RefreshPhotos() error {
 			WHERE m.marker_uid = ? GROUP BY f.photo_id)`,
 			gorm.Expr(Marker{}.TableName()), m.MarkerUID).Error
 	}
+
+	queueSearchIndexByMarker("m.marker_uid = ?", m.MarkerUID)
+
 	return err
 }
 
@@ -0,0 +1 @@
+ALTER TABLE services MODIFY COLUMN acc_key VARBINARY(4096);
\ No newline at end of file
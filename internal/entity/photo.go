@@ -98,6 +98,7 @@ type Photo struct {
 	Albums           []Album       `json:"Albums" yaml:"-"`
 	Files            []File        `yaml:"-"`
 	Labels           []PhotoLabel  `yaml:"-"`
+	Comments         Comments      `gorm:"-" json:"-" yaml:"Comments,omitempty"`
 	CreatedBy        string        `gorm:"type:VARBINARY(42);index" json:"CreatedBy,omitempty" yaml:"CreatedBy,omitempty"`
 	CreatedAt        time.Time     `json:"CreatedAt,omitempty" yaml:"CreatedAt,omitempty"`
 	UpdatedAt        time.Time     `json:"UpdatedAt,omitempty" yaml:"UpdatedAt,omitempty"`
@@ -312,6 +313,12 @@ func (m *Photo) Create() error {
 		return err
 	}
 
+	// Grant the user who added the photo full access.
+	SetPhotoOwner(m.PhotoUID, m.CreatedBy)
+
+	// Update the search index in the metadata worker.
+	QueueSearchIndex(m.ID)
+
 	return nil
 }
 
@@ -328,7 +335,14 @@ func (m *Photo) Save() error {
 		return err
 	}
 
-	return m.ResolvePrimary()
+	if err := m.ResolvePrimary(); err != nil {
+		return err
+	}
+
+	// Update the search index in the metadata worker.
+	QueueSearchIndex(m.ID)
+
+	return nil
 }
 
 // Update a column in the database.
@@ -1187,6 +1201,22 @@ func (m *Photo) DeletePermanently() (files Files, err error) {
 		log.Errorf("index: %s (remove albums)", logErr)
 	}
 
+	if logErr := UnscopedDb().Delete(PhotoEmbedding{}, "photo_id = ?", m.ID).Error; logErr != nil {
+		log.Errorf("index: %s (remove embedding)", logErr)
+	}
+
+	if logErr := UnscopedDb().Delete(PhotoUser{}, "uid = ?", m.PhotoUID).Error; logErr != nil {
+		log.Errorf("index: %s (remove permissions)", logErr)
+	}
+
+	if logErr := DeleteComments(m.PhotoUID); logErr != nil {
+		log.Errorf("index: %s (remove comments)", logErr)
+	}
+
+	if logErr := RemoveFromSearchIndex(m.ID); logErr != nil {
+		log.Errorf("index: %s (remove from search index)", logErr)
+	}
+
 	return files, UnscopedDb().Delete(m).Error
 }
 
@@ -0,0 +1,184 @@
+package entity
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt"
+	"github.com/photoprism/photoprism/pkg/vector"
+)
+
+// PhotoEmbeddings represents a list of photo embeddings.
+type PhotoEmbeddings []PhotoEmbedding
+
+// PhotoEmbedding stores the normalized image embedding of a photo for semantic search.
+// Values are encoded as little-endian float32, which needs a fraction of the space
+// of a JSON encoding and can be decoded quickly when ranking search results.
+type PhotoEmbedding struct {
+	PhotoID        uint      `gorm:"primary_key;auto_increment:false" json:"PhotoID" yaml:"-"`
+	PhotoUID       string    `gorm:"type:VARBINARY(42);index;" json:"PhotoUID" yaml:"PhotoUID"`
+	EmbeddingModel string    `gorm:"type:VARBINARY(255);index;" json:"Model" yaml:"Model"`
+	EmbeddingDim   int       `json:"Dim" yaml:"Dim"`
+	EmbeddingData  []byte    `gorm:"type:MEDIUMBLOB;" json:"-" yaml:"-"`
+	CreatedAt      time.Time `json:"CreatedAt" yaml:"-"`
+	UpdatedAt      time.Time `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns the entity table name.
+func (PhotoEmbedding) TableName() string {
+	return "photos_embeddings"
+}
+
+// NewPhotoEmbedding returns a new photo embedding with normalized values.
+func NewPhotoEmbedding(photo *Photo, modelName string, values vector.Vector) *PhotoEmbedding {
+	m := &PhotoEmbedding{
+		EmbeddingModel: clean.TypeLower(modelName),
+	}
+
+	if photo != nil {
+		m.PhotoID = photo.ID
+		m.PhotoUID = photo.PhotoUID
+	}
+
+	m.SetVector(values)
+
+	return m
+}
+
+// FindPhotoEmbedding returns the embedding of the specified photo, if any.
+func FindPhotoEmbedding(photoId uint) (*PhotoEmbedding, error) {
+	m := &PhotoEmbedding{}
+
+	if photoId == 0 {
+		return m, fmt.Errorf("photo id must not be empty")
+	}
+
+	if err := UnscopedDb().Where("photo_id = ?", photoId).First(m).Error; err != nil {
+		return m, err
+	}
+
+	return m, nil
+}
+
+// SetVector normalizes and encodes the embedding values.
+func (m *PhotoEmbedding) SetVector(values vector.Vector) {
+	values = values.Normalize()
+
+	data := make([]byte, 4*len(values))
+
+	for i, v := range values {
+		binary.LittleEndian.PutUint32(data[i*4:], math.Float32bits(float32(v)))
+	}
+
+	m.EmbeddingDim = len(values)
+	m.EmbeddingData = data
+}
+
+// Vector returns the decoded embedding values.
+func (m *PhotoEmbedding) Vector() vector.Vector {
+	if m == nil || len(m.EmbeddingData) < 4 {
+		return vector.Vector{}
+	}
+
+	n := len(m.EmbeddingData) / 4
+	result := make(vector.Vector, n)
+
+	for i := range result {
+		result[i] = float64(math.Float32frombits(binary.LittleEndian.Uint32(m.EmbeddingData[i*4:])))
+	}
+
+	return result
+}
+
+// Similarity returns the cosine similarity with the specified normalized vector,
+// or -1 if the number of dimensions does not match.
+func (m *PhotoEmbedding) Similarity(v vector.Vector) float64 {
+	if m == nil || m.EmbeddingDim != len(v) || len(m.EmbeddingData) != 4*len(v) {
+		return -1
+	}
+
+	var sum float64
+
+	for i := range v {
+		sum += v[i] * float64(math.Float32frombits(binary.LittleEndian.Uint32(m.EmbeddingData[i*4:])))
+	}
+
+	return sum
+}
+
+// Save updates the record in the database or inserts a new record if it does not already exist.
+func (m *PhotoEmbedding) Save() error {
+	if m.PhotoID == 0 {
+		return fmt.Errorf("embedding: photo id must not be empty (save)")
+	} else if m.EmbeddingDim == 0 {
+		return fmt.Errorf("embedding: values must not be empty (save)")
+	}
+
+	return UnscopedDb().Save(m).Error
+}
+
+// Delete removes the embedding from the database.
+func (m *PhotoEmbedding) Delete() error {
+	if m.PhotoID == 0 {
+		return fmt.Errorf("embedding: photo id must not be empty (delete)")
+	}
+
+	return UnscopedDb().Delete(PhotoEmbedding{}, "photo_id = ?", m.PhotoID).Error
+}
+
+// ShouldGenerateEmbedding checks if an embedding should be generated with the specified model.
+func (m *Photo) ShouldGenerateEmbedding(modelName string, force bool) bool {
+	if m == nil || m.ID == 0 {
+		return false
+	} else if force {
+		return true
+	}
+
+	existing, err := FindPhotoEmbedding(m.ID)
+
+	return err != nil || existing.EmbeddingModel != clean.TypeLower(modelName)
+}
+
+// EmbeddingText returns a text description of the photo for text embedding models,
+// which cannot process image data.
+func (m *Photo) EmbeddingText() string {
+	if m == nil {
+		return ""
+	}
+
+	parts := make([]string, 0, 5)
+
+	if title := strings.TrimSpace(m.PhotoTitle); title != "" {
+		parts = append(parts, title)
+	}
+
+	if caption := strings.TrimSpace(m.PhotoCaption); caption != "" {
+		parts = append(parts, caption)
+	}
+
+	labels := make([]string, 0, len(m.Labels))
+
+	for _, l := range m.Labels {
+		if l.Uncertainty < 100 && l.Label != nil && l.Label.LabelName != "" {
+			labels = append(labels, l.Label.LabelName)
+		}
+	}
+
+	if len(labels) > 0 {
+		parts = append(parts, strings.Join(labels, ", "))
+	}
+
+	if m.Details != nil && m.Details.Keywords != "" {
+		parts = append(parts, m.Details.Keywords)
+	}
+
+	if m.Place != nil && m.Place.PlaceLabel != "" && m.PlaceID != UnknownPlace.ID {
+		parts = append(parts, m.Place.PlaceLabel)
+	}
+
+	return txt.Clip(strings.Join(parts, ". "), txt.ClipText)
+}
@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"github.com/photoprism/photoprism/pkg/vector"
+)
+
+type PhotoEmbeddingMap map[string]PhotoEmbedding
+
+func (m PhotoEmbeddingMap) Get(name string) PhotoEmbedding {
+	if result, ok := m[name]; ok {
+		return result
+	}
+
+	return PhotoEmbedding{}
+}
+
+func (m PhotoEmbeddingMap) Pointer(name string) *PhotoEmbedding {
+	if result, ok := m[name]; ok {
+		return &result
+	}
+
+	return &PhotoEmbedding{}
+}
+
+// PhotoEmbeddingTestModel is the embedding model name used by the test fixtures.
+const PhotoEmbeddingTestModel = "test-embedding"
+
+var PhotoEmbeddingFixtures = PhotoEmbeddingMap{
+	"lake":       *NewPhotoEmbedding(PhotoFixtures.Pointer("19800101_000002_D640C559"), PhotoEmbeddingTestModel, vector.Vector{0.9, 0.1, 0.1}),
+	"photo01":    *NewPhotoEmbedding(PhotoFixtures.Pointer("Photo01"), PhotoEmbeddingTestModel, vector.Vector{0.7, 0.7, 0.1}),
+	"photo04":    *NewPhotoEmbedding(PhotoFixtures.Pointer("Photo04"), PhotoEmbeddingTestModel, vector.Vector{0.1, 0.2, 0.9}),
+	"otherModel": *NewPhotoEmbedding(PhotoFixtures.Pointer("Photo02"), "other-embedding", vector.Vector{0.9, 0.1}),
+}
+
+// CreatePhotoEmbeddingFixtures inserts known entities into the database for testing.
+func CreatePhotoEmbeddingFixtures() {
+	for _, entity := range PhotoEmbeddingFixtures {
+		Db().Create(&entity)
+	}
+}
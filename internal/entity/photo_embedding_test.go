@@ -0,0 +1,115 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/vector"
+)
+
+func TestPhotoEmbedding_TableName(t *testing.T) {
+	assert.Equal(t, "photos_embeddings", PhotoEmbedding{}.TableName())
+}
+
+func TestNewPhotoEmbedding(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		photo := PhotoFixtures.Pointer("Photo01")
+		m := NewPhotoEmbedding(photo, "CLIP-ViT", vector.Vector{3, 4})
+
+		assert.Equal(t, photo.ID, m.PhotoID)
+		assert.Equal(t, photo.PhotoUID, m.PhotoUID)
+		assert.Equal(t, "clip-vit", m.EmbeddingModel)
+		assert.Equal(t, 2, m.EmbeddingDim)
+		assert.Len(t, m.EmbeddingData, 8)
+
+		v := m.Vector()
+
+		assert.InDelta(t, 0.6, v[0], 0.0001)
+		assert.InDelta(t, 0.8, v[1], 0.0001)
+	})
+	t.Run("NilPhoto", func(t *testing.T) {
+		m := NewPhotoEmbedding(nil, "clip", vector.Vector{1, 0})
+
+		assert.Equal(t, uint(0), m.PhotoID)
+		assert.Error(t, m.Save())
+	})
+}
+
+func TestPhotoEmbedding_Similarity(t *testing.T) {
+	m := NewPhotoEmbedding(nil, "clip", vector.Vector{1, 1})
+
+	assert.InDelta(t, 1.0, m.Similarity(vector.Vector{1, 1}.Normalize()), 0.0001)
+	assert.InDelta(t, 0.7071, m.Similarity(vector.Vector{1, 0}), 0.0001)
+	assert.Equal(t, float64(-1), m.Similarity(vector.Vector{1, 0, 0}))
+	assert.Equal(t, float64(-1), (*PhotoEmbedding)(nil).Similarity(vector.Vector{1, 0}))
+}
+
+func TestPhotoEmbedding_Save(t *testing.T) {
+	t.Run("CreateUpdateDelete", func(t *testing.T) {
+		photo := PhotoFixtures.Pointer("Photo05")
+		m := NewPhotoEmbedding(photo, "clip", vector.Vector{0, 1, 0})
+
+		assert.NoError(t, m.Save())
+
+		found, err := FindPhotoEmbedding(photo.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, found.EmbeddingDim)
+		assert.InDelta(t, 1.0, found.Vector()[1], 0.0001)
+
+		m.SetVector(vector.Vector{1, 0})
+		assert.NoError(t, m.Save())
+
+		found, err = FindPhotoEmbedding(photo.ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 2, found.EmbeddingDim)
+
+		assert.NoError(t, m.Delete())
+
+		_, err = FindPhotoEmbedding(photo.ID)
+		assert.Error(t, err)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		m := &PhotoEmbedding{PhotoID: 1}
+		assert.Error(t, m.Save())
+	})
+}
+
+func TestFindPhotoEmbedding(t *testing.T) {
+	t.Run("Fixture", func(t *testing.T) {
+		m, err := FindPhotoEmbedding(PhotoFixtures.Get("Photo01").ID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, PhotoEmbeddingTestModel, m.EmbeddingModel)
+		assert.Equal(t, 3, m.EmbeddingDim)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := FindPhotoEmbedding(123456789)
+		assert.Error(t, err)
+	})
+	t.Run("InvalidID", func(t *testing.T) {
+		_, err := FindPhotoEmbedding(0)
+		assert.Error(t, err)
+	})
+}
+
+func TestPhoto_EmbeddingText(t *testing.T) {
+	t.Run("Nil", func(t *testing.T) {
+		assert.Equal(t, "", (*Photo)(nil).EmbeddingText())
+	})
+	t.Run("TitleCaptionLabels", func(t *testing.T) {
+		m := &Photo{
+			PhotoTitle:   "Lake",
+			PhotoCaption: "A frog in the lake",
+			Labels: []PhotoLabel{
+				{Uncertainty: 20, Label: &Label{LabelName: "Frog"}},
+				{Uncertainty: 100, Label: &Label{LabelName: "Ignored"}},
+			},
+			Details: &Details{Keywords: "nature, water"},
+		}
+
+		assert.Equal(t, "Lake. A frog in the lake. Frog. nature, water", m.EmbeddingText())
+	})
+}
@@ -30,15 +30,17 @@ func (m *PhotoKeyword) Create() error {
 	return Db().Create(m).Error
 }
 
-// AfterCreate flushes the keyword cache once a relation has been persisted.
+// AfterCreate flushes the keyword cache and queues a search index update once a relation has been persisted.
 func (m *PhotoKeyword) AfterCreate(scope *gorm.Scope) error {
 	FlushCachedPhotoKeyword(m)
+	queueSearchIndex(scope.DB(), m.PhotoID)
 	return nil
 }
 
-// AfterUpdate flushes the keyword cache after a relation change.
+// AfterUpdate flushes the keyword cache and queues a search index update after a relation change.
 func (m *PhotoKeyword) AfterUpdate(tx *gorm.DB) (err error) {
 	FlushCachedPhotoKeyword(m)
+	queueSearchIndex(tx, m.PhotoID)
 	return
 }
 
@@ -48,9 +50,10 @@ func (m *PhotoKeyword) Delete() error {
 	return Db().Delete(m).Error
 }
 
-// AfterDelete flushes the keyword cache when the photo-keyword relation is removed.
+// AfterDelete flushes the keyword cache and queues a search index update when the photo-keyword relation is removed.
 func (m *PhotoKeyword) AfterDelete(tx *gorm.DB) (err error) {
 	FlushCachedPhotoKeyword(m)
+	queueSearchIndex(tx, m.PhotoID)
 	return
 }
 
@@ -41,7 +41,7 @@ func NewPhotoLabel(photoID, labelID uint, uncertainty int, source string) *Photo
 	return result
 }
 
-// Updates mutates multiple columns in the database and clears cached copies.
+// Updates mutates multiple columns in the database, clears cached copies, and queues a search index update.
 func (m *PhotoLabel) Updates(values interface{}) error {
 	if m == nil {
 		return errors.New("photo label must not be nil - you may have found a bug")
@@ -54,10 +54,11 @@ func (m *PhotoLabel) Updates(values interface{}) error {
 	}
 
 	FlushCachedPhotoLabel(m)
+	QueueSearchIndex(m.PhotoID)
 	return nil
 }
 
-// Update mutates a single column in the database and clears cached copies.
+// Update mutates a single column in the database, clears cached copies, and queues a search index update.
 func (m *PhotoLabel) Update(attr string, value interface{}) error {
 	if m == nil {
 		return errors.New("photo label must not be nil - you may have found a bug")
@@ -70,6 +71,7 @@ func (m *PhotoLabel) Update(attr string, value interface{}) error {
 	}
 
 	FlushCachedPhotoLabel(m)
+	QueueSearchIndex(m.PhotoID)
 	return nil
 }
 
@@ -95,15 +97,17 @@ func (m *PhotoLabel) Create() error {
 	return Db().Create(m).Error
 }
 
-// AfterCreate flushes the label cache once a relation has been persisted.
+// AfterCreate flushes the label cache and queues a search index update once a relation has been persisted.
 func (m *PhotoLabel) AfterCreate(scope *gorm.Scope) error {
 	FlushCachedPhotoLabel(m)
+	queueSearchIndex(scope.DB(), m.PhotoID)
 	return nil
 }
 
-// AfterUpdate flushes the label cache after a relation change.
+// AfterUpdate flushes the label cache and queues a search index update after a relation change.
 func (m *PhotoLabel) AfterUpdate(tx *gorm.DB) (err error) {
 	FlushCachedPhotoLabel(m)
+	queueSearchIndex(tx, m.PhotoID)
 	return
 }
 
@@ -113,9 +117,10 @@ func (m *PhotoLabel) Delete() error {
 	return Db().Delete(m).Error
 }
 
-// AfterDelete flushes the label cache when a label is deleted.
+// AfterDelete flushes the label cache and queues a search index update when a label is deleted.
 func (m *PhotoLabel) AfterDelete(tx *gorm.DB) (err error) {
 	FlushCachedPhotoLabel(m)
+	queueSearchIndex(tx, m.PhotoID)
 	return
 }
 
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"fmt"
 	"sync"
 
 	"github.com/jinzhu/gorm"
@@ -102,26 +103,7 @@ func (m *Photo) Merge(mergeMeta, mergeUuid bool) (original Photo, merged Photos,
 			continue
 		}
 
-		deleted := Now()
-
-		logResult(UnscopedDb().Exec("UPDATE files SET photo_id = ?, photo_uid = ?, file_primary = 0 WHERE photo_id = ?", original.ID, original.PhotoUID, merge.ID))
-		logResult(UnscopedDb().Exec("UPDATE photos SET photo_quality = -1, deleted_at = ? WHERE id = ?", Now(), merge.ID))
-
-		switch DbDialect() {
-		case MySQL:
-			logResult(UnscopedDb().Exec("UPDATE IGNORE photos_keywords SET photo_id = ? WHERE photo_id = ?", original.ID, merge.ID))
-			logResult(UnscopedDb().Exec("UPDATE IGNORE photos_labels SET photo_id = ? WHERE photo_id = ?", original.ID, merge.ID))
-			logResult(UnscopedDb().Exec("UPDATE IGNORE photos_albums SET photo_uid = ? WHERE photo_uid = ?", original.PhotoUID, merge.PhotoUID))
-		case SQLite3:
-			logResult(UnscopedDb().Exec("UPDATE OR IGNORE photos_keywords SET photo_id = ? WHERE photo_id = ?", original.ID, merge.ID))
-			logResult(UnscopedDb().Exec("UPDATE OR IGNORE photos_labels SET photo_id = ? WHERE photo_id = ?", original.ID, merge.ID))
-			logResult(UnscopedDb().Exec("UPDATE OR IGNORE photos_albums SET photo_uid = ? WHERE photo_uid = ?", original.PhotoUID, merge.PhotoUID))
-		default:
-			log.Warnf("sql: unsupported dialect %s", DbDialect())
-		}
-
-		merge.DeletedAt = &deleted
-		merge.PhotoQuality = -1
+		mergePhoto(&original, merge, logResult)
 
 		merged = append(merged, merge)
 	}
@@ -136,3 +118,63 @@ func (m *Photo) Merge(mergeMeta, mergeUuid bool) (original Photo, merged Photos,
 
 	return original, merged, err
 }
+
+// Stack merges the other photos into this photo, e.g. to resolve visually similar pictures,
+// reassigning their files and associations and marking them deleted.
+func (m *Photo) Stack(others Photos) (merged Photos, err error) {
+	if !m.HasID() {
+		return merged, fmt.Errorf("photo has no id")
+	}
+
+	photoMergeMutex.Lock()
+	defer photoMergeMutex.Unlock()
+
+	logResult := func(res *gorm.DB) {
+		if res.Error != nil {
+			log.Errorf("merge: %s", res.Error.Error())
+			err = res.Error
+		}
+	}
+
+	for _, other := range others {
+		if other == nil || !other.HasID() || other.ID == m.ID {
+			continue
+		}
+
+		log.Debugf("photo: stacking id %d with %d", m.ID, other.ID)
+
+		mergePhoto(m, other, logResult)
+
+		merged = append(merged, other)
+	}
+
+	if len(merged) > 0 {
+		File{PhotoID: m.ID, PhotoUID: m.PhotoUID}.RegenerateIndex()
+	}
+
+	return merged, err
+}
+
+// mergePhoto moves the files and associations of a photo to the original and marks it deleted.
+func mergePhoto(original, merge *Photo, logResult func(res *gorm.DB)) {
+	deleted := Now()
+
+	logResult(UnscopedDb().Exec("UPDATE files SET photo_id = ?, photo_uid = ?, file_primary = 0 WHERE photo_id = ?", original.ID, original.PhotoUID, merge.ID))
+	logResult(UnscopedDb().Exec("UPDATE photos SET photo_quality = -1, deleted_at = ? WHERE id = ?", Now(), merge.ID))
+
+	switch DbDialect() {
+	case MySQL:
+		logResult(UnscopedDb().Exec("UPDATE IGNORE photos_keywords SET photo_id = ? WHERE photo_id = ?", original.ID, merge.ID))
+		logResult(UnscopedDb().Exec("UPDATE IGNORE photos_labels SET photo_id = ? WHERE photo_id = ?", original.ID, merge.ID))
+		logResult(UnscopedDb().Exec("UPDATE IGNORE photos_albums SET photo_uid = ? WHERE photo_uid = ?", original.PhotoUID, merge.PhotoUID))
+	case SQLite3:
+		logResult(UnscopedDb().Exec("UPDATE OR IGNORE photos_keywords SET photo_id = ? WHERE photo_id = ?", original.ID, merge.ID))
+		logResult(UnscopedDb().Exec("UPDATE OR IGNORE photos_labels SET photo_id = ? WHERE photo_id = ?", original.ID, merge.ID))
+		logResult(UnscopedDb().Exec("UPDATE OR IGNORE photos_albums SET photo_uid = ? WHERE photo_uid = ?", original.PhotoUID, merge.PhotoUID))
+	default:
+		log.Warnf("sql: unsupported dialect %s", DbDialect())
+	}
+
+	merge.DeletedAt = &deleted
+	merge.PhotoQuality = -1
+}
@@ -94,3 +94,47 @@ func TestPhoto_Merge(t *testing.T) {
 		assert.Equal(t, 1000024, int(merged[0].ID))
 	})
 }
+
+func TestPhoto_Stack(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		original := &Photo{PhotoName: "StackOriginal", PhotoPath: "2024/06", TakenAt: Now(), TakenSrc: SrcMeta}
+		similar := &Photo{PhotoName: "StackSimilar", PhotoPath: "2024/06", TakenAt: Now(), TakenSrc: SrcMeta}
+
+		if err := original.Create(); err != nil {
+			t.Fatal(err)
+		} else if err = similar.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		file := &File{PhotoID: similar.ID, PhotoUID: similar.PhotoUID, FileName: "2024/06/StackSimilar.jpg", FileRoot: RootOriginals, FilePrimary: true}
+
+		if err := file.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		merged, err := original.Stack(Photos{similar, original, nil})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, merged, 1)
+		assert.NotNil(t, similar.DeletedAt)
+		assert.Equal(t, -1, similar.PhotoQuality)
+
+		var found File
+
+		if err = Db().Where("file_uid = ?", file.FileUID).First(&found).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, original.ID, found.PhotoID)
+		assert.Equal(t, original.PhotoUID, found.PhotoUID)
+		assert.False(t, found.FilePrimary)
+	})
+	t.Run("NoID", func(t *testing.T) {
+		photo := &Photo{}
+		_, err := photo.Stack(Photos{})
+		assert.Error(t, err)
+	})
+}
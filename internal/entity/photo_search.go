@@ -0,0 +1,478 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Full-text search engines used for the photo search index, depending on the database.
+const (
+	SearchIndexNone     = ""
+	SearchIndexFts5     = "fts5"
+	SearchIndexFts4     = "fts4"
+	SearchIndexFulltext = "fulltext"
+)
+
+var (
+	searchIndexMutex   = sync.Mutex{}
+	searchIndexRebuild = sync.Mutex{}
+	searchIndexEngine  *string
+)
+
+// searchIndexColumns contains the indexed text columns in the order in which they are ranked.
+var searchIndexColumns = []string{"search_title", "search_caption", "search_keywords", "search_labels", "search_subjects", "search_places", "search_files"}
+
+// searchIndexWeights contains the FTS5 ranking weights of the photo_uid and indexed text columns.
+const searchIndexWeights = "0.0, 10.0, 5.0, 4.0, 4.0, 3.0, 2.0, 1.0"
+
+// PhotoSearch represents a row in the full-text search index for photo metadata. The index is a
+// virtual FTS5 (or FTS4) table on SQLite and a table with a FULLTEXT index on MariaDB/MySQL, so
+// it is created and queried with raw SQL rather than through the ORM.
+//
+// Field Descriptions:
+// - Caption contains the caption, subject, and notes of a photo.
+// - Places contains the place label, district, city, state, and country name.
+// - Files contains the file and original names, so that matching paths can be found.
+type PhotoSearch struct {
+	PhotoID  uint
+	PhotoUID string
+	Title    string
+	Caption  string
+	Keywords string
+	Labels   string
+	Subjects string
+	Places   string
+	Files    string
+}
+
+// TableName returns the entity table name.
+func (PhotoSearch) TableName() string {
+	return "photos_search"
+}
+
+// CreateSearchIndex creates the full-text search index table if it does not exist yet
+// and returns the search engine used.
+func CreateSearchIndex(db *gorm.DB) (engine string, err error) {
+	searchIndexMutex.Lock()
+	defer searchIndexMutex.Unlock()
+
+	table := PhotoSearch{}.TableName()
+	columns := strings.Join(searchIndexColumns, ", ")
+
+	switch db.Dialect().GetName() {
+	case MySQL:
+		textColumns := make([]string, len(searchIndexColumns))
+
+		for i, col := range searchIndexColumns {
+			textColumns[i] = col + " TEXT"
+		}
+
+		err = db.Exec(fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (photo_id INT UNSIGNED NOT NULL PRIMARY KEY, photo_uid VARBINARY(42), %s, "+
+			"FULLTEXT INDEX idx_photos_search_text (%s)) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci",
+			table, strings.Join(textColumns, ", "), columns)).Error
+		engine = SearchIndexFulltext
+	case SQLite3:
+		// FTS5 requires SQLite to be compiled with the "sqlite_fts5" build tag, so FTS4 is used as fallback.
+		if err = db.Exec(fmt.Sprintf("CREATE VIRTUAL TABLE IF NOT EXISTS %s USING fts5(photo_uid UNINDEXED, %s, tokenize = 'unicode61 remove_diacritics 2')",
+			table, columns)).Error; err == nil {
+			engine = SearchIndexFts5
+		} else if err = db.Exec(fmt.Sprintf("CREATE VIRTUAL TABLE IF NOT EXISTS %s USING fts4(photo_uid, %s, notindexed=photo_uid, tokenize=unicode61)",
+			table, columns)).Error; err == nil {
+			engine = SearchIndexFts4
+		}
+	default:
+		return SearchIndexNone, fmt.Errorf("unsupported dialect %s", db.Dialect().GetName())
+	}
+
+	if err != nil {
+		return SearchIndexNone, err
+	}
+
+	// Detect the engine of an existing table, e.g. one that was created with FTS4 before.
+	searchIndexEngine = nil
+
+	return detectSearchIndex(db), nil
+}
+
+// DropSearchIndex drops the full-text search index table.
+func DropSearchIndex(db *gorm.DB) error {
+	searchIndexMutex.Lock()
+	defer searchIndexMutex.Unlock()
+
+	searchIndexEngine = nil
+
+	return db.Exec(fmt.Sprintf("DROP TABLE IF EXISTS %s", PhotoSearch{}.TableName())).Error
+}
+
+// SearchIndexEngine returns the full-text search engine used for the photo search index,
+// or an empty string if the index does not exist.
+func SearchIndexEngine() string {
+	searchIndexMutex.Lock()
+	defer searchIndexMutex.Unlock()
+
+	if searchIndexEngine != nil {
+		return *searchIndexEngine
+	}
+
+	return detectSearchIndex(Db())
+}
+
+// detectSearchIndex detects and caches the full-text search engine, the mutex must be locked.
+func detectSearchIndex(db *gorm.DB) string {
+	engine := SearchIndexNone
+	table := PhotoSearch{}.TableName()
+
+	switch db.Dialect().GetName() {
+	case MySQL:
+		if db.Dialect().HasTable(table) {
+			engine = SearchIndexFulltext
+		}
+	case SQLite3:
+		var stmt []string
+
+		if err := db.Raw("SELECT sql FROM sqlite_master WHERE type = 'table' AND name = ?", table).Pluck("sql", &stmt).Error; err != nil || len(stmt) == 0 {
+			break
+		} else if s := strings.ToLower(stmt[0]); strings.Contains(s, "using fts5") {
+			engine = SearchIndexFts5
+		} else if strings.Contains(s, "using fts4") {
+			engine = SearchIndexFts4
+		}
+	}
+
+	searchIndexEngine = &engine
+
+	return engine
+}
+
+// IsSearchIndexTable checks if the table belongs to the full-text search index, including the update queue and
+// the shadow tables that SQLite creates for virtual tables. These tables do not need to be backed up, as they
+// can be rebuilt.
+func IsSearchIndexTable(name string) bool {
+	table := PhotoSearch{}.TableName()
+	return name == table || strings.HasPrefix(name, table+"_")
+}
+
+// searchIndexKey returns the name of the column that contains the photo id.
+func searchIndexKey(engine string) string {
+	switch engine {
+	case SearchIndexFulltext:
+		return "photo_id"
+	default:
+		return "rowid"
+	}
+}
+
+// NewPhotoSearch returns the search index values of the specified photo.
+func NewPhotoSearch(m *Photo) *PhotoSearch {
+	result := &PhotoSearch{
+		PhotoID:  m.ID,
+		PhotoUID: m.PhotoUID,
+		Title:    m.PhotoTitle,
+	}
+
+	if m.ID == 0 {
+		return result
+	}
+
+	// Add caption, subject, notes, and keywords.
+	caption := []string{m.PhotoCaption}
+
+	details := m.Details
+
+	if details == nil {
+		details = &Details{}
+		Db().Where("photo_id = ?", m.ID).First(details)
+	}
+
+	caption = append(caption, details.Subject, details.Notes)
+	keywords := []string{details.Keywords}
+
+	var words []string
+
+	Db().Table("keywords").Joins("JOIN photos_keywords pk ON pk.keyword_id = keywords.id").
+		Where("pk.photo_id = ?", m.ID).Pluck("keywords.keyword", &words)
+
+	result.Caption = joinSearchText(caption)
+	result.Keywords = joinSearchText(append(keywords, words...))
+
+	// Add labels.
+	var labels []string
+
+	Db().Table("labels").Joins("JOIN photos_labels pl ON pl.label_id = labels.id").
+		Where("pl.photo_id = ? AND pl.uncertainty < 100 AND labels.deleted_at IS NULL", m.ID).
+		Pluck("labels.label_name", &labels)
+
+	result.Labels = joinSearchText(labels)
+
+	// Add the names of people.
+	var subjects []string
+
+	Db().Table("subjects").Joins("JOIN markers ON markers.subj_uid = subjects.subj_uid AND markers.marker_invalid = 0").
+		Joins("JOIN files ON files.file_uid = markers.file_uid").
+		Where("files.photo_id = ? AND subjects.deleted_at IS NULL", m.ID).
+		Pluck("subjects.subj_name", &subjects)
+
+	result.Subjects = joinSearchText(subjects)
+
+	// Add place names.
+	var places []string
+
+	if m.PlaceID != "" && m.PlaceID != UnknownPlace.ID {
+		place := m.Place
+
+		if place == nil {
+			place = &Place{}
+			Db().Where("id = ?", m.PlaceID).First(place)
+		}
+
+		places = append(places, place.PlaceLabel, place.PlaceDistrict, place.PlaceCity, place.PlaceState)
+	}
+
+	if country := m.CountryName(); country != UnknownCountry.CountryName {
+		places = append(places, country)
+	}
+
+	result.Places = joinSearchText(places)
+
+	// Add file names.
+	files := []string{m.PhotoPath, m.PhotoName, m.OriginalName}
+
+	var fileNames []string
+
+	Db().Model(&File{}).Where("photo_id = ? AND deleted_at IS NULL", m.ID).Pluck("file_name", &fileNames)
+
+	result.Files = joinSearchText(append(files, fileNames...))
+
+	return result
+}
+
+// joinSearchText returns the unique non-empty values as space separated string.
+func joinSearchText(values []string) string {
+	result := make([]string, 0, len(values))
+	seen := make(map[string]bool, len(values))
+
+	for _, s := range values {
+		if s = strings.TrimSpace(s); s == "" || seen[s] {
+			continue
+		}
+
+		seen[s] = true
+		result = append(result, s)
+	}
+
+	return txt.Clip(strings.Join(result, " "), txt.ClipText)
+}
+
+// Save adds the values to the search index or replaces the existing row.
+func (m *PhotoSearch) Save() error {
+	if m.PhotoID == 0 {
+		return fmt.Errorf("photo id is missing")
+	}
+
+	engine := SearchIndexEngine()
+
+	if engine == SearchIndexNone {
+		return fmt.Errorf("search index not found")
+	}
+
+	table := m.TableName()
+	key := searchIndexKey(engine)
+	values := []interface{}{m.PhotoID, m.PhotoUID, m.Title, m.Caption, m.Keywords, m.Labels, m.Subjects, m.Places, m.Files}
+
+	if err := Db().Exec(fmt.Sprintf("DELETE FROM %s WHERE %s = ?", table, key), m.PhotoID).Error; err != nil {
+		return err
+	}
+
+	return Db().Exec(fmt.Sprintf("INSERT INTO %s (%s, photo_uid, %s) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		table, key, strings.Join(searchIndexColumns, ", ")), values...).Error
+}
+
+// RemoveFromSearchIndex removes the photo with the specified id from the search index.
+func RemoveFromSearchIndex(photoId uint) error {
+	engine := SearchIndexEngine()
+
+	if engine == SearchIndexNone || photoId == 0 {
+		return nil
+	}
+
+	return Db().Exec(fmt.Sprintf("DELETE FROM %s WHERE %s = ?", PhotoSearch{}.TableName(), searchIndexKey(engine)), photoId).Error
+}
+
+// RebuildSearchIndex recreates the search index values of all photos and returns the number of indexed photos.
+func RebuildSearchIndex() (indexed int, err error) {
+	searchIndexRebuild.Lock()
+	defer searchIndexRebuild.Unlock()
+
+	start := time.Now()
+
+	engine := SearchIndexEngine()
+
+	if engine == SearchIndexNone {
+		if engine, err = CreateSearchIndex(Db()); err != nil {
+			return 0, err
+		}
+	}
+
+	if err = Db().Exec(fmt.Sprintf("DELETE FROM %s", PhotoSearch{}.TableName())).Error; err != nil {
+		return 0, err
+	}
+
+	// Photos that change from now on must be queued again.
+	searchIndexQueued.Lock()
+	searchIndexQueued.ids = make(map[uint]struct{})
+	started := Now()
+	searchIndexQueued.Unlock()
+
+	batchSize := 500
+
+	// Archived photos are indexed as well, so that they can be found in the archive.
+	for offset := 0; ; offset += batchSize {
+		var photos Photos
+
+		if err = UnscopedDb().Order("id").
+			Offset(offset).Limit(batchSize).Find(&photos).Error; err != nil {
+			return indexed, err
+		} else if len(photos) == 0 {
+			break
+		}
+
+		for i := range photos {
+			if err = NewPhotoSearch(photos[i]).Save(); err != nil {
+				return indexed, err
+			}
+
+			indexed++
+		}
+	}
+
+	// Remove queued photos that have been indexed.
+	if err = UnscopedDb().Where("queued_at < ?", started).Delete(&PhotoSearchQueue{}).Error; err != nil {
+		return indexed, err
+	}
+
+	log.Debugf("search: rebuilt %s index with %d photos [%s]", engine, indexed, time.Since(start))
+
+	return indexed, nil
+}
+
+// InitSearchIndex rebuilds the full-text search index in the background if the number of indexed photos does not
+// match, e.g. after upgrading or restoring a backup, and otherwise updates the photos that are still queued.
+func InitSearchIndex() {
+	if SearchIndexEngine() == SearchIndexNone {
+		return
+	}
+
+	var indexed, photos int
+
+	if err := Db().Table(PhotoSearch{}.TableName()).Count(&indexed).Error; err != nil {
+		log.Warnf("search: %s (count indexed photos)", err)
+		return
+	} else if err = UnscopedDb().Model(&Photo{}).Count(&photos).Error; err != nil {
+		log.Warnf("search: %s (count photos)", err)
+		return
+	} else if indexed == photos {
+		if n, updateErr := UpdateQueuedSearchIndex(); updateErr != nil {
+			log.Warnf("search: %s (update index)", updateErr)
+		} else if n > 0 {
+			log.Debugf("search: updated index of %d queued photos", n)
+		}
+
+		return
+	}
+
+	log.Infof("search: indexing %d photos", photos)
+
+	go func() {
+		if _, err := RebuildSearchIndex(); err != nil {
+			log.Errorf("search: %s (rebuild index)", err)
+		}
+	}()
+}
+
+// SearchIndexMatch returns the full-text search expression for the words in the query string, or an empty
+// string if it contains no words. Words are matched as prefixes and must all be found, unless they are
+// separated by "|" or "OR".
+func SearchIndexMatch(engine, query string) string {
+	var groups []string
+
+	for _, group := range strings.Split(strings.ReplaceAll(query, " OR ", "|"), "|") {
+		var words []string
+
+		for _, w := range strings.FieldsFunc(strings.ToLower(group), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			switch engine {
+			case SearchIndexFulltext:
+				words = append(words, "+"+w+"*")
+			default:
+				words = append(words, w+"*")
+			}
+		}
+
+		if len(words) == 0 {
+			continue
+		} else if engine == SearchIndexFulltext {
+			groups = append(groups, "("+strings.Join(words, " ")+")")
+		} else {
+			groups = append(groups, strings.Join(words, " "))
+		}
+	}
+
+	switch {
+	case len(groups) == 0:
+		return ""
+	case engine == SearchIndexFulltext && len(groups) == 1:
+		return strings.TrimSuffix(strings.TrimPrefix(groups[0], "("), ")")
+	case engine == SearchIndexFulltext:
+		return "+(" + strings.Join(groups, " ") + ")"
+	case len(groups) == 1:
+		return groups[0]
+	default:
+		return "(" + strings.Join(groups, ") OR (") + ")"
+	}
+}
+
+// SearchIndexQuery returns an SQL subquery and its arguments that selects the ids of the photos matching the
+// query string as "photo_id", along with a "rank_score" by which the results can be sorted in ascending order.
+// The returned query is empty if no search index exists or the query string contains no words. Since the index
+// ignores punctuation, it is also empty for queries with other special characters such as "%", "&", or quotes,
+// so that they can be matched literally instead.
+func SearchIndexQuery(query string) (sql string, args []interface{}) {
+	if strings.IndexFunc(query, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsSpace(r) && !strings.ContainsRune("|-_.", r)
+	}) >= 0 {
+		return "", nil
+	}
+
+	engine := SearchIndexEngine()
+	match := SearchIndexMatch(engine, query)
+
+	if match == "" {
+		return "", nil
+	}
+
+	table := PhotoSearch{}.TableName()
+
+	switch engine {
+	case SearchIndexFts5:
+		return fmt.Sprintf("SELECT rowid AS photo_id, bm25(%s, %s) AS rank_score FROM %s WHERE %s MATCH ?",
+			table, searchIndexWeights, table, table), []interface{}{match}
+	case SearchIndexFts4:
+		// FTS4 has no built-in ranking function, so the number of matches is used instead.
+		return fmt.Sprintf("SELECT rowid AS photo_id, -length(offsets(%s)) AS rank_score FROM %s WHERE %s MATCH ?",
+			table, table, table), []interface{}{match}
+	case SearchIndexFulltext:
+		columns := strings.Join(searchIndexColumns, ", ")
+		return fmt.Sprintf("SELECT photo_id, -MATCH(%s) AGAINST(? IN BOOLEAN MODE) AS rank_score FROM %s WHERE MATCH(%s) AGAINST(? IN BOOLEAN MODE)",
+			columns, table, columns), []interface{}{match, match}
+	default:
+		return "", nil
+	}
+}
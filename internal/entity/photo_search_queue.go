@@ -0,0 +1,164 @@
+package entity
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jinzhu/gorm"
+)
+
+// PhotoSearchQueue represents a photo whose search index values need to be updated, so that frequent changes,
+// e.g. while indexing or editing labels, are batched and done by the metadata worker. The queue is stored in
+// the database, so that no updates get lost if the app is stopped, and search queries can still find queued
+// photos without using the index.
+type PhotoSearchQueue struct {
+	PhotoID  uint      `gorm:"primary_key;auto_increment:false"`
+	QueuedAt time.Time `gorm:"index;"`
+}
+
+// TableName returns the entity table name.
+func (PhotoSearchQueue) TableName() string {
+	return "photos_search_queue"
+}
+
+// searchIndexQueued contains the ids of photos that have already been added to the queue table,
+// so that it is not updated again each time they change.
+var searchIndexQueued = struct {
+	sync.Mutex
+	ids map[uint]struct{}
+}{ids: make(map[uint]struct{})}
+
+// QueueSearchIndex schedules a search index update for the photos with the specified ids, see UpdateQueuedSearchIndex.
+func QueueSearchIndex(photoIds ...uint) {
+	queueSearchIndex(UnscopedDb(), photoIds...)
+}
+
+// queueSearchIndex adds the photo ids to the queue table using the specified database connection, so that it can
+// also be called from hooks that run in a transaction.
+func queueSearchIndex(db *gorm.DB, photoIds ...uint) {
+	if len(photoIds) == 0 || SearchIndexEngine() == SearchIndexNone {
+		return
+	}
+
+	queuedAt := Now()
+
+	for _, id := range photoIds {
+		if id == 0 || searchIndexQueuedAdd(id) {
+			continue
+		}
+
+		if err := db.Save(&PhotoSearchQueue{PhotoID: id, QueuedAt: queuedAt}).Error; err != nil {
+			log.Warnf("search: %s (queue photo %d)", err, id)
+			searchIndexQueuedRemove(id)
+		}
+	}
+}
+
+// searchIndexQueuedAdd marks the photo as queued and returns true if it was already queued.
+func searchIndexQueuedAdd(id uint) (found bool) {
+	searchIndexQueued.Lock()
+	defer searchIndexQueued.Unlock()
+
+	if _, found = searchIndexQueued.ids[id]; !found {
+		searchIndexQueued.ids[id] = struct{}{}
+	}
+
+	return found
+}
+
+// searchIndexQueuedRemove marks the photo as not queued.
+func searchIndexQueuedRemove(id uint) {
+	searchIndexQueued.Lock()
+	defer searchIndexQueued.Unlock()
+
+	delete(searchIndexQueued.ids, id)
+}
+
+// queueSearchIndexByMarker schedules a search index update for the photos with face markers that match the
+// condition, e.g. after people have been renamed, since the index contains their names.
+func queueSearchIndexByMarker(query string, values ...interface{}) {
+	var ids []uint
+
+	if err := UnscopedDb().Table("files").
+		Joins(fmt.Sprintf("JOIN %s m ON m.file_uid = files.file_uid", Marker{}.TableName())).
+		Where(query, values...).Pluck("DISTINCT files.photo_id", &ids).Error; err != nil {
+		log.Warnf("search: %s (queue photos with markers)", err)
+		return
+	}
+
+	QueueSearchIndex(ids...)
+}
+
+// QueuedSearchIndex returns the number of photos waiting for a search index update.
+func QueuedSearchIndex() (count int) {
+	if err := UnscopedDb().Model(&PhotoSearchQueue{}).Count(&count).Error; err != nil {
+		log.Warnf("search: %s (count queued photos)", err)
+	}
+
+	return count
+}
+
+// UpdateQueuedSearchIndex updates the search index values of the queued photos and returns the number of updated photos.
+// Photos that no longer exist are removed from the index.
+func UpdateQueuedSearchIndex() (updated int, err error) {
+	if SearchIndexEngine() == SearchIndexNone {
+		return 0, nil
+	}
+
+	// Photos that change from now on must be queued again.
+	searchIndexQueued.Lock()
+	searchIndexQueued.ids = make(map[uint]struct{})
+	started := Now()
+	searchIndexQueued.Unlock()
+
+	var ids []uint
+
+	if err = UnscopedDb().Model(&PhotoSearchQueue{}).Order("photo_id").Pluck("photo_id", &ids).Error; err != nil || len(ids) == 0 {
+		return 0, err
+	}
+
+	batchSize := 500
+
+	for i := 0; i < len(ids); i += batchSize {
+		j := i + batchSize
+
+		if j > len(ids) {
+			j = len(ids)
+		}
+
+		var photos Photos
+
+		if err = UnscopedDb().Where("id IN (?)", ids[i:j]).Find(&photos).Error; err != nil {
+			return updated, err
+		}
+
+		found := make(map[uint]bool, len(photos))
+
+		for _, photo := range photos {
+			found[photo.ID] = true
+
+			if err = NewPhotoSearch(photo).Save(); err != nil {
+				log.Warnf("search: %s (update index for %s)", err, photo.String())
+			} else {
+				updated++
+			}
+		}
+
+		for _, id := range ids[i:j] {
+			if found[id] {
+				continue
+			} else if err = RemoveFromSearchIndex(id); err != nil {
+				log.Warnf("search: %s (remove photo %d from index)", err, id)
+			}
+		}
+
+		// Keep photos that have been queued again in the meantime.
+		if err = UnscopedDb().Where("photo_id IN (?) AND queued_at < ?", ids[i:j], started).
+			Delete(&PhotoSearchQueue{}).Error; err != nil {
+			return updated, err
+		}
+	}
+
+	return updated, nil
+}
@@ -0,0 +1,292 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPhotoSearch_TableName(t *testing.T) {
+	assert.Equal(t, "photos_search", PhotoSearch{}.TableName())
+}
+
+func TestSearchIndexEngine(t *testing.T) {
+	assert.Contains(t, []string{SearchIndexFts5, SearchIndexFts4, SearchIndexFulltext}, SearchIndexEngine())
+}
+
+func TestNewPhotoSearch(t *testing.T) {
+	t.Run("Lake", func(t *testing.T) {
+		m := NewPhotoSearch(PhotoFixtures.Pointer("19800101_000002_D640C559"))
+
+		assert.Equal(t, uint(1000000), m.PhotoID)
+		assert.Equal(t, "ps6sg6be2lvl0yh7", m.PhotoUID)
+		assert.Equal(t, "Lake / 2790", m.Title)
+		assert.Contains(t, m.Keywords, "bridge")
+		assert.Contains(t, m.Labels, "Flower")
+		assert.Contains(t, m.Labels, "Cake")
+		assert.Contains(t, m.Files, "27900704_070228_D6D51B6C")
+	})
+	t.Run("New", func(t *testing.T) {
+		m := NewPhotoSearch(&Photo{PhotoTitle: "Unsaved"})
+
+		assert.Equal(t, uint(0), m.PhotoID)
+		assert.Equal(t, "Unsaved", m.Title)
+		assert.Equal(t, "", m.Files)
+	})
+}
+
+func TestJoinSearchText(t *testing.T) {
+	assert.Equal(t, "", joinSearchText(nil))
+	assert.Equal(t, "Berlin Germany", joinSearchText([]string{" Berlin", "", "Germany", "Berlin"}))
+}
+
+func TestPhotoSearch_Save(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		m := NewPhotoSearch(PhotoFixtures.Pointer("Photo01"))
+		m.Caption = "Zanzibarian sunset"
+
+		assert.NoError(t, m.Save())
+
+		sql, args := SearchIndexQuery("zanzibar")
+
+		var ids []uint
+
+		assert.NoError(t, Db().Raw("SELECT photo_id FROM ("+sql+") m", args...).Pluck("photo_id", &ids).Error)
+		assert.Equal(t, []uint{1000001}, ids)
+
+		assert.NoError(t, RemoveFromSearchIndex(m.PhotoID))
+		assert.NoError(t, Db().Raw("SELECT photo_id FROM ("+sql+") m", args...).Pluck("photo_id", &ids).Error)
+		assert.Empty(t, ids)
+
+		assert.NoError(t, NewPhotoSearch(PhotoFixtures.Pointer("Photo01")).Save())
+	})
+	t.Run("MissingID", func(t *testing.T) {
+		assert.Error(t, (&PhotoSearch{}).Save())
+	})
+}
+
+func TestRebuildSearchIndex(t *testing.T) {
+	indexed, err := RebuildSearchIndex()
+
+	assert.NoError(t, err)
+	assert.Greater(t, indexed, 50)
+
+	sql, args := SearchIndexQuery("flower")
+
+	var ids []uint
+
+	assert.NoError(t, Db().Raw("SELECT photo_id FROM ("+sql+") m", args...).Pluck("photo_id", &ids).Error)
+	assert.Contains(t, ids, uint(1000000))
+}
+
+func TestSearchIndexMatch(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, "", SearchIndexMatch(SearchIndexFts5, ""))
+		assert.Equal(t, "", SearchIndexMatch(SearchIndexFts5, " | "))
+	})
+	t.Run("Words", func(t *testing.T) {
+		assert.Equal(t, "lake* bridge*", SearchIndexMatch(SearchIndexFts5, "Lake Bridge"))
+		assert.Equal(t, "+lake* +bridge*", SearchIndexMatch(SearchIndexFulltext, "Lake Bridge"))
+	})
+	t.Run("Or", func(t *testing.T) {
+		assert.Equal(t, "(lake*) OR (sea* beach*)", SearchIndexMatch(SearchIndexFts4, "lake|sea beach"))
+		assert.Equal(t, "(lake*) OR (sea*)", SearchIndexMatch(SearchIndexFts5, "lake OR sea"))
+		assert.Equal(t, "+((+lake*) (+sea* +beach*))", SearchIndexMatch(SearchIndexFulltext, "lake | sea beach"))
+	})
+}
+
+func TestSearchIndexQuery(t *testing.T) {
+	t.Run("Words", func(t *testing.T) {
+		sql, args := SearchIndexQuery("lake")
+
+		assert.Contains(t, sql, "photo_id")
+		assert.Contains(t, sql, "rank_score")
+		assert.NotEmpty(t, args)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		sql, args := SearchIndexQuery("")
+
+		assert.Equal(t, "", sql)
+		assert.Nil(t, args)
+	})
+	t.Run("SpecialChars", func(t *testing.T) {
+		sql, _ := SearchIndexQuery("Pets & Dogs")
+
+		assert.Equal(t, "", sql)
+	})
+}
+
+func TestIsSearchIndexTable(t *testing.T) {
+	assert.True(t, IsSearchIndexTable("photos_search"))
+	assert.True(t, IsSearchIndexTable("photos_search_content"))
+	assert.True(t, IsSearchIndexTable(PhotoSearchQueue{}.TableName()))
+	assert.False(t, IsSearchIndexTable("photos"))
+	assert.False(t, IsSearchIndexTable("photos_labels"))
+}
+
+// resetSearchIndexQueue removes all photos from the search index queue.
+func resetSearchIndexQueue(t *testing.T) {
+	if err := UnscopedDb().Delete(&PhotoSearchQueue{}).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	searchIndexQueued.Lock()
+	searchIndexQueued.ids = make(map[uint]struct{})
+	searchIndexQueued.Unlock()
+}
+
+// backdateSearchIndexQueue changes the time at which the photos were queued, since photos that have been
+// queued again after an update started are kept in the queue.
+func backdateSearchIndexQueue(t *testing.T) {
+	if err := UnscopedDb().Model(&PhotoSearchQueue{}).UpdateColumn("queued_at", Now().Add(-time.Minute)).Error; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestInitSearchIndex(t *testing.T) {
+	t.Run("Rebuild", func(t *testing.T) {
+		assert.NoError(t, Db().Exec("DELETE FROM photos_search").Error)
+
+		InitSearchIndex()
+
+		// The index is rebuilt in the background.
+		assert.Eventually(t, func() bool {
+			var indexed int
+			return Db().Table(PhotoSearch{}.TableName()).Count(&indexed).Error == nil && indexed > 50
+		}, 10*time.Second, 100*time.Millisecond)
+	})
+	t.Run("Queued", func(t *testing.T) {
+		if _, err := RebuildSearchIndex(); err != nil {
+			t.Fatal(err)
+		}
+
+		resetSearchIndexQueue(t)
+
+		QueueSearchIndex(PhotoFixtures.Get("Photo01").ID)
+
+		assert.Equal(t, 1, QueuedSearchIndex())
+
+		backdateSearchIndexQueue(t)
+		InitSearchIndex()
+
+		assert.Equal(t, 0, QueuedSearchIndex())
+	})
+}
+
+func TestQueueSearchIndex(t *testing.T) {
+	resetSearchIndexQueue(t)
+
+	id := PhotoFixtures.Get("Photo01").ID
+
+	QueueSearchIndex(id, id)
+
+	// The queue is stored in the database, so that it is not lost if the app is stopped.
+	var queued PhotoSearchQueue
+
+	assert.NoError(t, UnscopedDb().First(&queued, "photo_id = ?", id).Error)
+	assert.Equal(t, id, queued.PhotoID)
+	assert.Equal(t, 1, QueuedSearchIndex())
+
+	// Photos that are queued again while the index is updated are kept.
+	_, err := UpdateQueuedSearchIndex()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, QueuedSearchIndex())
+
+	backdateSearchIndexQueue(t)
+
+	_, err = UpdateQueuedSearchIndex()
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, QueuedSearchIndex())
+}
+
+func TestUpdateQueuedSearchIndex(t *testing.T) {
+	t.Run("PhotoSave", func(t *testing.T) {
+		resetSearchIndexQueue(t)
+
+		m := PhotoFixtures.Get("Photo01")
+		m.PhotoCaption = "Quixotically queued caption"
+
+		if err := m.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, QueuedSearchIndex())
+
+		sql, args := SearchIndexQuery("quixotically")
+
+		var ids []uint
+
+		assert.NoError(t, Db().Raw("SELECT photo_id FROM ("+sql+") m", args...).Pluck("photo_id", &ids).Error)
+		assert.Empty(t, ids)
+
+		backdateSearchIndexQueue(t)
+
+		updated, err := UpdateQueuedSearchIndex()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, updated)
+		assert.Equal(t, 0, QueuedSearchIndex())
+
+		assert.NoError(t, Db().Raw("SELECT photo_id FROM ("+sql+") m", args...).Pluck("photo_id", &ids).Error)
+		assert.Equal(t, []uint{m.ID}, ids)
+
+		m = PhotoFixtures.Get("Photo01")
+
+		if err = m.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = UpdateQueuedSearchIndex()
+		assert.NoError(t, err)
+	})
+	t.Run("PhotoLabel", func(t *testing.T) {
+		resetSearchIndexQueue(t)
+
+		label := NewPhotoLabel(1000001, LabelFixtures.Get("flower").ID, 10, SrcManual)
+
+		if err := label.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 1, QueuedSearchIndex())
+
+		if err := label.Delete(); err != nil {
+			t.Fatal(err)
+		}
+
+		updated, err := UpdateQueuedSearchIndex()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 1, updated)
+	})
+	t.Run("Subject", func(t *testing.T) {
+		resetSearchIndexQueue(t)
+
+		subj := SubjectFixtures.Pointer("john-doe")
+
+		assert.NoError(t, subj.RefreshPhotos())
+		assert.Positive(t, QueuedSearchIndex())
+
+		_, err := UpdateQueuedSearchIndex()
+		assert.NoError(t, err)
+	})
+	t.Run("Removed", func(t *testing.T) {
+		resetSearchIndexQueue(t)
+
+		QueueSearchIndex(0, 99999999)
+
+		assert.Equal(t, 1, QueuedSearchIndex())
+
+		backdateSearchIndexQueue(t)
+
+		updated, err := UpdateQueuedSearchIndex()
+
+		assert.NoError(t, err)
+		assert.Equal(t, 0, updated)
+		assert.Equal(t, 0, QueuedSearchIndex())
+	})
+	t.Cleanup(func() { resetSearchIndexQueue(t) })
+}
@@ -1,11 +1,19 @@
 package entity
 
 import (
+	"fmt"
+
 	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/log/status"
+	"github.com/photoprism/photoprism/pkg/rnd"
 )
 
+// PhotoUsers represents a list of photo users and teams.
+type PhotoUsers []PhotoUser
+
 // PhotoUser represents the user and group ownership of a Photo and the corresponding permissions.
+// Permissions granted to a team are stored with both UserUID and TeamUID set to the team UID.
 type PhotoUser struct {
 	UID     string `gorm:"type:VARBINARY(42);primary_key;auto_increment:false" json:"UID" yaml:"UID"`
 	UserUID string `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;index" json:"UserUID,omitempty" yaml:"UserUID,omitempty"`
@@ -44,7 +52,7 @@ func (m *PhotoUser) Save() error {
 func FirstOrCreatePhotoUser(m *PhotoUser) *PhotoUser {
 	found := PhotoUser{}
 
-	if err := Db().Where("uid = ?", m.UID).First(&found).Error; err == nil {
+	if err := Db().Where("uid = ? AND user_uid = ?", m.UID, m.UserUID).First(&found).Error; err == nil {
 		return &found
 	} else if err = m.Create(); err != nil {
 		event.AuditErr([]string{"photo %s", "failed to set owner and permissions", status.Error(err)}, m.UID)
@@ -53,3 +61,62 @@ func FirstOrCreatePhotoUser(m *PhotoUser) *PhotoUser {
 
 	return m
 }
+
+// Delete removes the record from the database.
+func (m *PhotoUser) Delete() error {
+	if m.UID == "" || m.UserUID == "" {
+		return fmt.Errorf("photo and user uid are required")
+	}
+
+	return UnscopedDb().Delete(&PhotoUser{}, "uid = ? AND user_uid = ?", m.UID, m.UserUID).Error
+}
+
+// IsTeam checks if the permissions were granted to a team rather than a single user.
+func (m *PhotoUser) IsTeam() bool {
+	return m.TeamUID != "" && m.TeamUID == m.UserUID
+}
+
+// FindPhotoUsers returns the users and teams who have been granted permissions for the specified photo.
+func FindPhotoUsers(uid string) (result PhotoUsers) {
+	result = PhotoUsers{}
+
+	if rnd.InvalidUID(uid, PhotoUID) {
+		return result
+	}
+
+	if err := Db().Where("uid = ?", uid).Order("user_uid").Find(&result).Error; err != nil {
+		log.Errorf("photo: %s (find users)", err)
+	}
+
+	return result
+}
+
+// SetPhotoOwner grants the user who created a photo full access to it.
+func SetPhotoOwner(uid, userUid string) *PhotoUser {
+	if rnd.InvalidUID(uid, PhotoUID) || rnd.InvalidUID(userUid, UserUID) {
+		return nil
+	}
+
+	return FirstOrCreatePhotoUser(NewPhotoUser(uid, userUid, "", PermAll))
+}
+
+// SharePhoto grants a registered user or a team the specified permissions for a photo.
+func SharePhoto(uid, principalUid string, perm uint) (*PhotoUser, error) {
+	if rnd.InvalidUID(uid, PhotoUID) {
+		return nil, fmt.Errorf("invalid photo uid %s", clean.Log(uid))
+	} else if perm&^PermGrantable != 0 {
+		return nil, fmt.Errorf("edit and upload permissions cannot be granted")
+	} else if perm == PermDefault {
+		perm = PermView
+	}
+
+	m := NewPhotoUser(uid, principalUid, "", perm)
+
+	if rnd.IsUID(principalUid, TeamUID) {
+		m.TeamUID = principalUid
+	} else if rnd.InvalidUID(principalUid, UserUID) {
+		return nil, fmt.Errorf("invalid user or team uid %s", clean.Log(principalUid))
+	}
+
+	return m, m.Save()
+}
@@ -0,0 +1,151 @@
+package entity
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+var photoXmpMutex = sync.Mutex{}
+
+// XmpSidecar returns the photo metadata that is written to XMP sidecar files.
+func (m *Photo) XmpSidecar() meta.XmpSidecar {
+	details := m.GetDetails()
+
+	result := meta.XmpSidecar{
+		UID:          m.PhotoUID,
+		Title:        m.PhotoTitle,
+		Caption:      m.PhotoCaption,
+		Artist:       details.Artist,
+		Copyright:    details.Copyright,
+		Favorite:     m.PhotoFavorite,
+		TakenAt:      m.TakenAtLocal,
+		Lat:          m.PhotoLat,
+		Lng:          m.PhotoLng,
+		Altitude:     m.PhotoAltitude,
+		MetadataDate: time.Now().UTC().Truncate(time.Second),
+	}
+
+	for _, w := range strings.Split(details.Keywords, ",") {
+		if w = strings.TrimSpace(w); w != "" {
+			result.Keywords = append(result.Keywords, w)
+		}
+	}
+
+	// Add the labels that have not been removed by a user.
+	if m.Labels == nil {
+		m.PreloadLabels()
+	}
+
+	for _, l := range m.Labels {
+		if l.Label != nil && l.Uncertainty < 100 {
+			result.Labels = append(result.Labels, l.Label.LabelName)
+		}
+	}
+
+	// Add the named faces of the primary file as MWG regions.
+	if m.PhotoUID == "" {
+		return result
+	} else if file, err := m.PrimaryFile(); err != nil || file == nil {
+		return result
+	} else {
+		result.Width = file.FileWidth
+		result.Height = file.FileHeight
+
+		for _, marker := range *file.Markers() {
+			if !marker.ValidFace() {
+				continue
+			} else if name := marker.SubjectName(); name != "" {
+				result.People = append(result.People, name)
+				result.Regions = append(result.Regions, meta.XmpRegion{Name: name, X: marker.X, Y: marker.Y, W: marker.W, H: marker.H})
+			}
+		}
+	}
+
+	return result
+}
+
+// XmpOriginal returns the original media file the XMP sidecar belongs to.
+func (m *Photo) XmpOriginal() (*File, error) {
+	file := File{}
+
+	if m.ID == 0 {
+		return &file, fmt.Errorf("photo id is empty")
+	}
+
+	res := Db().
+		Where("photo_id = ? AND file_root = ? AND file_sidecar = 0 AND file_missing = 0", m.ID, RootOriginals).
+		Order("file_primary DESC, id").
+		First(&file)
+
+	return &file, res.Error
+}
+
+// XmpFileName returns both the absolute file path and the relative name for the XMP sidecar file, e.g. for logging.
+// Existing sidecars next to the original are updated, e.g. "IMG_1234.CR2.xmp" or "IMG_1234.xmp". New files are
+// created next to the original, or in the sidecar path if it is not empty, e.g. because originals are read-only.
+func (m *Photo) XmpFileName(originalsPath, sidecarPath string) (absolute, relative string, err error) {
+	file, err := m.XmpOriginal()
+
+	if err != nil {
+		return "", "", err
+	}
+
+	original := filepath.Join(originalsPath, file.FileName)
+
+	for _, name := range []string{original + fs.ExtXMP, fs.StripKnownExt(original) + fs.ExtXMP} {
+		if fs.FileExists(name) {
+			return name, fs.RelName(name, originalsPath), nil
+		}
+	}
+
+	absolute, err = fs.FileName(original, sidecarPath, originalsPath, fs.ExtXMP)
+	relative = file.FileName + fs.ExtXMP
+
+	return absolute, relative, err
+}
+
+// SaveSidecarXmp creates or updates the XMP sidecar file of the photo based on the specified storage paths,
+// see XmpFileName. Properties written by other applications are preserved.
+func (m *Photo) SaveSidecarXmp(originalsPath, sidecarPath string) error {
+	if m == nil {
+		return fmt.Errorf("photo entity is nil - you may have found a bug")
+	} else if m.PhotoUID == "" {
+		return fmt.Errorf("photo uid is empty")
+	}
+
+	// Get photo XMP sidecar filename.
+	fileName, relName, err := m.XmpFileName(originalsPath, sidecarPath)
+
+	if err != nil {
+		log.Warnf("photo: %s (save xmp sidecar for %s)", err, clean.Log(m.PhotoUID))
+		return err
+	}
+
+	var action string
+
+	if fs.FileExists(fileName) {
+		action = "update"
+	} else {
+		action = "create"
+	}
+
+	photoXmpMutex.Lock()
+	defer photoXmpMutex.Unlock()
+
+	// Write photo metadata to XMP sidecar file.
+	if err = m.XmpSidecar().Save(fileName); err != nil {
+		log.Warnf("photo: %s (%s %s)", err, action, clean.Log(relName))
+		return err
+	} else {
+		log.Debugf("photo: %sd sidecar file %s", action, clean.Log(relName))
+	}
+
+	return nil
+}
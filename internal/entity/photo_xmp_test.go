@@ -0,0 +1,121 @@
+package entity
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestPhoto_XmpSidecar(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo01")
+		result := m.XmpSidecar()
+
+		assert.Equal(t, m.PhotoUID, result.UID)
+		assert.Equal(t, m.PhotoTitle, result.Title)
+		assert.Equal(t, m.TakenAtLocal, result.TakenAt)
+		assert.Equal(t, m.PhotoLat, result.Lat)
+		assert.Equal(t, m.PhotoLng, result.Lng)
+		assert.Equal(t, m.PhotoFavorite, result.Favorite)
+		assert.False(t, result.MetadataDate.IsZero())
+	})
+	t.Run("Labels", func(t *testing.T) {
+		m := Photo{
+			PhotoTitle: "Example",
+			Labels: []PhotoLabel{
+				LabelFixtures.PhotoLabel(1000000, "flower", 38, "image"),
+				LabelFixtures.PhotoLabel(1000000, "cake", 100, "manual"),
+			},
+		}
+
+		result := m.XmpSidecar()
+
+		assert.Equal(t, []string{"Flower"}, result.Labels)
+	})
+	t.Run("NoPhotoUID", func(t *testing.T) {
+		m := Photo{PhotoTitle: "Example"}
+		result := m.XmpSidecar()
+
+		assert.Equal(t, "Example", result.Title)
+		assert.Empty(t, result.Regions)
+	})
+}
+
+func TestPhoto_XmpFileName(t *testing.T) {
+	t.Run("New", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo01")
+		originalsPath := t.TempDir()
+
+		fileName, relative, err := m.XmpFileName(originalsPath, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(originalsPath, "2790/02/Photo01.dng.xmp"), fileName)
+		assert.Equal(t, "2790/02/Photo01.dng.xmp", relative)
+	})
+	t.Run("ReadOnly", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo01")
+		originalsPath := t.TempDir()
+		sidecarPath := t.TempDir()
+
+		fileName, relative, err := m.XmpFileName(originalsPath, sidecarPath)
+
+		assert.NoError(t, err)
+		assert.Equal(t, filepath.Join(sidecarPath, "2790/02/Photo01.dng.xmp"), fileName)
+		assert.Equal(t, "2790/02/Photo01.dng.xmp", relative)
+	})
+	t.Run("Existing", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo01")
+		originalsPath := t.TempDir()
+		existing := filepath.Join(originalsPath, "2790/02/Photo01.xmp")
+
+		if err := fs.MkdirAll(filepath.Dir(existing)); err != nil {
+			t.Fatal(err)
+		} else if err = os.WriteFile(existing, []byte{}, fs.ModeFile); err != nil {
+			t.Fatal(err)
+		}
+
+		fileName, relative, err := m.XmpFileName(originalsPath, t.TempDir())
+
+		assert.NoError(t, err)
+		assert.Equal(t, existing, fileName)
+		assert.Equal(t, "2790/02/Photo01.xmp", relative)
+	})
+	t.Run("NoID", func(t *testing.T) {
+		m := Photo{}
+		_, _, err := m.XmpFileName(t.TempDir(), "")
+		assert.Error(t, err)
+	})
+}
+
+func TestPhoto_SaveSidecarXmp(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo01")
+		originalsPath := t.TempDir()
+
+		if err := m.SaveSidecarXmp(originalsPath, ""); err != nil {
+			t.Fatal(err)
+		}
+
+		fileName := filepath.Join(originalsPath, "2790/02/Photo01.dng.xmp")
+
+		assert.FileExists(t, fileName)
+		assert.True(t, meta.XmpGenerated(fileName))
+
+		data, err := meta.XMP(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, m.PhotoTitle, data.Title)
+	})
+	t.Run("NoPhotoUID", func(t *testing.T) {
+		m := Photo{}
+		assert.Error(t, m.SaveSidecarXmp(t.TempDir(), ""))
+	})
+}
@@ -24,6 +24,10 @@ func (m *Photo) Yaml() ([]byte, error) {
 	m.CreatedAt = m.CreatedAt.UTC().Truncate(time.Second)
 	m.UpdatedAt = m.UpdatedAt.UTC().Truncate(time.Second)
 
+	// Include comments, so they can be restored from the sidecar file.
+	m.Comments = FindComments(m.PhotoUID)
+	defer func() { m.Comments = nil }()
+
 	out, err := yaml.Marshal(m)
 
 	if err != nil {
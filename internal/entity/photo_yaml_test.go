@@ -22,6 +22,18 @@ func TestPhoto_Yaml(t *testing.T) {
 
 		t.Logf("YAML: %s", result)
 	})
+	t.Run("Comments", func(t *testing.T) {
+		m := PhotoFixtures.Get("Photo01")
+		result, err := m.Yaml()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Comments are only included in the output, so that saving the entity does not restore them.
+		assert.Contains(t, string(result), "Comments:")
+		assert.Nil(t, m.Comments)
+	})
 }
 
 func TestPhoto_SaveAsYaml(t *testing.T) {
@@ -26,3 +26,41 @@ func AccountUploads(a entity.Service, limit int) (results entity.Files, err erro
 
 	return results, nil
 }
+
+// AccountModified returns previously synchronized files that have been modified locally since.
+func AccountModified(a entity.Service, limit int) (results []entity.FileSync, err error) {
+	s := Db().Joins("JOIN files ON files.id = files_sync.file_id").
+		Where("files_sync.service_id = ? AND files_sync.status IN (?)", a.ID, []string{entity.FileSyncDownloaded, entity.FileSyncUploaded}).
+		Where("files_sync.file_hash <> '' AND files.file_hash <> '' AND files.file_hash <> files_sync.file_hash").
+		Where("files.file_missing = 0 AND files.deleted_at IS NULL")
+
+	s = s.Order("files_sync.remote_name ASC")
+
+	if limit > 0 {
+		s = s.Limit(limit).Offset(0)
+	}
+
+	if result := s.Preload("File").Find(&results); result.Error != nil {
+		return results, result.Error
+	}
+
+	return results, nil
+}
+
+// AccountDeleted returns previously synchronized files that no longer exist locally.
+func AccountDeleted(a entity.Service, limit int) (results []entity.FileSync, err error) {
+	s := Db().Where("files_sync.service_id = ? AND files_sync.file_id > 0 AND files_sync.status IN (?)", a.ID, []string{entity.FileSyncDownloaded, entity.FileSyncUploaded}).
+		Where("files_sync.file_id NOT IN (SELECT id FROM files WHERE file_missing = 0 AND deleted_at IS NULL)")
+
+	s = s.Order("files_sync.remote_name ASC")
+
+	if limit > 0 {
+		s = s.Limit(limit).Offset(0)
+	}
+
+	if result := s.Find(&results); result.Error != nil {
+		return results, result.Error
+	}
+
+	return results, nil
+}
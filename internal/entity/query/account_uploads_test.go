@@ -23,3 +23,75 @@ func TestAccountUploads(t *testing.T) {
 		assert.GreaterOrEqual(t, len(results), 1)
 	})
 }
+
+func TestAccountModified(t *testing.T) {
+	a := entity.Service{ID: 1000000}
+
+	t.Run("Unchanged", func(t *testing.T) {
+		results, err := AccountModified(a, 10)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 0)
+	})
+	t.Run("Modified", func(t *testing.T) {
+		m := entity.NewFileSync(a.ID, "/modified-example.jpg")
+		m.FileID = entity.FileFixturesExampleJPG.ID
+		m.FileHash = "0000000000000000000000000000000000000000"
+		m.Status = entity.FileSyncUploaded
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer entity.UnscopedDb().Delete(m)
+
+		results, err := AccountModified(a, 10)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, results, 1) {
+			assert.Equal(t, "/modified-example.jpg", results[0].RemoteName)
+			assert.NotNil(t, results[0].File)
+		}
+	})
+}
+
+func TestAccountDeleted(t *testing.T) {
+	a := entity.Service{ID: 1000000}
+
+	t.Run("NoneDeleted", func(t *testing.T) {
+		results, err := AccountDeleted(a, 10)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 0)
+	})
+	t.Run("Deleted", func(t *testing.T) {
+		m := entity.NewFileSync(a.ID, "/deleted-example.jpg")
+		m.FileID = 999999999
+		m.Status = entity.FileSyncDownloaded
+
+		if err := m.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer entity.UnscopedDb().Delete(m)
+
+		results, err := AccountDeleted(a, 10)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, results, 1) {
+			assert.Equal(t, "/deleted-example.jpg", results[0].RemoteName)
+		}
+	})
+}
@@ -218,3 +218,18 @@ func AlbumsPhotoUIDs(albums []string, includeDefault, includePrivate bool) (phot
 
 	return photos, nil
 }
+
+// PhotoAlbumUIDs returns the UIDs of the albums that contain the specified photo, excluding hidden entries.
+func PhotoAlbumUIDs(photoUid string) (albums []string, err error) {
+	albums = []string{}
+
+	if photoUid == "" {
+		return albums, nil
+	}
+
+	err = Db().Model(&entity.PhotoAlbum{}).
+		Where("photo_uid = ? AND hidden = 0 AND missing = 0", photoUid).
+		Pluck("album_uid", &albums).Error
+
+	return albums, err
+}
@@ -126,3 +126,18 @@ func TestAlbumsByUID(t *testing.T) {
 		assert.Len(t, results, 2)
 	})
 }
+
+func TestPhotoAlbumUIDs(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		albums, err := PhotoAlbumUIDs("ps6sg6be2lvl0yh8")
+
+		assert.NoError(t, err)
+		assert.Contains(t, albums, "as6sg6bxpogaaba9")
+	})
+	t.Run("Empty", func(t *testing.T) {
+		albums, err := PhotoAlbumUIDs("")
+
+		assert.NoError(t, err)
+		assert.Empty(t, albums)
+	})
+}
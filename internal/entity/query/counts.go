@@ -54,9 +54,9 @@ func (c *Counts) Refresh() {
 		Take(c)
 
 	Db().Table("albums").
-		Select("SUM(album_type = ?) AS albums, SUM(album_type = ?) AS moments, "+
+		Select("SUM(album_type IN (?, ?)) AS albums, SUM(album_type = ?) AS moments, "+
 			"SUM(album_type = ?) AS folders",
-			entity.AlbumManual, entity.AlbumMoment, entity.AlbumFolder).
+			entity.AlbumManual, entity.AlbumSmart, entity.AlbumMoment, entity.AlbumFolder).
 		Where("deleted_at IS NULL").
 		Take(c)
 
@@ -391,6 +391,11 @@ func RemovePeopleAndFaces() (err error) {
 		return err
 	}
 
+	// Delete suggested merges.
+	if err = UnscopedDb().Delete(entity.FaceSuggestion{}).Error; err != nil {
+		return err
+	}
+
 	// Delete face markers.
 	if err = UnscopedDb().Delete(entity.Marker{}, "marker_type = ?", entity.MarkerFace).Error; err != nil {
 		return err
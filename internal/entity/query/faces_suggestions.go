@@ -0,0 +1,52 @@
+package query
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// FaceSuggestions returns suggested face merges with the specified status, ordered by confidence.
+func FaceSuggestions(status string, limit, offset int) (result entity.FaceSuggestions, err error) {
+	stmt := Db().Preload("Face").Preload("Subject")
+
+	if status != "" {
+		stmt = stmt.Where("status = ?", status)
+	}
+
+	if limit > 0 {
+		stmt = stmt.Limit(limit).Offset(offset)
+	}
+
+	err = stmt.Order("confidence DESC, id").Find(&result).Error
+
+	return result, err
+}
+
+// PurgeFaceSuggestions removes suggestions for faces that no longer exist, as well as pending
+// suggestions for faces that have been assigned in the meantime or people that have been deleted.
+func PurgeFaceSuggestions() (removed int64, err error) {
+	faces := entity.Face{}.TableName()
+	subjects := entity.Subject{}.TableName()
+
+	res := UnscopedDb().Delete(entity.FaceSuggestion{},
+		fmt.Sprintf("face_id NOT IN (SELECT id FROM %s)", faces))
+
+	if res.Error != nil {
+		return removed, res.Error
+	}
+
+	removed += res.RowsAffected
+
+	res = UnscopedDb().Delete(entity.FaceSuggestion{},
+		fmt.Sprintf("status = ? AND (face_id NOT IN (SELECT id FROM %s WHERE subj_uid = '') OR subj_uid NOT IN (SELECT subj_uid FROM %s WHERE deleted_at IS NULL))", faces, subjects),
+		entity.SuggestionPending)
+
+	if res.Error != nil {
+		return removed, res.Error
+	}
+
+	removed += res.RowsAffected
+
+	return removed, nil
+}
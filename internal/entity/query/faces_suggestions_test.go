@@ -0,0 +1,59 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestFaceSuggestions(t *testing.T) {
+	t.Run("Pending", func(t *testing.T) {
+		results, err := FaceSuggestions(entity.SuggestionPending, 10, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(results), 1)
+
+		for _, r := range results {
+			assert.Equal(t, entity.SuggestionPending, r.Status)
+		}
+
+		if len(results) > 0 && results[0].FaceID == entity.FaceFixtures.Get("unknown").ID {
+			assert.NotNil(t, results[0].Face)
+			assert.NotNil(t, results[0].Subject)
+		}
+	})
+	t.Run("All", func(t *testing.T) {
+		results, err := FaceSuggestions("", 0, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, len(results), 2)
+	})
+}
+
+func TestPurgeFaceSuggestions(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		m := entity.NewFaceSuggestion("PURGESUGGESTIONTESTFACE000000001", entity.SubjectFixtures.Get("john-doe").SubjUID, "", 0.5)
+
+		if _, err := m.Save(); err != nil {
+			t.Fatal(err)
+		}
+
+		removed, err := PurgeFaceSuggestions()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.GreaterOrEqual(t, removed, int64(1))
+		assert.Nil(t, entity.FindFaceSuggestion(m.ID))
+		assert.NotNil(t, entity.FindFaceSuggestion(entity.FaceSuggestionFixtures.Get("unknown-john-doe").ID))
+	})
+}
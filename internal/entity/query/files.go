@@ -54,6 +54,18 @@ func Files(limit, offset int, dir string, includeMissing bool) (files entity.Fil
 	return files, err
 }
 
+// FilesWithoutPhash returns up to limit JPEG and PNG files with an ID greater than afterID that do not
+// have a perceptual hash yet, e.g. because they were indexed with an earlier version, sorted by id.
+func FilesWithoutPhash(afterID uint, limit int) (files entity.Files, err error) {
+	err = Db().
+		Where("id > ? AND file_missing = 0 AND file_sidecar = 0", afterID).
+		Where("file_phash IS NULL OR file_phash = ''").
+		Where("file_type IN (?)", []string{fs.ImageJpeg.String(), fs.ImagePng.String()}).
+		Order("id").Limit(limit).Find(&files).Error
+
+	return files, err
+}
+
 // FilesByUID finds files for the given UIDs.
 func FilesByUID(u []string, limit int, offset int) (files entity.Files, err error) {
 	if err = Db().Where("(photo_uid IN (?) AND file_primary = 1) OR file_uid IN (?)", u, u).Preload("Photo").Limit(limit).Offset(offset).Find(&files).Error; err != nil {
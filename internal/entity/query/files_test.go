@@ -117,6 +117,43 @@ func TestFilesByUID(t *testing.T) {
 	})
 }
 
+func TestFilesWithoutPhash(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		files, err := FilesWithoutPhash(0, 100)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, files)
+
+		for _, f := range files {
+			assert.Empty(t, f.FilePhash)
+			assert.False(t, f.FileMissing)
+			assert.Contains(t, []string{"jpg", "png"}, f.FileType)
+		}
+	})
+	t.Run("AfterID", func(t *testing.T) {
+		files, err := FilesWithoutPhash(0, 2)
+
+		if err != nil {
+			t.Fatal(err)
+		} else if len(files) < 2 {
+			t.Skip("not enough files")
+		}
+
+		next, err := FilesWithoutPhash(files[0].ID, 1)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, next, 1) {
+			assert.Equal(t, files[1].ID, next[0].ID)
+		}
+	})
+}
+
 func TestFileByPhotoUID(t *testing.T) {
 	t.Run("FilesFound", func(t *testing.T) {
 		file, err := FileByPhotoUID("ps6sg6be2lvl0y11")
@@ -0,0 +1,70 @@
+package query
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// Jobs finds scheduled jobs and returns them.
+func Jobs(limit, offset int, search string) (result entity.Jobs, err error) {
+	result = entity.Jobs{}
+	stmt := Db()
+
+	search = strings.TrimSpace(search)
+
+	if search == "all" {
+		// Don't filter.
+	} else if rnd.IsUID(search, entity.JobUID) {
+		stmt = stmt.Where("job_uid = ?", search)
+	} else if search != "" {
+		stmt = stmt.Where("job_name LIKE ? OR job_actions LIKE ?", search+"%", "%"+search+"%")
+	}
+
+	if limit > 0 {
+		stmt = stmt.Limit(limit)
+
+		if offset > 0 {
+			stmt = stmt.Offset(offset)
+		}
+	}
+
+	err = stmt.Order("created_at, job_uid").Find(&result).Error
+
+	return result, err
+}
+
+// ScheduledJobs returns all jobs that should run on schedule.
+func ScheduledJobs() (result entity.Jobs, err error) {
+	result = entity.Jobs{}
+
+	err = Db().
+		Where("job_schedule <> '' AND job_paused = ?", false).
+		Order("created_at, job_uid").
+		Find(&result).Error
+
+	return result, err
+}
+
+// JobRuns returns the run history of a job, optionally filtered by status, newest first.
+func JobRuns(jobUid, status string, limit, offset int) (result entity.JobRuns, err error) {
+	result = entity.JobRuns{}
+	stmt := Db().Where("job_uid = ?", jobUid)
+
+	if status != "" {
+		stmt = stmt.Where("status = ?", status)
+	}
+
+	if limit > 0 {
+		stmt = stmt.Limit(limit)
+
+		if offset > 0 {
+			stmt = stmt.Offset(offset)
+		}
+	}
+
+	err = stmt.Order("id DESC").Find(&result).Error
+
+	return result, err
+}
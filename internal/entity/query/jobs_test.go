@@ -0,0 +1,75 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestJobs(t *testing.T) {
+	t.Run("All", func(t *testing.T) {
+		if results, err := Jobs(0, 0, "all"); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.LessOrEqual(t, 2, len(results))
+		}
+	})
+	t.Run("Limit", func(t *testing.T) {
+		if results, err := Jobs(1, 1, ""); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Len(t, results, 1)
+		}
+	})
+	t.Run("SearchByUID", func(t *testing.T) {
+		if results, err := Jobs(10, 0, "rt2hdkz1vgdb3ta2"); err != nil {
+			t.Fatal(err)
+		} else if assert.Len(t, results, 1) {
+			assert.Equal(t, "Nightly Import", results[0].JobName)
+		}
+	})
+	t.Run("SearchByName", func(t *testing.T) {
+		if results, err := Jobs(10, 0, "Weekly"); err != nil {
+			t.Fatal(err)
+		} else if assert.Len(t, results, 1) {
+			assert.Equal(t, "rt2hdl31ydq9yw0k", results[0].JobUID)
+		}
+	})
+}
+
+func TestScheduledJobs(t *testing.T) {
+	if results, err := ScheduledJobs(); err != nil {
+		t.Fatal(err)
+	} else {
+		for _, job := range results {
+			assert.True(t, job.Scheduled())
+			assert.NotEqual(t, "rt2hdl31ydq9yw0k", job.JobUID)
+		}
+	}
+}
+
+func TestJobRuns(t *testing.T) {
+	t.Run("Job", func(t *testing.T) {
+		if results, err := JobRuns("rt2hdkz1vgdb3ta2", "", 10, 0); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.LessOrEqual(t, 1, len(results))
+		}
+	})
+	t.Run("Failed", func(t *testing.T) {
+		if results, err := JobRuns("rt2hdl31ydq9yw0k", entity.JobFailed, 10, 0); err != nil {
+			t.Fatal(err)
+		} else if assert.Len(t, results, 1) {
+			assert.Equal(t, "index is busy", results[0].Error)
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		if results, err := JobRuns("rt2hdkz1vgdb3ta2", entity.JobFailed, 10, 0); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Len(t, results, 0)
+		}
+	})
+}
@@ -170,6 +170,26 @@ func PhotosMetadataUpdate(limit, offset int, delay, interval time.Duration) (pho
 	return photos, err
 }
 
+// PhotosTakenBetween returns the photos taken in the specified time range, e.g. to match them with a GPS track.
+func PhotosTakenBetween(start, end time.Time) (photos entity.Photos, err error) {
+	err = Db().
+		Preload("Labels", func(db *gorm.DB) *gorm.DB {
+			return db.Order("photos_labels.uncertainty ASC, photos_labels.label_id DESC")
+		}).
+		Preload("Labels.Label").
+		Preload("Camera").
+		Preload("Lens").
+		Preload("Details").
+		Preload("Place").
+		Preload("Cell").
+		Preload("Cell.Place").
+		Where("taken_at BETWEEN ? AND ?", start.UTC(), end.UTC()).
+		Where("photo_quality > -1").
+		Order("taken_at ASC, photos.ID ASC").Find(&photos).Error
+
+	return photos, err
+}
+
 // OrphanPhotos finds orphan index entries that may be removed.
 func OrphanPhotos() (photos entity.Photos, err error) {
 	err = UnscopedDb().
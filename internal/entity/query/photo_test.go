@@ -142,6 +142,38 @@ func TestPhotosMetadataUpdate(t *testing.T) {
 	assert.IsType(t, entity.Photos{}, result)
 }
 
+func TestPhotosTakenBetween(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		start := time.Date(2016, 11, 11, 0, 0, 0, 0, time.UTC)
+		end := time.Date(2016, 11, 12, 0, 0, 0, 0, time.UTC)
+
+		result, err := PhotosTakenBetween(start, end)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, result)
+
+		for _, p := range result {
+			assert.False(t, p.TakenAt.Before(start))
+			assert.False(t, p.TakenAt.After(end))
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		start := time.Date(1800, 1, 1, 0, 0, 0, 0, time.UTC)
+		end := time.Date(1800, 1, 2, 0, 0, 0, 0, time.UTC)
+
+		result, err := PhotosTakenBetween(start, end)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, result)
+	})
+}
+
 // TestOrphanPhotos validates photo query behavior.
 func TestOrphanPhotos(t *testing.T) {
 	result, err := OrphanPhotos()
@@ -0,0 +1,78 @@
+package query
+
+import (
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+// UserStorage represents the storage used by the original files owned by a user account.
+type UserStorage struct {
+	UserUID  string `json:"UID"`
+	UserName string `json:"Name"`
+	Size     int64  `json:"Size"`
+	Files    int    `json:"Files"`
+}
+
+// Quotas returns all storage quotas sorted by type and subject.
+func Quotas() (result entity.Quotas, err error) {
+	result = entity.Quotas{}
+
+	err = Db().Order("subject_type, subject_uid").Find(&result).Error
+
+	return result, err
+}
+
+// UserStorageUsage returns the size in bytes and the number of original files owned by the specified user,
+// including files the user has uploaded that have not been indexed yet, see entity.AddPendingUpload,
+// and files uploaded with the user's share links that have not been imported yet.
+func UserStorageUsage(userUid string) (size int64, files int, err error) {
+	result := UserStorage{}
+
+	if userUid == "" {
+		return 0, 0, nil
+	}
+
+	err = UnscopedDb().Table(entity.File{}.TableName()).
+		Select("COALESCE(SUM(files.file_size), 0) AS size, COUNT(files.id) AS files").
+		Joins("JOIN photos ON photos.id = files.photo_id").
+		Where("photos.created_by = ? AND files.file_root = ? AND files.deleted_at IS NULL", userUid, entity.RootOriginals).
+		Take(&result).Error
+
+	pendingSize, pendingFiles := entity.PendingUploadUsage(userUid)
+	stagedSize, stagedFiles := entity.ShareUploadUsage(userUid)
+
+	return result.Size + pendingSize + stagedSize, result.Files + pendingFiles + stagedFiles, err
+}
+
+// UsersStorageUsage returns the storage used by each registered user that owns original files.
+func UsersStorageUsage() (result []UserStorage, err error) {
+	result = []UserStorage{}
+
+	err = UnscopedDb().Table(entity.File{}.TableName()).
+		Select("auth_users.user_uid, auth_users.user_name, COALESCE(SUM(files.file_size), 0) AS size, COUNT(files.id) AS files").
+		Joins("JOIN photos ON photos.id = files.photo_id").
+		Joins("JOIN auth_users ON auth_users.user_uid = photos.created_by").
+		Where("files.file_root = ? AND files.deleted_at IS NULL AND auth_users.deleted_at IS NULL", entity.RootOriginals).
+		Group("auth_users.user_uid, auth_users.user_name").
+		Order("auth_users.user_name").
+		Scan(&result).Error
+
+	return result, err
+}
+
+// CheckUserQuota returns entity.ErrQuotaExceeded if adding the specified number of bytes
+// and files would exceed the storage quota of the user account.
+func CheckUserQuota(user *entity.User, addSize int64, addFiles int) error {
+	quota := entity.UserQuota(user)
+
+	if quota.Unlimited() {
+		return nil
+	}
+
+	size, files, err := UserStorageUsage(user.UserUID)
+
+	if err != nil {
+		return err
+	}
+
+	return quota.Check(size, files, addSize, addFiles)
+}
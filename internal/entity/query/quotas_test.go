@@ -0,0 +1,111 @@
+package query
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestUserStorageUsage(t *testing.T) {
+	user := entity.UserFixtures.Pointer("friend")
+
+	photo := entity.NewUserPhoto(false, user.UserUID)
+
+	if err := photo.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	file := entity.File{
+		PhotoID:     photo.ID,
+		PhotoUID:    photo.PhotoUID,
+		FileName:    "quota-test.jpg",
+		FileRoot:    entity.RootOriginals,
+		FileSize:    1500,
+		FileHash:    "quota-test-hash",
+		FileType:    "jpg",
+		FilePrimary: true,
+	}
+
+	if err := file.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() {
+		UnscopedDb().Delete(&file)
+		UnscopedDb().Delete(&photo)
+	}()
+
+	t.Run("User", func(t *testing.T) {
+		size, files, err := UserStorageUsage(user.UserUID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(1500), size)
+		assert.Equal(t, 1, files)
+	})
+	t.Run("PendingUploads", func(t *testing.T) {
+		entity.AddPendingUpload("/originals/quota-pending.jpg", user.UserUID, 500)
+		defer entity.RemovePendingUpload("/originals/quota-pending.jpg")
+
+		size, files, err := UserStorageUsage(user.UserUID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(2000), size)
+		assert.Equal(t, 2, files)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		size, files, err := UserStorageUsage("")
+
+		assert.NoError(t, err)
+		assert.Equal(t, int64(0), size)
+		assert.Equal(t, 0, files)
+	})
+	t.Run("Users", func(t *testing.T) {
+		result, err := UsersStorageUsage()
+
+		assert.NoError(t, err)
+
+		found := false
+
+		for _, usage := range result {
+			if usage.UserUID == user.UserUID {
+				found = true
+				assert.Equal(t, "friend", usage.UserName)
+				assert.Equal(t, int64(1500), usage.Size)
+			}
+		}
+
+		assert.True(t, found)
+	})
+	t.Run("CheckUserQuota", func(t *testing.T) {
+		assert.NoError(t, CheckUserQuota(user, 1000, 1))
+
+		quota, err := entity.SetQuota(entity.QuotaUser, user.UserUID, 2000, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer quota.Delete()
+
+		assert.NoError(t, CheckUserQuota(user, 500, 1))
+		assert.True(t, errors.Is(CheckUserQuota(user, 501, 1), entity.ErrQuotaExceeded))
+	})
+}
+
+func TestQuotas(t *testing.T) {
+	quota, err := entity.SetQuota(entity.QuotaRole, "admin", 0, 1000)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer quota.Delete()
+
+	result, err := Quotas()
+
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, 1, len(result))
+}
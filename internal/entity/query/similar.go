@@ -0,0 +1,50 @@
+package query
+
+import (
+	"time"
+)
+
+// SimilarPhoto represents a photo with the perceptual hash of its primary file.
+type SimilarPhoto struct {
+	PhotoID      uint      `json:"-"`
+	PhotoUID     string    `json:"UID"`
+	PhotoTitle   string    `json:"Title"`
+	TakenAt      time.Time `json:"TakenAt"`
+	TakenSrc     string    `json:"-"`
+	CameraID     uint      `json:"-"`
+	PhotoQuality int       `json:"Quality"`
+	UpdatedAt    time.Time `json:"-"`
+	FileUID      string    `json:"FileUID"`
+	FileRoot     string    `json:"-"`
+	FileName     string    `json:"-"`
+	FileHash     string    `json:"Hash"`
+	FileWidth    int       `json:"Width"`
+	FileHeight   int       `json:"Height"`
+	FileSize     int64     `json:"Size"`
+	FilePhash    string    `json:"Phash"`
+}
+
+// Pixels returns the resolution of the primary file in pixels.
+func (m SimilarPhoto) Pixels() int {
+	return m.FileWidth * m.FileHeight
+}
+
+// SimilarPhotos returns the photos whose primary file has a perceptual hash, sorted by the time they were taken.
+// If no photo UIDs are specified, all photos that have not been archived are returned.
+func SimilarPhotos(uids ...string) (results []SimilarPhoto, err error) {
+	stmt := UnscopedDb().Table("files").
+		Select("photos.id AS photo_id, photos.photo_uid, photos.photo_title, photos.taken_at, photos.taken_src, photos.camera_id, " +
+			"photos.photo_quality, photos.updated_at, files.file_uid, files.file_root, files.file_name, files.file_hash, " +
+			"files.file_width, files.file_height, files.file_size, files.file_phash").
+		Joins("JOIN photos ON photos.id = files.photo_id").
+		Where("files.file_primary = 1 AND files.file_missing = 0 AND files.deleted_at IS NULL AND files.file_phash <> ''").
+		Where("photos.deleted_at IS NULL AND photos.photo_quality > -1")
+
+	if len(uids) > 0 {
+		stmt = stmt.Where("photos.photo_uid IN (?)", uids)
+	}
+
+	err = stmt.Order("photos.taken_at, photos.id").Scan(&results).Error
+
+	return results, err
+}
@@ -0,0 +1,62 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestSimilarPhotos(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		file := entity.FileFixtures.Get("exampleFileName.jpg")
+
+		if err := entity.Db().Model(&entity.File{}).Where("file_uid = ?", file.FileUID).UpdateColumn("file_phash", "f0e1d2c3b4a59687").Error; err != nil {
+			t.Fatal(err)
+		}
+
+		defer entity.Db().Model(&entity.File{}).Where("file_uid = ?", file.FileUID).UpdateColumn("file_phash", "")
+
+		results, err := SimilarPhotos()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		found := false
+
+		for _, r := range results {
+			assert.NotEmpty(t, r.FilePhash)
+
+			if r.FileUID == file.FileUID {
+				found = true
+				assert.Equal(t, "f0e1d2c3b4a59687", r.FilePhash)
+				assert.Equal(t, file.PhotoUID, r.PhotoUID)
+				assert.Equal(t, file.FileName, r.FileName)
+				assert.Equal(t, file.FileRoot, r.FileRoot)
+				assert.Equal(t, r.FileWidth*r.FileHeight, r.Pixels())
+			}
+		}
+
+		assert.True(t, found)
+
+		// Filter by photo UID.
+		results, err = SimilarPhotos(file.PhotoUID)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, results, 1)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		results, err := SimilarPhotos("ps6sg6be2lvl0yh0")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+}
@@ -0,0 +1,71 @@
+package query
+
+import (
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// Webhooks finds registered webhooks and returns them.
+func Webhooks(limit, offset int, search string) (result entity.Webhooks, err error) {
+	result = entity.Webhooks{}
+	stmt := Db()
+
+	search = strings.TrimSpace(search)
+
+	if search == "all" {
+		// Don't filter.
+	} else if rnd.IsUID(search, entity.WebhookUID) {
+		stmt = stmt.Where("webhook_uid = ?", search)
+	} else if search != "" {
+		stmt = stmt.Where("webhook_name LIKE ? OR webhook_url LIKE ?", search+"%", "%"+search+"%")
+	}
+
+	if limit > 0 {
+		stmt = stmt.Limit(limit)
+
+		if offset > 0 {
+			stmt = stmt.Offset(offset)
+		}
+	}
+
+	err = stmt.Order("created_at, webhook_uid").Find(&result).Error
+
+	return result, err
+}
+
+// WebhookDeliveries returns the delivery log of a webhook, optionally filtered by status, newest first.
+func WebhookDeliveries(webhookUid, status string, limit, offset int) (result entity.WebhookDeliveries, err error) {
+	result = entity.WebhookDeliveries{}
+	stmt := Db().Where("webhook_uid = ?", webhookUid)
+
+	if status != "" {
+		stmt = stmt.Where("status = ?", status)
+	}
+
+	if limit > 0 {
+		stmt = stmt.Limit(limit)
+
+		if offset > 0 {
+			stmt = stmt.Offset(offset)
+		}
+	}
+
+	err = stmt.Order("id DESC").Find(&result).Error
+
+	return result, err
+}
+
+// DueWebhookDeliveries returns pending deliveries that should be attempted now, oldest first.
+func DueWebhookDeliveries(limit int) (result entity.WebhookDeliveries, err error) {
+	result = entity.WebhookDeliveries{}
+
+	err = Db().
+		Where("status = ? AND next_attempt <= ?", entity.WebhookPending, time.Now().UTC()).
+		Order("id").Limit(limit).
+		Find(&result).Error
+
+	return result, err
+}
@@ -0,0 +1,74 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestWebhooks(t *testing.T) {
+	t.Run("All", func(t *testing.T) {
+		if results, err := Webhooks(0, 0, "all"); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.LessOrEqual(t, 2, len(results))
+		}
+	})
+	t.Run("Limit", func(t *testing.T) {
+		if results, err := Webhooks(1, 1, ""); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Len(t, results, 1)
+		}
+	})
+	t.Run("SearchByUID", func(t *testing.T) {
+		if results, err := Webhooks(10, 0, "wt2hdkz1vgdb3ta2"); err != nil {
+			t.Fatal(err)
+		} else if assert.Len(t, results, 1) {
+			assert.Equal(t, "Automation", results[0].WebhookName)
+		}
+	})
+	t.Run("SearchByName", func(t *testing.T) {
+		if results, err := Webhooks(10, 0, "disab"); err != nil {
+			t.Fatal(err)
+		} else if assert.Len(t, results, 1) {
+			assert.Equal(t, "wt2hdl31ydq9yw0k", results[0].WebhookUID)
+		}
+	})
+}
+
+func TestWebhookDeliveries(t *testing.T) {
+	t.Run("Webhook", func(t *testing.T) {
+		if results, err := WebhookDeliveries("wt2hdkz1vgdb3ta2", "", 10, 0); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.LessOrEqual(t, 1, len(results))
+		}
+	})
+	t.Run("Failed", func(t *testing.T) {
+		if results, err := WebhookDeliveries("wt2hdl31ydq9yw0k", entity.WebhookFailed, 10, 0); err != nil {
+			t.Fatal(err)
+		} else if assert.Len(t, results, 1) {
+			assert.Equal(t, uint(1000001), results[0].ID)
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		if results, err := WebhookDeliveries("wt2hdl31ydq9yw0k", entity.WebhookDelivered, 10, 0); err != nil {
+			t.Fatal(err)
+		} else {
+			assert.Empty(t, results)
+		}
+	})
+}
+
+func TestDueWebhookDeliveries(t *testing.T) {
+	if results, err := DueWebhookDeliveries(100); err != nil {
+		t.Fatal(err)
+	} else {
+		for _, r := range results {
+			assert.Equal(t, entity.WebhookPending, r.Status)
+		}
+	}
+}
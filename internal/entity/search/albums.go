@@ -44,7 +44,7 @@ func UserAlbums(frm form.SearchAlbums, sess *entity.Session) (results AlbumResul
 		// Determine resource to check.
 		var aclResource acl.Resource
 		switch frm.Type {
-		case entity.AlbumManual:
+		case entity.AlbumManual, entity.AlbumSmart:
 			aclResource = acl.ResourceAlbums
 		case entity.AlbumFolder:
 			aclResource = acl.ResourceFolders
@@ -65,7 +65,9 @@ func UserAlbums(frm form.SearchAlbums, sess *entity.Session) (results AlbumResul
 		if sess.IsVisitor() || sess.NotRegistered() {
 			s = s.Where("albums.album_uid IN (?) OR albums.published_at > ?", sess.SharedUIDs(), entity.Now())
 		} else if acl.Rules.DenyAll(aclResource, aclRole, acl.Permissions{acl.AccessAll, acl.AccessLibrary}) {
-			s = s.Where("albums.album_uid IN (?) OR albums.created_by = ? OR albums.published_at > ?", sess.SharedUIDs(), user.UserUID, entity.Now())
+			grants := user.GrantUIDs()
+			s = s.Where("albums.album_uid IN (?) OR "+grantedAlbums+" OR albums.created_by = ? OR albums.published_at > ?",
+				sess.SharedUIDs(), grants, grants, user.UserUID, entity.Now())
 		}
 
 		// Exclude private content?
@@ -80,7 +82,7 @@ func UserAlbums(frm form.SearchAlbums, sess *entity.Session) (results AlbumResul
 	case sortby.Count:
 		s = s.Order(OrderExpr("photo_count DESC, albums.album_title, albums.album_uid DESC", frm.Reverse))
 	case sortby.Moment, sortby.Newest:
-		if frm.Type == entity.AlbumManual || frm.Type == entity.AlbumState {
+		if frm.Type == entity.AlbumManual || frm.Type == entity.AlbumSmart || frm.Type == entity.AlbumState {
 			s = s.Order(OrderExpr("albums.album_uid DESC", frm.Reverse))
 		} else if frm.Type == entity.AlbumMoment {
 			s = s.Order(OrderExpr("has_year, albums.album_year DESC, albums.album_month DESC, albums.album_day DESC, albums.album_title, albums.album_uid DESC", frm.Reverse))
@@ -88,7 +90,7 @@ func UserAlbums(frm form.SearchAlbums, sess *entity.Session) (results AlbumResul
 			s = s.Order(OrderExpr("albums.album_year DESC, albums.album_month DESC, albums.album_day DESC, albums.album_title, albums.album_uid DESC", frm.Reverse))
 		}
 	case sortby.Oldest:
-		if frm.Type == entity.AlbumManual || frm.Type == entity.AlbumState {
+		if frm.Type == entity.AlbumManual || frm.Type == entity.AlbumSmart || frm.Type == entity.AlbumState {
 			s = s.Order(OrderExpr("albums.album_uid ASC", frm.Reverse))
 		} else if frm.Type == entity.AlbumMoment {
 			s = s.Order(OrderExpr("has_year, albums.album_year ASC, albums.album_month ASC, albums.album_day ASC, albums.album_title, albums.album_uid ASC", frm.Reverse))
@@ -159,7 +161,14 @@ func UserAlbums(frm form.SearchAlbums, sess *entity.Session) (results AlbumResul
 	}
 
 	if txt.NotEmpty(frm.Type) {
-		s = s.Where("albums.album_type IN (?)", strings.Split(frm.Type, txt.Or))
+		types := strings.Split(frm.Type, txt.Or)
+
+		// Smart albums are listed together with regular albums.
+		if frm.Type == entity.AlbumManual {
+			types = append(types, entity.AlbumSmart)
+		}
+
+		s = s.Where("albums.album_type IN (?)", types)
 	}
 
 	if txt.NotEmpty(frm.Category) {
@@ -21,6 +21,39 @@ func TestAlbumPhotos(t *testing.T) {
 			t.Errorf("at least 2 results expected: %d", len(results))
 		}
 	})
+	t.Run("SmartAlbum", func(t *testing.T) {
+		album := entity.NewSmartAlbum("Favorite Smart Album", "favorite:true", "", entity.OwnerUnknown)
+
+		if err := album.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		results, err := AlbumPhotos(*album, 100, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotEmpty(t, results)
+
+		for _, r := range results {
+			assert.True(t, r.PhotoFavorite)
+		}
+
+		// Smart albums are listed together with regular albums.
+		query := form.NewAlbumSearch("Favorite Smart")
+		query.Type = entity.AlbumManual
+		albums, err := Albums(query)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, albums, 1) {
+			assert.Equal(t, entity.AlbumSmart, albums[0].AlbumType)
+			assert.Equal(t, "favorite:true", albums[0].AlbumFilter)
+		}
+	})
 }
 
 func TestUserAlbums(t *testing.T) {
@@ -744,3 +777,41 @@ func TestAlbums(t *testing.T) {
 		assert.Equal(t, false, result[0].AlbumFavorite)
 	})
 }
+
+func TestUserAlbums_Grants(t *testing.T) {
+	sess := entity.NewSession(0, 0).SetUser(entity.UserFixtures.Pointer("guest"))
+	frm := form.SearchAlbums{Type: entity.AlbumManual, Count: 1000}
+	albumUid := "as6sg6bxpogaaba7"
+
+	results, err := UserAlbums(frm, sess)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, r := range results {
+		assert.NotEqual(t, albumUid, r.AlbumUID)
+	}
+
+	grant, err := entity.ShareAlbum(albumUid, "tt2hdkz1vgdb3ta2", entity.PermView)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _ = grant.Delete() }()
+
+	if results, err = UserAlbums(frm, sess); err != nil {
+		t.Fatal(err)
+	}
+
+	found := false
+
+	for _, r := range results {
+		if r.AlbumUID == albumUid {
+			found = true
+		}
+	}
+
+	assert.True(t, found)
+}
@@ -10,6 +10,16 @@ import (
 	"github.com/jinzhu/inflection"
 )
 
+// Conditions matching the photos and albums for which permissions were granted to a user or team, see
+// entity.PhotoUser and entity.AlbumUser. The UIDs returned by entity.User.GrantUIDs must be passed once
+// for each placeholder.
+const (
+	grantedAlbums = "albums.album_uid IN (SELECT uid FROM albums_users WHERE user_uid IN (?) OR team_uid IN (?))"
+	grantedPhotos = "photos.photo_uid IN (SELECT uid FROM photos_users WHERE user_uid IN (?) OR team_uid IN (?)) OR " +
+		"photos.photo_uid IN (SELECT photo_uid FROM photos_albums WHERE hidden = 0 AND missing = 0 AND album_uid IN " +
+		"(SELECT uid FROM albums_users WHERE user_uid IN (?) OR team_uid IN (?)))"
+)
+
 // Like sanitizes user input so it can be safely interpolated into SQL LIKE
 // expressions. It strips operators that we don't expect to persist in the
 // statement and lets callers provide their own surrounding wildcards.
@@ -13,4 +13,5 @@ var (
 	ErrBadSortOrder = fmt.Errorf("invalid sort order")
 	ErrBadFilter    = fmt.Errorf("invalid search filter")
 	ErrInvalidId    = fmt.Errorf("invalid ID specified")
+	ErrNoEmbedding  = fmt.Errorf("semantic search is not available")
 )
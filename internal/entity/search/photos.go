@@ -56,6 +56,12 @@ func searchPhotos(frm form.SearchPhotos, sess *entity.Session, resultCols string
 	// Parse query string and filter.
 	if err = frm.ParseQueryString(); err != nil {
 		log.Debugf("search: %s", err)
+
+		// Return query syntax errors so that they can be shown to the user.
+		if form.IsQueryError(err) {
+			return PhotoResults{}, 0, err
+		}
+
 		return PhotoResults{}, 0, ErrBadRequest
 	}
 
@@ -73,6 +79,13 @@ func searchPhotos(frm form.SearchPhotos, sess *entity.Session, resultCols string
 		frm.S2 = photo.CellID
 	}
 
+	// Rank pictures by the similarity of their embeddings?
+	semantic, semanticErr := semanticSearch(&frm)
+
+	if semanticErr != nil {
+		return PhotoResults{}, 0, semanticErr
+	}
+
 	// Set default search distance.
 	if frm.Dist <= 0 {
 		frm.Dist = geo.DefaultDist
@@ -93,6 +106,18 @@ func searchPhotos(frm form.SearchPhotos, sess *entity.Session, resultCols string
 		Joins("LEFT JOIN lenses ON photos.lens_id = lenses.id").
 		Joins("LEFT JOIN places ON photos.place_id = places.id")
 
+	// Filter by boolean query expression, e.g. with OR, NOT, or ranges.
+	if frm.Expr != nil {
+		where, values, exprErr := PhotosQuery(frm.Expr)
+
+		if exprErr != nil {
+			log.Debugf("search: %s", exprErr)
+			return PhotoResults{}, 0, exprErr
+		}
+
+		s = s.Where(where, values...)
+	}
+
 	// Accept the album UID as scope for backward compatibility.
 	if rnd.IsUID(frm.Album, entity.AlbumUID) {
 		if txt.Empty(frm.Scope) {
@@ -169,15 +194,25 @@ func searchPhotos(frm form.SearchPhotos, sess *entity.Session, resultCols string
 
 			if sess.IsVisitor() || sess.NotRegistered() {
 				s = s.Where(sharedAlbums+"photos.published_at > ?", sess.SharedUIDs(), entity.Now())
-			} else if basePath := user.GetBasePath(); basePath == "" {
-				s = s.Where(sharedAlbums+"photos.created_by = ? OR photos.published_at > ?", sess.SharedUIDs(), user.UserUID, entity.Now())
+			} else if grants, basePath := user.GrantUIDs(), user.GetBasePath(); basePath == "" {
+				s = s.Where(sharedAlbums+grantedPhotos+" OR photos.created_by = ? OR photos.published_at > ?",
+					sess.SharedUIDs(), grants, grants, grants, grants, user.UserUID, entity.Now())
 			} else {
-				s = s.Where(sharedAlbums+"photos.created_by = ? OR photos.published_at > ? OR photos.photo_path = ? OR photos.photo_path LIKE ?",
-					sess.SharedUIDs(), user.UserUID, entity.Now(), basePath, basePath+"/%")
+				s = s.Where(sharedAlbums+grantedPhotos+" OR photos.created_by = ? OR photos.published_at > ? OR photos.photo_path = ? OR photos.photo_path LIKE ?",
+					sess.SharedUIDs(), grants, grants, grants, grants, user.UserUID, entity.Now(), basePath, basePath+"/%")
 			}
 		}
 	}
 
+	// Find the most similar pictures only.
+	if semantic != nil {
+		if len(semantic) == 0 {
+			return PhotoResults{}, 0, nil
+		}
+
+		s = s.Where("photos.id IN (?)", semantic.IDs())
+	}
+
 	// Set sort order.
 	switch frm.Order {
 	case sortby.Edited:
@@ -192,6 +227,14 @@ func searchPhotos(frm form.SearchPhotos, sess *entity.Session, resultCols string
 		} else {
 			s = s.Order(OrderExpr("photos.photo_quality DESC, files.time_index", frm.Reverse))
 		}
+	case sortby.Rank:
+		// Sort by full-text search relevance, if possible.
+		if rankSql, rankArgs := entity.SearchIndexQuery(frm.Query); rankSql != "" {
+			s = s.Joins("LEFT JOIN ("+rankSql+") photos_rank ON photos_rank.photo_id = files.photo_id", rankArgs...).
+				Order(OrderExpr("COALESCE(photos_rank.rank_score, 0) ASC, files.time_index", frm.Reverse))
+		} else {
+			s = s.Order(OrderExpr("files.time_index", frm.Reverse))
+		}
 	case sortby.Duration:
 		s = s.Order(OrderExpr("photos.photo_duration DESC, files.time_index", frm.Reverse))
 	case sortby.Size:
@@ -401,10 +444,19 @@ func searchPhotos(frm form.SearchPhotos, sess *entity.Session, resultCols string
 		var labelIds []uint
 
 		if labelsErr := Db().Where(AnySlug("custom_slug", frm.Query, " ")).Find(&labels).Error; len(labels) == 0 || labelsErr != nil {
-			log.Tracef("search: label %s not found, using fuzzy search", txt.LogParamLower(frm.Query))
+			if matchSql, matchArgs := entity.SearchIndexQuery(frm.Query); matchSql != "" {
+				log.Tracef("search: label %s not found, using full-text search", txt.LogParamLower(frm.Query))
+
+				// Photos that are waiting for an index update are matched by their keywords instead.
+				s = s.Where(fmt.Sprintf("files.photo_id IN (SELECT photo_id FROM (%s) photos_match) OR files.photo_id IN (SELECT photo_id FROM %s) AND (%s)",
+					matchSql, entity.PhotoSearchQueue{}.TableName(), photosQueryAll("files.photo_id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (%s))",
+						LikeAnyKeyword("k.keyword", frm.Query))), matchArgs...)
+			} else {
+				log.Tracef("search: label %s not found, using fuzzy search", txt.LogParamLower(frm.Query))
 
-			for _, where := range LikeAnyKeyword("k.keyword", frm.Query) {
-				s = s.Where("files.photo_id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?))", gorm.Expr(where))
+				for _, where := range LikeAnyKeyword("k.keyword", frm.Query) {
+					s = s.Where("files.photo_id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (?))", gorm.Expr(where))
+				}
 			}
 		} else {
 			for _, l := range labels {
@@ -837,13 +889,29 @@ func searchPhotos(frm form.SearchPhotos, sess *entity.Session, resultCols string
 		frm.Count = MaxResults
 	}
 
-	s = s.Limit(frm.Count).Offset(frm.Offset)
+	// Semantic search results are sorted by similarity after the query.
+	if semantic == nil {
+		s = s.Limit(frm.Count).Offset(frm.Offset)
+	}
 
 	// Query database.
 	if err = s.Scan(&results).Error; err != nil {
 		return results, 0, err
 	}
 
+	// Sort semantic search results by similarity and apply count and offset.
+	if semantic != nil {
+		semantic.Sort(results)
+
+		if frm.Offset >= len(results) {
+			results = PhotoResults{}
+		} else if end := frm.Offset + frm.Count; end < len(results) {
+			results = results[frm.Offset:end]
+		} else {
+			results = results[frm.Offset:]
+		}
+	}
+
 	// Log number of results.
 	log.Debugf("photos: found %s for %s [%s]", english.Plural(len(results), "result", "results"), frm.SerializeAll(), time.Since(start))
 
@@ -147,11 +147,12 @@ func UserPhotosGeo(frm form.SearchPhotosGeo, sess *entity.Session) (results GeoR
 
 			if sess.IsVisitor() || sess.NotRegistered() {
 				s = s.Where(sharedAlbums+"photos.published_at > ?", sess.SharedUIDs(), entity.Now())
-			} else if basePath := user.GetBasePath(); basePath == "" {
-				s = s.Where(sharedAlbums+"photos.created_by = ? OR photos.published_at > ?", sess.SharedUIDs(), user.UserUID, entity.Now())
+			} else if grants, basePath := user.GrantUIDs(), user.GetBasePath(); basePath == "" {
+				s = s.Where(sharedAlbums+grantedPhotos+" OR photos.created_by = ? OR photos.published_at > ?",
+					sess.SharedUIDs(), grants, grants, grants, grants, user.UserUID, entity.Now())
 			} else {
-				s = s.Where(sharedAlbums+"photos.created_by = ? OR photos.published_at > ? OR photos.photo_path = ? OR photos.photo_path LIKE ?",
-					sess.SharedUIDs(), user.UserUID, entity.Now(), basePath, basePath+"/%")
+				s = s.Where(sharedAlbums+grantedPhotos+" OR photos.created_by = ? OR photos.published_at > ? OR photos.photo_path = ? OR photos.photo_path LIKE ?",
+					sess.SharedUIDs(), grants, grants, grants, grants, user.UserUID, entity.Now(), basePath, basePath+"/%")
 			}
 		}
 	}
@@ -0,0 +1,497 @@
+package search
+
+import (
+	"fmt"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/enum"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/media"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// PhotosQuery returns the SQL condition and values for a boolean search query expression, see form.ParseQuery.
+// Terms are matched per photo, so that they can be negated and combined with OR.
+func PhotosQuery(expr *form.QueryExpr) (where string, values []interface{}, err error) {
+	if expr == nil {
+		return "", nil, nil
+	}
+
+	switch expr.Op {
+	case form.QueryAnd, form.QueryOr:
+		wheres := make([]string, 0, len(expr.Nodes))
+
+		for _, n := range expr.Nodes {
+			w, v, nodeErr := PhotosQuery(n)
+
+			if nodeErr != nil {
+				return "", nil, nodeErr
+			}
+
+			wheres = append(wheres, "("+w+")")
+			values = append(values, v...)
+		}
+
+		return strings.Join(wheres, " "+expr.Op+" "), values, nil
+	case form.QueryNot:
+		if where, values, err = PhotosQuery(expr.Nodes[0]); err != nil {
+			return "", nil, err
+		}
+
+		return "NOT (" + where + ")", values, nil
+	default:
+		return photosQueryTerm(expr)
+	}
+}
+
+// photosQueryTerm returns the SQL condition and values for a single search query term.
+func photosQueryTerm(t *form.QueryExpr) (where string, values []interface{}, err error) {
+	// Only numbers and dates can be searched by range.
+	switch t.Key {
+	case "iso", "mm", "f", "mp", "alt", "year", "month", "day", "taken", "added", "updated", "edited":
+	default:
+		if t.Range {
+			return "", nil, form.NewQueryError(t.Pos, "%s filter does not support ranges", t.Key)
+		}
+	}
+
+	// Values are sanitized in the same way as the search form fields.
+	v := clean.SearchString(t.Value)
+
+	if t.Key != "" && v == "" && !t.Range {
+		return "", nil, form.NewQueryError(t.Pos, "missing %s filter value", t.Key)
+	}
+
+	switch t.Key {
+	case "":
+		return photosQueryText(v, t.Phrase)
+	case "label":
+		slugs := make([]string, 0, 2)
+
+		for _, s := range SplitOr(v) {
+			slugs = append(slugs, txt.Slug(s))
+		}
+
+		// Labels also match the labels in their category, e.g. "animal" also finds cats.
+		return "photos.id IN (SELECT pl.photo_id FROM photos_labels pl JOIN labels l ON l.id = pl.label_id WHERE pl.uncertainty < 100 AND " +
+			"(l.label_slug IN (?) OR l.custom_slug IN (?) OR l.id IN (SELECT c.label_id FROM categories c JOIN labels lc ON lc.id = c.category_id " +
+			"WHERE lc.label_slug IN (?) OR lc.custom_slug IN (?))))", []interface{}{slugs, slugs, slugs, slugs}, nil
+	case "keywords":
+		return photosQueryAll("photos.id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (%s))",
+			LikeAnyWord("k.keyword", v)), nil, nil
+	case "subject", "person":
+		var wheres []string
+
+		for _, subj := range SplitAnd(strings.ToLower(v)) {
+			if subjects := SplitOr(subj); rnd.ContainsUID(subjects, 'j') {
+				wheres = append(wheres, "photos.id IN (SELECT f.photo_id FROM files f JOIN markers m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 WHERE m.subj_uid IN (?))")
+				values = append(values, subjects)
+			} else {
+				wheres = append(wheres, fmt.Sprintf("photos.id IN (SELECT f.photo_id FROM files f JOIN markers m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 "+
+					"JOIN subjects s ON s.subj_uid = m.subj_uid WHERE (%s))", AnySlug("s.subj_slug", subj, txt.Or)))
+			}
+		}
+
+		return strings.Join(wheres, " AND "), values, nil
+	case "subjects", "people":
+		return photosQueryAll("photos.id IN (SELECT f.photo_id FROM files f JOIN markers m ON f.file_uid = m.file_uid AND m.marker_invalid = 0 "+
+			"JOIN subjects s ON s.subj_uid = m.subj_uid WHERE (%s))", LikeAllNames(Cols{"subj_name", "subj_alias"}, v)), nil, nil
+	case "album":
+		if rnd.IsUID(v, entity.AlbumUID) {
+			return "photos.photo_uid IN (SELECT photo_uid FROM photos_albums WHERE hidden = 0 AND album_uid = ?)", []interface{}{v}, nil
+		}
+
+		like := strings.Trim(v, "*%") + "%"
+
+		return "photos.photo_uid IN (SELECT pa.photo_uid FROM photos_albums pa JOIN albums a ON a.album_uid = pa.album_uid AND pa.hidden = 0 " +
+			"WHERE (a.album_title LIKE ? OR a.album_slug LIKE ?))", []interface{}{like, like}, nil
+	case "albums":
+		return photosQueryAll("photos.photo_uid IN (SELECT pa.photo_uid FROM photos_albums pa JOIN albums a ON a.album_uid = pa.album_uid AND pa.hidden = 0 WHERE (%s))",
+			LikeAnyWord("a.album_title", v)), nil, nil
+	case "country":
+		return "photos.photo_country IN (?)", []interface{}{SplitOr(strings.ToLower(v))}, nil
+	case "state":
+		return "photos.place_id IN (SELECT id FROM places WHERE place_state IN (?))", []interface{}{SplitOr(v)}, nil
+	case "city":
+		return "photos.place_id IN (SELECT id FROM places WHERE place_city IN (?))", []interface{}{SplitOr(v)}, nil
+	case "category":
+		return "photos.cell_id IN (SELECT id FROM cells WHERE cell_category IN (?))", []interface{}{SplitOr(strings.ToLower(v))}, nil
+	case "camera":
+		if txt.IsPosInt(v) {
+			return "photos.camera_id = ?", []interface{}{txt.UInt(v)}, nil
+		}
+
+		like := strings.Trim(v, "*%") + "%"
+
+		return "photos.camera_id IN (SELECT id FROM cameras WHERE camera_name LIKE ? OR camera_model LIKE ? OR camera_slug LIKE ?)", []interface{}{like, like, like}, nil
+	case "lens":
+		if txt.IsPosInt(v) {
+			return "photos.lens_id = ?", []interface{}{txt.UInt(v)}, nil
+		}
+
+		like := strings.Trim(v, "*%") + "%"
+
+		return "photos.lens_id IN (SELECT id FROM lenses WHERE lens_name LIKE ? OR lens_model LIKE ? OR lens_slug LIKE ?)", []interface{}{like, like, like}, nil
+	case "iso":
+		return photosQueryIntRange(t, "photos.photo_iso", 0, 10000000)
+	case "mm":
+		return photosQueryIntRange(t, "photos.photo_focal_length", 0, 10000000)
+	case "mp":
+		return photosQueryIntRange(t, "photos.photo_resolution", 0, 32000)
+	case "alt":
+		return photosQueryIntRange(t, "photos.photo_altitude", -6378000, 1000000000)
+	case "f":
+		start, end, rangeErr := photosQueryFloatRange(t, 0, 10000000)
+
+		if rangeErr != nil {
+			return "", nil, rangeErr
+		}
+
+		return "photos.photo_f_number >= ? AND photos.photo_f_number <= ?", []interface{}{start - 0.01, end + 0.01}, nil
+	case "year":
+		if !t.Range {
+			return photosQueryAnyInt(t, "photos.photo_year", entity.UnknownYear, txt.YearMax)
+		}
+
+		return photosQueryIntRange(t, "photos.photo_year", entity.UnknownYear, txt.YearMax)
+	case "month":
+		if !t.Range {
+			return photosQueryAnyInt(t, "photos.photo_month", entity.UnknownMonth, txt.MonthMax)
+		}
+
+		return photosQueryIntRange(t, "photos.photo_month", entity.UnknownMonth, txt.MonthMax)
+	case "day":
+		if !t.Range {
+			return photosQueryAnyInt(t, "photos.photo_day", entity.UnknownDay, txt.DayMax)
+		}
+
+		return photosQueryIntRange(t, "photos.photo_day", entity.UnknownDay, txt.DayMax)
+	case "taken":
+		return photosQueryTimeRange(t, "photos.taken_at")
+	case "added":
+		return photosQueryTimeRange(t, "photos.created_at")
+	case "updated":
+		return photosQueryTimeRange(t, "photos.updated_at")
+	case "edited":
+		return photosQueryTimeRange(t, "photos.edited_at")
+	case "before", "after":
+		start, _, timeErr := photosQueryTime(t, v)
+
+		if timeErr != nil {
+			return "", nil, timeErr
+		} else if t.Key == "before" {
+			return "photos.taken_at < ?", []interface{}{photosQueryTimeValue(start)}, nil
+		}
+
+		return "photos.taken_at >= ?", []interface{}{photosQueryTimeValue(start)}, nil
+	case "title":
+		return photosQueryLike(v, "photos.photo_title")
+	case "caption":
+		return photosQueryLike(v, "photos.photo_caption")
+	case "description":
+		switch v {
+		case enum.False:
+			return "photos.photo_title = '' AND photos.photo_caption = ''", nil, nil
+		case enum.True:
+			return "photos.photo_title <> '' OR photos.photo_caption <> ''", nil, nil
+		default:
+			where, values = OrLikeCols([]string{"photos.photo_title", "photos.photo_caption"}, v)
+			return where, values, nil
+		}
+	case "path", "folder":
+		if p := strings.TrimPrefix(v, "/"); strings.HasSuffix(p, "/") {
+			return "photos.photo_path = ?", []interface{}{strings.TrimSuffix(p, "/")}, nil
+		} else {
+			where, values = OrLike("photos.photo_path", p)
+			return where, values, nil
+		}
+	case "name":
+		where, values = OrLike("photos.photo_name", fs.StripKnownExt(v))
+
+		// Omit file path and known extensions.
+		for i := range values {
+			values[i] = fs.StripKnownExt(path.Base(values[i].(string)))
+		}
+
+		return where, values, nil
+	case "filename":
+		where, values = OrLike("f.file_name", v)
+		return "photos.id IN (SELECT f.photo_id FROM files f WHERE f.deleted_at IS NULL AND (" + where + "))", values, nil
+	case "original":
+		where, values = OrLike("photos.original_name", v)
+		return where, values, nil
+	case "hash":
+		return "photos.id IN (SELECT photo_id FROM files WHERE file_hash IN (?))", []interface{}{SplitOr(strings.ToLower(v))}, nil
+	case "uid":
+		uids := SplitOr(strings.ToLower(v))
+		return "photos.photo_uid IN (?) OR photos.id IN (SELECT photo_id FROM files WHERE file_uid IN (?))", []interface{}{uids, uids}, nil
+	case "type":
+		return "photos.photo_type IN (?)", []interface{}{SplitOr(strings.ToLower(v))}, nil
+	case "color":
+		return "files.file_main_color IN (?)", []interface{}{SplitOr(strings.ToLower(v))}, nil
+	case "codec":
+		return "files.file_codec IN (?)", []interface{}{SplitOr(strings.ToLower(v))}, nil
+	case "faces":
+		switch {
+		case txt.IsUInt(v):
+			return "photos.photo_faces >= ?", []interface{}{txt.Int(v)}, nil
+		case txt.No(v):
+			return "photos.photo_faces = 0", nil, nil
+		default:
+			return "photos.photo_faces > 0", nil, nil
+		}
+	case "geo":
+		return photosQueryFlag(v, "photos.cell_id <> 'zz'")
+	case "favorite":
+		return photosQueryFlag(v, "photos.photo_favorite = 1")
+	case "scan":
+		return photosQueryFlag(v, "photos.photo_scan = 1")
+	case "private":
+		return photosQueryFlag(v, "photos.photo_private = 1")
+	case "public":
+		return photosQueryFlag(v, "photos.photo_private = 0")
+	case "panorama":
+		return photosQueryFlag(v, "photos.photo_panorama = 1")
+	case "portrait":
+		return photosQueryFlag(v, "files.file_portrait = 1")
+	case "landscape":
+		return photosQueryFlag(v, "files.file_aspect_ratio > 1.25")
+	case "square":
+		return photosQueryFlag(v, "files.file_aspect_ratio = 1")
+	case "mono":
+		return photosQueryFlag(v, "files.file_chroma = 0")
+	case "unsorted":
+		return photosQueryFlag(v, "photos.photo_uid NOT IN (SELECT photo_uid FROM photos_albums pa JOIN albums a ON a.album_uid = pa.album_uid WHERE pa.hidden = 0 AND a.deleted_at IS NULL)")
+	case "photo":
+		return photosQueryFlag(v, "photos.photo_type IN ('image','raw','live')")
+	case "media":
+		return photosQueryFlag(v, "photos.photo_type IN ('live','video','audio','animated')")
+	case "image", "raw", "live", "video", "audio", "animated", "vector", "document":
+		return photosQueryFlag(v, "photos.photo_type = ?", media.Type(t.Key))
+	}
+
+	if (&form.SearchPhotos{}).HasFilter(t.Key) {
+		return "", nil, form.NewQueryError(t.Pos, "%s filter cannot be combined with OR, NOT, or ranges", t.Key)
+	}
+
+	return "", nil, form.NewQueryError(t.Pos, "unknown filter %s", t.Key)
+}
+
+// photosQueryText returns the SQL condition and values for words and quoted phrases without a filter name.
+func photosQueryText(s string, phrase bool) (where string, values []interface{}, err error) {
+	if s == "" {
+		return "1 = 1", nil, nil
+	}
+
+	// Phrases must be found as they are in the title, caption, or keywords.
+	if phrase {
+		like := "%" + Like(s) + "%"
+
+		return "photos.photo_title LIKE ? OR photos.photo_caption LIKE ? OR photos.id IN (SELECT photo_id FROM details WHERE keywords LIKE ?)",
+			[]interface{}{like, like, like}, nil
+	}
+
+	// Find matching keywords and labels.
+	slug := txt.Slug(s)
+
+	where = photosQueryAll("photos.id IN (SELECT pk.photo_id FROM keywords k JOIN photos_keywords pk ON k.id = pk.keyword_id WHERE (%s))", LikeAnyKeyword("k.keyword", s)) +
+		" OR photos.id IN (SELECT pl.photo_id FROM photos_labels pl JOIN labels l ON l.id = pl.label_id WHERE pl.uncertainty < 100 AND (l.label_slug = ? OR l.custom_slug = ?))"
+	values = []interface{}{slug, slug}
+
+	// Use the full-text search index if possible, except for photos that are waiting for an index update.
+	if matchSql, matchArgs := entity.SearchIndexQuery(s); matchSql != "" {
+		return fmt.Sprintf("photos.id IN (SELECT photo_id FROM (%s) photos_match) OR photos.id IN (SELECT photo_id FROM %s) AND (%s)",
+			matchSql, entity.PhotoSearchQueue{}.TableName(), where), append(matchArgs, values...), nil
+	}
+
+	return where, values, nil
+}
+
+// photosQueryAll returns a condition that requires all wheres to match, using the format for each.
+func photosQueryAll(format string, wheres []string) string {
+	if len(wheres) == 0 {
+		return "1 = 0"
+	}
+
+	result := make([]string, len(wheres))
+
+	for i, where := range wheres {
+		result[i] = fmt.Sprintf(format, where)
+	}
+
+	return strings.Join(result, " AND ")
+}
+
+// photosQueryFlag returns the condition if the value is true, and its negation otherwise.
+func photosQueryFlag(s, where string, values ...interface{}) (string, []interface{}, error) {
+	if txt.No(s) {
+		return "NOT (" + where + ")", values, nil
+	}
+
+	return where, values, nil
+}
+
+// photosQueryLike returns the SQL condition and values for a text filter that may be true or false to
+// find content with or without text.
+func photosQueryLike(s, col string) (where string, values []interface{}, err error) {
+	switch s {
+	case enum.False:
+		return col + " = ''", nil, nil
+	case enum.True:
+		return col + " <> ''", nil, nil
+	default:
+		where, values = OrLike(col, s)
+		return where, values, nil
+	}
+}
+
+// photosQueryAnyInt returns the SQL condition for a list of numbers separated by |, e.g. "year:2020|2021".
+func photosQueryAnyInt(t *form.QueryExpr, col string, min, max int) (string, []interface{}, error) {
+	if where := AnyInt(col, t.Value, txt.Or, min, max); where != "" {
+		return where, nil, nil
+	}
+
+	return "", nil, form.NewQueryError(t.Pos, "invalid %s value", t.Key)
+}
+
+// photosQueryIntRange returns the SQL condition and values for a number or range, e.g. "iso:200",
+// "iso:200-400", "iso:200..400", or "iso:..400".
+func photosQueryIntRange(t *form.QueryExpr, col string, min, max int) (where string, values []interface{}, err error) {
+	start, end := min, max
+
+	if !t.Range {
+		if start, end, err = txt.IntRange(t.Value, min, max); err != nil {
+			return "", nil, form.NewQueryError(t.Pos, "invalid %s value", t.Key)
+		}
+	} else if t.Value != "" {
+		if start, err = strconv.Atoi(t.Value); err != nil {
+			return "", nil, form.NewQueryError(t.Pos, "invalid %s range", t.Key)
+		}
+	}
+
+	if t.Range && t.Max != "" {
+		if end, err = strconv.Atoi(t.Max); err != nil {
+			return "", nil, form.NewQueryError(t.Pos, "invalid %s range", t.Key)
+		}
+	}
+
+	if start > end {
+		return "", nil, form.NewQueryError(t.Pos, "invalid %s range", t.Key)
+	}
+
+	return col + " >= ? AND " + col + " <= ?", []interface{}{start, end}, nil
+}
+
+// photosQueryFloatRange returns the start and end of a decimal number or range, e.g. "f:2.8..4".
+func photosQueryFloatRange(t *form.QueryExpr, min, max float64) (start, end float64, err error) {
+	start, end = min, max
+
+	if !t.Range {
+		if start, end, err = txt.FloatRange(t.Value, min, max); err != nil {
+			return start, end, form.NewQueryError(t.Pos, "invalid %s value", t.Key)
+		}
+
+		return start, end, nil
+	}
+
+	if t.Value != "" {
+		if start, err = strconv.ParseFloat(t.Value, 64); err != nil {
+			return start, end, form.NewQueryError(t.Pos, "invalid %s range", t.Key)
+		}
+	}
+
+	if t.Max != "" {
+		if end, err = strconv.ParseFloat(t.Max, 64); err != nil {
+			return start, end, form.NewQueryError(t.Pos, "invalid %s range", t.Key)
+		}
+	}
+
+	if start > end {
+		return start, end, form.NewQueryError(t.Pos, "invalid %s range", t.Key)
+	}
+
+	return start, end, nil
+}
+
+// photosQueryTimeRange returns the SQL condition and values for a date or date range, e.g. "taken:2020",
+// "taken:2020-01-15", or "taken:2020-01..2020-06", which includes all of June.
+func photosQueryTimeRange(t *form.QueryExpr, col string) (where string, values []interface{}, err error) {
+	var start, end time.Time
+
+	if !t.Range {
+		if start, end, err = photosQueryTime(t, t.Value); err != nil {
+			return "", nil, err
+		}
+	} else {
+		if t.Value != "" {
+			if start, _, err = photosQueryTime(t, t.Value); err != nil {
+				return "", nil, err
+			}
+		}
+
+		if t.Max != "" {
+			if _, end, err = photosQueryTime(t, t.Max); err != nil {
+				return "", nil, err
+			}
+		}
+	}
+
+	switch {
+	case start.IsZero():
+		return col + " < ?", []interface{}{photosQueryTimeValue(end)}, nil
+	case end.IsZero():
+		return col + " >= ?", []interface{}{photosQueryTimeValue(start)}, nil
+	case !start.Before(end):
+		return "", nil, form.NewQueryError(t.Pos, "invalid %s range", t.Key)
+	default:
+		return col + " >= ? AND " + col + " < ?", []interface{}{photosQueryTimeValue(start), photosQueryTimeValue(end)}, nil
+	}
+}
+
+// photosQueryTimeValue returns the time as UTC string for comparison with a datetime column.
+func photosQueryTimeValue(t time.Time) string {
+	return t.UTC().Format("2006-01-02 15:04:05")
+}
+
+// photosQueryTime returns the start and the exclusive end of the year, month, or day specified as
+// "2006", "2006-01", or "2006-01-02", or the exact time otherwise.
+func photosQueryTime(t *form.QueryExpr, s string) (start, end time.Time, err error) {
+	var layout string
+
+	switch len(s) {
+	case 4:
+		layout = "2006"
+	case 7:
+		layout = "2006-01"
+	case 10:
+		layout = "2006-01-02"
+	}
+
+	if layout == "" {
+		if start = txt.ParseTimeUTC(s); start.IsZero() {
+			return start, end, form.NewQueryError(t.Pos, "invalid %s date", t.Key)
+		}
+
+		return start, start.Add(time.Second), nil
+	} else if start, err = time.Parse(layout, s); err != nil {
+		return start, end, form.NewQueryError(t.Pos, "invalid %s date", t.Key)
+	}
+
+	switch layout {
+	case "2006":
+		end = start.AddDate(1, 0, 0)
+	case "2006-01":
+		end = start.AddDate(0, 1, 0)
+	default:
+		end = start.AddDate(0, 0, 1)
+	}
+
+	return start.UTC(), end.UTC(), nil
+}
@@ -0,0 +1,131 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestPhotosQuery(t *testing.T) {
+	count := func(t *testing.T, q string) int {
+		var f form.SearchPhotos
+
+		f.Query = q
+		f.Merged = true
+		f.Count = 10000
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		return len(photos)
+	}
+
+	t.Run("Nil", func(t *testing.T) {
+		where, values, err := PhotosQuery(nil)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "", where)
+		assert.Empty(t, values)
+	})
+	t.Run("NotLabel", func(t *testing.T) {
+		all := count(t, "")
+		cake := count(t, "label:cake")
+
+		assert.Equal(t, 5, cake)
+		assert.Equal(t, all-cake, count(t, "-label:cake"))
+		assert.Equal(t, all-cake, count(t, "NOT label:cake"))
+	})
+	t.Run("LabelOrLabel", func(t *testing.T) {
+		cake := count(t, "label:cake")
+		flower := count(t, "label:flower")
+		result := count(t, "label:cake OR label:flower")
+
+		assert.GreaterOrEqual(t, result, cake)
+		assert.GreaterOrEqual(t, result, flower)
+		assert.LessOrEqual(t, result, cake+flower)
+		assert.Equal(t, count(t, "label:cake|flower"), result)
+	})
+	t.Run("LabelOrSubject", func(t *testing.T) {
+		cake := count(t, "label:cake")
+		john := count(t, "subject:john-doe")
+		result := count(t, "label:cake OR subject:john-doe")
+
+		assert.Greater(t, john, 0)
+		assert.GreaterOrEqual(t, result, cake)
+		assert.GreaterOrEqual(t, result, john)
+		assert.LessOrEqual(t, result, cake+john)
+	})
+	t.Run("NotCountry", func(t *testing.T) {
+		all := count(t, "")
+		de := count(t, "country:de")
+
+		assert.Greater(t, de, 0)
+		assert.Equal(t, all-de, count(t, "NOT country:de"))
+	})
+	t.Run("Parentheses", func(t *testing.T) {
+		cake := count(t, "label:cake")
+		result := count(t, "label:cake -(label:flower OR country:de)")
+
+		assert.LessOrEqual(t, result, cake)
+		assert.Equal(t, count(t, "label:cake -label:flower -country:de"), result)
+	})
+	t.Run("IsoRange", func(t *testing.T) {
+		all := count(t, "")
+		inRange := count(t, "iso:100..400")
+		below := count(t, "iso:..99")
+		above := count(t, "iso:401..")
+
+		assert.Greater(t, inRange, 0)
+		assert.LessOrEqual(t, inRange+below+above, all)
+		assert.Equal(t, count(t, "iso:100..200 OR iso:201..400"), inRange)
+	})
+	t.Run("TakenRange", func(t *testing.T) {
+		year := count(t, "taken:2016..2016")
+
+		assert.Equal(t, count(t, "taken:2016-01..2016-12"), year)
+		assert.GreaterOrEqual(t, count(t, "taken:2016-01..2016-06")+count(t, "taken:2016-07..2016-12"), year)
+		assert.GreaterOrEqual(t, count(t, "taken:2000..2030"), year)
+	})
+	t.Run("Phrase", func(t *testing.T) {
+		assert.GreaterOrEqual(t, count(t, "\"Lake\" OR \"Snow\""), count(t, "\"Lake\""))
+	})
+	t.Run("UnknownFilter", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "-foo:bar"
+
+		_, _, err := Photos(f)
+
+		if assert.Error(t, err) {
+			assert.True(t, form.IsQueryError(err))
+			assert.Equal(t, "unknown filter foo at position 2", err.Error())
+		}
+	})
+	t.Run("UnsupportedRange", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "label:a..b"
+
+		_, _, err := Photos(f)
+
+		if assert.Error(t, err) {
+			assert.Equal(t, "label filter does not support ranges at position 1", err.Error())
+		}
+	})
+	t.Run("SyntaxError", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Query = "(label:cake OR label:flower"
+
+		_, _, err := Photos(f)
+
+		if assert.Error(t, err) {
+			assert.Equal(t, "missing closing parenthesis at position 1", err.Error())
+		}
+	})
+}
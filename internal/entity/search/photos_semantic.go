@@ -0,0 +1,191 @@
+package search
+
+import (
+	"sort"
+	"time"
+
+	gc "github.com/patrickmn/go-cache"
+
+	"github.com/photoprism/photoprism/internal/ai/vision"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/txt"
+	"github.com/photoprism/photoprism/pkg/vector"
+)
+
+// SemanticLimit is the maximum number of pictures that are ranked by embedding similarity.
+var SemanticLimit = 1000
+
+// SemanticThreshold is the minimum cosine similarity of semantic search results.
+var SemanticThreshold = 0.2
+
+// semanticBatchSize is the number of embeddings that are loaded from the database at once.
+const semanticBatchSize = 1000
+
+// semanticCache caches the ranked search results, so that the embeddings do not
+// have to be loaded and compared again for each page of results.
+var semanticCache = gc.New(5*time.Minute, 10*time.Minute)
+
+// FlushSemanticCache clears the cached semantic search results.
+func FlushSemanticCache() {
+	semanticCache.Flush()
+}
+
+// SemanticScores maps photo IDs to their cosine similarity with the search vector.
+type SemanticScores map[uint]float64
+
+// IDs returns the photo IDs ordered by similarity, most similar first.
+func (s SemanticScores) IDs() []uint {
+	ids := make([]uint, 0, len(s))
+
+	for id := range s {
+		ids = append(ids, id)
+	}
+
+	sort.Slice(ids, func(i, j int) bool {
+		if s[ids[i]] == s[ids[j]] {
+			return ids[i] < ids[j]
+		}
+
+		return s[ids[i]] > s[ids[j]]
+	})
+
+	return ids
+}
+
+// Sort sorts the search results by similarity while keeping the file order of each photo.
+func (s SemanticScores) Sort(results PhotoResults) {
+	sort.SliceStable(results, func(i, j int) bool {
+		if a, b := s[results[i].ID], s[results[j].ID]; a != b {
+			return a > b
+		}
+
+		return results[i].ID < results[j].ID
+	})
+}
+
+// SemanticPhotos returns the IDs and similarity scores of the pictures whose
+// embeddings are closest to the search vector.
+func SemanticPhotos(modelName string, v vector.Vector, excludeId uint, threshold float64, limit int) (SemanticScores, error) {
+	scores := make(SemanticScores)
+
+	if len(v) == 0 || modelName == "" {
+		return scores, nil
+	}
+
+	if limit <= 0 {
+		limit = SemanticLimit
+	}
+
+	v = v.Normalize()
+
+	var lastId uint
+
+	for {
+		var batch entity.PhotoEmbeddings
+
+		if err := Db().
+			Where("embedding_model = ? AND embedding_dim = ? AND photo_id > ?", modelName, len(v), lastId).
+			Order("photo_id").Limit(semanticBatchSize).Find(&batch).Error; err != nil {
+			return scores, err
+		}
+
+		for i := range batch {
+			if batch[i].PhotoID == excludeId {
+				continue
+			} else if score := batch[i].Similarity(v); score >= threshold {
+				scores[batch[i].PhotoID] = score
+			}
+		}
+
+		// Keep only the best matches to limit memory usage.
+		if len(scores) > 2*limit {
+			scores = scores.top(limit)
+		}
+
+		if len(batch) < semanticBatchSize {
+			break
+		}
+
+		lastId = batch[len(batch)-1].PhotoID
+	}
+
+	return scores.top(limit), nil
+}
+
+// top returns the specified number of best matches.
+func (s SemanticScores) top(limit int) SemanticScores {
+	if len(s) <= limit {
+		return s
+	}
+
+	result := make(SemanticScores, limit)
+
+	for _, id := range s.IDs()[:limit] {
+		result[id] = s[id]
+	}
+
+	return result
+}
+
+// semanticSearch returns the similarity scores for a semantic search query or for
+// pictures similar to a reference picture, or nil if no semantic search was requested.
+// The scores are cached by query so that further pages can be returned without ranking
+// all embeddings again.
+func semanticSearch(frm *form.SearchPhotos) (scores SemanticScores, err error) {
+	var cacheKey string
+
+	switch {
+	case txt.NotEmpty(frm.Similar):
+		cacheKey = "similar:" + frm.Similar
+	case frm.Semantic && txt.NotEmpty(frm.Query):
+		cacheKey = "query:" + frm.Query
+
+		// Text embeddings replace the keyword search.
+		defer func() { frm.Query = "" }()
+	default:
+		return nil, nil
+	}
+
+	if cacheData, hit := semanticCache.Get(cacheKey); hit {
+		log.Tracef("search: cache hit for %s (semantic search)", clean.Log(cacheKey))
+		return cacheData.(SemanticScores), nil
+	}
+
+	if scores, err = rankSemantic(frm); err == nil {
+		semanticCache.SetDefault(cacheKey, scores)
+	}
+
+	return scores, err
+}
+
+// rankSemantic ranks the pictures by their similarity with the search query or reference picture.
+func rankSemantic(frm *form.SearchPhotos) (SemanticScores, error) {
+	switch {
+	case txt.NotEmpty(frm.Similar):
+		photo := entity.Photo{}
+
+		// Find the reference picture and its embedding.
+		if err := Db().First(&photo, "photo_uid = ?", frm.Similar).Error; err != nil {
+			log.Debugf("search: %s (find similar)", err)
+			return nil, ErrNotFound
+		} else if m, embErr := entity.FindPhotoEmbedding(photo.ID); embErr != nil {
+			log.Debugf("search: %s has no embedding (find similar)", clean.Log(photo.PhotoUID))
+			return SemanticScores{}, nil
+		} else {
+			return SemanticPhotos(m.EmbeddingModel, m.Vector(), photo.ID, SemanticThreshold, SemanticLimit)
+		}
+	case frm.Semantic && txt.NotEmpty(frm.Query):
+		result, _, err := vision.GenerateTextEmbedding(frm.Query)
+
+		if err != nil {
+			log.Debugf("search: %s (semantic search)", clean.Error(err))
+			return nil, ErrNoEmbedding
+		}
+
+		return SemanticPhotos(clean.TypeLower(result.Model), result.Vector(), 0, SemanticThreshold, SemanticLimit)
+	default:
+		return nil, nil
+	}
+}
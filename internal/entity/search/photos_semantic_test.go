@@ -0,0 +1,171 @@
+package search
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/ai/vision"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/pkg/media"
+	"github.com/photoprism/photoprism/pkg/vector"
+)
+
+func TestSemanticPhotos(t *testing.T) {
+	t.Run("Ranked", func(t *testing.T) {
+		scores, err := SemanticPhotos(entity.PhotoEmbeddingTestModel, vector.Vector{1, 0, 0}, 0, 0.5, 10)
+
+		assert.NoError(t, err)
+
+		ids := scores.IDs()
+
+		if assert.Len(t, ids, 2) {
+			assert.Equal(t, entity.PhotoFixtures.Get("19800101_000002_D640C559").ID, ids[0])
+			assert.Equal(t, entity.PhotoFixtures.Get("Photo01").ID, ids[1])
+		}
+	})
+	t.Run("Exclude", func(t *testing.T) {
+		lake := entity.PhotoFixtures.Get("19800101_000002_D640C559")
+		scores, err := SemanticPhotos(entity.PhotoEmbeddingTestModel, vector.Vector{1, 0, 0}, lake.ID, 0.5, 10)
+
+		assert.NoError(t, err)
+		assert.NotContains(t, scores, lake.ID)
+	})
+	t.Run("Limit", func(t *testing.T) {
+		scores, err := SemanticPhotos(entity.PhotoEmbeddingTestModel, vector.Vector{1, 0, 0}, 0, -1, 1)
+
+		assert.NoError(t, err)
+		assert.Len(t, scores, 1)
+	})
+	t.Run("OtherModel", func(t *testing.T) {
+		scores, err := SemanticPhotos("unknown-model", vector.Vector{1, 0, 0}, 0, -1, 10)
+
+		assert.NoError(t, err)
+		assert.Empty(t, scores)
+	})
+	t.Run("DimensionMismatch", func(t *testing.T) {
+		scores, err := SemanticPhotos(entity.PhotoEmbeddingTestModel, vector.Vector{1, 0}, 0, -1, 10)
+
+		assert.NoError(t, err)
+		assert.Empty(t, scores)
+	})
+}
+
+func TestPhotosSemantic(t *testing.T) {
+	t.Run("Similar", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Similar = entity.PhotoFixtures.Get("Photo01").PhotoUID
+		f.Merged = true
+		f.Count = 10
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.GreaterOrEqual(t, len(photos), 1) {
+			assert.Equal(t, entity.PhotoFixtures.Get("19800101_000002_D640C559").PhotoUID, photos[0].PhotoUID)
+		}
+
+		for _, p := range photos {
+			assert.NotEqual(t, f.Similar, p.PhotoUID)
+		}
+	})
+	t.Run("SimilarNoEmbedding", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Similar = entity.PhotoFixtures.Get("Photo03").PhotoUID
+		f.Merged = true
+
+		photos, _, err := Photos(f)
+
+		assert.NoError(t, err)
+		assert.Empty(t, photos)
+	})
+	t.Run("SimilarNotFound", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Similar = "ps6sg6be2lvl0xxx"
+
+		_, _, err := Photos(f)
+
+		assert.Equal(t, ErrNotFound, err)
+	})
+	t.Run("Query", func(t *testing.T) {
+		vision.SetEmbeddingFunc(func(images vision.Files, text string, mediaSrc media.Src) (*vision.EmbeddingResult, *vision.Model, error) {
+			return &vision.EmbeddingResult{Values: []float64{0, 0, 1}, Model: entity.PhotoEmbeddingTestModel}, &vision.Model{}, nil
+		})
+
+		t.Cleanup(func() { vision.SetEmbeddingFunc(nil) })
+
+		var f form.SearchPhotos
+
+		f.Query = "semantic:true neckar bridge"
+		f.Merged = true
+		f.Count = 10
+
+		photos, _, err := Photos(f)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.GreaterOrEqual(t, len(photos), 1) {
+			assert.Equal(t, entity.PhotoFixtures.Get("Photo04").PhotoUID, photos[0].PhotoUID)
+		}
+	})
+	t.Run("Cached", func(t *testing.T) {
+		FlushSemanticCache()
+
+		var f form.SearchPhotos
+
+		f.Similar = entity.PhotoFixtures.Get("Photo01").PhotoUID
+		f.Merged = true
+		f.Count = 10
+
+		if _, _, err := Photos(f); err != nil {
+			t.Fatal(err)
+		}
+
+		_, hit := semanticCache.Get("similar:" + f.Similar)
+		assert.True(t, hit)
+
+		FlushSemanticCache()
+
+		_, hit = semanticCache.Get("similar:" + f.Similar)
+		assert.False(t, hit)
+	})
+	t.Run("Offset", func(t *testing.T) {
+		var f form.SearchPhotos
+
+		f.Similar = entity.PhotoFixtures.Get("Photo01").PhotoUID
+		f.Merged = true
+		f.Count = 10
+		f.Offset = 100
+
+		photos, _, err := Photos(f)
+
+		assert.NoError(t, err)
+		assert.Empty(t, photos)
+	})
+	t.Run("NoModel", func(t *testing.T) {
+		vision.SetEmbeddingFunc(func(images vision.Files, text string, mediaSrc media.Src) (*vision.EmbeddingResult, *vision.Model, error) {
+			return nil, nil, errors.New("missing embedding model")
+		})
+
+		t.Cleanup(func() { vision.SetEmbeddingFunc(nil) })
+
+		var f form.SearchPhotos
+
+		f.Query = "bridge"
+		f.Semantic = true
+
+		_, _, err := Photos(f)
+
+		assert.Equal(t, ErrNoEmbedding, err)
+	})
+}
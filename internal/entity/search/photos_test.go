@@ -41,6 +41,40 @@ func TestPhotos(t *testing.T) {
 
 		assert.LessOrEqual(t, 2, len(photos))
 	})
+	t.Run("OrderRank", func(t *testing.T) {
+		var frm form.SearchPhotos
+
+		frm.Query = "lake"
+		frm.Count = 10
+		frm.Offset = 0
+		frm.Order = sortby.Rank
+
+		photos, _, err := Photos(frm)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(photos) == 0 {
+			t.Fatal("expected at least one photo")
+		}
+
+		assert.Equal(t, "Lake / 2790", photos[0].PhotoTitle)
+	})
+	t.Run("OrderRankWithoutQuery", func(t *testing.T) {
+		var frm form.SearchPhotos
+
+		frm.Query = ""
+		frm.Count = 10
+		frm.Offset = 0
+		frm.Order = sortby.Rank
+
+		photos, _, err := Photos(frm)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.LessOrEqual(t, 2, len(photos))
+	})
 	t.Run("OrderRandom", func(t *testing.T) {
 		var frm form.SearchPhotos
 
@@ -238,6 +272,38 @@ func TestPhotos(t *testing.T) {
 
 		assert.LessOrEqual(t, 2, len(photos))
 	})
+	t.Run("SearchForQueuedKeyword", func(t *testing.T) {
+		// Photos that are waiting for an index update must still be found by their keywords.
+		if err := entity.RemoveFromSearchIndex(1000000); err != nil {
+			t.Fatal(err)
+		}
+
+		entity.QueueSearchIndex(1000000)
+
+		t.Cleanup(func() {
+			_, _ = entity.UpdateQueuedSearchIndex()
+		})
+
+		var frm form.SearchPhotos
+
+		frm.Query = "bridge"
+		frm.Count = 5000
+		frm.Offset = 0
+
+		photos, _, err := Photos(frm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ids := make([]uint, len(photos))
+
+		for i := range photos {
+			ids[i] = photos[i].ID
+		}
+
+		assert.Contains(t, ids, uint(1000000))
+	})
 	t.Run("SearchForLabelInQuery", func(t *testing.T) {
 		var frm form.SearchPhotos
 
@@ -2434,3 +2500,68 @@ func TestPhotos(t *testing.T) {
 		}
 	})
 }
+
+func TestUserPhotos_Grants(t *testing.T) {
+	sess := entity.NewSession(0, 0).SetUser(entity.UserFixtures.Pointer("guest"))
+	frm := form.SearchPhotos{Count: 1000}
+
+	t.Run("Photo", func(t *testing.T) {
+		photoUid := "ps6sg6be2lvl0yh8"
+
+		results, _, err := UserPhotos(frm, sess)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotContains(t, results.UIDs(), photoUid)
+
+		grant, err := entity.SharePhoto(photoUid, "tt2hdkz1vgdb3ta2", entity.PermView)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer func() { _ = grant.Delete() }()
+
+		if results, _, err = UserPhotos(frm, sess); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, results.UIDs(), photoUid)
+	})
+	t.Run("Album", func(t *testing.T) {
+		photoUid := "ps6sg6be2lvl0yh7"
+
+		results, _, err := UserPhotos(frm, sess)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotContains(t, results.UIDs(), photoUid)
+
+		grant, err := entity.ShareAlbum("as6sg6bxpogaaba8", sess.UserUID, entity.PermView)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer func() { _ = grant.Delete() }()
+
+		if results, _, err = UserPhotos(frm, sess); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, results.UIDs(), photoUid)
+
+		// Search the shared album.
+		frm.Scope = "as6sg6bxpogaaba8"
+
+		if results, _, err = UserPhotos(frm, sess); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, results.UIDs(), photoUid)
+	})
+}
@@ -4,13 +4,18 @@ import (
 	"database/sql"
 	"fmt"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/ulule/deepcopier"
 
 	"github.com/photoprism/photoprism/internal/form"
 	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/service/local"
+	"github.com/photoprism/photoprism/internal/service/s3"
+	"github.com/photoprism/photoprism/internal/service/sftp"
 	"github.com/photoprism/photoprism/internal/service/webdav"
+	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/txt"
 	"github.com/photoprism/photoprism/pkg/txt/clip"
@@ -23,6 +28,15 @@ const (
 	SyncStatusSynced   = "synced"
 )
 
+// Conflict resolution policies for files that have been changed both locally and remotely.
+const (
+	SyncConflictKeepBoth = "keep"
+	SyncConflictNewest   = "newest"
+	SyncConflictRemote   = "remote"
+	SyncConflictLocal    = "local"
+	SyncConflictManual   = "manual"
+)
+
 type Services []Service
 
 // Service represents a remote service, e.g. for uploading, downloading or syncing media files.
@@ -30,18 +44,26 @@ type Services []Service
 // Field Descriptions:
 // - AccTimeout configures the timeout for requests, options: "", high, medium, low, none.
 // - AccErrors holds the number of connection errors since the last reset.
+// - AccHostKey contains the SSH host key fingerprint that was pinned when the SFTP server was first connected.
 // - AccShare enables manual upload, see SharePath, ShareSize, and ShareExpires.
 // - AccSync enables automatic file synchronization, see SyncDownload and SyncUpload.
 // - RetryLimit specifies the number of retry attempts, a negative value disables the limit.
+// - SyncDelete propagates deleted files to the other side if SyncDownload and/or SyncUpload are enabled,
+// pictures of files that were deleted remotely are archived so that they can be restored.
+// - SyncConflict specifies how files changed on both sides are handled: keep, newest, remote, local, or manual.
+// - SyncInclude and SyncExclude contain glob patterns separated by commas or line breaks, see SyncFilter.
+// - SyncTypes limits the media types to sync, e.g. "image,video,raw,sidecar", or all supported types if empty.
+// - SyncMaxSize is the maximum file size in MB, SyncSince skips files that were last modified before.
 type Service struct {
 	ID            uint         `gorm:"primary_key" json:"ID"`
 	AccName       string       `gorm:"type:VARCHAR(160);" json:"AccName"`
 	AccOwner      string       `gorm:"type:VARCHAR(160);" json:"AccOwner"`
 	AccURL        string       `gorm:"type:VARCHAR(255);" json:"AccURL"`
 	AccType       string       `gorm:"type:VARBINARY(255);" json:"AccType"`
-	AccKey        string       `gorm:"type:VARBINARY(255);" json:"-"`
+	AccKey        string       `gorm:"type:VARBINARY(4096);" json:"-"`
 	AccUser       string       `gorm:"type:VARBINARY(255);" json:"AccUser"`
 	AccPass       string       `gorm:"type:VARBINARY(255);" json:"-"`
+	AccHostKey    string       `gorm:"type:VARBINARY(255);" json:"AccHostKey"`
 	AccTimeout    string       `gorm:"type:VARBINARY(16);" json:"AccTimeout"`
 	AccError      string       `gorm:"type:VARBINARY(512);" json:"AccError"`
 	AccErrors     int          `json:"AccErrors"`
@@ -57,8 +79,15 @@ type Service struct {
 	SyncDate      sql.NullTime `deepcopier:"skip" json:"SyncDate"`
 	SyncUpload    bool         `json:"SyncUpload"`
 	SyncDownload  bool         `json:"SyncDownload"`
+	SyncDelete    bool         `json:"SyncDelete"`
+	SyncConflict  string       `gorm:"type:VARBINARY(16);" json:"SyncConflict"`
 	SyncFilenames bool         `json:"SyncFilenames"`
 	SyncRaw       bool         `json:"SyncRaw"`
+	SyncInclude   string       `gorm:"type:VARBINARY(1024);" json:"SyncInclude"`
+	SyncExclude   string       `gorm:"type:VARBINARY(1024);" json:"SyncExclude"`
+	SyncTypes     string       `gorm:"type:VARBINARY(64);" json:"SyncTypes"`
+	SyncMaxSize   int          `json:"SyncMaxSize"`
+	SyncSince     *time.Time   `json:"SyncSince"`
 	CreatedAt     time.Time    `deepcopier:"skip" json:"CreatedAt"`
 	UpdatedAt     time.Time    `deepcopier:"skip" json:"UpdatedAt"`
 	DeletedAt     *time.Time   `deepcopier:"skip" sql:"index" json:"DeletedAt"`
@@ -134,21 +163,43 @@ func (m *Service) ResetErrors(share, sync bool) error {
 // SaveForm saves the entity using form data and stores it in the database.
 func (m *Service) SaveForm(form form.Service) error {
 	db := Db()
+	filter := m.SyncFilter()
+	accUrl := m.AccURL
 
 	// Copy model values from form.
 	if err := deepcopier.Copy(m).From(form); err != nil {
 		return err
 	}
 
-	// TODO: Support for other remote services in addition to WebDAV.
-	if m.AccType != service.WebDAV {
+	// Normalize file filters.
+	m.SyncInclude = strings.Join(SyncPatterns(m.SyncInclude), ", ")
+	m.SyncExclude = strings.Join(SyncPatterns(m.SyncExclude), ", ")
+	m.SyncTypes = strings.Join(SyncTypes(m.SyncTypes), ",")
+
+	if m.SyncMaxSize < 0 {
+		m.SyncMaxSize = 0
+	}
+
+	if m.SyncSince != nil && m.SyncSince.IsZero() {
+		m.SyncSince = nil
+	}
+
+	// Disable upload and sync for unsupported remote services.
+	if !service.Supported(m.AccType) {
 		m.AccShare = false // Disable manual upload.
 		m.AccSync = false  // Disable background sync.
 	}
 
-	// Prevent two-way sync, see https://github.com/photoprism/photoprism/issues/1785
-	if m.SyncUpload && m.SyncDownload {
-		m.SyncUpload = false
+	// Pin the SSH host key again if the server has changed.
+	if m.AccURL != accUrl {
+		m.AccHostKey = ""
+	}
+
+	// Use default conflict resolution policy if empty or invalid.
+	switch m.SyncConflict {
+	case SyncConflictKeepBoth, SyncConflictNewest, SyncConflictRemote, SyncConflictLocal, SyncConflictManual:
+	default:
+		m.SyncConflict = SyncConflictKeepBoth
 	}
 
 	// Set default manual upload folder if empty.
@@ -176,6 +227,12 @@ func (m *Service) SaveForm(form form.Service) error {
 	// Reset error counters if account already exists.
 	if !Db().NewRecord(m) {
 		Log("service", "reset errors", m.ResetErrors(m.AccShare, m.AccSync))
+
+		// Check previously ignored files again if the filters have changed.
+		if !filter.Equal(m.SyncFilter()) {
+			Log("service", "reset ignored files", m.ResetIgnored())
+			m.SyncStatus = SyncStatusRefresh
+		}
 	}
 
 	// Ensure account name and owner are not too long.
@@ -191,14 +248,60 @@ func (m *Service) Delete() error {
 	return Db().Delete(m).Error
 }
 
+// Client returns a new client for the remote service, or an error if the service type is not supported.
+func (m *Service) Client() (service.Client, error) {
+	switch m.AccType {
+	case service.WebDAV:
+		if client, err := webdav.NewClient(m.AccURL, m.AccUser, m.AccPass, webdav.Timeout(m.AccTimeout)); err != nil {
+			return nil, err
+		} else {
+			return client, nil
+		}
+	case service.S3:
+		if client, err := s3.NewClient(m.AccURL, m.AccUser, m.AccPass, webdav.Durations[webdav.Timeout(m.AccTimeout)]); err != nil {
+			return nil, err
+		} else {
+			return client, nil
+		}
+	case service.SFTP:
+		if client, err := sftp.NewClient(m.AccURL, m.AccUser, m.AccPass, m.AccKey, webdav.Durations[webdav.Timeout(m.AccTimeout)]); err != nil {
+			return nil, err
+		} else {
+			client.PinHostKey(m.AccHostKey, m.PinHostKey)
+			return client, nil
+		}
+	case service.Local:
+		if client, err := local.NewClient(m.AccURL); err != nil {
+			return nil, err
+		} else {
+			return client, nil
+		}
+	default:
+		return nil, fmt.Errorf("unsupported service type %s", clean.Log(m.AccType))
+	}
+}
+
+// PinHostKey stores the SSH host key fingerprint of the server, so that it is verified on subsequent connections.
+func (m *Service) PinHostKey(fingerprint string) error {
+	m.AccHostKey = fingerprint
+
+	if Db().NewRecord(m) {
+		return nil
+	}
+
+	return m.Update("acc_host_key", fingerprint)
+}
+
 // Directories returns a list of directories or albums in an account.
 func (m *Service) Directories() (result fs.FileInfos, err error) {
-	if m.AccType == service.WebDAV {
-		var client *webdav.Client
-		if client, err = webdav.NewClient(m.AccURL, m.AccUser, m.AccPass, webdav.Timeout(m.AccTimeout)); err != nil {
+	if service.Supported(m.AccType) {
+		var client service.Client
+		if client, err = m.Client(); err != nil {
 			return result, err
 		}
 
+		defer client.Close()
+
 		result, err = client.Directories("/", true, 0)
 	}
 
@@ -233,6 +336,11 @@ func (m *Service) Create() error {
 	return Db().Create(m).Error
 }
 
+// TwoWaySync tests if files should be synchronized in both directions.
+func (m *Service) TwoWaySync() bool {
+	return m.AccSync && m.SyncDownload && m.SyncUpload
+}
+
 // ShareOriginals tests if the unmodified originals should be shared.
 func (m *Service) ShareOriginals() bool {
 	return m.ShareSize == ""
@@ -0,0 +1,230 @@
+package entity
+
+import (
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/media"
+)
+
+// Media type filters for synchronization, see Service.SyncTypes.
+const (
+	SyncTypeImage   = "image"
+	SyncTypeVideo   = "video"
+	SyncTypeRaw     = "raw"
+	SyncTypeSidecar = "sidecar"
+)
+
+// SyncTypeList contains all supported media type filters.
+var SyncTypeList = []string{SyncTypeImage, SyncTypeVideo, SyncTypeRaw, SyncTypeSidecar}
+
+// SyncFilter represents the file filters of a service account, which are applied when files are
+// downloaded, uploaded, or shared. Empty filters match all files.
+//
+// Patterns without a slash match the file name or the name of a parent folder, e.g. "*.jpg" or "Private",
+// while patterns with a slash match the path relative to the sync folder, e.g. "Shared/2024-*".
+type SyncFilter struct {
+	Include []string
+	Exclude []string
+	Types   []string
+	Raw     bool
+	Video   bool
+	MaxSize int64
+	Since   time.Time
+}
+
+// SyncFilter returns the file filters of the service account.
+func (m *Service) SyncFilter() SyncFilter {
+	result := SyncFilter{
+		Include: SyncPatterns(m.SyncInclude),
+		Exclude: SyncPatterns(m.SyncExclude),
+		Types:   SyncTypes(m.SyncTypes),
+		Raw:     m.SyncRaw,
+		Video:   m.SyncRaw,
+	}
+
+	if m.SyncMaxSize > 0 {
+		result.MaxSize = int64(m.SyncMaxSize) * 1024 * 1024
+	}
+
+	if m.SyncSince != nil {
+		result.Since = *m.SyncSince
+	}
+
+	return result
+}
+
+// ResetIgnored removes local files that have been ignored when uploading from the sync status,
+// so that they are checked again. Ignored remote files are checked again when the list is refreshed.
+func (m *Service) ResetIgnored() error {
+	if m.ID == 0 {
+		return nil
+	}
+
+	return UnscopedDb().Where("service_id = ? AND file_id > 0 AND status = ?", m.ID, FileSyncIgnore).Delete(&FileSync{}).Error
+}
+
+// SyncPatterns returns the glob patterns contained in a string, separated by commas or line breaks.
+func SyncPatterns(s string) (result []string) {
+	if s = strings.TrimSpace(s); s == "" {
+		return result
+	}
+
+	for _, pattern := range strings.FieldsFunc(s, func(r rune) bool { return r == ',' || r == '\n' || r == '\r' }) {
+		if pattern = strings.Trim(strings.TrimSpace(pattern), "/"); pattern == "" {
+			continue
+		} else if _, err := path.Match(pattern, ""); err != nil {
+			log.Debugf("sync: invalid pattern %s (%s)", pattern, err)
+			continue
+		} else if !slices.Contains(result, pattern) {
+			result = append(result, pattern)
+		}
+	}
+
+	return result
+}
+
+// SyncTypes returns the supported media type filters contained in a string, separated by commas.
+func SyncTypes(s string) (result []string) {
+	for _, t := range strings.Split(strings.ToLower(s), ",") {
+		if t = strings.TrimSpace(t); slices.Contains(SyncTypeList, t) && !slices.Contains(result, t) {
+			result = append(result, t)
+		}
+	}
+
+	// Keep the default order, so that equal filters can be compared.
+	slices.SortFunc(result, func(a, b string) int {
+		return slices.Index(SyncTypeList, a) - slices.Index(SyncTypeList, b)
+	})
+
+	return result
+}
+
+// Equal checks if both filters are the same.
+func (f SyncFilter) Equal(other SyncFilter) bool {
+	return slices.Equal(f.Include, other.Include) &&
+		slices.Equal(f.Exclude, other.Exclude) &&
+		slices.Equal(f.Types, other.Types) &&
+		f.Raw == other.Raw &&
+		f.Video == other.Video &&
+		f.MaxSize == other.MaxSize &&
+		f.Since.Equal(other.Since)
+}
+
+// Match checks if a file matches the filters, based on its name relative to the sync folder,
+// media type, size in bytes, and modification time.
+func (f SyncFilter) Match(relName string, mediaType media.Type, size int64, modified time.Time) bool {
+	if !f.MatchType(mediaType) {
+		return false
+	} else if f.MaxSize > 0 && size > f.MaxSize {
+		return false
+	} else if !f.Since.IsZero() && !modified.IsZero() && modified.Before(f.Since) {
+		return false
+	}
+
+	return f.MatchName(relName)
+}
+
+// MatchFile checks if an indexed file matches the filters, based on its name relative to the originals folder.
+func (f SyncFilter) MatchFile(file *File) bool {
+	if file == nil {
+		return false
+	}
+
+	var modified time.Time
+
+	if file.ModTime > 0 {
+		modified = time.Unix(file.ModTime, 0)
+	}
+
+	return f.Match(file.FileName, media.FromName(file.FileName), file.FileSize, modified)
+}
+
+// MatchType checks if files of the specified media type should be synchronized. If no types are
+// configured, all supported types are synchronized, with raw images depending on Raw and videos on Video.
+func (f SyncFilter) MatchType(t media.Type) bool {
+	var name string
+
+	switch t {
+	case media.Image, media.Live, media.Animated, media.Vector, media.Document:
+		name = SyncTypeImage
+	case media.Video:
+		name = SyncTypeVideo
+	case media.Raw:
+		name = SyncTypeRaw
+	case media.Sidecar:
+		name = SyncTypeSidecar
+	default:
+		return false
+	}
+
+	if len(f.Types) > 0 {
+		return slices.Contains(f.Types, name)
+	}
+
+	switch name {
+	case SyncTypeRaw:
+		return f.Raw
+	case SyncTypeVideo:
+		return f.Video
+	default:
+		return true
+	}
+}
+
+// MatchName checks if the file name relative to the sync folder matches the include and exclude patterns.
+func (f SyncFilter) MatchName(relName string) bool {
+	relName = strings.Trim(path.Clean("/"+relName), "/")
+
+	for _, pattern := range f.Exclude {
+		if matchSyncPattern(pattern, relName) {
+			return false
+		}
+	}
+
+	if len(f.Include) == 0 {
+		return true
+	}
+
+	for _, pattern := range f.Include {
+		if matchSyncPattern(pattern, relName) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchSyncPattern checks if the relative file name matches a glob pattern, ignoring the case.
+func matchSyncPattern(pattern, relName string) bool {
+	pattern = strings.ToLower(pattern)
+	relName = strings.ToLower(relName)
+
+	if relName == "" {
+		return false
+	}
+
+	names := strings.Split(relName, "/")
+
+	// Patterns without a slash match the file name or a parent folder name.
+	if !strings.Contains(pattern, "/") {
+		for _, name := range names {
+			if ok, _ := path.Match(pattern, name); ok {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	// Patterns with a slash match the relative path of the file or a parent folder.
+	for i := len(names); i > 0; i-- {
+		if ok, _ := path.Match(pattern, strings.Join(names[:i], "/")); ok {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,121 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/media"
+)
+
+func TestSyncPatterns(t *testing.T) {
+	assert.Empty(t, SyncPatterns(""))
+	assert.Equal(t, []string{"*.jpg", "Shared/Private", "*.mp4"}, SyncPatterns(" *.jpg, /Shared/Private/\n*.mp4\r\n*.jpg"))
+	assert.Equal(t, []string{"*.png"}, SyncPatterns("[invalid, *.png"))
+}
+
+func TestSyncTypes(t *testing.T) {
+	assert.Empty(t, SyncTypes(""))
+	assert.Equal(t, []string{SyncTypeImage, SyncTypeRaw}, SyncTypes("RAW, image, foo, raw"))
+	assert.Equal(t, SyncTypeList, SyncTypes("sidecar,raw,video,image"))
+}
+
+func TestService_SyncFilter(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	m := Service{SyncInclude: "*.jpg", SyncExclude: "Private", SyncTypes: "image", SyncMaxSize: 2, SyncSince: &since, SyncRaw: true}
+
+	f := m.SyncFilter()
+
+	assert.Equal(t, []string{"*.jpg"}, f.Include)
+	assert.Equal(t, []string{"Private"}, f.Exclude)
+	assert.Equal(t, []string{SyncTypeImage}, f.Types)
+	assert.Equal(t, int64(2*1024*1024), f.MaxSize)
+	assert.Equal(t, since, f.Since)
+	assert.True(t, f.Raw)
+	assert.True(t, f.Video)
+	assert.True(t, f.Equal(m.SyncFilter()))
+	assert.False(t, f.Equal(SyncFilter{}))
+}
+
+func TestSyncFilter_MatchType(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		f := SyncFilter{}
+
+		assert.True(t, f.MatchType(media.Image))
+		assert.True(t, f.MatchType(media.Sidecar))
+		assert.True(t, f.MatchType(media.Live))
+		assert.False(t, f.MatchType(media.Raw))
+		assert.False(t, f.MatchType(media.Video))
+		assert.False(t, f.MatchType(media.Archive))
+		assert.False(t, f.MatchType(media.Unknown))
+	})
+	t.Run("Raw", func(t *testing.T) {
+		f := SyncFilter{Raw: true}
+
+		assert.True(t, f.MatchType(media.Image))
+		assert.True(t, f.MatchType(media.Raw))
+		assert.False(t, f.MatchType(media.Video))
+	})
+	t.Run("Video", func(t *testing.T) {
+		f := SyncFilter{Video: true}
+
+		assert.True(t, f.MatchType(media.Image))
+		assert.False(t, f.MatchType(media.Raw))
+		assert.True(t, f.MatchType(media.Video))
+	})
+	t.Run("Types", func(t *testing.T) {
+		f := SyncFilter{Types: []string{SyncTypeVideo, SyncTypeSidecar}}
+
+		assert.False(t, f.MatchType(media.Image))
+		assert.False(t, f.MatchType(media.Raw))
+		assert.True(t, f.MatchType(media.Video))
+		assert.True(t, f.MatchType(media.Sidecar))
+	})
+}
+
+func TestSyncFilter_MatchName(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		f := SyncFilter{}
+
+		assert.True(t, f.MatchName("2024/photo.jpg"))
+	})
+	t.Run("Exclude", func(t *testing.T) {
+		f := SyncFilter{Exclude: []string{"private", "*.tmp", "Shared/20??-*"}}
+
+		assert.True(t, f.MatchName("/2024/photo.jpg"))
+		assert.False(t, f.MatchName("/Private/photo.jpg"))
+		assert.False(t, f.MatchName("2024/photo.TMP"))
+		assert.False(t, f.MatchName("Shared/2024-01/photo.jpg"))
+		assert.True(t, f.MatchName("Shared/Other/photo.jpg"))
+	})
+	t.Run("Include", func(t *testing.T) {
+		f := SyncFilter{Include: []string{"*.jpg", "Albums/*"}, Exclude: []string{"Albums/Private"}}
+
+		assert.True(t, f.MatchName("2024/photo.JPG"))
+		assert.False(t, f.MatchName("2024/video.mp4"))
+		assert.True(t, f.MatchName("Albums/Holiday/video.mp4"))
+		assert.False(t, f.MatchName("Albums/Private/photo.jpg"))
+	})
+}
+
+func TestSyncFilter_Match(t *testing.T) {
+	since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	f := SyncFilter{Types: []string{SyncTypeImage}, MaxSize: 1024, Since: since}
+
+	assert.True(t, f.Match("photo.jpg", media.Image, 1024, since))
+	assert.True(t, f.Match("photo.jpg", media.Image, 1024, time.Time{}))
+	assert.False(t, f.Match("photo.jpg", media.Image, 1025, since))
+	assert.False(t, f.Match("photo.jpg", media.Image, 1024, since.Add(-time.Second)))
+	assert.False(t, f.Match("video.mp4", media.Video, 1024, since))
+}
+
+func TestSyncFilter_MatchFile(t *testing.T) {
+	f := SyncFilter{Exclude: []string{"Private"}, Since: time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)}
+
+	assert.False(t, f.MatchFile(nil))
+	assert.True(t, f.MatchFile(&File{FileName: "2020/photo.jpg", ModTime: time.Date(2020, 3, 6, 0, 0, 0, 0, time.UTC).Unix()}))
+	assert.True(t, f.MatchFile(&File{FileName: "2020/photo.jpg"}))
+	assert.False(t, f.MatchFile(&File{FileName: "2017/photo.jpg", ModTime: time.Date(2017, 1, 6, 0, 0, 0, 0, time.UTC).Unix()}))
+	assert.False(t, f.MatchFile(&File{FileName: "Private/photo.jpg"}))
+}
@@ -1,11 +1,21 @@
 package entity
 
 import (
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/pem"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/ssh"
 
 	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/internal/service/local"
+	"github.com/photoprism/photoprism/internal/service/s3"
+	"github.com/photoprism/photoprism/internal/service/sftp"
+	"github.com/photoprism/photoprism/internal/service/webdav"
 )
 
 func TestCreateService(t *testing.T) {
@@ -68,12 +78,13 @@ func TestService_SaveForm(t *testing.T) {
 		}
 
 		assert.Equal(t, true, model.SyncDownload)
-		assert.Equal(t, false, model.SyncUpload)
+		assert.Equal(t, true, model.SyncUpload)
+		assert.Equal(t, SyncConflictKeepBoth, model.SyncConflict)
 		assert.Equal(t, "Foo", model.AccName)
 		assert.Equal(t, "bar", model.AccOwner)
 		assert.Equal(t, "test.com", model.AccURL)
 
-		accountUpdate := Service{AccName: "NewName", AccOwner: "NewOwner", AccURL: "new.com", SyncUpload: true, SyncDownload: true}
+		accountUpdate := Service{AccName: "NewName", AccOwner: "NewOwner", AccURL: "new.com", SyncUpload: true, SyncDownload: true, SyncConflict: SyncConflictNewest}
 
 		UpdateForm, err := form.NewService(accountUpdate)
 
@@ -87,11 +98,164 @@ func TestService_SaveForm(t *testing.T) {
 		}
 
 		assert.Equal(t, true, model.SyncDownload)
-		assert.Equal(t, false, model.SyncUpload)
+		assert.Equal(t, true, model.SyncUpload)
+		assert.Equal(t, SyncConflictNewest, model.SyncConflict)
 		assert.Equal(t, "NewName", model.AccName)
 		assert.Equal(t, "NewOwner", model.AccOwner)
 		assert.Equal(t, "new.com", model.AccURL)
 	})
+	t.Run("Filters", func(t *testing.T) {
+		account := Service{AccName: "Filters", AccURL: "test.com", AccType: "test", SyncPath: "/sync"}
+
+		accountForm, err := form.NewService(account)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		model, err := AddService(accountForm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// Files that have been ignored when uploading are checked again if the filters change.
+		ignored := NewFileSync(model.ID, "/sync/2020/photo.jpg")
+		ignored.Status = FileSyncIgnore
+		ignored.FileID = FileFixtures.Pointer("exampleFileName.jpg").ID
+
+		if err = ignored.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		since := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		accountForm.SyncInclude = "*.jpg\n/Albums/ "
+		accountForm.SyncExclude = "Private, *.tmp"
+		accountForm.SyncTypes = "raw,Image,foo"
+		accountForm.SyncMaxSize = -1
+		accountForm.SyncSince = &since
+
+		if err = model.SaveForm(accountForm); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "*.jpg, Albums", model.SyncInclude)
+		assert.Equal(t, "Private, *.tmp", model.SyncExclude)
+		assert.Equal(t, "image,raw", model.SyncTypes)
+		assert.Equal(t, 0, model.SyncMaxSize)
+		assert.Equal(t, since, *model.SyncSince)
+		assert.Equal(t, SyncStatusRefresh, model.SyncStatus)
+
+		var count int
+
+		if err = Db().Model(&FileSync{}).Where("service_id = ?", model.ID).Count(&count).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, 0, count)
+	})
+	t.Run("SyncConflict", func(t *testing.T) {
+		accountForm, err := form.NewService(Service{AccName: "Conflicts", AccURL: "test.com", AccType: "test", SyncConflict: SyncConflictLocal})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		model, err := AddService(accountForm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, SyncConflictLocal, model.SyncConflict)
+
+		for _, policy := range []string{SyncConflictKeepBoth, SyncConflictNewest, SyncConflictRemote, SyncConflictManual} {
+			accountForm.SyncConflict = policy
+			assert.NoError(t, model.SaveForm(accountForm))
+			assert.Equal(t, policy, model.SyncConflict)
+		}
+
+		accountForm.SyncConflict = "invalid"
+		assert.NoError(t, model.SaveForm(accountForm))
+		assert.Equal(t, SyncConflictKeepBoth, model.SyncConflict)
+	})
+}
+
+func TestService_AccKey(t *testing.T) {
+	t.Run("OpenSSH", func(t *testing.T) {
+		rsaKey, err := rsa.GenerateKey(rand.Reader, 4096)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		block, err := ssh.MarshalPrivateKey(rsaKey, "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		key := string(pem.EncodeToMemory(block))
+
+		assert.Greater(t, len(key), 3000)
+
+		accountForm, err := form.NewService(Service{AccName: "SFTP Key", AccURL: "sftp://key.example.com/photos", AccType: service.SFTP, AccKey: key, AccUser: "alice"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		model, err := AddService(accountForm)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var found Service
+
+		if err = Db().First(&found, model.ID).Error; err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, key, found.AccKey)
+
+		_, err = ssh.ParsePrivateKey([]byte(found.AccKey))
+		assert.NoError(t, err)
+	})
+}
+
+func TestService_PinHostKey(t *testing.T) {
+	accountForm, err := form.NewService(Service{AccName: "SFTP Host Key", AccURL: "sftp://pin.example.com/photos", AccType: service.SFTP, AccUser: "alice", AccPass: "secret"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	model, err := AddService(accountForm)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pinned := func() string {
+		var found Service
+		Db().First(&found, model.ID)
+		return found.AccHostKey
+	}
+
+	assert.NoError(t, model.PinHostKey("SHA256:abc"))
+	assert.Equal(t, "SHA256:abc", pinned())
+
+	// The host key is not changed if the URL remains the same.
+	accountForm.AccName = "SFTP Pinned Host Key"
+	assert.NoError(t, model.SaveForm(accountForm))
+	assert.Equal(t, "SHA256:abc", pinned())
+
+	// The host key must be pinned again when the server is changed.
+	accountForm.AccURL = "sftp://other.example.com/photos"
+	assert.NoError(t, model.SaveForm(accountForm))
+	assert.Equal(t, "", pinned())
 }
 
 func TestService_Delete(t *testing.T) {
@@ -173,6 +337,90 @@ func TestService_Directories(t *testing.T) {
 	})
 }
 
+func TestService_Client(t *testing.T) {
+	t.Run("WebDAV", func(t *testing.T) {
+		account := Service{AccURL: "http://dummy-webdav/", AccType: service.WebDAV, AccUser: "admin", AccPass: "photoprism"}
+
+		client, err := account.Client()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.IsType(t, &webdav.Client{}, client)
+	})
+	t.Run("S3", func(t *testing.T) {
+		account := Service{AccURL: "http://dummy-s3:9000/photos", AccType: service.S3, AccUser: "admin", AccPass: "photoprism"}
+
+		client, err := account.Client()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.IsType(t, &s3.Client{}, client)
+	})
+	t.Run("SFTP", func(t *testing.T) {
+		account := Service{AccURL: "sftp://dummy-sftp/photos", AccType: service.SFTP, AccUser: "admin", AccPass: "photoprism"}
+
+		client, err := account.Client()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.IsType(t, &sftp.Client{}, client)
+		assert.NoError(t, client.Close())
+	})
+	t.Run("Local", func(t *testing.T) {
+		dir := t.TempDir()
+		local.Roots = []string{dir}
+		defer func() { local.Roots = nil }()
+
+		account := Service{AccURL: "file://" + dir, AccType: service.Local}
+
+		client, err := account.Client()
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.IsType(t, &local.Client{}, client)
+	})
+	t.Run("LocalNotFound", func(t *testing.T) {
+		account := Service{AccURL: "file:///photoprism/missing", AccType: service.Local}
+
+		client, err := account.Client()
+
+		assert.Error(t, err)
+		assert.Nil(t, client)
+	})
+	t.Run("LocalNotAllowed", func(t *testing.T) {
+		account := Service{AccURL: "file://" + t.TempDir(), AccType: service.Local}
+
+		client, err := account.Client()
+
+		assert.Error(t, err)
+		assert.Nil(t, client)
+	})
+	t.Run("InvalidBucket", func(t *testing.T) {
+		account := Service{AccURL: "http://dummy-s3:9000/", AccType: service.S3, AccUser: "admin", AccPass: "photoprism"}
+
+		client, err := account.Client()
+
+		assert.Error(t, err)
+		assert.Nil(t, client)
+	})
+	t.Run("Unsupported", func(t *testing.T) {
+		account := Service{AccURL: "https://www.facebook.com/", AccType: service.Facebook}
+
+		client, err := account.Client()
+
+		assert.Error(t, err)
+		assert.Nil(t, client)
+	})
+}
+
 func TestService_Updates(t *testing.T) {
 	t.Run("Success", func(t *testing.T) {
 		account := Service{AccName: "DeleteAccount", AccOwner: "Delete", AccURL: "test.com", AccType: "test", AccKey: "123", AccUser: "testuser", AccPass: "testpass",
@@ -270,3 +518,9 @@ func TestService_Create(t *testing.T) {
 		}
 	})
 }
+
+func TestService_TwoWaySync(t *testing.T) {
+	assert.True(t, (&Service{AccSync: true, SyncDownload: true, SyncUpload: true}).TwoWaySync())
+	assert.False(t, (&Service{AccSync: false, SyncDownload: true, SyncUpload: true}).TwoWaySync())
+	assert.False(t, (&Service{AccSync: true, SyncDownload: true, SyncUpload: false}).TwoWaySync())
+}
@@ -3,6 +3,7 @@ package sortby
 const (
 	Default     = ""
 	Relevance   = "relevance"
+	Rank        = "rank"
 	Duration    = "duration"
 	Size        = "size"
 	Count       = "count"
@@ -42,6 +42,7 @@ const (
 	SrcSubject  Src = classify.SrcSubject // Prio 16
 	SrcKeyword  Src = classify.SrcKeyword // Prio 16
 	SrcMeta     Src = "meta"              // Prio 16
+	SrcTrack    Src = "track"             // Prio 16
 	SrcXmp      Src = "xmp"               // Prio 32
 	SrcBatch    Src = "batch"             // Prio 64
 	SrcVision   Src = "vision"            // Prio 64
@@ -80,6 +81,7 @@ var SrcPriority = Priorities{
 	SrcSubject:  16,
 	SrcKeyword:  16,
 	SrcMeta:     16,
+	SrcTrack:    16,
 	SrcXmp:      32,
 	SrcBatch:    64,
 	SrcVision:   64,
@@ -130,6 +132,7 @@ var SrcDesc = map[Src]string{
 	SrcSubject:  "Person",
 	SrcKeyword:  "Picture Keywords",
 	SrcMeta:     "Embedded Metadata",
+	SrcTrack:    "GPS Track",
 	SrcXmp:      "XMP Sidecar",
 	SrcBatch:    "Batch Edit",
 	SrcVision:   "Computer Vision (manual)",
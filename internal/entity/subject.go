@@ -500,6 +500,8 @@ func (m *Subject) RefreshPhotos() error {
 		err = UnscopedDb().Exec(update, m.SubjUID).Error
 	}
 
+	queueSearchIndexByMarker("m.subj_uid = ?", m.SubjUID)
+
 	return err
 }
 
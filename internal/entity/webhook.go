@@ -0,0 +1,258 @@
+package entity
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/jinzhu/gorm"
+	"github.com/ulule/deepcopier"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/service/webhook"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+	"github.com/photoprism/photoprism/pkg/txt/clip"
+)
+
+// WebhookUID is the unique ID prefix.
+const (
+	WebhookUID = byte('w')
+)
+
+// Webhooks represents a list of webhook endpoints.
+type Webhooks []Webhook
+
+// Webhook represents an endpoint that receives signed notifications for the subscribed event topics.
+//
+// Field Descriptions:
+// - WebhookEvents contains the subscribed event topics as comma-separated list, see webhook.Events.
+// - WebhookSecret is the "whsec_" prefixed key used to sign deliveries, it is not included in JSON responses.
+// - WebhookError and WebhookErrors contain the last error message and the number of failed deliveries since the last success.
+type Webhook struct {
+	WebhookUID     string     `gorm:"type:VARBINARY(42);primary_key;auto_increment:false;" json:"UID" yaml:"UID"`
+	WebhookName    string     `gorm:"type:VARCHAR(160);" json:"Name" yaml:"Name,omitempty"`
+	WebhookURL     string     `gorm:"type:VARCHAR(512);" json:"URL" yaml:"URL"`
+	WebhookSecret  string     `gorm:"type:VARBINARY(255);" json:"-" yaml:"-"`
+	WebhookEvents  string     `gorm:"type:VARBINARY(1024);" json:"Events" yaml:"Events,omitempty"`
+	WebhookEnabled bool       `json:"Enabled" yaml:"Enabled,omitempty"`
+	WebhookError   string     `gorm:"type:VARBINARY(512);" json:"Error" yaml:"Error,omitempty"`
+	WebhookErrors  int        `json:"Errors" yaml:"Errors,omitempty"`
+	CreatedAt      time.Time  `json:"CreatedAt" yaml:"-"`
+	UpdatedAt      time.Time  `json:"UpdatedAt" yaml:"-"`
+	DeletedAt      *time.Time `sql:"index" json:"DeletedAt,omitempty" yaml:"-"`
+}
+
+// TableName returns the entity table name.
+func (Webhook) TableName() string {
+	return "webhooks"
+}
+
+// BeforeCreate creates a random UID if needed before inserting a new row to the database.
+func (m *Webhook) BeforeCreate(scope *gorm.Scope) error {
+	if rnd.IsUID(m.WebhookUID, WebhookUID) {
+		return nil
+	}
+
+	m.WebhookUID = rnd.GenerateUID(WebhookUID)
+
+	return scope.SetColumn("WebhookUID", m.WebhookUID)
+}
+
+// AddWebhook registers a new webhook endpoint with a random signing secret.
+func AddWebhook(frm form.Webhook) (m *Webhook, err error) {
+	m = &Webhook{}
+
+	if m.WebhookSecret, err = webhook.NewSecret(); err != nil {
+		return m, err
+	}
+
+	err = m.SaveForm(frm)
+
+	return m, err
+}
+
+// FindWebhook returns the matching webhook or nil if it was not found.
+func FindWebhook(uid string) *Webhook {
+	if rnd.InvalidUID(uid, WebhookUID) {
+		return nil
+	}
+
+	m := &Webhook{}
+
+	// Find matching record.
+	if err := Db().First(m, "webhook_uid = ?", uid).Error; err != nil {
+		return nil
+	}
+
+	return m
+}
+
+// SaveForm validates the form values and saves them to the database.
+func (m *Webhook) SaveForm(frm form.Webhook) error {
+	if err := deepcopier.Copy(m).From(frm); err != nil {
+		return err
+	}
+
+	m.WebhookName = txt.Clip(m.WebhookName, txt.ClipName)
+	m.WebhookURL = strings.TrimSpace(m.WebhookURL)
+
+	if u, err := url.Parse(m.WebhookURL); err != nil || u.Host == "" || u.Scheme != "https" && u.Scheme != "http" {
+		return fmt.Errorf("invalid webhook url")
+	} else if len(m.WebhookURL) > txt.ClipURL {
+		return fmt.Errorf("webhook url is too long")
+	}
+
+	if events := webhook.ParseEvents(m.WebhookEvents); len(events) == 0 {
+		return fmt.Errorf("invalid webhook events %s", clean.Log(m.WebhookEvents))
+	} else {
+		m.WebhookEvents = strings.Join(events, ",")
+	}
+
+	// Reset errors when the webhook is changed.
+	m.WebhookError = ""
+	m.WebhookErrors = 0
+
+	return m.Save()
+}
+
+// NewSecret sets a new random signing secret and returns it.
+func (m *Webhook) NewSecret() (secret string, err error) {
+	if secret, err = webhook.NewSecret(); err != nil {
+		return "", err
+	}
+
+	m.WebhookSecret = secret
+
+	if m.WebhookUID == "" {
+		return secret, nil
+	}
+
+	return secret, m.Updates(Values{"webhook_secret": secret})
+}
+
+// Events returns the subscribed event topics.
+func (m *Webhook) Events() []string {
+	if m.WebhookEvents == "" {
+		return []string{}
+	}
+
+	return strings.Split(m.WebhookEvents, ",")
+}
+
+// Subscribed checks if the webhook is enabled and subscribed to the event topic.
+func (m *Webhook) Subscribed(topic string) bool {
+	if !m.WebhookEnabled || m.Deleted() {
+		return false
+	}
+
+	for _, ev := range m.Events() {
+		if ev == topic {
+			return true
+		}
+	}
+
+	return false
+}
+
+// LogErr updates the error message and counter based on the result of a delivery attempt.
+func (m *Webhook) LogErr(err error) error {
+	if err == nil {
+		if m.WebhookErrors == 0 {
+			return nil
+		}
+
+		m.WebhookError = ""
+		m.WebhookErrors = 0
+	} else {
+		m.WebhookError = clip.Chars(err.Error(), txt.ClipError)
+		m.WebhookErrors++
+	}
+
+	return m.Updates(Values{"webhook_error": m.WebhookError, "webhook_errors": m.WebhookErrors})
+}
+
+// Replay schedules the specified failed deliveries to be sent again, or all failed
+// deliveries if no IDs are passed, and returns the deliveries that will be resent.
+func (m *Webhook) Replay(ids []uint) (result WebhookDeliveries, err error) {
+	result = WebhookDeliveries{}
+
+	if m.WebhookUID == "" {
+		return result, fmt.Errorf("webhook uid is empty")
+	}
+
+	stmt := Db().Where("webhook_uid = ? AND status = ?", m.WebhookUID, WebhookFailed)
+
+	if len(ids) > 0 {
+		stmt = stmt.Where("id IN (?)", ids)
+	}
+
+	if err = stmt.Order("id").Find(&result).Error; err != nil {
+		return result, err
+	}
+
+	for i := range result {
+		if err = result[i].Replay(); err != nil {
+			return result, err
+		}
+	}
+
+	return result, nil
+}
+
+// Report returns the entity values as rows, without the signing secret.
+func (m *Webhook) Report(skipEmpty bool) (rows [][]string, cols []string) {
+	cols = []string{"Name", "Value"}
+
+	// Extract model values.
+	values, _, err := ModelValues(m, "WebhookSecret")
+
+	// Ok?
+	if err != nil {
+		return rows, cols
+	}
+
+	rows = make([][]string, 0, len(values))
+
+	for k, v := range values {
+		s := fmt.Sprintf("%#v", v)
+
+		// Skip empty values?
+		if !skipEmpty || s != "" {
+			rows = append(rows, []string{k, s})
+		}
+	}
+
+	return rows, cols
+}
+
+// Deleted checks if the webhook has been deleted.
+func (m *Webhook) Deleted() bool {
+	return m.DeletedAt != nil
+}
+
+// Delete marks the webhook as deleted, its delivery log is kept.
+func (m *Webhook) Delete() error {
+	if m.WebhookUID == "" {
+		return fmt.Errorf("webhook uid is empty")
+	}
+
+	return Db().Delete(m).Error
+}
+
+// Updates multiple columns in the database.
+func (m *Webhook) Updates(values interface{}) error {
+	return UnscopedDb().Model(m).UpdateColumns(values).Error
+}
+
+// Save updates the record in the database or inserts a new record if it does not already exist.
+func (m *Webhook) Save() error {
+	return Db().Save(m).Error
+}
+
+// Create inserts a new row to the database.
+func (m *Webhook) Create() error {
+	return Db().Create(m).Error
+}
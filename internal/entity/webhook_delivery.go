@@ -0,0 +1,136 @@
+package entity
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/service/webhook"
+	"github.com/photoprism/photoprism/pkg/rnd"
+	"github.com/photoprism/photoprism/pkg/txt"
+	"github.com/photoprism/photoprism/pkg/txt/clip"
+)
+
+// Webhook delivery status values.
+const (
+	WebhookPending   = "pending"
+	WebhookDelivered = "delivered"
+	WebhookFailed    = "failed"
+)
+
+// WebhookRetryDelays specifies the delay after each failed delivery attempt. A delivery
+// is marked as failed when all retries have been exhausted, so it can be replayed manually.
+var WebhookRetryDelays = []time.Duration{
+	time.Minute,
+	5 * time.Minute,
+	30 * time.Minute,
+	2 * time.Hour,
+	8 * time.Hour,
+}
+
+// WebhookDeliveries represents a list of webhook deliveries.
+type WebhookDeliveries []WebhookDelivery
+
+// WebhookDelivery represents a notification sent to a webhook endpoint, including the result of the last attempt.
+type WebhookDelivery struct {
+	ID           uint       `gorm:"primary_key" json:"ID" yaml:"-"`
+	WebhookUID   string     `gorm:"type:VARBINARY(42);index;" json:"WebhookUID" yaml:"WebhookUID"`
+	MessageID    string     `gorm:"type:VARBINARY(64);" json:"MessageID" yaml:"MessageID"`
+	Event        string     `gorm:"type:VARBINARY(64);" json:"Event" yaml:"Event"`
+	Payload      []byte     `gorm:"type:MEDIUMBLOB;" json:"-" yaml:"-"`
+	Status       string     `gorm:"type:VARBINARY(16);index;" json:"Status" yaml:"Status"`
+	Attempts     int        `json:"Attempts" yaml:"Attempts,omitempty"`
+	ResponseCode int        `json:"ResponseCode" yaml:"ResponseCode,omitempty"`
+	ResponseBody string     `gorm:"type:VARBINARY(1024);" json:"ResponseBody" yaml:"ResponseBody,omitempty"`
+	Error        string     `gorm:"type:VARBINARY(512);" json:"Error" yaml:"Error,omitempty"`
+	NextAttempt  time.Time  `sql:"index" json:"NextAttempt" yaml:"-"`
+	DeliveredAt  *time.Time `json:"DeliveredAt" yaml:"DeliveredAt,omitempty"`
+	CreatedAt    time.Time  `json:"CreatedAt" yaml:"-"`
+	UpdatedAt    time.Time  `json:"UpdatedAt" yaml:"-"`
+}
+
+// TableName returns the entity table name.
+func (WebhookDelivery) TableName() string {
+	return "webhooks_deliveries"
+}
+
+// NewWebhookDelivery returns a new pending delivery of the event payload to the webhook.
+func NewWebhookDelivery(webhookUid, event string, payload []byte) *WebhookDelivery {
+	return &WebhookDelivery{
+		WebhookUID:  webhookUid,
+		MessageID:   "msg_" + rnd.Base62(24),
+		Event:       event,
+		Payload:     payload,
+		Status:      WebhookPending,
+		NextAttempt: time.Now().UTC(),
+	}
+}
+
+// FindWebhookDelivery returns the matching delivery or nil if it was not found.
+func FindWebhookDelivery(id uint) *WebhookDelivery {
+	if id == 0 {
+		return nil
+	}
+
+	m := &WebhookDelivery{}
+
+	if err := Db().First(m, "id = ?", id).Error; err != nil {
+		return nil
+	}
+
+	return m
+}
+
+// Attempted updates the delivery status based on the result of a delivery attempt
+// and schedules a retry with increasing delay if it was not successful.
+func (m *WebhookDelivery) Attempted(resp webhook.Response, err error) error {
+	now := time.Now().UTC()
+
+	m.Attempts++
+	m.ResponseCode = resp.StatusCode
+	m.ResponseBody = clip.Chars(resp.Body, webhook.ResponseLimit)
+
+	if err == nil && !resp.Success() {
+		err = fmt.Errorf("endpoint returned status %d", resp.StatusCode)
+	}
+
+	if err == nil {
+		m.Status = WebhookDelivered
+		m.Error = ""
+		m.DeliveredAt = &now
+	} else if m.Error = clip.Chars(err.Error(), txt.ClipError); m.Attempts > len(WebhookRetryDelays) {
+		m.Status = WebhookFailed
+	} else {
+		m.Status = WebhookPending
+		m.NextAttempt = now.Add(WebhookRetryDelays[m.Attempts-1])
+	}
+
+	return m.Save()
+}
+
+// Replay schedules a failed delivery to be sent again with a new set of retries.
+func (m *WebhookDelivery) Replay() error {
+	if m.Status != WebhookFailed {
+		return fmt.Errorf("delivery %d has not failed", m.ID)
+	}
+
+	m.Status = WebhookPending
+	m.Attempts = 0
+	m.NextAttempt = time.Now().UTC()
+
+	return m.Save()
+}
+
+// Failed checks if all delivery attempts have failed.
+func (m *WebhookDelivery) Failed() bool {
+	return m.Status == WebhookFailed
+}
+
+// Save updates the record in the database or inserts a new record if it does not already exist.
+func (m *WebhookDelivery) Save() error {
+	return Db().Save(m).Error
+}
+
+// Create inserts a new row to the database.
+func (m *WebhookDelivery) Create() error {
+	return Db().Create(m).Error
+}
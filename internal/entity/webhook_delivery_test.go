@@ -0,0 +1,61 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/service/webhook"
+)
+
+func TestNewWebhookDelivery(t *testing.T) {
+	m := NewWebhookDelivery("wt2hdkz1vgdb3ta2", webhook.ImportCompleted, []byte(`{}`))
+
+	assert.Equal(t, WebhookPending, m.Status)
+	assert.Len(t, m.MessageID, 28)
+	assert.False(t, m.NextAttempt.IsZero())
+}
+
+func TestWebhookDelivery_Attempted(t *testing.T) {
+	t.Run("Delivered", func(t *testing.T) {
+		m := NewWebhookDelivery("wt2hdkz1vgdb3ta2", webhook.ImportCompleted, []byte(`{}`))
+
+		assert.NoError(t, m.Attempted(webhook.Response{StatusCode: 204}, nil))
+		assert.Equal(t, WebhookDelivered, m.Status)
+		assert.NotNil(t, m.DeliveredAt)
+		assert.Equal(t, 1, m.Attempts)
+		assert.NotNil(t, FindWebhookDelivery(m.ID))
+	})
+	t.Run("Retry", func(t *testing.T) {
+		m := NewWebhookDelivery("wt2hdkz1vgdb3ta2", webhook.ImportCompleted, []byte(`{}`))
+		before := m.NextAttempt
+
+		assert.NoError(t, m.Attempted(webhook.Response{StatusCode: 500, Body: "error"}, nil))
+		assert.Equal(t, WebhookPending, m.Status)
+		assert.Equal(t, "endpoint returned status 500", m.Error)
+		assert.Equal(t, "error", m.ResponseBody)
+		assert.True(t, m.NextAttempt.After(before))
+	})
+	t.Run("Failed", func(t *testing.T) {
+		m := NewWebhookDelivery("wt2hdkz1vgdb3ta2", webhook.ImportCompleted, []byte(`{}`))
+
+		for i := 0; i <= len(WebhookRetryDelays); i++ {
+			assert.NoError(t, m.Attempted(webhook.Response{}, assert.AnError))
+		}
+
+		assert.True(t, m.Failed())
+		assert.Equal(t, len(WebhookRetryDelays)+1, m.Attempts)
+
+		// Failed deliveries can be replayed.
+		assert.NoError(t, m.Replay())
+		assert.Equal(t, WebhookPending, m.Status)
+		assert.Equal(t, 0, m.Attempts)
+		assert.Error(t, m.Replay())
+	})
+}
+
+func TestFindWebhookDelivery(t *testing.T) {
+	assert.NotNil(t, FindWebhookDelivery(1000000))
+	assert.Nil(t, FindWebhookDelivery(0))
+	assert.Nil(t, FindWebhookDelivery(999))
+}
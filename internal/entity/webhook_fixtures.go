@@ -0,0 +1,111 @@
+package entity
+
+import (
+	"time"
+)
+
+type WebhookMap map[string]Webhook
+
+func (m WebhookMap) Get(name string) Webhook {
+	if result, ok := m[name]; ok {
+		return result
+	}
+
+	return Webhook{}
+}
+
+func (m WebhookMap) Pointer(name string) *Webhook {
+	if result, ok := m[name]; ok {
+		return &result
+	}
+
+	return &Webhook{}
+}
+
+var WebhookFixtures = WebhookMap{
+	"automation": {
+		WebhookUID:     "wt2hdkz1vgdb3ta2",
+		WebhookName:    "Automation",
+		WebhookURL:     "https://automation.example.com/hooks/photoprism",
+		WebhookSecret:  "whsec_MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw",
+		WebhookEvents:  "photos.created,import.completed,index.completed",
+		WebhookEnabled: true,
+		CreatedAt:      time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:      time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+	},
+	"disabled": {
+		WebhookUID:     "wt2hdl31ydq9yw0k",
+		WebhookName:    "Disabled",
+		WebhookURL:     "http://localhost:9999/webhook",
+		WebhookSecret:  "whsec_C2FVsBQIhrscChlQIMV+b5sSYspob7oD",
+		WebhookEvents:  "albums.created,albums.updated,albums.deleted",
+		WebhookEnabled: false,
+		WebhookError:   "endpoint returned status 503",
+		WebhookErrors:  6,
+		CreatedAt:      time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		UpdatedAt:      time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+	},
+}
+
+// CreateWebhookFixtures inserts known entities into the database for testing.
+func CreateWebhookFixtures() {
+	for _, entity := range WebhookFixtures {
+		Db().Create(&entity)
+	}
+}
+
+type WebhookDeliveryMap map[string]WebhookDelivery
+
+func (m WebhookDeliveryMap) Get(name string) WebhookDelivery {
+	if result, ok := m[name]; ok {
+		return result
+	}
+
+	return WebhookDelivery{}
+}
+
+func (m WebhookDeliveryMap) Pointer(name string) *WebhookDelivery {
+	if result, ok := m[name]; ok {
+		return &result
+	}
+
+	return &WebhookDelivery{}
+}
+
+var WebhookDeliveryFixtures = WebhookDeliveryMap{
+	"delivered": {
+		ID:           1000000,
+		WebhookUID:   WebhookFixtures.Pointer("automation").WebhookUID,
+		MessageID:    "msg_2Xr8Wv6hJ1yQk0CnV5tB9sLd",
+		Event:        "import.completed",
+		Payload:      []byte(`{"type":"import.completed","timestamp":"2024-01-01T12:00:00Z","data":{"seconds":5}}`),
+		Status:       WebhookDelivered,
+		Attempts:     1,
+		ResponseCode: 200,
+		NextAttempt:  time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+		DeliveredAt:  TimeStamp(),
+		CreatedAt:    time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+		UpdatedAt:    time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+	},
+	"failed": {
+		ID:           1000001,
+		WebhookUID:   WebhookFixtures.Pointer("disabled").WebhookUID,
+		MessageID:    "msg_7Pq3Zx1cN4mVb8Ty2Lk6Hs0R",
+		Event:        "albums.updated",
+		Payload:      []byte(`{"type":"albums.updated","timestamp":"2024-01-02T12:00:00Z","data":{}}`),
+		Status:       WebhookFailed,
+		Attempts:     6,
+		ResponseCode: 503,
+		Error:        "endpoint returned status 503",
+		NextAttempt:  time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC),
+		CreatedAt:    time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC),
+		UpdatedAt:    time.Date(2024, 1, 2, 12, 0, 0, 0, time.UTC),
+	},
+}
+
+// CreateWebhookDeliveryFixtures inserts known entities into the database for testing.
+func CreateWebhookDeliveryFixtures() {
+	for _, entity := range WebhookDeliveryFixtures {
+		Db().Create(&entity)
+	}
+}
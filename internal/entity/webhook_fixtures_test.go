@@ -0,0 +1,59 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWebhookMap_Get(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		r := WebhookFixtures.Get("automation")
+		assert.Equal(t, "wt2hdkz1vgdb3ta2", r.WebhookUID)
+		assert.IsType(t, Webhook{}, r)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		r := WebhookFixtures.Get("xxx")
+		assert.Equal(t, "", r.WebhookUID)
+		assert.IsType(t, Webhook{}, r)
+	})
+}
+
+func TestWebhookMap_Pointer(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		r := WebhookFixtures.Pointer("disabled")
+		assert.Equal(t, "wt2hdl31ydq9yw0k", r.WebhookUID)
+		assert.IsType(t, &Webhook{}, r)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		r := WebhookFixtures.Pointer("xxx")
+		assert.Equal(t, "", r.WebhookUID)
+		assert.IsType(t, &Webhook{}, r)
+	})
+}
+
+func TestWebhookDeliveryMap_Get(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		r := WebhookDeliveryFixtures.Get("failed")
+		assert.Equal(t, uint(1000001), r.ID)
+		assert.IsType(t, WebhookDelivery{}, r)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		r := WebhookDeliveryFixtures.Get("xxx")
+		assert.Equal(t, uint(0), r.ID)
+		assert.IsType(t, WebhookDelivery{}, r)
+	})
+}
+
+func TestWebhookDeliveryMap_Pointer(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		r := WebhookDeliveryFixtures.Pointer("delivered")
+		assert.Equal(t, uint(1000000), r.ID)
+		assert.IsType(t, &WebhookDelivery{}, r)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		r := WebhookDeliveryFixtures.Pointer("xxx")
+		assert.Equal(t, uint(0), r.ID)
+		assert.IsType(t, &WebhookDelivery{}, r)
+	})
+}
@@ -0,0 +1,164 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/service/webhook"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+func TestAddWebhook(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		m, err := AddWebhook(form.Webhook{
+			WebhookName:    "Test",
+			WebhookURL:     " https://example.com/hook ",
+			WebhookEvents:  "albums.*, import.completed, foo",
+			WebhookEnabled: true,
+		})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, rnd.IsUID(m.WebhookUID, WebhookUID))
+		assert.Equal(t, "https://example.com/hook", m.WebhookURL)
+		assert.Equal(t, "albums.created,albums.updated,albums.deleted,import.completed", m.WebhookEvents)
+		assert.Contains(t, m.WebhookSecret, "whsec_")
+
+		found := FindWebhook(m.WebhookUID)
+
+		if found == nil {
+			t.Fatal("webhook not found")
+		}
+
+		assert.Equal(t, m.WebhookSecret, found.WebhookSecret)
+		assert.NoError(t, found.Delete())
+		assert.Nil(t, FindWebhook(m.WebhookUID))
+	})
+	t.Run("AllEvents", func(t *testing.T) {
+		m, err := AddWebhook(form.Webhook{WebhookURL: "http://localhost:8080/hook"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, webhook.Events, m.Events())
+		assert.NoError(t, m.Delete())
+	})
+	t.Run("InvalidURL", func(t *testing.T) {
+		_, err := AddWebhook(form.Webhook{WebhookURL: "ftp://example.com/hook"})
+		assert.Error(t, err)
+	})
+	t.Run("InvalidEvents", func(t *testing.T) {
+		_, err := AddWebhook(form.Webhook{WebhookURL: "https://example.com/hook", WebhookEvents: "log.info"})
+		assert.Error(t, err)
+	})
+}
+
+func TestFindWebhook(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		m := FindWebhook("wt2hdkz1vgdb3ta2")
+
+		if m == nil {
+			t.Fatal("webhook not found")
+		}
+
+		assert.Equal(t, "Automation", m.WebhookName)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Nil(t, FindWebhook("xxx"))
+		assert.Nil(t, FindWebhook("wt2hdkz1vgdb3xxx"))
+	})
+}
+
+func TestWebhook_Subscribed(t *testing.T) {
+	t.Run("Enabled", func(t *testing.T) {
+		m := WebhookFixtures.Get("automation")
+		assert.True(t, m.Subscribed(webhook.PhotosCreated))
+		assert.False(t, m.Subscribed(webhook.PhotosUpdated))
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		m := WebhookFixtures.Get("disabled")
+		assert.False(t, m.Subscribed(webhook.AlbumsUpdated))
+	})
+}
+
+func TestWebhook_NewSecret(t *testing.T) {
+	m, err := AddWebhook(form.Webhook{WebhookURL: "https://example.com/secret"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	old := m.WebhookSecret
+	secret, err := m.NewSecret()
+
+	assert.NoError(t, err)
+	assert.NotEqual(t, old, secret)
+	assert.Equal(t, secret, FindWebhook(m.WebhookUID).WebhookSecret)
+	assert.NoError(t, m.Delete())
+}
+
+func TestWebhook_LogErr(t *testing.T) {
+	m, err := AddWebhook(form.Webhook{WebhookURL: "https://example.com/errors"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NoError(t, m.LogErr(assert.AnError))
+	assert.NoError(t, m.LogErr(assert.AnError))
+	assert.Equal(t, 2, FindWebhook(m.WebhookUID).WebhookErrors)
+	assert.NoError(t, m.LogErr(nil))
+	assert.Equal(t, 0, FindWebhook(m.WebhookUID).WebhookErrors)
+	assert.NoError(t, m.Delete())
+}
+
+func TestWebhook_Replay(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		m, err := AddWebhook(form.Webhook{WebhookURL: "https://example.com/replay"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		failed := NewWebhookDelivery(m.WebhookUID, webhook.PhotosCreated, []byte(`{}`))
+		failed.Status = WebhookFailed
+
+		if err = failed.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := m.Replay(nil)
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, WebhookPending, FindWebhookDelivery(failed.ID).Status)
+
+		result, err = m.Replay([]uint{failed.ID})
+
+		assert.NoError(t, err)
+		assert.Empty(t, result)
+		assert.NoError(t, m.Delete())
+	})
+	t.Run("NoUID", func(t *testing.T) {
+		m := Webhook{}
+		_, err := m.Replay(nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestWebhook_Report(t *testing.T) {
+	m := WebhookFixtures.Pointer("automation")
+	rows, cols := m.Report(true)
+
+	assert.Equal(t, []string{"Name", "Value"}, cols)
+	assert.NotEmpty(t, rows)
+
+	for _, r := range rows {
+		assert.NotEqual(t, "WebhookSecret", r[0])
+	}
+}
@@ -0,0 +1,28 @@
+package encode
+
+import "os/exec"
+
+// TranscodeToAv1Cmd returns the default FFmpeg command for transcoding video files to AV1 in an MP4 container.
+func TranscodeToAv1Cmd(srcName, destName string, opt Options) *exec.Cmd {
+	// #nosec G204 -- command arguments are built from validated options and paths.
+	return exec.Command(
+		opt.Bin,
+		"-hide_banner",
+		"-y",
+		"-strict", "-2",
+		"-i", srcName,
+		"-c:v", SoftwareAv1.String(),
+		"-map", opt.MapVideo,
+		"-map", opt.MapAudio,
+		"-ignore_unknown",
+		"-c:a", "aac",
+		"-preset", SvtAv1Preset(opt.Preset),
+		"-vf", opt.VideoFilter(FormatYUV420P),
+		"-max_muxing_queue_size", "1024",
+		"-crf", opt.Av1CrfQuality(),
+		"-f", "mp4",
+		"-movflags", opt.MovFlags,
+		"-map_metadata", opt.MapMetadata,
+		destName,
+	)
+}
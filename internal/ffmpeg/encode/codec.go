@@ -0,0 +1,90 @@
+package encode
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/http/header"
+)
+
+// Codec represents a video codec that videos can be transcoded to.
+type Codec string
+
+// Supported video transcoding target codecs, sorted from most to least efficient.
+const (
+	CodecAv1  Codec = "av1"  // AOMedia Video 1 (AV1)
+	CodecHevc Codec = "hevc" // High Efficiency Video Coding (HEVC, H.265)
+	CodecAvc  Codec = "avc"  // Advanced Video Coding (AVC, H.264)
+)
+
+// Codecs maps codec and format names to the supported transcoding target codecs.
+var Codecs = map[string]Codec{
+	"":     CodecAvc,
+	"avc":  CodecAvc,
+	"avc1": CodecAvc,
+	"h264": CodecAvc,
+	"x264": CodecAvc,
+	"hevc": CodecHevc,
+	"hvc":  CodecHevc,
+	"hvc1": CodecHevc,
+	"h265": CodecHevc,
+	"x265": CodecHevc,
+	"av1":  CodecAv1,
+	"av01": CodecAv1,
+	"svt":  CodecAv1,
+}
+
+// ParseCodec returns the transcoding target codec matching the specified name, or an empty string if it is not supported.
+func ParseCodec(s string) Codec {
+	if codec, ok := Codecs[strings.ToLower(strings.TrimSpace(s))]; ok {
+		return codec
+	}
+
+	return ""
+}
+
+// String returns the codec name as string.
+func (c Codec) String() string {
+	return string(c)
+}
+
+// Encoder returns the software encoder for the codec.
+func (c Codec) Encoder() Encoder {
+	switch c {
+	case CodecHevc:
+		return SoftwareHevc
+	case CodecAv1:
+		return SoftwareAv1
+	default:
+		return SoftwareAvc
+	}
+}
+
+// FileType returns the video file type of transcoded files.
+func (c Codec) FileType() fs.Type {
+	switch c {
+	case CodecHevc:
+		return fs.VideoHvc
+	case CodecAv1:
+		return fs.VideoAv1
+	default:
+		return fs.VideoAvc
+	}
+}
+
+// FileExt returns the file extension of transcoded files.
+func (c Codec) FileExt() string {
+	return "." + c.FileType().String()
+}
+
+// ContentType returns the HTTP content type of transcoded files.
+func (c Codec) ContentType() string {
+	switch c {
+	case CodecHevc:
+		return header.ContentTypeMp4HvcMain
+	case CodecAv1:
+		return header.ContentTypeMp4Av1Main
+	default:
+		return header.ContentTypeMp4AvcMain
+	}
+}
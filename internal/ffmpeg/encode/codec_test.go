@@ -0,0 +1,43 @@
+package encode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/http/header"
+)
+
+func TestParseCodec(t *testing.T) {
+	assert.Equal(t, CodecAvc, ParseCodec(""))
+	assert.Equal(t, CodecAvc, ParseCodec("H264"))
+	assert.Equal(t, CodecHevc, ParseCodec("hvc"))
+	assert.Equal(t, CodecHevc, ParseCodec(" HEVC "))
+	assert.Equal(t, CodecAv1, ParseCodec("av01"))
+	assert.Equal(t, Codec(""), ParseCodec("vp9"))
+}
+
+func TestCodec_Encoder(t *testing.T) {
+	assert.Equal(t, SoftwareAvc, CodecAvc.Encoder())
+	assert.Equal(t, SoftwareHevc, CodecHevc.Encoder())
+	assert.Equal(t, SoftwareAv1, CodecAv1.Encoder())
+	assert.Equal(t, CodecHevc, SoftwareHevc.Codec())
+	assert.Equal(t, CodecAv1, SoftwareAv1.Codec())
+	assert.Equal(t, CodecAvc, IntelAvc.Codec())
+}
+
+func TestCodec_FileType(t *testing.T) {
+	assert.Equal(t, fs.VideoAvc, CodecAvc.FileType())
+	assert.Equal(t, fs.VideoHvc, CodecHevc.FileType())
+	assert.Equal(t, fs.VideoAv1, CodecAv1.FileType())
+	assert.Equal(t, ".avc", CodecAvc.FileExt())
+	assert.Equal(t, ".hvc", CodecHevc.FileExt())
+	assert.Equal(t, ".av1", CodecAv1.FileExt())
+}
+
+func TestCodec_ContentType(t *testing.T) {
+	assert.Equal(t, header.ContentTypeMp4AvcMain, CodecAvc.ContentType())
+	assert.Equal(t, header.ContentTypeMp4HvcMain, CodecHevc.ContentType())
+	assert.Equal(t, header.ContentTypeMp4Av1Main, CodecAv1.ContentType())
+}
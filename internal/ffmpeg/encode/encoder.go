@@ -4,24 +4,38 @@ import (
 	"github.com/photoprism/photoprism/pkg/clean"
 )
 
-// Encoder represents a supported FFmpeg AVC encoder name.
+// Encoder represents a supported FFmpeg video encoder name.
 type Encoder string
 
-// String returns the FFmpeg AVC encoder name as string.
+// String returns the FFmpeg video encoder name as string.
 func (name Encoder) String() string {
 	return string(name)
 }
 
 // Currently supported FFmpeg output encoders.
 const (
-	SoftwareAvc Encoder = "libx264"           // SoftwareAvc see https://trac.ffmpeg.org/wiki/HWAccelIntro.
-	IntelAvc    Encoder = "h264_qsv"          // IntelAvc is the Intel Quick Sync H.264 encoder.
-	AppleAvc    Encoder = "h264_videotoolbox" // AppleAvc is the Apple Video Toolbox H.264 encoder.
-	VaapiAvc    Encoder = "h264_vaapi"        // VaapiAvc is the Video Acceleration API H.264 encoder.
-	NvidiaAvc   Encoder = "h264_nvenc"        // NvidiaAvc is the NVIDIA H.264 encoder.
-	V4LAvc      Encoder = "h264_v4l2m2m"      // V4LAvc is the Video4Linux H.264 encoder.
+	SoftwareAvc  Encoder = "libx264"           // SoftwareAvc see https://trac.ffmpeg.org/wiki/HWAccelIntro.
+	IntelAvc     Encoder = "h264_qsv"          // IntelAvc is the Intel Quick Sync H.264 encoder.
+	AppleAvc     Encoder = "h264_videotoolbox" // AppleAvc is the Apple Video Toolbox H.264 encoder.
+	VaapiAvc     Encoder = "h264_vaapi"        // VaapiAvc is the Video Acceleration API H.264 encoder.
+	NvidiaAvc    Encoder = "h264_nvenc"        // NvidiaAvc is the NVIDIA H.264 encoder.
+	V4LAvc       Encoder = "h264_v4l2m2m"      // V4LAvc is the Video4Linux H.264 encoder.
+	SoftwareHevc Encoder = "libx265"           // SoftwareHevc is the x265 software HEVC encoder.
+	SoftwareAv1  Encoder = "libsvtav1"         // SoftwareAv1 is the SVT-AV1 software encoder.
 )
 
+// Codec returns the target video codec of the encoder.
+func (name Encoder) Codec() Codec {
+	switch name {
+	case SoftwareHevc:
+		return CodecHevc
+	case SoftwareAv1:
+		return CodecAv1
+	default:
+		return CodecAvc
+	}
+}
+
 // AvcEncoders is the list of supported H.264 encoders with aliases.
 var AvcEncoders = map[string]Encoder{
 	"":                  SoftwareAvc,
@@ -0,0 +1,181 @@
+package encode
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// HTTP Live Streaming (HLS) playlist and segment settings.
+const (
+	HlsSegmentDuration = 6                 // Target segment duration in seconds.
+	HlsAudioBitrate    = 128               // Audio bitrate in kbit/s.
+	HlsMasterPlaylist  = "master.m3u8"     // Master playlist filename.
+	HlsPlaylist        = "index.m3u8"      // Rendition playlist filename.
+	HlsSegmentPrefix   = "segment_"        // Segment filename prefix.
+	HlsSegmentExt      = ".ts"             // Segment filename extension.
+	HlsSegmentPattern  = "segment_%05d.ts" // Segment filename pattern.
+	HlsPlaylistEnd     = "#EXT-X-ENDLIST"  // Tag that marks a complete playlist.
+	HlsCodecs          = "avc1.640028,mp4a.40.2"
+)
+
+// HlsRendition represents a video stream variant that can be selected by HLS clients based on the available bandwidth.
+type HlsRendition struct {
+	Size    int // Maximum width and height in pixels.
+	Bitrate int // Maximum video bitrate in kbit/s.
+}
+
+// HlsRenditions contains the supported HLS stream variants sorted by size, matching the thumb.VideoSizes limits.
+var HlsRenditions = []HlsRendition{
+	{Size: 720, Bitrate: 1200},
+	{Size: 1280, Bitrate: 3000},
+	{Size: 1920, Bitrate: 6000},
+	{Size: 2560, Bitrate: 10000},
+	{Size: 3840, Bitrate: 16000},
+}
+
+// FindHlsRendition returns the stream variant with the specified name.
+func FindHlsRendition(name string) (HlsRendition, bool) {
+	for _, r := range HlsRenditions {
+		if r.Name() == name {
+			return r, true
+		}
+	}
+
+	return HlsRendition{}, false
+}
+
+// NewHlsRenditions returns the stream variants for a video with the specified resolution,
+// so that videos are not upscaled and the configured size limit is not exceeded.
+func NewHlsRenditions(sizeLimit, width, height int) (result []HlsRendition) {
+	size := max(width, height)
+
+	for _, r := range HlsRenditions {
+		if r.Size > sizeLimit && sizeLimit > 0 {
+			break
+		} else if len(result) > 0 && r.Size > size && size > 0 {
+			break
+		}
+
+		result = append(result, r)
+	}
+
+	// Always provide at least the smallest stream variant.
+	if len(result) == 0 && len(HlsRenditions) > 0 {
+		result = append(result, HlsRenditions[0])
+	}
+
+	return result
+}
+
+// Name returns the rendition name used in playlist and cache paths.
+func (r HlsRendition) Name() string {
+	return strconv.Itoa(r.Size)
+}
+
+// Bandwidth returns the peak stream bandwidth in bit/s, including audio.
+func (r HlsRendition) Bandwidth() int {
+	return (r.Bitrate + HlsAudioBitrate) * 1000
+}
+
+// Resolution returns the output resolution for a video with the specified dimensions.
+func (r HlsRendition) Resolution(width, height int) (w, h int) {
+	if width <= 0 || height <= 0 {
+		return 0, 0
+	}
+
+	w, h = width, height
+
+	if w >= h && w > r.Size {
+		h = h * r.Size / w
+		w = r.Size
+	} else if h > w && h > r.Size {
+		w = w * r.Size / h
+		h = r.Size
+	}
+
+	// Dimensions must be divisible by 2.
+	return w - w%2, h - h%2
+}
+
+// HlsMasterPlaylistData returns the master playlist that references the rendition playlists.
+func HlsMasterPlaylistData(renditions []HlsRendition, width, height int) []byte {
+	var b strings.Builder
+
+	b.WriteString("#EXTM3U\n")
+	b.WriteString("#EXT-X-VERSION:3\n")
+
+	for _, r := range renditions {
+		if w, h := r.Resolution(width, height); w > 0 && h > 0 {
+			b.WriteString(fmt.Sprintf("#EXT-X-STREAM-INF:BANDWIDTH=%d,RESOLUTION=%dx%d,CODECS=\"%s\"\n", r.Bandwidth(), w, h, HlsCodecs))
+		} else {
+			b.WriteString(fmt.Sprintf("#EXT-X-STREAM-INF:BANDWIDTH=%d,CODECS=\"%s\"\n", r.Bandwidth(), HlsCodecs))
+		}
+
+		b.WriteString(fmt.Sprintf("%s/%s\n", r.Name(), HlsPlaylist))
+	}
+
+	return []byte(b.String())
+}
+
+// IsHlsSegment checks if the filename matches the segment filename pattern.
+func IsHlsSegment(fileName string) bool {
+	if !strings.HasPrefix(fileName, HlsSegmentPrefix) || !strings.HasSuffix(fileName, HlsSegmentExt) {
+		return false
+	}
+
+	n := strings.TrimSuffix(strings.TrimPrefix(fileName, HlsSegmentPrefix), HlsSegmentExt)
+
+	if n == "" {
+		return false
+	}
+
+	for _, c := range n {
+		if c < '0' || c > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// TranscodeToHlsCmd returns the FFmpeg command for segmenting a video into an HLS stream variant.
+// The command is killed when the context is canceled.
+// The software encoder is used so that keyframes can be placed exactly at segment boundaries.
+func TranscodeToHlsCmd(ctx context.Context, srcName, destDir string, opt Options, r HlsRendition) *exec.Cmd {
+	opt.SizeLimit = r.Size
+
+	// #nosec G204 -- command arguments are built from validated options and paths.
+	return exec.CommandContext(
+		ctx,
+		opt.Bin,
+		"-hide_banner",
+		"-y",
+		"-strict", "-2",
+		"-i", srcName,
+		"-map", opt.MapVideo,
+		"-map", opt.MapAudio,
+		"-ignore_unknown",
+		"-c:v", SoftwareAvc.String(),
+		"-preset", opt.Preset,
+		"-crf", opt.CrfQuality(),
+		"-maxrate", fmt.Sprintf("%dk", r.Bitrate),
+		"-bufsize", fmt.Sprintf("%dk", r.Bitrate*2),
+		"-vf", opt.VideoFilter(FormatYUV420P),
+		"-force_key_frames", fmt.Sprintf("expr:gte(t,n_forced*%d)", HlsSegmentDuration),
+		"-sc_threshold", "0",
+		"-c:a", "aac",
+		"-b:a", fmt.Sprintf("%dk", HlsAudioBitrate),
+		"-ac", "2",
+		"-max_muxing_queue_size", "1024",
+		"-f", "hls",
+		"-hls_time", strconv.Itoa(HlsSegmentDuration),
+		"-hls_playlist_type", "event",
+		"-hls_flags", "independent_segments+temp_file",
+		"-hls_segment_filename", filepath.Join(destDir, HlsSegmentPattern),
+		filepath.Join(destDir, HlsPlaylist),
+	)
+}
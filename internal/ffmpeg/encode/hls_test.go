@@ -0,0 +1,102 @@
+package encode
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindHlsRendition(t *testing.T) {
+	t.Run("Found", func(t *testing.T) {
+		r, ok := FindHlsRendition("1280")
+		assert.True(t, ok)
+		assert.Equal(t, 1280, r.Size)
+		assert.Equal(t, "1280", r.Name())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, ok := FindHlsRendition("1000")
+		assert.False(t, ok)
+		_, ok = FindHlsRendition("")
+		assert.False(t, ok)
+	})
+}
+
+func TestNewHlsRenditions(t *testing.T) {
+	names := func(renditions []HlsRendition) (result []string) {
+		for _, r := range renditions {
+			result = append(result, r.Name())
+		}
+
+		return result
+	}
+
+	t.Run("FullHD", func(t *testing.T) {
+		assert.Equal(t, []string{"720", "1280", "1920"}, names(NewHlsRenditions(4096, 1920, 1080)))
+	})
+	t.Run("Portrait4K", func(t *testing.T) {
+		assert.Equal(t, []string{"720", "1280", "1920", "2560", "3840"}, names(NewHlsRenditions(4096, 2160, 3840)))
+	})
+	t.Run("SizeLimit", func(t *testing.T) {
+		assert.Equal(t, []string{"720", "1280"}, names(NewHlsRenditions(1280, 3840, 2160)))
+	})
+	t.Run("Small", func(t *testing.T) {
+		assert.Equal(t, []string{"720"}, names(NewHlsRenditions(4096, 640, 480)))
+		assert.Equal(t, []string{"720"}, names(NewHlsRenditions(100, 1920, 1080)))
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		assert.Equal(t, []string{"720", "1280", "1920"}, names(NewHlsRenditions(1920, 0, 0)))
+	})
+}
+
+func TestHlsRendition_Resolution(t *testing.T) {
+	r := HlsRendition{Size: 1280, Bitrate: 3000}
+
+	w, h := r.Resolution(3840, 2160)
+	assert.Equal(t, 1280, w)
+	assert.Equal(t, 720, h)
+
+	w, h = r.Resolution(1080, 1920)
+	assert.Equal(t, 720, w)
+	assert.Equal(t, 1280, h)
+
+	w, h = r.Resolution(641, 481)
+	assert.Equal(t, 640, w)
+	assert.Equal(t, 480, h)
+
+	w, h = r.Resolution(0, 0)
+	assert.Equal(t, 0, w)
+	assert.Equal(t, 0, h)
+
+	assert.Equal(t, 3128000, r.Bandwidth())
+}
+
+func TestHlsMasterPlaylistData(t *testing.T) {
+	expected := "#EXTM3U\n" +
+		"#EXT-X-VERSION:3\n" +
+		"#EXT-X-STREAM-INF:BANDWIDTH=1328000,RESOLUTION=720x404,CODECS=\"avc1.640028,mp4a.40.2\"\n" +
+		"720/index.m3u8\n" +
+		"#EXT-X-STREAM-INF:BANDWIDTH=3128000,RESOLUTION=1280x720,CODECS=\"avc1.640028,mp4a.40.2\"\n" +
+		"1280/index.m3u8\n"
+
+	assert.Equal(t, expected, string(HlsMasterPlaylistData(NewHlsRenditions(1280, 1920, 1080), 1920, 1080)))
+}
+
+func TestIsHlsSegment(t *testing.T) {
+	assert.True(t, IsHlsSegment("segment_00001.ts"))
+	assert.False(t, IsHlsSegment("segment_.ts"))
+	assert.False(t, IsHlsSegment("segment_0001a.ts"))
+	assert.False(t, IsHlsSegment("segment_00001.ts.tmp"))
+	assert.False(t, IsHlsSegment("../index.m3u8"))
+	assert.False(t, IsHlsSegment(HlsPlaylist))
+}
+
+func TestTranscodeToHlsCmd(t *testing.T) {
+	opt := NewVideoOptions("/usr/bin/ffmpeg", IntelAvc, 4096, DefaultQuality, PresetFast, "", "", "")
+	r, _ := FindHlsRendition("1280")
+
+	cmd := TranscodeToHlsCmd(context.Background(), "SRC", "/cache/hls/1280", opt, r)
+
+	assert.Equal(t, "/usr/bin/ffmpeg -hide_banner -y -strict -2 -i SRC -map 0:v:0 -map 0:a:0? -ignore_unknown -c:v libx264 -preset fast -crf 25 -maxrate 3000k -bufsize 6000k -vf scale='if(gte(iw,ih), min(1280, iw), -2):if(gte(iw,ih), -2, min(1280, ih))',format=yuv420p -force_key_frames expr:gte(t,n_forced*6) -sc_threshold 0 -c:a aac -b:a 128k -ac 2 -max_muxing_queue_size 1024 -f hls -hls_time 6 -hls_playlist_type event -hls_flags independent_segments+temp_file -hls_segment_filename /cache/hls/1280/segment_%05d.ts /cache/hls/1280/index.m3u8", cmd.String())
+	assert.Equal(t, 4096, opt.SizeLimit)
+}
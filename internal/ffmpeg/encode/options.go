@@ -145,6 +145,11 @@ func (o *Options) CrfQuality() string {
 	return CrfQuality(o.Quality)
 }
 
+// Av1CrfQuality returns the video encoding quality as "-crf" parameter string for AV1 encoders.
+func (o *Options) Av1CrfQuality() string {
+	return Av1CrfQuality(o.Quality)
+}
+
 // QpQuality returns the video encoding quality as "-qp" parameter string.
 func (o *Options) QpQuality() string {
 	return QpQuality(o.Quality)
@@ -13,3 +13,28 @@ const (
 	PresetSlower    = "slower"
 	PresetVerySlow  = "veryslow"
 )
+
+// SvtAv1Preset returns the numeric SVT-AV1 encoder preset that matches the specified preset name,
+// where lower values are slower and result in a better compression.
+func SvtAv1Preset(preset string) string {
+	switch preset {
+	case PresetUltraFast:
+		return "12"
+	case PresetSuperFast:
+		return "11"
+	case PresetVeryFast:
+		return "10"
+	case PresetFaster:
+		return "9"
+	case PresetMedium:
+		return "6"
+	case PresetSlow:
+		return "5"
+	case PresetSlower:
+		return "4"
+	case PresetVerySlow:
+		return "2"
+	default:
+		return "8"
+	}
+}
@@ -105,3 +105,24 @@ func CqQuality(q int) string {
 		return fmt.Sprintf("%d", result)
 	}
 }
+
+// Av1CrfQuality returns the video encoding quality as "-crf" parameter string for AV1 encoders,
+// which use a scale from 0 to 63.
+func Av1CrfQuality(q int) string {
+	if q <= 0 {
+		q = DefaultQuality
+	} else if q > BestQuality {
+		q = BestQuality
+	}
+
+	result := (100 - q) * 63 / 100
+
+	switch {
+	case result < 1:
+		return "1"
+	case result > 63:
+		return "63"
+	default:
+		return fmt.Sprintf("%d", result)
+	}
+}
@@ -56,3 +56,13 @@ func TestCqQuality(t *testing.T) {
 		assert.Equal(t, "1", CqQuality(123))
 	})
 }
+
+func TestAv1CrfQuality(t *testing.T) {
+	t.Run("Defaults", func(t *testing.T) {
+		assert.Equal(t, "31", Av1CrfQuality(0))
+		assert.Equal(t, "1", Av1CrfQuality(BestQuality))
+		assert.Equal(t, "31", Av1CrfQuality(DefaultQuality))
+		assert.Equal(t, "62", Av1CrfQuality(WorstQuality))
+		assert.Equal(t, "1", Av1CrfQuality(102))
+	})
+}
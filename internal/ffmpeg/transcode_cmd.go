@@ -13,7 +13,8 @@ import (
 	"github.com/photoprism/photoprism/pkg/fs"
 )
 
-// TranscodeCmd returns the FFmpeg command for transcoding existing video files to MPEG-4 AVC.
+// TranscodeCmd returns the FFmpeg command for transcoding existing video files with the selected encoder,
+// e.g. to MPEG-4 AVC, HEVC, or AV1.
 func TranscodeCmd(srcName, destName string, opt encode.Options) (cmd *exec.Cmd, useMutex bool, err error) {
 	if srcName == "" {
 		return nil, false, fmt.Errorf("empty source filename")
@@ -66,6 +67,10 @@ func TranscodeCmd(srcName, destName string, opt encode.Options) (cmd *exec.Cmd,
 		cmd = nvidia.TranscodeToAvcCmd(srcName, destName, opt)
 	case encode.V4LAvc:
 		cmd = v4l.TranscodeToAvcCmd(srcName, destName, opt)
+	case encode.SoftwareHevc:
+		cmd = encode.TranscodeToHevcCmd(srcName, destName, opt)
+	case encode.SoftwareAv1:
+		cmd = encode.TranscodeToAv1Cmd(srcName, destName, opt)
 	default:
 		cmd = encode.TranscodeToAvcCmd(srcName, destName, opt)
 	}
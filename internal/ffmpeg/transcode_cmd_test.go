@@ -58,6 +58,52 @@ func TestTranscodeCmd(t *testing.T) {
 		// Run generated command to test software transcoding.
 		RunCommandTest(t, opt.Encoder, srcName, destName, cmd, true)
 	})
+	t.Run("VP9toHEVC", func(t *testing.T) {
+		opt := encode.NewVideoOptions(ffmpegBin, encode.SoftwareHevc, 1500, encode.DefaultQuality, encode.PresetFast, "", "", "")
+
+		srcName := fs.Abs("./testdata/25fps.vp9")
+		destName := fs.Abs("./testdata/25fps.hvc")
+
+		cmd, _, err := TranscodeCmd(srcName, destName, opt)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cmdStr := cmd.String()
+		cmdStr = strings.Replace(cmdStr, srcName, "SRC", 1)
+		cmdStr = strings.Replace(cmdStr, destName, "DEST", 1)
+
+		assert.Equal(t, "/usr/bin/ffmpeg -hide_banner -y -strict -2 -i SRC -c:v libx265 -map 0:v:0 -map 0:a:0? -ignore_unknown -c:a aac -preset fast -vf scale='if(gte(iw,ih), min(1500, iw), -2):if(gte(iw,ih), -2, min(1500, ih))',format=yuv420p -max_muxing_queue_size 1024 -crf 25 -tag:v hvc1 -x265-params log-level=error -f mp4 -movflags use_metadata_tags+faststart -map_metadata 0 DEST", cmdStr)
+
+		// This transcoding test requires FFmpeg to be built with libx265:
+		if os.Getenv("PHOTOPRISM_FFMPEG_ENCODER") == encode.SoftwareHevc.String() {
+			RunCommandTest(t, opt.Encoder, srcName, destName, cmd, true)
+		}
+	})
+	t.Run("VP9toAV1", func(t *testing.T) {
+		opt := encode.NewVideoOptions(ffmpegBin, encode.SoftwareAv1, 1500, encode.DefaultQuality, encode.PresetFast, "", "", "")
+
+		srcName := fs.Abs("./testdata/25fps.vp9")
+		destName := fs.Abs("./testdata/25fps.av1")
+
+		cmd, _, err := TranscodeCmd(srcName, destName, opt)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cmdStr := cmd.String()
+		cmdStr = strings.Replace(cmdStr, srcName, "SRC", 1)
+		cmdStr = strings.Replace(cmdStr, destName, "DEST", 1)
+
+		assert.Equal(t, "/usr/bin/ffmpeg -hide_banner -y -strict -2 -i SRC -c:v libsvtav1 -map 0:v:0 -map 0:a:0? -ignore_unknown -c:a aac -preset 8 -vf scale='if(gte(iw,ih), min(1500, iw), -2):if(gte(iw,ih), -2, min(1500, ih))',format=yuv420p -max_muxing_queue_size 1024 -crf 31 -f mp4 -movflags use_metadata_tags+faststart -map_metadata 0 DEST", cmdStr)
+
+		// This transcoding test requires FFmpeg to be built with libsvtav1:
+		if os.Getenv("PHOTOPRISM_FFMPEG_ENCODER") == encode.SoftwareAv1.String() {
+			RunCommandTest(t, opt.Encoder, srcName, destName, cmd, true)
+		}
+	})
 	t.Run("Vaapi", func(t *testing.T) {
 		opt := encode.NewVideoOptions(ffmpegBin, encode.VaapiAvc, 1500, encode.DefaultQuality, encode.PresetFast, "", "", "")
 
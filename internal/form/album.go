@@ -1,6 +1,14 @@
 package form
 
-import "github.com/ulule/deepcopier"
+import (
+	"errors"
+	"strings"
+
+	"github.com/ulule/deepcopier"
+)
+
+// AlbumFilterMaxLength is the maximum length of a saved album search filter.
+const AlbumFilterMaxLength = 2048
 
 // Album represents an album edit form.
 type Album struct {
@@ -28,3 +36,16 @@ func NewAlbum(m interface{}) (*Album, error) {
 
 	return frm, err
 }
+
+// ValidateFilter checks if the album filter is a valid photo search filter, e.g. for smart albums.
+func (f *Album) ValidateFilter() error {
+	f.AlbumFilter = strings.TrimSpace(f.AlbumFilter)
+
+	if f.AlbumFilter == "" {
+		return errors.New("filter must not be empty")
+	} else if len(f.AlbumFilter) > AlbumFilterMaxLength {
+		return errors.New("filter is too long")
+	}
+
+	return Unserialize(&SearchPhotos{}, f.AlbumFilter)
+}
@@ -1,6 +1,7 @@
 package form
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -38,3 +39,31 @@ func TestNewAlbum(t *testing.T) {
 		assert.Equal(t, true, r.AlbumFavorite)
 	})
 }
+
+func TestAlbum_ValidateFilter(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		frm := &Album{AlbumFilter: " label:beach country:es year:2024 favorite:true "}
+		assert.NoError(t, frm.ValidateFilter())
+		assert.Equal(t, "label:beach country:es year:2024 favorite:true", frm.AlbumFilter)
+	})
+	t.Run("Query", func(t *testing.T) {
+		frm := &Album{AlbumFilter: "sunset label:beach"}
+		assert.NoError(t, frm.ValidateFilter())
+	})
+	t.Run("Empty", func(t *testing.T) {
+		frm := &Album{AlbumFilter: "  "}
+		assert.Error(t, frm.ValidateFilter())
+	})
+	t.Run("UnknownFilter", func(t *testing.T) {
+		frm := &Album{AlbumFilter: "foo:bar"}
+		assert.Error(t, frm.ValidateFilter())
+	})
+	t.Run("InvalidValue", func(t *testing.T) {
+		frm := &Album{AlbumFilter: "dist:far"}
+		assert.Error(t, frm.ValidateFilter())
+	})
+	t.Run("TooLong", func(t *testing.T) {
+		frm := &Album{AlbumFilter: "label:" + strings.Repeat("x", AlbumFilterMaxLength)}
+		assert.Error(t, frm.ValidateFilter())
+	})
+}
@@ -0,0 +1,15 @@
+package form
+
+import (
+	"github.com/photoprism/photoprism/pkg/txt"
+)
+
+// Comment represents a comment on a photo or album.
+type Comment struct {
+	Text string `json:"Text"`
+}
+
+// Empty reports whether the comment form lacks text.
+func (f Comment) Empty() bool {
+	return txt.Clip(f.Text, txt.ClipText) == ""
+}
@@ -0,0 +1,17 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestComment_Empty(t *testing.T) {
+	t.Run("True", func(t *testing.T) {
+		assert.True(t, Comment{}.Empty())
+		assert.True(t, Comment{Text: "   "}.Empty())
+	})
+	t.Run("False", func(t *testing.T) {
+		assert.False(t, Comment{Text: "Great shot!"}.Empty())
+	})
+}
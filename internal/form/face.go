@@ -14,3 +14,8 @@ func NewFace(m interface{}) (f Face, err error) {
 
 	return f, err
 }
+
+// FaceSuggestions represents a request to confirm or reject suggested face merges.
+type FaceSuggestions struct {
+	Suggestions []uint `json:"Suggestions" binding:"required"` // Suggestion IDs.
+}
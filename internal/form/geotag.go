@@ -0,0 +1,32 @@
+package form
+
+import (
+	"time"
+)
+
+// Geotag represents the form fields for matching pictures with uploaded GPS tracks.
+type Geotag struct {
+	Offset   string `form:"offset" json:"offset"`
+	TimeZone string `form:"timezone" json:"timezone"`
+	MaxGap   string `form:"maxGap" json:"maxGap"`
+	DryRun   bool   `form:"dryRun" json:"dryRun"`
+}
+
+// OffsetDuration returns the camera clock offset, e.g. "-1h30m".
+func (f *Geotag) OffsetDuration() (time.Duration, error) {
+	return parseDuration(f.Offset)
+}
+
+// MaxGapDuration returns the maximum time between track points and pictures.
+func (f *Geotag) MaxGapDuration() (time.Duration, error) {
+	return parseDuration(f.MaxGap)
+}
+
+// parseDuration parses a duration string, and returns zero if it is empty.
+func parseDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	return time.ParseDuration(s)
+}
@@ -0,0 +1,35 @@
+package form
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGeotag(t *testing.T) {
+	t.Run("Durations", func(t *testing.T) {
+		frm := Geotag{Offset: "-1h30m", MaxGap: "15m"}
+
+		offset, err := frm.OffsetDuration()
+		assert.NoError(t, err)
+		assert.Equal(t, -90*time.Minute, offset)
+
+		maxGap, err := frm.MaxGapDuration()
+		assert.NoError(t, err)
+		assert.Equal(t, 15*time.Minute, maxGap)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		frm := Geotag{}
+
+		offset, err := frm.OffsetDuration()
+		assert.NoError(t, err)
+		assert.Equal(t, time.Duration(0), offset)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		frm := Geotag{Offset: "one hour"}
+
+		_, err := frm.OffsetDuration()
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,21 @@
+package form
+
+import (
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// Grant represents a form to share a photo or album with a registered user or team.
+type Grant struct {
+	UserUID string `json:"UserUID"`
+	TeamUID string `json:"TeamUID"`
+	Perm    uint   `json:"Perm"`
+}
+
+// Principal returns the UID of the team or, if no team was specified, of the user to share with.
+func (f Grant) Principal() string {
+	if uid := clean.UID(f.TeamUID); uid != "" {
+		return uid
+	}
+
+	return clean.UID(f.UserUID)
+}
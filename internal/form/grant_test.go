@@ -0,0 +1,19 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGrant_Principal(t *testing.T) {
+	t.Run("User", func(t *testing.T) {
+		assert.Equal(t, "uqxc08w3d0ej2283", Grant{UserUID: "uqxc08w3d0ej2283"}.Principal())
+	})
+	t.Run("Team", func(t *testing.T) {
+		assert.Equal(t, "tt2hdkz1vgdb3ta2", Grant{UserUID: "uqxc08w3d0ej2283", TeamUID: "tt2hdkz1vgdb3ta2"}.Principal())
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, "", Grant{}.Principal())
+	})
+}
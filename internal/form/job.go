@@ -0,0 +1,64 @@
+package form
+
+import (
+	"strings"
+
+	"github.com/ulule/deepcopier"
+	"github.com/urfave/cli/v2"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// Job represents the settings of a scheduled job.
+type Job struct {
+	JobName     string `json:"Name"`
+	JobActions  string `json:"Actions"`  // Comma-separated actions that run in sequence, e.g. "import, faces".
+	JobPath     string `json:"Path"`     // Optional sub-folder for the index, import, purge, and thumbs actions.
+	JobSchedule string `json:"Schedule"` // Cron schedule, e.g. "0 2 * * *", or empty to run the job manually only.
+	JobPaused   bool   `json:"Paused"`
+}
+
+// NewJob creates a new job form with values from the specified model.
+func NewJob(m interface{}) (f Job, err error) {
+	err = deepcopier.Copy(m).To(&f)
+
+	return f, err
+}
+
+// AddJobFromCli creates a new form for adding a job with values from the specified CLI context.
+func AddJobFromCli(ctx *cli.Context) Job {
+	f := Job{
+		JobName:     clean.Name(ctx.String("name")),
+		JobActions:  strings.Join(ctx.Args().Slice(), ","),
+		JobPath:     strings.TrimSpace(ctx.String("path")),
+		JobSchedule: strings.TrimSpace(ctx.String("schedule")),
+		JobPaused:   ctx.Bool("pause"),
+	}
+
+	return f
+}
+
+// ModJobFromCli updates the form with the values that have been set in the specified CLI context.
+func (f *Job) ModJobFromCli(ctx *cli.Context) {
+	if ctx.IsSet("name") {
+		f.JobName = clean.Name(ctx.String("name"))
+	}
+
+	if ctx.IsSet("actions") {
+		f.JobActions = strings.Join(ctx.StringSlice("actions"), ",")
+	}
+
+	if ctx.IsSet("path") {
+		f.JobPath = strings.TrimSpace(ctx.String("path"))
+	}
+
+	if ctx.IsSet("schedule") {
+		f.JobSchedule = strings.TrimSpace(ctx.String("schedule"))
+	}
+
+	if ctx.Bool("pause") {
+		f.JobPaused = true
+	} else if ctx.Bool("resume") {
+		f.JobPaused = false
+	}
+}
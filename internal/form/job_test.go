@@ -0,0 +1,74 @@
+package form
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v2"
+)
+
+func TestNewJob(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var m = struct {
+			JobName     string
+			JobActions  string
+			JobPath     string
+			JobSchedule string
+			JobPaused   bool
+		}{
+			JobName:     "Nightly Import",
+			JobActions:  "import,faces",
+			JobPath:     "scans",
+			JobSchedule: "0 2 * * *",
+			JobPaused:   true,
+		}
+
+		f, err := NewJob(m)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Nightly Import", f.JobName)
+		assert.Equal(t, "import,faces", f.JobActions)
+		assert.Equal(t, "scans", f.JobPath)
+		assert.Equal(t, "0 2 * * *", f.JobSchedule)
+		assert.True(t, f.JobPaused)
+	})
+}
+
+func TestJobFromCli(t *testing.T) {
+	newContext := func(args ...string) *cli.Context {
+		set := flag.NewFlagSet("test", flag.ContinueOnError)
+		set.String("name", "", "")
+		set.Var(cli.NewStringSlice(), "actions", "")
+		set.String("path", "", "")
+		set.String("schedule", "", "")
+		set.Bool("pause", false, "")
+		set.Bool("resume", false, "")
+
+		if err := set.Parse(args); err != nil {
+			t.Fatal(err)
+		}
+
+		return cli.NewContext(cli.NewApp(), set, nil)
+	}
+
+	t.Run("Add", func(t *testing.T) {
+		f := AddJobFromCli(newContext("--name", "Nightly Import", "--schedule", " 0 2 * * * ", "import", "faces"))
+
+		assert.Equal(t, "Nightly Import", f.JobName)
+		assert.Equal(t, "import,faces", f.JobActions)
+		assert.Equal(t, "", f.JobPath)
+		assert.Equal(t, "0 2 * * *", f.JobSchedule)
+		assert.False(t, f.JobPaused)
+	})
+	t.Run("Mod", func(t *testing.T) {
+		f := Job{JobName: "Old", JobActions: "index", JobSchedule: "daily", JobPaused: true}
+		f.ModJobFromCli(newContext("--actions", "cleanup", "--actions", "purge", "--path", "2024", "--resume"))
+
+		assert.Equal(t, "Old", f.JobName)
+		assert.Equal(t, "cleanup,purge", f.JobActions)
+		assert.Equal(t, "2024", f.JobPath)
+		assert.Equal(t, "daily", f.JobSchedule)
+		assert.False(t, f.JobPaused)
+	})
+}
@@ -1,6 +1,7 @@
 package form
 
 import (
+	"reflect"
 	"time"
 
 	"github.com/photoprism/photoprism/pkg/fs"
@@ -9,7 +10,7 @@ import (
 
 // SearchPhotos represents search form fields for "/api/v1/photos".
 type SearchPhotos struct {
-	Query       string    `form:"q"`
+	Query       string    `form:"q" notes:"Search terms and filters, which can be negated with - or NOT, combined with OR, grouped with (), and specify ranges like iso:100..400"`
 	Scope       string    `form:"s" serialize:"-" example:"s:ariqwb43p5dh9h13" notes:"Restricts results to the specified album UID or other supported scopes"`
 	Filter      string    `form:"filter" serialize:"-" notes:"-"`
 	ID          string    `form:"id" example:"id:123e4567-e89b-..." notes:"Finds content with the specified Image, Document or Instance IDs, separated by |"`
@@ -53,6 +54,8 @@ type SearchPhotos struct {
 	Favorite    string    `form:"favorite" example:"favorite:true favorite:false" notes:"Finds favorite content"`
 	Unsorted    bool      `form:"unsorted" notes:"Finds content that is not in an album"`
 	Near        string    `form:"near" example:"near:pqbcf5j446s0futy" notes:"Finds nearby pictures (UID)"`
+	Similar     string    `form:"similar" example:"similar:pqbcf5j446s0futy" notes:"Finds visually similar pictures (UID)"`
+	Semantic    bool      `form:"semantic" notes:"Finds pictures by meaning, ranked by similarity to the search query"`
 	S2          string    `form:"s2" example:"s2:4799e370ca54c8b9"  notes:"Position, specified as S2 Cell ID"`
 	Olc         string    `form:"olc" example:"olc:8FWCHX7W+" notes:"Open Location Code (OLC)"`
 	Lat         float64   `form:"lat" example:"lat:41.894043" notes:"Position latitude (-90.0 to 90.0 deg)"`
@@ -101,6 +104,9 @@ type SearchPhotos struct {
 	Reverse     bool      `form:"reverse" serialize:"-"`                                                                                                                                 // Merge FILES in response
 	Merged      bool      `form:"merged" serialize:"-"`                                                                                                                                  // Merge FILES in response
 	Details     bool      `form:"-" serialize:"-"`                                                                                                                                       // Include additional information from details table
+
+	// Expr contains terms that are negated, combined with OR, or specify a range.
+	Expr *QueryExpr `form:"-" serialize:"-"`
 }
 
 // GetQuery returns the current search query string.
@@ -113,8 +119,24 @@ func (f *SearchPhotos) SetQuery(q string) {
 	f.Query = q
 }
 
-// ParseQueryString deserializes the query string into form fields and applies aliases.
+// ParseQueryString deserializes the query string into form fields and applies aliases. Terms that
+// are negated, combined with OR, or specify a range are stored as boolean expression in Expr.
 func (f *SearchPhotos) ParseQueryString() error {
+	if expr, err := ParseQuery(f.Query); err != nil {
+		// Unbalanced quotes are ignored in queries without boolean operators for backward compatibility.
+		if !IsQuoteError(err) || IsBooleanQuery(f.Query) {
+			return err
+		}
+
+		f.Expr = nil
+	} else if expr.IsBoolean() {
+		var terms []*QueryExpr
+		terms, f.Expr = expr.Split()
+		f.Query = QueryString(terms)
+	} else {
+		f.Expr = nil
+	}
+
 	if err := ParseQueryString(f); err != nil {
 		return err
 	}
@@ -180,7 +202,20 @@ func (f *SearchPhotos) SerializeAll() string {
 
 // FindUidOnly checks if search filters other than UID may be skipped to improve performance.
 func (f *SearchPhotos) FindUidOnly() bool {
-	return f.UID != "" && f.Query == "" && f.Scope == "" && f.Filter == "" && f.Album == "" && f.Albums == ""
+	return f.UID != "" && f.Query == "" && f.Expr == nil && f.Scope == "" && f.Filter == "" && f.Album == "" && f.Albums == ""
+}
+
+// HasFilter checks if a search filter with the specified name exists.
+func (f *SearchPhotos) HasFilter(name string) bool {
+	t := reflect.TypeOf(*f)
+
+	for i := 0; i < t.NumField(); i++ {
+		if tag := t.Field(i).Tag; tag.Get("form") == name && tag.Get("serialize") != "-" {
+			return true
+		}
+	}
+
+	return false
 }
 
 // NewSearchPhotos creates a SearchPhotos form with the provided query.
@@ -0,0 +1,531 @@
+package form
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Operators of parsed search query expressions.
+const (
+	QueryTerm = ""
+	QueryAnd  = "AND"
+	QueryOr   = "OR"
+	QueryNot  = "NOT"
+)
+
+// QueryExpr represents a node of a parsed search query, which is either a term, i.e. a word, a quoted
+// phrase, or a "key:value" filter, or an AND, OR, or NOT operator applied to the child nodes.
+//
+// Field Descriptions:
+// - Value contains the lower bound of a range like "iso:100..400", which is empty if it is open.
+// - Max contains the upper bound of a range, which is empty if it is open.
+// - Phrase indicates that the value was quoted.
+// - Pos contains the position of the term in the query string, starting at 1.
+type QueryExpr struct {
+	Op     string
+	Key    string
+	Value  string
+	Max    string
+	Range  bool
+	Phrase bool
+	Pos    int
+	Nodes  []*QueryExpr
+}
+
+// queryQuoteMissing is the error message for phrases without a closing quote.
+const queryQuoteMissing = "missing closing quote"
+
+// QueryError represents a search query syntax error.
+type QueryError struct {
+	Pos int
+	Msg string
+}
+
+// Error returns the error message including the position in the query string.
+func (e *QueryError) Error() string {
+	return fmt.Sprintf("%s at position %d", e.Msg, e.Pos)
+}
+
+// NewQueryError returns a new search query syntax error for the specified position.
+func NewQueryError(pos int, format string, args ...interface{}) *QueryError {
+	return &QueryError{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+// IsQueryError checks if the error is a search query syntax error.
+func IsQueryError(err error) bool {
+	var queryErr *QueryError
+	return errors.As(err, &queryErr)
+}
+
+// ParseQuery parses a search query into an expression tree. Terms are combined with AND by default and
+// can be combined with OR, negated with NOT or a "-" prefix, and grouped with parentheses, e.g.:
+//
+//	label:dog OR subject:anna
+//	-label:cat (country:de OR country:at) iso:100..400 taken:2020-01..2020-06 "golden gate"
+//
+// The operators must be written in uppercase, so that lowercase words like "or" are searched for.
+// An empty query returns nil.
+func ParseQuery(q string) (*QueryExpr, error) {
+	tokens, err := lexQuery(q)
+
+	if err != nil {
+		return nil, err
+	} else if len(tokens) == 0 {
+		return nil, nil
+	}
+
+	p := &queryParser{tokens: tokens}
+
+	expr, err := p.parseOr()
+
+	if err != nil {
+		return nil, err
+	} else if t := p.peek(); t != nil {
+		return nil, NewQueryError(t.pos, "unexpected %s", t)
+	}
+
+	return expr, nil
+}
+
+// IsQuoteError checks if the error is caused by a missing closing quote.
+func IsQuoteError(err error) bool {
+	var queryErr *QueryError
+	return errors.As(err, &queryErr) && queryErr.Msg == queryQuoteMissing
+}
+
+// IsBooleanQuery checks if the search query contains operators, parentheses, or ranges,
+// ignoring quotes so that it also works for queries with unbalanced quotes.
+func IsBooleanQuery(q string) bool {
+	expr, err := ParseQuery(strings.ReplaceAll(q, "\"", " "))
+	return err != nil || expr.IsBoolean()
+}
+
+// IsTerm checks if the expression is a single term.
+func (e *QueryExpr) IsTerm() bool {
+	return e != nil && e.Op == QueryTerm
+}
+
+// IsBoolean checks if the expression uses OR, NOT, parentheses, or ranges, so that it cannot be
+// assigned to the search form fields as a list of "key:value" filters.
+func (e *QueryExpr) IsBoolean() bool {
+	switch {
+	case e == nil:
+		return false
+	case e.Op == QueryTerm:
+		return e.Range
+	case e.Op == QueryAnd:
+		for _, n := range e.Nodes {
+			if !n.IsTerm() || n.Range {
+				return true
+			}
+		}
+
+		return false
+	default:
+		return true
+	}
+}
+
+// Split separates the terms that can be assigned to the search form fields, i.e. words, phrases, and
+// "key:value" filters that are combined with AND, from the remaining boolean expression, if any.
+func (e *QueryExpr) Split() (terms []*QueryExpr, expr *QueryExpr) {
+	if e == nil {
+		return nil, nil
+	} else if !e.IsBoolean() {
+		if e.Op == QueryTerm {
+			return []*QueryExpr{e}, nil
+		}
+
+		return e.Nodes, nil
+	} else if e.Op != QueryAnd {
+		return nil, e
+	}
+
+	var nodes []*QueryExpr
+
+	for _, n := range e.Nodes {
+		if n.IsTerm() && !n.Range {
+			terms = append(terms, n)
+		} else {
+			nodes = append(nodes, n)
+		}
+	}
+
+	if len(nodes) == 1 {
+		return terms, nodes[0]
+	}
+
+	return terms, &QueryExpr{Op: QueryAnd, Pos: nodes[0].Pos, Nodes: nodes}
+}
+
+// String returns the expression as query string.
+func (e *QueryExpr) String() string {
+	if e == nil {
+		return ""
+	}
+
+	switch e.Op {
+	case QueryTerm:
+		var value string
+
+		if e.Range {
+			value = e.Value + ".." + e.Max
+		} else if e.Phrase || strings.ContainsFunc(e.Value, unicode.IsSpace) {
+			value = "\"" + e.Value + "\""
+		} else {
+			value = e.Value
+		}
+
+		if e.Key == "" {
+			return value
+		}
+
+		return e.Key + ":" + value
+	case QueryNot:
+		if n := e.Nodes[0]; n.Op == QueryTerm || n.Op == QueryNot {
+			return "-" + n.String()
+		} else {
+			return "-(" + n.String() + ")"
+		}
+	default:
+		s := make([]string, len(e.Nodes))
+
+		for i, n := range e.Nodes {
+			if n.Op == QueryAnd || n.Op == QueryOr {
+				s[i] = "(" + n.String() + ")"
+			} else {
+				s[i] = n.String()
+			}
+		}
+
+		if e.Op == QueryOr {
+			return strings.Join(s, " OR ")
+		}
+
+		return strings.Join(s, " ")
+	}
+}
+
+// QueryString returns the query string for the specified terms.
+func QueryString(terms []*QueryExpr) string {
+	s := make([]string, len(terms))
+
+	for i, t := range terms {
+		s[i] = t.String()
+	}
+
+	return strings.Join(s, " ")
+}
+
+// Query token types.
+const (
+	tokenTerm = iota
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenOpen
+	tokenClose
+)
+
+// queryToken represents a search query token.
+type queryToken struct {
+	kind int
+	pos  int
+	term *QueryExpr
+}
+
+// String returns a human-readable description of the token for error messages.
+func (t *queryToken) String() string {
+	switch t.kind {
+	case tokenAnd:
+		return "AND"
+	case tokenOr:
+		return "OR"
+	case tokenNot:
+		return "NOT"
+	case tokenOpen:
+		return "opening parenthesis"
+	case tokenClose:
+		return "closing parenthesis"
+	default:
+		return fmt.Sprintf("term %s", t.term.String())
+	}
+}
+
+// lexQuery splits the search query into tokens.
+func lexQuery(q string) (tokens []*queryToken, err error) {
+	r := []rune(q)
+	n := len(r)
+
+	for i := 0; i < n; {
+		c := r[i]
+		pos := i + 1
+
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '(':
+			tokens = append(tokens, &queryToken{kind: tokenOpen, pos: pos})
+			i++
+		case c == ')':
+			tokens = append(tokens, &queryToken{kind: tokenClose, pos: pos})
+			i++
+		case c == '-' && i+1 < n && (unicode.IsLetter(r[i+1]) || r[i+1] == '(' || r[i+1] == '"'):
+			tokens = append(tokens, &queryToken{kind: tokenNot, pos: pos})
+			i++
+		case c == '"':
+			end := indexRune(r, '"', i+1)
+
+			if end < 0 {
+				return nil, NewQueryError(pos, queryQuoteMissing)
+			}
+
+			tokens = append(tokens, &queryToken{kind: tokenTerm, pos: pos,
+				term: &QueryExpr{Op: QueryTerm, Value: string(r[i+1 : end]), Phrase: true, Pos: pos}})
+			i = end + 1
+		default:
+			// Read word or filter name.
+			start := i
+
+			for i < n && !unicode.IsSpace(r[i]) && r[i] != '(' && r[i] != ')' && r[i] != '"' && r[i] != ':' {
+				i++
+			}
+
+			word := string(r[start:i])
+
+			// Operators must be separate words in uppercase.
+			if i >= n || r[i] != ':' {
+				switch word {
+				case QueryAnd:
+					tokens = append(tokens, &queryToken{kind: tokenAnd, pos: pos})
+				case QueryOr:
+					tokens = append(tokens, &queryToken{kind: tokenOr, pos: pos})
+				case QueryNot:
+					tokens = append(tokens, &queryToken{kind: tokenNot, pos: pos})
+				default:
+					tokens = append(tokens, &queryToken{kind: tokenTerm, pos: pos,
+						term: &QueryExpr{Op: QueryTerm, Value: word, Pos: pos}})
+				}
+
+				continue
+			}
+
+			// Words like "12:30" that do not start with a filter name are searched for as they are.
+			if !isQueryKey(word) {
+				for i < n && !unicode.IsSpace(r[i]) && r[i] != '(' && r[i] != ')' && r[i] != '"' {
+					i++
+				}
+
+				tokens = append(tokens, &queryToken{kind: tokenTerm, pos: pos,
+					term: &QueryExpr{Op: QueryTerm, Value: string(r[start:i]), Pos: pos}})
+
+				continue
+			}
+
+			// Read filter value.
+			term := &QueryExpr{Op: QueryTerm, Key: strings.ToLower(word), Pos: pos}
+			i++
+
+			if i < n && r[i] == '"' {
+				end := indexRune(r, '"', i+1)
+
+				if end < 0 {
+					return nil, NewQueryError(i+1, queryQuoteMissing)
+				}
+
+				term.Value = string(r[i+1 : end])
+				term.Phrase = true
+				i = end + 1
+			} else {
+				start = i
+
+				for i < n && !unicode.IsSpace(r[i]) && r[i] != ')' {
+					i++
+				}
+
+				term.Value = string(r[start:i])
+
+				if min, max, found := strings.Cut(term.Value, ".."); found {
+					if min == "" && max == "" {
+						return nil, NewQueryError(pos, "missing %s range bounds", term.Key)
+					}
+
+					term.Value, term.Max, term.Range = min, max, true
+				}
+			}
+
+			tokens = append(tokens, &queryToken{kind: tokenTerm, pos: pos, term: term})
+		}
+	}
+
+	return tokens, nil
+}
+
+// isQueryKey checks if the string is a valid filter name.
+func isQueryKey(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for _, c := range s {
+		if !unicode.IsLetter(c) && !unicode.IsDigit(c) {
+			return false
+		}
+	}
+
+	return unicode.IsLetter([]rune(s)[0])
+}
+
+// indexRune returns the index of the first occurrence of c in r starting at i, or -1 if it is not found.
+func indexRune(r []rune, c rune, i int) int {
+	for ; i < len(r); i++ {
+		if r[i] == c {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// queryParser builds an expression tree from search query tokens.
+type queryParser struct {
+	tokens []*queryToken
+	next   int
+}
+
+// peek returns the next token without consuming it, or nil at the end of the query.
+func (p *queryParser) peek() *queryToken {
+	if p.next >= len(p.tokens) {
+		return nil
+	}
+
+	return p.tokens[p.next]
+}
+
+// parseOr parses terms that are combined with OR.
+func (p *queryParser) parseOr() (*QueryExpr, error) {
+	expr, err := p.parseAnd()
+
+	if err != nil {
+		return nil, err
+	}
+
+	nodes := []*QueryExpr{expr}
+
+	for t := p.peek(); t != nil && t.kind == tokenOr; t = p.peek() {
+		p.next++
+
+		if next := p.peek(); next == nil || next.kind == tokenClose || next.kind == tokenOr || next.kind == tokenAnd {
+			return nil, NewQueryError(t.pos, "missing search term after OR")
+		}
+
+		if expr, err = p.parseAnd(); err != nil {
+			return nil, err
+		}
+
+		nodes = append(nodes, expr)
+	}
+
+	if len(nodes) == 1 {
+		return nodes[0], nil
+	}
+
+	return &QueryExpr{Op: QueryOr, Pos: nodes[0].Pos, Nodes: nodes}, nil
+}
+
+// parseAnd parses terms that are combined with AND, either explicitly or implicitly.
+func (p *queryParser) parseAnd() (*QueryExpr, error) {
+	var nodes []*QueryExpr
+
+	for t := p.peek(); t != nil && t.kind != tokenOr && t.kind != tokenClose; t = p.peek() {
+		if t.kind == tokenAnd {
+			if len(nodes) == 0 {
+				return nil, NewQueryError(t.pos, "missing search term before AND")
+			}
+
+			p.next++
+
+			if next := p.peek(); next == nil || next.kind == tokenClose || next.kind == tokenOr || next.kind == tokenAnd {
+				return nil, NewQueryError(t.pos, "missing search term after AND")
+			}
+
+			continue
+		}
+
+		expr, err := p.parseNot()
+
+		if err != nil {
+			return nil, err
+		}
+
+		nodes = append(nodes, expr)
+	}
+
+	switch len(nodes) {
+	case 0:
+		if t := p.peek(); t == nil {
+			return nil, NewQueryError(1, "missing search term")
+		} else if t.kind == tokenOr {
+			return nil, NewQueryError(t.pos, "missing search term before OR")
+		} else {
+			return nil, NewQueryError(t.pos, "unexpected closing parenthesis")
+		}
+	case 1:
+		return nodes[0], nil
+	default:
+		return &QueryExpr{Op: QueryAnd, Pos: nodes[0].Pos, Nodes: nodes}, nil
+	}
+}
+
+// parseNot parses negated terms and groups.
+func (p *queryParser) parseNot() (*QueryExpr, error) {
+	t := p.peek()
+
+	if t.kind != tokenNot {
+		return p.parsePrimary()
+	}
+
+	p.next++
+
+	if next := p.peek(); next == nil || next.kind == tokenClose || next.kind == tokenOr || next.kind == tokenAnd {
+		return nil, NewQueryError(t.pos, "missing search term after NOT")
+	}
+
+	expr, err := p.parseNot()
+
+	if err != nil {
+		return nil, err
+	}
+
+	return &QueryExpr{Op: QueryNot, Pos: t.pos, Nodes: []*QueryExpr{expr}}, nil
+}
+
+// parsePrimary parses a single term or a group of terms in parentheses.
+func (p *queryParser) parsePrimary() (*QueryExpr, error) {
+	t := p.peek()
+	p.next++
+
+	if t.kind == tokenTerm {
+		return t.term, nil
+	} else if t.kind != tokenOpen {
+		return nil, NewQueryError(t.pos, "unexpected %s", t)
+	} else if next := p.peek(); next == nil {
+		return nil, NewQueryError(t.pos, "missing closing parenthesis")
+	} else if next.kind == tokenClose {
+		return nil, NewQueryError(t.pos, "empty parentheses")
+	}
+
+	expr, err := p.parseOr()
+
+	if err != nil {
+		return nil, err
+	} else if next := p.peek(); next == nil || next.kind != tokenClose {
+		return nil, NewQueryError(t.pos, "missing closing parenthesis")
+	}
+
+	p.next++
+
+	return expr, nil
+}
@@ -0,0 +1,242 @@
+package form
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseQuery(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		expr, err := ParseQuery("  ")
+
+		assert.NoError(t, err)
+		assert.Nil(t, expr)
+		assert.False(t, expr.IsBoolean())
+	})
+	t.Run("Filters", func(t *testing.T) {
+		expr, err := ParseQuery("label:cat title:\"Lake View\" sunset")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, QueryAnd, expr.Op)
+		assert.Len(t, expr.Nodes, 3)
+		assert.Equal(t, "label", expr.Nodes[0].Key)
+		assert.Equal(t, "cat", expr.Nodes[0].Value)
+		assert.Equal(t, "Lake View", expr.Nodes[1].Value)
+		assert.True(t, expr.Nodes[1].Phrase)
+		assert.Equal(t, "", expr.Nodes[2].Key)
+		assert.Equal(t, "sunset", expr.Nodes[2].Value)
+		assert.Equal(t, 11, expr.Nodes[1].Pos)
+		assert.False(t, expr.IsBoolean())
+	})
+	t.Run("Negation", func(t *testing.T) {
+		expr, err := ParseQuery("-label:cat NOT country:us")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, QueryAnd, expr.Op)
+		assert.Equal(t, QueryNot, expr.Nodes[0].Op)
+		assert.Equal(t, "label", expr.Nodes[0].Nodes[0].Key)
+		assert.Equal(t, QueryNot, expr.Nodes[1].Op)
+		assert.Equal(t, "us", expr.Nodes[1].Nodes[0].Value)
+		assert.True(t, expr.IsBoolean())
+		assert.Equal(t, "-label:cat -country:us", expr.String())
+	})
+	t.Run("Or", func(t *testing.T) {
+		expr, err := ParseQuery("label:dog OR subject:anna")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, QueryOr, expr.Op)
+		assert.Len(t, expr.Nodes, 2)
+		assert.Equal(t, "subject", expr.Nodes[1].Key)
+		assert.True(t, expr.IsBoolean())
+	})
+	t.Run("Precedence", func(t *testing.T) {
+		expr, err := ParseQuery("a b OR c AND d")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "(a b) OR (c d)", expr.String())
+	})
+	t.Run("Parentheses", func(t *testing.T) {
+		expr, err := ParseQuery("favorite:true -(label:dog OR label:cat)")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "favorite:true -(label:dog OR label:cat)", expr.String())
+	})
+	t.Run("Ranges", func(t *testing.T) {
+		expr, err := ParseQuery("iso:100..400 taken:2020-01..2020-06 f:..2.8")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, expr.Nodes, 3)
+		assert.True(t, expr.Nodes[0].Range)
+		assert.Equal(t, "100", expr.Nodes[0].Value)
+		assert.Equal(t, "400", expr.Nodes[0].Max)
+		assert.Equal(t, "2020-01", expr.Nodes[1].Value)
+		assert.Equal(t, "2020-06", expr.Nodes[1].Max)
+		assert.Equal(t, "", expr.Nodes[2].Value)
+		assert.Equal(t, "2.8", expr.Nodes[2].Max)
+		assert.True(t, expr.IsBoolean())
+	})
+	t.Run("Phrase", func(t *testing.T) {
+		expr, err := ParseQuery("\"golden gate\" OR bridge")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, expr.Nodes[0].Phrase)
+		assert.Equal(t, "golden gate", expr.Nodes[0].Value)
+		assert.Equal(t, "\"golden gate\" OR bridge", expr.String())
+	})
+	t.Run("LowercaseOperators", func(t *testing.T) {
+		expr, err := ParseQuery("salt or pepper not-sweet")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, expr.IsBoolean())
+		assert.Equal(t, "salt or pepper not-sweet", expr.String())
+	})
+	t.Run("SpecialChars", func(t *testing.T) {
+		for _, q := range []string{"I love % dog", "\"Pets & Dogs\"", "'Family", "Light&", "|Banana", "12:30", "Mexico-With-Family", "-", "mp:3-6"} {
+			expr, err := ParseQuery(q)
+
+			assert.NoError(t, err, q)
+			assert.False(t, expr.IsBoolean(), q)
+		}
+	})
+	t.Run("SyntaxErrors", func(t *testing.T) {
+		for q, msg := range map[string]string{
+			"label:\"cat":          "missing closing quote at position 7",
+			"\"golden gate":        "missing closing quote at position 1",
+			"(label:dog OR cat":    "missing closing parenthesis at position 1",
+			"label:dog)":           "unexpected closing parenthesis at position 10",
+			"()":                   "empty parentheses at position 1",
+			"dog OR":               "missing search term after OR at position 5",
+			"OR dog":               "missing search term before OR at position 1",
+			"dog NOT":              "missing search term after NOT at position 5",
+			"AND dog":              "missing search term before AND at position 1",
+			"dog AND OR cat":       "missing search term after AND at position 5",
+			"iso:..":               "missing iso range bounds at position 1",
+			"(dog OR cat) OR (cow": "missing closing parenthesis at position 17",
+		} {
+			_, err := ParseQuery(q)
+
+			if assert.Error(t, err, q) {
+				assert.Equal(t, msg, err.Error(), q)
+				assert.True(t, IsQueryError(err), q)
+			}
+		}
+	})
+}
+
+func TestIsQuoteError(t *testing.T) {
+	_, err := ParseQuery("label:\"cat")
+	assert.True(t, IsQuoteError(err))
+	_, err = ParseQuery("dog OR")
+	assert.False(t, IsQuoteError(err))
+	assert.False(t, IsQuoteError(nil))
+}
+
+func TestIsBooleanQuery(t *testing.T) {
+	assert.False(t, IsBooleanQuery(""))
+	assert.False(t, IsBooleanQuery("label:\"\"king\""))
+	assert.False(t, IsBooleanQuery("\"golden gate"))
+	assert.True(t, IsBooleanQuery("label:cat OR \"golden gate"))
+	assert.True(t, IsBooleanQuery("-label:\"cat"))
+	assert.True(t, IsBooleanQuery("(dog"))
+}
+
+func TestQueryExpr_Split(t *testing.T) {
+	t.Run("Simple", func(t *testing.T) {
+		expr, _ := ParseQuery("label:cat sunset")
+		terms, rest := expr.Split()
+
+		assert.Nil(t, rest)
+		assert.Equal(t, "label:cat sunset", QueryString(terms))
+	})
+	t.Run("Mixed", func(t *testing.T) {
+		expr, _ := ParseQuery("label:cat -country:us iso:100..400 \"golden gate\"")
+		terms, rest := expr.Split()
+
+		assert.Equal(t, "label:cat \"golden gate\"", QueryString(terms))
+		assert.Equal(t, "-country:us iso:100..400", rest.String())
+	})
+	t.Run("Or", func(t *testing.T) {
+		expr, _ := ParseQuery("label:dog OR subject:anna")
+		terms, rest := expr.Split()
+
+		assert.Empty(t, terms)
+		assert.Equal(t, expr, rest)
+	})
+}
+
+func TestSearchPhotos_ParseQueryString(t *testing.T) {
+	t.Run("Boolean", func(t *testing.T) {
+		frm := &SearchPhotos{Query: "label:cat year:2020 sunset -country:us"}
+
+		if err := frm.ParseQueryString(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "cat", frm.Label)
+		assert.Equal(t, "2020", frm.Year)
+		assert.Equal(t, "sunset", frm.Query)
+		assert.Equal(t, "", frm.Country)
+		assert.Equal(t, "-country:us", frm.Expr.String())
+	})
+	t.Run("NoExpr", func(t *testing.T) {
+		frm := &SearchPhotos{Query: "label:cat", Expr: &QueryExpr{}}
+
+		if err := frm.ParseQueryString(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "cat", frm.Label)
+		assert.Nil(t, frm.Expr)
+	})
+	t.Run("UnbalancedQuotes", func(t *testing.T) {
+		frm := &SearchPhotos{Query: "label:\"\"king\""}
+
+		assert.NoError(t, frm.ParseQueryString())
+		assert.Nil(t, frm.Expr)
+
+		frm = &SearchPhotos{Query: "label:cat OR \"golden gate"}
+
+		assert.True(t, IsQuoteError(frm.ParseQueryString()))
+	})
+	t.Run("SyntaxError", func(t *testing.T) {
+		frm := &SearchPhotos{Query: "label:dog OR"}
+
+		err := frm.ParseQueryString()
+
+		assert.True(t, IsQueryError(err))
+	})
+}
+
+func TestSearchPhotos_HasFilter(t *testing.T) {
+	frm := &SearchPhotos{}
+
+	assert.True(t, frm.HasFilter("label"))
+	assert.True(t, frm.HasFilter("near"))
+	assert.False(t, frm.HasFilter("count"))
+	assert.False(t, frm.HasFilter("foo"))
+}
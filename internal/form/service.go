@@ -1,6 +1,8 @@
 package form
 
 import (
+	"time"
+
 	"github.com/ulule/deepcopier"
 
 	"github.com/photoprism/photoprism/internal/service"
@@ -8,27 +10,34 @@ import (
 
 // Service represents a remote service form for uploading, downloading or syncing media files.
 type Service struct {
-	AccName       string `json:"AccName"`
-	AccOwner      string `json:"AccOwner"`
-	AccURL        string `json:"AccURL"`
-	AccType       string `json:"AccType"`
-	AccKey        string `json:"AccKey"`
-	AccUser       string `json:"AccUser"`
-	AccPass       string `json:"AccPass"`
-	AccTimeout    string `json:"AccTimeout"` // Request timeout: default, high, medium, low, none
-	AccError      string `json:"AccError"`
-	AccShare      bool   `json:"AccShare"`   // Manual upload enabled, see SharePath, ShareSize, and ShareExpires.
-	AccSync       bool   `json:"AccSync"`    // Background sync enabled, see SyncDownload and SyncUpload.
-	RetryLimit    int    `json:"RetryLimit"` // Maximum number of failed requests.
-	SharePath     string `json:"SharePath"`
-	ShareSize     string `json:"ShareSize"`
-	ShareExpires  int    `json:"ShareExpires"`
-	SyncPath      string `json:"SyncPath"`
-	SyncInterval  int    `json:"SyncInterval"`
-	SyncUpload    bool   `json:"SyncUpload"`
-	SyncDownload  bool   `json:"SyncDownload"`
-	SyncFilenames bool   `json:"SyncFilenames"`
-	SyncRaw       bool   `json:"SyncRaw"`
+	AccName       string     `json:"AccName"`
+	AccOwner      string     `json:"AccOwner"`
+	AccURL        string     `json:"AccURL"`
+	AccType       string     `json:"AccType"`
+	AccKey        string     `json:"AccKey"`
+	AccUser       string     `json:"AccUser"`
+	AccPass       string     `json:"AccPass"`
+	AccTimeout    string     `json:"AccTimeout"` // Request timeout: default, high, medium, low, none
+	AccError      string     `json:"AccError"`
+	AccShare      bool       `json:"AccShare"`   // Manual upload enabled, see SharePath, ShareSize, and ShareExpires.
+	AccSync       bool       `json:"AccSync"`    // Background sync enabled, see SyncDownload and SyncUpload.
+	RetryLimit    int        `json:"RetryLimit"` // Maximum number of failed requests.
+	SharePath     string     `json:"SharePath"`
+	ShareSize     string     `json:"ShareSize"`
+	ShareExpires  int        `json:"ShareExpires"`
+	SyncPath      string     `json:"SyncPath"`
+	SyncInterval  int        `json:"SyncInterval"`
+	SyncUpload    bool       `json:"SyncUpload"`
+	SyncDownload  bool       `json:"SyncDownload"`
+	SyncDelete    bool       `json:"SyncDelete"`   // Propagate deleted files.
+	SyncConflict  string     `json:"SyncConflict"` // Conflict resolution policy: keep, newest, remote, local, manual
+	SyncFilenames bool       `json:"SyncFilenames"`
+	SyncRaw       bool       `json:"SyncRaw"`
+	SyncInclude   string     `json:"SyncInclude"` // Glob patterns of files to include, separated by commas or line breaks.
+	SyncExclude   string     `json:"SyncExclude"` // Glob patterns of files to exclude, separated by commas or line breaks.
+	SyncTypes     string     `json:"SyncTypes"`   // Media types to sync: image, video, raw, sidecar
+	SyncMaxSize   int        `json:"SyncMaxSize"` // Maximum file size in MB.
+	SyncSince     *time.Time `json:"SyncSince"`   // Skip files that were last modified before.
 }
 
 // NewService creates a new service form.
@@ -0,0 +1,7 @@
+package form
+
+// ServiceConflict represents a request to resolve conflicts between local and remote file changes.
+type ServiceConflict struct {
+	RemoteName string `json:"RemoteName"` // Remote file name, all conflicts are resolved if empty.
+	Resolution string `json:"Resolution"` // Resolution policy: keep, newest, remote, local
+}
@@ -0,0 +1,15 @@
+package form
+
+// SearchSimilar represents search form fields for "/api/v1/photos/similar".
+type SearchSimilar struct {
+	Distance int `form:"distance"`
+	Count    int `form:"count" binding:"required" serialize:"-"`
+	Offset   int `form:"offset" serialize:"-"`
+}
+
+// ResolveSimilar represents a group of visually similar pictures to be resolved.
+type ResolveSimilar struct {
+	Photos []string `json:"photos"`
+	Keep   string   `json:"keep"`
+	Action string   `json:"action"`
+}
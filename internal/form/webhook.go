@@ -0,0 +1,63 @@
+package form
+
+import (
+	"strings"
+
+	"github.com/ulule/deepcopier"
+	"github.com/urfave/cli/v2"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// Webhook represents the settings of a webhook endpoint.
+type Webhook struct {
+	WebhookName    string `json:"Name"`
+	WebhookURL     string `json:"URL"`
+	WebhookEvents  string `json:"Events"` // Comma-separated event topics, e.g. "photos.created, import.completed".
+	WebhookEnabled bool   `json:"Enabled"`
+}
+
+// NewWebhook creates a new webhook form with values from the specified model.
+func NewWebhook(m interface{}) (f Webhook, err error) {
+	err = deepcopier.Copy(m).To(&f)
+
+	return f, err
+}
+
+// AddWebhookFromCli creates a new form for adding a webhook with values from the specified CLI context.
+func AddWebhookFromCli(ctx *cli.Context) Webhook {
+	f := Webhook{
+		WebhookName:    clean.Name(ctx.String("name")),
+		WebhookURL:     strings.TrimSpace(ctx.Args().First()),
+		WebhookEvents:  strings.Join(ctx.StringSlice("events"), ","),
+		WebhookEnabled: !ctx.Bool("disable"),
+	}
+
+	return f
+}
+
+// ModWebhookFromCli updates the form with the values that have been set in the specified CLI context.
+func (f *Webhook) ModWebhookFromCli(ctx *cli.Context) {
+	if ctx.IsSet("name") {
+		f.WebhookName = clean.Name(ctx.String("name"))
+	}
+
+	if ctx.IsSet("url") {
+		f.WebhookURL = strings.TrimSpace(ctx.String("url"))
+	}
+
+	if ctx.IsSet("events") {
+		f.WebhookEvents = strings.Join(ctx.StringSlice("events"), ",")
+	}
+
+	if ctx.Bool("enable") {
+		f.WebhookEnabled = true
+	} else if ctx.Bool("disable") {
+		f.WebhookEnabled = false
+	}
+}
+
+// WebhookReplay represents a request to resend failed webhook deliveries.
+type WebhookReplay struct {
+	Deliveries []uint `json:"Deliveries"` // Delivery IDs, all failed deliveries are resent if empty.
+}
@@ -0,0 +1,68 @@
+package form
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/urfave/cli/v2"
+)
+
+func TestNewWebhook(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		var m = struct {
+			WebhookName    string
+			WebhookURL     string
+			WebhookEvents  string
+			WebhookEnabled bool
+		}{
+			WebhookName:    "Automation",
+			WebhookURL:     "https://example.com/hooks/photoprism",
+			WebhookEvents:  "photos.created",
+			WebhookEnabled: true,
+		}
+
+		f, err := NewWebhook(m)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Automation", f.WebhookName)
+		assert.Equal(t, "https://example.com/hooks/photoprism", f.WebhookURL)
+		assert.Equal(t, "photos.created", f.WebhookEvents)
+		assert.True(t, f.WebhookEnabled)
+	})
+}
+
+func TestWebhookFromCli(t *testing.T) {
+	newContext := func(args ...string) *cli.Context {
+		set := flag.NewFlagSet("test", flag.ContinueOnError)
+		set.String("name", "", "")
+		set.String("url", "", "")
+		set.Var(cli.NewStringSlice(), "events", "")
+		set.Bool("enable", false, "")
+		set.Bool("disable", false, "")
+
+		if err := set.Parse(args); err != nil {
+			t.Fatal(err)
+		}
+
+		return cli.NewContext(cli.NewApp(), set, nil)
+	}
+
+	t.Run("Add", func(t *testing.T) {
+		f := AddWebhookFromCli(newContext("--name", "Automation", "--events", "photos.created", "--events", "albums.*", "https://example.com/hook"))
+
+		assert.Equal(t, "Automation", f.WebhookName)
+		assert.Equal(t, "https://example.com/hook", f.WebhookURL)
+		assert.Equal(t, "photos.created,albums.*", f.WebhookEvents)
+		assert.True(t, f.WebhookEnabled)
+	})
+	t.Run("Mod", func(t *testing.T) {
+		f := Webhook{WebhookName: "Old", WebhookURL: "https://example.com/old", WebhookEvents: "*", WebhookEnabled: true}
+		f.ModWebhookFromCli(newContext("--url", "https://example.com/new", "--disable"))
+
+		assert.Equal(t, "Old", f.WebhookName)
+		assert.Equal(t, "https://example.com/new", f.WebhookURL)
+		assert.Equal(t, "*", f.WebhookEvents)
+		assert.False(t, f.WebhookEnabled)
+	})
+}
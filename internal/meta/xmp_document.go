@@ -280,7 +280,7 @@ func (doc *XmpDocument) TakenAt(timeZone string) time.Time {
 
 // Keywords returns the XMP document keywords.
 func (doc *XmpDocument) Keywords() string {
-	s := doc.RDF.Description.Subject.Seq.Li
+	s := append(append([]string{}, doc.RDF.Description.Subject.Seq.Li...), doc.RDF.Description.Subject.Bag.Li...)
 
 	return strings.Join(s, ", ")
 }
@@ -0,0 +1,392 @@
+package meta
+
+import (
+	"bytes"
+	"crypto/sha1" //nolint:gosec // used for change detection only
+	"encoding/hex"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// XMP namespace URIs used in sidecar files.
+const (
+	XmpNsRdf         = "http://www.w3.org/1999/02/22-rdf-syntax-ns#"
+	XmpNsDc          = "http://purl.org/dc/elements/1.1/"
+	XmpNsXmp         = "http://ns.adobe.com/xap/1.0/"
+	XmpNsPhotoshop   = "http://ns.adobe.com/photoshop/1.0/"
+	XmpNsExif        = "http://ns.adobe.com/exif/1.0/"
+	XmpNsIptc4xmpExt = "http://iptc.org/std/Iptc4xmpExt/2008-02-29/"
+	XmpNsMwgRs       = "http://www.metadataworkinggroup.com/schemas/regions/"
+	XmpNsStDim       = "http://ns.adobe.com/xap/1.0/sType/Dimensions#"
+	XmpNsStArea      = "http://ns.adobe.com/xmp/sType/Area#"
+	XmpNsPhotoPrism  = "http://ns.photoprism.app/xmp/1.0/"
+)
+
+// XmpRatingFavorite is the rating written for pictures marked as favorite.
+const XmpRatingFavorite = "5"
+
+// xmpTemplate is the minimal document used when a new sidecar file is created.
+const xmpTemplate = `<?xml version="1.0" encoding="UTF-8"?>
+<x:xmpmeta xmlns:x="adobe:ns:meta/" x:xmptk="PhotoPrism">
+ <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+  <rdf:Description rdf:about="">
+  </rdf:Description>
+ </rdf:RDF>
+</x:xmpmeta>
+`
+
+// XmpRegion represents a named face region, with relative coordinates measured from the top left corner.
+type XmpRegion struct {
+	Name string
+	X    float32
+	Y    float32
+	W    float32
+	H    float32
+}
+
+// XmpSidecar represents the picture metadata written to XMP sidecar files.
+type XmpSidecar struct {
+	UID          string
+	Title        string
+	Caption      string
+	Artist       string
+	Copyright    string
+	Keywords     []string
+	Labels       []string
+	Favorite     bool
+	TakenAt      time.Time // Local time.
+	Lat          float64
+	Lng          float64
+	Altitude     int
+	Width        int
+	Height       int
+	People       []string
+	Regions      []XmpRegion
+	MetadataDate time.Time
+}
+
+// Save creates or updates an XMP sidecar file. Properties not managed by PhotoPrism, such as
+// the development history stored by other applications, are preserved.
+func (s XmpSidecar) Save(fileName string) error {
+	var data []byte
+
+	if fs.FileExists(fileName) {
+		existing, err := os.ReadFile(fileName) //nolint:gosec // fileName is provided by caller
+
+		if err != nil {
+			return err
+		}
+
+		data = existing
+	}
+
+	result, err := s.Render(data)
+
+	if err != nil {
+		return fmt.Errorf("%s in %s", err, clean.Log(filepath.Base(fileName)))
+	}
+
+	return os.WriteFile(fileName, result, fs.ModeFile)
+}
+
+// Render merges the metadata into an existing XMP document, or creates a new document if data is empty.
+func (s XmpSidecar) Render(data []byte) ([]byte, error) {
+	if len(bytes.TrimSpace(data)) == 0 {
+		data = []byte(xmpTemplate)
+	}
+
+	doc, err := parseXmlTree(data)
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid xmp document (%s)", err)
+	}
+
+	rdf := doc.Find(XmpNsRdf, "RDF")
+
+	if rdf == nil {
+		return nil, fmt.Errorf("rdf element not found")
+	}
+
+	var desc *xmlNode
+	var descriptions []*xmlNode
+	var wasFavorite bool
+
+	for _, d := range rdf.Elements() {
+		if !d.Is(XmpNsRdf, "Description") {
+			continue
+		} else if desc == nil {
+			desc = d
+		}
+
+		if v, ok := d.AttrValue(XmpNsPhotoPrism, "Favorite"); ok && v == "true" {
+			wasFavorite = true
+		}
+
+		descriptions = append(descriptions, d)
+	}
+
+	// Favorites are written as 5-star rating. Ratings are only changed if the favorite flag
+	// has changed since the file was last written, so ratings set by other applications are kept.
+	updateRating := s.Favorite != wasFavorite
+
+	// Remove managed properties from all top-level descriptions.
+	for _, d := range descriptions {
+		if updateRating && (s.Favorite || d.Value(XmpNsXmp, "Rating") == XmpRatingFavorite) {
+			d.Remove(XmpNsXmp, "Rating")
+		}
+
+		d.Remove(XmpNsDc, "title", "description", "subject", "creator", "rights")
+		d.Remove(XmpNsXmp, "MetadataDate")
+		d.Remove(XmpNsPhotoshop, "DateCreated")
+		d.Remove(XmpNsExif, "GPSLatitude", "GPSLongitude", "GPSAltitude", "GPSAltitudeRef")
+		d.Remove(XmpNsIptc4xmpExt, "PersonInImage")
+		d.Remove(XmpNsMwgRs, "Regions")
+		d.Remove(XmpNsPhotoPrism)
+	}
+
+	if desc == nil {
+		rdfPrefix := rdf.Prefix(XmpNsRdf)
+		desc = rdf.Append(newXmlElement(rdfPrefix, "Description", xmlAttr(rdfPrefix, "about", "")))
+	}
+
+	rdfPrefix := desc.Prefix(XmpNsRdf)
+	pp := desc.Bind(XmpNsPhotoPrism, "photoprism")
+
+	if s.Favorite && updateRating {
+		desc.Append(textElement(desc.Bind(XmpNsXmp, "xmp"), "Rating", XmpRatingFavorite))
+	}
+
+	if !s.MetadataDate.IsZero() {
+		desc.Append(textElement(desc.Bind(XmpNsXmp, "xmp"), "MetadataDate", s.MetadataDate.Format(time.RFC3339)))
+	}
+
+	if s.Title != "" {
+		desc.Append(langAltElement(rdfPrefix, desc.Bind(XmpNsDc, "dc"), "title", s.Title))
+	}
+
+	if s.Caption != "" {
+		desc.Append(langAltElement(rdfPrefix, desc.Bind(XmpNsDc, "dc"), "description", s.Caption))
+	}
+
+	if s.Artist != "" {
+		desc.Append(listElement(rdfPrefix, desc.Bind(XmpNsDc, "dc"), "creator", "Seq", []string{s.Artist}))
+	}
+
+	if s.Copyright != "" {
+		desc.Append(langAltElement(rdfPrefix, desc.Bind(XmpNsDc, "dc"), "rights", s.Copyright))
+	}
+
+	if subjects := s.Subjects(); len(subjects) > 0 {
+		desc.Append(listElement(rdfPrefix, desc.Bind(XmpNsDc, "dc"), "subject", "Bag", subjects))
+	}
+
+	if !s.TakenAt.IsZero() {
+		desc.Append(textElement(desc.Bind(XmpNsPhotoshop, "photoshop"), "DateCreated", s.TakenAt.Format("2006-01-02T15:04:05")))
+	}
+
+	if s.Lat != 0 || s.Lng != 0 {
+		exif := desc.Bind(XmpNsExif, "exif")
+		desc.Append(textElement(exif, "GPSLatitude", xmpCoordinate(s.Lat, "N", "S")))
+		desc.Append(textElement(exif, "GPSLongitude", xmpCoordinate(s.Lng, "E", "W")))
+
+		if s.Altitude != 0 {
+			altRef := "0"
+
+			if s.Altitude < 0 {
+				altRef = "1"
+			}
+
+			desc.Append(textElement(exif, "GPSAltitude", fmt.Sprintf("%d/1", absInt(s.Altitude))))
+			desc.Append(textElement(exif, "GPSAltitudeRef", altRef))
+		}
+	}
+
+	if len(s.People) > 0 {
+		desc.Append(listElement(rdfPrefix, desc.Bind(XmpNsIptc4xmpExt, "Iptc4xmpExt"), "PersonInImage", "Bag", s.People))
+	}
+
+	if len(s.Regions) > 0 {
+		desc.Append(s.regionsElement(desc, rdfPrefix))
+	}
+
+	// Add marker and digest attributes to identify unmodified sidecar files.
+	if s.UID != "" {
+		desc.Attr = append(desc.Attr, xmlAttr(pp, "UID", s.UID))
+	}
+
+	if s.Favorite {
+		desc.Attr = append(desc.Attr, xmlAttr(pp, "Favorite", "true"))
+	}
+
+	desc.Attr = append(desc.Attr, xmlAttr(pp, "Digest", ""))
+
+	// Compute the digest with an empty value and insert it afterwards.
+	result := doc.Bytes()
+	placeholder := []byte(pp + `:Digest=""`)
+	digest := xmpDigest(result, placeholder)
+
+	return bytes.Replace(result, placeholder, []byte(pp+`:Digest="`+digest+`"`), 1), nil
+}
+
+// Subjects returns the keywords and labels written as dc:subject, without duplicates.
+func (s XmpSidecar) Subjects() (result []string) {
+	found := make(map[string]bool, len(s.Keywords)+len(s.Labels))
+
+	for _, values := range [][]string{s.Keywords, s.Labels} {
+		for _, v := range values {
+			if v = strings.TrimSpace(v); v == "" {
+				continue
+			} else if key := strings.ToLower(v); found[key] {
+				continue
+			} else {
+				found[key] = true
+				result = append(result, v)
+			}
+		}
+	}
+
+	return result
+}
+
+// regionsElement returns the MWG face regions element.
+func (s XmpSidecar) regionsElement(desc *xmlNode, rdfPrefix string) *xmlNode {
+	mwg := desc.Bind(XmpNsMwgRs, "mwg-rs")
+	stDim := desc.Bind(XmpNsStDim, "stDim")
+	stArea := desc.Bind(XmpNsStArea, "stArea")
+
+	regions := newXmlElement(mwg, "Regions", xmlAttr(rdfPrefix, "parseType", "Resource"))
+
+	if s.Width > 0 && s.Height > 0 {
+		regions.add(newXmlElement(mwg, "AppliedToDimensions",
+			xmlAttr(stDim, "w", strconv.Itoa(s.Width)),
+			xmlAttr(stDim, "h", strconv.Itoa(s.Height)),
+			xmlAttr(stDim, "unit", "pixel")))
+	}
+
+	bag := newXmlElement(rdfPrefix, "Bag")
+
+	for _, r := range s.Regions {
+		area := newXmlElement(mwg, "Area",
+			xmlAttr(stArea, "x", xmpFloat(r.X+r.W/2)),
+			xmlAttr(stArea, "y", xmpFloat(r.Y+r.H/2)),
+			xmlAttr(stArea, "w", xmpFloat(r.W)),
+			xmlAttr(stArea, "h", xmpFloat(r.H)),
+			xmlAttr(stArea, "unit", "normalized"))
+
+		region := newXmlElement(rdfPrefix, "Description", xmlAttr(mwg, "Name", r.Name), xmlAttr(mwg, "Type", "Face"))
+		region.add(area)
+
+		bag.add(newXmlElement(rdfPrefix, "li", xmlAttr(rdfPrefix, "parseType", "Resource"))).add(region)
+	}
+
+	regions.add(newXmlElement(mwg, "RegionList")).add(bag)
+
+	return regions
+}
+
+// XmpGenerated checks if the file is an XMP sidecar written by PhotoPrism that has not been
+// modified by other applications since, so its metadata doesn't need to be imported again.
+func XmpGenerated(fileName string) bool {
+	data, err := os.ReadFile(fileName) //nolint:gosec // fileName is provided by caller
+
+	if err != nil {
+		return false
+	}
+
+	doc, err := parseXmlTree(data)
+
+	if err != nil {
+		return false
+	}
+
+	desc := doc.Find(XmpNsRdf, "Description")
+
+	if desc == nil {
+		return false
+	}
+
+	digest, ok := desc.AttrValue(XmpNsPhotoPrism, "Digest")
+
+	if !ok || digest == "" {
+		return false
+	}
+
+	pp := desc.Prefix(XmpNsPhotoPrism)
+	placeholder := []byte(pp + `:Digest=""`)
+
+	return xmpDigest(bytes.Replace(data, []byte(pp+`:Digest="`+digest+`"`), placeholder, 1), placeholder) == digest
+}
+
+// xmpDigest returns the SHA1 checksum of a document.
+func xmpDigest(data, placeholder []byte) string {
+	if !bytes.Contains(data, placeholder) {
+		return ""
+	}
+
+	sum := sha1.Sum(data) //nolint:gosec // used for change detection only
+
+	return hex.EncodeToString(sum[:])
+}
+
+// textElement returns a simple property element.
+func textElement(prefix, local, value string) *xmlNode {
+	return newXmlElement(prefix, local).AddText(value)
+}
+
+// langAltElement returns a language alternative property element with a default value.
+func langAltElement(rdfPrefix, prefix, local, value string) *xmlNode {
+	n := newXmlElement(prefix, local)
+	n.add(newXmlElement(rdfPrefix, "Alt")).add(newXmlElement(rdfPrefix, "li", xmlAttr("xml", "lang", "x-default"))).AddText(value)
+	return n
+}
+
+// listElement returns an unordered (Bag) or ordered (Seq) array property element.
+func listElement(rdfPrefix, prefix, local, list string, values []string) *xmlNode {
+	n := newXmlElement(prefix, local)
+	l := n.add(newXmlElement(rdfPrefix, list))
+
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			l.add(newXmlElement(rdfPrefix, "li")).AddText(v)
+		}
+	}
+
+	return n
+}
+
+// xmpCoordinate formats a GPS coordinate as degrees and decimal minutes, e.g. "52,27.5814N".
+func xmpCoordinate(value float64, pos, neg string) string {
+	ref := pos
+
+	if value < 0 {
+		ref = neg
+		value = -value
+	}
+
+	deg := math.Floor(value)
+	minutes := (value - deg) * 60
+
+	return fmt.Sprintf("%d,%s%s", int(deg), strconv.FormatFloat(minutes, 'f', 6, 64), ref)
+}
+
+// xmpFloat formats a relative coordinate.
+func xmpFloat(f float32) string {
+	return strconv.FormatFloat(float64(f), 'f', 6, 32)
+}
+
+// absInt returns the absolute value of an integer.
+func absInt(i int) int {
+	if i < 0 {
+		return -i
+	}
+
+	return i
+}
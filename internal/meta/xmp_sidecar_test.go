@@ -0,0 +1,281 @@
+package meta
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+const testDarktableXmp = `<?xml version="1.0" encoding="UTF-8"?>
+<x:xmpmeta xmlns:x="adobe:ns:meta/" x:xmptk="XMP Core 4.4.0-Exiv2">
+ <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+  <rdf:Description rdf:about=""
+    xmlns:xmp="http://ns.adobe.com/xap/1.0/"
+    xmlns:dc="http://purl.org/dc/elements/1.1/"
+    xmlns:darktable="http://darktable.sf.net/"
+   xmp:Rating="3"
+   darktable:xmp_version="5">
+   <dc:title>
+    <rdf:Alt>
+     <rdf:li xml:lang="x-default">Old Title</rdf:li>
+    </rdf:Alt>
+   </dc:title>
+   <darktable:history>
+    <rdf:Seq>
+     <rdf:li darktable:operation="exposure" darktable:enabled="1"/>
+    </rdf:Seq>
+   </darktable:history>
+  </rdf:Description>
+ </rdf:RDF>
+</x:xmpmeta>
+`
+
+func TestXmpSidecar_Render(t *testing.T) {
+	sidecar := XmpSidecar{
+		UID:       "pqbcf5j446s0futy",
+		Title:     "Night Shift / Berlin",
+		Caption:   "Tom & Jerry <3",
+		Artist:    "Michael Mayer",
+		Copyright: "CC BY-SA 4.0",
+		Keywords:  []string{"berlin", "night"},
+		Labels:    []string{"Night", "Cityscape"},
+		Favorite:  true,
+		TakenAt:   time.Date(2020, 1, 1, 17, 28, 25, 0, time.UTC),
+		Lat:       52.459690,
+		Lng:       -13.321832,
+		Altitude:  -20,
+		Width:     4000,
+		Height:    3000,
+		People:    []string{"Jane Doe"},
+		Regions:   []XmpRegion{{Name: "Jane Doe", X: 0.25, Y: 0.5, W: 0.1, H: 0.2}},
+	}
+
+	t.Run("New", func(t *testing.T) {
+		result, err := sidecar.Render(nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s := string(result)
+
+		assert.Contains(t, s, `<rdf:li xml:lang="x-default">Night Shift / Berlin</rdf:li>`)
+		assert.Contains(t, s, `<rdf:li xml:lang="x-default">Tom &amp; Jerry &lt;3</rdf:li>`)
+		assert.Contains(t, s, `<rdf:li>berlin</rdf:li>`)
+		assert.Contains(t, s, `<xmp:Rating>5</xmp:Rating>`)
+		assert.Contains(t, s, `<photoshop:DateCreated>2020-01-01T17:28:25</photoshop:DateCreated>`)
+		assert.Contains(t, s, `<exif:GPSLatitude>52,27.581400N</exif:GPSLatitude>`)
+		assert.Contains(t, s, `<exif:GPSLongitude>13,19.309920W</exif:GPSLongitude>`)
+		assert.Contains(t, s, `<exif:GPSAltitudeRef>1</exif:GPSAltitudeRef>`)
+		assert.Contains(t, s, `<Iptc4xmpExt:PersonInImage>`)
+		assert.Contains(t, s, `stArea:x="0.300000" stArea:y="0.600000"`)
+		assert.Contains(t, s, `photoprism:UID="pqbcf5j446s0futy"`)
+
+		// The result must be readable by the XMP parser.
+		doc := XmpDocument{}
+
+		if err = doc.Load(writeTestXmp(t, result)); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Night Shift / Berlin", doc.Title())
+		assert.Equal(t, "Michael Mayer", doc.Artist())
+		assert.Equal(t, "CC BY-SA 4.0", doc.Copyright())
+		assert.Equal(t, "berlin, night, Cityscape", doc.Keywords())
+	})
+	t.Run("Merge", func(t *testing.T) {
+		result, err := sidecar.Render([]byte(testDarktableXmp))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s := string(result)
+
+		assert.NotContains(t, s, "Old Title")
+		assert.NotContains(t, s, `xmp:Rating="3"`)
+		assert.Contains(t, s, `<darktable:history>`)
+		assert.Contains(t, s, `darktable:operation="exposure"`)
+		assert.Contains(t, s, `x:xmptk="XMP Core 4.4.0-Exiv2"`)
+		assert.Equal(t, 1, strings.Count(s, "Night Shift / Berlin"))
+
+		// Updating the sidecar again must give the same result.
+		again, err := sidecar.Render(result)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, s, string(again))
+	})
+	t.Run("KeepRating", func(t *testing.T) {
+		result, err := XmpSidecar{Title: "Test"}.Render([]byte(testDarktableXmp))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, string(result), `xmp:Rating="3"`)
+	})
+	t.Run("RemoveFavoriteRating", func(t *testing.T) {
+		favorite, err := XmpSidecar{Favorite: true}.Render(nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result, err := XmpSidecar{Favorite: false}.Render(favorite)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.NotContains(t, string(result), "Rating")
+	})
+	t.Run("KeepFiveStarRating", func(t *testing.T) {
+		fiveStars := strings.Replace(testDarktableXmp, `xmp:Rating="3"`, `xmp:Rating="5"`, 1)
+
+		result, err := XmpSidecar{Title: "Test"}.Render([]byte(fiveStars))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, string(result), `xmp:Rating="5"`)
+		assert.NotContains(t, string(result), `photoprism:Favorite`)
+	})
+	t.Run("KeepChangedRating", func(t *testing.T) {
+		favorite, err := XmpSidecar{Favorite: true}.Render(nil)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, string(favorite), `photoprism:Favorite="true"`)
+
+		// Another application changes the rating while the photo remains a favorite.
+		changed := strings.Replace(string(favorite), `<xmp:Rating>5</xmp:Rating>`, `<xmp:Rating>4</xmp:Rating>`, 1)
+		result, err := XmpSidecar{Favorite: true}.Render([]byte(changed))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, string(result), `<xmp:Rating>4</xmp:Rating>`)
+		assert.NotContains(t, string(result), `<xmp:Rating>5</xmp:Rating>`)
+	})
+	t.Run("MultipleDescriptions", func(t *testing.T) {
+		data := `<?xml version="1.0" encoding="UTF-8"?>
+<x:xmpmeta xmlns:x="adobe:ns:meta/">
+ <rdf:RDF xmlns:rdf="http://www.w3.org/1999/02/22-rdf-syntax-ns#">
+  <rdf:Description rdf:about="" xmlns:xmp="http://ns.adobe.com/xap/1.0/" xmlns:photoprism="http://ns.photoprism.app/xmp/1.0/" photoprism:Favorite="true">
+   <xmp:Rating>5</xmp:Rating>
+  </rdf:Description>
+  <rdf:Description rdf:about="" xmlns:xmp="http://ns.adobe.com/xap/1.0/">
+   <xmp:Label>Red</xmp:Label>
+  </rdf:Description>
+  <rdf:Description rdf:about="" xmlns:xmp="http://ns.adobe.com/xap/1.0/" xmp:Rating="2">
+  </rdf:Description>
+ </rdf:RDF>
+</x:xmpmeta>
+`
+		result, err := XmpSidecar{Favorite: false}.Render([]byte(data))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		s := string(result)
+
+		assert.NotContains(t, s, `<xmp:Rating>5</xmp:Rating>`)
+		assert.Contains(t, s, `xmp:Rating="2"`)
+		assert.Contains(t, s, `<xmp:Label>Red</xmp:Label>`)
+		assert.NotContains(t, s, `photoprism:Favorite`)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := sidecar.Render([]byte("<x:xmpmeta><rdf:RDF>"))
+		assert.Error(t, err)
+	})
+}
+
+func TestXmpSidecar_Subjects(t *testing.T) {
+	t.Run("Merge", func(t *testing.T) {
+		s := XmpSidecar{Keywords: []string{"cat", " ", "Garden"}, Labels: []string{"Cat", "Plant", "garden"}}
+		assert.Equal(t, []string{"cat", "Garden", "Plant"}, s.Subjects())
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.Empty(t, XmpSidecar{}.Subjects())
+	})
+}
+
+func TestXmpSidecar_Save(t *testing.T) {
+	fileName := filepath.Join(t.TempDir(), "example.jpg.xmp")
+
+	if err := (XmpSidecar{Title: "Example"}).Save(fileName); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.FileExists(t, fileName)
+
+	data, err := XMP(fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, "Example", data.Title)
+}
+
+func TestXmpGenerated(t *testing.T) {
+	t.Run("Unmodified", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "example.jpg.xmp")
+
+		if err := (XmpSidecar{Title: "Example"}).Save(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.True(t, XmpGenerated(fileName))
+	})
+	t.Run("Modified", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "example.jpg.xmp")
+
+		if err := (XmpSidecar{Title: "Example"}).Save(fileName); err != nil {
+			t.Fatal(err)
+		}
+
+		data, err := os.ReadFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err = os.WriteFile(fileName, []byte(strings.Replace(string(data), "Example", "Edited", 1)), fs.ModeFile); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.False(t, XmpGenerated(fileName))
+	})
+	t.Run("External", func(t *testing.T) {
+		assert.False(t, XmpGenerated("testdata/photoshop.xmp"))
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		assert.False(t, XmpGenerated("testdata/missing.xmp"))
+	})
+}
+
+// writeTestXmp saves the data to a temporary file and returns its name.
+func writeTestXmp(t *testing.T, data []byte) string {
+	fileName := filepath.Join(t.TempDir(), "test.xmp")
+
+	if err := os.WriteFile(fileName, data, fs.ModeFile); err != nil {
+		t.Fatal(err)
+	}
+
+	return fileName
+}
@@ -35,6 +35,7 @@ func TestXMP(t *testing.T) {
 		assert.Equal(t, "HUAWEI", data.CameraMake)
 		assert.Equal(t, "ELE-L29", data.CameraModel)
 		assert.Equal(t, "HUAWEI P30 Rear Main Camera", data.LensModel)
+		assert.Equal(t, Keywords{"coffee", "computer", "desk"}, data.Keywords)
 	})
 	t.Run("CanonEosSixD", func(t *testing.T) {
 		data, err := XMP("testdata/canon_eos_6d.xmp")
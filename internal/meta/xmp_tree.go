@@ -0,0 +1,360 @@
+package meta
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// xmlNode represents an element, text, or other raw token of a generic XML document. Unlike
+// xml.Unmarshal, it preserves unknown elements and namespace prefixes so that XMP sidecar
+// files written by other applications can be updated without losing information.
+type xmlNode struct {
+	Name   xml.Name // Element name, Name.Space contains the namespace prefix.
+	Attr   []xml.Attr
+	Nodes  []*xmlNode
+	Text   string // Character data if this is a text node.
+	Raw    string // Comments, processing instructions, and directives.
+	parent *xmlNode
+	pretty bool // Indent child elements, used for generated nodes.
+}
+
+// parseXmlTree parses an XML document into a tree of nodes.
+func parseXmlTree(data []byte) (*xmlNode, error) {
+	root := &xmlNode{}
+	current := root
+
+	dec := xml.NewDecoder(bytes.NewReader(data))
+
+	for {
+		t, err := dec.RawToken()
+
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		switch t := t.(type) {
+		case xml.StartElement:
+			n := &xmlNode{Name: t.Name, Attr: append([]xml.Attr{}, t.Attr...)}
+			current.add(n)
+			current = n
+		case xml.EndElement:
+			if current.parent == nil {
+				return nil, errors.New("unexpected end element")
+			}
+
+			current = current.parent
+		case xml.CharData:
+			current.add(&xmlNode{Text: string(t)})
+		case xml.Comment:
+			current.add(&xmlNode{Raw: "<!--" + string(t) + "-->"})
+		case xml.ProcInst:
+			if len(t.Inst) == 0 {
+				current.add(&xmlNode{Raw: "<?" + t.Target + "?>"})
+			} else {
+				current.add(&xmlNode{Raw: "<?" + t.Target + " " + string(t.Inst) + "?>"})
+			}
+		case xml.Directive:
+			current.add(&xmlNode{Raw: "<!" + string(t) + ">"})
+		}
+	}
+
+	if current != root {
+		return nil, errors.New("unexpected end of document")
+	}
+
+	return root, nil
+}
+
+// newXmlElement returns a new element node that is indented when rendered.
+func newXmlElement(prefix, local string, attr ...xml.Attr) *xmlNode {
+	return &xmlNode{Name: xml.Name{Space: prefix, Local: local}, Attr: attr, pretty: true}
+}
+
+// xmlAttr returns a new attribute with the specified prefix, name, and value.
+func xmlAttr(prefix, local, value string) xml.Attr {
+	return xml.Attr{Name: xml.Name{Space: prefix, Local: local}, Value: value}
+}
+
+// IsElement checks if the node is an element.
+func (n *xmlNode) IsElement() bool {
+	return n.Name.Local != ""
+}
+
+// IsSpace checks if the node contains whitespace only.
+func (n *xmlNode) IsSpace() bool {
+	return !n.IsElement() && n.Raw == "" && strings.TrimSpace(n.Text) == ""
+}
+
+// add appends a child node.
+func (n *xmlNode) add(child *xmlNode) *xmlNode {
+	child.parent = n
+	n.Nodes = append(n.Nodes, child)
+	return child
+}
+
+// AddText appends a text node.
+func (n *xmlNode) AddText(s string) *xmlNode {
+	n.add(&xmlNode{Text: s})
+	return n
+}
+
+// Elements returns the child elements.
+func (n *xmlNode) Elements() (result []*xmlNode) {
+	for _, c := range n.Nodes {
+		if c.IsElement() {
+			result = append(result, c)
+		}
+	}
+
+	return result
+}
+
+// Depth returns the number of parent elements.
+func (n *xmlNode) Depth() (depth int) {
+	for p := n.parent; p != nil && p.IsElement(); p = p.parent {
+		depth++
+	}
+
+	return depth
+}
+
+// Namespace returns the namespace URI bound to a prefix in the scope of this node.
+func (n *xmlNode) Namespace(prefix string) string {
+	switch prefix {
+	case "xml":
+		return "http://www.w3.org/XML/1998/namespace"
+	case "xmlns":
+		return ""
+	}
+
+	for e := n; e != nil; e = e.parent {
+		for _, a := range e.Attr {
+			if prefix == "" && a.Name.Space == "" && a.Name.Local == "xmlns" {
+				return a.Value
+			} else if prefix != "" && a.Name.Space == "xmlns" && a.Name.Local == prefix {
+				return a.Value
+			}
+		}
+	}
+
+	return ""
+}
+
+// Prefix returns the prefix bound to a namespace URI in the scope of this node, or an empty string if none.
+func (n *xmlNode) Prefix(uri string) string {
+	for e := n; e != nil; e = e.parent {
+		for _, a := range e.Attr {
+			if a.Name.Space == "xmlns" && a.Value == uri && n.Namespace(a.Name.Local) == uri {
+				return a.Name.Local
+			}
+		}
+	}
+
+	return ""
+}
+
+// Bind returns the prefix bound to a namespace URI and declares it on this node if needed.
+func (n *xmlNode) Bind(uri, preferred string) string {
+	if prefix := n.Prefix(uri); prefix != "" {
+		return prefix
+	}
+
+	prefix := preferred
+
+	// Avoid conflicts with prefixes already bound to a different namespace.
+	for i := 2; n.Namespace(prefix) != ""; i++ {
+		prefix = fmt.Sprintf("%s%d", preferred, i)
+	}
+
+	n.Attr = append(n.Attr, xmlAttr("xmlns", prefix, uri))
+
+	return prefix
+}
+
+// Is checks if this is an element with the specified namespace URI and local name.
+func (n *xmlNode) Is(uri, local string) bool {
+	return n.IsElement() && n.Name.Local == local && n.Namespace(n.Name.Space) == uri
+}
+
+// Find returns the first descendant element with the specified namespace URI and local name.
+func (n *xmlNode) Find(uri, local string) *xmlNode {
+	for _, c := range n.Nodes {
+		if c.Is(uri, local) {
+			return c
+		} else if found := c.Find(uri, local); found != nil {
+			return found
+		}
+	}
+
+	return nil
+}
+
+// AttrValue returns the value of the attribute with the specified namespace URI and local name.
+func (n *xmlNode) AttrValue(uri, local string) (string, bool) {
+	for _, a := range n.Attr {
+		if a.Name.Local == local && a.Name.Space != "xmlns" && n.Namespace(a.Name.Space) == uri {
+			return a.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// Value returns the value of a simple property, which can be either an attribute or a child element.
+func (n *xmlNode) Value(uri, local string) string {
+	if s, ok := n.AttrValue(uri, local); ok {
+		return s
+	}
+
+	for _, c := range n.Elements() {
+		if c.Is(uri, local) {
+			return strings.TrimSpace(c.InnerText())
+		}
+	}
+
+	return ""
+}
+
+// InnerText returns the concatenated character data of all descendant nodes.
+func (n *xmlNode) InnerText() string {
+	var b strings.Builder
+
+	for _, c := range n.Nodes {
+		if c.IsElement() {
+			b.WriteString(c.InnerText())
+		} else {
+			b.WriteString(c.Text)
+		}
+	}
+
+	return b.String()
+}
+
+// Remove removes all attributes and child elements matching the namespace URI and local names.
+func (n *xmlNode) Remove(uri string, locals ...string) {
+	match := func(local string) bool {
+		for _, l := range locals {
+			if l == local {
+				return true
+			}
+		}
+
+		return len(locals) == 0
+	}
+
+	attr := make([]xml.Attr, 0, len(n.Attr))
+
+	for _, a := range n.Attr {
+		if a.Name.Space != "xmlns" && match(a.Name.Local) && n.Namespace(a.Name.Space) == uri {
+			continue
+		}
+
+		attr = append(attr, a)
+	}
+
+	n.Attr = attr
+
+	nodes := make([]*xmlNode, 0, len(n.Nodes))
+
+	for _, c := range n.Nodes {
+		if c.IsElement() && match(c.Name.Local) && c.Namespace(c.Name.Space) == uri {
+			// Remove the indentation preceding the element as well.
+			if l := len(nodes); l > 0 && nodes[l-1].IsSpace() {
+				nodes = nodes[:l-1]
+			}
+
+			continue
+		}
+
+		nodes = append(nodes, c)
+	}
+
+	n.Nodes = nodes
+}
+
+// Append adds a child element before any trailing whitespace so that the existing indentation is preserved.
+func (n *xmlNode) Append(child *xmlNode) *xmlNode {
+	indent := &xmlNode{Text: "\n" + strings.Repeat(" ", n.Depth()+1), parent: n}
+	child.parent = n
+
+	if l := len(n.Nodes); l > 0 && n.Nodes[l-1].IsSpace() {
+		last := n.Nodes[l-1]
+		n.Nodes = append(n.Nodes[:l-1], indent, child, last)
+	} else {
+		n.Nodes = append(n.Nodes, indent, child, &xmlNode{Text: "\n" + strings.Repeat(" ", n.Depth()), parent: n})
+	}
+
+	return child
+}
+
+// Bytes renders the document.
+func (n *xmlNode) Bytes() []byte {
+	var b bytes.Buffer
+
+	for _, c := range n.Nodes {
+		c.write(&b, 0)
+	}
+
+	return b.Bytes()
+}
+
+// write renders the node and its children.
+func (n *xmlNode) write(b *bytes.Buffer, depth int) {
+	if n.Raw != "" {
+		b.WriteString(n.Raw)
+		return
+	} else if !n.IsElement() {
+		b.WriteString(xmlTextEscaper.Replace(n.Text))
+		return
+	}
+
+	b.WriteString("<" + xmlName(n.Name))
+
+	for _, a := range n.Attr {
+		b.WriteString(" " + xmlName(a.Name) + "=\"" + xmlAttrEscaper.Replace(a.Value) + "\"")
+	}
+
+	if len(n.Nodes) == 0 {
+		b.WriteString("/>")
+		return
+	}
+
+	b.WriteString(">")
+
+	indent := n.pretty && len(n.Elements()) > 0
+
+	for _, c := range n.Nodes {
+		if indent {
+			b.WriteString("\n" + strings.Repeat(" ", depth+1))
+		}
+
+		c.write(b, depth+1)
+	}
+
+	if indent {
+		b.WriteString("\n" + strings.Repeat(" ", depth))
+	}
+
+	b.WriteString("</" + xmlName(n.Name) + ">")
+}
+
+// Escape special characters in text and attribute values, while preserving whitespace.
+var (
+	xmlTextEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+	xmlAttrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", "\"", "&quot;", "\n", "&#xA;", "\r", "&#xD;", "\t", "&#x9;")
+)
+
+// xmlName returns the qualified name with namespace prefix.
+func xmlName(name xml.Name) string {
+	if name.Space == "" {
+		return name.Local
+	}
+
+	return name.Space + ":" + name.Local
+}
@@ -2,15 +2,16 @@ package mutex
 
 // Activities that can be started and stopped.
 var (
-	IndexWorker  = Activity{}
-	SyncWorker   = Activity{}
-	BackupWorker = Activity{}
-	ShareWorker  = Activity{}
-	MetaWorker   = Activity{}
-	VisionWorker = Activity{}
-	FacesWorker  = Activity{}
-	UpdatePeople = Activity{}
-	BatchEdit    = Activity{}
+	IndexWorker   = Activity{}
+	SyncWorker    = Activity{}
+	BackupWorker  = Activity{}
+	ShareWorker   = Activity{}
+	MetaWorker    = Activity{}
+	VisionWorker  = Activity{}
+	FacesWorker   = Activity{}
+	WebhookWorker = Activity{}
+	UpdatePeople  = Activity{}
+	BatchEdit     = Activity{}
 )
 
 // CancelAll requests to stop all activities.
@@ -22,6 +23,7 @@ func CancelAll() {
 	MetaWorker.Cancel()
 	VisionWorker.Cancel()
 	FacesWorker.Cancel()
+	WebhookWorker.Cancel()
 	UpdatePeople.Cancel()
 	BatchEdit.Cancel()
 }
@@ -125,6 +125,7 @@ func RestoreAlbums(backupPath string, force bool) (count int, result error) {
 		} else if err = a.Create(); err != nil {
 			log.Errorf("%s: %s in %s (restore)", a.AlbumType, err, clean.Log(filepath.Base(fileName)))
 		} else {
+			a.Comments.Restore(a.AlbumUID)
 			count++
 		}
 	}
@@ -0,0 +1,585 @@
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/photoprism/get"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// ArchiveOptions specifies how database backup archives are created.
+type ArchiveOptions struct {
+	Compression string
+	Incremental bool
+	Albums      bool
+	AlbumsPath  string
+	Sidecars    bool
+	SidecarPath string
+	Force       bool
+	Retain      int
+}
+
+// Archive creates a database backup archive with the specified file and path name. Archives contain a
+// manifest with checksums and row counts, a dump of each table, and optionally the album YAML backup
+// and photo YAML sidecar files. Incremental archives only include the tables that have changed since
+// the last full backup.
+func Archive(backupPath, fileName string, opt ArchiveOptions) (manifest *Manifest, err error) {
+	// Ensure that only one database backup/restore operation is running at a time.
+	backupDatabaseMutex.Lock()
+	defer backupDatabaseMutex.Unlock()
+
+	// Backup action shown in logs.
+	backupAction := "creating"
+
+	// Get configuration.
+	c := get.Config()
+
+	if backupPath == "" {
+		backupPath = c.BackupDatabasePath()
+	}
+
+	// Create the backup path if it does not already exist.
+	if err = fs.MkdirAll(backupPath); err != nil {
+		return nil, err
+	}
+
+	// Check if the backup path is writable.
+	if !fs.PathWritable(backupPath) {
+		return nil, fmt.Errorf("backup path is not writable")
+	}
+
+	if opt.Compression == "" {
+		opt.Compression = c.BackupCompression()
+	}
+
+	if fileName == "" {
+		backupFile := time.Now().UTC().Format("2006-01-02") + ArchiveExt(opt.Compression)
+		fileName = filepath.Join(backupPath, backupFile)
+	}
+
+	log.Debugf("backup: database backups will be stored in %s", clean.Log(backupPath))
+
+	if _, err = os.Stat(fileName); err == nil && !opt.Force {
+		return nil, fmt.Errorf("%s already exists", clean.Log(filepath.Base(fileName)))
+	} else if err == nil {
+		backupAction = "replacing"
+	}
+
+	archiveDir := filepath.Dir(fileName)
+
+	// Create archive path if not exists.
+	if err = fs.MkdirAll(archiveDir); err != nil {
+		return nil, err
+	}
+
+	tables, err := databaseTables(c)
+
+	if err != nil {
+		return nil, err
+	}
+
+	manifest = NewManifest(c.DatabaseDriver(), opt.Compression)
+
+	// Find the last full backup to compare table checksums with.
+	var base *Manifest
+
+	if opt.Incremental {
+		if manifest.Base, base = lastFullArchive(archiveDir, c.DatabaseDriver(), fileName); base == nil {
+			log.Infof("backup: found no full backup, creating a full backup instead")
+		} else {
+			log.Infof("backup: creating incremental backup based on %s", clean.Log(manifest.Base))
+		}
+	}
+
+	// Table dumps are written to a temporary directory first, so that unchanged
+	// tables can be skipped and the manifest can be stored as the first entry.
+	tempDir, err := os.MkdirTemp(archiveDir, ".backup-")
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer os.RemoveAll(tempDir)
+
+	log.Infof("backup: %s database backup archive %s", backupAction, clean.Log(filepath.Base(fileName)))
+
+	for _, table := range tables {
+		entry, dumpErr := dumpTable(c, table, tempDir)
+
+		if dumpErr != nil {
+			return nil, fmt.Errorf("%s (dump %s)", dumpErr, clean.Log(table))
+		}
+
+		if base != nil {
+			if found := base.Table(table); found != nil && found.Checksum == entry.Checksum {
+				entry.Base = true
+			}
+		}
+
+		manifest.Tables = append(manifest.Tables, entry)
+	}
+
+	// Source paths of the bundled YAML files by archive directory.
+	filePaths := make(map[string]string, 2)
+
+	// Bundle album YAML backup files?
+	if opt.Albums {
+		if opt.AlbumsPath == "" {
+			opt.AlbumsPath = c.BackupAlbumsPath()
+		}
+
+		filePaths[ArchiveAlbumsDir] = opt.AlbumsPath
+
+		if manifest.Files, err = yamlEntries(opt.AlbumsPath, ArchiveAlbumsDir); err != nil {
+			return nil, err
+		}
+	}
+
+	// Bundle photo YAML sidecar files?
+	if opt.Sidecars {
+		if opt.SidecarPath == "" {
+			opt.SidecarPath = c.SidecarPath()
+		}
+
+		filePaths[ArchiveSidecarDir] = opt.SidecarPath
+
+		if entries, entriesErr := yamlEntries(opt.SidecarPath, ArchiveSidecarDir); entriesErr != nil {
+			return nil, entriesErr
+		} else {
+			manifest.Files = append(manifest.Files, entries...)
+		}
+	}
+
+	// Write the archive to a temporary file before replacing existing backups.
+	tempFile := filepath.Join(tempDir, filepath.Base(fileName))
+
+	if err = writeArchive(tempFile, manifest, tempDir, filePaths); err != nil {
+		return nil, err
+	} else if err = os.Rename(tempFile, fileName); err != nil {
+		return nil, err
+	}
+
+	if changed := manifest.Changed(); base != nil {
+		log.Infof("backup: %s changed since %s", english.Plural(changed, "table", "tables"), clean.Log(manifest.Base))
+	}
+
+	// Delete old backup archives if the number of backups to keep has been specified.
+	if backupPath != "" && opt.Retain > 0 {
+		if err = retainArchives(backupPath, opt.Retain); err != nil {
+			return manifest, err
+		}
+	}
+
+	return manifest, nil
+}
+
+// databaseTables returns the names of all tables in the index database.
+func databaseTables(c *config.Config) (tables []string, err error) {
+	var stmt string
+
+	switch c.DatabaseDriver() {
+	case config.MySQL, config.MariaDB:
+		stmt = "SELECT table_name FROM information_schema.tables WHERE table_schema = DATABASE() AND table_type = 'BASE TABLE' ORDER BY table_name"
+	case config.SQLite3:
+		stmt = "SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' ORDER BY name"
+	default:
+		return tables, fmt.Errorf("unsupported database type: %s", c.DatabaseDriver())
+	}
+
+	rows, err := c.Db().Raw(stmt).Rows()
+
+	if err != nil {
+		return tables, err
+	}
+
+	defer rows.Close()
+
+	for rows.Next() {
+		var name string
+
+		if err = rows.Scan(&name); err != nil {
+			return tables, err
+		}
+
+		// Skip the full-text search index, as it is rebuilt from the other tables.
+		if entity.IsSearchIndexTable(name) {
+			continue
+		}
+
+		tables = append(tables, name)
+	}
+
+	return tables, rows.Err()
+}
+
+// dumpTable writes a dump of the specified table to the directory and returns its manifest entry.
+func dumpTable(c *config.Config, table, dir string) (entry ManifestEntry, err error) {
+	entry = ManifestEntry{
+		Name:  path.Join(ArchiveDatabaseDir, table+".sql"),
+		Table: table,
+	}
+
+	// Count rows immediately before the table is dumped, so the
+	// manifest matches the dump unless data is added concurrently.
+	if err = c.Db().Table(table).Count(&entry.Rows).Error; err != nil {
+		return entry, err
+	}
+
+	cmd, err := dumpCommand(c, table)
+
+	if err != nil {
+		return entry, err
+	}
+
+	f, err := os.OpenFile(filepath.Join(dir, table+".sql"), os.O_TRUNC|os.O_RDWR|os.O_CREATE, fs.ModeBackupFile) // #nosec G304 temporary backup path
+
+	if err != nil {
+		return entry, err
+	}
+
+	defer f.Close()
+
+	hash := sha256.New()
+	size := &countWriter{}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	cmd.Stdout = io.MultiWriter(f, hash, size)
+
+	// Log exact command for debugging in trace mode.
+	log.Trace(cmd.String())
+
+	if cmdErr := cmd.Run(); cmdErr != nil {
+		if errStr := strings.TrimSpace(stderr.String()); errStr != "" {
+			return entry, errors.New(errStr)
+		}
+
+		return entry, cmdErr
+	}
+
+	entry.Size = size.n
+	entry.Checksum = hex.EncodeToString(hash.Sum(nil))
+
+	return entry, nil
+}
+
+// yamlEntries returns the manifest entries of the YAML files in the specified path, with their
+// names prefixed by the archive directory.
+func yamlEntries(filePath, archiveDir string) (entries []ManifestEntry, err error) {
+	if !fs.PathExists(filePath) {
+		return entries, nil
+	}
+
+	err = filepath.Walk(filePath, func(fileName string, info os.FileInfo, walkErr error) error {
+		if walkErr != nil {
+			return walkErr
+		} else if info.IsDir() || filepath.Ext(fileName) != fs.ExtYml {
+			return nil
+		}
+
+		rel, relErr := filepath.Rel(filePath, fileName)
+
+		if relErr != nil {
+			return relErr
+		}
+
+		data, readErr := os.ReadFile(fileName) // #nosec G304 backup and sidecar paths from config
+
+		if readErr != nil {
+			return readErr
+		}
+
+		sum := sha256.Sum256(data)
+
+		entries = append(entries, ManifestEntry{
+			Name:     path.Join(archiveDir, filepath.ToSlash(rel)),
+			Size:     int64(len(data)),
+			Checksum: hex.EncodeToString(sum[:]),
+		})
+
+		return nil
+	})
+
+	return entries, err
+}
+
+// writeArchive writes the manifest, the changed table dumps, and the YAML files to a new archive.
+// The source paths of the YAML files are passed by archive directory.
+func writeArchive(fileName string, m *Manifest, dumpPath string, filePaths map[string]string) (err error) {
+	f, err := os.OpenFile(fileName, os.O_TRUNC|os.O_RDWR|os.O_CREATE, fs.ModeBackupFile) // #nosec G304 backup path validated by configuration
+
+	if err != nil {
+		return fmt.Errorf("failed to create %s (%s)", clean.Log(filepath.Base(fileName)), err)
+	}
+
+	defer f.Close()
+
+	zw, err := compressWriter(f, m.Compression)
+
+	if err != nil {
+		return err
+	}
+
+	tw := tar.NewWriter(zw)
+
+	manifestJson, err := json.MarshalIndent(m, "", "  ")
+
+	if err != nil {
+		return err
+	}
+
+	if err = writeArchiveEntry(tw, ManifestFileName, m.Created, bytes.NewReader(manifestJson), int64(len(manifestJson))); err != nil {
+		return err
+	}
+
+	for _, entry := range m.Tables {
+		if entry.Base {
+			continue
+		}
+
+		if err = copyArchiveFile(tw, entry, m.Created, filepath.Join(dumpPath, entry.Table+".sql")); err != nil {
+			return err
+		}
+	}
+
+	for _, entry := range m.Files {
+		dir, rel, _ := strings.Cut(entry.Name, "/")
+
+		if filePath, ok := filePaths[dir]; !ok {
+			return fmt.Errorf("unknown archive directory %s", clean.Log(dir))
+		} else if err = copyArchiveFile(tw, entry, m.Created, filepath.Join(filePath, filepath.FromSlash(rel))); err != nil {
+			return err
+		}
+	}
+
+	if err = tw.Close(); err != nil {
+		return err
+	} else if err = zw.Close(); err != nil {
+		return err
+	}
+
+	return f.Sync()
+}
+
+// copyArchiveFile adds the file with the specified name to the archive.
+func copyArchiveFile(tw *tar.Writer, entry ManifestEntry, modTime time.Time, fileName string) error {
+	f, err := os.Open(fileName) // #nosec G304 file names are determined by the backup
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	return writeArchiveEntry(tw, entry.Name, modTime, f, entry.Size)
+}
+
+// writeArchiveEntry adds a file entry to the archive.
+func writeArchiveEntry(tw *tar.Writer, name string, modTime time.Time, r io.Reader, size int64) error {
+	header := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     int64(fs.ModeBackupFile),
+		Size:     size,
+		ModTime:  modTime,
+		Format:   tar.FormatPAX,
+	}
+
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+
+	_, err := io.CopyN(tw, r, size)
+
+	return err
+}
+
+// findArchives returns the backup archives in the specified path, sorted by name.
+func findArchives(backupPath string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(regexp.QuoteMeta(backupPath), ArchiveFileNamePattern))
+
+	if err != nil {
+		return files, err
+	}
+
+	sort.Strings(files)
+
+	return files, nil
+}
+
+// lastFullArchive returns the name and manifest of the most recent full backup archive in the specified path.
+func lastFullArchive(backupPath, driver, exclude string) (string, *Manifest) {
+	files, err := findArchives(backupPath)
+
+	if err != nil {
+		return "", nil
+	}
+
+	for i := len(files) - 1; i >= 0; i-- {
+		if filepath.Base(files[i]) == filepath.Base(exclude) {
+			continue
+		}
+
+		if m, manifestErr := ReadManifest(files[i]); manifestErr != nil {
+			log.Debugf("backup: %s in %s", manifestErr, clean.Log(filepath.Base(files[i])))
+		} else if !m.Incremental() && m.Driver == driver {
+			return filepath.Base(files[i]), m
+		}
+	}
+
+	return "", nil
+}
+
+// retainArchives deletes old backup archives while keeping the base archives of incremental backups.
+func retainArchives(backupPath string, retain int) error {
+	files, err := findArchives(backupPath)
+
+	if err != nil {
+		return err
+	} else if len(files) <= retain {
+		return nil
+	}
+
+	log.Infof("backup: retaining %s", english.Plural(retain, "database backup archive", "database backup archives"))
+
+	// Base archives of the retained incremental backups must not be deleted.
+	required := make(map[string]bool)
+
+	for _, fileName := range files[len(files)-retain:] {
+		if m, manifestErr := ReadManifest(fileName); manifestErr == nil && m.Incremental() {
+			required[m.Base] = true
+		}
+	}
+
+	for _, fileName := range files[:len(files)-retain] {
+		if required[filepath.Base(fileName)] {
+			log.Debugf("backup: keeping %s as it is required by incremental backups", clean.Log(filepath.Base(fileName)))
+			continue
+		}
+
+		if err = os.Remove(fileName); err != nil {
+			return err
+		}
+
+		log.Infof("backup: removed database backup archive %s", clean.Log(filepath.Base(fileName)))
+	}
+
+	return nil
+}
+
+// countWriter counts the number of bytes written.
+type countWriter struct {
+	n int64
+}
+
+// Write implements io.Writer.
+func (w *countWriter) Write(p []byte) (int, error) {
+	w.n += int64(len(p))
+	return len(p), nil
+}
+
+// ExtractAlbums extracts the album YAML backup files from an archive to the specified path.
+func ExtractAlbums(fileName, albumsPath string) (count int, err error) {
+	return extractFiles(fileName, ArchiveAlbumsDir, albumsPath, true)
+}
+
+// ExtractSidecars extracts the photo YAML sidecar files from an archive to the specified path.
+// Existing files are not replaced, as they may contain more recent changes.
+func ExtractSidecars(fileName, sidecarPath string) (count int, err error) {
+	return extractFiles(fileName, ArchiveSidecarDir, sidecarPath, false)
+}
+
+// extractFiles extracts the files in the specified archive directory to the destination path.
+func extractFiles(fileName, archiveDir, destPath string, replace bool) (count int, err error) {
+	f, err := os.Open(fileName) // #nosec G304 backup path validated by caller
+
+	if err != nil {
+		return count, err
+	}
+
+	defer f.Close()
+
+	br, release, err := decompressReader(f)
+
+	if err != nil {
+		return count, err
+	}
+
+	defer release()
+
+	if !isTar(br) {
+		return count, fmt.Errorf("%s is %w", clean.Log(filepath.Base(fileName)), ErrNoArchive)
+	}
+
+	tr := tar.NewReader(br)
+	m, err := readManifest(tr)
+
+	if err != nil {
+		return count, err
+	}
+
+	for {
+		header, nextErr := tr.Next()
+
+		if errors.Is(nextErr, io.EOF) {
+			break
+		} else if nextErr != nil {
+			return count, nextErr
+		}
+
+		entry := m.Entry(header.Name)
+
+		if entry == nil || entry.Table != "" || !strings.HasPrefix(header.Name, archiveDir+"/") {
+			continue
+		}
+
+		// Make sure that files cannot be written outside the destination path.
+		rel := filepath.FromSlash(strings.TrimPrefix(path.Clean(header.Name), archiveDir+"/"))
+
+		if rel == "" || strings.HasPrefix(rel, "..") || filepath.IsAbs(rel) {
+			continue
+		}
+
+		dest := filepath.Join(destPath, rel)
+
+		if !replace && fs.FileExists(dest) {
+			continue
+		}
+
+		data, readErr := io.ReadAll(io.LimitReader(tr, header.Size))
+
+		if readErr != nil {
+			return count, readErr
+		} else if sum := sha256.Sum256(data); hex.EncodeToString(sum[:]) != entry.Checksum {
+			return count, fmt.Errorf("checksum of %s does not match", clean.Log(header.Name))
+		}
+
+		if err = fs.MkdirAll(filepath.Dir(dest)); err != nil {
+			return count, err
+		} else if err = os.WriteFile(dest, data, fs.ModeFile); err != nil {
+			return count, err
+		}
+
+		count++
+	}
+
+	return count, nil
+}
@@ -0,0 +1,195 @@
+package backup
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestArchive(t *testing.T) {
+	backupPath, err := filepath.Abs("./testdata/archive")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err = os.MkdirAll(backupPath, fs.ModeDir); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(backupPath)
+
+	albumsPath := filepath.Join(backupPath, "albums")
+
+	if err = os.MkdirAll(filepath.Join(albumsPath, "album"), fs.ModeDir); err != nil {
+		t.Fatal(err)
+	} else if err = os.WriteFile(filepath.Join(albumsPath, "album", "as6sg6bxpogaaba7.yml"), []byte("UID: as6sg6bxpogaaba7\n"), fs.ModeFile); err != nil {
+		t.Fatal(err)
+	}
+
+	sidecarPath := filepath.Join(backupPath, "sidecar")
+
+	if err = os.MkdirAll(filepath.Join(sidecarPath, "2025"), fs.ModeDir); err != nil {
+		t.Fatal(err)
+	} else if err = os.WriteFile(filepath.Join(sidecarPath, "2025", "IMG_0001.yml"), []byte("Title: Lake\n"), fs.ModeFile); err != nil {
+		t.Fatal(err)
+	} else if err = os.WriteFile(filepath.Join(sidecarPath, "2025", "IMG_0001.json"), []byte("{}"), fs.ModeFile); err != nil {
+		t.Fatal(err)
+	}
+
+	fullName := filepath.Join(backupPath, "2025-01-01.tar.gz")
+	incrementalName := filepath.Join(backupPath, "2025-01-02.tar.zst")
+
+	t.Run("Full", func(t *testing.T) {
+		manifest, archiveErr := Archive(backupPath, fullName, ArchiveOptions{
+			Compression: config.BackupCompressionGzip,
+			Albums:      true,
+			AlbumsPath:  albumsPath,
+			Sidecars:    true,
+			SidecarPath: sidecarPath,
+		})
+
+		if archiveErr != nil {
+			t.Fatal(archiveErr)
+		}
+
+		assert.False(t, manifest.Incremental())
+		assert.Equal(t, config.SQLite3, manifest.Driver)
+		assert.NotEmpty(t, manifest.Tables)
+		assert.Equal(t, len(manifest.Tables), manifest.Changed())
+		if assert.Len(t, manifest.Files, 2) {
+			assert.Equal(t, "albums/album/as6sg6bxpogaaba7.yml", manifest.Files[0].Name)
+			assert.Equal(t, "sidecar/2025/IMG_0001.yml", manifest.Files[1].Name)
+		}
+
+		photos := manifest.Table("photos")
+
+		if assert.NotNil(t, photos) {
+			assert.Equal(t, "database/photos.sql", photos.Name)
+			assert.Len(t, photos.Checksum, 64)
+			assert.Positive(t, photos.Rows)
+			assert.Positive(t, photos.Size)
+		}
+
+		found, readErr := ReadManifest(fullName)
+
+		if readErr != nil {
+			t.Fatal(readErr)
+		}
+
+		assert.Equal(t, manifest.Tables, found.Tables)
+		assert.Equal(t, manifest.Files, found.Files)
+	})
+	t.Run("ExtractAlbums", func(t *testing.T) {
+		extractPath := t.TempDir()
+		count, extractErr := ExtractAlbums(fullName, extractPath)
+
+		assert.NoError(t, extractErr)
+		assert.Equal(t, 1, count)
+		assert.FileExists(t, filepath.Join(extractPath, "album", "as6sg6bxpogaaba7.yml"))
+	})
+	t.Run("ExtractSidecars", func(t *testing.T) {
+		extractPath := t.TempDir()
+		count, extractErr := ExtractSidecars(fullName, extractPath)
+
+		assert.NoError(t, extractErr)
+		assert.Equal(t, 1, count)
+		assert.FileExists(t, filepath.Join(extractPath, "2025", "IMG_0001.yml"))
+		assert.NoFileExists(t, filepath.Join(extractPath, "album", "as6sg6bxpogaaba7.yml"))
+
+		// Existing sidecar files are not replaced.
+		count, extractErr = ExtractSidecars(fullName, extractPath)
+
+		assert.NoError(t, extractErr)
+		assert.Equal(t, 0, count)
+	})
+	t.Run("Exists", func(t *testing.T) {
+		_, archiveErr := Archive(backupPath, fullName, ArchiveOptions{Compression: config.BackupCompressionGzip})
+		assert.Error(t, archiveErr)
+	})
+	t.Run("Incremental", func(t *testing.T) {
+		manifest, archiveErr := Archive(backupPath, incrementalName, ArchiveOptions{
+			Compression: config.BackupCompressionZstd,
+			Incremental: true,
+		})
+
+		if archiveErr != nil {
+			t.Fatal(archiveErr)
+		}
+
+		assert.True(t, manifest.Incremental())
+		assert.Equal(t, "2025-01-01.tar.gz", manifest.Base)
+		assert.Less(t, manifest.Changed(), len(manifest.Tables))
+		assert.Empty(t, manifest.Files)
+	})
+	t.Run("CopyDump", func(t *testing.T) {
+		f, openErr := os.Open(incrementalName)
+
+		if openErr != nil {
+			t.Fatal(openErr)
+		}
+
+		defer f.Close()
+
+		var buf bytes.Buffer
+
+		assert.NoError(t, copyDump(&buf, f, backupPath))
+		assert.True(t, strings.Contains(buf.String(), "CREATE TABLE"))
+		assert.True(t, strings.Contains(buf.String(), "photos"))
+	})
+	t.Run("CopyDumpWithoutBase", func(t *testing.T) {
+		f, openErr := os.Open(incrementalName)
+
+		if openErr != nil {
+			t.Fatal(openErr)
+		}
+
+		defer f.Close()
+
+		assert.Error(t, copyDump(&bytes.Buffer{}, f, ""))
+	})
+	t.Run("Verify", func(t *testing.T) {
+		result, verifyErr := Verify(backupPath, "")
+
+		if verifyErr != nil {
+			t.Fatal(verifyErr)
+		}
+
+		assert.NotEmpty(t, result)
+
+		for _, table := range result {
+			assert.True(t, table.OK(), table.Table)
+		}
+	})
+	t.Run("Retain", func(t *testing.T) {
+		assert.NoError(t, retainArchives(backupPath, 1))
+
+		// The base archive is required by the incremental backup.
+		assert.FileExists(t, fullName)
+		assert.FileExists(t, incrementalName)
+	})
+}
+
+func TestReadManifest(t *testing.T) {
+	t.Run("NoArchive", func(t *testing.T) {
+		fileName := filepath.Join(t.TempDir(), "2025-01-01.sql")
+
+		if err := os.WriteFile(fileName, []byte("CREATE TABLE test (id INTEGER);\n"), fs.ModeFile); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err := ReadManifest(fileName)
+		assert.ErrorIs(t, err, ErrNoArchive)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := ReadManifest("testdata/missing.tar.gz")
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,123 @@
+package backup
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// dumpCommand returns the command for creating a database dump, optionally limited to a single table.
+func dumpCommand(c *config.Config, table string) (cmd *exec.Cmd, err error) {
+	switch c.DatabaseDriver() {
+	case config.MySQL, config.MariaDB:
+		var args []string
+
+		// Connect via Unix Domain Socket?
+		if socketName := c.DatabaseServer(); strings.HasPrefix(socketName, "/") {
+			args = []string{
+				"--protocol", "socket",
+				"-S", socketName,
+				"-u", c.DatabaseUser(),
+				"-p" + c.DatabasePassword(),
+			}
+		} else if c.DatabaseSsl() {
+			// see https://mariadb.org/mission-impossible-zero-configuration-ssl/
+			log.Infof("backup: server supports zero-configuration ssl")
+
+			args = []string{
+				"--protocol", "tcp",
+				"-h", c.DatabaseHost(),
+				"-P", c.DatabasePortString(),
+				"-u", c.DatabaseUser(),
+				"-p" + c.DatabasePassword(),
+			}
+		} else {
+			// see https://mariadb.org/mission-impossible-zero-configuration-ssl/
+			log.Infof("backup: zero-configuration ssl not supported by the server")
+
+			args = []string{
+				"--protocol", "tcp",
+				"--skip-ssl",
+				"-h", c.DatabaseHost(),
+				"-P", c.DatabasePortString(),
+				"-u", c.DatabaseUser(),
+				"-p" + c.DatabasePassword(),
+			}
+		}
+
+		if table == "" {
+			args = append(args, c.DatabaseName())
+		} else {
+			// Omit the dump date so that the checksums of unchanged tables remain the same.
+			args = append(args, "--skip-dump-date", "--single-transaction", c.DatabaseName(), table)
+		}
+
+		return exec.Command(c.MariadbDumpBin(), args...), nil // #nosec G204 database connection parameters from trusted config
+	case config.SQLite3:
+		if !fs.FileExistsNotEmpty(c.DatabaseFile()) {
+			return nil, fmt.Errorf("sqlite database file %s not found", clean.LogQuote(c.DatabaseFile()))
+		}
+
+		if table == "" {
+			return exec.Command(c.SqliteBin(), c.DatabaseFile(), ".dump"), nil // #nosec G204 sqlite dump uses configured binary and db path
+		}
+
+		return exec.Command(c.SqliteBin(), c.DatabaseFile(), ".dump "+table), nil // #nosec G204 table names are read from the database schema
+	default:
+		return nil, fmt.Errorf("unsupported database type: %s", c.DatabaseDriver())
+	}
+}
+
+// restoreCommand returns the command for restoring a database dump, where the target is
+// the database name for MariaDB and the database file name for SQLite.
+func restoreCommand(c *config.Config, driver, target string) (cmd *exec.Cmd, err error) {
+	switch driver {
+	case config.MySQL, config.MariaDB:
+		var args []string
+
+		// Connect via Unix Domain Socket?
+		if socketName := c.DatabaseServer(); strings.HasPrefix(socketName, "/") {
+			args = []string{
+				"--protocol", "socket",
+				"-S", socketName,
+				"-u", c.DatabaseUser(),
+				"-p" + c.DatabasePassword(),
+			}
+		} else if c.DatabaseSsl() {
+			// see https://mariadb.org/mission-impossible-zero-configuration-ssl/
+			log.Infof("restore: server supports zero-configuration ssl")
+
+			args = []string{
+				"--protocol", "tcp",
+				"-h", c.DatabaseHost(),
+				"-P", c.DatabasePortString(),
+				"-u", c.DatabaseUser(),
+				"-p" + c.DatabasePassword(),
+			}
+		} else {
+			// see https://mariadb.org/mission-impossible-zero-configuration-ssl/
+			log.Infof("restore: zero-configuration ssl not supported by the server")
+
+			args = []string{
+				"--protocol", "tcp",
+				"--skip-ssl",
+				"-h", c.DatabaseHost(),
+				"-P", c.DatabasePortString(),
+				"-u", c.DatabaseUser(),
+				"-p" + c.DatabasePassword(),
+			}
+		}
+
+		args = append(args, "-f", target)
+
+		return exec.Command(c.MariadbBin(), args...), nil // #nosec G204 database connection parameters from config
+	case config.SQLite3:
+		return exec.Command(c.SqliteBin(), target), nil // #nosec G204 sqlite restore uses configured binary and db path
+	default:
+		return nil, fmt.Errorf("unsupported database type: %s", driver)
+	}
+}
@@ -0,0 +1,105 @@
+package backup
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/klauspost/compress/zstd"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zstdMagic = []byte{0x28, 0xb5, 0x2f, 0xfd}
+	tarMagic  = []byte("ustar")
+)
+
+// ArchiveExt returns the file extension of backup archives with the specified compression.
+func ArchiveExt(compression string) string {
+	switch compression {
+	case config.BackupCompressionNone:
+		return ".tar"
+	case config.BackupCompressionZstd:
+		return ".tar.zst"
+	default:
+		return ".tar.gz"
+	}
+}
+
+// IsArchive checks if the file name has a backup archive extension.
+func IsArchive(fileName string) bool {
+	fileName = strings.ToLower(fileName)
+
+	for _, ext := range []string{".tar", ".tar.gz", ".tgz", ".tar.zst"} {
+		if strings.HasSuffix(fileName, ext) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// compressWriter returns a writer that compresses the data with the specified format.
+func compressWriter(w io.Writer, compression string) (io.WriteCloser, error) {
+	switch compression {
+	case config.BackupCompressionNone:
+		return nopWriteCloser{w}, nil
+	case config.BackupCompressionZstd:
+		return zstd.NewWriter(w)
+	case config.BackupCompressionGzip:
+		return gzip.NewWriter(w), nil
+	default:
+		return nil, fmt.Errorf("unsupported compression %s", compression)
+	}
+}
+
+// decompressReader returns a reader that transparently decompresses gzip and zstd streams,
+// as well as a function that must be called to release its resources.
+func decompressReader(r io.Reader) (*bufio.Reader, func(), error) {
+	br := bufio.NewReader(r)
+
+	magic, _ := br.Peek(len(zstdMagic))
+
+	switch {
+	case bytes.HasPrefix(magic, gzipMagic):
+		zr, err := gzip.NewReader(br)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return bufio.NewReader(zr), func() { _ = zr.Close() }, nil
+	case bytes.HasPrefix(magic, zstdMagic):
+		zr, err := zstd.NewReader(br)
+
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return bufio.NewReader(zr), zr.Close, nil
+	default:
+		return br, func() {}, nil
+	}
+}
+
+// isTar checks if the buffered stream starts with a tar header.
+func isTar(br *bufio.Reader) bool {
+	header, _ := br.Peek(257 + len(tarMagic))
+
+	return len(header) == 257+len(tarMagic) && bytes.Equal(header[257:], tarMagic)
+}
+
+// nopWriteCloser adds a no-op Close method to an io.Writer.
+type nopWriteCloser struct {
+	io.Writer
+}
+
+// Close implements io.Closer.
+func (nopWriteCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,63 @@
+package backup
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+func TestArchiveExt(t *testing.T) {
+	assert.Equal(t, ".tar", ArchiveExt(config.BackupCompressionNone))
+	assert.Equal(t, ".tar.gz", ArchiveExt(config.BackupCompressionGzip))
+	assert.Equal(t, ".tar.zst", ArchiveExt(config.BackupCompressionZstd))
+	assert.Equal(t, ".tar.gz", ArchiveExt(""))
+}
+
+func TestIsArchive(t *testing.T) {
+	assert.True(t, IsArchive("2025-01-01.tar"))
+	assert.True(t, IsArchive("2025-01-01.tar.gz"))
+	assert.True(t, IsArchive("2025-01-01.TAR.ZST"))
+	assert.False(t, IsArchive("2025-01-01.sql"))
+	assert.False(t, IsArchive(""))
+}
+
+func TestDecompressReader(t *testing.T) {
+	data := []byte("CREATE TABLE test (id INTEGER);\n")
+
+	for _, compression := range []string{config.BackupCompressionNone, config.BackupCompressionGzip, config.BackupCompressionZstd} {
+		t.Run(compression, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			w, err := compressWriter(&buf, compression)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, err = w.Write(data)
+			assert.NoError(t, err)
+			assert.NoError(t, w.Close())
+
+			r, release, err := decompressReader(&buf)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			defer release()
+
+			result, err := io.ReadAll(r)
+
+			assert.NoError(t, err)
+			assert.Equal(t, data, result)
+		})
+	}
+	t.Run("Unsupported", func(t *testing.T) {
+		_, err := compressWriter(io.Discard, "lz4")
+		assert.Error(t, err)
+	})
+}
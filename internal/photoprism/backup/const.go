@@ -2,3 +2,19 @@ package backup
 
 // SqlBackupFileNamePattern matches YYYY-MM-DD.sql database backup filenames.
 const SqlBackupFileNamePattern = "[2-9][0-9][0-9][0-9]-[0-1][0-9]-[0-3][0-9].sql"
+
+// ArchiveFileNamePattern matches YYYY-MM-DD.tar, YYYY-MM-DD.tar.gz, and YYYY-MM-DD.tar.zst backup archives.
+const ArchiveFileNamePattern = "[2-9][0-9][0-9][0-9]-[0-1][0-9]-[0-3][0-9].tar*"
+
+// ManifestFileName is the name of the manifest entry in backup archives.
+const ManifestFileName = "manifest.json"
+
+// ManifestVersion is the current version of the backup archive manifest format.
+const ManifestVersion = 1
+
+// Archive entry name prefixes for table dumps, album YAML files, and photo YAML sidecar files.
+const (
+	ArchiveDatabaseDir = "database"
+	ArchiveAlbumsDir   = "albums"
+	ArchiveSidecarDir  = "sidecar"
+)
@@ -1,12 +1,14 @@
 package backup
 
 import (
+	"archive/tar"
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
 	"os"
-	"os/exec"
 	"path/filepath"
 	"regexp"
 	"sort"
@@ -70,60 +72,10 @@ func Database(backupPath, fileName string, toStdOut, force bool, retain int) (er
 		}
 	}
 
-	var cmd *exec.Cmd
-
-	switch c.DatabaseDriver() {
-	case config.MySQL, config.MariaDB:
-		// Connect via Unix Domain Socket?
-		if socketName := c.DatabaseServer(); strings.HasPrefix(socketName, "/") {
-			cmd = exec.Command( // #nosec G204 database connection parameters from trusted config
-				c.MariadbDumpBin(),
-				"--protocol", "socket",
-				"-S", socketName,
-				"-u", c.DatabaseUser(),
-				"-p"+c.DatabasePassword(),
-				c.DatabaseName(),
-			)
-		} else if c.DatabaseSsl() {
-			// see https://mariadb.org/mission-impossible-zero-configuration-ssl/
-			log.Infof("backup: server supports zero-configuration ssl")
-
-			cmd = exec.Command( // #nosec G204 database connection parameters from trusted config
-				c.MariadbDumpBin(),
-				"--protocol", "tcp",
-				"-h", c.DatabaseHost(),
-				"-P", c.DatabasePortString(),
-				"-u", c.DatabaseUser(),
-				"-p"+c.DatabasePassword(),
-				c.DatabaseName(),
-			)
-		} else {
-			// see https://mariadb.org/mission-impossible-zero-configuration-ssl/
-			log.Infof("backup: zero-configuration ssl not supported by the server")
-
-			cmd = exec.Command( // #nosec G204 database connection parameters from trusted config
-				c.MariadbDumpBin(),
-				"--protocol", "tcp",
-				"--skip-ssl",
-				"-h", c.DatabaseHost(),
-				"-P", c.DatabasePortString(),
-				"-u", c.DatabaseUser(),
-				"-p"+c.DatabasePassword(),
-				c.DatabaseName(),
-			)
-		}
-	case config.SQLite3:
-		if !fs.FileExistsNotEmpty(c.DatabaseFile()) {
-			return fmt.Errorf("sqlite database file %s not found", clean.LogQuote(c.DatabaseFile()))
-		}
+	cmd, err := dumpCommand(c, "")
 
-		cmd = exec.Command( // #nosec G204 sqlite dump uses configured binary and db path
-			c.SqliteBin(),
-			c.DatabaseFile(),
-			".dump",
-		)
-	default:
-		return fmt.Errorf("unsupported database type: %s", c.DatabaseDriver())
+	if err != nil {
+		return err
 	}
 
 	// Write to stdout or file.
@@ -200,7 +152,7 @@ func RestoreDatabase(backupPath, fileName string, fromStdIn, force bool) (err er
 				backupPath = c.BackupDatabasePath()
 			}
 
-			files, globErr := filepath.Glob(filepath.Join(regexp.QuoteMeta(backupPath), SqlBackupFileNamePattern))
+			files, globErr := findBackups(backupPath)
 
 			if globErr != nil {
 				return globErr
@@ -210,8 +162,6 @@ func RestoreDatabase(backupPath, fileName string, fromStdIn, force bool) (err er
 				return fmt.Errorf("failed to find a backup in %s, index cannot be restored", backupPath)
 			}
 
-			sort.Strings(files)
-
 			fileName = files[len(files)-1]
 
 			if !fs.FileExistsNotEmpty(fileName) {
@@ -234,6 +184,25 @@ func RestoreDatabase(backupPath, fileName string, fromStdIn, force bool) (err er
 		}
 	}
 
+	// Incremental backup archives require the base archive from the same directory.
+	dumpDir := ""
+
+	if fromStdIn {
+		// Buffer the backup, so it can be verified before the existing index is replaced.
+		if fileName, err = bufferStdin(c.TempPath()); err != nil {
+			return err
+		}
+
+		defer os.Remove(fileName)
+	} else {
+		dumpDir = filepath.Dir(fileName)
+	}
+
+	// Verify the checksums of archived table dumps before any existing tables are dropped.
+	if err = verifyDump(fileName, dumpDir); err != nil {
+		return fmt.Errorf("%s, index cannot be restored", err)
+	}
+
 	counts := struct{ Photos int }{}
 
 	c.Db().Unscoped().Table("photos").
@@ -251,73 +220,34 @@ func RestoreDatabase(backupPath, fileName string, fromStdIn, force bool) (err er
 
 	tables := entity.Entities
 
-	var cmd *exec.Cmd
-
-	switch c.DatabaseDriver() {
-	case config.MySQL, config.MariaDB:
-		// Connect via Unix Domain Socket?
-		if socketName := c.DatabaseServer(); strings.HasPrefix(socketName, "/") {
-			cmd = exec.Command( // #nosec G204 database connection parameters from config
-				c.MariadbBin(),
-				"--protocol", "socket",
-				"-S", socketName,
-				"-u", c.DatabaseUser(),
-				"-p"+c.DatabasePassword(),
-				"-f",
-				c.DatabaseName(),
-			)
-		} else if c.DatabaseSsl() {
-			// see https://mariadb.org/mission-impossible-zero-configuration-ssl/
-			log.Infof("restore: server supports zero-configuration ssl")
-
-			cmd = exec.Command( // #nosec G204 database connection parameters from config
-				c.MariadbBin(),
-				"--protocol", "tcp",
-				"-h", c.DatabaseHost(),
-				"-P", c.DatabasePortString(),
-				"-u", c.DatabaseUser(),
-				"-p"+c.DatabasePassword(),
-				"-f",
-				c.DatabaseName(),
-			)
-		} else {
-			// see https://mariadb.org/mission-impossible-zero-configuration-ssl/
-			log.Infof("restore: zero-configuration ssl not supported by the server")
-
-			cmd = exec.Command( // #nosec G204 database connection parameters from config
-				c.MariadbBin(),
-				"--protocol", "tcp",
-				"--skip-ssl",
-				"-h", c.DatabaseHost(),
-				"-P", c.DatabasePortString(),
-				"-u", c.DatabaseUser(),
-				"-p"+c.DatabasePassword(),
-				"-f",
-				c.DatabaseName(),
-			)
-		}
-	case config.SQLite3:
+	// Restore target, i.e. the database name or SQLite file.
+	target := c.DatabaseName()
+
+	if c.DatabaseDriver() == config.SQLite3 {
 		log.Infoln("restore: dropping existing sqlite database tables")
 		tables.Drop(c.Db())
-		cmd = exec.Command( // #nosec G204 sqlite restore uses configured binary and db path
-			c.SqliteBin(),
-			c.DatabaseFile(),
-		)
-	default:
-		return fmt.Errorf("unsupported database type: %s", c.DatabaseDriver())
+		target = c.DatabaseFile()
 	}
 
-	// Read from stdin or file.
-	var f *os.File
+	cmd, err := restoreCommand(c, c.DatabaseDriver(), target)
+
+	if err != nil {
+		return err
+	}
+
+	// Read from the backup file, or the buffered stdin.
+	f, err := os.OpenFile(fileName, os.O_RDONLY, 0) // #nosec G304 backup path validated by configuration
+
+	if err != nil {
+		return fmt.Errorf("failed to open %s: %s", clean.Log(fileName), err)
+	}
+
+	defer f.Close()
+
 	if fromStdIn {
 		log.Infof("restore: restoring database backup from stdin")
-		f = os.Stdin
-		// #nosec G304 backup path validated by configuration
-	} else if f, err = os.OpenFile(fileName, os.O_RDONLY, 0); err != nil {
-		return fmt.Errorf("failed to open %s: %s", clean.Log(fileName), err)
 	} else {
 		log.Infof("restore: restoring database backup from %s", clean.Log(filepath.Base(fileName)))
-		defer f.Close()
 	}
 
 	var stderr bytes.Buffer
@@ -330,11 +260,12 @@ func RestoreDatabase(backupPath, fileName string, fromStdIn, force bool) (err er
 		return fmt.Errorf("restore: failed to create stdin pipe: %w", err)
 	}
 
+	// Plain, compressed, and archived dumps are read transparently.
+	copyErr := make(chan error, 1)
+
 	go func() {
 		defer stdin.Close()
-		if _, err = io.Copy(stdin, f); err != nil {
-			log.Errorf(err.Error())
-		}
+		copyErr <- copyDump(stdin, f, dumpDir)
 	}()
 
 	// Log exact command for debugging in trace mode.
@@ -349,9 +280,172 @@ func RestoreDatabase(backupPath, fileName string, fromStdIn, force bool) (err er
 		}
 
 		return cmdErr
+	} else if err = <-copyErr; err != nil {
+		log.Errorf("restore: failed to restore index database")
+		return err
 	} else {
 		log.Infof("restore: index database successfully restored")
 	}
 
 	return nil
 }
+
+// bufferStdin copies a database backup from stdin to a temporary file in the specified path and returns its name.
+func bufferStdin(tempPath string) (fileName string, err error) {
+	if err = fs.MkdirAll(tempPath); err != nil {
+		return "", err
+	}
+
+	f, err := os.CreateTemp(tempPath, "restore-*.sql")
+
+	if err != nil {
+		return "", err
+	}
+
+	defer f.Close()
+
+	if _, err = io.Copy(f, os.Stdin); err != nil {
+		_ = os.Remove(f.Name())
+		return "", fmt.Errorf("failed to read backup from stdin (%s)", err)
+	}
+
+	return f.Name(), nil
+}
+
+// verifyDump reads the database backup with the specified file name and returns an error if it cannot be
+// restored, e.g. because the checksum of an archived table dump does not match or the base archive is missing.
+func verifyDump(fileName, dir string) error {
+	f, err := os.Open(fileName) // #nosec G304 backup path validated by caller
+
+	if err != nil {
+		return fmt.Errorf("failed to open %s (%s)", clean.Log(filepath.Base(fileName)), err)
+	}
+
+	defer f.Close()
+
+	return copyDump(io.Discard, f, dir)
+}
+
+// findBackups returns the database backup files and archives in the specified path, sorted by name.
+func findBackups(backupPath string) ([]string, error) {
+	files, err := filepath.Glob(filepath.Join(regexp.QuoteMeta(backupPath), SqlBackupFileNamePattern))
+
+	if err != nil {
+		return files, err
+	}
+
+	archives, err := findArchives(backupPath)
+
+	if err != nil {
+		return files, err
+	}
+
+	files = append(files, archives...)
+
+	sort.Slice(files, func(i, j int) bool {
+		return filepath.Base(files[i]) < filepath.Base(files[j])
+	})
+
+	return files, nil
+}
+
+// copyDump writes the SQL statements of a plain, compressed, or archived database backup to w. The
+// checksums of archived table dumps are verified and the base archive of incremental backups is
+// read from the specified directory.
+func copyDump(w io.Writer, r io.Reader, dir string) error {
+	br, release, err := decompressReader(r)
+
+	if err != nil {
+		return err
+	}
+
+	defer release()
+
+	if !isTar(br) {
+		_, err = io.Copy(w, br)
+		return err
+	}
+
+	tr := tar.NewReader(br)
+	m, err := readManifest(tr)
+
+	if err != nil {
+		return err
+	} else if err = copyTables(w, tr, m, false); err != nil {
+		return err
+	} else if !m.Incremental() {
+		return nil
+	} else if dir == "" {
+		return fmt.Errorf("base backup %s is required to restore an incremental backup", clean.Log(m.Base))
+	}
+
+	f, err := os.Open(filepath.Join(dir, filepath.Base(m.Base))) // #nosec G304 base archive is located in the backup path
+
+	if err != nil {
+		return fmt.Errorf("failed to open base backup %s (%s)", clean.Log(m.Base), err)
+	}
+
+	defer f.Close()
+
+	baseReader, baseRelease, err := decompressReader(f)
+
+	if err != nil {
+		return err
+	}
+
+	defer baseRelease()
+
+	baseArchive := tar.NewReader(baseReader)
+
+	if _, err = readManifest(baseArchive); err != nil {
+		return err
+	}
+
+	return copyTables(w, baseArchive, m, true)
+}
+
+// copyTables copies the table dumps listed in the manifest from the archive to w and verifies
+// their checksums. If base is true, only the tables stored in the base archive are copied.
+func copyTables(w io.Writer, tr *tar.Reader, m *Manifest, base bool) error {
+	expected := 0
+
+	for _, t := range m.Tables {
+		if t.Base == base {
+			expected++
+		}
+	}
+
+	found := 0
+
+	for {
+		header, err := tr.Next()
+
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return err
+		}
+
+		entry := m.Entry(header.Name)
+
+		if entry == nil || entry.Table == "" || entry.Base != base {
+			continue
+		}
+
+		hash := sha256.New()
+
+		if _, err = io.Copy(io.MultiWriter(w, hash), tr); err != nil {
+			return err
+		} else if sum := hex.EncodeToString(hash.Sum(nil)); sum != entry.Checksum {
+			return fmt.Errorf("checksum of %s does not match", clean.Log(header.Name))
+		}
+
+		found++
+	}
+
+	if found < expected {
+		return fmt.Errorf("backup is incomplete, found %d of %d tables", found, expected)
+	}
+
+	return nil
+}
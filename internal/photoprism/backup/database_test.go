@@ -7,6 +7,8 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism/get"
 	"github.com/photoprism/photoprism/pkg/fs"
 )
 
@@ -50,3 +52,45 @@ func TestDatabase(t *testing.T) {
 		}
 	})
 }
+
+func TestRestoreDatabase(t *testing.T) {
+	t.Run("ChecksumMismatch", func(t *testing.T) {
+		backupPath := t.TempDir()
+		dumpPath := t.TempDir()
+		dump := []byte("DROP TABLE IF EXISTS photos;\n")
+
+		if err := os.WriteFile(filepath.Join(dumpPath, "photos.sql"), dump, fs.ModeFile); err != nil {
+			t.Fatal(err)
+		}
+
+		m := NewManifest(config.SQLite3, config.BackupCompressionNone)
+		m.Tables = append(m.Tables, ManifestEntry{
+			Name:     ArchiveDatabaseDir + "/photos.sql",
+			Table:    "photos",
+			Size:     int64(len(dump)),
+			Checksum: "0000000000000000000000000000000000000000000000000000000000000000",
+		})
+
+		if err := writeArchive(filepath.Join(backupPath, "2025-01-01.tar"), m, dumpPath, nil); err != nil {
+			t.Fatal(err)
+		}
+
+		count := func() (n int) {
+			get.Config().Db().Table("photos").Count(&n)
+			return n
+		}
+
+		photos := count()
+
+		assert.Positive(t, photos)
+
+		err := RestoreDatabase(backupPath, "2025-01-01.tar", false, true)
+
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "checksum")
+		}
+
+		// Existing tables must not have been dropped.
+		assert.Equal(t, photos, count())
+	})
+}
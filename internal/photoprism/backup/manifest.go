@@ -0,0 +1,141 @@
+package backup
+
+import (
+	"archive/tar"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// ErrNoArchive is returned when a backup file is a plain SQL dump without a manifest.
+var ErrNoArchive = errors.New("not a backup archive")
+
+// Manifest describes the contents of a backup archive. It is stored as the first archive entry so
+// that it can be read without scanning the whole archive.
+type Manifest struct {
+	Version     int             `json:"Version"`
+	Created     time.Time       `json:"Created"`
+	Driver      string          `json:"Driver"`
+	Compression string          `json:"Compression"`
+	Base        string          `json:"Base,omitempty"`
+	Tables      []ManifestEntry `json:"Tables"`
+	Files       []ManifestEntry `json:"Files,omitempty"`
+}
+
+// ManifestEntry describes a table dump or file in a backup archive. Entries with Base set to true
+// are not included in the archive because they have not changed since the base backup.
+type ManifestEntry struct {
+	Name     string `json:"Name"`
+	Table    string `json:"Table,omitempty"`
+	Rows     int64  `json:"Rows,omitempty"`
+	Size     int64  `json:"Size"`
+	Checksum string `json:"Checksum"`
+	Base     bool   `json:"Base,omitempty"`
+}
+
+// NewManifest returns a new backup archive manifest.
+func NewManifest(driver, compression string) *Manifest {
+	return &Manifest{
+		Version:     ManifestVersion,
+		Created:     time.Now().UTC().Truncate(time.Second),
+		Driver:      driver,
+		Compression: compression,
+		Tables:      []ManifestEntry{},
+	}
+}
+
+// Incremental checks if the archive only contains the tables that have changed since the base backup.
+func (m *Manifest) Incremental() bool {
+	return m.Base != ""
+}
+
+// Changed returns the number of table dumps included in the archive, i.e. that are not stored in the base backup.
+func (m *Manifest) Changed() (n int) {
+	for _, t := range m.Tables {
+		if !t.Base {
+			n++
+		}
+	}
+
+	return n
+}
+
+// Table returns the manifest entry of the specified table, or nil if it was not found.
+func (m *Manifest) Table(name string) *ManifestEntry {
+	for i := range m.Tables {
+		if m.Tables[i].Table == name {
+			return &m.Tables[i]
+		}
+	}
+
+	return nil
+}
+
+// Entry returns the manifest entry with the specified archive name, or nil if it was not found.
+func (m *Manifest) Entry(name string) *ManifestEntry {
+	for i := range m.Tables {
+		if m.Tables[i].Name == name {
+			return &m.Tables[i]
+		}
+	}
+
+	for i := range m.Files {
+		if m.Files[i].Name == name {
+			return &m.Files[i]
+		}
+	}
+
+	return nil
+}
+
+// ReadManifest reads the manifest from the backup archive with the specified file name.
+func ReadManifest(fileName string) (*Manifest, error) {
+	f, err := os.Open(fileName) // #nosec G304 backup path validated by caller
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer f.Close()
+
+	br, release, err := decompressReader(f)
+
+	if err != nil {
+		return nil, err
+	}
+
+	defer release()
+
+	if !isTar(br) {
+		return nil, fmt.Errorf("%s is %w", clean.Log(filepath.Base(fileName)), ErrNoArchive)
+	}
+
+	return readManifest(tar.NewReader(br))
+}
+
+// readManifest reads the manifest from the first entry of a backup archive.
+func readManifest(tr *tar.Reader) (*Manifest, error) {
+	header, err := tr.Next()
+
+	if err != nil {
+		return nil, err
+	} else if header.Name != ManifestFileName {
+		return nil, errors.New("missing backup manifest")
+	}
+
+	m := &Manifest{}
+
+	if err = json.NewDecoder(io.LimitReader(tr, header.Size)).Decode(m); err != nil {
+		return nil, fmt.Errorf("invalid backup manifest (%s)", err)
+	} else if m.Version < 1 || m.Version > ManifestVersion {
+		return nil, fmt.Errorf("unsupported backup manifest version %d", m.Version)
+	}
+
+	return m, nil
+}
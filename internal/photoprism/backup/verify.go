@@ -0,0 +1,367 @@
+package backup
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/dustin/go-humanize/english"
+	"github.com/jinzhu/gorm"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/photoprism/get"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// VerifiedTable represents the verification result of a table in a database backup.
+type VerifiedTable struct {
+	Table    string
+	Rows     int64
+	Restored int64
+}
+
+// OK checks if the number of restored rows matches the manifest.
+func (t VerifiedTable) OK() bool {
+	return t.Rows < 0 || t.Rows == t.Restored
+}
+
+// Verify checks if the database backup with the specified file name is complete and, in the case
+// of archives, whether the checksums and row counts match the manifest. SQLite backups are restored
+// into a scratch database, while the rows in MariaDB backups are counted by parsing the dump, so that
+// no database needs to be created on the server. If no file name is passed, the most recent backup
+// in the path is verified.
+func Verify(backupPath, fileName string) (result []VerifiedTable, err error) {
+	c := get.Config()
+
+	if fileName == "" {
+		if backupPath == "" {
+			backupPath = c.BackupDatabasePath()
+		}
+
+		files, globErr := findBackups(backupPath)
+
+		if globErr != nil {
+			return result, globErr
+		} else if len(files) == 0 {
+			return result, fmt.Errorf("failed to find a backup in %s", clean.Log(backupPath))
+		}
+
+		fileName = files[len(files)-1]
+	} else if backupPath != "" && !filepath.IsAbs(fileName) {
+		fileName = filepath.Join(backupPath, fileName)
+	}
+
+	if !fs.FileExistsNotEmpty(fileName) {
+		return result, fmt.Errorf("failed to find %s", clean.Log(fileName))
+	}
+
+	// Plain SQL dumps do not have a manifest and are expected
+	// to match the currently configured database driver.
+	driver := c.DatabaseDriver()
+	manifest, err := ReadManifest(fileName)
+
+	if errors.Is(err, ErrNoArchive) {
+		manifest = nil
+	} else if err != nil {
+		return result, err
+	} else {
+		driver = manifest.Driver
+	}
+
+	log.Infof("backup: verifying %s", clean.Log(filepath.Base(fileName)))
+
+	var counts map[string]int64
+
+	switch driver {
+	case config.SQLite3:
+		counts, err = verifySqlite(c, fileName)
+	case config.MySQL, config.MariaDB:
+		counts, err = verifyMariaDB(fileName)
+	default:
+		return result, fmt.Errorf("unsupported database type: %s", driver)
+	}
+
+	if err != nil {
+		return result, err
+	}
+
+	// Compare the restored row counts with the manifest.
+	if manifest == nil {
+		for table, n := range counts {
+			result = append(result, VerifiedTable{Table: table, Rows: -1, Restored: n})
+		}
+	} else {
+		for _, t := range manifest.Tables {
+			result = append(result, VerifiedTable{Table: t.Table, Rows: t.Rows, Restored: counts[t.Table]})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Table < result[j].Table
+	})
+
+	mismatch := 0
+
+	for _, t := range result {
+		if !t.OK() {
+			mismatch++
+			log.Warnf("backup: table %s has %d rows, expected %d", clean.Log(t.Table), t.Restored, t.Rows)
+		}
+	}
+
+	if mismatch > 0 {
+		return result, fmt.Errorf("row counts of %s do not match", english.Plural(mismatch, "table", "tables"))
+	}
+
+	log.Infof("backup: %s successfully verified", clean.Log(filepath.Base(fileName)))
+
+	return result, nil
+}
+
+// verifySqlite restores the backup into a temporary SQLite database and returns the table row counts.
+func verifySqlite(c *config.Config, fileName string) (counts map[string]int64, err error) {
+	if err = fs.MkdirAll(c.TempPath()); err != nil {
+		return counts, err
+	}
+
+	tempDir, err := os.MkdirTemp(c.TempPath(), "backup-verify-")
+
+	if err != nil {
+		return counts, err
+	}
+
+	defer os.RemoveAll(tempDir)
+
+	scratchFile := filepath.Join(tempDir, "index.db")
+
+	if err = restoreScratch(c, config.SQLite3, scratchFile, fileName); err != nil {
+		return counts, err
+	}
+
+	db, err := gorm.Open(config.SQLite3, scratchFile)
+
+	if err != nil {
+		return counts, err
+	}
+
+	defer db.Close()
+
+	return tableCounts(db, "SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%'", "")
+}
+
+// verifyMariaDB counts the rows in the MariaDB backup without restoring it, so that no
+// scratch database needs to be created on the database server.
+func verifyMariaDB(fileName string) (counts map[string]int64, err error) {
+	f, err := os.Open(fileName) // #nosec G304 backup path validated by caller
+
+	if err != nil {
+		return counts, err
+	}
+
+	defer f.Close()
+
+	w := newDumpRowCounter()
+
+	if err = copyDump(w, f, filepath.Dir(fileName)); err != nil {
+		return counts, err
+	}
+
+	return w.counts, nil
+}
+
+// dumpRowCounter counts the rows in the extended INSERT statements of a MariaDB dump.
+type dumpRowCounter struct {
+	counts map[string]int64
+	line   []byte
+	table  string
+	depth  int
+	quote  byte
+	escape bool
+}
+
+// newDumpRowCounter returns a new MariaDB dump row counter.
+func newDumpRowCounter() *dumpRowCounter {
+	return &dumpRowCounter{counts: make(map[string]int64)}
+}
+
+// Write implements io.Writer and updates the row counts.
+func (w *dumpRowCounter) Write(p []byte) (int, error) {
+	for _, b := range p {
+		if w.table == "" {
+			w.header(b)
+		} else {
+			w.values(b)
+		}
+	}
+
+	return len(p), nil
+}
+
+// header parses statements until the values of an INSERT statement begin.
+func (w *dumpRowCounter) header(b byte) {
+	if b == '\n' {
+		if name, ok := quotedName(w.line, "CREATE TABLE `"); ok {
+			w.counts[name] += 0
+		}
+
+		w.line = w.line[:0]
+		return
+	}
+
+	// Statement headers are short, so long lines can be skipped.
+	if len(w.line) < 4096 {
+		w.line = append(w.line, b)
+	}
+
+	if !bytes.HasSuffix(w.line, []byte(" VALUES ")) {
+		return
+	}
+
+	if name, ok := quotedName(w.line, "INSERT INTO `"); ok {
+		w.table = name
+		w.counts[name] += 0
+		w.line = w.line[:0]
+	}
+}
+
+// values counts the top-level value tuples of an INSERT statement until it ends.
+func (w *dumpRowCounter) values(b byte) {
+	switch {
+	case w.escape:
+		w.escape = false
+	case w.quote != 0:
+		if b == '\\' {
+			w.escape = true
+		} else if b == w.quote {
+			w.quote = 0
+		}
+	case b == '\'' || b == '"':
+		w.quote = b
+	case b == '(':
+		if w.depth == 0 {
+			w.counts[w.table]++
+		}
+
+		w.depth++
+	case b == ')':
+		w.depth--
+	case b == ';' && w.depth == 0:
+		w.table = ""
+	}
+}
+
+// quotedName returns the backtick-quoted name that follows the prefix.
+func quotedName(line []byte, prefix string) (string, bool) {
+	if !bytes.HasPrefix(line, []byte(prefix)) {
+		return "", false
+	}
+
+	name, _, found := bytes.Cut(line[len(prefix):], []byte("`"))
+
+	if !found || len(name) == 0 {
+		return "", false
+	}
+
+	return string(name), true
+}
+
+// restoreScratch restores the backup file into the specified scratch database.
+func restoreScratch(c *config.Config, driver, target, fileName string) error {
+	cmd, err := restoreCommand(c, driver, target)
+
+	if err != nil {
+		return err
+	}
+
+	f, err := os.Open(fileName) // #nosec G304 backup path validated by caller
+
+	if err != nil {
+		return err
+	}
+
+	defer f.Close()
+
+	stdin, err := cmd.StdinPipe()
+
+	if err != nil {
+		return err
+	}
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	copyErr := make(chan error, 1)
+
+	go func() {
+		defer stdin.Close()
+		copyErr <- copyDump(stdin, f, filepath.Dir(fileName))
+	}()
+
+	// Log exact command for debugging in trace mode.
+	log.Trace(cmd.String())
+
+	cmdErr := cmd.Run()
+
+	if err = <-copyErr; err != nil {
+		return err
+	} else if errStr := strings.TrimSpace(stderr.String()); errStr != "" {
+		return errors.New(errStr)
+	}
+
+	return cmdErr
+}
+
+// tableCounts returns the number of rows in each table returned by the specified query.
+func tableCounts(db *gorm.DB, stmt, schema string) (counts map[string]int64, err error) {
+	var tables []string
+
+	args := []interface{}{}
+
+	if schema != "" {
+		args = append(args, schema)
+	}
+
+	rows, err := db.Raw(stmt, args...).Rows()
+
+	if err != nil {
+		return counts, err
+	}
+
+	for rows.Next() {
+		var name string
+
+		if err = rows.Scan(&name); err != nil {
+			rows.Close()
+			return counts, err
+		}
+
+		tables = append(tables, name)
+	}
+
+	rows.Close()
+
+	counts = make(map[string]int64, len(tables))
+
+	for _, table := range tables {
+		var n int64
+
+		tableName := table
+
+		if schema != "" {
+			tableName = schema + "." + table
+		}
+
+		if err = db.Table(tableName).Count(&n).Error; err != nil {
+			return counts, err
+		}
+
+		counts[table] = n
+	}
+
+	return counts, nil
+}
@@ -0,0 +1,35 @@
+package backup
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDumpRowCounter(t *testing.T) {
+	t.Run("ExtendedInserts", func(t *testing.T) {
+		dump := "CREATE TABLE `albums` (\n  `id` int(10) NOT NULL\n);\n" +
+			"CREATE TABLE `errors` (\n  `id` int(10) NOT NULL\n);\n" +
+			"INSERT INTO `albums` VALUES (1,'Foo (Bar)'),(2,'It\\'s ''quoted'', ok;'),(3,NULL);\n" +
+			"INSERT INTO `albums` VALUES (4,\"(x)\");\n" +
+			"INSERT INTO `photos` (`id`, `title`) VALUES (1,'a\\\\'),(2,'b');\n"
+
+		w := newDumpRowCounter()
+
+		// Write the dump in small chunks to check that the state is preserved.
+		for i := 0; i < len(dump); i += 7 {
+			end := min(i+7, len(dump))
+			n, err := w.Write([]byte(dump[i:end]))
+			assert.NoError(t, err)
+			assert.Equal(t, end-i, n)
+		}
+
+		assert.Equal(t, map[string]int64{"albums": 4, "errors": 0, "photos": 2}, w.counts)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		w := newDumpRowCounter()
+		_, err := w.Write([]byte("-- MariaDB dump\n"))
+		assert.NoError(t, err)
+		assert.Empty(t, w.counts)
+	})
+}
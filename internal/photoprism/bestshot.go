@@ -0,0 +1,295 @@
+package photoprism
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"sort"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/ai/face"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/media/phash"
+	"github.com/photoprism/photoprism/pkg/media/quality"
+)
+
+// Weights of the individual scores in the total score of a shot.
+var (
+	ShotSharpnessWeight = 0.5
+	ShotExposureWeight  = 0.2
+	ShotEyesWeight      = 0.3
+)
+
+// ShotScore represents the estimated technical quality of a picture, with values between 0 and 1.
+type ShotScore struct {
+	Sharpness float64 `json:"Sharpness"`
+	Exposure  float64 `json:"Exposure"`
+	Eyes      float64 `json:"Eyes"`
+	Faces     int     `json:"Faces"`
+	Total     float64 `json:"Total"`
+}
+
+// BestShotPhoto represents a picture in a burst.
+type BestShotPhoto struct {
+	query.SimilarPhoto
+	Score ShotScore `json:"Score"`
+}
+
+// BestShotResult represents a burst of pictures and its best shot.
+type BestShotResult struct {
+	Best   string          `json:"Best"`
+	Photos []BestShotPhoto `json:"Photos"`
+	Action string          `json:"Action"`
+}
+
+// BestShot represents a worker that selects the best shot in bursts of visually similar pictures.
+type BestShot struct {
+	conf *config.Config
+}
+
+// NewBestShot returns a new BestShot worker.
+func NewBestShot(conf *config.Config) *BestShot {
+	return &BestShot{conf: conf}
+}
+
+// Start finds bursts of visually similar pictures, scores their sharpness, exposure, and whether eyes are open,
+// and then stacks or archives all pictures except the best shot if permitted by the policy.
+func (w *BestShot) Start(opt BestShotOptions) (results []BestShotResult, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("bestshot: %s (panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	results = []BestShotResult{}
+
+	switch opt.Policy {
+	case "", config.BestShotOff, config.BestShotStack, config.BestShotArchive:
+	default:
+		return results, fmt.Errorf("bestshot: unknown policy %s", clean.Log(opt.Policy))
+	}
+
+	// Check if a worker is already running.
+	if opt.Apply() {
+		if err = mutex.IndexWorker.Start(); err != nil {
+			log.Warnf("bestshot: %s", err.Error())
+			return results, err
+		}
+
+		defer mutex.IndexWorker.Stop()
+	}
+
+	start := time.Now()
+
+	bursts, err := w.Bursts(opt.Interval, opt.Distance)
+
+	if err != nil {
+		return results, err
+	}
+
+	resolved := 0
+
+	for _, burst := range bursts {
+		if opt.Apply() && mutex.IndexWorker.Canceled() {
+			return results, errors.New("bestshot: worker canceled")
+		}
+
+		// Skip bursts that have not changed since the last run.
+		if !opt.Since.IsZero() && !burstUpdatedSince(burst, opt.Since) {
+			continue
+		}
+
+		result := BestShotResult{Photos: make([]BestShotPhoto, len(burst))}
+		uids := make([]string, len(burst))
+		scored := true
+
+		for i, p := range burst {
+			score, scoreErr := w.Score(p)
+
+			if scoreErr != nil {
+				log.Debugf("bestshot: %s in %s (score)", clean.Error(scoreErr), clean.Log(p.PhotoUID))
+				scored = false
+			}
+
+			result.Photos[i] = BestShotPhoto{SimilarPhoto: p, Score: score}
+			uids[i] = p.PhotoUID
+		}
+
+		result.Best = BestShotOf(result.Photos).PhotoUID
+
+		// Never change pictures if the quality of a shot could not be estimated.
+		if opt.Apply() && scored {
+			if _, photos, resolveErr := NewSimilar(w.conf).Resolve(uids, result.Best, opt.Policy); resolveErr != nil {
+				log.Errorf("bestshot: %s", clean.Error(resolveErr))
+			} else if len(photos) > 0 {
+				result.Action = opt.Policy
+				resolved += len(photos)
+			}
+		}
+
+		results = append(results, result)
+	}
+
+	if resolved > 0 {
+		log.Infof("bestshot: %s %s [%s]", opt.Policy, english.Plural(resolved, "picture", "pictures"), time.Since(start))
+	} else {
+		log.Debugf("bestshot: found %s [%s]", english.Plural(len(results), "burst", "bursts"), time.Since(start))
+	}
+
+	return results, nil
+}
+
+// Bursts returns groups of visually similar pictures that were taken with the same camera in quick succession.
+// If the interval or distance is 0, the configured defaults are used.
+func (w *BestShot) Bursts(interval time.Duration, distance int) (bursts [][]query.SimilarPhoto, err error) {
+	if interval <= 0 {
+		interval = w.conf.BestShotInterval()
+	}
+
+	if distance <= 0 {
+		distance = w.conf.SimilarDistance()
+	}
+
+	photos, err := query.SimilarPhotos()
+
+	if err != nil {
+		return bursts, err
+	}
+
+	// Pictures are sorted by the time they were taken,
+	// so there can be one open burst per camera.
+	open := make(map[uint][]query.SimilarPhoto)
+
+	flush := func(burst []query.SimilarPhoto) {
+		if len(burst) > 1 {
+			bursts = append(bursts, burst)
+		}
+	}
+
+	for _, p := range photos {
+		// Ignore pictures without a reliable date.
+		if p.TakenSrc == entity.SrcAuto {
+			continue
+		}
+
+		hash, parseErr := phash.Parse(p.FilePhash)
+
+		if parseErr != nil {
+			log.Debugf("bestshot: %s in %s", parseErr, clean.Log(p.PhotoUID))
+			continue
+		}
+
+		burst := open[p.CameraID]
+
+		if n := len(burst); n > 0 && p.TakenAt.Sub(burst[n-1].TakenAt) <= interval {
+			if first, firstErr := phash.Parse(burst[0].FilePhash); firstErr == nil && first.Similar(hash, distance) {
+				open[p.CameraID] = append(burst, p)
+				continue
+			}
+		}
+
+		flush(burst)
+		open[p.CameraID] = []query.SimilarPhoto{p}
+	}
+
+	for _, burst := range open {
+		flush(burst)
+	}
+
+	sort.Slice(bursts, func(i, j int) bool {
+		return bursts[i][0].TakenAt.Before(bursts[j][0].TakenAt)
+	})
+
+	return bursts, nil
+}
+
+// Score estimates the technical quality of a picture based on the fit_720 thumbnail of its primary file.
+func (w *BestShot) Score(p query.SimilarPhoto) (score ShotScore, err error) {
+	m, err := NewMediaFile(FileName(p.FileRoot, p.FileName))
+
+	if err != nil {
+		return score, err
+	} else if !m.IsPreviewImage() {
+		return score, fmt.Errorf("%s is not a jpeg", clean.Log(m.BaseName()))
+	}
+
+	thumbPath := w.conf.ThumbCachePath()
+	img, err := m.Resample(thumbPath, thumb.Fit720)
+
+	if err != nil {
+		return score, err
+	}
+
+	score.Sharpness = quality.Sharpness(img)
+	score.Exposure = quality.Exposure(img)
+
+	// Pictures without faces that are large enough to locate the eyes are not penalized.
+	score.Eyes = 1
+
+	if !w.conf.DisableFaces() {
+		if thumbName, thumbErr := m.Thumbnail(thumbPath, thumb.Fit720); thumbErr != nil {
+			log.Debugf("bestshot: %s in %s (thumbnail)", clean.Error(thumbErr), clean.Log(m.BaseName()))
+		} else if faces, detectErr := face.DetectEyes(thumbName, w.conf.FaceSize()); detectErr != nil {
+			log.Debugf("bestshot: %s in %s (detect eyes)", clean.Error(detectErr), clean.Log(m.BaseName()))
+		} else {
+			eyesOpen := 0
+
+			for _, f := range faces {
+				if !f.HasLandmarks() {
+					continue
+				}
+
+				score.Faces++
+
+				if f.EyesFound() {
+					eyesOpen++
+				}
+			}
+
+			if score.Faces > 0 {
+				score.Eyes = float64(eyesOpen) / float64(score.Faces)
+			}
+		}
+	}
+
+	score.Total = ShotSharpnessWeight*score.Sharpness + ShotExposureWeight*score.Exposure + ShotEyesWeight*score.Eyes
+
+	return score, nil
+}
+
+// BestShotOf returns the picture with the highest total score, or the highest resolution if the scores are equal.
+func BestShotOf(photos []BestShotPhoto) (best BestShotPhoto) {
+	for i, p := range photos {
+		switch {
+		case i == 0:
+			best = p
+		case p.Score.Total != best.Score.Total:
+			if p.Score.Total > best.Score.Total {
+				best = p
+			}
+		case p.Pixels() > best.Pixels():
+			best = p
+		}
+	}
+
+	return best
+}
+
+// burstUpdatedSince checks if a picture in the burst has been updated since the specified time.
+func burstUpdatedSince(burst []query.SimilarPhoto, since time.Time) bool {
+	for _, p := range burst {
+		if !p.UpdatedAt.Before(since) {
+			return true
+		}
+	}
+
+	return false
+}
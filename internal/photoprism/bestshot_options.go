@@ -0,0 +1,25 @@
+package photoprism
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+// BestShotOptions controls how the best shots in bursts are selected.
+type BestShotOptions struct {
+	Policy   string        // What to do with the other pictures of a burst: off, stack, or archive.
+	Interval time.Duration // Maximum time between consecutive pictures of a burst.
+	Distance int           // Maximum perceptual hash distance between pictures of a burst.
+	Since    time.Time     // Only select the best shot in bursts with pictures updated since then.
+}
+
+// Apply checks if the other pictures of a burst should be stacked or archived.
+func (o BestShotOptions) Apply() bool {
+	return o.Policy == config.BestShotStack || o.Policy == config.BestShotArchive
+}
+
+// BestShotOptionsDefault returns new best shot options with default values.
+func BestShotOptionsDefault() BestShotOptions {
+	return BestShotOptions{Policy: config.BestShotOff}
+}
@@ -0,0 +1,164 @@
+package photoprism
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+)
+
+// createBurstPhoto adds a photo with an example image as primary file that was taken at the specified time.
+func createBurstPhoto(t *testing.T, fileName, hash string, takenAt time.Time) *entity.Photo {
+	t.Helper()
+
+	photo := &entity.Photo{PhotoName: "Burst_" + fileName, PhotoPath: "burst", TakenAt: takenAt, TakenAtLocal: takenAt, TakenSrc: entity.SrcMeta}
+
+	if err := photo.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	file := &entity.File{
+		PhotoID:     photo.ID,
+		PhotoUID:    photo.PhotoUID,
+		FileName:    fileName,
+		FileRoot:    entity.RootExamples,
+		FileHash:    "Burst_" + fileName,
+		FileWidth:   1000,
+		FileHeight:  500,
+		FilePrimary: true,
+		FilePhash:   hash,
+	}
+
+	if err := file.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	return photo
+}
+
+func TestBestShot(t *testing.T) {
+	conf := config.TestConfig()
+	w := NewBestShot(conf)
+	since := time.Now().Add(-1 * time.Second)
+	takenAt := time.Date(1860, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	first := createBurstPhoto(t, "beach_sand.jpg", "0f0f0f0f0f0f0f0f", takenAt)
+	second := createBurstPhoto(t, "beach_wood.jpg", "0f0f0f0f0f0f0f0e", takenAt.Add(2*time.Second))
+	late := createBurstPhoto(t, "cat_brown.jpg", "0f0f0f0f0f0f0f0f", takenAt.Add(time.Minute))
+
+	t.Run("Bursts", func(t *testing.T) {
+		bursts, err := w.Bursts(0, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var found []query.SimilarPhoto
+
+		for _, burst := range bursts {
+			if burst[0].PhotoUID == first.PhotoUID {
+				found = burst
+			}
+		}
+
+		if assert.Len(t, found, 2) {
+			assert.Equal(t, second.PhotoUID, found[1].PhotoUID)
+		}
+
+		for _, burst := range bursts {
+			for _, p := range burst {
+				assert.NotEqual(t, late.PhotoUID, p.PhotoUID)
+			}
+		}
+	})
+	t.Run("Score", func(t *testing.T) {
+		score, err := w.Score(query.SimilarPhoto{FileRoot: entity.RootExamples, FileName: "beach_sand.jpg"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Greater(t, score.Sharpness, 0.0)
+		assert.Greater(t, score.Exposure, 0.0)
+		assert.Equal(t, 1.0, score.Eyes)
+		assert.Greater(t, score.Total, 0.0)
+		assert.LessOrEqual(t, score.Total, 1.0)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := w.Score(query.SimilarPhoto{FileRoot: entity.RootExamples, FileName: "burst-not-found.jpg"})
+		assert.Error(t, err)
+	})
+	t.Run("UnknownPolicy", func(t *testing.T) {
+		_, err := w.Start(BestShotOptions{Policy: "delete"})
+		assert.Error(t, err)
+	})
+	t.Run("Off", func(t *testing.T) {
+		results, err := w.Start(BestShotOptions{Policy: config.BestShotOff, Since: since})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, results, 1) {
+			assert.Len(t, results[0].Photos, 2)
+			assert.Contains(t, []string{first.PhotoUID, second.PhotoUID}, results[0].Best)
+			assert.Equal(t, "", results[0].Action)
+		}
+	})
+	t.Run("Since", func(t *testing.T) {
+		results, err := w.Start(BestShotOptions{Policy: config.BestShotOff, Since: time.Now().Add(time.Hour)})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+	t.Run("Stack", func(t *testing.T) {
+		results, err := w.Start(BestShotOptions{Policy: config.BestShotStack, Since: since})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !assert.Len(t, results, 1) {
+			return
+		}
+
+		assert.Equal(t, config.BestShotStack, results[0].Action)
+
+		best := results[0].Best
+		file, fileErr := query.FileByHash("Burst_beach_wood.jpg")
+
+		if fileErr != nil {
+			t.Fatal(fileErr)
+		}
+
+		assert.Equal(t, best, file.PhotoUID)
+
+		// The burst has been resolved.
+		results, err = w.Start(BestShotOptions{Policy: config.BestShotStack, Since: since})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Empty(t, results)
+	})
+}
+
+func TestBestShotOf(t *testing.T) {
+	photos := []BestShotPhoto{
+		{SimilarPhoto: query.SimilarPhoto{PhotoUID: "a", FileWidth: 100, FileHeight: 100}, Score: ShotScore{Total: 0.5}},
+		{SimilarPhoto: query.SimilarPhoto{PhotoUID: "b", FileWidth: 100, FileHeight: 100}, Score: ShotScore{Total: 0.8}},
+		{SimilarPhoto: query.SimilarPhoto{PhotoUID: "c", FileWidth: 200, FileHeight: 100}, Score: ShotScore{Total: 0.8}},
+		{SimilarPhoto: query.SimilarPhoto{PhotoUID: "d", FileWidth: 400, FileHeight: 400}, Score: ShotScore{Total: 0.7}},
+	}
+
+	assert.Equal(t, "c", BestShotOf(photos).PhotoUID)
+	assert.Equal(t, "", BestShotOf(nil).PhotoUID)
+}
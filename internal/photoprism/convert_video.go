@@ -0,0 +1,125 @@
+package photoprism
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/ffmpeg"
+	"github.com/photoprism/photoprism/internal/ffmpeg/encode"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// ToVideo transcodes a video file to the specified codec. AVC videos are created in the sidecar
+// path, while other codecs are stored in the media cache, as they are only needed for streaming.
+func (w *Convert) ToVideo(f *MediaFile, fileHash string, codec encode.Codec, force bool) (*MediaFile, error) {
+	// Use the existing AVC transcoding workflow, which also supports hardware encoders.
+	if codec == "" || codec == encode.CodecAvc {
+		return w.ToAvc(f, w.conf.FFmpegEncoder(), false, force)
+	}
+
+	// Abort if the source media file is nil.
+	if f == nil {
+		return nil, fmt.Errorf("convert: no media file provided for processing - you may have found a bug")
+	}
+
+	// Sanitized relative filename for use in logs.
+	logFileName := clean.Log(f.RootRelName())
+
+	// Abort if the source media file does not exist or is not a video.
+	if !f.Exists() {
+		return nil, fmt.Errorf("convert: %s not found", logFileName)
+	} else if f.Empty() {
+		return nil, fmt.Errorf("convert: %s is empty", logFileName)
+	} else if !f.IsVideo() {
+		return nil, fmt.Errorf("convert: file type %s of %s cannot be transcoded to %s", f.FileType(), logFileName, codec)
+	}
+
+	if fileHash == "" {
+		fileHash = f.Hash()
+	}
+
+	if !rnd.IsSHA(fileHash) {
+		return nil, fmt.Errorf("convert: invalid file hash %s", clean.Log(fileHash))
+	}
+
+	// Transcoded video filename in the media cache.
+	cacheDir := w.conf.MediaFileCachePath(fileHash)
+	videoName := filepath.Join(cacheDir, fileHash+codec.FileExt())
+
+	// Make sure only one convert command runs at a time.
+	w.cmdMutex.Lock()
+	defer w.cmdMutex.Unlock()
+
+	// Return the transcoded video file if it already exists.
+	if !fs.FileExistsNotEmpty(videoName) {
+		// Do nothing.
+	} else if !force {
+		log.Debugf("convert: %s has already been transcoded to %s", logFileName, codec)
+		return NewMediaFile(videoName)
+	} else if err := os.Remove(videoName); err != nil {
+		return nil, fmt.Errorf("convert: failed removing %s (%s)", clean.Log(filepath.Base(videoName)), err)
+	}
+
+	if err := fs.MkdirAll(cacheDir); err != nil {
+		return nil, fmt.Errorf("convert: failed to create cache folder for %s (%s)", logFileName, err)
+	}
+
+	encoder := codec.Encoder()
+
+	opt, err := w.conf.FFmpegOptions(encoder, w.AvcBitrate(f))
+
+	if err != nil {
+		return nil, fmt.Errorf("convert: failed to transcode %s (%s)", logFileName, err)
+	}
+
+	cmd, _, err := ffmpeg.TranscodeCmd(f.FileName(), videoName, opt)
+
+	if err != nil {
+		return nil, fmt.Errorf("convert: failed to transcode %s (%s)", logFileName, err)
+	}
+
+	// Fetch command output.
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	cmd.Env = append(cmd.Env, fmt.Sprintf("HOME=%s", w.conf.CmdCachePath()))
+
+	log.Infof("%s: transcoding %s to %s", encoder, logFileName, codec)
+
+	// Log exact command for debugging in trace mode.
+	log.Trace(cmd.String())
+
+	// Transcode source media file.
+	start := time.Now()
+
+	if err = cmd.Run(); err != nil {
+		if stderr.String() != "" {
+			err = errors.New(stderr.String())
+		}
+
+		// Log ffmpeg output for debugging.
+		log.Debug(err)
+
+		// Log filename and transcoding time.
+		log.Warnf("%s: failed to transcode %s [%s]", encoder, logFileName, time.Since(start))
+
+		// Remove broken video file.
+		if fs.FileExists(videoName) {
+			if removeErr := os.Remove(videoName); removeErr != nil {
+				log.Errorf("convert: failed to remove %s (%s)", clean.Log(filepath.Base(videoName)), removeErr)
+			}
+		}
+
+		return nil, err
+	}
+
+	// Log filename and transcoding time.
+	log.Infof("%s: created %s [%s]", encoder, filepath.Base(videoName), time.Since(start))
+
+	return NewMediaFile(videoName)
+}
@@ -0,0 +1,97 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/ffmpeg/encode"
+)
+
+// newConvertVideoTest returns a new Convert worker that uses the specified shell script instead of FFmpeg.
+func newConvertVideoTest(t *testing.T, script string) *Convert {
+	t.Helper()
+
+	c := config.NewMinimalTestConfig(t.TempDir())
+	bin := filepath.Join(t.TempDir(), "ffmpeg")
+
+	if err := os.WriteFile(bin, []byte("#!/bin/sh\n"+script), 0o755); err != nil { //nolint:gosec // test script must be executable
+		t.Fatal(err)
+	}
+
+	c.Options().FFmpegBin = bin
+	c.Options().DisableFFmpeg = false
+
+	return NewConvert(c)
+}
+
+func TestConvert_ToVideo(t *testing.T) {
+	const fileHash = "acad9168fa6acc5c5c2965ddf6ec465ca42fd831"
+
+	fileName := filepath.Join(config.TestConfig().ExamplesPath(), "gopher-video.mp4")
+
+	t.Run("Hevc", func(t *testing.T) {
+		w := newConvertVideoTest(t, "for last; do :; done\nprintf 'data' > \"$last\"\n")
+
+		mf, err := NewMediaFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		videoFile, err := w.ToVideo(mf, fileHash, encode.CodecHevc, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, filepath.Join(w.conf.MediaFileCachePath(fileHash), fileHash+".hvc"), videoFile.FileName())
+		assert.FileExists(t, videoFile.FileName())
+
+		// Return the existing file.
+		existing, err := w.ToVideo(mf, fileHash, encode.CodecHevc, false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, videoFile.FileName(), existing.FileName())
+	})
+	t.Run("Failed", func(t *testing.T) {
+		w := newConvertVideoTest(t, "for last; do :; done\nprintf 'data' > \"$last\"\necho 'invalid data' >&2\nexit 1\n")
+
+		mf, err := NewMediaFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = w.ToVideo(mf, fileHash, encode.CodecAv1, false)
+
+		assert.Error(t, err)
+		assert.NoFileExists(t, filepath.Join(w.conf.MediaFileCachePath(fileHash), fileHash+".av1"))
+	})
+	t.Run("InvalidHash", func(t *testing.T) {
+		w := newConvertVideoTest(t, "exit 0\n")
+
+		mf, err := NewMediaFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = w.ToVideo(mf, "../acad9168", encode.CodecAv1, false)
+
+		assert.Error(t, err)
+	})
+	t.Run("NoFile", func(t *testing.T) {
+		w := newConvertVideoTest(t, "exit 0\n")
+
+		_, err := w.ToVideo(nil, fileHash, encode.CodecHevc, false)
+
+		assert.Error(t, err)
+	})
+}
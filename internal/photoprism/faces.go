@@ -202,6 +202,16 @@ func (w *Faces) Start(opt FacesOptions) (err error) {
 		log.Debugf("faces: removed %d clusters [%s]", count, time.Since(start))
 	}
 
+	// Suggest known people for unknown faces.
+	start = time.Now()
+	if res, err := w.Suggest(); err != nil {
+		log.Errorf("faces: %s (suggest)", err)
+	} else if res.Added > 0 {
+		log.Infof("faces: suggested %s for review [%s]", english.Plural(res.Added, "match", "matches"), time.Since(start))
+	} else {
+		log.Debugf("faces: found no new matches to suggest [%s]", time.Since(start))
+	}
+
 	entity.UpdateFaces.Store(false)
 
 	return nil
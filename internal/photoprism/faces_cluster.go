@@ -40,9 +40,18 @@ func (w *Faces) Cluster(opt FacesOptions) (added entity.Faces, err error) {
 		var c alg.HardClusterer
 
 		// See https://dl.photoprism.app/research/ for research on face clustering algorithms.
-		if c, err = alg.DBSCAN(face.ClusterCore, face.ClusterDist, w.conf.IndexWorkers(), alg.EuclideanDist); err != nil {
+		if c, err = w.clusterer(); err != nil {
 			return added, err
-		} else if err = c.Learn(embeddings.Float64()); err != nil {
+		} else if err = w.learn(c, embeddings.Float64()); err != nil && face.ClusterAlgorithm == face.ClusterOPTICS {
+			// Fall back to DBSCAN if OPTICS fails, e.g. because too few points are reachable.
+			log.Warnf("faces: %s clustering failed (%s), falling back to %s", face.ClusterOPTICS, err, face.ClusterDBSCAN)
+
+			if c, err = alg.DBSCAN(face.ClusterCore, face.ClusterDist, w.conf.IndexWorkers(), alg.EuclideanDist); err != nil {
+				return added, err
+			} else if err = c.Learn(embeddings.Float64()); err != nil {
+				return added, err
+			}
+		} else if err != nil {
 			return added, err
 		}
 
@@ -63,7 +72,7 @@ func (w *Faces) Cluster(opt FacesOptions) (added entity.Faces, err error) {
 		guesses := c.Guesses()
 
 		for i, n := range guesses {
-			if n < 1 {
+			if n < 1 || n > len(results) {
 				continue
 			}
 
@@ -88,3 +97,26 @@ func (w *Faces) Cluster(opt FacesOptions) (added entity.Faces, err error) {
 
 	return added, nil
 }
+
+// clusterer returns a new clusterer based on the configured face clustering algorithm.
+func (w *Faces) clusterer() (alg.HardClusterer, error) {
+	switch face.ClusterAlgorithm {
+	case face.ClusterOPTICS:
+		// OPTICS extracts clusters of varying density within the core distance.
+		log.Debugf("faces: clustering with %s, xi %f", face.ClusterAlgorithm, face.ClusterXi)
+		return alg.OPTICS(face.ClusterCore, face.ClusterDist, face.ClusterXi, w.conf.IndexWorkers(), alg.EuclideanDist)
+	default:
+		return alg.DBSCAN(face.ClusterCore, face.ClusterDist, w.conf.IndexWorkers(), alg.EuclideanDist)
+	}
+}
+
+// learn clusters the embeddings and returns an error if the clusterer fails, including runtime panics.
+func (w *Faces) learn(c alg.HardClusterer, data [][]float64) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%s (panic)", r)
+		}
+	}()
+
+	return c.Learn(data)
+}
@@ -3,6 +3,9 @@ package photoprism
 import (
 	"testing"
 
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/ai/face"
 	"github.com/photoprism/photoprism/internal/config"
 )
 
@@ -43,4 +46,68 @@ func TestFaces_Cluster(t *testing.T) {
 
 		t.Log(r)
 	})
+	t.Run("OPTICS", func(t *testing.T) {
+		c := config.TestConfig()
+
+		algorithm := face.ClusterAlgorithm
+		face.ClusterAlgorithm = face.ClusterOPTICS
+		defer func() { face.ClusterAlgorithm = algorithm }()
+
+		m := NewFaces(c)
+
+		opt := FacesOptions{
+			Force:     true,
+			Threshold: 1,
+		}
+
+		r, err := m.Cluster(opt)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		t.Log(r)
+	})
+}
+
+func TestFaces_Clusterer(t *testing.T) {
+	c := config.TestConfig()
+	m := NewFaces(c)
+
+	algorithm := face.ClusterAlgorithm
+	defer func() { face.ClusterAlgorithm = algorithm }()
+
+	t.Run("DBSCAN", func(t *testing.T) {
+		face.ClusterAlgorithm = face.ClusterDBSCAN
+		r, err := m.clusterer()
+		assert.NoError(t, err)
+		assert.NotNil(t, r)
+	})
+	t.Run("OPTICS", func(t *testing.T) {
+		face.ClusterAlgorithm = face.ClusterOPTICS
+		r, err := m.clusterer()
+		assert.NoError(t, err)
+		assert.NotNil(t, r)
+	})
+}
+
+func TestFaces_Learn(t *testing.T) {
+	c := config.TestConfig()
+	m := NewFaces(c)
+
+	algorithm := face.ClusterAlgorithm
+	defer func() { face.ClusterAlgorithm = algorithm }()
+
+	t.Run("DBSCAN", func(t *testing.T) {
+		face.ClusterAlgorithm = face.ClusterDBSCAN
+		r, err := m.clusterer()
+		assert.NoError(t, err)
+		assert.NoError(t, m.learn(r, [][]float64{{1}, {1.1}, {1.2}, {5}, {5.1}, {5.2}}))
+	})
+	t.Run("Panic", func(t *testing.T) {
+		face.ClusterAlgorithm = face.ClusterOPTICS
+		r, err := m.clusterer()
+		assert.NoError(t, err)
+		assert.Error(t, m.learn(r, [][]float64{{1}}))
+	})
 }
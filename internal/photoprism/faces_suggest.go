@@ -0,0 +1,96 @@
+package photoprism
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/internal/ai/face"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+)
+
+// FacesSuggestResult represents the outcome of Faces.Suggest().
+type FacesSuggestResult struct {
+	Added   int
+	Updated int
+	Removed int64
+}
+
+// Suggest compares unknown face clusters with the clusters of known people and adds
+// likely matches to the review queue, so they can be confirmed or rejected by a user.
+func (w *Faces) Suggest() (result FacesSuggestResult, err error) {
+	if w.Disabled() {
+		return result, fmt.Errorf("face recognition is disabled")
+	}
+
+	// Remove outdated suggestions first.
+	if result.Removed, err = query.PurgeFaceSuggestions(); err != nil {
+		return result, err
+	}
+
+	faces, err := query.Faces(false, false, false, false)
+
+	if err != nil {
+		return result, err
+	}
+
+	var known, unknown entity.Faces
+
+	for _, f := range faces {
+		if f.SkipMatching() {
+			continue
+		} else if f.SubjUID == "" {
+			unknown = append(unknown, f)
+		} else {
+			known = append(known, f)
+		}
+	}
+
+	if len(known) == 0 || len(unknown) == 0 {
+		return result, nil
+	}
+
+	for _, u := range unknown {
+		if w.Canceled() {
+			return result, fmt.Errorf("worker canceled")
+		}
+
+		emb := u.Embedding()
+
+		if len(emb) == 0 {
+			continue
+		}
+
+		var match *entity.Face
+		dist := -1.0
+
+		// Find the closest known face cluster.
+		for i := range known {
+			if d := emb.Dist(known[i].Embedding()); d < 0 {
+				continue
+			} else if match == nil || d < dist {
+				match = &known[i]
+				dist = d
+			}
+		}
+
+		if match == nil || dist > face.SuggestDist {
+			continue
+		}
+
+		s := entity.NewFaceSuggestion(u.ID, match.SubjUID, match.ID, dist)
+
+		if created, saveErr := s.Save(); saveErr != nil {
+			log.Errorf("faces: %s (suggest %s)", saveErr, u.ID)
+		} else if created {
+			result.Added++
+		} else if s.Pending() && (s.MatchID != match.ID || s.Distance != dist) {
+			if updateErr := s.Update(match.ID, dist); updateErr != nil {
+				log.Errorf("faces: %s (update suggestion %d)", updateErr, s.ID)
+			} else {
+				result.Updated++
+			}
+		}
+	}
+
+	return result, nil
+}
@@ -0,0 +1,21 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/photoprism/photoprism/internal/config"
+)
+
+func TestFaces_Suggest(t *testing.T) {
+	c := config.TestConfig()
+
+	m := NewFaces(c)
+
+	r, err := m.Suggest()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Log(r)
+}
@@ -0,0 +1,179 @@
+package photoprism
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"runtime/debug"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/geo/track"
+	"github.com/photoprism/photoprism/pkg/time/tz"
+)
+
+// Geotag result status values.
+const (
+	GeotagUpdated   = "updated"
+	GeotagMatched   = "matched"
+	GeotagUnchanged = "unchanged"
+	GeotagSkipped   = "skipped"
+)
+
+// geotagTimeMargin is the maximum difference between local time and UTC,
+// since pictures without a known time zone are stored with their local time.
+const geotagTimeMargin = 14 * time.Hour
+
+// GeotagResult represents a picture that matches a GPS track.
+type GeotagResult struct {
+	UID      string    `json:"UID"`
+	Name     string    `json:"Name"`
+	TakenAt  time.Time `json:"TakenAt"`
+	Lat      float64   `json:"Lat"`
+	Lng      float64   `json:"Lng"`
+	Altitude float64   `json:"Altitude"`
+	Src      string    `json:"Src"`
+	Status   string    `json:"Status"`
+}
+
+// Geotag represents a worker that sets the location of pictures based on GPS tracks.
+type Geotag struct {
+	conf *config.Config
+}
+
+// NewGeotag returns a new Geotag worker.
+func NewGeotag(conf *config.Config) *Geotag {
+	return &Geotag{conf: conf}
+}
+
+// Start matches pictures with the track by the time they were taken, and updates their location
+// unless it has been set from a source with the same or a higher priority, e.g. manually or from metadata.
+func (w *Geotag) Start(trk track.Track, opt GeotagOptions) (results []GeotagResult, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("geotag: %s (panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	results = []GeotagResult{}
+
+	if trk.Empty() {
+		return results, errors.New("geotag: track has no points")
+	}
+
+	if opt.TimeZone != "" && !tz.IsLocal(opt.TimeZone) && opt.Location() == tz.TimeLocal {
+		return results, fmt.Errorf("geotag: unknown time zone %s", clean.Log(opt.TimeZone))
+	}
+
+	// Check if a worker is already running.
+	if !opt.DryRun {
+		if err = mutex.IndexWorker.Start(); err != nil {
+			log.Warnf("geotag: %s", err.Error())
+			return results, err
+		}
+
+		defer mutex.IndexWorker.Stop()
+	}
+
+	start := time.Now()
+	maxGap := opt.GapLimit()
+	loc := opt.Location()
+
+	if loc == nil {
+		loc = w.conf.DefaultTimezone()
+	}
+
+	// Find pictures that may have been taken while the track was recorded.
+	photos, err := query.PhotosTakenBetween(
+		trk.Start().Add(-1*(maxGap+opt.Offset+geotagTimeMargin)),
+		trk.End().Add(maxGap-opt.Offset+geotagTimeMargin),
+	)
+
+	if err != nil {
+		return results, err
+	}
+
+	updated := 0
+
+	for _, photo := range photos {
+		if !opt.DryRun && mutex.IndexWorker.Canceled() {
+			return results, errors.New("geotag: worker canceled")
+		}
+
+		// Ignore pictures without a reliable date.
+		if photo.TakenSrc == entity.SrcAuto {
+			continue
+		}
+
+		takenAt := w.takenAt(photo, loc).Add(opt.Offset)
+		pos, ok := trk.Position(takenAt, maxGap)
+
+		if !ok {
+			continue
+		}
+
+		result := GeotagResult{
+			UID:      photo.PhotoUID,
+			Name:     path.Join(photo.PhotoPath, photo.PhotoName),
+			TakenAt:  takenAt,
+			Lat:      pos.Lat,
+			Lng:      pos.Lng,
+			Altitude: pos.Altitude,
+			Src:      photo.PlaceSrc,
+		}
+
+		// Never change locations that have been set manually or from metadata.
+		if photo.PlaceSrc != entity.SrcTrack && entity.SrcPriority[photo.PlaceSrc] >= entity.SrcPriority[entity.SrcTrack] {
+			result.Status = GeotagSkipped
+			results = append(results, result)
+			continue
+		}
+
+		if opt.DryRun {
+			result.Status = GeotagMatched
+			results = append(results, result)
+			continue
+		}
+
+		lat, lng := photo.PhotoLat, photo.PhotoLng
+
+		photo.SetPosition(pos, entity.SrcTrack, false)
+
+		if photo.PhotoLat == lat && photo.PhotoLng == lng {
+			result.Status = GeotagUnchanged
+		} else if err = photo.SaveLabels(); err != nil {
+			log.Errorf("geotag: %s in %s", clean.Error(err), photo.String())
+			result.Status = GeotagSkipped
+		} else {
+			result.Status = GeotagUpdated
+			updated++
+		}
+
+		results = append(results, result)
+	}
+
+	if updated > 0 {
+		log.Infof("geotag: updated location of %s [%s]", english.Plural(updated, "picture", "pictures"), time.Since(start))
+	}
+
+	return results, nil
+}
+
+// takenAt returns the time in UTC when the picture was taken,
+// using the specified time zone if the picture has no known time zone.
+func (w *Geotag) takenAt(photo *entity.Photo, loc *time.Location) time.Time {
+	if !photo.TimeZoneLocal() || photo.TakenAtLocal.IsZero() {
+		return photo.GetTakenAt()
+	}
+
+	t := photo.TakenAtLocal
+
+	return time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), loc).UTC()
+}
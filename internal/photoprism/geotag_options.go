@@ -0,0 +1,40 @@
+package photoprism
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/geo/track"
+	"github.com/photoprism/photoprism/pkg/time/tz"
+)
+
+// GeotagOptions controls how pictures are matched with GPS tracks.
+type GeotagOptions struct {
+	Offset   time.Duration // Camera clock offset, added to the time pictures were taken.
+	TimeZone string        // Time zone of pictures without a known time zone.
+	MaxGap   time.Duration // Maximum time between track points and pictures.
+	DryRun   bool          // Report matches without updating pictures.
+}
+
+// GapLimit returns the maximum time between track points and pictures.
+func (o GeotagOptions) GapLimit() time.Duration {
+	if o.MaxGap > 0 {
+		return o.MaxGap
+	}
+
+	return track.DefaultMaxGap
+}
+
+// Location returns the time zone of pictures without a known time zone,
+// or nil if the default time zone should be used.
+func (o GeotagOptions) Location() *time.Location {
+	if o.TimeZone == "" {
+		return nil
+	}
+
+	return tz.Find(o.TimeZone)
+}
+
+// GeotagOptionsDefault returns new geotag options with default values.
+func GeotagOptionsDefault() GeotagOptions {
+	return GeotagOptions{MaxGap: track.DefaultMaxGap}
+}
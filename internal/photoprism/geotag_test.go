@@ -0,0 +1,97 @@
+package photoprism
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/geo"
+	"github.com/photoprism/photoprism/pkg/geo/track"
+)
+
+func TestGeotag_Start(t *testing.T) {
+	conf := config.TestConfig()
+
+	// Pictures from fixture "PhotoTimeZone" were taken at 23:02:46 local time on 2015-05-17.
+	trk := track.New("Berlin", []geo.Position{
+		{Time: time.Date(2015, 5, 17, 20, 50, 0, 0, time.UTC), Lat: 52.5200, Lng: 13.4050},
+		{Time: time.Date(2015, 5, 17, 21, 20, 0, 0, time.UTC), Lat: 52.5300, Lng: 13.4150},
+	})
+
+	t.Run("EmptyTrack", func(t *testing.T) {
+		results, err := NewGeotag(conf).Start(track.Track{}, GeotagOptionsDefault())
+		assert.Error(t, err)
+		assert.Empty(t, results)
+	})
+	t.Run("InvalidTimeZone", func(t *testing.T) {
+		opt := GeotagOptions{TimeZone: "Mars/Olympus", DryRun: true}
+		results, err := NewGeotag(conf).Start(trk, opt)
+		assert.Error(t, err)
+		assert.Empty(t, results)
+	})
+	t.Run("TimeZone", func(t *testing.T) {
+		opt := GeotagOptions{TimeZone: "Europe/Berlin", DryRun: true}
+		results, err := NewGeotag(conf).Start(trk, opt)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var found *GeotagResult
+
+		for i := range results {
+			if results[i].UID == entity.PhotoFixtures.Get("PhotoTimeZone").PhotoUID {
+				found = &results[i]
+			}
+		}
+
+		if found == nil {
+			t.Fatal("picture not found")
+		}
+
+		assert.Equal(t, GeotagMatched, found.Status)
+		assert.Equal(t, time.Date(2015, 5, 17, 21, 2, 46, 0, time.UTC), found.TakenAt)
+		assert.InDelta(t, 52.5242, found.Lat, 0.001)
+		assert.InDelta(t, 13.4092, found.Lng, 0.001)
+	})
+	t.Run("Offset", func(t *testing.T) {
+		opt := GeotagOptions{TimeZone: "Europe/Berlin", Offset: 5 * time.Hour, DryRun: true}
+		results, err := NewGeotag(conf).Start(trk, opt)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, r := range results {
+			assert.NotEqual(t, entity.PhotoFixtures.Get("PhotoTimeZone").PhotoUID, r.UID)
+		}
+	})
+}
+
+func TestGeotag_takenAt(t *testing.T) {
+	w := NewGeotag(config.TestConfig())
+
+	t.Run("Local", func(t *testing.T) {
+		photo := &entity.Photo{
+			TimeZone:     "Local",
+			TakenAt:      time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+			TakenAtLocal: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+		}
+
+		loc, _ := time.LoadLocation("America/New_York")
+		assert.Equal(t, time.Date(2024, 6, 1, 16, 0, 0, 0, time.UTC), w.takenAt(photo, loc))
+	})
+	t.Run("Known", func(t *testing.T) {
+		photo := &entity.Photo{
+			TimeZone:     "Europe/Berlin",
+			TakenAt:      time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC),
+			TakenAtLocal: time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+		}
+
+		loc, _ := time.LoadLocation("America/New_York")
+		assert.Equal(t, time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC), w.takenAt(photo, loc))
+	})
+}
@@ -0,0 +1,405 @@
+package photoprism
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/ffmpeg/encode"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/media/video"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// HlsCacheMaxAge specifies how long cached HLS segments are kept after they were last requested.
+const HlsCacheMaxAge = 72 * time.Hour
+
+// HlsWaitTimeout specifies how long to wait for segments that are still being created.
+var HlsWaitTimeout = 30 * time.Second
+
+// HlsIdleTimeout specifies after which time segmenting is stopped if no client has requested the stream,
+// e.g. because the video was closed before it was completely segmented.
+var HlsIdleTimeout = time.Minute
+
+// HlsMaxJobs specifies how many stream variants can be segmented at the same time.
+const HlsMaxJobs = 2
+
+// hlsPollInterval specifies how often to check for new segments while waiting.
+var hlsPollInterval = 250 * time.Millisecond
+
+// hlsSlots limits the number of segmentation commands that run at the same time.
+var hlsSlots = make(chan struct{}, HlsMaxJobs)
+
+// hlsJob represents a queued or running segmentation command for a single stream variant.
+type hlsJob struct {
+	done     chan struct{}
+	err      error
+	cancel   context.CancelFunc
+	lastUsed atomic.Int64
+}
+
+// touch marks the job as requested by a client.
+func (j *hlsJob) touch() {
+	j.lastUsed.Store(time.Now().UnixNano())
+}
+
+// idle returns the time since the job was last requested by a client.
+func (j *hlsJob) idle() time.Duration {
+	return time.Since(time.Unix(0, j.lastUsed.Load()))
+}
+
+// watch cancels the job once it has not been requested within the idle timeout.
+func (j *hlsJob) watch(ctx context.Context) {
+	ticker := time.NewTicker(hlsPollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if j.idle() > HlsIdleTimeout {
+				j.cancel()
+				return
+			}
+		}
+	}
+}
+
+// hlsJobs contains the running segmentation commands by cache directory.
+var hlsJobs = struct {
+	sync.Mutex
+	jobs map[string]*hlsJob
+}{jobs: make(map[string]*hlsJob)}
+
+// Hls segments videos into HTTP Live Streaming (HLS) variants on demand and caches the results.
+type Hls struct {
+	conf *config.Config
+}
+
+// NewHls returns a new HLS video streaming worker.
+func NewHls(conf *config.Config) *Hls {
+	return &Hls{conf: conf}
+}
+
+// Renditions returns the stream variants for a video with the specified resolution.
+func (w *Hls) Renditions(width, height int) []encode.HlsRendition {
+	return encode.NewHlsRenditions(w.conf.FFmpegSize(), width, height)
+}
+
+// Needed checks if a video with the specified content type and average bitrate in Mbps should be streamed
+// with HLS, because it cannot be streamed directly or exceeds the configured bitrate limit.
+func (w *Hls) Needed(contentType string, bitrate float64) bool {
+	return !video.Compatible(contentType, video.Avc.ContentType) || w.conf.FFmpegBitrateExceeded(bitrate)
+}
+
+// MasterPlaylist returns the master playlist for a video with the specified hash and resolution.
+func (w *Hls) MasterPlaylist(hash string, width, height int) ([]byte, error) {
+	if err := w.check(hash); err != nil {
+		return nil, err
+	}
+
+	w.touch(hash)
+
+	return encode.HlsMasterPlaylistData(w.Renditions(width, height), width, height), nil
+}
+
+// Dir returns the cache directory for a stream variant of the video with the specified hash.
+func (w *Hls) Dir(hash string, r encode.HlsRendition) string {
+	return filepath.Join(w.conf.HlsCachePath(), hash, r.Name())
+}
+
+// Playlist returns the playlist filename of a stream variant and starts segmenting the video if needed.
+// It waits until the first segments have been created, so that clients can start playback right away,
+// or until the context is canceled because the client has gone away.
+func (w *Hls) Playlist(ctx context.Context, srcName, hash string, r encode.HlsRendition) (string, error) {
+	if err := w.check(hash); err != nil {
+		return "", err
+	} else if srcName == "" {
+		return "", fmt.Errorf("hls: empty source filename")
+	}
+
+	dir := w.Dir(hash, r)
+	fileName := filepath.Join(dir, encode.HlsPlaylist)
+
+	w.touch(hash)
+
+	// Return the cached playlist if it is complete.
+	if hlsPlaylistComplete(fileName) {
+		return fileName, nil
+	}
+
+	job, err := w.start(srcName, dir, r)
+
+	if err != nil {
+		return "", err
+	}
+
+	return fileName, hlsWait(ctx, job, func() bool { return hlsPlaylistReady(fileName) })
+}
+
+// Segment returns the filename of a cached segment, waiting for it if it is still being created.
+func (w *Hls) Segment(ctx context.Context, hash string, r encode.HlsRendition, name string) (string, error) {
+	if err := w.check(hash); err != nil {
+		return "", err
+	} else if !encode.IsHlsSegment(name) {
+		return "", fmt.Errorf("hls: invalid segment name %s", clean.Log(name))
+	}
+
+	dir := w.Dir(hash, r)
+	fileName := filepath.Join(dir, name)
+
+	hlsJobs.Lock()
+	job := hlsJobs.jobs[dir]
+	hlsJobs.Unlock()
+
+	if job != nil {
+		job.touch()
+	}
+
+	if fs.FileExists(fileName) {
+		return fileName, nil
+	} else if job == nil {
+		return "", os.ErrNotExist
+	}
+
+	return fileName, hlsWait(ctx, job, func() bool { return fs.FileExists(fileName) })
+}
+
+// Cleanup removes cached stream variants of videos that have not been requested within the specified time.
+func (w *Hls) Cleanup(maxAge time.Duration) (deleted int, err error) {
+	cachePath := w.conf.HlsCachePath()
+
+	entries, err := os.ReadDir(cachePath)
+
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, nil
+	} else if err != nil {
+		return 0, err
+	}
+
+	expired := time.Now().Add(-1 * maxAge)
+
+	for _, entry := range entries {
+		if !entry.IsDir() || !rnd.IsSHA(entry.Name()) || hlsRunning(entry.Name()) {
+			continue
+		}
+
+		info, infoErr := entry.Info()
+
+		if infoErr != nil || info.ModTime().After(expired) {
+			continue
+		}
+
+		if removeErr := os.RemoveAll(filepath.Join(cachePath, entry.Name())); removeErr != nil {
+			log.Warnf("hls: %s", removeErr)
+		} else {
+			deleted++
+		}
+	}
+
+	if deleted > 0 {
+		log.Infof("hls: removed stale segments of %s from cache", english.Plural(deleted, "video", "videos"))
+	}
+
+	return deleted, nil
+}
+
+// check returns an error if HLS streaming is disabled or the file hash is invalid.
+func (w *Hls) check(hash string) error {
+	if w.conf.DisableHLS() {
+		return fmt.Errorf("hls: streaming is disabled")
+	} else if !rnd.IsSHA(hash) {
+		return fmt.Errorf("hls: invalid file hash %s", clean.Log(hash))
+	}
+
+	return nil
+}
+
+// touch updates the modification time of the video cache directory to indicate that it is in use.
+func (w *Hls) touch(hash string) {
+	dir := filepath.Join(w.conf.HlsCachePath(), hash)
+
+	if err := fs.MkdirAll(dir); err != nil {
+		log.Warnf("hls: %s", err)
+		return
+	}
+
+	now := time.Now()
+
+	if err := os.Chtimes(dir, now, now); err != nil {
+		log.Debugf("hls: %s", err)
+	}
+}
+
+// start runs the segmentation command for a stream variant in the background, unless it is already running.
+// It returns nil if the cached playlist is already complete. The number of commands running at the same
+// time is limited by HlsMaxJobs, and jobs are canceled if they are not requested within HlsIdleTimeout.
+func (w *Hls) start(srcName, dir string, r encode.HlsRendition) (*hlsJob, error) {
+	hlsJobs.Lock()
+	defer hlsJobs.Unlock()
+
+	if job, ok := hlsJobs.jobs[dir]; ok {
+		job.touch()
+		return job, nil
+	} else if hlsPlaylistComplete(filepath.Join(dir, encode.HlsPlaylist)) {
+		return nil, nil
+	}
+
+	opt, err := w.conf.FFmpegOptions(encode.SoftwareAvc, fmt.Sprintf("%dk", r.Bitrate))
+
+	if err != nil {
+		return nil, fmt.Errorf("hls: %s", err)
+	}
+
+	// Remove incomplete segments, e.g. if the server was restarted while segmenting.
+	if err = os.RemoveAll(dir); err != nil {
+		return nil, fmt.Errorf("hls: %s", err)
+	} else if err = fs.MkdirAll(dir); err != nil {
+		return nil, fmt.Errorf("hls: %s", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cmd := encode.TranscodeToHlsCmd(ctx, srcName, dir, opt, r)
+
+	// Fetch command output.
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	cmd.Env = append(cmd.Env, []string{
+		fmt.Sprintf("HOME=%s", w.conf.CmdCachePath()),
+	}...)
+
+	job := &hlsJob{done: make(chan struct{}), cancel: cancel}
+	job.touch()
+	hlsJobs.jobs[dir] = job
+
+	relName := clean.Log(filepath.Base(srcName))
+
+	go func() {
+		defer cancel()
+
+		// Stop segmenting if the stream is no longer requested.
+		go job.watch(ctx)
+
+		var runErr error
+
+		// Wait for a free slot, unless the job is canceled first.
+		select {
+		case hlsSlots <- struct{}{}:
+			start := time.Now()
+
+			log.Infof("hls: creating %s stream of %s", r.Name(), relName)
+
+			// Log exact command for debugging in trace mode.
+			log.Trace(cmd.String())
+
+			if runErr = cmd.Run(); runErr == nil {
+				log.Infof("hls: created %s stream of %s [%s]", r.Name(), relName, time.Since(start))
+			} else if ctx.Err() == nil {
+				if stderr.String() != "" {
+					runErr = errors.New(stderr.String())
+				}
+
+				log.Debug(runErr)
+				log.Warnf("hls: failed to create %s stream of %s [%s]", r.Name(), relName, time.Since(start))
+			}
+
+			<-hlsSlots
+		case <-ctx.Done():
+			runErr = ctx.Err()
+		}
+
+		if ctx.Err() != nil {
+			log.Infof("hls: stopped creating %s stream of %s, as it is no longer requested", r.Name(), relName)
+			job.err = fmt.Errorf("hls: stopped creating %s stream of %s", r.Name(), relName)
+		} else if runErr != nil {
+			job.err = fmt.Errorf("hls: failed to create %s stream of %s", r.Name(), relName)
+		}
+
+		if job.err != nil {
+
+			if removeErr := os.RemoveAll(dir); removeErr != nil {
+				log.Warnf("hls: %s", removeErr)
+			}
+		}
+
+		hlsJobs.Lock()
+		delete(hlsJobs.jobs, dir)
+		hlsJobs.Unlock()
+
+		close(job.done)
+	}()
+
+	return job, nil
+}
+
+// hlsWait waits until the ready function returns true, the job is done, the context is canceled,
+// or the timeout is exceeded. The job is kept alive while waiting.
+func hlsWait(ctx context.Context, job *hlsJob, ready func() bool) error {
+	timeout := time.After(HlsWaitTimeout)
+
+	for {
+		if ready() {
+			return nil
+		} else if job == nil {
+			return os.ErrNotExist
+		}
+
+		job.touch()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-job.done:
+			if job.err != nil {
+				return job.err
+			} else if ready() {
+				return nil
+			}
+
+			return os.ErrNotExist
+		case <-timeout:
+			return fmt.Errorf("hls: timeout while waiting for segments")
+		case <-time.After(hlsPollInterval):
+		}
+	}
+}
+
+// hlsRunning checks if a stream of the video with the specified hash is currently being created.
+func hlsRunning(hash string) bool {
+	hlsJobs.Lock()
+	defer hlsJobs.Unlock()
+
+	for dir := range hlsJobs.jobs {
+		if filepath.Base(filepath.Dir(dir)) == hash {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hlsPlaylistReady checks if the playlist exists and references at least one segment.
+func hlsPlaylistReady(fileName string) bool {
+	data, err := os.ReadFile(fileName) //nolint:gosec // path derived from cache directory
+
+	return err == nil && bytes.Contains(data, []byte("#EXTINF"))
+}
+
+// hlsPlaylistComplete checks if the playlist exists and all segments have been created.
+func hlsPlaylistComplete(fileName string) bool {
+	data, err := os.ReadFile(fileName) //nolint:gosec // path derived from cache directory
+
+	return err == nil && strings.Contains(string(data), encode.HlsPlaylistEnd)
+}
@@ -0,0 +1,177 @@
+package photoprism
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/ffmpeg/encode"
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/http/header"
+)
+
+// newHlsTest returns a new HLS worker that uses the specified shell script instead of FFmpeg.
+func newHlsTest(t *testing.T, script string) *Hls {
+	t.Helper()
+
+	c := config.NewMinimalTestConfig(t.TempDir())
+	bin := filepath.Join(t.TempDir(), "ffmpeg")
+
+	if err := os.WriteFile(bin, []byte("#!/bin/sh\n"+script), 0o755); err != nil { //nolint:gosec // test script must be executable
+		t.Fatal(err)
+	}
+
+	c.Options().FFmpegBin = bin
+	c.Options().DisableFFmpeg = false
+
+	return NewHls(c)
+}
+
+func TestHls_Needed(t *testing.T) {
+	w := newHlsTest(t, "exit 0\n")
+
+	assert.False(t, w.Needed(header.ContentTypeMp4AvcMain, 8))
+	assert.True(t, w.Needed(header.ContentTypeMp4AvcMain, 100))
+	assert.True(t, w.Needed(header.ContentTypeMp4HvcMain, 8))
+	assert.True(t, w.Needed("", 0))
+}
+
+func TestHls_MasterPlaylist(t *testing.T) {
+	w := newHlsTest(t, "exit 0\n")
+
+	t.Run("Success", func(t *testing.T) {
+		data, err := w.MasterPlaylist("acad9168fa6acc5c5c2965ddf6ec465ca42fd831", 1920, 1080)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Contains(t, string(data), "#EXT-X-STREAM-INF:BANDWIDTH=6128000,RESOLUTION=1920x1080")
+		assert.Contains(t, string(data), "1920/index.m3u8")
+		assert.DirExists(t, filepath.Join(w.conf.HlsCachePath(), "acad9168fa6acc5c5c2965ddf6ec465ca42fd831"))
+	})
+	t.Run("InvalidHash", func(t *testing.T) {
+		_, err := w.MasterPlaylist("../acad9168", 1920, 1080)
+		assert.Error(t, err)
+	})
+}
+
+func TestHls_Playlist(t *testing.T) {
+	r, _ := encode.FindHlsRendition("720")
+
+	t.Run("Success", func(t *testing.T) {
+		w := newHlsTest(t, `for last; do :; done
+dir=$(dirname "$last")
+printf 'data' > "$dir/segment_00000.ts"
+printf '#EXTM3U\n#EXTINF:6.000000,\nsegment_00000.ts\n#EXT-X-ENDLIST\n' > "$last"
+`)
+
+		hash := "acad9168fa6acc5c5c2965ddf6ec465ca42fd831"
+
+		fileName, err := w.Playlist(context.Background(), "/photos/video.mp4", hash, r)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, filepath.Join(w.Dir(hash, r), encode.HlsPlaylist), fileName)
+
+		segmentName, err := w.Segment(context.Background(), hash, r, "segment_00000.ts")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.FileExists(t, segmentName)
+
+		_, err = w.Segment(context.Background(), hash, r, "segment_00001.ts")
+		assert.ErrorIs(t, err, os.ErrNotExist)
+
+		_, err = w.Segment(context.Background(), hash, r, "../index.m3u8")
+		assert.Error(t, err)
+	})
+	t.Run("Failed", func(t *testing.T) {
+		w := newHlsTest(t, "echo 'invalid data' >&2\nexit 1\n")
+
+		hash := "acad9168fa6acc5c5c2965ddf6ec465ca42fd832"
+
+		_, err := w.Playlist(context.Background(), "/photos/video.mp4", hash, r)
+
+		assert.Error(t, err)
+		assert.NoDirExists(t, w.Dir(hash, r))
+	})
+	t.Run("Canceled", func(t *testing.T) {
+		w := newHlsTest(t, "exec sleep 10\n")
+
+		hash := "acad9168fa6acc5c5c2965ddf6ec465ca42fd834"
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := w.Playlist(ctx, "/photos/video.mp4", hash, r)
+		assert.ErrorIs(t, err, context.Canceled)
+
+		hlsJobs.Lock()
+		job := hlsJobs.jobs[w.Dir(hash, r)]
+		hlsJobs.Unlock()
+
+		if job == nil {
+			t.Fatal("job expected")
+		}
+
+		// Simulate that the stream has not been requested since the idle timeout.
+		job.lastUsed.Store(time.Now().Add(-2 * HlsIdleTimeout).UnixNano())
+
+		select {
+		case <-job.done:
+			assert.Error(t, job.err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("job should have been canceled")
+		}
+
+		assert.False(t, hlsRunning(hash))
+		assert.NoDirExists(t, w.Dir(hash, r))
+	})
+	t.Run("Disabled", func(t *testing.T) {
+		w := newHlsTest(t, "exit 0\n")
+		w.conf.Options().DisableHLS = true
+
+		_, err := w.Playlist(context.Background(), "/photos/video.mp4", "acad9168fa6acc5c5c2965ddf6ec465ca42fd833", r)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestHls_Cleanup(t *testing.T) {
+	w := newHlsTest(t, "exit 0\n")
+
+	staleDir := filepath.Join(w.conf.HlsCachePath(), "acad9168fa6acc5c5c2965ddf6ec465ca42fd831")
+	recentDir := filepath.Join(w.conf.HlsCachePath(), "acad9168fa6acc5c5c2965ddf6ec465ca42fd832")
+
+	for _, dir := range []string{staleDir, recentDir} {
+		if err := fs.MkdirAll(filepath.Join(dir, "720")); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expired := time.Now().Add(-2 * HlsCacheMaxAge)
+
+	if err := os.Chtimes(staleDir, expired, expired); err != nil {
+		t.Fatal(err)
+	}
+
+	deleted, err := w.Cleanup(HlsCacheMaxAge)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, 1, deleted)
+	assert.NoDirExists(t, staleDir)
+	assert.DirExists(t, recentDir)
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/pkg/clean"
@@ -85,6 +86,11 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 		defer mutex.IndexWorker.Stop()
 	}
 
+	// Look up the location of pictures only once per import, see ImportTemplate.
+	if opt.places == nil {
+		opt.places = newImportPlaces()
+	}
+
 	jobs := make(chan ImportJob)
 
 	// Start a fixed number of goroutines to import files.
@@ -116,6 +122,10 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 		log.Infof(`import: ignored "%s"`, fs.RelName(fileName, importPath))
 	}
 
+	// Files imported on behalf of a user must not exceed the storage quota of the account.
+	quota, usedSize, usedFiles := imp.userQuota(opt.UID)
+	quotaSkipped := 0
+
 	err := godirwalk.Walk(importPath, &godirwalk.Options{
 		ErrorCallback: func(fileName string, err error) godirwalk.ErrorAction {
 			return godirwalk.SkipNode
@@ -202,6 +212,7 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 			}
 
 			var files MediaFiles
+			var filesSize int64
 
 			for _, f := range related.Files {
 				if f.FileSize() == 0 || done[f.FileName()].Processed() {
@@ -209,6 +220,20 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 				}
 
 				files = append(files, f)
+				filesSize += f.FileSize()
+			}
+
+			// Skip files that would exceed the storage quota of the user.
+			if quotaErr := quota.Check(usedSize, usedFiles, filesSize, len(files)); quotaErr != nil {
+				log.Warnf("import: skipped %s (%s)", clean.Log(mf.RootRelName()), quotaErr)
+				quotaSkipped += len(files)
+				return nil
+			}
+
+			usedSize += filesSize
+			usedFiles += len(files)
+
+			for _, f := range files {
 				filesImported++
 				done[f.FileName()] = fs.Processed
 			}
@@ -234,6 +259,10 @@ func (imp *Import) Start(opt ImportOptions) fs.Done {
 	close(jobs)
 	wg.Wait()
 
+	if quotaSkipped > 0 {
+		event.Warn(fmt.Sprintf("import: skipped %d files because the storage quota has been exceeded", quotaSkipped))
+	}
+
 	sort.Slice(directories, func(i, j int) bool {
 		return len(directories[i]) > len(directories[j])
 	})
@@ -296,10 +325,11 @@ func (imp *Import) Cancel() {
 }
 
 // DestinationFilename returns the destination filename of a MediaFile to be imported.
-// Format: 2006/01/20060102_150405_CHECKSUM.ext
-func (imp *Import) DestinationFilename(mainFile *MediaFile, mediaFile *MediaFile, folder string) (string, error) {
+// Format: 2006/01/20060102_150405_CHECKSUM.ext, unless a destination path template is configured.
+func (imp *Import) DestinationFilename(mainFile *MediaFile, mediaFile *MediaFile, opt ImportOptions) (string, error) {
 	// Get the import destination path and file name patterns.
-	pathPattern, namePattern := imp.conf.Settings().Import.GetDestName()
+	settings := imp.conf.Settings().Import
+	pathPattern, namePattern := settings.GetDestName()
 
 	fileName := mainFile.CanonicalName(namePattern)
 	fileExtension := mediaFile.Extension()
@@ -318,7 +348,13 @@ func (imp *Import) DestinationFilename(mainFile *MediaFile, mediaFile *MediaFile
 
 	// Find and return the next available file name if the default name is already being used by another file.
 	i := 0
-	pathName := filepath.Join(imp.originalsPath(), folder, dateCreated.Format(pathPattern))
+	pathName := filepath.Join(imp.originalsPath(), opt.DestFolder, dateCreated.Format(pathPattern))
+
+	// Render the destination path template instead, if configured.
+	if tpl := settings.GetTemplate(); tpl != "" {
+		pathName = filepath.Join(imp.originalsPath(), opt.DestFolder, NewImportTemplate(mainFile, opt).Render(tpl))
+	}
+
 	filePath := filepath.Join(pathName, fileName+fileExtension)
 
 	for fs.FileExists(filePath) {
@@ -333,3 +369,22 @@ func (imp *Import) DestinationFilename(mainFile *MediaFile, mediaFile *MediaFile
 
 	return filePath, nil
 }
+
+// userQuota returns the storage quota of the user with the specified UID along with the
+// current usage, or nil if no user is specified or the account has no quota.
+func (imp *Import) userQuota(userUid string) (quota *entity.Quota, usedSize int64, usedFiles int) {
+	if userUid == "" {
+		return nil, 0, 0
+	} else if quota = entity.UserQuota(entity.FindUserByUID(userUid)); quota.Unlimited() {
+		return nil, 0, 0
+	}
+
+	usedSize, usedFiles, err := query.UserStorageUsage(userUid)
+
+	if err != nil {
+		log.Warnf("import: %s (check quota)", err)
+		return nil, 0, 0
+	}
+
+	return quota, usedSize, usedFiles
+}
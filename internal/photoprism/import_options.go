@@ -15,6 +15,7 @@ type ImportOptions struct {
 	RemoveInvalidFiles     bool
 	RemoveExistingFiles    bool
 	RemoveEmptyDirectories bool
+	places                 *importPlaces
 }
 
 // SetUser sets the user who performs the import operation.
@@ -0,0 +1,119 @@
+package photoprism
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+
+	"github.com/karrick/godirwalk"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+	"github.com/photoprism/photoprism/pkg/media"
+)
+
+// ImportPreview represents the destination of a file in the import folder.
+type ImportPreview struct {
+	Source string
+	Dest   string
+	Skip   string
+}
+
+// ImportPreviews represents a list of import destinations.
+type ImportPreviews []ImportPreview
+
+// Preview returns the destination of each file in the import folder without copying or moving it.
+// Name conflicts between files of the same import are not taken into account.
+func (imp *Import) Preview(opt ImportOptions) (result ImportPreviews, err error) {
+	if imp.conf == nil {
+		return result, errors.New("config is not set")
+	}
+
+	importPath := opt.Path
+
+	if !fs.PathExists(importPath) {
+		return result, fmt.Errorf("directory %s not found", importPath)
+	}
+
+	if opt.places == nil {
+		opt.places = newImportPlaces()
+	}
+
+	done := make(fs.Done)
+	skipRaw := imp.conf.DisableRaw()
+	ignore := fs.NewIgnoreList(fs.PPIgnoreFilename, true, false)
+
+	if err = ignore.Path(importPath); err != nil {
+		log.Debugf("import: %s", err)
+	}
+
+	err = godirwalk.Walk(importPath, &godirwalk.Options{
+		ErrorCallback: func(fileName string, err error) godirwalk.ErrorAction {
+			return godirwalk.SkipNode
+		},
+		Callback: func(fileName string, info *godirwalk.Dirent) error {
+			isDir, _ := info.IsDirOrSymlinkToDir()
+			isSymlink := info.IsSymlink()
+
+			if skip, result := fs.SkipWalk(fileName, isDir, isSymlink, done, ignore); skip {
+				return result
+			}
+
+			done[fileName] = fs.Found
+
+			if !media.MainFile(fileName) {
+				return nil
+			}
+
+			mf, mediaErr := NewMediaFile(fileName)
+
+			if mediaErr != nil || mf.Empty() {
+				return nil
+			} else if mf.IsRaw() && skipRaw {
+				result = append(result, ImportPreview{Source: mf.RelName(importPath), Skip: "raw images are disabled"})
+				return nil
+			}
+
+			related, relatedErr := mf.RelatedFiles(imp.conf.Settings().StackSequences())
+
+			if relatedErr != nil {
+				result = append(result, ImportPreview{Source: mf.RelName(importPath), Skip: relatedErr.Error()})
+				return nil
+			} else if related.Main == nil {
+				return nil
+			}
+
+			for _, f := range related.Files {
+				if f.FileSize() == 0 || done[f.FileName()].Processed() {
+					continue
+				}
+
+				done[f.FileName()] = fs.Processed
+
+				preview := ImportPreview{Source: f.RelName(importPath)}
+
+				if imp.AllowExt.Excludes(related.Main.FileType().DefaultExt()) {
+					preview.Skip = "file type is not allowed"
+				} else if destName, destErr := imp.DestinationFilename(related.Main, f, opt); destErr != nil {
+					preview.Dest = fs.RelName(destName, imp.originalsPath())
+					preview.Skip = destErr.Error()
+				} else {
+					preview.Dest = fs.RelName(destName, imp.originalsPath())
+				}
+
+				result = append(result, preview)
+			}
+
+			done[fileName] = fs.Processed
+
+			return nil
+		},
+		Unsorted:            false,
+		FollowSymbolicLinks: true,
+	})
+
+	if errors.Is(err, filepath.SkipDir) {
+		err = nil
+	}
+
+	return result, err
+}
@@ -0,0 +1,260 @@
+package photoprism
+
+import (
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/config/customize"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/meta"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// ImportTemplate renders import destination paths from a template, see customize.ImportPlaceholders.
+type ImportTemplate struct {
+	file     *MediaFile
+	opt      ImportOptions
+	meta     *meta.Data
+	location *entity.Place
+	located  bool
+}
+
+// importPlaces caches the places resolved from GPS coordinates by cell ID, so that the location of
+// pictures taken nearby is only looked up once per import, even if the lookup fails.
+type importPlaces struct {
+	mu     sync.Mutex
+	places map[string]*importPlace
+}
+
+// importPlace is a cached place, which is looked up only once, without blocking other cells.
+type importPlace struct {
+	once  sync.Once
+	place *entity.Place
+}
+
+// newImportPlaces returns a new place cache for an import.
+func newImportPlaces() *importPlaces {
+	return &importPlaces{places: make(map[string]*importPlace)}
+}
+
+// Find returns the place of the specified GPS coordinates, or nil if it is unknown.
+func (p *importPlaces) Find(lat, lng float64) *entity.Place {
+	cell := entity.NewCell(lat, lng)
+
+	if cell.Unknown() {
+		return nil
+	}
+
+	if p == nil {
+		return findImportPlace(cell)
+	}
+
+	p.mu.Lock()
+	c, ok := p.places[cell.ID]
+
+	if !ok {
+		c = &importPlace{}
+		p.places[cell.ID] = c
+	}
+
+	p.mu.Unlock()
+
+	c.once.Do(func() {
+		c.place = findImportPlace(cell)
+	})
+
+	return c.place
+}
+
+// findImportPlace looks up the place of the specified cell and returns nil if it is unknown.
+func findImportPlace(cell *entity.Cell) *entity.Place {
+	if err := cell.Find(entity.GeoApi); err != nil {
+		log.Debugf("import: %s (find location)", err)
+		return nil
+	} else if cell.Place == nil || cell.Place.Unknown() {
+		return nil
+	}
+
+	return cell.Place
+}
+
+// NewImportTemplate returns a new template renderer for the main media file to be imported.
+func NewImportTemplate(file *MediaFile, opt ImportOptions) *ImportTemplate {
+	return &ImportTemplate{file: file, opt: opt}
+}
+
+// Render replaces the placeholders in the template and returns the sanitized relative path.
+// Placeholders without a value are replaced by their fallback, if any, and empty folders are omitted.
+func (t *ImportTemplate) Render(tpl string) string {
+	result := customize.ImportTemplateRegexp.ReplaceAllStringFunc(tpl, func(match string) string {
+		m := customize.ImportTemplateRegexp.FindStringSubmatch(match)
+
+		if v := t.Value(m[1], m[2]); v != "" {
+			return v
+		}
+
+		return pathValue(m[3], false)
+	})
+
+	// Remove empty folders.
+	folders := strings.Split(result, "/")
+	result = ""
+
+	for _, folder := range folders {
+		if folder = strings.TrimSpace(folder); folder == "" {
+			continue
+		} else if result == "" {
+			result = folder
+		} else {
+			result = result + "/" + folder
+		}
+	}
+
+	return clean.UserPath(result)
+}
+
+// Value returns the sanitized value of the specified placeholder, or an empty string if it is unknown.
+func (t *ImportTemplate) Value(name, arg string) string {
+	if t.file == nil {
+		return ""
+	}
+
+	switch name {
+	case "year":
+		return t.file.DateCreated().Format("2006")
+	case "month":
+		return t.file.DateCreated().Format("01")
+	case "day":
+		return t.file.DateCreated().Format("02")
+	case "date":
+		if arg == "" {
+			arg = "2006-01-02"
+		}
+
+		// Date layouts may contain slashes to create nested folders.
+		return pathValue(t.file.DateCreated().Format(arg), true)
+	case "make":
+		if cam := t.camera(); cam.CameraMake != entity.MakeNone {
+			return pathValue(cam.CameraMake, false)
+		}
+	case "model":
+		if cam := t.camera(); cam.CameraModel != entity.ModelUnknown {
+			return pathValue(cam.CameraModel, false)
+		}
+	case "camera":
+		if cam := t.camera(); cam != &entity.UnknownCamera {
+			return pathValue(cam.CameraName, false)
+		}
+	case "country":
+		if p := t.place(); p != nil {
+			return pathValue(p.CountryName(), false)
+		}
+	case "state":
+		if p := t.place(); p != nil {
+			return pathValue(p.State(), false)
+		}
+	case "city":
+		if p := t.place(); p != nil {
+			return pathValue(p.City(), false)
+		}
+	case "album":
+		return pathValue(t.album(), false)
+	case "folder":
+		if dir := filepath.Dir(t.file.RelName(t.opt.Path)); dir != "." {
+			return pathValue(filepath.ToSlash(dir), true)
+		}
+	case "user":
+		if u := entity.FindUserByUID(t.opt.UID); u != nil {
+			return pathValue(u.Username(), false)
+		}
+	case "type":
+		return pathValue(string(t.file.MediaType()), false)
+	case "hash":
+		hash := t.file.Hash()
+		n, err := strconv.Atoi(arg)
+
+		if err != nil || n < 1 {
+			n = 8
+		}
+
+		if n > len(hash) {
+			n = len(hash)
+		}
+
+		return hash[:n]
+	}
+
+	return ""
+}
+
+// data returns the metadata of the media file.
+func (t *ImportTemplate) data() *meta.Data {
+	if t.meta == nil {
+		data := t.file.MetaData()
+		t.meta = &data
+	}
+
+	return t.meta
+}
+
+// camera returns the normalized camera make and model.
+func (t *ImportTemplate) camera() *entity.Camera {
+	data := t.data()
+
+	return entity.NewCamera(data.CameraMake, data.CameraModel)
+}
+
+// place returns the place resolved from the GPS coordinates, or nil if it is unknown.
+func (t *ImportTemplate) place() *entity.Place {
+	if t.located {
+		return t.location
+	}
+
+	t.located = true
+
+	if data := t.data(); data.Lat != 0 || data.Lng != 0 {
+		t.location = t.opt.places.Find(data.Lat, data.Lng)
+	}
+
+	return t.location
+}
+
+// album returns the title of the first album to which the files are added.
+func (t *ImportTemplate) album() string {
+	for _, album := range t.opt.Albums {
+		if album = strings.TrimSpace(album); album == "" {
+			continue
+		} else if !rnd.IsUID(album, entity.AlbumUID) {
+			return album
+		} else if a := entity.FindAlbum(entity.Album{AlbumUID: album}); a != nil {
+			return a.AlbumTitle
+		}
+	}
+
+	return ""
+}
+
+// pathValue sanitizes a placeholder value so that it can be used as folder name. Slashes are replaced
+// by a dash, unless nested folders are allowed, e.g. for date layouts and the original folder.
+func pathValue(s string, nested bool) string {
+	if s = strings.TrimSpace(s); s == "" {
+		return ""
+	}
+
+	if nested {
+		s = strings.ReplaceAll(s, "\\", "-")
+	} else {
+		s = strings.NewReplacer("/", "-", "\\", "-").Replace(s)
+	}
+
+	folders := strings.Split(s, "/")
+
+	for i := range folders {
+		folders[i] = strings.Trim(clean.FileName(folders[i]), ". ")
+	}
+
+	return strings.Join(folders, "/")
+}
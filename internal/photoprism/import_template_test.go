@@ -0,0 +1,122 @@
+package photoprism
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestImportTemplate_Render(t *testing.T) {
+	c := config.TestConfig()
+
+	img, err := NewMediaFile(c.ExamplesPath() + "/elephants.jpg")
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	opt := ImportOptions{
+		UID:    entity.UserFixtures.Get("alice").UserUID,
+		Albums: []string{"Client / Wedding"},
+		Path:   c.ExamplesPath(),
+	}
+
+	t.Run("Date", func(t *testing.T) {
+		tpl := NewImportTemplate(img, opt)
+		assert.Equal(t, "2013/11/26", tpl.Render("{year}/{month}/{day}"))
+		assert.Equal(t, "2013/2013-11", tpl.Render("{date:2006/2006-01}"))
+	})
+	t.Run("Camera", func(t *testing.T) {
+		tpl := NewImportTemplate(img, opt)
+		assert.Equal(t, "Canon/EOS 6D", tpl.Render("{make}/{model}"))
+		assert.Equal(t, "Canon EOS 6D", tpl.Render("{camera}"))
+	})
+	t.Run("AlbumAndUser", func(t *testing.T) {
+		tpl := NewImportTemplate(img, opt)
+		assert.Equal(t, "Client - Wedding/alice/image", tpl.Render("{album}/{user}/{type}"))
+	})
+	t.Run("Hash", func(t *testing.T) {
+		tpl := NewImportTemplate(img, opt)
+		assert.Equal(t, img.Hash()[:8], tpl.Render("{hash}"))
+		assert.Equal(t, "photos-"+img.Hash()[:4], tpl.Render("photos-{hash:4}"))
+	})
+	t.Run("Fallback", func(t *testing.T) {
+		tpl := NewImportTemplate(img, ImportOptions{Path: c.ExamplesPath()})
+		assert.Equal(t, "Unsorted/2013", tpl.Render("{album|Unsorted}/{folder}/{year}"))
+		assert.Equal(t, "2013", tpl.Render("{album}/{user}/{year}"))
+	})
+}
+
+func TestImportTemplate_Value(t *testing.T) {
+	t.Run("NoFile", func(t *testing.T) {
+		tpl := NewImportTemplate(nil, ImportOptions{})
+		assert.Equal(t, "", tpl.Value("year", ""))
+	})
+}
+
+func TestPathValue(t *testing.T) {
+	t.Run("Slashes", func(t *testing.T) {
+		assert.Equal(t, "AC-DC", pathValue("AC/DC", false))
+		assert.Equal(t, "2024/01", pathValue("2024/01", true))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Equal(t, "", pathValue("..", false))
+		assert.Equal(t, "What's up", pathValue(" What's up? ", false))
+	})
+}
+
+// cachedImportPlace returns an import place cache entry that has already been looked up.
+func cachedImportPlace(place *entity.Place) *importPlace {
+	c := &importPlace{place: place}
+	c.once.Do(func() {})
+	return c
+}
+
+func TestImportPlaces_Find(t *testing.T) {
+	t.Run("Cached", func(t *testing.T) {
+		p := newImportPlaces()
+		cell := entity.NewCell(52.5208, 13.40953)
+		berlin := &entity.Place{ID: "de:berlin", PlaceCity: "Berlin", PlaceCountry: "de"}
+
+		p.places[cell.ID] = cachedImportPlace(berlin)
+
+		assert.Same(t, berlin, p.Find(52.5208, 13.40953))
+	})
+	t.Run("CachedUnknown", func(t *testing.T) {
+		p := newImportPlaces()
+		cell := entity.NewCell(48.8583, 2.2944)
+
+		p.places[cell.ID] = cachedImportPlace(nil)
+
+		assert.Nil(t, p.Find(48.8583, 2.2944))
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		p := newImportPlaces()
+
+		assert.Nil(t, p.Find(0, 0))
+		assert.Empty(t, p.places)
+	})
+	t.Run("Template", func(t *testing.T) {
+		c := config.TestConfig()
+
+		img, err := NewMediaFile(c.ExamplesPath() + "/elephants.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		data := img.MetaData()
+
+		if data.Lat == 0 && data.Lng == 0 {
+			t.Skip("no gps coordinates")
+		}
+
+		opt := ImportOptions{Path: c.ExamplesPath(), places: newImportPlaces()}
+		opt.places.places[entity.NewCell(data.Lat, data.Lng).ID] = cachedImportPlace(&entity.Place{ID: "za:kruger", PlaceState: "Mpumalanga", PlaceCountry: "za"})
+
+		assert.Equal(t, "South Africa/Mpumalanga", NewImportTemplate(img, opt).Render("{country}/{state}"))
+	})
+}
@@ -1,11 +1,13 @@
 package photoprism
 
 import (
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/pkg/fs"
 )
 
 func TestNewImport(t *testing.T) {
@@ -38,7 +40,7 @@ func TestImport_DestinationFilename(t *testing.T) {
 	}
 
 	t.Run("NoBasePath", func(t *testing.T) {
-		fileName, err := imp.DestinationFilename(rawFile, rawFile, "")
+		fileName, err := imp.DestinationFilename(rawFile, rawFile, ImportOptions{})
 
 		if err != nil {
 			t.Fatal(err)
@@ -47,7 +49,7 @@ func TestImport_DestinationFilename(t *testing.T) {
 		assert.Equal(t, cfg.OriginalsPath()+"/2019/07/20190705_153230_C167C6FD.cr2", fileName)
 	})
 	t.Run("WithBasePath", func(t *testing.T) {
-		fileName, err := imp.DestinationFilename(rawFile, rawFile, "users/guest")
+		fileName, err := imp.DestinationFilename(rawFile, rawFile, ImportOptions{DestFolder: "users/guest"})
 
 		if err != nil {
 			t.Fatal(err)
@@ -55,6 +57,19 @@ func TestImport_DestinationFilename(t *testing.T) {
 
 		assert.Equal(t, cfg.OriginalsPath()+"/users/guest/2019/07/20190705_153230_C167C6FD.cr2", fileName)
 	})
+	t.Run("Template", func(t *testing.T) {
+		settings := cfg.Settings()
+		settings.Import.Template = "{type}/{album|Unsorted}"
+		defer func() { settings.Import.Template = "" }()
+
+		fileName, err := imp.DestinationFilename(rawFile, rawFile, ImportOptions{Path: cfg.ImportPath()})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, cfg.OriginalsPath()+"/raw/Unsorted/20190705_153230_C167C6FD.cr2", fileName)
+	})
 }
 
 func TestImport_Start(t *testing.T) {
@@ -77,3 +92,43 @@ func TestImport_Start(t *testing.T) {
 
 	imp.Start(opt)
 }
+
+func TestImport_Preview(t *testing.T) {
+	cfg := config.TestConfig()
+
+	convert := NewConvert(cfg)
+	ind := NewIndex(cfg, convert, NewFiles(), NewPhotos())
+	imp := NewImport(cfg, ind, convert)
+
+	t.Run("Template", func(t *testing.T) {
+		importPath := t.TempDir()
+
+		if err := fs.Copy(cfg.ExamplesPath()+"/elephants.jpg", filepath.Join(importPath, "Safari", "elephants.jpg"), false); err != nil {
+			t.Fatal(err)
+		}
+
+		settings := cfg.Settings()
+		settings.Import.Template = "{folder}/{make}"
+		defer func() { settings.Import.Template = "" }()
+
+		result, err := imp.Preview(ImportOptions{Path: importPath})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if assert.Len(t, result, 1) {
+			assert.Equal(t, "Safari/elephants.jpg", result[0].Source)
+
+			// The file may already have been indexed by other tests.
+			if result[0].Skip == "" {
+				assert.Equal(t, "Safari/Canon/20131126_135355_CE96833B.jpg", result[0].Dest)
+				assert.False(t, fs.FileExists(filepath.Join(cfg.OriginalsPath(), result[0].Dest)))
+			}
+		}
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := imp.Preview(ImportOptions{Path: "/foo/bar/baz"})
+		assert.Error(t, err)
+	})
+}
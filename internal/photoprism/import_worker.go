@@ -58,6 +58,15 @@ func ImportWorker(jobs <-chan ImportJob) {
 			"subFolder": opt.DestFolder,
 		})
 
+		// Files uploaded by users, e.g. via WebDAV, belong to the user who uploaded them.
+		userUID := opt.UID
+
+		for _, f := range related.Files {
+			if uploadedBy := entity.RemovePendingUpload(f.FileName()); uploadedBy != "" {
+				userUID = uploadedBy
+			}
+		}
+
 		// Create JSON sidecar file, if needed.
 		if jsonErr := related.Main.CreateExifToolJson(imp.convert); jsonErr != nil {
 			log.Warnf("import: %s", clean.Error(jsonErr))
@@ -66,7 +75,7 @@ func ImportWorker(jobs <-chan ImportJob) {
 		for _, f := range related.Files {
 			relFileName := f.RelName(src)
 
-			if destFileName, err := imp.DestinationFilename(related.Main, f, opt.DestFolder); err == nil {
+			if destFileName, err := imp.DestinationFilename(related.Main, f, opt); err == nil {
 				destDir := filepath.Dir(destFileName)
 
 				// Remember the original filenames of related files, so they can later be indexed and searched.
@@ -114,7 +123,7 @@ func ImportWorker(jobs <-chan ImportJob) {
 					// Do nothing.
 				} else if file, fileErr := entity.FirstFileByHash(fileHash); fileErr != nil {
 					// Do nothing.
-				} else if albumErr := entity.AddPhotoToUserAlbums(file.PhotoUID, opt.Albums, imp.conf.Settings().Albums.Order.Album, opt.UID); albumErr != nil {
+				} else if albumErr := entity.AddPhotoToUserAlbums(file.PhotoUID, opt.Albums, imp.conf.Settings().Albums.Order.Album, userUID); albumErr != nil {
 					log.Warn(albumErr)
 				}
 
@@ -202,7 +211,7 @@ func ImportWorker(jobs <-chan ImportJob) {
 				}
 
 				// Index main MediaFile.
-				res := ind.UserMediaFile(main, o, originalName, "", opt.UID)
+				res := ind.UserMediaFile(main, o, originalName, "", userUID)
 
 				// Log result.
 				log.Infof("import: %s main %s file %s", res, main.FileType(), clean.Log(main.RootRelName()))
@@ -215,7 +224,7 @@ func ImportWorker(jobs <-chan ImportJob) {
 					photoUID = res.PhotoUID
 
 					// Add photo to album if a list of albums was provided when importing.
-					if albumErr := entity.AddPhotoToUserAlbums(photoUID, opt.Albums, imp.conf.Settings().Albums.Order.Album, opt.UID); albumErr != nil {
+					if albumErr := entity.AddPhotoToUserAlbums(photoUID, opt.Albums, imp.conf.Settings().Albums.Order.Album, userUID); albumErr != nil {
 						log.Warn(albumErr)
 					}
 				}
@@ -252,7 +261,7 @@ func ImportWorker(jobs <-chan ImportJob) {
 				}
 
 				// Index related media file including its original filename.
-				res := ind.UserMediaFile(file, o, relatedOriginalNames[file.FileName()], photoUID, opt.UID)
+				res := ind.UserMediaFile(file, o, relatedOriginalNames[file.FileName()], photoUID, userUID)
 
 				// Save file error.
 				if fileUid, fileErr := res.FileError(); fileErr != nil {
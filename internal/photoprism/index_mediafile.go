@@ -38,6 +38,11 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 		return result
 	}
 
+	// Files uploaded by users, e.g. via WebDAV, belong to the user who uploaded them.
+	if uploadedBy := entity.RemovePendingUpload(m.FileName()); uploadedBy != "" {
+		userUID = uploadedBy
+	}
+
 	// Skip file?
 	if ind.files.Ignore(m.RootRelName(), m.Root(), m.ModTime(), o.Rescan) {
 		// Skip known file.
@@ -81,6 +86,8 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 
 	photoExists := false
 
+	var restoreComments entity.Comments
+
 	event.Publish("index.indexing", event.Data{
 		"uid":      o.UID,
 		"action":   o.Action,
@@ -271,6 +278,7 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 				log.Errorf("index: %s in %s (restore from yaml)", err.Error(), logName)
 			} else if photo.HasUID() {
 				photoExists = true
+				restoreComments = photo.Comments
 				log.Infof("index: metadata of photo uid %s restored from %s", photo.PhotoUID, clean.Log(filepath.Base(yamlName)))
 			}
 		}
@@ -405,6 +413,13 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 			}
 		}
 
+		// Update perceptual hash to find visually similar pictures.
+		if hash, hashErr := m.PerceptualHash(ind.thumbPath()); hashErr != nil {
+			log.Debugf("%s while calculating perceptual hash", hashErr.Error())
+		} else {
+			file.FilePhash = hash.Hex()
+		}
+
 		// Update resolution and aspect ratio.
 		if m.Width() > 0 && m.Height() > 0 {
 			file.FileWidth = m.Width()
@@ -475,7 +490,10 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 			photo.SetMediaType(media.Image, entity.SrcAuto)
 		}
 	case m.IsXMP():
-		if data, dataErr := meta.XMP(m.FileName()); dataErr == nil {
+		if meta.XmpGenerated(m.FileName()) {
+			// Skip sidecar files written by PhotoPrism that have not been edited by other applications.
+			log.Debugf("index: skipped metadata from generated sidecar %s", clean.Log(m.RootRelName()))
+		} else if data, dataErr := meta.XMP(m.FileName()); dataErr == nil {
 			// Update basic metadata.
 			photo.SetTitle(data.Title, entity.SrcXmp)
 			photo.SetCaption(data.Caption, entity.SrcXmp)
@@ -794,6 +812,7 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 			file.FileLuminance = primaryFile.FileLuminance
 			file.FileDiff = primaryFile.FileDiff
 			file.FileChroma = primaryFile.FileChroma
+			file.FilePhash = primaryFile.FilePhash
 		}
 	}
 
@@ -919,6 +938,11 @@ func (ind *Index) UserMediaFile(m *MediaFile, o IndexOptions, originalName, phot
 			photo = *p
 		}
 
+		// Restore comments from the YAML sidecar file, if any.
+		if len(restoreComments) > 0 {
+			restoreComments.Restore(photo.PhotoUID)
+		}
+
 		if photo.PhotoPrivate {
 			event.Publish("count.private", event.Data{
 				"count": 1,
@@ -1,12 +1,19 @@
 package photoprism
 
 import (
+	"bytes"
+	"image"
+	"image/jpeg"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
 )
 
 func TestIndex_MediaFile(t *testing.T) {
@@ -70,6 +77,60 @@ func TestIndex_MediaFile(t *testing.T) {
 		assert.Equal(t, "Blue Gopher", mediaFile.metaData.Title)
 		assert.Equal(t, IndexStatus("added"), result.Status)
 	})
+	t.Run("PendingUpload", func(t *testing.T) {
+		cfg := config.TestConfig()
+
+		initErr := cfg.InitializeTestData()
+		assert.NoError(t, initErr)
+
+		// Create a new image, so that it is not a duplicate of an indexed file.
+		img := image.NewRGBA(image.Rect(0, 0, 64, 48))
+
+		for i := range img.Pix {
+			img.Pix[i] = byte(rand.IntN(256))
+		}
+
+		fileName := filepath.Join(cfg.OriginalsPath(), "webdav-upload", "pending.jpg")
+
+		if err := fs.MkdirAll(filepath.Dir(fileName)); err != nil {
+			t.Fatal(err)
+		}
+
+		var buf bytes.Buffer
+
+		if err := jpeg.Encode(&buf, img, nil); err != nil {
+			t.Fatal(err)
+		} else if err = os.WriteFile(fileName, buf.Bytes(), fs.ModeFile); err != nil {
+			t.Fatal(err)
+		}
+
+		alice := entity.UserFixtures.Pointer("alice")
+		entity.AddPendingUpload(fileName, alice.UserUID, int64(buf.Len()))
+
+		convert := NewConvert(cfg)
+
+		ind := NewIndex(cfg, convert, NewFiles(), NewPhotos())
+		indexOpt := IndexOptionsAll(cfg)
+		mediaFile, err := NewMediaFile(fileName)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		result := ind.MediaFile(mediaFile, indexOpt, "", "")
+
+		assert.Equal(t, IndexStatus("added"), result.Status)
+
+		photo := entity.FindPhoto(entity.Photo{PhotoUID: result.PhotoUID})
+
+		if assert.NotNil(t, photo) {
+			assert.Equal(t, alice.UserUID, photo.CreatedBy)
+		}
+
+		size, files := entity.PendingUploadUsage(alice.UserUID)
+		assert.Equal(t, int64(0), size)
+		assert.Equal(t, 0, files)
+	})
 	t.Run("Error", func(t *testing.T) {
 		cfg := config.TestConfig()
 
@@ -2,6 +2,7 @@ package photoprism
 
 import (
 	"errors"
+	"strings"
 	"time"
 
 	"github.com/dustin/go-humanize/english"
@@ -56,6 +57,57 @@ func (m *MediaFile) GenerateCaption(captionSrc entity.Src) (caption *vision.Capt
 	return caption, err
 }
 
+// GenerateEmbedding generates an embedding of the media file for semantic search using the
+// active vision model. Models that cannot process image data embed the provided text instead.
+func (m *MediaFile) GenerateEmbedding(text string) (result *vision.EmbeddingResult, err error) {
+	start := time.Now()
+
+	model := vision.Config.Model(vision.ModelTypeEmbedding)
+
+	// No embedding model configured or usable.
+	if model == nil {
+		return result, errors.New("no embedding model configured")
+	}
+
+	if model.EmbedsImages() {
+		size := vision.Thumb(vision.ModelTypeEmbedding)
+
+		// Get the thumbnail filename for the selected size.
+		fileName, fileErr := m.Thumbnail(Config().ThumbCachePath(), size.Name)
+
+		if fileErr != nil {
+			return result, fileErr
+		}
+
+		result, _, err = vision.GenerateEmbedding(vision.Files{fileName}, media.SrcLocal)
+	} else if text = strings.TrimSpace(text); text == "" {
+		return result, errors.New("no text to embed")
+	} else {
+		result, _, err = vision.GenerateTextEmbedding(text)
+	}
+
+	if err == nil {
+		log.Debugf("vision: generated embedding for %s [%s]", clean.Log(m.RootRelName()), time.Since(start))
+	}
+
+	return result, err
+}
+
+// SavePhotoEmbedding generates and saves the embedding of a photo for semantic search.
+func SavePhotoEmbedding(photo *entity.Photo, file *MediaFile) error {
+	if photo == nil || file == nil {
+		return errors.New("photo and file must not be nil")
+	}
+
+	result, err := file.GenerateEmbedding(photo.EmbeddingText())
+
+	if err != nil {
+		return err
+	}
+
+	return entity.NewPhotoEmbedding(photo, result.Model, result.Vector()).Save()
+}
+
 // GenerateLabels classifies the media file and returns matching labels. When labelSrc
 // is SrcAuto the model's declared source is used; otherwise the provided source
 // is applied to every returned label.
@@ -0,0 +1,68 @@
+package photoprism
+
+import (
+	"fmt"
+
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/thumb"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/media/phash"
+)
+
+// PerceptualHash returns the perceptual hash of an image, based on an existing thumbnail,
+// which can be used to find visually similar pictures, e.g. resized or re-encoded copies.
+func (m *MediaFile) PerceptualHash(thumbPath string) (hash phash.Hash, err error) {
+	if !m.IsPreviewImage() || m.IsThumb() {
+		return hash, fmt.Errorf("%s is not a jpeg", clean.Log(m.BaseName()))
+	}
+
+	img, err := m.Resample(thumbPath, thumb.Tile224)
+
+	if err != nil {
+		log.Debugf("phash: %s in %s (resample)", err, clean.Log(m.BaseName()))
+		return hash, err
+	}
+
+	return phash.New(img), nil
+}
+
+// UpdatePerceptualHashes adds the missing perceptual hashes of indexed JPEG and PNG files, e.g. of files
+// indexed with an earlier version, and returns the number of updated files.
+func UpdatePerceptualHashes(thumbPath string) (updated int, err error) {
+	var afterID uint
+
+	for {
+		files, queryErr := query.FilesWithoutPhash(afterID, 1000)
+
+		if queryErr != nil {
+			return updated, queryErr
+		} else if len(files) == 0 {
+			return updated, nil
+		}
+
+		for i := range files {
+			if mutex.MetaWorker.Canceled() {
+				return updated, fmt.Errorf("phash: worker canceled")
+			}
+
+			f := &files[i]
+			afterID = f.ID
+
+			m, fileErr := NewMediaFile(FileName(f.FileRoot, f.FileName))
+
+			if fileErr != nil {
+				log.Debugf("phash: %s", fileErr)
+				continue
+			}
+
+			if hash, hashErr := m.PerceptualHash(thumbPath); hashErr != nil {
+				log.Debugf("phash: %s in %s", hashErr, clean.Log(f.FileName))
+			} else if updateErr := f.Update("FilePhash", hash.Hex()); updateErr != nil {
+				log.Warnf("phash: %s in %s (update)", updateErr, clean.Log(f.FileName))
+			} else {
+				updated++
+			}
+		}
+	}
+}
@@ -0,0 +1,92 @@
+package photoprism
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestMediaFile_PerceptualHash(t *testing.T) {
+	c := config.TestConfig()
+
+	t.Run("Jpeg", func(t *testing.T) {
+		cat, err := NewMediaFile(c.ExamplesPath() + "/cat_brown.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		fern, err := NewMediaFile(c.ExamplesPath() + "/fern_green.jpg")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		catHash, err := cat.PerceptualHash(c.ThumbCachePath())
+		assert.NoError(t, err)
+		assert.Len(t, catHash.Hex(), 16)
+
+		catHash2, err := cat.PerceptualHash(c.ThumbCachePath())
+		assert.NoError(t, err)
+		assert.Equal(t, catHash, catHash2)
+
+		fernHash, err := fern.PerceptualHash(c.ThumbCachePath())
+		assert.NoError(t, err)
+		assert.Greater(t, catHash.Distance(fernHash), 8)
+	})
+	t.Run("Json", func(t *testing.T) {
+		mediaFile, err := NewMediaFile("testdata/2015-02-04.jpg.json")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = mediaFile.PerceptualHash(c.ThumbCachePath())
+		assert.Error(t, err)
+	})
+}
+
+func TestUpdatePerceptualHashes(t *testing.T) {
+	c := config.TestConfig()
+
+	fileName := filepath.Join(c.OriginalsPath(), "phash", "cat_brown.jpg")
+
+	if err := fs.Copy(filepath.Join(c.ExamplesPath(), "cat_brown.jpg"), fileName, true); err != nil {
+		t.Fatal(err)
+	}
+
+	defer os.RemoveAll(filepath.Dir(fileName))
+
+	file := &entity.File{
+		PhotoID:  1000000,
+		FileRoot: entity.RootOriginals,
+		FileName: "phash/cat_brown.jpg",
+		FileHash: "a1b2c3d4e5f6a7b8c9d0e1f2a3b4c5d6e7f8a9b0",
+		FileType: fs.ImageJpeg.String(),
+	}
+
+	if err := file.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer func() { _ = file.DeletePermanently() }()
+
+	updated, err := UpdatePerceptualHashes(c.ThumbCachePath())
+
+	assert.NoError(t, err)
+	assert.GreaterOrEqual(t, updated, 1)
+
+	result, err := entity.FirstFileByHash(file.FileHash)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Len(t, result.FilePhash, 16)
+}
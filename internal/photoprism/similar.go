@@ -0,0 +1,181 @@
+package photoprism
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/media/phash"
+)
+
+// Actions for resolving visually similar pictures.
+const (
+	SimilarArchive = "archive" // Archive all pictures except the one to keep.
+	SimilarStack   = "stack"   // Stack all pictures with the one to keep.
+)
+
+// SimilarPhoto represents a picture in a group of visually similar pictures.
+type SimilarPhoto struct {
+	query.SimilarPhoto
+	Distance int `json:"Distance"`
+}
+
+// SimilarGroup represents a group of visually similar pictures.
+type SimilarGroup struct {
+	Best   string         `json:"Best"`
+	Photos []SimilarPhoto `json:"Photos"`
+}
+
+// Similar finds and resolves visually similar pictures based on the perceptual hashes of their primary files.
+type Similar struct {
+	conf *config.Config
+}
+
+// NewSimilar returns a new Similar worker.
+func NewSimilar(conf *config.Config) *Similar {
+	return &Similar{conf: conf}
+}
+
+// Groups finds groups of visually similar pictures, whose perceptual hashes do not exceed
+// the maximum distance or the configured default if it is 0, and caches the result.
+func (w *Similar) Groups(maxDistance int) (groups []SimilarGroup, err error) {
+	if maxDistance <= 0 {
+		maxDistance = w.conf.SimilarDistance()
+	}
+
+	photos, err := query.SimilarPhotos()
+
+	if err != nil {
+		return groups, err
+	}
+
+	hashes := make([]phash.Hash, 0, len(photos))
+	valid := make([]query.SimilarPhoto, 0, len(photos))
+
+	for _, p := range photos {
+		if h, parseErr := phash.Parse(p.FilePhash); parseErr != nil {
+			log.Debugf("similar: %s in %s", parseErr, clean.Log(p.PhotoUID))
+		} else {
+			hashes = append(hashes, h)
+			valid = append(valid, p)
+		}
+	}
+
+	for _, indexes := range phash.Group(hashes, maxDistance) {
+		center := hashes[indexes[0]]
+		group := SimilarGroup{Photos: make([]SimilarPhoto, len(indexes))}
+
+		for i, index := range indexes {
+			group.Photos[i] = SimilarPhoto{SimilarPhoto: valid[index], Distance: center.Distance(hashes[index])}
+		}
+
+		group.Best = BestSimilarPhoto(group.Photos).PhotoUID
+		groups = append(groups, group)
+	}
+
+	similarCache.SetDefault(strconv.Itoa(maxDistance), groups)
+
+	return groups, nil
+}
+
+// BestSimilarPhoto returns the picture with the highest resolution, quality score, and file size.
+func BestSimilarPhoto(photos []SimilarPhoto) (best SimilarPhoto) {
+	for i, p := range photos {
+		switch {
+		case i == 0:
+			best = p
+		case p.Pixels() != best.Pixels():
+			if p.Pixels() > best.Pixels() {
+				best = p
+			}
+		case p.PhotoQuality != best.PhotoQuality:
+			if p.PhotoQuality > best.PhotoQuality {
+				best = p
+			}
+		case p.FileSize > best.FileSize:
+			best = p
+		}
+	}
+
+	return best
+}
+
+// Resolve archives or stacks the specified pictures, except the one to keep.
+// If no picture to keep is specified, the best one is kept.
+func (w *Similar) Resolve(uids []string, keep, action string) (kept *entity.Photo, resolved entity.Photos, err error) {
+	if action != SimilarArchive && action != SimilarStack {
+		return nil, resolved, fmt.Errorf("similar: unknown action %s", clean.Log(action))
+	} else if len(uids) < 2 {
+		return nil, resolved, errors.New("similar: at least two pictures are required")
+	}
+
+	// Keep the best picture if none was specified.
+	if keep == "" {
+		results, queryErr := query.SimilarPhotos(uids...)
+
+		if queryErr != nil {
+			return nil, resolved, queryErr
+		} else if len(results) == 0 {
+			return nil, resolved, errors.New("similar: pictures not found")
+		}
+
+		photos := make([]SimilarPhoto, len(results))
+
+		for i := range results {
+			photos[i] = SimilarPhoto{SimilarPhoto: results[i]}
+		}
+
+		keep = BestSimilarPhoto(photos).PhotoUID
+	}
+
+	photos, err := query.PhotoPreloadByUIDs(append([]string{keep}, uids...))
+
+	if err != nil {
+		return nil, resolved, err
+	}
+
+	others := make(entity.Photos, 0, len(photos))
+
+	for _, p := range photos {
+		if p == nil {
+			continue
+		} else if p.PhotoUID == keep {
+			kept = p
+		} else if p.DeletedAt == nil {
+			others = append(others, p)
+		}
+	}
+
+	if kept == nil {
+		return nil, resolved, fmt.Errorf("similar: picture %s not found", clean.Log(keep))
+	}
+
+	switch action {
+	case SimilarArchive:
+		for _, p := range others {
+			if archiveErr := p.Archive(); archiveErr != nil {
+				log.Errorf("similar: %s in %s (archive)", clean.Error(archiveErr), p.String())
+				err = archiveErr
+			} else {
+				resolved = append(resolved, p)
+			}
+		}
+	case SimilarStack:
+		resolved, err = kept.Stack(others)
+	}
+
+	// Find groups again the next time they are requested.
+	if len(resolved) > 0 {
+		FlushSimilarCache()
+	}
+
+	log.Infof("similar: resolved %s similar to %s (%s)", english.Plural(len(resolved), "picture", "pictures"), kept.String(), action)
+
+	return kept, resolved, err
+}
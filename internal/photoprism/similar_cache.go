@@ -0,0 +1,32 @@
+package photoprism
+
+import (
+	"strconv"
+	"time"
+
+	gc "github.com/patrickmn/go-cache"
+)
+
+// similarCache caches groups of visually similar pictures by maximum distance, so that they are
+// not found again for each page of results.
+var similarCache = gc.New(15*time.Minute, 5*time.Minute)
+
+// FlushSimilarCache clears the cached groups of visually similar pictures.
+func FlushSimilarCache() {
+	similarCache.Flush()
+}
+
+// CachedGroups returns the cached groups of visually similar pictures for the maximum distance,
+// or finds them if they are not cached yet.
+func (w *Similar) CachedGroups(maxDistance int) (groups []SimilarGroup, err error) {
+	if maxDistance <= 0 {
+		maxDistance = w.conf.SimilarDistance()
+	}
+
+	if cacheData, hit := similarCache.Get(strconv.Itoa(maxDistance)); hit {
+		log.Tracef("similar: cache hit for distance %d", maxDistance)
+		return cacheData.([]SimilarGroup), nil
+	}
+
+	return w.Groups(maxDistance)
+}
@@ -0,0 +1,150 @@
+package photoprism
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+)
+
+// createSimilarPhoto adds a photo with a primary file that has the specified perceptual hash.
+func createSimilarPhoto(t *testing.T, name, hash string, width int) *entity.Photo {
+	t.Helper()
+
+	photo := &entity.Photo{PhotoName: name, PhotoPath: "similar", TakenAt: time.Date(1850, 1, 1, 0, 0, 0, 0, time.UTC), TakenSrc: entity.SrcMeta}
+
+	if err := photo.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	file := &entity.File{
+		PhotoID:     photo.ID,
+		PhotoUID:    photo.PhotoUID,
+		FileName:    "similar/" + name + ".jpg",
+		FileRoot:    entity.RootOriginals,
+		FileHash:    name,
+		FileWidth:   width,
+		FileHeight:  width / 2,
+		FilePrimary: true,
+		FilePhash:   hash,
+	}
+
+	if err := file.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	return photo
+}
+
+func TestSimilar(t *testing.T) {
+	conf := config.TestConfig()
+	w := NewSimilar(conf)
+
+	small := createSimilarPhoto(t, "SimilarSmall", "ffffffff00000000", 1000)
+	large := createSimilarPhoto(t, "SimilarLarge", "ffffffff00000001", 4000)
+	other := createSimilarPhoto(t, "SimilarOther", "00000000ffffffff", 2000)
+
+	t.Run("Groups", func(t *testing.T) {
+		groups, err := w.Groups(0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var found *SimilarGroup
+
+		for i := range groups {
+			for _, p := range groups[i].Photos {
+				assert.NotEqual(t, other.PhotoUID, p.PhotoUID)
+
+				if p.PhotoUID == small.PhotoUID {
+					found = &groups[i]
+				}
+			}
+		}
+
+		if found == nil {
+			t.Fatal("group not found")
+		}
+
+		assert.Len(t, found.Photos, 2)
+		assert.Equal(t, large.PhotoUID, found.Best)
+		assert.Equal(t, 0, found.Photos[0].Distance)
+		assert.Equal(t, 1, found.Photos[1].Distance)
+	})
+	t.Run("CachedGroups", func(t *testing.T) {
+		groups, err := w.Groups(0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		cached, err := w.CachedGroups(0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, groups, cached)
+	})
+	t.Run("InvalidAction", func(t *testing.T) {
+		_, _, err := w.Resolve([]string{small.PhotoUID, large.PhotoUID}, "", "delete")
+		assert.Error(t, err)
+	})
+	t.Run("NotEnough", func(t *testing.T) {
+		_, _, err := w.Resolve([]string{small.PhotoUID}, "", SimilarArchive)
+		assert.Error(t, err)
+	})
+	t.Run("Archive", func(t *testing.T) {
+		kept, resolved, err := w.Resolve([]string{small.PhotoUID, large.PhotoUID}, "", SimilarArchive)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, large.PhotoUID, kept.PhotoUID)
+		assert.Len(t, resolved, 1)
+		assert.Equal(t, small.PhotoUID, resolved[0].PhotoUID)
+		assert.Zero(t, similarCache.ItemCount())
+
+		if archived, findErr := query.PhotoByUID(small.PhotoUID); findErr != nil {
+			t.Fatal(findErr)
+		} else {
+			assert.NotNil(t, archived.DeletedAt)
+		}
+	})
+	t.Run("Stack", func(t *testing.T) {
+		kept, resolved, err := w.Resolve([]string{other.PhotoUID, large.PhotoUID}, other.PhotoUID, SimilarStack)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, other.PhotoUID, kept.PhotoUID)
+		assert.Len(t, resolved, 1)
+
+		file, fileErr := query.FileByHash("SimilarLarge")
+
+		if fileErr != nil {
+			t.Fatal(fileErr)
+		}
+
+		assert.Equal(t, other.PhotoUID, file.PhotoUID)
+	})
+}
+
+func TestBestSimilarPhoto(t *testing.T) {
+	photos := []SimilarPhoto{
+		{SimilarPhoto: query.SimilarPhoto{PhotoUID: "a", FileWidth: 100, FileHeight: 100, PhotoQuality: 3, FileSize: 10}},
+		{SimilarPhoto: query.SimilarPhoto{PhotoUID: "b", FileWidth: 100, FileHeight: 100, PhotoQuality: 4, FileSize: 5}},
+		{SimilarPhoto: query.SimilarPhoto{PhotoUID: "c", FileWidth: 100, FileHeight: 100, PhotoQuality: 4, FileSize: 20}},
+		{SimilarPhoto: query.SimilarPhoto{PhotoUID: "d", FileWidth: 50, FileHeight: 100, PhotoQuality: 5, FileSize: 50}},
+	}
+
+	assert.Equal(t, "c", BestSimilarPhoto(photos).PhotoUID)
+	assert.Equal(t, "", BestSimilarPhoto(nil).PhotoUID)
+}
@@ -85,12 +85,36 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	api.AddService(APIv1)
 	api.DeleteService(APIv1)
 	api.UpdateService(APIv1)
+	api.GetServiceConflicts(APIv1)
+	api.ResolveServiceConflicts(APIv1)
+
+	// Webhooks.
+	api.SearchWebhooks(APIv1)
+	api.GetWebhook(APIv1)
+	api.AddWebhook(APIv1)
+	api.UpdateWebhook(APIv1)
+	api.DeleteWebhook(APIv1)
+	api.GetWebhookDeliveries(APIv1)
+	api.ReplayWebhookDeliveries(APIv1)
+
+	// Scheduled Jobs.
+	api.SearchJobs(APIv1)
+	api.GetJob(APIv1)
+	api.AddJob(APIv1)
+	api.UpdateJob(APIv1)
+	api.DeleteJob(APIv1)
+	api.RunJob(APIv1)
+	api.PauseJob(APIv1)
+	api.ResumeJob(APIv1)
+	api.GetJobRuns(APIv1)
 
 	// Thumbnail Images.
 	api.GetThumb(APIv1)
 
 	// Video Streaming.
 	api.GetVideo(APIv1)
+	api.GetVideoHls(APIv1)
+	api.GetVideoHlsFile(APIv1)
 
 	// Downloads.
 	api.GetDownload(APIv1)
@@ -111,6 +135,9 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	api.GetPhoto(APIv1)
 	api.GetPhotoYaml(APIv1)
 	api.UpdatePhoto(APIv1)
+	api.PostGeotag(APIv1)
+	api.SearchSimilarPhotos(APIv1)
+	api.ResolveSimilarPhotos(APIv1)
 	api.GetPhotoDownload(APIv1)
 	// api.GetPhotoLinks(APIv1)
 	// api.CreatePhotoLink(APIv1)
@@ -119,6 +146,13 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	api.ApprovePhoto(APIv1)
 	api.LikePhoto(APIv1)
 	api.DislikePhoto(APIv1)
+	api.GetPhotoComments(APIv1)
+	api.CreatePhotoComment(APIv1)
+	api.UpdatePhotoComment(APIv1)
+	api.DeletePhotoComment(APIv1)
+	api.GetPhotoPermissions(APIv1)
+	api.GrantPhotoPermissions(APIv1)
+	api.RevokePhotoPermissions(APIv1)
 	api.AddPhotoLabel(APIv1)
 	api.RemovePhotoLabel(APIv1)
 	api.UpdatePhotoLabel(APIv1)
@@ -144,8 +178,18 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 	api.CreateAlbumLink(APIv1)
 	api.UpdateAlbumLink(APIv1)
 	api.DeleteAlbumLink(APIv1)
+	api.GetAlbumLinkUploads(APIv1)
+	api.ApproveAlbumLinkUploads(APIv1)
+	api.RejectAlbumLinkUploads(APIv1)
 	api.LikeAlbum(APIv1)
 	api.DislikeAlbum(APIv1)
+	api.GetAlbumComments(APIv1)
+	api.CreateAlbumComment(APIv1)
+	api.UpdateAlbumComment(APIv1)
+	api.DeleteAlbumComment(APIv1)
+	api.GetAlbumPermissions(APIv1)
+	api.GrantAlbumPermissions(APIv1)
+	api.RevokeAlbumPermissions(APIv1)
 	api.CloneAlbums(APIv1)
 	api.AddPhotosToAlbum(APIv1)
 	api.RemovePhotosFromAlbum(APIv1)
@@ -181,6 +225,9 @@ func registerRoutes(router *gin.Engine, conf *config.Config) {
 
 	// Faces.
 	api.SearchFaces(APIv1)
+	api.SearchFaceSuggestions(APIv1)
+	api.ConfirmFaceSuggestions(APIv1)
+	api.RejectFaceSuggestions(APIv1)
 	api.GetFace(APIv1)
 	api.UpdateFace(APIv1)
 
@@ -19,5 +19,6 @@ func registerSharingRoutes(router *gin.Engine, conf *config.Config) {
 		api.ShareToken(s)
 		api.ShareTokenShared(s)
 		api.SharePreview(s)
+		api.ShareUpload(s)
 	}
 }
@@ -1,6 +1,8 @@
 package server
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -12,6 +14,9 @@ import (
 	"golang.org/x/net/webdav"
 
 	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/workers/auto"
 	"github.com/photoprism/photoprism/pkg/clean"
@@ -20,6 +25,9 @@ import (
 	"github.com/photoprism/photoprism/pkg/txt"
 )
 
+// webdavUserKey is the request context key of the authenticated user.
+type webdavUserKey struct{}
+
 // WebDAVHandler wraps the http request handler so that it can be customized.
 var WebDAVHandler = func(c *gin.Context, router *gin.RouterGroup, srv *webdav.Handler) {
 	srv.ServeHTTP(c.Writer, c.Request)
@@ -55,6 +63,9 @@ func WebDAV(dir string, router *gin.RouterGroup, conf *config.Config) {
 		} else {
 			// Determine the filename if it is an uploaded file and process custom request headers, if any.
 			if fileName := WebDAVFileName(request, router, conf); fileName != "" {
+				// Count the file towards the storage quota of the user until it has been indexed.
+				WebDAVAddPendingUpload(request, fileName)
+
 				// Flag the uploaded file as favorite if the "X-Favorite" header is set to "1".
 				if request.Header.Get(header.XFavorite) == "1" {
 					WebDAVSetFavoriteFlag(fileName)
@@ -70,6 +81,13 @@ func WebDAV(dir string, router *gin.RouterGroup, conf *config.Config) {
 			case MethodPut, MethodPost, MethodPatch, MethodDelete, MethodCopy, MethodMove:
 				log.Infof("webdav: %s %s", clean.Log(request.Method), clean.Log(request.URL.String()))
 
+				// Stop counting deleted files towards the storage quota.
+				if request.Method == MethodDelete {
+					if fileName := webdavRequestPath(request, router, conf); fileName != "" {
+						entity.RemovePendingUploads(fileName)
+					}
+				}
+
 				if router.BasePath() == conf.BaseUri(WebDAVOriginals) {
 					auto.ShouldIndex()
 				} else if router.BasePath() == conf.BaseUri(WebDAVImport) {
@@ -99,6 +117,18 @@ func WebDAV(dir string, router *gin.RouterGroup, conf *config.Config) {
 				c.AbortWithStatus(http.StatusInsufficientStorage)
 				return
 			}
+
+			// Abort if the file would exceed the storage quota of the user.
+			if quotaErr := WebDAVCheckQuota(c); quotaErr != nil {
+				event.AuditWarn([]string{header.ClientIP(c), "webdav", "%s %s", clean.Error(quotaErr)}, clean.Log(c.Request.Method), clean.Log(c.Request.URL.Path))
+				_ = c.AbortWithError(http.StatusInsufficientStorage, quotaErr)
+				return
+			}
+		}
+
+		// Add the authenticated user to the request context, so that uploaded files can be attributed to it.
+		if user, ok := c.Get(gin.AuthUserKey); ok {
+			c.Request = c.Request.WithContext(context.WithValue(c.Request.Context(), webdavUserKey{}, user))
 		}
 
 		// Invoke handler callback.
@@ -147,6 +177,19 @@ func WebDAVFileName(request *http.Request, router *gin.RouterGroup, conf *config
 		return ""
 	}
 
+	fileName = webdavRequestPath(request, router, conf)
+
+	// Check if the file actually exists and return an empty string otherwise.
+	if fileName == "" || !fs.FileExists(fileName) {
+		return ""
+	}
+
+	return fileName
+}
+
+// webdavRequestPath returns the absolute path of the file or folder specified in the request URL,
+// or an empty string if it is invalid.
+func webdavRequestPath(request *http.Request, router *gin.RouterGroup, conf *config.Config) (fileName string) {
 	basePath := router.BasePath()
 
 	// Determine the absolute file path based on the request URL and the configuration.
@@ -174,11 +217,6 @@ func WebDAVFileName(request *http.Request, router *gin.RouterGroup, conf *config
 		return ""
 	}
 
-	// Check if the file actually exists and return an empty string otherwise.
-	if !fs.FileExists(fileName) {
-		return ""
-	}
-
 	return fileName
 }
 
@@ -239,3 +277,55 @@ func WebDAVSetFileMtime(fileName string, mtimeUnix int64) {
 		log.Infof("webdav: set mtime for %s", clean.Log(filepath.Base(fileName)))
 	}
 }
+
+// WebDAVAddPendingUpload remembers the user who uploaded a file, so that it counts towards the storage
+// quota of the account and the user becomes the owner when the file is indexed or imported.
+func WebDAVAddPendingUpload(request *http.Request, fileName string) {
+	user, ok := request.Context().Value(webdavUserKey{}).(*entity.User)
+
+	if !ok || user == nil {
+		return
+	}
+
+	// Ignore files that are not indexed, e.g. text files.
+	if fs.FileType(fileName) == fs.TypeUnknown {
+		return
+	}
+
+	info, err := os.Stat(fileName)
+
+	if err != nil || info.IsDir() {
+		return
+	}
+
+	entity.AddPendingUpload(fileName, user.UserUID, info.Size())
+}
+
+// WebDAVCheckQuota returns entity.ErrQuotaExceeded if the file sent with the request would exceed the storage quota of the user.
+func WebDAVCheckQuota(c *gin.Context) error {
+	user, ok := c.Get(gin.AuthUserKey)
+
+	if !ok {
+		return nil
+	}
+
+	u, ok := user.(*entity.User)
+
+	if !ok || u == nil {
+		return nil
+	}
+
+	size := c.Request.ContentLength
+
+	if size < 0 {
+		size = 0
+	}
+
+	if err := query.CheckUserQuota(u, size, 1); errors.Is(err, entity.ErrQuotaExceeded) {
+		return err
+	} else if err != nil {
+		log.Warnf("webdav: %s (check quota)", err)
+	}
+
+	return nil
+}
@@ -15,6 +15,7 @@ import (
 
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
 	"github.com/photoprism/photoprism/pkg/http/header"
 )
 
@@ -321,3 +322,77 @@ func TestWebDAVWrite_ReadOnlyForbidden(t *testing.T) {
 	r.ServeHTTP(w, req)
 	assert.Equal(t, http.StatusForbidden, w.Code)
 }
+
+func TestWebDAVWrite_UserQuotaExceeded(t *testing.T) {
+	conf := config.NewMinimalTestConfigWithDb("webdav-quota", t.TempDir())
+	if err := conf.CreateDirectories(); err != nil {
+		t.Fatalf("failed to create test directories: %v", err)
+	}
+	r := setupWebDAVRouter(conf)
+
+	alice := entity.UserFixtures.Pointer("alice")
+	quota, err := entity.SetQuota(entity.QuotaUser, alice.UserUID, 4, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = quota.Delete() }()
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(MethodPut, conf.BaseUri(WebDAVOriginals)+"/quota.txt", bytes.NewBufferString("hello"))
+	authBearer(req)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusInsufficientStorage, w.Code)
+	_, statErr := os.Stat(filepath.Join(conf.OriginalsPath(), "quota.txt"))
+	assert.True(t, os.IsNotExist(statErr))
+}
+
+func TestWebDAVWrite_UserQuotaPendingUploads(t *testing.T) {
+	conf := config.NewMinimalTestConfigWithDb("webdav-quota-pending", t.TempDir())
+	if err := conf.CreateDirectories(); err != nil {
+		t.Fatalf("failed to create test directories: %v", err)
+	}
+	r := setupWebDAVRouter(conf)
+
+	alice := entity.UserFixtures.Pointer("alice")
+	usedSize, _, err := query.UserStorageUsage(alice.UserUID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pendingSize, pendingFiles := entity.PendingUploadUsage(alice.UserUID)
+	quota, err := entity.SetQuota(entity.QuotaUser, alice.UserUID, usedSize+12, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() { _ = quota.Delete() }()
+	defer entity.RemovePendingUploads(conf.OriginalsPath())
+
+	put := func(name string) int {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(MethodPut, conf.BaseUri(WebDAVOriginals)+"/"+name, bytes.NewBufferString("hello"))
+		authBearer(req)
+		r.ServeHTTP(w, req)
+		return w.Code
+	}
+
+	// Files that have not been indexed yet count towards the quota.
+	assert.Equal(t, http.StatusCreated, put("pending1.jpg"))
+	assert.Equal(t, http.StatusCreated, put("pending2.jpg"))
+	assert.Equal(t, http.StatusInsufficientStorage, put("pending3.jpg"))
+	_, statErr := os.Stat(filepath.Join(conf.OriginalsPath(), "pending3.jpg"))
+	assert.True(t, os.IsNotExist(statErr))
+
+	size, files := entity.PendingUploadUsage(alice.UserUID)
+	assert.Equal(t, pendingSize+10, size)
+	assert.Equal(t, pendingFiles+2, files)
+
+	// Deleted files no longer count towards the quota.
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(MethodDelete, conf.BaseUri(WebDAVOriginals)+"/pending2.jpg", nil)
+	authBearer(req)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	assert.Equal(t, http.StatusCreated, put("pending3.jpg"))
+
+	// The uploaded files are attributed to the user when they are indexed.
+	assert.Equal(t, alice.UserUID, entity.RemovePendingUpload(filepath.Join(conf.OriginalsPath(), "pending1.jpg")))
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Client represents a remote service client that can be used to share and synchronize files.
+// Close must be called once the client is no longer needed, so that open connections are released.
+type Client interface {
+	Files(dir string, recursive bool) (fs.FileInfos, error)
+	Directories(dir string, recursive bool, timeout time.Duration) (fs.FileInfos, error)
+	MkdirAll(dir string) error
+	Upload(src, dest string) error
+	Download(src, dest string, force bool) error
+	Delete(name string) error
+	Close() error
+}
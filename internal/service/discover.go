@@ -3,6 +3,7 @@ package service
 import (
 	"errors"
 	"net/url"
+	"path"
 	"strings"
 
 	"github.com/photoprism/photoprism/pkg/txt"
@@ -24,6 +25,11 @@ func Discover(rawUrl, user, pass string) (result Account, err error) {
 		return result, errors.New("service URL is empty")
 	}
 
+	// Absolute paths refer to local folders, e.g. mounted SMB or NFS shares.
+	if strings.HasPrefix(rawUrl, "/") {
+		rawUrl = "file://" + rawUrl
+	}
+
 	u, err := url.Parse(rawUrl)
 
 	if err != nil {
@@ -54,14 +60,16 @@ func Discover(rawUrl, user, pass string) (result Account, err error) {
 	}
 
 	for _, h := range Heuristics {
-		if !h.MatchDomain(u.Host) {
+		if !h.MatchScheme(u.Scheme) || !h.MatchDomain(u.Host) {
 			continue
 		}
 
 		if serviceUrl := h.Discover(u.String(), result.AccUser); serviceUrl != nil {
 			serviceUrl.User = nil
 
-			if w := txt.Keywords(serviceUrl.Host); len(w) > 0 {
+			if serviceUrl.Host == "" {
+				result.AccName = txt.Title(path.Base(serviceUrl.Path))
+			} else if w := txt.Keywords(serviceUrl.Host); len(w) > 0 {
 				result.AccName = txt.Title(w[0])
 			} else {
 				result.AccName = serviceUrl.Host
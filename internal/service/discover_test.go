@@ -1,9 +1,16 @@
 package service
 
 import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/service/local"
 )
 
 func TestDiscover(t *testing.T) {
@@ -79,6 +86,115 @@ func TestDiscover(t *testing.T) {
 		assert.Equal(t, "admin", r.AccUser)
 		assert.Equal(t, "photoprism", r.AccPass)
 	})
+	t.Run("S3", func(t *testing.T) {
+		// Anonymous requests are denied, but the response headers identify the service.
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Amz-Request-Id", "17A3B5C8D9E0F123")
+			w.WriteHeader(http.StatusForbidden)
+		}))
+		defer ts.Close()
+
+		r, err := Discover(ts.URL+"/photos?region=eu-central-1", "minioadmin", "secret")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "s3", r.AccType)
+		assert.Equal(t, ts.URL+"/photos?region=eu-central-1", r.AccURL)
+		assert.Equal(t, "minioadmin", r.AccUser)
+		assert.Equal(t, "secret", r.AccPass)
+	})
+	t.Run("WebDAVWithAmzHeader", func(t *testing.T) {
+		// WebDAV servers behind S3-compatible gateways may also send this header.
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Amz-Request-Id", "17A3B5C8D9E0F123")
+
+			if r.Method == "PROPFIND" {
+				w.WriteHeader(http.StatusMultiStatus)
+			} else {
+				w.WriteHeader(http.StatusForbidden)
+			}
+		}))
+		defer ts.Close()
+
+		r, err := Discover(ts.URL+"/", "admin", "photoprism")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "webdav", r.AccType)
+		assert.Equal(t, ts.URL+"/", r.AccURL)
+	})
+	t.Run("SFTP", func(t *testing.T) {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer listener.Close()
+
+		go func() {
+			for {
+				conn, acceptErr := listener.Accept()
+
+				if acceptErr != nil {
+					return
+				}
+
+				_, _ = conn.Write([]byte("SSH-2.0-OpenSSH_9.6\r\n"))
+				_ = conn.Close()
+			}
+		}()
+
+		r, err := Discover("sftp://alice@"+listener.Addr().String()+"/volume1/photos", "", "secret")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "sftp", r.AccType)
+		assert.Equal(t, "sftp://"+listener.Addr().String()+"/volume1/photos", r.AccURL)
+		assert.Equal(t, "alice", r.AccUser)
+		assert.Equal(t, "secret", r.AccPass)
+	})
+	t.Run("SFTPNotFound", func(t *testing.T) {
+		_, err := Discover("sftp://127.0.0.1:1/photos", "alice", "secret")
+
+		assert.Error(t, err)
+	})
+	t.Run("Local", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "photos")
+
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			t.Fatal(err)
+		}
+
+		local.Roots = []string{filepath.Dir(dir)}
+		defer func() { local.Roots = nil }()
+
+		r, err := Discover(dir, "", "")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "Photos", r.AccName)
+		assert.Equal(t, "local", r.AccType)
+		assert.Equal(t, "file://"+dir, r.AccURL)
+	})
+	t.Run("LocalNotFound", func(t *testing.T) {
+		_, err := Discover("file://"+filepath.Join(t.TempDir(), "missing"), "", "")
+
+		assert.Error(t, err)
+	})
+	t.Run("LocalNotAllowed", func(t *testing.T) {
+		_, err := Discover("file://"+t.TempDir(), "", "")
+
+		assert.Error(t, err)
+	})
 	t.Run("Facebook", func(t *testing.T) {
 		r, err := Discover("https://www.facebook.com/terms", "test", "")
 
@@ -0,0 +1,91 @@
+package geonames
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/geo/s2"
+)
+
+var (
+	index        *Index
+	indexFile    string
+	indexModTime time.Time
+	indexMutex   = sync.Mutex{}
+)
+
+// Cell returns location details for the specified S2 cell ID based on the nearest city.
+func Cell(id string) (result Location, err error) {
+	// Normalize S2 Cell ID.
+	id = s2.NormalizeToken(id)
+
+	// Valid?
+	if len(id) == 0 {
+		return result, fmt.Errorf("empty cell id")
+	} else if n := len(id); n < 4 || n > 16 {
+		return result, fmt.Errorf("invalid cell id %s", clean.Log(id))
+	}
+
+	// Convert S2 Cell ID to latitude and longitude.
+	lat, lng := s2.LatLng(id)
+
+	// Return if latitude and longitude are null.
+	if lat == 0.0 || lng == 0.0 {
+		return result, fmt.Errorf("skipping lat %f, lng %f", lat, lng)
+	}
+
+	idx, err := DefaultIndex()
+
+	if err != nil {
+		return result, err
+	}
+
+	city, _, found := idx.Nearest(lat, lng)
+
+	if !found {
+		return result, fmt.Errorf("no city found near %s", clean.Log(id))
+	}
+
+	return Location{ID: id, Nearest: city}, nil
+}
+
+// DefaultIndex returns the index of the dataset specified by FileName,
+// and reloads it if the file has been updated.
+func DefaultIndex() (*Index, error) {
+	indexMutex.Lock()
+	defer indexMutex.Unlock()
+
+	if FileName == "" {
+		return nil, fmt.Errorf("geonames: no dataset configured")
+	}
+
+	info, err := os.Stat(FileName)
+
+	if err != nil {
+		return nil, fmt.Errorf("geonames: dataset not found, please run the places import command")
+	}
+
+	// Return cached index if the dataset has not changed.
+	if index != nil && indexFile == FileName && indexModTime.Equal(info.ModTime()) {
+		return index, nil
+	}
+
+	start := time.Now()
+
+	cities, err := LoadDataset(FileName)
+
+	if err != nil {
+		return nil, err
+	}
+
+	index = NewIndex(cities)
+	indexFile = FileName
+	indexModTime = info.ModTime()
+
+	log.Infof("geonames: loaded %d cities [%s]", index.Len(), time.Since(start))
+
+	return index, nil
+}
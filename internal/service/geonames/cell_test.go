@@ -0,0 +1,67 @@
+package geonames
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/service/maps"
+	"github.com/photoprism/photoprism/pkg/geo/s2"
+)
+
+func TestCell(t *testing.T) {
+	cities, err := ReadDump("testdata/cities.txt", "testdata/admin1.txt", 1000)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	FileName = filepath.Join(t.TempDir(), "geonames.tsv")
+
+	defer func() { FileName = "" }()
+
+	if err = SaveDataset(FileName, cities); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Neustadt", func(t *testing.T) {
+		l, err := Cell(s2.PrefixedToken(49.35, 8.14))
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "de:gn2864435", l.PlaceID())
+		assert.Equal(t, "Neustadt an der Weinstraße", l.City())
+		assert.Equal(t, "Rheinland-Pfalz", l.State())
+		assert.Equal(t, "de", l.CountryCode())
+		assert.Equal(t, "Neustadt an der Weinstraße, Rheinland-Pfalz, Germany", l.Label())
+		assert.Equal(t, ApiName, l.Source())
+	})
+	t.Run("Geocoder", func(t *testing.T) {
+		l := maps.Location{ID: s2.Token(52.52, 13.40)}
+
+		if err := l.QueryApi(ApiName); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "de:gn2950159", l.PlaceID())
+		assert.Equal(t, "Berlin, Germany", l.Label())
+		assert.Equal(t, "Berlin", l.City())
+		assert.Equal(t, "Germany", l.CountryName())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := Cell(s2.Token(-33.86, 151.21))
+		assert.Error(t, err)
+	})
+	t.Run("InvalidID", func(t *testing.T) {
+		_, err := Cell("s2:")
+		assert.Error(t, err)
+	})
+	t.Run("NoDataset", func(t *testing.T) {
+		FileName = filepath.Join(t.TempDir(), "missing.tsv")
+		_, err := Cell(s2.Token(52.52, 13.40))
+		assert.Error(t, err)
+	})
+}
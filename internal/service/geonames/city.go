@@ -0,0 +1,55 @@
+package geonames
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/service/maps"
+	"github.com/photoprism/photoprism/pkg/geo"
+)
+
+// City represents a populated place from the GeoNames dataset.
+type City struct {
+	ID         int
+	Name       string
+	Lat        float64
+	Lng        float64
+	Country    string
+	State      string
+	Population int
+}
+
+// Cities represents a list of cities.
+type Cities = []City
+
+// PlaceID returns a unique place identifier with country code prefix, like the ones returned by the places service.
+func (c City) PlaceID() string {
+	return fmt.Sprintf("%s:gn%d", c.Country, c.ID)
+}
+
+// CountryName returns the English country name.
+func (c City) CountryName() string {
+	return maps.CountryName(c.Country)
+}
+
+// Label returns the place label consisting of the city, state, and country names.
+func (c City) Label() string {
+	parts := make([]string, 0, 3)
+
+	for _, s := range []string{c.Name, c.State, c.CountryName()} {
+		if s == "" {
+			continue
+		} else if n := len(parts); n > 0 && strings.EqualFold(parts[n-1], s) {
+			continue
+		}
+
+		parts = append(parts, s)
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// Position returns the city coordinates.
+func (c City) Position() geo.Position {
+	return geo.Position{Name: c.Name, Lat: c.Lat, Lng: c.Lng}
+}
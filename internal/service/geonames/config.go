@@ -0,0 +1,18 @@
+package geonames
+
+// ApiName is the reverse geocoding provider name.
+const ApiName = "geonames"
+
+// FileName specifies the prepared dataset file that is loaded on demand.
+var FileName = ""
+
+// IndexLevel specifies the S2 cell level used to index cities. The minimum cell width
+// must not be smaller than MaxDistance, so that all cities within range are found
+// in the same or an adjacent cell, see https://s2geometry.io/resources/s2cell_statistics.html.
+const IndexLevel = 7
+
+// MaxDistance specifies the maximum distance in km to the nearest city (must not exceed 46 km).
+const MaxDistance = 40.0
+
+// MinPopulation specifies the default population threshold for importing cities.
+var MinPopulation = 1000
@@ -0,0 +1,87 @@
+package geonames
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// datasetHeader is the first line of prepared dataset files.
+const datasetHeader = "# id\tname\tlat\tlng\tcountry\tstate\tpopulation"
+
+// SaveDataset writes cities to a prepared dataset file that can be loaded quickly.
+func SaveDataset(fileName string, cities Cities) (err error) {
+	if len(cities) == 0 {
+		return fmt.Errorf("geonames: no cities to save")
+	}
+
+	if err = fs.MkdirAll(filepath.Dir(fileName)); err != nil {
+		return fmt.Errorf("geonames: %s", err)
+	}
+
+	// Write to a temporary file first so that running instances never read a partial dataset.
+	tmpName := fileName + ".tmp"
+
+	f, err := os.OpenFile(tmpName, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, fs.ModeFile) //nolint:gosec // path derived from config
+
+	if err != nil {
+		return fmt.Errorf("geonames: %s", err)
+	}
+
+	w := bufio.NewWriter(f)
+
+	_, _ = fmt.Fprintln(w, datasetHeader)
+
+	for _, c := range cities {
+		_, _ = fmt.Fprintf(w, "%d\t%s\t%.5f\t%.5f\t%s\t%s\t%d\n", c.ID, c.Name, c.Lat, c.Lng, c.Country, c.State, c.Population)
+	}
+
+	if err = w.Flush(); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("geonames: %s", err)
+	} else if err = f.Close(); err != nil {
+		_ = os.Remove(tmpName)
+		return fmt.Errorf("geonames: %s", err)
+	}
+
+	if err = os.Rename(tmpName, fileName); err != nil {
+		return fmt.Errorf("geonames: %s", err)
+	}
+
+	return nil
+}
+
+// LoadDataset reads cities from a prepared dataset file.
+func LoadDataset(fileName string) (result Cities, err error) {
+	err = readLines(fileName, func(fields []string) {
+		if len(fields) < 7 {
+			return
+		}
+
+		id, idErr := strconv.Atoi(fields[0])
+		lat, latErr := strconv.ParseFloat(fields[2], 64)
+		lng, lngErr := strconv.ParseFloat(fields[3], 64)
+		population, _ := strconv.Atoi(fields[6])
+
+		if idErr != nil || latErr != nil || lngErr != nil {
+			return
+		}
+
+		result = append(result, City{
+			ID:         id,
+			Name:       fields[1],
+			Lat:        lat,
+			Lng:        lng,
+			Country:    fields[4],
+			State:      fields[5],
+			Population: population,
+		})
+	})
+
+	return result, err
+}
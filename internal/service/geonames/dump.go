@@ -0,0 +1,149 @@
+package geonames
+
+import (
+	"archive/zip"
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// GeoNames dump columns, see https://download.geonames.org/export/dump/readme.txt.
+const (
+	colID           = 0
+	colName         = 1
+	colLat          = 4
+	colLng          = 5
+	colFeatureClass = 6
+	colCountry      = 8
+	colAdmin1       = 10
+	colPopulation   = 14
+	colCount        = 15
+)
+
+// ReadDump reads populated places from a GeoNames cities dump like cities1000.txt or cities1000.zip,
+// and resolves state names with the admin1CodesASCII.txt file, if specified.
+func ReadDump(citiesFile, admin1File string, minPopulation int) (result Cities, err error) {
+	states := make(map[string]string)
+
+	// Read first-level administrative division names.
+	if admin1File != "" {
+		if err = readLines(admin1File, func(fields []string) {
+			if len(fields) >= 2 && fields[0] != "" {
+				states[strings.ToUpper(fields[0])] = fields[1]
+			}
+		}); err != nil {
+			return result, err
+		}
+	}
+
+	// Read populated places.
+	err = readLines(citiesFile, func(fields []string) {
+		if len(fields) < colCount || fields[colFeatureClass] != "P" {
+			return
+		}
+
+		id, idErr := strconv.Atoi(fields[colID])
+		lat, latErr := strconv.ParseFloat(fields[colLat], 64)
+		lng, lngErr := strconv.ParseFloat(fields[colLng], 64)
+		population, _ := strconv.Atoi(fields[colPopulation])
+
+		if idErr != nil || latErr != nil || lngErr != nil || fields[colName] == "" || len(fields[colCountry]) != 2 {
+			return
+		} else if population < minPopulation {
+			return
+		}
+
+		country := strings.ToUpper(fields[colCountry])
+
+		result = append(result, City{
+			ID:         id,
+			Name:       fields[colName],
+			Lat:        lat,
+			Lng:        lng,
+			Country:    strings.ToLower(country),
+			State:      states[country+"."+strings.ToUpper(fields[colAdmin1])],
+			Population: population,
+		})
+	})
+
+	if err != nil {
+		return result, err
+	} else if len(result) == 0 {
+		return result, fmt.Errorf("geonames: no cities found in %s", clean.Log(filepath.Base(citiesFile)))
+	}
+
+	return result, nil
+}
+
+// readLines calls the handler with the tab-separated fields of each line, skipping comments.
+func readLines(fileName string, handler func(fields []string)) error {
+	if !fs.FileExistsNotEmpty(fileName) {
+		return fmt.Errorf("geonames: %s not found", clean.Log(filepath.Base(fileName)))
+	}
+
+	r, closeFile, err := openDump(fileName)
+
+	if err != nil {
+		return err
+	}
+
+	defer closeFile()
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+
+	for scanner.Scan() {
+		if line := scanner.Text(); line == "" || strings.HasPrefix(line, "#") {
+			continue
+		} else {
+			handler(strings.Split(line, "\t"))
+		}
+	}
+
+	return scanner.Err()
+}
+
+// openDump opens a text file, or the first text file in a zip archive as provided by GeoNames.
+func openDump(fileName string) (r io.Reader, closeFile func(), err error) {
+	if strings.EqualFold(filepath.Ext(fileName), fs.ExtZip) {
+		zr, zipErr := zip.OpenReader(fileName) //nolint:gosec // dataset path provided by admin
+
+		if zipErr != nil {
+			return nil, nil, fmt.Errorf("geonames: %s", zipErr)
+		}
+
+		for _, zf := range zr.File {
+			if !strings.EqualFold(filepath.Ext(zf.Name), fs.ExtTxt) {
+				continue
+			}
+
+			rc, openErr := zf.Open()
+
+			if openErr != nil {
+				_ = zr.Close()
+				return nil, nil, fmt.Errorf("geonames: %s", openErr)
+			}
+
+			return rc, func() { _ = rc.Close(); _ = zr.Close() }, nil
+		}
+
+		_ = zr.Close()
+
+		return nil, nil, fmt.Errorf("geonames: no text file found in %s", clean.Log(filepath.Base(fileName)))
+	}
+
+	f, err := os.Open(fileName) //nolint:gosec // dataset path provided by admin
+
+	if err != nil {
+		return nil, nil, fmt.Errorf("geonames: %s", err)
+	}
+
+	return f, func() { _ = f.Close() }, nil
+}
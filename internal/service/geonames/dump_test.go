@@ -0,0 +1,44 @@
+package geonames
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadDump(t *testing.T) {
+	t.Run("Text", func(t *testing.T) {
+		cities, err := ReadDump("testdata/cities.txt", "testdata/admin1.txt", 1000)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, cities, 4)
+		assert.Equal(t, 2950159, cities[0].ID)
+		assert.Equal(t, "Berlin", cities[0].Name)
+		assert.Equal(t, "de", cities[0].Country)
+		assert.Equal(t, "Berlin", cities[0].State)
+		assert.Equal(t, "Brandenburg", cities[1].State)
+		assert.InEpsilon(t, 52.52437, cities[0].Lat, 0.00001)
+		assert.InEpsilon(t, 13.41053, cities[0].Lng, 0.00001)
+	})
+	t.Run("Zip", func(t *testing.T) {
+		cities, err := ReadDump("testdata/cities.zip", "", 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, cities, 5)
+		assert.Equal(t, "", cities[0].State)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := ReadDump("testdata/missing.txt", "", 0)
+		assert.Error(t, err)
+	})
+	t.Run("NoCities", func(t *testing.T) {
+		_, err := ReadDump("testdata/cities.txt", "", 100000000)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,66 @@
+package geonames
+
+import (
+	"github.com/photoprism/photoprism/pkg/geo"
+	"github.com/photoprism/photoprism/pkg/geo/s2"
+)
+
+// Index represents an in-memory spatial index of cities, grouped by S2 cell.
+type Index struct {
+	cities Cities
+	cells  map[string][]int
+}
+
+// NewIndex creates a spatial index for the specified cities.
+func NewIndex(cities Cities) *Index {
+	idx := &Index{
+		cities: cities,
+		cells:  make(map[string][]int, len(cities)/4+1),
+	}
+
+	for i, c := range cities {
+		if token := s2.TokenLevel(c.Lat, c.Lng, IndexLevel); token != "" {
+			idx.cells[token] = append(idx.cells[token], i)
+		}
+	}
+
+	return idx
+}
+
+// Len returns the number of indexed cities.
+func (idx *Index) Len() int {
+	if idx == nil {
+		return 0
+	}
+
+	return len(idx.cities)
+}
+
+// Nearest returns the city closest to the specified coordinates within MaxDistance.
+func (idx *Index) Nearest(lat, lng float64) (result City, dist float64, found bool) {
+	if idx.Len() == 0 {
+		return result, dist, false
+	}
+
+	token := s2.TokenLevel(lat, lng, IndexLevel)
+
+	if token == "" {
+		return result, dist, false
+	}
+
+	pos := geo.Position{Lat: lat, Lng: lng}
+
+	for _, cell := range append([]string{token}, s2.Neighbors(token)...) {
+		for _, i := range idx.cells[cell] {
+			c := idx.cities[i]
+
+			if km := geo.Km(pos, c.Position()); km > MaxDistance {
+				continue
+			} else if !found || km < dist {
+				result, dist, found = c, km, true
+			}
+		}
+	}
+
+	return result, dist, found
+}
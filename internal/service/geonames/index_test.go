@@ -0,0 +1,63 @@
+package geonames
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIndex_Nearest(t *testing.T) {
+	cities, err := ReadDump("testdata/cities.txt", "testdata/admin1.txt", 1000)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	idx := NewIndex(cities)
+
+	assert.Equal(t, 4, idx.Len())
+
+	t.Run("Berlin", func(t *testing.T) {
+		city, dist, found := idx.Nearest(52.51, 13.38)
+		assert.True(t, found)
+		assert.Equal(t, "Berlin", city.Name)
+		assert.Less(t, dist, 5.0)
+	})
+	t.Run("Potsdam", func(t *testing.T) {
+		city, _, found := idx.Nearest(52.40, 13.00)
+		assert.True(t, found)
+		assert.Equal(t, "Potsdam", city.Name)
+	})
+	t.Run("OutOfRange", func(t *testing.T) {
+		_, _, found := idx.Nearest(50.0, 1.0)
+		assert.False(t, found)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, _, found := NewIndex(nil).Nearest(52.51, 13.38)
+		assert.False(t, found)
+	})
+}
+
+func TestSaveDataset(t *testing.T) {
+	cities, err := ReadDump("testdata/cities.txt", "testdata/admin1.txt", 1000)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	fileName := filepath.Join(t.TempDir(), "places", "geonames.tsv")
+
+	if err = SaveDataset(fileName, cities); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded, err := LoadDataset(fileName)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, cities, loaded)
+	assert.Error(t, SaveDataset(fileName, nil))
+}
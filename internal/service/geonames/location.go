@@ -0,0 +1,76 @@
+package geonames
+
+import (
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// Location represents the location details of an S2 cell based on the nearest city.
+type Location struct {
+	ID      string
+	Nearest City
+}
+
+// CellID returns the S2 cell identifier string.
+func (l Location) CellID() string {
+	return l.ID
+}
+
+// PlaceID returns the place identifier string.
+func (l Location) PlaceID() string {
+	return l.Nearest.PlaceID()
+}
+
+// Name returns an empty string, as the dataset contains no points of interest.
+func (l Location) Name() string {
+	return ""
+}
+
+// Street returns an empty string, as the dataset contains no addresses.
+func (l Location) Street() string {
+	return ""
+}
+
+// Postcode returns an empty string, as the dataset contains no addresses.
+func (l Location) Postcode() string {
+	return ""
+}
+
+// Category returns an empty string, as the dataset contains no points of interest.
+func (l Location) Category() string {
+	return ""
+}
+
+// Label returns the location label.
+func (l Location) Label() string {
+	return l.Nearest.Label()
+}
+
+// District returns an empty string, as the dataset contains no districts.
+func (l Location) District() string {
+	return ""
+}
+
+// City returns the city name.
+func (l Location) City() string {
+	return l.Nearest.Name
+}
+
+// State returns the state name.
+func (l Location) State() string {
+	return clean.State(l.Nearest.State, l.CountryCode())
+}
+
+// CountryCode returns the country code.
+func (l Location) CountryCode() string {
+	return l.Nearest.Country
+}
+
+// Keywords returns location keywords if any.
+func (l Location) Keywords() []string {
+	return []string{}
+}
+
+// Source returns the backend API name.
+func (l Location) Source() string {
+	return ApiName
+}
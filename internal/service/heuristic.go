@@ -10,12 +10,18 @@ import (
 type Headers = map[string]string
 
 // Heuristic represents a heuristic for detecting a remote service type, e.g. WebDAV.
+//
+// If Expect is set, the service is identified by the presence of this response
+// header, regardless of the status code, e.g. because anonymous requests are denied.
+// Schemes limits the heuristic to the specified URL schemes, by default http and https.
 type Heuristic struct {
 	Type    Type
+	Schemes []string
 	Domains []string
 	Paths   []string
 	Method  string
 	Headers Headers
+	Expect  string
 }
 
 // Heuristics for common remote service types.
@@ -29,12 +35,25 @@ var Heuristics = []Heuristic{
 	{Type: OneDrive, Domains: []string{"onedrive.live.com"}, Paths: []string{}, Method: "GET"},
 	{Type: GDrive, Domains: []string{"drive.google.com"}, Paths: []string{}, Method: "GET"},
 	{Type: GPhotos, Domains: []string{"photos.google.com"}, Paths: []string{}, Method: "GET"},
+	{Type: SFTP, Schemes: []string{"sftp", "ssh"}, Domains: []string{}, Paths: []string{}, Method: MethodSSH},
+	{Type: Local, Schemes: []string{"file"}, Domains: []string{}, Paths: []string{}, Method: MethodStat},
 	{Type: WebDAV,
 		Domains: []string{},
 		Paths:   []string{"/", "/webdav/", "/originals/", "/import/", "/remote.php/dav/files/{user}/", "/remote.php/webdav/", "/dav/files/{user}/", "/servlet/webdav.infostore/"},
 		Method:  "PROPFIND",
 		Headers: Headers{"Depth": "1"},
 	},
+	// Matches any host, so it must be checked after WebDAV.
+	{Type: S3, Domains: []string{}, Paths: []string{}, Method: "HEAD", Expect: "X-Amz-Request-Id"},
+}
+
+// MatchScheme returns true if the heuristic allows the provided URL scheme.
+func (h Heuristic) MatchScheme(scheme string) bool {
+	if len(h.Schemes) == 0 {
+		return scheme == "http" || scheme == "https"
+	}
+
+	return slices.Contains(h.Schemes, scheme)
 }
 
 // MatchDomain returns true if the heuristic allows the provided domain.
@@ -0,0 +1,251 @@
+package local
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Client represents a client for a local folder, e.g. a mounted SMB or NFS share.
+type Client struct {
+	root  string
+	mkdir map[string]bool
+}
+
+// ParseRoot returns the absolute path of the root folder from a file url like
+// "file:///mnt/nas/photos" or an absolute path.
+func ParseRoot(serverUrl string) (string, error) {
+	root := serverUrl
+
+	if strings.Contains(serverUrl, "://") {
+		u, err := url.Parse(serverUrl)
+
+		// Check url.
+		if err != nil {
+			return "", err
+		} else if u.Scheme != "file" {
+			return "", fmt.Errorf("unsupported url scheme %s", clean.Log(u.Scheme))
+		} else if u.Host != "" && u.Host != "localhost" {
+			return "", fmt.Errorf("invalid server url")
+		}
+
+		root = u.Path
+	}
+
+	if root == "" || !filepath.IsAbs(root) {
+		return "", fmt.Errorf("folder path must be absolute")
+	}
+
+	return filepath.Clean(root), nil
+}
+
+// NewClient creates a new client for the specified local folder, which must exist
+// within one of the configured Roots, see Allowed.
+func NewClient(serverUrl string) (*Client, error) {
+	root, err := ParseRoot(serverUrl)
+
+	if err != nil {
+		return nil, err
+	} else if !fs.PathExists(root) {
+		return nil, fmt.Errorf("folder %s not found", clean.Log(root))
+	} else if err = Allowed(root); err != nil {
+		return nil, err
+	}
+
+	log.Debugf("local: using folder %s", clean.Log(root))
+
+	result := &Client{
+		root:  root,
+		mkdir: make(map[string]bool, 128),
+	}
+
+	return result, nil
+}
+
+// Close does nothing, since there is no connection to close.
+func (c *Client) Close() error {
+	return nil
+}
+
+// localName returns the absolute local file name, which cannot be outside the root folder.
+func (c *Client) localName(name string) string {
+	return filepath.Join(c.root, filepath.FromSlash(path.Clean("/"+filepath.ToSlash(name))))
+}
+
+// absName returns the file name relative to the root folder, with a leading slash.
+func (c *Client) absName(localName string) string {
+	return "/" + strings.Trim(filepath.ToSlash(strings.TrimPrefix(localName, c.root)), "/")
+}
+
+// list returns the files and folders in a directory, optionally recursively. Hidden files
+// and folders, as well as symbolic links, are skipped.
+func (c *Client) list(dir string, recursive bool) (result fs.FileInfos, err error) {
+	found, err := os.ReadDir(dir)
+
+	if err != nil {
+		return result, err
+	}
+
+	for _, entry := range found {
+		name := entry.Name()
+
+		if name == "" || strings.HasPrefix(name, ".") {
+			continue
+		}
+
+		info, infoErr := entry.Info()
+
+		if infoErr != nil {
+			continue
+		}
+
+		localName := filepath.Join(dir, name)
+
+		if info.IsDir() {
+			result = append(result, fs.FileInfo{
+				Name: name,
+				Abs:  c.absName(localName),
+				Date: info.ModTime(),
+				Dir:  true,
+			})
+
+			if !recursive {
+				continue
+			}
+
+			nested, nestedErr := c.list(localName, true)
+
+			if nestedErr != nil {
+				return result, nestedErr
+			}
+
+			result = append(result, nested...)
+		} else if info.Mode().IsRegular() {
+			result = append(result, fs.FileInfo{
+				Name: name,
+				Abs:  c.absName(localName),
+				Size: info.Size(),
+				Date: info.ModTime(),
+				Dir:  false,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// Files returns information about files in a directory, optionally recursively.
+func (c *Client) Files(dir string, recursive bool) (result fs.FileInfos, err error) {
+	found, err := c.list(c.localName(dir), recursive)
+
+	if err != nil {
+		return result, err
+	}
+
+	result = make(fs.FileInfos, 0, len(found))
+
+	for _, f := range found {
+		if !f.Dir {
+			result = append(result, f)
+		}
+	}
+
+	return result, nil
+}
+
+// Directories returns all subdirectories in a path. The timeout is ignored, since local file
+// system operations cannot be canceled.
+func (c *Client) Directories(dir string, recursive bool, timeout time.Duration) (result fs.FileInfos, err error) {
+	found, err := c.list(c.localName(dir), recursive)
+
+	if err != nil {
+		return result, err
+	}
+
+	result = make(fs.FileInfos, 0, len(found))
+
+	for _, f := range found {
+		if f.Dir {
+			result = append(result, f)
+		}
+	}
+
+	sort.Sort(result)
+
+	return result, nil
+}
+
+// MkdirAll recursively creates directories.
+func (c *Client) MkdirAll(dir string) error {
+	dir = c.localName(dir)
+
+	if dir == c.root || c.mkdir[dir] {
+		// Dir already exists.
+		return nil
+	}
+
+	if err := fs.MkdirAll(dir); err != nil {
+		return err
+	}
+
+	c.mkdir[dir] = true
+
+	return nil
+}
+
+// Upload copies a single file to the folder.
+func (c *Client) Upload(src, dest string) error {
+	if !fs.FileExists(src) {
+		return fmt.Errorf("file %s not found", clean.Log(path.Base(src)))
+	}
+
+	if err := fs.Copy(src, c.localName(dest), true); err != nil {
+		log.Errorf("local: %s", clean.Error(err))
+		return fmt.Errorf("local: failed to upload %s", clean.Log(dest))
+	}
+
+	return nil
+}
+
+// Download copies a single file from the folder to the given location.
+func (c *Client) Download(src, dest string, force bool) error {
+	// Skip if file already exists.
+	if _, err := os.Stat(dest); err == nil && !force {
+		return fmt.Errorf("local: download skipped, %s already exists", clean.Log(dest))
+	}
+
+	localName := c.localName(src)
+
+	if !fs.FileExists(localName) {
+		return fmt.Errorf("local: failed to download %s", clean.Log(src))
+	}
+
+	if err := fs.Copy(localName, dest, true); err != nil {
+		log.Errorf("local: %s", clean.Error(err))
+		return fmt.Errorf("local: failed to download %s", clean.Log(src))
+	}
+
+	return nil
+}
+
+// Delete deletes a single file or directory.
+func (c *Client) Delete(name string) error {
+	localName := c.localName(name)
+
+	if localName == c.root {
+		return fmt.Errorf("local: cannot delete root folder")
+	}
+
+	// Forget created folders, since nested folders may be deleted as well.
+	c.mkdir = make(map[string]bool, 128)
+
+	return os.RemoveAll(localName)
+}
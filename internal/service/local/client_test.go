@@ -0,0 +1,233 @@
+package local
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+func TestParseRoot(t *testing.T) {
+	t.Run("FileURL", func(t *testing.T) {
+		result, err := ParseRoot("file:///mnt/nas/photos/")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "/mnt/nas/photos", result)
+	})
+	t.Run("Localhost", func(t *testing.T) {
+		result, err := ParseRoot("file://localhost/mnt/nas")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "/mnt/nas", result)
+	})
+	t.Run("Path", func(t *testing.T) {
+		result, err := ParseRoot("/mnt/nas/../smb")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "/mnt/smb", result)
+	})
+	t.Run("Relative", func(t *testing.T) {
+		_, err := ParseRoot("mnt/nas")
+		assert.Error(t, err)
+	})
+	t.Run("RemoteHost", func(t *testing.T) {
+		_, err := ParseRoot("file://nas/photos")
+		assert.Error(t, err)
+	})
+	t.Run("InvalidScheme", func(t *testing.T) {
+		_, err := ParseRoot("smb://nas/photos")
+		assert.Error(t, err)
+	})
+}
+
+func TestNewClient(t *testing.T) {
+	Roots = []string{os.TempDir()}
+	defer func() { Roots = nil }()
+
+	t.Run("Success", func(t *testing.T) {
+		root := t.TempDir()
+		c, err := NewClient("file://" + root)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.IsType(t, &Client{}, c)
+		assert.Equal(t, root, c.root)
+		assert.NoError(t, c.Close())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := NewClient(filepath.Join(t.TempDir(), "missing"))
+		assert.Error(t, err)
+	})
+	t.Run("NotAllowed", func(t *testing.T) {
+		_, err := NewClient("/etc")
+		assert.Error(t, err)
+	})
+}
+
+func TestAllowed(t *testing.T) {
+	Roots = []string{"/mnt/nas", "/photoprism"}
+	Protected = []string{"/photoprism/storage", "/photoprism/originals"}
+	defer func() { Roots, Protected = nil, nil }()
+
+	assert.NoError(t, Allowed("/mnt/nas"))
+	assert.NoError(t, Allowed("/mnt/nas/photos"))
+	assert.NoError(t, Allowed("/photoprism/import"))
+	assert.Error(t, Allowed("/mnt/nas2"))
+	assert.Error(t, Allowed("/mnt"))
+	assert.Error(t, Allowed("/etc"))
+	assert.Error(t, Allowed("mnt/nas"))
+	assert.Error(t, Allowed("/photoprism"))
+	assert.Error(t, Allowed("/photoprism/storage"))
+	assert.Error(t, Allowed("/photoprism/originals/2024"))
+}
+
+func TestClient_localName(t *testing.T) {
+	c := &Client{root: "/mnt/nas"}
+
+	assert.Equal(t, "/mnt/nas/Photos/a.jpg", c.localName("/Photos/a.jpg"))
+	assert.Equal(t, "/mnt/nas", c.localName("/"))
+	assert.Equal(t, "/mnt/nas/etc/passwd", c.localName("../../etc/passwd"))
+	assert.Equal(t, "/Photos/a.jpg", c.absName("/mnt/nas/Photos/a.jpg"))
+}
+
+func TestClient_FilesAndDirectories(t *testing.T) {
+	Roots = []string{os.TempDir()}
+	defer func() { Roots = nil }()
+
+	root := t.TempDir()
+
+	for name, data := range map[string]string{
+		"Photos/a.jpg":         "a",
+		"Photos/2024/b.jpg":    "bb",
+		"Photos/2024/01/c.jpg": "c",
+		"Photos/.hidden":       "x",
+		"Photos/.thumbs/d.jpg": "d",
+		"e.jpg":                "e",
+	} {
+		fileName := filepath.Join(root, filepath.FromSlash(name))
+
+		if err := os.MkdirAll(filepath.Dir(fileName), fs.ModeDir); err != nil {
+			t.Fatal(err)
+		} else if err = os.WriteFile(fileName, []byte(data), fs.ModeFile); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c, err := NewClient(root)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("FilesNonRecursive", func(t *testing.T) {
+		files, err := c.Files("/Photos", false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"/Photos/a.jpg"}, files.Abs())
+	})
+	t.Run("FilesRecursive", func(t *testing.T) {
+		files, err := c.Files("/Photos", true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.ElementsMatch(t, []string{"/Photos/2024/01/c.jpg", "/Photos/2024/b.jpg", "/Photos/a.jpg"}, files.Abs())
+	})
+	t.Run("DirectoriesRoot", func(t *testing.T) {
+		dirs, err := c.Directories("/", false, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"/Photos"}, dirs.Abs())
+	})
+	t.Run("DirectoriesRecursive", func(t *testing.T) {
+		dirs, err := c.Directories("/Photos", true, time.Minute)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"/Photos/2024", "/Photos/2024/01"}, dirs.Abs())
+	})
+}
+
+func TestClient_UploadDownloadDelete(t *testing.T) {
+	Roots = []string{os.TempDir()}
+	defer func() { Roots = nil }()
+
+	root := t.TempDir()
+
+	c, err := NewClient(root)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tempDir := t.TempDir()
+	src := filepath.Join(tempDir, "src.txt")
+
+	if err = os.WriteFile(src, []byte("Hello SMB!"), fs.ModeFile); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NoError(t, c.MkdirAll("/Photos/New"))
+	assert.DirExists(t, filepath.Join(root, "Photos", "New"))
+
+	if err = c.Upload(src, "/Photos/New/hello.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.FileExists(t, filepath.Join(root, "Photos", "New", "hello.txt"))
+
+	dest := filepath.Join(tempDir, "download", "hello.txt")
+
+	if err = c.Download("/Photos/New/hello.txt", dest, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if data, readErr := os.ReadFile(dest); readErr != nil {
+		t.Fatal(readErr)
+	} else {
+		assert.Equal(t, "Hello SMB!", string(data))
+	}
+
+	// Existing files are not overwritten unless forced.
+	assert.Error(t, c.Download("/Photos/New/hello.txt", dest, false))
+	assert.NoError(t, c.Download("/Photos/New/hello.txt", dest, true))
+
+	// Missing files cannot be downloaded.
+	assert.Error(t, c.Download("/Photos/New/missing.txt", filepath.Join(tempDir, "missing.txt"), false))
+	assert.NoFileExists(t, filepath.Join(tempDir, "missing.txt"))
+
+	// Deleting a folder removes all nested files.
+	if err = c.Delete("/Photos"); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NoDirExists(t, filepath.Join(root, "Photos"))
+
+	// The root folder cannot be deleted.
+	assert.Error(t, c.Delete("/"))
+	assert.DirExists(t, root)
+}
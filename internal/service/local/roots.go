@@ -0,0 +1,67 @@
+package local
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+var (
+	// Roots contains the folders in which local services can be added, e.g. mounted network shares.
+	Roots []string
+	// Protected contains folders that local services must not access, e.g. the config and storage paths.
+	Protected []string
+)
+
+// Allowed returns an error if the folder is not within one of the configured Roots
+// or if it is within or contains a Protected folder.
+func Allowed(dir string) error {
+	dir = resolvePath(dir)
+
+	if dir == "" {
+		return fmt.Errorf("folder path must be absolute")
+	}
+
+	allowed := false
+
+	for _, root := range Roots {
+		if root = resolvePath(root); root != "" && within(dir, root) {
+			allowed = true
+			break
+		}
+	}
+
+	if !allowed {
+		return fmt.Errorf("folder %s is not within a mount path", clean.Log(dir))
+	}
+
+	for _, p := range Protected {
+		if p = resolvePath(p); p != "" && (within(dir, p) || within(p, dir)) {
+			return fmt.Errorf("folder %s must not be used", clean.Log(dir))
+		}
+	}
+
+	return nil
+}
+
+// resolvePath returns the clean absolute path with symbolic links resolved, if it exists.
+func resolvePath(dir string) string {
+	if dir == "" || !filepath.IsAbs(dir) {
+		return ""
+	}
+
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil {
+		return resolved
+	}
+
+	return filepath.Clean(dir)
+}
+
+// within checks if the path is the same as or within the parent folder.
+func within(dir, parent string) bool {
+	rel, err := filepath.Rel(parent, dir)
+
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
@@ -0,0 +1,55 @@
+package maps
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/photoprism/photoprism/internal/service/hub/places"
+)
+
+// Geocoder represents a reverse geocoding provider that returns location details for S2 cell IDs.
+type Geocoder interface {
+	Cell(id string) (LocationSource, error)
+}
+
+// GeocoderFunc allows ordinary functions to be used as Geocoder.
+type GeocoderFunc func(id string) (LocationSource, error)
+
+// Cell returns location details for the specified S2 cell ID.
+func (f GeocoderFunc) Cell(id string) (LocationSource, error) {
+	return f(id)
+}
+
+var (
+	geocoders     = map[string]Geocoder{places.ApiName: GeocoderFunc(placesCell)}
+	geocoderMutex = sync.RWMutex{}
+)
+
+// RegisterGeocoder adds a reverse geocoding provider, or replaces an existing provider with the same name.
+func RegisterGeocoder(name string, g Geocoder) {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	if name == "" || g == nil {
+		return
+	}
+
+	geocoderMutex.Lock()
+	defer geocoderMutex.Unlock()
+
+	geocoders[name] = g
+}
+
+// FindGeocoder returns the reverse geocoding provider with the specified name, if registered.
+func FindGeocoder(name string) (g Geocoder, ok bool) {
+	geocoderMutex.RLock()
+	defer geocoderMutex.RUnlock()
+
+	g, ok = geocoders[strings.ToLower(strings.TrimSpace(name))]
+
+	return g, ok
+}
+
+// placesCell returns location details from the remote places service.
+func placesCell(id string) (LocationSource, error) {
+	return places.Cell(id, places.DefaultLocale)
+}
@@ -0,0 +1,51 @@
+package maps
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/service/hub/places"
+)
+
+func TestRegisterGeocoder(t *testing.T) {
+	RegisterGeocoder("test", GeocoderFunc(func(id string) (LocationSource, error) {
+		if id == "" {
+			return nil, errors.New("empty cell id")
+		}
+
+		return places.Location{ID: id, LocCountry: "de", Place: places.Place{PlaceID: "de:test", LocLabel: "Berlin, Germany", LocCity: "Berlin"}}, nil
+	}))
+
+	t.Run("Found", func(t *testing.T) {
+		g, ok := FindGeocoder(" TEST ")
+		assert.True(t, ok)
+		assert.NotNil(t, g)
+
+		_, ok = FindGeocoder("places")
+		assert.True(t, ok)
+
+		_, ok = FindGeocoder("xxx")
+		assert.False(t, ok)
+	})
+	t.Run("QueryApi", func(t *testing.T) {
+		l := Location{ID: "47a85a63f764"}
+
+		if err := l.QueryApi("test"); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "de:test", l.PlaceID())
+		assert.Equal(t, "Berlin, Germany", l.Label())
+		assert.Equal(t, "Berlin", l.City())
+		assert.Equal(t, "de", l.CountryCode())
+
+		assert.Error(t, (&Location{}).QueryApi("test"))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		RegisterGeocoder("", nil)
+		_, ok := FindGeocoder("")
+		assert.False(t, ok)
+	})
+}
@@ -35,6 +35,7 @@ type LocationSource interface {
 	Street() string
 	Category() string
 	Postcode() string
+	Label() string
 	District() string
 	City() string
 	State() string
@@ -43,14 +44,26 @@ type LocationSource interface {
 	Source() string
 }
 
-func (l *Location) QueryApi(api string) error { //nolint:gocritic // single-case switch retained for future APIs
-	if api == places.ApiName {
-		return l.QueryPlaces()
+// QueryApi retrieves location details from the specified reverse geocoding provider.
+func (l *Location) QueryApi(api string) error {
+	g, ok := FindGeocoder(api)
+
+	if !ok {
+		return errors.New("maps: location lookup disabled")
 	}
 
-	return errors.New("maps: location lookup disabled")
+	s, err := g.Cell(l.ID)
+
+	if err != nil {
+		return err
+	}
+
+	l.SetSource(s)
+
+	return nil
 }
 
+// QueryPlaces retrieves location details from the remote places service.
 func (l *Location) QueryPlaces() error {
 	s, err := places.Cell(l.ID, places.DefaultLocale)
 
@@ -58,6 +71,13 @@ func (l *Location) QueryPlaces() error {
 		return err
 	}
 
+	l.SetSource(s)
+
+	return nil
+}
+
+// SetSource updates the location details based on the specified source.
+func (l *Location) SetSource(s LocationSource) {
 	l.placeID = s.PlaceID()
 	l.LocSource = s.Source()
 	l.LocName = s.Name()
@@ -70,8 +90,6 @@ func (l *Location) QueryPlaces() error {
 	l.LocState = s.State()
 	l.LocCountry = s.CountryCode()
 	l.LocKeywords = s.Keywords()
-
-	return nil
 }
 
 func (l *Location) Unknown() bool {
@@ -1,12 +1,32 @@
 package service
 
 import (
+	"bufio"
+	"net"
 	"net/http"
+	"net/url"
+	"os"
+	"strings"
 	"time"
+
+	"github.com/photoprism/photoprism/internal/service/local"
+)
+
+// Test methods for services that are not accessed via HTTP.
+const (
+	MethodSSH  = "SSH"  // Checks if the server responds with an SSH protocol version, e.g. for SFTP.
+	MethodStat = "STAT" // Checks if the local folder exists, e.g. a mounted SMB or NFS share.
 )
 
 // TestRequest makes a test request to the given URL and returns true if successful.
 func (h Heuristic) TestRequest(method, rawUrl string) bool {
+	switch method {
+	case MethodSSH:
+		return testSSH(rawUrl)
+	case MethodStat:
+		return testStat(rawUrl)
+	}
+
 	req, err := http.NewRequest(method, rawUrl, nil)
 
 	if err != nil {
@@ -33,9 +53,67 @@ func (h Heuristic) TestRequest(method, rawUrl string) bool {
 	// Send request to see if it fails.
 	if resp, reqErr := client.Do(req); reqErr != nil {
 		return false
+	} else if h.Expect != "" {
+		return resp.Header.Get(h.Expect) != ""
 	} else if resp.StatusCode < 400 {
 		return true
 	}
 
 	return false
 }
+
+// testSSH connects to the server and returns true if it responds with an SSH protocol version.
+func testSSH(rawUrl string) bool {
+	u, err := url.Parse(rawUrl)
+
+	if err != nil || u.Hostname() == "" {
+		return false
+	}
+
+	port := u.Port()
+
+	if port == "" {
+		port = "22"
+	}
+
+	conn, err := net.DialTimeout("tcp", net.JoinHostPort(u.Hostname(), port), 30*time.Second)
+
+	if err != nil {
+		return false
+	}
+
+	defer conn.Close()
+
+	if err = conn.SetReadDeadline(time.Now().Add(10 * time.Second)); err != nil {
+		return false
+	}
+
+	// The server must send its version, e.g. "SSH-2.0-OpenSSH_9.6", see RFC 4253 section 4.2.
+	// Other lines may be sent before the version string.
+	r := bufio.NewReader(conn)
+
+	for i := 0; i < 10; i++ {
+		line, readErr := r.ReadString('\n')
+
+		if strings.HasPrefix(line, "SSH-") {
+			return true
+		} else if readErr != nil {
+			return false
+		}
+	}
+
+	return false
+}
+
+// testStat returns true if the url refers to an existing local folder that can be added as service.
+func testStat(rawUrl string) bool {
+	u, err := url.Parse(rawUrl)
+
+	if err != nil || u.Path == "" || u.Host != "" && u.Host != "localhost" {
+		return false
+	}
+
+	info, err := os.Stat(u.Path)
+
+	return err == nil && info.IsDir() && local.Allowed(u.Path) == nil
+}
@@ -0,0 +1,371 @@
+package s3
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// Client represents an S3 client for a single bucket and optional key prefix.
+type Client struct {
+	client  *minio.Client
+	ctx     context.Context
+	bucket  string
+	prefix  string
+	timeout time.Duration
+}
+
+// Endpoint represents the parsed connection details of an S3-compatible service URL,
+// e.g. "https://minio.example.com:9000/bucket/prefix?region=eu-central-1".
+type Endpoint struct {
+	Host   string
+	Secure bool
+	Bucket string
+	Prefix string
+	Region string
+}
+
+// ParseEndpoint parses an S3 service URL with the bucket name as first path segment.
+func ParseEndpoint(serverUrl string) (result Endpoint, err error) {
+	u, err := url.Parse(serverUrl)
+
+	// Check url.
+	if err != nil {
+		return result, err
+	} else if u == nil || u.Host == "" {
+		return result, fmt.Errorf("invalid server url")
+	}
+
+	switch u.Scheme {
+	case "https", "s3":
+		result.Secure = true
+	case "http":
+		result.Secure = false
+	default:
+		return result, fmt.Errorf("unsupported url scheme %s", clean.Log(u.Scheme))
+	}
+
+	result.Host = u.Host
+
+	// The first path segment is the bucket name, the remainder an optional key prefix.
+	if segments := splitPath(u.Path); len(segments) == 0 || segments[0] == "" {
+		return result, fmt.Errorf("missing bucket name")
+	} else {
+		result.Bucket = segments[0]
+		result.Prefix = strings.Join(segments[1:], "/")
+	}
+
+	if result.Region = u.Query().Get("region"); result.Region == "" {
+		result.Region = DefaultRegion
+	}
+
+	return result, nil
+}
+
+// NewClient creates a new S3 client for the specified endpoint, using the user as
+// access key id and the password as secret access key.
+func NewClient(serverUrl, user, pass string, timeout time.Duration) (*Client, error) {
+	endpoint, err := ParseEndpoint(serverUrl)
+
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debugf("s3: connecting to %s", clean.Log(endpoint.Host))
+
+	client, err := minio.New(endpoint.Host, &minio.Options{
+		Creds:        credentials.NewStaticV4(user, pass, ""),
+		Secure:       endpoint.Secure,
+		Region:       endpoint.Region,
+		BucketLookup: minio.BucketLookupPath,
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Client{
+		client:  client,
+		ctx:     context.Background(),
+		bucket:  endpoint.Bucket,
+		prefix:  endpoint.Prefix,
+		timeout: timeout,
+	}
+
+	return result, nil
+}
+
+// withTimeout returns a context with the specified total request time.
+func (c *Client) withTimeout(timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout < 0 {
+		return context.WithCancel(c.ctx)
+	} else if timeout == 0 {
+		timeout = c.timeout
+	}
+
+	if timeout <= 0 {
+		return context.WithCancel(c.ctx)
+	}
+
+	return context.WithTimeout(c.ctx, timeout)
+}
+
+// objectKey returns the object key for the specified remote file name.
+func (c *Client) objectKey(name string) string {
+	return strings.Trim(path.Join(c.prefix, trimPath(name)), "/")
+}
+
+// keyPrefix returns the key prefix for listing the contents of a remote directory.
+func (c *Client) keyPrefix(dir string) string {
+	if key := c.objectKey(dir); key != "" {
+		return key + "/"
+	}
+
+	return ""
+}
+
+// absName returns the absolute remote file name for the specified object key.
+func (c *Client) absName(key string) string {
+	key = strings.Trim(key, "/")
+
+	if c.prefix != "" {
+		key = strings.TrimPrefix(strings.TrimPrefix(key, c.prefix), "/")
+	}
+
+	return "/" + key
+}
+
+// Files returns information about files in a directory, optionally recursively.
+func (c *Client) Files(dir string, recursive bool) (result fs.FileInfos, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("s3: %s (panic while listing files)\nstack: %s", r, debug.Stack())
+		}
+	}()
+
+	ctx, cancel := c.withTimeout(-1)
+	defer cancel()
+
+	opts := minio.ListObjectsOptions{Prefix: c.keyPrefix(dir), Recursive: recursive}
+
+	result = make(fs.FileInfos, 0, 64)
+
+	for obj := range c.client.ListObjects(ctx, c.bucket, opts) {
+		if obj.Err != nil {
+			return result, obj.Err
+		}
+
+		// Skip common prefixes, folder placeholders, and hidden files.
+		if obj.Key == "" || strings.HasSuffix(obj.Key, "/") {
+			continue
+		} else if name := path.Base(obj.Key); strings.HasPrefix(name, ".") {
+			continue
+		} else {
+			result = append(result, fs.FileInfo{
+				Name: name,
+				Abs:  c.absName(obj.Key),
+				Size: obj.Size,
+				Date: obj.LastModified,
+				Dir:  false,
+				ETag: obj.ETag,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// Directories returns all subdirectories in a path. Since object storage has no real
+// folders, they are derived from the keys of the objects found.
+func (c *Client) Directories(dir string, recursive bool, timeout time.Duration) (result fs.FileInfos, err error) {
+	ctx, cancel := c.withTimeout(timeout)
+	defer cancel()
+
+	keyPrefix := c.keyPrefix(dir)
+	opts := minio.ListObjectsOptions{Prefix: keyPrefix, Recursive: recursive}
+	found := make(map[string]bool)
+
+	for obj := range c.client.ListObjects(ctx, c.bucket, opts) {
+		if obj.Err != nil {
+			return result, obj.Err
+		}
+
+		rel := strings.TrimPrefix(obj.Key, keyPrefix)
+
+		// Ignore objects in the directory itself.
+		if i := strings.LastIndex(rel, "/"); i <= 0 {
+			continue
+		} else {
+			rel = rel[:i]
+		}
+
+		// Add the folder and, if recursive, all of its parents.
+		for rel != "" && rel != "." {
+			if strings.HasPrefix(path.Base(rel), ".") {
+				// Skip hidden folders.
+			} else if key := keyPrefix + rel; !found[key] {
+				found[key] = true
+			}
+
+			rel = path.Dir(rel)
+		}
+	}
+
+	result = make(fs.FileInfos, 0, len(found))
+
+	for key := range found {
+		result = append(result, fs.FileInfo{
+			Name: path.Base(key),
+			Abs:  c.absName(key),
+			Dir:  true,
+		})
+	}
+
+	sort.Sort(result)
+
+	return result, nil
+}
+
+// MkdirAll does nothing, since folders are implied by object keys in S3-compatible storage.
+func (c *Client) MkdirAll(dir string) error {
+	return nil
+}
+
+// Upload uploads a single file to the remote bucket.
+func (c *Client) Upload(src, dest string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("s3: %s (panic while uploading)\nstack: %s", r, debug.Stack())
+		}
+	}()
+
+	key := c.objectKey(dest)
+
+	if key == "" {
+		return fmt.Errorf("s3: invalid file name %s", clean.Log(dest))
+	} else if !fs.FileExists(src) {
+		return fmt.Errorf("file %s not found", clean.Log(path.Base(src)))
+	}
+
+	ctx, cancel := c.withTimeout(MaxRequestDuration)
+	defer cancel()
+
+	if _, err = c.client.FPutObject(ctx, c.bucket, key, src, minio.PutObjectOptions{}); err != nil {
+		log.Errorf("s3: %s", clean.Error(err))
+		return fmt.Errorf("s3: failed to upload %s", clean.Log(dest))
+	}
+
+	return nil
+}
+
+// Download downloads a single file to the given location.
+func (c *Client) Download(src, dest string, force bool) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Errorf("s3: %s (panic while downloading %s)\nstack: %s", r, clean.Log(src), debug.Stack())
+			err = fmt.Errorf("s3: unexpected error while downloading %s", clean.Log(src))
+		}
+	}()
+
+	key := c.objectKey(src)
+
+	// Skip if file already exists.
+	if _, err = os.Stat(dest); err == nil && !force {
+		return fmt.Errorf("s3: download skipped, %s already exists", clean.Log(dest))
+	}
+
+	dir := path.Dir(dest)
+	dirInfo, err := os.Stat(dir)
+
+	if err != nil {
+		// Create local storage path.
+		if err = fs.MkdirAll(dir); err != nil {
+			return fmt.Errorf("s3: cannot create folder %s (%s)", clean.Log(dir), err)
+		}
+	} else if !dirInfo.IsDir() {
+		return fmt.Errorf("s3: %s is not a folder", clean.Log(dir))
+	}
+
+	ctx, cancel := c.withTimeout(MaxRequestDuration)
+	defer cancel()
+
+	// Start download.
+	obj, err := c.client.GetObject(ctx, c.bucket, key, minio.GetObjectOptions{})
+
+	// Error?
+	if err != nil {
+		log.Errorf("s3: %s", clean.Error(err))
+		return fmt.Errorf("s3: failed to download %s", clean.Log(src))
+	}
+
+	defer obj.Close()
+
+	// Make sure the object exists before creating the local file.
+	if _, err = obj.Stat(); err != nil {
+		log.Errorf("s3: %s", clean.Error(err))
+		return fmt.Errorf("s3: failed to download %s", clean.Log(src))
+	}
+
+	f, err := os.OpenFile(dest, os.O_TRUNC|os.O_RDWR|os.O_CREATE, fs.ModeFile) //nolint:gosec // dest provided by caller
+
+	if err != nil {
+		log.Errorf("s3: %s", clean.Error(err))
+		return fmt.Errorf("s3: failed to create %s", clean.Log(path.Base(dest)))
+	}
+
+	defer f.Close()
+
+	if _, err = io.Copy(f, obj); err != nil {
+		log.Errorf("s3: %s", clean.Error(err))
+		return fmt.Errorf("s3: failed writing to %s", clean.Log(path.Base(dest)))
+	}
+
+	return nil
+}
+
+// Close does nothing, since requests do not use a persistent connection.
+func (c *Client) Close() error {
+	return nil
+}
+
+// Delete deletes a single file or all files with the specified folder prefix.
+func (c *Client) Delete(name string) error {
+	key := c.objectKey(name)
+
+	if key == "" {
+		return fmt.Errorf("s3: invalid file name %s", clean.Log(name))
+	}
+
+	ctx, cancel := c.withTimeout(MaxRequestDuration)
+	defer cancel()
+
+	if err := c.client.RemoveObject(ctx, c.bucket, key, minio.RemoveObjectOptions{}); err != nil {
+		return err
+	}
+
+	// Remove nested objects, in case the name refers to a folder.
+	opts := minio.ListObjectsOptions{Prefix: key + "/", Recursive: true}
+
+	for obj := range c.client.ListObjects(ctx, c.bucket, opts) {
+		if obj.Err != nil {
+			return obj.Err
+		} else if err := c.client.RemoveObject(ctx, c.bucket, obj.Key, minio.RemoveObjectOptions{}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
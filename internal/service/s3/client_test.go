@@ -0,0 +1,211 @@
+package s3
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+const (
+	testBucket = "photos"
+	testUser   = "admin"
+	testPass   = "photoprism"
+)
+
+func TestParseEndpoint(t *testing.T) {
+	t.Run("Bucket", func(t *testing.T) {
+		result, err := ParseEndpoint("https://minio.localssl.dev:9000/photos")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "minio.localssl.dev:9000", result.Host)
+		assert.True(t, result.Secure)
+		assert.Equal(t, "photos", result.Bucket)
+		assert.Equal(t, "", result.Prefix)
+		assert.Equal(t, DefaultRegion, result.Region)
+	})
+	t.Run("PrefixAndRegion", func(t *testing.T) {
+		result, err := ParseEndpoint("http://minio:9000/photos/archive/2024/?region=eu-central-1")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "minio:9000", result.Host)
+		assert.False(t, result.Secure)
+		assert.Equal(t, "photos", result.Bucket)
+		assert.Equal(t, "archive/2024", result.Prefix)
+		assert.Equal(t, "eu-central-1", result.Region)
+	})
+	t.Run("NoBucket", func(t *testing.T) {
+		_, err := ParseEndpoint("http://minio:9000/")
+		assert.Error(t, err)
+	})
+	t.Run("InvalidScheme", func(t *testing.T) {
+		_, err := ParseEndpoint("ftp://minio:9000/photos")
+		assert.Error(t, err)
+	})
+}
+
+func TestNewClient(t *testing.T) {
+	c, err := NewClient("http://minio:9000/photos", testUser, testPass, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.IsType(t, &Client{}, c)
+	assert.Equal(t, "photos", c.bucket)
+}
+
+func TestClient_objectKey(t *testing.T) {
+	c := &Client{prefix: "archive"}
+
+	assert.Equal(t, "archive/Photos/a.jpg", c.objectKey("/Photos/a.jpg"))
+	assert.Equal(t, "archive", c.objectKey("/"))
+	assert.Equal(t, "archive/Photos/", c.keyPrefix("Photos"))
+	assert.Equal(t, "/Photos/a.jpg", c.absName("archive/Photos/a.jpg"))
+
+	c.prefix = ""
+
+	assert.Equal(t, "Photos/a.jpg", c.objectKey("/Photos/a.jpg"))
+	assert.Equal(t, "", c.keyPrefix("/"))
+	assert.Equal(t, "/Photos/a.jpg", c.absName("Photos/a.jpg"))
+}
+
+func TestClient_Files(t *testing.T) {
+	ts, s := newTestServer(testBucket)
+	defer ts.Close()
+
+	s.Put("archive/Photos/a.jpg", []byte("a"))
+	s.Put("archive/Photos/2024/b.jpg", []byte("bb"))
+	s.Put("archive/Photos/.hidden", []byte("x"))
+	s.Put("archive/Other/c.jpg", []byte("c"))
+
+	c, err := NewClient(ts.URL+"/photos/archive", testUser, testPass, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("NonRecursive", func(t *testing.T) {
+		files, err := c.Files("/Photos", false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"/Photos/a.jpg"}, files.Abs())
+	})
+	t.Run("Recursive", func(t *testing.T) {
+		files, err := c.Files("/Photos", true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.ElementsMatch(t, []string{"/Photos/2024/b.jpg", "/Photos/a.jpg"}, files.Abs())
+
+		for _, f := range files {
+			if f.Name == "b.jpg" {
+				assert.Equal(t, int64(2), f.Size)
+				assert.False(t, f.Date.IsZero())
+			}
+		}
+	})
+}
+
+func TestClient_Directories(t *testing.T) {
+	ts, s := newTestServer(testBucket)
+	defer ts.Close()
+
+	s.Put("Photos/2024/01/a.jpg", []byte("a"))
+	s.Put("Photos/2023/b.jpg", []byte("b"))
+	s.Put("Photos/.thumbs/c.jpg", []byte("c"))
+	s.Put("d.jpg", []byte("d"))
+
+	c, err := NewClient(ts.URL+"/photos", testUser, testPass, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("Root", func(t *testing.T) {
+		dirs, err := c.Directories("/", false, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"/Photos"}, dirs.Abs())
+	})
+	t.Run("Recursive", func(t *testing.T) {
+		dirs, err := c.Directories("/Photos", true, time.Minute)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"/Photos/2023", "/Photos/2024", "/Photos/2024/01"}, dirs.Abs())
+	})
+}
+
+func TestClient_UploadDownloadDelete(t *testing.T) {
+	ts, s := newTestServer(testBucket)
+	defer ts.Close()
+
+	c, err := NewClient(ts.URL+"/photos/sync", testUser, testPass, 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tempDir := t.TempDir()
+	src := filepath.Join(tempDir, "src.txt")
+
+	if err = os.WriteFile(src, []byte("Hello S3!"), fs.ModeFile); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NoError(t, c.MkdirAll("/Photos/New"))
+
+	if err = c.Upload(src, "/Photos/New/hello.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, []string{"sync/Photos/New/hello.txt"}, s.Keys())
+
+	dest := filepath.Join(tempDir, "download", "hello.txt")
+
+	if err = c.Download("/Photos/New/hello.txt", dest, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if data, readErr := os.ReadFile(dest); readErr != nil {
+		t.Fatal(readErr)
+	} else {
+		assert.Equal(t, "Hello S3!", string(data))
+	}
+
+	// Existing files are not overwritten unless forced.
+	assert.Error(t, c.Download("/Photos/New/hello.txt", dest, false))
+	assert.NoError(t, c.Download("/Photos/New/hello.txt", dest, true))
+
+	// Missing files cannot be downloaded.
+	assert.Error(t, c.Download("/Photos/New/missing.txt", filepath.Join(tempDir, "missing.txt"), false))
+	assert.NoFileExists(t, filepath.Join(tempDir, "missing.txt"))
+
+	// Deleting a folder removes all nested files.
+	if err = c.Delete("/Photos"); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Empty(t, s.Keys())
+}
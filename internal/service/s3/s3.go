@@ -0,0 +1,40 @@
+/*
+Package s3 provides file sharing and synchronization with S3-compatible object storage.
+
+Copyright (c) 2018 - 2025 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://www.photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package s3
+
+import (
+	"time"
+
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+// Global log instance.
+var log = event.Log
+
+// DefaultRegion is used if no region is specified in the endpoint URL.
+const DefaultRegion = "us-east-1"
+
+// MaxRequestDuration is the maximum request duration e.g. for uploading or downloading large files.
+const MaxRequestDuration = 30 * time.Minute
@@ -0,0 +1,239 @@
+package s3
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/md5" //nolint:gosec // used for etags only
+	"encoding/hex"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// testObject represents an object stored by the in-process test server.
+type testObject struct {
+	Data     []byte
+	Modified time.Time
+}
+
+// testServer implements the subset of the S3 REST API used by Client with path-style bucket lookups.
+type testServer struct {
+	mu      sync.Mutex
+	bucket  string
+	objects map[string]testObject
+}
+
+type testListContents struct {
+	Key          string    `xml:"Key"`
+	LastModified time.Time `xml:"LastModified"`
+	ETag         string    `xml:"ETag"`
+	Size         int64     `xml:"Size"`
+	StorageClass string    `xml:"StorageClass"`
+}
+
+type testListPrefix struct {
+	Prefix string `xml:"Prefix"`
+}
+
+type testListResult struct {
+	XMLName        xml.Name           `xml:"ListBucketResult"`
+	Name           string             `xml:"Name"`
+	Prefix         string             `xml:"Prefix"`
+	Delimiter      string             `xml:"Delimiter,omitempty"`
+	KeyCount       int                `xml:"KeyCount"`
+	MaxKeys        int                `xml:"MaxKeys"`
+	IsTruncated    bool               `xml:"IsTruncated"`
+	Contents       []testListContents `xml:"Contents"`
+	CommonPrefixes []testListPrefix   `xml:"CommonPrefixes"`
+}
+
+// newTestServer starts an in-process S3 server with an empty bucket.
+func newTestServer(bucket string) (*httptest.Server, *testServer) {
+	s := &testServer{bucket: bucket, objects: make(map[string]testObject)}
+	return httptest.NewServer(s), s
+}
+
+// Put adds an object to the test bucket.
+func (s *testServer) Put(key string, data []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.objects[key] = testObject{Data: data, Modified: time.Now().UTC().Truncate(time.Second)}
+}
+
+// Keys returns the sorted keys of all objects in the test bucket.
+func (s *testServer) Keys() (keys []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for k := range s.objects {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+func (s *testServer) error(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte(`<?xml version="1.0" encoding="UTF-8"?><Error><Code>` + code + `</Code><Message>` + code + `</Message></Error>`))
+}
+
+func etag(data []byte) string {
+	sum := md5.Sum(data) //nolint:gosec // used for etags only
+	return `"` + hex.EncodeToString(sum[:]) + `"`
+}
+
+// ServeHTTP handles S3 API requests.
+func (s *testServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("X-Amz-Request-Id", "test")
+
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+
+	if bucket != s.bucket {
+		s.error(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	switch {
+	case key == "" && r.Method == http.MethodGet:
+		s.list(w, r)
+	case key == "":
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodPut:
+		data, err := readBody(r)
+
+		if err != nil {
+			s.error(w, http.StatusBadRequest, "IncompleteBody")
+			return
+		}
+
+		s.objects[key] = testObject{Data: data, Modified: time.Now().UTC().Truncate(time.Second)}
+		w.Header().Set("ETag", etag(data))
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodGet || r.Method == http.MethodHead:
+		obj, ok := s.objects[key]
+
+		if !ok {
+			s.error(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Header().Set("Content-Length", strconv.Itoa(len(obj.Data)))
+		w.Header().Set("Last-Modified", obj.Modified.Format(http.TimeFormat))
+		w.Header().Set("ETag", etag(obj.Data))
+		w.WriteHeader(http.StatusOK)
+
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(obj.Data)
+		}
+	case r.Method == http.MethodDelete:
+		delete(s.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		s.error(w, http.StatusNotImplemented, "NotImplemented")
+	}
+}
+
+// list handles ListObjectsV2 requests.
+func (s *testServer) list(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	prefix := q.Get("prefix")
+	delimiter := q.Get("delimiter")
+
+	result := testListResult{Name: s.bucket, Prefix: prefix, Delimiter: delimiter, MaxKeys: 1000}
+	prefixes := make(map[string]bool)
+
+	for _, k := range s.keys() {
+		if !strings.HasPrefix(k, prefix) {
+			continue
+		}
+
+		if delimiter != "" {
+			if i := strings.Index(k[len(prefix):], delimiter); i >= 0 {
+				p := k[:len(prefix)+i+len(delimiter)]
+
+				if !prefixes[p] {
+					prefixes[p] = true
+					result.CommonPrefixes = append(result.CommonPrefixes, testListPrefix{Prefix: p})
+				}
+
+				continue
+			}
+		}
+
+		obj := s.objects[k]
+		result.Contents = append(result.Contents, testListContents{
+			Key:          k,
+			LastModified: obj.Modified,
+			ETag:         etag(obj.Data),
+			Size:         int64(len(obj.Data)),
+			StorageClass: "STANDARD",
+		})
+	}
+
+	result.KeyCount = len(result.Contents) + len(result.CommonPrefixes)
+
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(http.StatusOK)
+	_ = xml.NewEncoder(w).Encode(result)
+}
+
+// keys returns the sorted object keys, the caller must hold the lock.
+func (s *testServer) keys() (keys []string) {
+	for k := range s.objects {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// readBody returns the request payload and decodes it if the aws-chunked encoding is used.
+func readBody(r *http.Request) ([]byte, error) {
+	if !strings.HasPrefix(r.Header.Get("X-Amz-Content-Sha256"), "STREAMING-") {
+		return io.ReadAll(r.Body)
+	}
+
+	var buf bytes.Buffer
+
+	reader := bufio.NewReader(r.Body)
+
+	for {
+		line, err := reader.ReadString('\n')
+
+		if err != nil {
+			return nil, err
+		}
+
+		sizeHex, _, _ := strings.Cut(strings.TrimSpace(line), ";")
+		size, err := strconv.ParseInt(sizeHex, 16, 64)
+
+		if err != nil {
+			return nil, err
+		} else if size == 0 {
+			return buf.Bytes(), nil
+		}
+
+		if _, err = io.CopyN(&buf, reader, size); err != nil {
+			return nil, err
+		}
+
+		// Skip the CRLF after each chunk.
+		if _, err = reader.ReadString('\n'); err != nil {
+			return nil, err
+		}
+	}
+}
@@ -0,0 +1,547 @@
+package sftp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"os"
+	"path"
+	"runtime/debug"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+// ConnectTimeout is the maximum time to establish a connection if no request timeout is specified.
+const ConnectTimeout = 30 * time.Second
+
+// Client represents an SFTP client for a single remote folder. The connection is established
+// when the first operation is performed and must be closed once it is no longer needed.
+type Client struct {
+	mu       sync.Mutex
+	ctx      context.Context
+	config   *ssh.ClientConfig
+	endpoint Endpoint
+	conn     *ssh.Client
+	client   *sftp.Client
+	timeout  time.Duration
+	mkdir    map[string]bool
+	pin      func(fingerprint string) error
+}
+
+// Endpoint represents the parsed connection details of an SFTP service URL,
+// e.g. "sftp://nas.example.com:2222/volume1/photos?fingerprint=SHA256:...".
+type Endpoint struct {
+	Host        string
+	Root        string
+	Fingerprint string
+}
+
+// ParseEndpoint parses an SFTP service URL with the absolute path of the remote root folder.
+func ParseEndpoint(serverUrl string) (result Endpoint, err error) {
+	u, err := url.Parse(serverUrl)
+
+	// Check url.
+	if err != nil {
+		return result, err
+	} else if u == nil || u.Hostname() == "" {
+		return result, fmt.Errorf("invalid server url")
+	}
+
+	switch u.Scheme {
+	case "sftp", "ssh":
+	default:
+		return result, fmt.Errorf("unsupported url scheme %s", clean.Log(u.Scheme))
+	}
+
+	if port := u.Port(); port != "" {
+		result.Host = net.JoinHostPort(u.Hostname(), port)
+	} else {
+		result.Host = net.JoinHostPort(u.Hostname(), DefaultPort)
+	}
+
+	result.Root = path.Clean("/" + u.Path)
+
+	// Base64 encoded fingerprints may contain unescaped plus signs, which are decoded as spaces.
+	result.Fingerprint = strings.ReplaceAll(u.Query().Get("fingerprint"), " ", "+")
+
+	return result, nil
+}
+
+// NewClient creates a new SFTP client for the specified endpoint. The private key is
+// optional, if it is encrypted, the password is used as passphrase.
+func NewClient(serverUrl, user, pass, key string, timeout time.Duration) (*Client, error) {
+	endpoint, err := ParseEndpoint(serverUrl)
+
+	if err != nil {
+		return nil, err
+	}
+
+	// Use the username from the service url if none was specified.
+	if user == "" {
+		if u, parseErr := url.Parse(serverUrl); parseErr == nil {
+			user = u.User.Username()
+		}
+	}
+
+	if user == "" {
+		return nil, fmt.Errorf("missing username")
+	}
+
+	auth, err := authMethods(pass, key)
+
+	if err != nil {
+		return nil, err
+	}
+
+	connectTimeout := timeout
+
+	if connectTimeout <= 0 {
+		connectTimeout = ConnectTimeout
+	}
+
+	result := &Client{
+		ctx: context.Background(),
+		config: &ssh.ClientConfig{
+			User:    user,
+			Auth:    auth,
+			Timeout: connectTimeout,
+		},
+		endpoint: endpoint,
+		timeout:  timeout,
+		mkdir:    make(map[string]bool, 128),
+	}
+
+	result.config.HostKeyCallback = result.verifyHostKey
+
+	return result, nil
+}
+
+// PinHostKey sets the fingerprint of a previously accepted host key if the service URL does not specify one,
+// and the function that stores the fingerprint when a server without known fingerprint is connected for the first time.
+func (c *Client) PinHostKey(fingerprint string, pin func(fingerprint string) error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.endpoint.Fingerprint == "" {
+		c.endpoint.Fingerprint = strings.TrimSpace(fingerprint)
+	}
+
+	c.pin = pin
+}
+
+// Fingerprint returns the SHA256 fingerprint of the server's host key if it is known.
+func (c *Client) Fingerprint() string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.endpoint.Fingerprint
+}
+
+// authMethods returns the SSH authentication methods for the specified password and private key.
+func authMethods(pass, key string) (result []ssh.AuthMethod, err error) {
+	if key = strings.TrimSpace(key); key != "" {
+		var signer ssh.Signer
+
+		signer, err = ssh.ParsePrivateKey([]byte(key))
+
+		// Use the password as passphrase if the private key is encrypted.
+		var passphraseErr *ssh.PassphraseMissingError
+
+		if errors.As(err, &passphraseErr) && pass != "" {
+			signer, err = ssh.ParsePrivateKeyWithPassphrase([]byte(key), []byte(pass))
+			pass = ""
+		}
+
+		if err != nil {
+			log.Debugf("sftp: %s", clean.Error(err))
+			return result, fmt.Errorf("invalid private key")
+		}
+
+		result = append(result, ssh.PublicKeys(signer))
+	}
+
+	if pass != "" {
+		result = append(result, ssh.Password(pass))
+	}
+
+	if len(result) == 0 {
+		return result, fmt.Errorf("missing password or private key")
+	}
+
+	return result, nil
+}
+
+// verifyHostKey checks the SHA256 fingerprint of the server's host key. If no fingerprint is known yet,
+// the host key is trusted on first use and pinned, or rejected if it cannot be pinned, so that
+// credentials are never sent to an unverified server. It is called while the client is locked.
+func (c *Client) verifyHostKey(hostname string, remote net.Addr, key ssh.PublicKey) error {
+	found := ssh.FingerprintSHA256(key)
+
+	if expected := strings.TrimPrefix(c.endpoint.Fingerprint, "SHA256:"); expected != "" {
+		if strings.TrimPrefix(found, "SHA256:") != expected {
+			return fmt.Errorf("sftp: host key %s of %s does not match", found, clean.Log(hostname))
+		}
+
+		return nil
+	} else if c.pin == nil {
+		return fmt.Errorf("sftp: host key %s of %s cannot be verified without fingerprint", found, clean.Log(hostname))
+	}
+
+	if err := c.pin(found); err != nil {
+		return fmt.Errorf("sftp: failed to pin host key %s of %s (%s)", found, clean.Log(hostname), err)
+	}
+
+	log.Infof("sftp: pinned host key %s of %s", found, clean.Log(hostname))
+
+	c.endpoint.Fingerprint = found
+
+	return nil
+}
+
+// connect returns the SFTP client and establishes a new connection if needed.
+func (c *Client) connect() (*sftp.Client, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.client != nil {
+		return c.client, nil
+	}
+
+	log.Debugf("sftp: connecting to %s", clean.Log(c.endpoint.Host))
+
+	conn, err := ssh.Dial("tcp", c.endpoint.Host, c.config)
+
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := sftp.NewClient(conn)
+
+	if err != nil {
+		_ = conn.Close()
+		return nil, err
+	}
+
+	c.conn = conn
+	c.client = client
+
+	return client, nil
+}
+
+// Close closes the connection, if any.
+func (c *Client) Close() (err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.client != nil {
+		err = c.client.Close()
+		c.client = nil
+	}
+
+	if c.conn != nil {
+		_ = c.conn.Close()
+		c.conn = nil
+	}
+
+	return err
+}
+
+// withTimeout returns a context with the specified total request time.
+func (c *Client) withTimeout(timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout < 0 {
+		return context.WithCancel(c.ctx)
+	} else if timeout == 0 {
+		timeout = c.timeout
+	}
+
+	if timeout <= 0 {
+		return context.WithCancel(c.ctx)
+	}
+
+	return context.WithTimeout(c.ctx, timeout)
+}
+
+// remoteName returns the absolute remote file name, which cannot be outside the root folder.
+func (c *Client) remoteName(name string) string {
+	return path.Join(c.endpoint.Root, path.Clean("/"+name))
+}
+
+// absName returns the file name relative to the root folder, with a leading slash.
+func (c *Client) absName(remoteName string) string {
+	return "/" + strings.Trim(strings.TrimPrefix(remoteName, c.endpoint.Root), "/")
+}
+
+// list returns the remote files and folders in a directory, optionally recursively. Hidden files
+// and folders are skipped.
+func (c *Client) list(ctx context.Context, dir string, recursive bool) (result fs.FileInfos, err error) {
+	client, err := c.connect()
+
+	if err != nil {
+		return result, err
+	}
+
+	found, err := client.ReadDirContext(ctx, dir)
+
+	if err != nil {
+		return result, err
+	}
+
+	for _, info := range found {
+		name := info.Name()
+
+		if name == "" || strings.HasPrefix(name, ".") {
+			continue
+		}
+
+		remoteName := path.Join(dir, name)
+
+		if info.IsDir() {
+			result = append(result, fs.FileInfo{
+				Name: name,
+				Abs:  c.absName(remoteName),
+				Date: info.ModTime(),
+				Dir:  true,
+			})
+
+			if !recursive {
+				continue
+			}
+
+			nested, nestedErr := c.list(ctx, remoteName, true)
+
+			if nestedErr != nil {
+				return result, nestedErr
+			}
+
+			result = append(result, nested...)
+		} else if info.Mode().IsRegular() {
+			result = append(result, fs.FileInfo{
+				Name: name,
+				Abs:  c.absName(remoteName),
+				Size: info.Size(),
+				Date: info.ModTime(),
+				Dir:  false,
+			})
+		}
+	}
+
+	return result, nil
+}
+
+// Files returns information about files in a directory, optionally recursively.
+func (c *Client) Files(dir string, recursive bool) (result fs.FileInfos, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("sftp: %s (panic while listing files)\nstack: %s", r, debug.Stack())
+		}
+	}()
+
+	ctx, cancel := c.withTimeout(-1)
+	defer cancel()
+
+	found, err := c.list(ctx, c.remoteName(dir), recursive)
+
+	if err != nil {
+		return result, err
+	}
+
+	result = make(fs.FileInfos, 0, len(found))
+
+	for _, f := range found {
+		if !f.Dir {
+			result = append(result, f)
+		}
+	}
+
+	return result, nil
+}
+
+// Directories returns all subdirectories in a path.
+func (c *Client) Directories(dir string, recursive bool, timeout time.Duration) (result fs.FileInfos, err error) {
+	ctx, cancel := c.withTimeout(timeout)
+	defer cancel()
+
+	found, err := c.list(ctx, c.remoteName(dir), recursive)
+
+	if err != nil {
+		return result, err
+	}
+
+	result = make(fs.FileInfos, 0, len(found))
+
+	for _, f := range found {
+		if f.Dir {
+			result = append(result, f)
+		}
+	}
+
+	sort.Sort(result)
+
+	return result, nil
+}
+
+// MkdirAll recursively creates remote directories.
+func (c *Client) MkdirAll(dir string) error {
+	dir = c.remoteName(dir)
+
+	if dir == c.endpoint.Root || c.mkdir[dir] {
+		// Dir already exists.
+		return nil
+	}
+
+	client, err := c.connect()
+
+	if err != nil {
+		return err
+	}
+
+	if err = client.MkdirAll(dir); err != nil {
+		return err
+	}
+
+	c.mkdir[dir] = true
+
+	return nil
+}
+
+// Upload uploads a single file to the remote server.
+func (c *Client) Upload(src, dest string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("sftp: %s (panic while uploading)\nstack: %s", r, debug.Stack())
+		}
+	}()
+
+	if !fs.FileExists(src) {
+		return fmt.Errorf("file %s not found", clean.Log(path.Base(src)))
+	}
+
+	client, err := c.connect()
+
+	if err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(src, os.O_RDONLY, 0) //nolint:gosec // path provided by caller; read-only
+
+	if err != nil {
+		log.Errorf("sftp: %s", clean.Error(err))
+		return fmt.Errorf("sftp: failed to read %s", clean.Log(path.Base(src)))
+	}
+
+	defer f.Close()
+
+	writer, err := client.Create(c.remoteName(dest))
+
+	if err != nil {
+		log.Errorf("sftp: %s", clean.Error(err))
+		return fmt.Errorf("sftp: failed to write %s", clean.Log(dest))
+	}
+
+	defer writer.Close()
+
+	if _, err = writer.ReadFrom(f); err != nil {
+		log.Errorf("sftp: %s", clean.Error(err))
+		return fmt.Errorf("sftp: failed to upload %s", clean.Log(dest))
+	}
+
+	return nil
+}
+
+// Download downloads a single file to the given location.
+func (c *Client) Download(src, dest string, force bool) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			log.Errorf("sftp: %s (panic)\nstack: %s", r, clean.Log(src))
+			err = fmt.Errorf("sftp: unexpected error while downloading %s", clean.Log(src))
+		}
+	}()
+
+	// Skip if file already exists.
+	if _, err = os.Stat(dest); err == nil && !force {
+		return fmt.Errorf("sftp: download skipped, %s already exists", clean.Log(dest))
+	}
+
+	dir := path.Dir(dest)
+	dirInfo, err := os.Stat(dir)
+
+	if err != nil {
+		// Create local storage path.
+		if err = fs.MkdirAll(dir); err != nil {
+			return fmt.Errorf("sftp: cannot create folder %s (%s)", clean.Log(dir), err)
+		}
+	} else if !dirInfo.IsDir() {
+		return fmt.Errorf("sftp: %s is not a folder", clean.Log(dir))
+	}
+
+	client, err := c.connect()
+
+	if err != nil {
+		return err
+	}
+
+	// Start download.
+	reader, err := client.Open(c.remoteName(src))
+
+	// Error?
+	if err != nil {
+		log.Errorf("sftp: %s", clean.Error(err))
+		return fmt.Errorf("sftp: failed to download %s", clean.Log(src))
+	}
+
+	defer reader.Close()
+
+	f, err := os.OpenFile(dest, os.O_TRUNC|os.O_RDWR|os.O_CREATE, fs.ModeFile) //nolint:gosec // dest provided by caller
+
+	if err != nil {
+		log.Errorf("sftp: %s", clean.Error(err))
+		return fmt.Errorf("sftp: failed to create %s", clean.Log(path.Base(dest)))
+	}
+
+	defer f.Close()
+
+	if _, err = io.Copy(f, reader); err != nil {
+		log.Errorf("sftp: %s", clean.Error(err))
+		return fmt.Errorf("sftp: failed writing to %s", clean.Log(path.Base(dest)))
+	}
+
+	return nil
+}
+
+// Delete deletes a single file or directory on the remote server.
+func (c *Client) Delete(name string) error {
+	remoteName := c.remoteName(name)
+
+	if remoteName == c.endpoint.Root {
+		return fmt.Errorf("sftp: cannot delete root folder")
+	}
+
+	client, err := c.connect()
+
+	if err != nil {
+		return err
+	}
+
+	info, err := client.Lstat(remoteName)
+
+	if err != nil {
+		return err
+	} else if info.IsDir() {
+		// Forget created folders, since nested folders are deleted as well.
+		c.mkdir = make(map[string]bool, 128)
+		return client.RemoveAll(remoteName)
+	}
+
+	return client.Remove(remoteName)
+}
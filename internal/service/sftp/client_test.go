@@ -0,0 +1,370 @@
+package sftp
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/fs"
+)
+
+const (
+	testUser = "admin"
+	testPass = "photoprism"
+)
+
+func TestParseEndpoint(t *testing.T) {
+	t.Run("DefaultPort", func(t *testing.T) {
+		result, err := ParseEndpoint("sftp://nas.localssl.dev/volume1/photos/")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "nas.localssl.dev:22", result.Host)
+		assert.Equal(t, "/volume1/photos", result.Root)
+		assert.Equal(t, "", result.Fingerprint)
+	})
+	t.Run("PortAndFingerprint", func(t *testing.T) {
+		result, err := ParseEndpoint("ssh://admin@nas:2222?fingerprint=SHA256:abc")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "nas:2222", result.Host)
+		assert.Equal(t, "/", result.Root)
+		assert.Equal(t, "SHA256:abc", result.Fingerprint)
+	})
+	t.Run("NoHost", func(t *testing.T) {
+		_, err := ParseEndpoint("sftp:///photos")
+		assert.Error(t, err)
+	})
+	t.Run("InvalidScheme", func(t *testing.T) {
+		_, err := ParseEndpoint("https://nas/photos")
+		assert.Error(t, err)
+	})
+}
+
+func TestNewClient(t *testing.T) {
+	t.Run("Password", func(t *testing.T) {
+		c, err := NewClient("sftp://nas/photos", testUser, testPass, "", 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.IsType(t, &Client{}, c)
+		assert.Equal(t, "/photos", c.endpoint.Root)
+		assert.Equal(t, testUser, c.config.User)
+	})
+	t.Run("UserFromURL", func(t *testing.T) {
+		c, err := NewClient("sftp://alice@nas/photos", "", testPass, "", 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "alice", c.config.User)
+	})
+	t.Run("NoUser", func(t *testing.T) {
+		_, err := NewClient("sftp://nas/photos", "", testPass, "", 0)
+		assert.Error(t, err)
+	})
+	t.Run("NoCredentials", func(t *testing.T) {
+		_, err := NewClient("sftp://nas/photos", testUser, "", "", 0)
+		assert.Error(t, err)
+	})
+	t.Run("InvalidKey", func(t *testing.T) {
+		_, err := NewClient("sftp://nas/photos", testUser, "", "invalid", 0)
+		assert.Error(t, err)
+	})
+}
+
+func TestClient_remoteName(t *testing.T) {
+	c := &Client{endpoint: Endpoint{Root: "/volume1/photos"}}
+
+	assert.Equal(t, "/volume1/photos/Photos/a.jpg", c.remoteName("Photos/a.jpg"))
+	assert.Equal(t, "/volume1/photos", c.remoteName("/"))
+	assert.Equal(t, "/volume1/photos/etc/passwd", c.remoteName("../../etc/passwd"))
+	assert.Equal(t, "/Photos/a.jpg", c.absName("/volume1/photos/Photos/a.jpg"))
+
+	c.endpoint.Root = "/"
+
+	assert.Equal(t, "/Photos/a.jpg", c.remoteName("Photos/a.jpg"))
+	assert.Equal(t, "/Photos/a.jpg", c.absName("/Photos/a.jpg"))
+}
+
+func TestClient_Auth(t *testing.T) {
+	signer, key := newTestKey()
+	s := newTestServer(testUser, testPass, signer.PublicKey())
+	defer s.Close()
+
+	root := t.TempDir()
+
+	t.Run("PrivateKey", func(t *testing.T) {
+		c, err := NewClient(s.URL(root), testUser, "", key, time.Minute)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer c.Close()
+
+		_, err = c.Directories("/", false, 0)
+		assert.NoError(t, err)
+	})
+	t.Run("Fingerprint", func(t *testing.T) {
+		c, err := NewClient(s.RawURL(root)+"?fingerprint="+s.Fingerprint(), testUser, testPass, "", 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer c.Close()
+
+		_, err = c.Directories("/", false, 0)
+		assert.NoError(t, err)
+	})
+	t.Run("WrongFingerprint", func(t *testing.T) {
+		c, err := NewClient(s.RawURL(root)+"?fingerprint=SHA256:invalid", testUser, testPass, "", 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer c.Close()
+
+		_, err = c.Directories("/", false, 0)
+		assert.Error(t, err)
+	})
+	t.Run("Unverified", func(t *testing.T) {
+		c, err := NewClient(s.RawURL(root), testUser, testPass, "", 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer c.Close()
+
+		_, err = c.Directories("/", false, 0)
+
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "cannot be verified without fingerprint")
+		}
+	})
+	t.Run("PinHostKey", func(t *testing.T) {
+		var pinned string
+
+		c, err := NewClient(s.RawURL(root), testUser, testPass, "", 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		c.PinHostKey("", func(fingerprint string) error {
+			pinned = fingerprint
+			return nil
+		})
+
+		_, err = c.Directories("/", false, 0)
+		assert.NoError(t, err)
+		assert.NoError(t, c.Close())
+		assert.Equal(t, s.Fingerprint(), pinned)
+		assert.Equal(t, s.Fingerprint(), c.Fingerprint())
+
+		// Connect again with the pinned fingerprint.
+		c, err = NewClient(s.RawURL(root), testUser, testPass, "", 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		c.PinHostKey(pinned, nil)
+
+		_, err = c.Directories("/", false, 0)
+		assert.NoError(t, err)
+		assert.NoError(t, c.Close())
+	})
+	t.Run("PinnedHostKeyChanged", func(t *testing.T) {
+		c, err := NewClient(s.RawURL(root), testUser, testPass, "", 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer c.Close()
+
+		c.PinHostKey("SHA256:other", func(fingerprint string) error {
+			t.Fatal("host key must not be pinned again")
+			return nil
+		})
+
+		_, err = c.Directories("/", false, 0)
+
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "does not match")
+		}
+	})
+	t.Run("WrongPassword", func(t *testing.T) {
+		c, err := NewClient(s.URL(root), testUser, "wrong", "", 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer c.Close()
+
+		_, err = c.Files("/", false)
+		assert.Error(t, err)
+	})
+}
+
+func TestClient_FilesAndDirectories(t *testing.T) {
+	s := newTestServer(testUser, testPass, nil)
+	defer s.Close()
+
+	root := t.TempDir()
+
+	for name, data := range map[string]string{
+		"Photos/a.jpg":         "a",
+		"Photos/2024/b.jpg":    "bb",
+		"Photos/2024/01/c.jpg": "c",
+		"Photos/.hidden":       "x",
+		"Photos/.thumbs/d.jpg": "d",
+		"e.jpg":                "e",
+	} {
+		fileName := filepath.Join(root, filepath.FromSlash(name))
+
+		if err := os.MkdirAll(filepath.Dir(fileName), fs.ModeDir); err != nil {
+			t.Fatal(err)
+		} else if err = os.WriteFile(fileName, []byte(data), fs.ModeFile); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	c, err := NewClient(s.URL(root), testUser, testPass, "", 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer c.Close()
+
+	t.Run("FilesNonRecursive", func(t *testing.T) {
+		files, err := c.Files("/Photos", false)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"/Photos/a.jpg"}, files.Abs())
+	})
+	t.Run("FilesRecursive", func(t *testing.T) {
+		files, err := c.Files("/Photos", true)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.ElementsMatch(t, []string{"/Photos/2024/01/c.jpg", "/Photos/2024/b.jpg", "/Photos/a.jpg"}, files.Abs())
+
+		for _, f := range files {
+			if f.Name == "b.jpg" {
+				assert.Equal(t, int64(2), f.Size)
+				assert.False(t, f.Date.IsZero())
+			}
+		}
+	})
+	t.Run("DirectoriesRoot", func(t *testing.T) {
+		dirs, err := c.Directories("/", false, 0)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"/Photos"}, dirs.Abs())
+	})
+	t.Run("DirectoriesRecursive", func(t *testing.T) {
+		dirs, err := c.Directories("/Photos", true, time.Minute)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, []string{"/Photos/2024", "/Photos/2024/01"}, dirs.Abs())
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := c.Files("/Missing", true)
+		assert.Error(t, err)
+	})
+}
+
+func TestClient_UploadDownloadDelete(t *testing.T) {
+	s := newTestServer(testUser, testPass, nil)
+	defer s.Close()
+
+	root := t.TempDir()
+
+	c, err := NewClient(s.URL(root), testUser, testPass, "", 0)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer c.Close()
+
+	tempDir := t.TempDir()
+	src := filepath.Join(tempDir, "src.txt")
+
+	if err = os.WriteFile(src, []byte("Hello SFTP!"), fs.ModeFile); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NoError(t, c.MkdirAll("/Photos/New"))
+	assert.DirExists(t, filepath.Join(root, "Photos", "New"))
+
+	if err = c.Upload(src, "/Photos/New/hello.txt"); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.FileExists(t, filepath.Join(root, "Photos", "New", "hello.txt"))
+
+	dest := filepath.Join(tempDir, "download", "hello.txt")
+
+	if err = c.Download("/Photos/New/hello.txt", dest, false); err != nil {
+		t.Fatal(err)
+	}
+
+	if data, readErr := os.ReadFile(dest); readErr != nil {
+		t.Fatal(readErr)
+	} else {
+		assert.Equal(t, "Hello SFTP!", string(data))
+	}
+
+	// Existing files are not overwritten unless forced.
+	assert.Error(t, c.Download("/Photos/New/hello.txt", dest, false))
+	assert.NoError(t, c.Download("/Photos/New/hello.txt", dest, true))
+
+	// Missing files cannot be downloaded.
+	assert.Error(t, c.Download("/Photos/New/missing.txt", filepath.Join(tempDir, "missing.txt"), false))
+	assert.NoFileExists(t, filepath.Join(tempDir, "missing.txt"))
+
+	// Deleting a folder removes all nested files.
+	if err = c.Delete("/Photos"); err != nil {
+		t.Fatal(err)
+	}
+
+	assert.NoDirExists(t, filepath.Join(root, "Photos"))
+
+	// The root folder cannot be deleted.
+	assert.Error(t, c.Delete("/"))
+	assert.DirExists(t, root)
+
+	// Folders can be created again after they have been deleted.
+	assert.NoError(t, c.MkdirAll("/Photos/New"))
+	assert.DirExists(t, filepath.Join(root, "Photos", "New"))
+}
@@ -0,0 +1,159 @@
+package sftp
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"fmt"
+	"net"
+	"sync"
+
+	"github.com/pkg/sftp"
+	"golang.org/x/crypto/ssh"
+)
+
+// testServer implements an in-process SSH server with the SFTP subsystem for the specified user.
+type testServer struct {
+	listener net.Listener
+	config   *ssh.ServerConfig
+	hostKey  ssh.Signer
+	wg       sync.WaitGroup
+}
+
+// newTestKey generates a new private key and returns it in OpenSSH PEM format.
+func newTestKey() (ssh.Signer, string) {
+	_, key, err := ed25519.GenerateKey(rand.Reader)
+
+	if err != nil {
+		panic(err)
+	}
+
+	block, err := ssh.MarshalPrivateKey(key, "")
+
+	if err != nil {
+		panic(err)
+	}
+
+	signer, err := ssh.NewSignerFromKey(key)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return signer, string(pem.EncodeToMemory(block))
+}
+
+// newTestServer starts an in-process SSH server that accepts the password and public key specified.
+func newTestServer(user, pass string, authorizedKey ssh.PublicKey) *testServer {
+	hostKey, _ := newTestKey()
+
+	config := &ssh.ServerConfig{
+		PasswordCallback: func(c ssh.ConnMetadata, p []byte) (*ssh.Permissions, error) {
+			if c.User() == user && pass != "" && string(p) == pass {
+				return nil, nil
+			}
+
+			return nil, fmt.Errorf("password rejected for %s", c.User())
+		},
+		PublicKeyCallback: func(c ssh.ConnMetadata, key ssh.PublicKey) (*ssh.Permissions, error) {
+			if c.User() == user && authorizedKey != nil && string(key.Marshal()) == string(authorizedKey.Marshal()) {
+				return nil, nil
+			}
+
+			return nil, fmt.Errorf("unknown public key for %s", c.User())
+		},
+	}
+
+	config.AddHostKey(hostKey)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+
+	if err != nil {
+		panic(err)
+	}
+
+	s := &testServer{listener: listener, config: config, hostKey: hostKey}
+
+	s.wg.Add(1)
+
+	go s.serve()
+
+	return s
+}
+
+// URL returns the service url for the specified remote root folder, including the host key fingerprint.
+func (s *testServer) URL(root string) string {
+	return s.RawURL(root) + "?fingerprint=" + s.Fingerprint()
+}
+
+// RawURL returns the service url for the specified remote root folder without fingerprint.
+func (s *testServer) RawURL(root string) string {
+	return "sftp://" + s.listener.Addr().String() + root
+}
+
+// Fingerprint returns the SHA256 fingerprint of the server's host key.
+func (s *testServer) Fingerprint() string {
+	return ssh.FingerprintSHA256(s.hostKey.PublicKey())
+}
+
+// Close stops the server.
+func (s *testServer) Close() {
+	_ = s.listener.Close()
+	s.wg.Wait()
+}
+
+// serve accepts new connections until the listener is closed.
+func (s *testServer) serve() {
+	defer s.wg.Done()
+
+	for {
+		conn, err := s.listener.Accept()
+
+		if err != nil {
+			return
+		}
+
+		go s.handle(conn)
+	}
+}
+
+// handle performs the SSH handshake and serves SFTP subsystem requests.
+func (s *testServer) handle(conn net.Conn) {
+	_, chans, reqs, err := ssh.NewServerConn(conn, s.config)
+
+	if err != nil {
+		_ = conn.Close()
+		return
+	}
+
+	go ssh.DiscardRequests(reqs)
+
+	for newChannel := range chans {
+		if newChannel.ChannelType() != "session" {
+			_ = newChannel.Reject(ssh.UnknownChannelType, "unknown channel type")
+			continue
+		}
+
+		channel, requests, acceptErr := newChannel.Accept()
+
+		if acceptErr != nil {
+			continue
+		}
+
+		go func(in <-chan *ssh.Request) {
+			for req := range in {
+				// The payload contains the length-prefixed subsystem name.
+				ok := req.Type == "subsystem" && len(req.Payload) > 4 && string(req.Payload[4:]) == "sftp"
+				_ = req.Reply(ok, nil)
+			}
+		}(requests)
+
+		go func() {
+			defer channel.Close()
+
+			if server, serverErr := sftp.NewServer(channel); serverErr == nil {
+				_ = server.Serve()
+			}
+		}()
+	}
+}
@@ -6,6 +6,9 @@ type Type = string
 // Identifiers for common remote services.
 const (
 	WebDAV    Type = "webdav"
+	S3        Type = "s3"
+	SFTP      Type = "sftp"
+	Local     Type = "local"
 	Facebook  Type = "facebook"
 	Twitter   Type = "twitter"
 	Flickr    Type = "flickr"
@@ -16,3 +19,13 @@ const (
 	GDrive    Type = "gdrive"
 	OneDrive  Type = "onedrive"
 )
+
+// Supported checks if files can be shared with and synchronized to the specified service type.
+func Supported(t Type) bool {
+	switch t {
+	case WebDAV, S3, SFTP, Local:
+		return true
+	default:
+		return false
+	}
+}
@@ -339,6 +339,11 @@ func (c *Client) DownloadDir(src, dest string, recursive, force bool) (errs []er
 	return errs
 }
 
+// Close does nothing, since requests do not use a persistent connection.
+func (c *Client) Close() error {
+	return nil
+}
+
 // Delete deletes a single file or directory on a remote server.
 func (c *Client) Delete(dir string) error {
 	dir = trimPath(dir)
@@ -0,0 +1,85 @@
+package webhook
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/http/header"
+)
+
+// Timeout specifies the maximum time for a single delivery attempt.
+var Timeout = 15 * time.Second
+
+// UserAgent is sent with each delivery request.
+var UserAgent = "PhotoPrism/Webhooks"
+
+// ResponseLimit specifies the maximum number of response body bytes that are kept for the delivery log.
+const ResponseLimit = 1024
+
+// Payload represents the JSON request body sent to webhook endpoints.
+type Payload struct {
+	Type      string      `json:"type"`
+	Timestamp time.Time   `json:"timestamp"`
+	Data      interface{} `json:"data"`
+}
+
+// NewPayload returns the encoded request body for the specified event topic and data.
+func NewPayload(topic string, ts time.Time, data interface{}) ([]byte, error) {
+	return json.Marshal(Payload{Type: topic, Timestamp: ts.UTC(), Data: data})
+}
+
+// Response represents the result of a delivery attempt.
+type Response struct {
+	StatusCode int
+	Body       string
+}
+
+// Success checks if the endpoint has accepted the delivery.
+func (r Response) Success() bool {
+	return r.StatusCode >= 200 && r.StatusCode < 300
+}
+
+// Send posts a signed payload to the specified endpoint URL. An error is only returned if
+// the request could not be completed, so callers must also check the response status.
+func Send(endpointUrl, secret, msgId string, payload []byte) (resp Response, err error) {
+	ts := time.Now()
+
+	signature, err := Sign(secret, msgId, ts, payload)
+
+	if err != nil {
+		return resp, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, endpointUrl, bytes.NewReader(payload))
+
+	if err != nil {
+		return resp, err
+	}
+
+	req.Header.Set(header.ContentType, header.ContentTypeJson)
+	req.Header.Set(header.UserAgent, UserAgent)
+	req.Header.Set(header.WebhookID, msgId)
+	req.Header.Set(header.WebhookTimestamp, strconv.FormatInt(ts.Unix(), 10))
+	req.Header.Set(header.WebhookSignature, signature)
+
+	client := &http.Client{Timeout: Timeout}
+
+	r, err := client.Do(req)
+
+	if err != nil {
+		return resp, err
+	}
+
+	defer r.Body.Close()
+
+	body, _ := io.ReadAll(io.LimitReader(r.Body, ResponseLimit))
+
+	resp.StatusCode = r.StatusCode
+	resp.Body = string(body)
+
+	return resp, nil
+}
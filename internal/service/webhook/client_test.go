@@ -0,0 +1,64 @@
+package webhook
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/http/header"
+)
+
+func TestNewPayload(t *testing.T) {
+	payload, err := NewPayload(ImportCompleted, time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), map[string]interface{}{"seconds": 5})
+
+	assert.NoError(t, err)
+	assert.Equal(t, `{"type":"import.completed","timestamp":"2025-01-02T03:04:05Z","data":{"seconds":5}}`, string(payload))
+}
+
+func TestSend(t *testing.T) {
+	secret := "whsec_MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw"
+	payload := []byte(`{"type":"index.completed"}`)
+
+	t.Run("Success", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			body, _ := io.ReadAll(r.Body)
+			unix, _ := strconv.ParseInt(r.Header.Get(header.WebhookTimestamp), 10, 64)
+
+			if r.Header.Get(header.WebhookID) != "msg_123" || !Verify(secret, "msg_123", time.Unix(unix, 0), body, r.Header.Get(header.WebhookSignature)) {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+
+			w.WriteHeader(http.StatusNoContent)
+		}))
+		defer ts.Close()
+
+		resp, err := Send(ts.URL, secret, "msg_123", payload)
+
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+		assert.True(t, resp.Success())
+	})
+	t.Run("ServerError", func(t *testing.T) {
+		ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte("unavailable"))
+		}))
+		defer ts.Close()
+
+		resp, err := Send(ts.URL, secret, "msg_123", payload)
+
+		assert.NoError(t, err)
+		assert.False(t, resp.Success())
+		assert.Equal(t, "unavailable", resp.Body)
+	})
+	t.Run("InvalidSecret", func(t *testing.T) {
+		_, err := Send("http://localhost/", "", "msg_123", payload)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,78 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/http/header"
+	"github.com/photoprism/photoprism/pkg/rnd"
+)
+
+// SecretSize specifies the number of random bytes in new secrets.
+const SecretSize = 24
+
+// SignatureVersion is the signature scheme prefix, see https://www.standardwebhooks.com/.
+const SignatureVersion = "v1"
+
+// NewSecret returns a new random signing secret, e.g. "whsec_MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw".
+func NewSecret() (string, error) {
+	b, err := rnd.RandomBytes(SecretSize)
+
+	if err != nil {
+		return "", err
+	}
+
+	return header.WebhookSecretPrefix + base64.StdEncoding.EncodeToString(b), nil
+}
+
+// SecretKey returns the signing key encoded in the secret.
+func SecretKey(secret string) ([]byte, error) {
+	if secret == "" {
+		return nil, fmt.Errorf("secret is empty")
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(secret, header.WebhookSecretPrefix))
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid secret")
+	}
+
+	return key, nil
+}
+
+// Sign returns the signature header value for the message id, timestamp, and payload.
+func Sign(secret, msgId string, ts time.Time, payload []byte) (string, error) {
+	key, err := SecretKey(secret)
+
+	if err != nil {
+		return "", err
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(msgId + "." + strconv.FormatInt(ts.Unix(), 10) + "."))
+	mac.Write(payload)
+
+	return SignatureVersion + "," + base64.StdEncoding.EncodeToString(mac.Sum(nil)), nil
+}
+
+// Verify checks if the signature header, which may contain multiple space-separated signatures, matches the payload.
+func Verify(secret, msgId string, ts time.Time, payload []byte, signatures string) bool {
+	expected, err := Sign(secret, msgId, ts, payload)
+
+	if err != nil {
+		return false
+	}
+
+	for _, sig := range strings.Fields(signatures) {
+		if hmac.Equal([]byte(sig), []byte(expected)) {
+			return true
+		}
+	}
+
+	return false
+}
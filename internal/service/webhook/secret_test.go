@@ -0,0 +1,57 @@
+package webhook
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/http/header"
+)
+
+func TestNewSecret(t *testing.T) {
+	secret, err := NewSecret()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.True(t, strings.HasPrefix(secret, header.WebhookSecretPrefix))
+
+	key, err := SecretKey(secret)
+
+	assert.NoError(t, err)
+	assert.Len(t, key, SecretSize)
+}
+
+func TestSecretKey(t *testing.T) {
+	t.Run("Empty", func(t *testing.T) {
+		_, err := SecretKey("")
+		assert.Error(t, err)
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := SecretKey("whsec_!invalid!")
+		assert.Error(t, err)
+	})
+}
+
+func TestSign(t *testing.T) {
+	// Test vector from the Standard Webhooks specification.
+	secret := "whsec_MfKQ9r8GKYqrTwjUPD8ILPZIo2LaLaSw"
+	msgId := "msg_p5jXN8AQM9LWM0D4loKWxJek"
+	ts := time.Unix(1614265330, 0)
+	payload := []byte(`{"test": 2432232314}`)
+
+	t.Run("Success", func(t *testing.T) {
+		sig, err := Sign(secret, msgId, ts, payload)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "v1,g0hM9SsE+OTPJTGt/tmIKtSyZlE3uFJELVlNIOLJ1OE=", sig)
+	})
+	t.Run("Verify", func(t *testing.T) {
+		assert.True(t, Verify(secret, msgId, ts, payload, "v1,invalid v1,g0hM9SsE+OTPJTGt/tmIKtSyZlE3uFJELVlNIOLJ1OE="))
+		assert.False(t, Verify(secret, msgId, ts, []byte(`{"test": 1}`), "v1,g0hM9SsE+OTPJTGt/tmIKtSyZlE3uFJELVlNIOLJ1OE="))
+		assert.False(t, Verify(secret, msgId, ts.Add(time.Second), payload, "v1,g0hM9SsE+OTPJTGt/tmIKtSyZlE3uFJELVlNIOLJ1OE="))
+	})
+}
@@ -0,0 +1,96 @@
+/*
+Package webhook provides signed event notifications to external endpoints based on the Standard Webhooks specification.
+
+Copyright (c) 2018 - 2025 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://www.photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package webhook
+
+import (
+	"strings"
+
+	"github.com/photoprism/photoprism/internal/event"
+)
+
+// Global log instance.
+var log = event.Log
+
+// Event topics that webhooks can subscribe to.
+const (
+	PhotosCreated   = "photos.created"
+	PhotosUpdated   = "photos.updated"
+	PhotosDeleted   = "photos.deleted"
+	PhotosArchived  = "photos.archived"
+	PhotosRestored  = "photos.restored"
+	AlbumsCreated   = "albums.created"
+	AlbumsUpdated   = "albums.updated"
+	AlbumsDeleted   = "albums.deleted"
+	ImportCompleted = "import.completed"
+	IndexCompleted  = "index.completed"
+)
+
+// Events lists the supported event topics.
+var Events = []string{
+	PhotosCreated,
+	PhotosUpdated,
+	PhotosDeleted,
+	PhotosArchived,
+	PhotosRestored,
+	AlbumsCreated,
+	AlbumsUpdated,
+	AlbumsDeleted,
+	ImportCompleted,
+	IndexCompleted,
+}
+
+// ValidEvent checks if the event topic is supported.
+func ValidEvent(topic string) bool {
+	for _, ev := range Events {
+		if ev == topic {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ParseEvents returns the supported topics from a comma or space-separated list, e.g. "photos.created, albums.*".
+// A single "*" or an empty string subscribes to all events, while unknown topics are ignored.
+func ParseEvents(s string) (result []string) {
+	s = strings.TrimSpace(s)
+
+	if s == "" || s == "*" {
+		return Events
+	}
+
+	topics := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool { return r == ',' || r == ' ' || r == ';' })
+
+	for _, ev := range Events {
+		for _, t := range topics {
+			if ev == t || strings.HasSuffix(t, ".*") && strings.HasPrefix(ev, strings.TrimSuffix(t, "*")) {
+				result = append(result, ev)
+				break
+			}
+		}
+	}
+
+	return result
+}
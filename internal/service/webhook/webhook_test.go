@@ -0,0 +1,30 @@
+package webhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidEvent(t *testing.T) {
+	assert.True(t, ValidEvent(PhotosCreated))
+	assert.True(t, ValidEvent("index.completed"))
+	assert.False(t, ValidEvent("photos"))
+	assert.False(t, ValidEvent("log.info"))
+}
+
+func TestParseEvents(t *testing.T) {
+	t.Run("All", func(t *testing.T) {
+		assert.Equal(t, Events, ParseEvents(""))
+		assert.Equal(t, Events, ParseEvents("*"))
+	})
+	t.Run("List", func(t *testing.T) {
+		assert.Equal(t, []string{PhotosCreated, ImportCompleted}, ParseEvents("import.completed, photos.created"))
+	})
+	t.Run("Wildcard", func(t *testing.T) {
+		assert.Equal(t, []string{AlbumsCreated, AlbumsUpdated, AlbumsDeleted}, ParseEvents("Albums.*"))
+	})
+	t.Run("Unknown", func(t *testing.T) {
+		assert.Empty(t, ParseEvents("log.info foo"))
+	})
+}
@@ -54,12 +54,14 @@ func (w *Backup) Start(database, albums bool, force bool, retain int) (err error
 	// Start creating backups.
 	start := time.Now()
 
-	// Create database backup.
-	if database {
-		databasePath := w.conf.BackupDatabasePath()
+	// Create albums backup first, so that it can be included in database backup archives.
+	if albums {
+		albumsPath := w.conf.BackupAlbumsPath()
 
-		if err = backup.Database(databasePath, "", false, force, retain); err != nil {
-			log.Errorf("backup: %s (database)", err)
+		if count, backupErr := backup.Albums(albumsPath, false); backupErr != nil {
+			log.Errorf("backup: %s (albums)", backupErr.Error())
+		} else if count > 0 {
+			log.Infof("backup: saved %s", english.Plural(count, "album backup", "album backups"))
 		}
 	}
 
@@ -67,15 +69,24 @@ func (w *Backup) Start(database, albums bool, force bool, retain int) (err error
 		return errors.New("canceled")
 	}
 
-	// Create albums backup.
-	if albums {
-		albumsPath := w.conf.BackupAlbumsPath()
-
-		if count, backupErr := backup.Albums(albumsPath, false); backupErr != nil {
-			log.Errorf("backup: %s (albums)", backupErr.Error())
-		} else if count > 0 {
-			log.Infof("backup: saved %s", english.Plural(count, "album backup", "album backups"))
+	// Create database backup.
+	if !database {
+		// Skip.
+	} else if databasePath := w.conf.BackupDatabasePath(); !w.conf.BackupArchive() {
+		if err = backup.Database(databasePath, "", false, force, retain); err != nil {
+			log.Errorf("backup: %s (database)", err)
 		}
+	} else if _, err = backup.Archive(databasePath, "", backup.ArchiveOptions{
+		Compression: w.conf.BackupCompression(),
+		Incremental: w.conf.BackupIncremental(),
+		Albums:      albums,
+		AlbumsPath:  w.conf.BackupAlbumsPath(),
+		Sidecars:    w.conf.SidecarYaml(),
+		SidecarPath: w.conf.SidecarPath(),
+		Force:       force,
+		Retain:      retain,
+	}); err != nil {
+		log.Errorf("backup: %s (database)", err)
 	}
 
 	elapsed := time.Since(start)
@@ -0,0 +1,304 @@
+package workers
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dustin/go-humanize/english"
+
+	"github.com/photoprism/photoprism/internal/ai/vision"
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/photoprism/get"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// JobPrefix is prepended to the job UID to get the name of the job in the scheduler.
+const JobPrefix = "job:"
+
+var (
+	// jobMutex makes sure that only one job runs at a time.
+	jobMutex = sync.Mutex{}
+	// jobSchedules keeps the schedules of the jobs that have been added to the scheduler.
+	jobSchedules      = make(map[string]string)
+	jobSchedulesMutex = sync.Mutex{}
+)
+
+// JobRunner represents a worker that runs user-defined jobs and records their history.
+type JobRunner struct {
+	conf *config.Config
+}
+
+// NewJobRunner returns a new JobRunner worker.
+func NewJobRunner(conf *config.Config) *JobRunner {
+	return &JobRunner{conf: conf}
+}
+
+// StartScheduled starts a scheduled run of the specified job, unless it has been paused or deleted.
+func (w *JobRunner) StartScheduled(uid string) {
+	job := entity.FindJob(uid)
+
+	if job == nil || !job.Scheduled() {
+		return
+	}
+
+	if _, err := w.Run(job, entity.JobSchedule); err != nil {
+		log.Errorf("scheduler: %s (job %s)", err, clean.Log(job.JobName))
+	}
+}
+
+// Run runs the job actions in sequence and records the duration, outcome, and log output in its history.
+// Jobs never run in parallel, so a job that is triggered while another one is running waits for it to finish.
+func (w *JobRunner) Run(job *entity.Job, trigger string) (run *entity.JobRun, err error) {
+	if job == nil {
+		return nil, errors.New("job not found")
+	}
+
+	jobMutex.Lock()
+	defer jobMutex.Unlock()
+
+	if run, err = job.Started(trigger); err != nil {
+		return run, err
+	}
+
+	runLog := &jobLog{}
+
+	runLog.Infof("jobs: running %s (%s)", clean.Log(job.JobName), strings.Join(job.Actions(), ", "))
+
+	var runErr error
+
+	for _, action := range job.Actions() {
+		start := time.Now()
+
+		if runErr = w.runAction(action, job.JobPath, runLog); runErr != nil {
+			runLog.Errorf("jobs: %s failed (%s)", action, runErr)
+			break
+		}
+
+		runLog.Infof("jobs: %s completed in %s", action, time.Since(start))
+	}
+
+	if err = job.Finished(run, runErr, runLog.String()); err != nil {
+		return run, err
+	}
+
+	return run, runErr
+}
+
+// runAction runs a single job action, the path optionally limits it to a sub-folder.
+func (w *JobRunner) runAction(action, path string, runLog *jobLog) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%s: %s (panic)", action, r)
+			log.Errorf("jobs: %s\nstack: %s", err, debug.Stack())
+		}
+	}()
+
+	conf := w.conf
+
+	if conf == nil {
+		return errors.New("config is not set")
+	}
+
+	switch action {
+	case entity.JobIndex:
+		if mutex.IndexWorker.Running() {
+			return errors.New("index is busy")
+		}
+
+		if path == "" {
+			path = entity.RootPath
+		}
+
+		convert := conf.Settings().Index.Convert && conf.SidecarWritable()
+		opt := photoprism.NewIndexOptions(path, false, convert, true, false, true, conf)
+
+		found, _ := get.Index().Start(opt)
+
+		if files, photos, updated, purgeErr := get.Purge().Start(photoprism.PurgeOptions{
+			Path:   filepath.Clean(path),
+			Ignore: found,
+			Force:  true,
+		}); purgeErr != nil {
+			return purgeErr
+		} else if updated > 0 {
+			runLog.Infof("jobs: removed %s and %s", english.Plural(len(files), "file", "files"), english.Plural(len(photos), "photo", "photos"))
+		}
+
+		return get.Moments().Start()
+	case entity.JobImport:
+		if conf.ReadOnly() {
+			return config.ErrReadOnly
+		} else if mutex.IndexWorker.Running() {
+			return errors.New("index is busy")
+		}
+
+		sourcePath := filepath.Join(conf.ImportPath(), path)
+
+		if sourcePath == conf.OriginalsPath() {
+			return errors.New("import path is identical with originals")
+		}
+
+		get.Import().Start(photoprism.ImportOptionsMove(sourcePath, conf.ImportDest()))
+	case entity.JobFaces:
+		if conf.DisableFaces() {
+			return errors.New("facial recognition is disabled")
+		}
+
+		return get.Faces().Start(photoprism.FacesOptions{})
+	case entity.JobCleanUp:
+		if thumbs, _, sidecars, cleanErr := get.CleanUp().Start(photoprism.CleanUpOptions{}); cleanErr != nil {
+			return cleanErr
+		} else if total := thumbs + sidecars; total > 0 {
+			runLog.Infof("jobs: removed %s", english.Plural(total, "file", "files"))
+		}
+	case entity.JobPurge:
+		if files, photos, updated, purgeErr := get.Purge().Start(photoprism.PurgeOptions{
+			Path:  path,
+			Force: true,
+		}); purgeErr != nil {
+			return purgeErr
+		} else if updated > 0 {
+			runLog.Infof("jobs: purged %s and %s", english.Plural(len(files), "file", "files"), english.Plural(len(photos), "photo", "photos"))
+		}
+	case entity.JobThumbs:
+		return get.Thumbs().Start(path, false, false)
+	case entity.JobBackup:
+		return NewBackup(conf).Start(conf.BackupDatabase(), conf.BackupAlbums(), true, conf.BackupRetain())
+	case entity.JobVision:
+		worker := NewVision(conf)
+
+		if models := worker.scheduledModels(); len(models) == 0 {
+			runLog.Infof("jobs: no vision models configured to run on schedule")
+		} else {
+			return worker.Start(conf.VisionFilter(), 0, models, entity.SrcAuto, false, vision.RunOnSchedule)
+		}
+	default:
+		return fmt.Errorf("unknown action %s", clean.Log(action))
+	}
+
+	return nil
+}
+
+// Schedule adds jobs with a schedule to the scheduler and removes those that have been paused, changed, or deleted.
+func (w *JobRunner) Schedule() error {
+	if Scheduler == nil {
+		return nil
+	}
+
+	jobs, err := query.ScheduledJobs()
+
+	if err != nil {
+		return err
+	}
+
+	jobSchedulesMutex.Lock()
+	defer jobSchedulesMutex.Unlock()
+
+	scheduled := make(map[string]bool, len(jobs))
+
+	for _, job := range jobs {
+		uid := job.JobUID
+		name := JobPrefix + uid
+		scheduled[name] = true
+
+		if jobSchedules[name] == job.JobSchedule {
+			continue
+		}
+
+		removeJob(name)
+		delete(jobSchedules, name)
+
+		if err = NewJob(name, job.JobSchedule, func() { w.StartScheduled(uid) }); err != nil {
+			log.Errorf("scheduler: %s (job %s)", err, clean.Log(job.JobName))
+		} else {
+			jobSchedules[name] = job.JobSchedule
+		}
+	}
+
+	for name := range jobSchedules {
+		if !scheduled[name] {
+			removeJob(name)
+			delete(jobSchedules, name)
+		}
+	}
+
+	return nil
+}
+
+// RunJob runs the specified job in the background.
+func RunJob(conf *config.Config, job *entity.Job) {
+	go func() {
+		if _, err := NewJobRunner(conf).Run(job, entity.JobManual); err != nil {
+			log.Warnf("jobs: %s (%s)", err, clean.Log(job.JobName))
+		}
+	}()
+}
+
+// ScheduleJobs updates the scheduler after jobs have been added, changed, or removed.
+func ScheduleJobs(conf *config.Config) {
+	if err := NewJobRunner(conf).Schedule(); err != nil {
+		log.Errorf("scheduler: %s (jobs)", err)
+	}
+}
+
+// removeJob removes the named job from the scheduler.
+func removeJob(name string) {
+	if job, ok := Jobs[name]; !ok {
+		return
+	} else if err := Scheduler.RemoveJob(job.ID()); err != nil {
+		log.Warnf("scheduler: %s (remove %s)", err, name)
+	}
+
+	delete(Jobs, name)
+}
+
+// jobLog records the messages logged by a job runner, so that the run history only includes the
+// output of the job itself and not the messages logged by other workers at the same time.
+type jobLog struct {
+	mu  sync.Mutex
+	buf strings.Builder
+}
+
+// Infof logs an info message and adds it to the job log.
+func (l *jobLog) Infof(format string, args ...interface{}) {
+	log.Infof(format, args...)
+	l.add("info", format, args...)
+}
+
+// Errorf logs an error message and adds it to the job log.
+func (l *jobLog) Errorf(format string, args ...interface{}) {
+	log.Errorf(format, args...)
+	l.add("error", format, args...)
+}
+
+// add adds a message with the specified level to the job log.
+func (l *jobLog) add(level, format string, args ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.buf.WriteString(level + " " + fmt.Sprintf(format, args...) + "\n")
+
+	// Only keep the end of the log output.
+	if l.buf.Len() > 2*entity.JobLogLimit {
+		s := l.buf.String()
+		l.buf.Reset()
+		l.buf.WriteString(s[len(s)-entity.JobLogLimit:])
+	}
+}
+
+// String returns the messages in the job log as text.
+func (l *jobLog) String() string {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return strings.TrimSpace(l.buf.String())
+}
@@ -0,0 +1,117 @@
+package workers
+
+import (
+	"testing"
+
+	"github.com/go-co-op/gocron/v2"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+)
+
+func TestNewJobRunner(t *testing.T) {
+	worker := NewJobRunner(config.TestConfig())
+
+	assert.IsType(t, &JobRunner{}, worker)
+}
+
+func TestJobRunner_Run(t *testing.T) {
+	t.Run("Succeeded", func(t *testing.T) {
+		job, err := entity.AddJob(form.Job{JobName: "Test Run", JobActions: "backup"})
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		defer job.Delete()
+
+		run, err := NewJobRunner(config.TestConfig()).Run(job, entity.JobManual)
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, entity.JobSucceeded, run.Status)
+		assert.Equal(t, entity.JobManual, run.Trigger)
+		assert.NotNil(t, run.FinishedAt)
+
+		if runs, err := query.JobRuns(job.JobUID, "", 10, 0); err != nil {
+			t.Fatal(err)
+		} else if assert.Len(t, runs, 1) {
+			assert.Equal(t, entity.JobSucceeded, runs[0].Status)
+		}
+
+		assert.Equal(t, entity.JobSucceeded, entity.FindJob(job.JobUID).JobStatus)
+	})
+	t.Run("Failed", func(t *testing.T) {
+		job := &entity.Job{JobName: "Test Failure", JobActions: "reboot"}
+
+		if err := job.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		defer job.Delete()
+
+		run, err := NewJobRunner(config.TestConfig()).Run(job, entity.JobSchedule)
+
+		assert.Error(t, err)
+		assert.True(t, run.Failed())
+		assert.Contains(t, run.Error, "unknown action")
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := NewJobRunner(config.TestConfig()).Run(nil, entity.JobManual)
+		assert.Error(t, err)
+	})
+}
+
+func TestJobRunner_Schedule(t *testing.T) {
+	scheduler, err := gocron.NewScheduler()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	Scheduler = scheduler
+
+	defer func() {
+		_ = Scheduler.Shutdown()
+		Scheduler = nil
+	}()
+
+	job, err := entity.AddJob(form.Job{JobName: "Test Schedule", JobActions: "thumbs", JobSchedule: "0 3 * * *"})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer job.Delete()
+
+	name := JobPrefix + job.JobUID
+	worker := NewJobRunner(config.TestConfig())
+
+	assert.NoError(t, worker.Schedule())
+	assert.Contains(t, Jobs, name)
+	assert.Contains(t, Jobs, JobPrefix+entity.JobFixtures.Get("nightly").JobUID)
+	assert.NotContains(t, Jobs, JobPrefix+entity.JobFixtures.Get("paused").JobUID)
+
+	assert.NoError(t, job.SetPaused(true))
+	assert.NoError(t, worker.Schedule())
+	assert.NotContains(t, Jobs, name)
+}
+
+func TestJobLog(t *testing.T) {
+	l := &jobLog{}
+
+	l.Infof("jobs: %s", "test message")
+	event.Log.Infof("index: message from another worker")
+	l.Errorf("jobs: %s failed", "index")
+
+	result := l.String()
+
+	assert.Equal(t, "info jobs: test message\nerror jobs: index failed", result)
+	assert.NotContains(t, result, "another worker")
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/photoprism/photoprism/internal/config"
 	"github.com/photoprism/photoprism/internal/entity"
 	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/internal/entity/search"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/pkg/clean"
@@ -49,7 +50,8 @@ func (w *Meta) Start(delay, interval time.Duration, force bool) (err error) {
 	defer mutex.MetaWorker.Stop()
 
 	// Check time when worker was last executed.
-	updateIndex := force || mutex.MetaWorker.LastRun().Before(time.Now().Add(-1*entity.IndexUpdateInterval))
+	lastRun := mutex.MetaWorker.LastRun()
+	updateIndex := force || lastRun.Before(time.Now().Add(-1*entity.IndexUpdateInterval))
 
 	// Refresh index metadata.
 	log.Debugf("index: updating metadata")
@@ -66,6 +68,8 @@ func (w *Meta) Start(delay, interval time.Duration, force bool) (err error) {
 	captionModelShouldRun := w.conf.VisionModelShouldRun(vision.ModelTypeCaption, vision.RunNewlyIndexed)
 	nsfwModelShouldRun := w.conf.VisionModelShouldRun(vision.ModelTypeNsfw, vision.RunNewlyIndexed)
 	detectFaces := w.conf.VisionModelShouldRun(vision.ModelTypeFace, vision.RunNewlyIndexed)
+	embeddingModelShouldRun := w.conf.VisionModelShouldRun(vision.ModelTypeEmbedding, vision.RunNewlyIndexed)
+	embeddingModel, _, _ := vision.Config.Model(vision.ModelTypeEmbedding).GetModel()
 
 	if nsfwModelShouldRun {
 		log.Debugf("index: cannot run %s model on %s", vision.ModelTypeNsfw, vision.RunNewlyIndexed)
@@ -100,9 +104,10 @@ func (w *Meta) Start(delay, interval time.Duration, force bool) (err error) {
 
 			generateLabels := labelsModelShouldRun && photo.ShouldGenerateLabels(false)
 			generateCaption := captionModelShouldRun && photo.ShouldGenerateCaption(entity.SrcAuto, false)
+			generateEmbedding := embeddingModelShouldRun && photo.ShouldGenerateEmbedding(embeddingModel, false)
 
 			// If configured, generate metadata for newly indexed photos using external vision services.
-			if photo.IsNewlyIndexed() && (detectFaces || generateLabels || generateCaption) {
+			if photo.IsNewlyIndexed() && (detectFaces || generateLabels || generateCaption || generateEmbedding) {
 				primaryFile, fileErr := photo.PrimaryFile()
 
 				if fileErr != nil {
@@ -174,6 +179,19 @@ func (w *Meta) Start(delay, interval time.Duration, force bool) (err error) {
 								updated = true
 							}
 						}
+
+						// Generate an embedding for semantic search based on the updated metadata.
+						if generateEmbedding {
+							if changed {
+								photo.PreloadLabels()
+							}
+
+							if embeddingErr := photoprism.SavePhotoEmbedding(photo, mediaFile); embeddingErr != nil {
+								log.Debugf("index: failed to generate embedding for %s (%s)", logName, clean.Error(embeddingErr))
+							} else {
+								search.FlushSemanticCache()
+							}
+						}
 					}
 				}
 
@@ -212,6 +230,28 @@ func (w *Meta) Start(delay, interval time.Duration, force bool) (err error) {
 		updateIndex = true
 	}
 
+	// Add the missing perceptual hashes of files indexed with an earlier version.
+	if n, phashErr := photoprism.UpdatePerceptualHashes(w.conf.ThumbCachePath()); phashErr != nil {
+		log.Warnf("index: %s in optimization worker", phashErr)
+	} else if n > 0 {
+		log.Infof("index: added perceptual hashes of %s", english.Plural(n, "file", "files"))
+	}
+
+	// Select the best shots in bursts that have changed since the last run, if enabled.
+	if policy := w.conf.BestShot(); policy != config.BestShotOff {
+		opt := photoprism.BestShotOptions{Policy: policy, Since: lastRun}
+
+		if results, bestShotErr := photoprism.NewBestShot(w.conf).Start(opt); bestShotErr != nil {
+			log.Warnf("index: %s in optimization worker", bestShotErr)
+		} else {
+			for _, result := range results {
+				if result.Action != "" {
+					updateIndex = true
+				}
+			}
+		}
+	}
+
 	// Perform face recognition.
 	if updateFaces {
 		log.Debugf("index: running face recognition")
@@ -222,6 +262,18 @@ func (w *Meta) Start(delay, interval time.Duration, force bool) (err error) {
 		}
 	}
 
+	// Update the search index of photos whose metadata, labels, keywords, or people have changed.
+	if n, searchErr := entity.UpdateQueuedSearchIndex(); searchErr != nil {
+		log.Warnf("index: %s in optimization worker", searchErr)
+	} else if n > 0 {
+		log.Debugf("index: updated search index of %s", english.Plural(n, "photo", "photos"))
+	}
+
+	// Remove cached HLS video segments that have not been requested recently.
+	if _, hlsErr := photoprism.NewHls(w.conf).Cleanup(photoprism.HlsCacheMaxAge); hlsErr != nil {
+		log.Warnf("index: %s in optimization worker", hlsErr)
+	}
+
 	// Only update index if necessary.
 	if updateIndex {
 		// Set photo quality scores to -1 if files are missing.
@@ -13,7 +13,6 @@ import (
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/service"
-	"github.com/photoprism/photoprism/internal/service/webdav"
 	"github.com/photoprism/photoprism/internal/thumb"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
@@ -64,7 +63,7 @@ func (w *Share) Start() (err error) {
 			return nil
 		}
 
-		if a.AccType != service.WebDAV {
+		if !service.Supported(a.AccType) {
 			continue
 		}
 
@@ -90,67 +89,9 @@ func (w *Share) Start() (err error) {
 			}
 		}
 
-		client, err := webdav.NewClient(a.AccURL, a.AccUser, a.AccPass, webdav.Timeout(a.AccTimeout))
-
-		if err != nil {
+		if err = w.upload(a, files, size); err != nil {
 			return err
 		}
-
-		for _, file := range files {
-			if mutex.ShareWorker.Canceled() {
-				return nil
-			}
-
-			// Skip deleted files.
-			if file.File == nil || file.FileID <= 0 {
-				log.Warnf("share: %s cannot be uploaded because it has been deleted", clean.Log(file.RemoteName))
-				file.Status = entity.FileShareError
-				file.Error = "file not found"
-				file.Errors++
-				w.logErr(entity.Db().Save(&file).Error)
-				continue
-			}
-
-			dir := path.Dir(file.RemoteName)
-
-			// Ensure remote folder exists.
-			if err := client.MkdirAll(dir); err != nil {
-				log.Debugf("share: %s", err)
-			}
-
-			srcFileName := photoprism.FileName(file.File.FileRoot, file.File.FileName)
-
-			if fs.ImageJpeg.Equal(file.File.FileType) && size.Width > 0 && size.Height > 0 {
-				srcFileName, err = thumb.FromFile(srcFileName, file.File.FileHash, w.conf.ThumbCachePath(), size.Width, size.Height, file.File.FileOrientation, size.Options...)
-
-				if err != nil {
-					w.logErr(err)
-					continue
-				}
-			}
-
-			if err := client.Upload(srcFileName, file.RemoteName); err != nil {
-				w.logErr(err)
-				file.Errors++
-				file.Error = err.Error()
-			} else {
-				log.Infof("share: uploaded %s to %s", file.RemoteName, a.AccName)
-				file.Errors = 0
-				file.Error = ""
-				file.Status = entity.FileShareShared
-			}
-
-			// Failed too often?
-			if a.RetryLimit > 0 && file.Errors > a.RetryLimit {
-				file.Status = entity.FileShareError
-			}
-
-			if mutex.ShareWorker.Canceled() {
-				return nil
-			}
-
-			w.logErr(entity.Db().Save(&file).Error)
-		}
 	}
 
 	// Remove previously shared files if expired
@@ -159,7 +100,7 @@ func (w *Share) Start() (err error) {
 			return nil
 		}
 
-		if a.AccType != service.WebDAV {
+		if !service.Supported(a.AccType) {
 			continue
 		}
 
@@ -175,32 +116,126 @@ func (w *Share) Start() (err error) {
 			continue
 		}
 
-		client, err := webdav.NewClient(a.AccURL, a.AccUser, a.AccPass, webdav.Timeout(a.AccTimeout))
-
-		if err != nil {
+		if err = w.remove(a, files); err != nil {
 			return err
 		}
+	}
 
-		for _, file := range files {
-			if mutex.ShareWorker.Canceled() {
-				return nil
-			}
+	return err
+}
 
-			if err := client.Delete(file.RemoteName); err != nil {
-				file.Errors++
-				file.Error = err.Error()
-			} else {
-				log.Infof("share: removed %s from %s", file.RemoteName, a.AccName)
-				file.Errors = 0
-				file.Error = ""
-				file.Status = entity.FileShareRemoved
-			}
+// upload uploads the newly shared files of an account and releases its connection when done.
+func (w *Share) upload(a entity.Service, files []entity.FileShare, size thumb.Size) error {
+	client, err := a.Client()
+
+	if err != nil {
+		return err
+	}
+
+	defer client.Close()
+
+	// Files are selected manually, so raw images and videos are shared regardless of the sync settings.
+	filter := a.SyncFilter()
+	filter.Raw = true
+	filter.Video = true
+
+	for _, file := range files {
+		if mutex.ShareWorker.Canceled() {
+			return nil
+		}
 
-			if err := entity.Db().Save(&file).Error; err != nil {
+		// Skip deleted files.
+		if file.File == nil || file.FileID <= 0 {
+			log.Warnf("share: %s cannot be uploaded because it has been deleted", clean.Log(file.RemoteName))
+			file.Status = entity.FileShareError
+			file.Error = "file not found"
+			file.Errors++
+			w.logErr(entity.Db().Save(&file).Error)
+			continue
+		}
+
+		// Skip files that do not match the filters.
+		if !filter.MatchFile(file.File) {
+			log.Infof("share: %s has been skipped because it does not match the filters", clean.Log(file.RemoteName))
+			file.Status = entity.FileShareError
+			file.Error = "file does not match filters"
+			w.logErr(entity.Db().Save(&file).Error)
+			continue
+		}
+
+		dir := path.Dir(file.RemoteName)
+
+		// Ensure remote folder exists.
+		if err := client.MkdirAll(dir); err != nil {
+			log.Debugf("share: %s", err)
+		}
+
+		srcFileName := photoprism.FileName(file.File.FileRoot, file.File.FileName)
+
+		if fs.ImageJpeg.Equal(file.File.FileType) && size.Width > 0 && size.Height > 0 {
+			srcFileName, err = thumb.FromFile(srcFileName, file.File.FileHash, w.conf.ThumbCachePath(), size.Width, size.Height, file.File.FileOrientation, size.Options...)
+
+			if err != nil {
 				w.logErr(err)
+				continue
 			}
 		}
+
+		if err := client.Upload(srcFileName, file.RemoteName); err != nil {
+			w.logErr(err)
+			file.Errors++
+			file.Error = err.Error()
+		} else {
+			log.Infof("share: uploaded %s to %s", file.RemoteName, a.AccName)
+			file.Errors = 0
+			file.Error = ""
+			file.Status = entity.FileShareShared
+		}
+
+		// Failed too often?
+		if a.RetryLimit > 0 && file.Errors > a.RetryLimit {
+			file.Status = entity.FileShareError
+		}
+
+		if mutex.ShareWorker.Canceled() {
+			return nil
+		}
+
+		w.logErr(entity.Db().Save(&file).Error)
 	}
 
-	return err
+	return nil
+}
+
+// remove deletes the expired shared files of an account and releases its connection when done.
+func (w *Share) remove(a entity.Service, files []entity.FileShare) error {
+	client, err := a.Client()
+
+	if err != nil {
+		return err
+	}
+
+	defer client.Close()
+
+	for _, file := range files {
+		if mutex.ShareWorker.Canceled() {
+			return nil
+		}
+
+		if err := client.Delete(file.RemoteName); err != nil {
+			file.Errors++
+			file.Error = err.Error()
+		} else {
+			log.Infof("share: removed %s from %s", file.RemoteName, a.AccName)
+			file.Errors = 0
+			file.Error = ""
+			file.Status = entity.FileShareRemoved
+		}
+
+		if err := entity.Db().Save(&file).Error; err != nil {
+			w.logErr(err)
+		}
+	}
+
+	return nil
 }
@@ -64,7 +64,7 @@ func (w *Sync) Start() (err error) {
 	accounts, err := search.Accounts(f)
 
 	for _, a := range accounts {
-		if a.AccType != service.WebDAV {
+		if !service.Supported(a.AccType) {
 			continue
 		}
 
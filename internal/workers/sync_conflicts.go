@@ -0,0 +1,91 @@
+package workers
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/photoprism"
+	"github.com/photoprism/photoprism/internal/service"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// conflictName returns the file name for keeping the remote version of a conflicting file.
+func conflictName(fileName string, t time.Time) string {
+	ext := filepath.Ext(fileName)
+	return fmt.Sprintf("%s-conflict-%s%s", strings.TrimSuffix(fileName, ext), t.UTC().Format("20060102-150405"), ext)
+}
+
+// resolveConflicts applies the conflict resolution policy to files that have been modified on both sides
+// and returns the local file names of remote versions that were kept as a copy.
+func (w *Sync) resolveConflicts(a entity.Service, client service.Client) (copies []string, err error) {
+	files, err := query.FileSyncs(a.ID, entity.FileSyncConflict, 1000)
+
+	if err != nil {
+		return copies, err
+	}
+
+	for _, file := range files {
+		if mutex.SyncWorker.Canceled() {
+			return copies, nil
+		}
+
+		policy := file.Resolution
+
+		if policy == "" {
+			policy = a.SyncConflict
+		}
+
+		// Download the remote version if the local file no longer exists.
+		if file.File == nil {
+			policy = entity.SyncConflictRemote
+		}
+
+		localName := ""
+
+		if file.File != nil {
+			localName = photoprism.FileName(file.File.FileRoot, file.File.FileName)
+		}
+
+		// Let the newest version win, if configured.
+		if policy == entity.SyncConflictNewest {
+			if info, statErr := os.Stat(localName); statErr != nil || file.RemoteDate.After(info.ModTime()) {
+				policy = entity.SyncConflictRemote
+			} else {
+				policy = entity.SyncConflictLocal
+			}
+		}
+
+		switch policy {
+		case entity.SyncConflictRemote:
+			// Download and overwrite the local version.
+			log.Infof("sync: resolving conflict for %s, keeping remote version", clean.Log(file.RemoteName))
+			w.logErr(file.Updates(entity.Values{"Status": entity.FileSyncNew, "Resolution": ""}))
+		case entity.SyncConflictLocal:
+			// Upload and overwrite the remote version.
+			log.Infof("sync: resolving conflict for %s, keeping local version", clean.Log(file.RemoteName))
+			w.logErr(file.Updates(entity.Values{"Status": entity.FileSyncUploaded, "Resolution": ""}))
+		case entity.SyncConflictKeepBoth:
+			// Download the remote version with a suffix, then upload the local version.
+			copyName := conflictName(localName, time.Now())
+
+			if err = client.Download(file.RemoteName, copyName, false); err != nil {
+				w.logErr(err)
+				continue
+			}
+
+			log.Infof("sync: resolving conflict for %s, kept remote version as %s", clean.Log(file.RemoteName), clean.Log(filepath.Base(copyName)))
+			copies = append(copies, copyName)
+			w.logErr(file.Updates(entity.Values{"Status": entity.FileSyncUploaded, "Resolution": ""}))
+		default:
+			log.Debugf("sync: conflict for %s must be resolved manually", clean.Log(file.RemoteName))
+		}
+	}
+
+	return copies, nil
+}
@@ -0,0 +1,57 @@
+package workers
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestConflictName(t *testing.T) {
+	ts := time.Date(2024, 3, 9, 14, 30, 5, 0, time.UTC)
+
+	assert.Equal(t, "/photos/IMG_1234-conflict-20240309-143005.jpg", conflictName("/photos/IMG_1234.jpg", ts))
+	assert.Equal(t, "/photos/README-conflict-20240309-143005", conflictName("/photos/README", ts))
+}
+
+func TestSync_resolveConflicts(t *testing.T) {
+	conf := config.TestConfig()
+	worker := NewSync(conf)
+	account := entity.ServiceFixtureWebdavDummy
+
+	conflict := entity.NewFileSync(account.ID, "/conflict-worker.jpg")
+	conflict.Status = entity.FileSyncConflict
+	conflict.Resolution = entity.SyncConflictRemote
+
+	if err := conflict.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer entity.UnscopedDb().Delete(conflict)
+
+	client, err := account.Client()
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	copies, err := worker.resolveConflicts(account, client)
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Empty(t, copies)
+
+	result := entity.FileSync{}
+
+	if err = entity.Db().Where("service_id = ? AND remote_name = ?", account.ID, conflict.RemoteName).First(&result).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, entity.FileSyncNew, result.Status)
+	assert.Equal(t, "", result.Resolution)
+}
@@ -12,7 +12,6 @@ import (
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/photoprism"
 	"github.com/photoprism/photoprism/internal/photoprism/get"
-	"github.com/photoprism/photoprism/internal/service/webdav"
 	"github.com/photoprism/photoprism/pkg/clean"
 	"github.com/photoprism/photoprism/pkg/fs"
 )
@@ -52,6 +51,39 @@ func (w *Sync) relatedDownloads(a entity.Service) (result Downloads, err error)
 	return result, nil
 }
 
+// localName returns the local file name for downloading a remote file, which is the existing
+// original if the file has been synchronized before.
+func (w *Sync) localName(baseDir string, file entity.FileSync) string {
+	if file.FileID > 0 && file.File != nil && file.File.FileName != "" {
+		return photoprism.FileName(file.File.FileRoot, file.File.FileName)
+	}
+
+	return baseDir + file.RemoteName
+}
+
+// index indexes a single downloaded file and its related files.
+func (w *Sync) index(jobs chan photoprism.IndexJob, fileName string) {
+	mf, err := photoprism.NewMediaFile(fileName)
+
+	if err != nil || !mf.IsMedia() || mf.Empty() {
+		return
+	}
+
+	related, err := mf.RelatedFiles(w.conf.Settings().StackSequences())
+
+	if err != nil {
+		w.logWarn(err)
+		return
+	}
+
+	jobs <- photoprism.IndexJob{
+		FileName: mf.FileName(),
+		Related:  related,
+		IndexOpt: photoprism.IndexOptionsAll(w.conf),
+		Ind:      get.Index(),
+	}
+}
+
 // Downloads remote files in batches and imports / indexes them
 func (w *Sync) download(a entity.Service) (complete bool, err error) {
 	// Set up index worker
@@ -65,6 +97,26 @@ func (w *Sync) download(a entity.Service) (complete bool, err error) {
 	go photoprism.ImportWorker(importJobs)
 	defer close(importJobs)
 
+	// Resolve conflicts between local and remote changes first.
+	if a.TwoWaySync() && !w.conf.ReadOnly() {
+		client, clientErr := a.Client()
+
+		if clientErr != nil {
+			return false, clientErr
+		}
+
+		copies, resolveErr := w.resolveConflicts(a, client)
+		w.logErr(client.Close())
+
+		if resolveErr != nil {
+			w.logErr(resolveErr)
+		} else {
+			for _, fileName := range copies {
+				w.index(indexJobs, fileName)
+			}
+		}
+	}
+
 	relatedFiles, err := w.relatedDownloads(a)
 
 	if err != nil {
@@ -89,12 +141,14 @@ func (w *Sync) download(a entity.Service) (complete bool, err error) {
 	// Display log message.
 	log.Infof("sync: downloading from %s", a.AccName)
 
-	client, err := webdav.NewClient(a.AccURL, a.AccUser, a.AccPass, webdav.Timeout(a.AccTimeout))
+	client, err := a.Client()
 
 	if err != nil {
 		return false, err
 	}
 
+	defer client.Close()
+
 	var baseDir string
 
 	if a.SyncFilenames {
@@ -104,6 +158,7 @@ func (w *Sync) download(a entity.Service) (complete bool, err error) {
 	}
 
 	done := make(map[string]bool)
+	localNames := make(map[string]string)
 
 	for _, files := range relatedFiles {
 		if w.conf.FilesQuotaReached() {
@@ -122,15 +177,19 @@ func (w *Sync) download(a entity.Service) (complete bool, err error) {
 				continue
 			}
 
-			localName := baseDir + file.RemoteName
+			localName := w.localName(baseDir, file)
+			localNames[file.RemoteName] = localName
+
+			// Files that have been synchronized before are updated in place.
+			overwrite := file.FileID > 0 && file.File != nil
 
-			if _, err = os.Stat(localName); err == nil {
+			if _, err = os.Stat(localName); err == nil && !overwrite {
 				log.Warnf("sync: skipped download of %s from %s because local file %s already exists", file.RemoteName, clean.Log(a.AccName), localName)
 				file.Status = entity.FileSyncExists
 				file.Error = ""
 				file.Errors = 0
 			} else {
-				if err = client.Download(file.RemoteName, localName, false); err != nil {
+				if err = client.Download(file.RemoteName, localName, overwrite); err != nil {
 					file.Errors++
 					file.Error = err.Error()
 
@@ -140,6 +199,7 @@ func (w *Sync) download(a entity.Service) (complete bool, err error) {
 				} else {
 					log.Infof("sync: downloaded %s from %s", file.RemoteName, clean.Log(a.AccName))
 					file.Status = entity.FileSyncDownloaded
+					file.FileHash = fs.Hash(localName)
 					file.Error = ""
 					file.Errors = 0
 				}
@@ -161,7 +221,7 @@ func (w *Sync) download(a entity.Service) (complete bool, err error) {
 				continue
 			}
 
-			mf, err := photoprism.NewMediaFile(baseDir + file.RemoteName)
+			mf, err := photoprism.NewMediaFile(localNames[file.RemoteName])
 
 			if err != nil || !mf.IsMedia() || mf.Empty() {
 				continue
@@ -188,7 +248,7 @@ func (w *Sync) download(a entity.Service) (complete bool, err error) {
 			done[mf.FileName()] = true
 			related.Files = rf
 
-			if a.SyncFilenames {
+			if a.SyncFilenames || file.FileID > 0 {
 				log.Infof("sync: indexing %s and related files", file.RemoteName)
 				indexJobs <- photoprism.IndexJob{
 					FileName: mf.FileName(),
@@ -1,25 +1,33 @@
 package workers
 
 import (
+	"path"
+	"strings"
+
 	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/service"
 	"github.com/photoprism/photoprism/internal/service/webdav"
+	"github.com/photoprism/photoprism/pkg/clean"
+	"github.com/photoprism/photoprism/pkg/fs"
 	"github.com/photoprism/photoprism/pkg/media"
 )
 
 // Updates the local list of remote files so that they can be downloaded in batches
 func (w *Sync) refresh(a entity.Service) (complete bool, err error) {
-	if a.AccType != service.WebDAV {
+	if !service.Supported(a.AccType) {
 		return false, nil
 	}
 
-	client, err := webdav.NewClient(a.AccURL, a.AccUser, a.AccPass, webdav.Timeout(a.AccTimeout))
+	client, err := a.Client()
 
 	if err != nil {
 		return false, err
 	}
 
+	defer client.Close()
+
 	// Ensure remote folder exists.
 	if err = client.MkdirAll(a.SyncPath); err != nil {
 		log.Debugf("sync: %s", err)
@@ -33,6 +41,9 @@ func (w *Sync) refresh(a entity.Service) (complete bool, err error) {
 	}
 
 	dirs := append(subDirs.Abs(), a.SyncPath)
+	found := make(map[string]bool)
+	listed := make(map[string]bool, len(dirs))
+	filter := a.SyncFilter()
 
 	for _, dir := range dirs {
 		if mutex.SyncWorker.Canceled() {
@@ -46,26 +57,27 @@ func (w *Sync) refresh(a entity.Service) (complete bool, err error) {
 			return false, err
 		}
 
+		listed[path.Clean(dir)] = true
+
 		for _, file := range files {
 			if mutex.SyncWorker.Canceled() {
 				return false, nil
 			}
 
+			found[file.Abs] = true
+
 			f := entity.NewFileSync(a.ID, file.Abs)
 
 			f.Status = entity.FileSyncIgnore
 			f.RemoteDate = file.Date
 			f.RemoteSize = file.Size
+			f.RemoteETag = file.ETag
+
+			// Select supported types and files matching the filters for download.
+			download := filter.Match(fs.RelName(file.Abs, a.SyncPath), media.FromName(file.Name), file.Size, file.Date)
 
-			// Select supported types for download.
-			content := media.FromName(file.Name)
-			switch content {
-			case media.Image, media.Sidecar, media.Vector, media.Document, media.Live, media.Animated:
+			if download {
 				f.Status = entity.FileSyncNew
-			case media.Raw, media.Video:
-				if a.SyncRaw {
-					f.Status = entity.FileSyncNew
-				}
 			}
 
 			f = entity.FirstOrCreateFileSync(f)
@@ -75,19 +87,101 @@ func (w *Sync) refresh(a entity.Service) (complete bool, err error) {
 				continue
 			}
 
-			if f.Status == entity.FileSyncIgnore && a.SyncRaw && (content == media.Raw || content == media.Video) {
+			// Update the status of remote files that have not been downloaded yet if the filters have changed.
+			switch {
+			case f.Status == entity.FileSyncIgnore && f.FileID == 0 && download:
 				w.logErr(f.Update("Status", entity.FileSyncNew))
+			case f.Status == entity.FileSyncNew && !download:
+				w.logErr(f.Update("Status", entity.FileSyncIgnore))
 			}
 
-			if f.Status == entity.FileSyncDownloaded && !f.RemoteDate.Equal(file.Date) {
+			switch {
+			case f.Synced() && f.RemoteDate.IsZero():
+				// Remember the state of uploaded files to detect remote changes later.
+				w.logErr(f.Updates(entity.Values{
+					"RemoteDate": file.Date,
+					"RemoteSize": file.Size,
+					"RemoteETag": file.ETag,
+				}))
+			case a.SyncDownload && f.Synced() && f.RemoteChanged(file.ETag, file.Date, file.Size):
+				status := entity.FileSyncNew
+
+				// Both versions have changed if the local file was also modified.
+				if a.SyncUpload && f.LocalChanged() {
+					log.Warnf("sync: %s has been modified locally and on %s", clean.Log(f.RemoteName), clean.Log(a.AccName))
+					status = entity.FileSyncConflict
+				}
+
 				w.logErr(f.Updates(entity.Values{
-					"Status":     entity.FileSyncNew,
+					"Status":     status,
 					"RemoteDate": file.Date,
 					"RemoteSize": file.Size,
+					"RemoteETag": file.ETag,
 				}))
 			}
 		}
 	}
 
+	// Propagate remote deletions only if the complete folder tree could be listed.
+	if a.SyncDelete && a.SyncDownload {
+		w.deleteLocal(a, found, listed)
+	}
+
 	return true, nil
 }
+
+// remoteDeleted checks if a synchronized file no longer exists on the remote service. Files in folders that
+// have not been listed and hidden files, which remote clients skip, are never considered deleted.
+func remoteDeleted(remoteName string, found, listed map[string]bool) bool {
+	if found[remoteName] || !listed[path.Dir(path.Clean("/"+remoteName))] {
+		return false
+	}
+
+	for _, name := range strings.Split(remoteName, "/") {
+		if fs.FileNameHidden(name) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// deleteLocal archives the local pictures of synchronized files that no longer exist on the remote service,
+// so that they can be restored if needed, instead of deleting the originals.
+func (w *Sync) deleteLocal(a entity.Service, found, listed map[string]bool) {
+	if w.conf.ReadOnly() {
+		return
+	}
+
+	files, err := query.FileSyncs(a.ID, "", 0)
+
+	if err != nil {
+		w.logErr(err)
+		return
+	}
+
+	for _, f := range files {
+		if mutex.SyncWorker.Canceled() {
+			return
+		} else if !remoteDeleted(f.RemoteName, found, listed) || !f.Synced() || f.FileID == 0 || f.File == nil {
+			continue
+		} else if f.File.FileMissing || f.File.DeletedAt != nil {
+			continue
+		}
+
+		photo := entity.FindPhoto(entity.Photo{ID: f.File.PhotoID})
+
+		if photo == nil {
+			continue
+		} else if photo.DeletedAt == nil {
+			log.Infof("sync: archiving %s, removed from %s", clean.Log(f.File.FileName), clean.Log(a.AccName))
+
+			if err = photo.Archive(); err != nil {
+				w.logErr(err)
+				continue
+			}
+		}
+
+		w.logErr(f.Update("Status", entity.FileSyncDeleted))
+	}
+}
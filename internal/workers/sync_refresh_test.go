@@ -0,0 +1,98 @@
+package workers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+)
+
+func TestRemoteDeleted(t *testing.T) {
+	found := map[string]bool{"/photos/found.jpg": true}
+	listed := map[string]bool{"/": true, "/photos": true}
+
+	assert.True(t, remoteDeleted("/photos/deleted.jpg", found, listed))
+	assert.True(t, remoteDeleted("/deleted.jpg", found, listed))
+	assert.False(t, remoteDeleted("/photos/found.jpg", found, listed))
+	assert.False(t, remoteDeleted("/unlisted/deleted.jpg", found, listed))
+	assert.False(t, remoteDeleted("/photos/.hidden.jpg", found, listed))
+	assert.False(t, remoteDeleted("/.hidden/deleted.jpg", found, map[string]bool{"/.hidden": true}))
+	assert.False(t, remoteDeleted("/@eaDir/deleted.jpg", found, map[string]bool{"/@eaDir": true}))
+}
+
+func TestSync_deleteLocal(t *testing.T) {
+	conf := config.TestConfig()
+	worker := NewSync(conf)
+
+	account := entity.Service{AccName: "Delete Local", AccType: "webdav", AccURL: "http://delete-local.example.com/", SyncDelete: true, SyncDownload: true}
+
+	if err := account.Create(); err != nil {
+		t.Fatal(err)
+	}
+
+	defer entity.UnscopedDb().Delete(&account)
+
+	// addSynced creates a picture with a file that was downloaded from the remote service.
+	addSynced := func(remoteName string) (*entity.Photo, *entity.File) {
+		photo := entity.NewPhoto(false)
+
+		if err := photo.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		file := &entity.File{PhotoID: photo.ID, PhotoUID: photo.PhotoUID, FileName: "sync-delete" + remoteName, FileRoot: entity.RootOriginals,
+			FileHash: "sync-delete" + remoteName, FileType: "jpg", FilePrimary: true}
+
+		if err := file.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		fileSync := entity.NewFileSync(account.ID, remoteName)
+		fileSync.Status = entity.FileSyncDownloaded
+		fileSync.FileID = file.ID
+
+		if err := fileSync.Create(); err != nil {
+			t.Fatal(err)
+		}
+
+		t.Cleanup(func() {
+			entity.UnscopedDb().Delete(file)
+			entity.UnscopedDb().Delete(&photo)
+		})
+
+		return &photo, file
+	}
+
+	deleted, deletedFile := addSynced("/photos/deleted.jpg")
+	unlisted, _ := addSynced("/unlisted/deleted.jpg")
+	hidden, _ := addSynced("/photos/.hidden/deleted.jpg")
+
+	defer entity.UnscopedDb().Delete(entity.FileSync{}, "service_id = ?", account.ID)
+
+	worker.deleteLocal(account, map[string]bool{}, map[string]bool{"/": true, "/photos": true, "/photos/.hidden": true})
+
+	// Pictures of files that were deleted remotely are archived.
+	assert.NotNil(t, entity.FindPhoto(entity.Photo{ID: deleted.ID}).DeletedAt)
+	assert.Nil(t, entity.FindPhoto(entity.Photo{ID: unlisted.ID}).DeletedAt)
+	assert.Nil(t, entity.FindPhoto(entity.Photo{ID: hidden.ID}).DeletedAt)
+
+	// The files are kept so that the pictures can be restored.
+	file := entity.File{}
+
+	if err := entity.UnscopedDb().First(&file, deletedFile.ID).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.False(t, file.FileMissing)
+	assert.Nil(t, file.DeletedAt)
+
+	result := entity.FileSync{}
+
+	if err := entity.Db().Where("service_id = ? AND remote_name = ?", account.ID, "/photos/deleted.jpg").First(&result).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	assert.Equal(t, entity.FileSyncDeleted, result.Status)
+}
@@ -9,7 +9,6 @@ import (
 	"github.com/photoprism/photoprism/internal/event"
 	"github.com/photoprism/photoprism/internal/mutex"
 	"github.com/photoprism/photoprism/internal/photoprism"
-	"github.com/photoprism/photoprism/internal/service/webdav"
 	"github.com/photoprism/photoprism/pkg/clean"
 )
 
@@ -24,18 +23,83 @@ func (w *Sync) upload(a entity.Service) (complete bool, err error) {
 		return false, err
 	}
 
-	if len(files) == 0 {
+	// Get files that have been modified or deleted locally since they were synchronized.
+	modified, err := query.AccountModified(a, maxResults)
+
+	if err != nil {
+		return false, err
+	}
+
+	var deleted []entity.FileSync
+
+	if a.SyncDelete {
+		if deleted, err = query.AccountDeleted(a, maxResults); err != nil {
+			return false, err
+		}
+	}
+
+	if len(files) == 0 && len(modified) == 0 && len(deleted) == 0 {
 		log.Infof("sync: upload complete for %s", a.AccName)
 		event.Publish("sync.uploaded", event.Data{"account": a})
 		return true, nil
 	}
 
-	client, err := webdav.NewClient(a.AccURL, a.AccUser, a.AccPass, webdav.Timeout(a.AccTimeout))
+	client, err := a.Client()
 
 	if err != nil {
 		return false, err
 	}
 
+	defer client.Close()
+
+	// Overwrite remote files with the modified local version.
+	for _, fileSync := range modified {
+		if mutex.SyncWorker.Canceled() {
+			return false, nil
+		} else if fileSync.File == nil {
+			continue
+		}
+
+		fileName := photoprism.FileName(fileSync.File.FileRoot, fileSync.File.FileName)
+
+		if err = client.Upload(fileName, fileSync.RemoteName); err != nil {
+			w.logErr(err)
+			continue // try again next time
+		}
+
+		log.Infof("sync: updated %s on %s", clean.Log(fileSync.RemoteName), a.AccName)
+
+		w.logErr(fileSync.Updates(entity.Values{
+			"Status":     entity.FileSyncUploaded,
+			"FileHash":   fileSync.File.FileHash,
+			"RemoteDate": time.Time{},
+			"RemoteSize": fileSync.File.FileSize,
+			"RemoteETag": "",
+			"Error":      "",
+			"Errors":     0,
+		}))
+	}
+
+	// Delete remote files that have been deleted locally.
+	for _, fileSync := range deleted {
+		if mutex.SyncWorker.Canceled() {
+			return false, nil
+		}
+
+		if err = client.Delete(fileSync.RemoteName); err != nil {
+			w.logErr(err)
+			continue // try again next time
+		}
+
+		log.Infof("sync: deleted %s from %s", clean.Log(fileSync.RemoteName), a.AccName)
+
+		w.logErr(fileSync.Update("Status", entity.FileSyncDeleted))
+	}
+
+	// Videos are uploaded regardless of the raw file setting, which only excludes raw images.
+	filter := a.SyncFilter()
+	filter.Video = true
+
 	for _, file := range files {
 		if mutex.SyncWorker.Canceled() {
 			return false, nil
@@ -45,6 +109,18 @@ func (w *Sync) upload(a entity.Service) (complete bool, err error) {
 		remoteName := path.Join(a.SyncPath, file.FileName)
 		remoteDir := path.Dir(remoteName)
 
+		// Remember files that do not match the filters, so that they are not checked again.
+		if !filter.MatchFile(&file) {
+			log.Debugf("sync: %s does not match the filters for %s", clean.Log(file.FileName), a.AccName)
+
+			fileSync := entity.NewFileSync(a.ID, remoteName)
+			fileSync.Status = entity.FileSyncIgnore
+			fileSync.FileID = file.ID
+
+			w.logErr(entity.Db().Save(&fileSync).Error)
+			continue
+		}
+
 		// Ensure remote folder exists.
 		if err = client.MkdirAll(remoteDir); err != nil {
 			log.Debugf("sync: %s", err)
@@ -59,9 +135,9 @@ func (w *Sync) upload(a entity.Service) (complete bool, err error) {
 
 		fileSync := entity.NewFileSync(a.ID, remoteName)
 		fileSync.Status = entity.FileSyncUploaded
-		fileSync.RemoteDate = time.Now()
 		fileSync.RemoteSize = file.FileSize
 		fileSync.FileID = file.ID
+		fileSync.FileHash = file.FileHash
 		fileSync.Error = ""
 		fileSync.Errors = 0
 
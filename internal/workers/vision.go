@@ -25,7 +25,7 @@ import (
 )
 
 // Vision orchestrates background computer-vision tasks (labels, captions,
-// NSFW detection). It wraps configuration lookups and scheduling helpers.
+// NSFW detection, embeddings). It wraps configuration lookups and scheduling helpers.
 type Vision struct {
 	conf *config.Config
 }
@@ -80,6 +80,10 @@ func (w *Vision) scheduledModels() []string {
 		models = append(models, vision.ModelTypeFace)
 	}
 
+	if w.conf.VisionModelShouldRun(vision.ModelTypeEmbedding, vision.RunOnSchedule) {
+		models = append(models, vision.ModelTypeEmbedding)
+	}
+
 	return models
 }
 
@@ -110,6 +114,7 @@ func (w *Vision) Start(filter string, count int, models []string, customSrc stri
 	updateNsfw := slices.Contains(models, vision.ModelTypeNsfw)
 	updateCaptions := slices.Contains(models, vision.ModelTypeCaption)
 	detectFaces := slices.Contains(models, vision.ModelTypeFace)
+	updateEmbeddings := slices.Contains(models, vision.ModelTypeEmbedding)
 
 	// Refresh index metadata.
 	if n := len(models); n == 0 {
@@ -121,6 +126,9 @@ func (w *Vision) Start(filter string, count int, models []string, customSrc stri
 
 	customSrc = clean.ShortTypeLower(customSrc)
 
+	// Remember the embedding model name to skip pictures with up-to-date embeddings.
+	embeddingModel, _, _ := vision.Config.Model(vision.ModelTypeEmbedding).GetModel()
+
 	// Check time when worker was last executed.
 	updateIndex := false
 	// Remember if we saved new face markers so recognition can run after the loop.
@@ -148,7 +156,7 @@ func (w *Vision) Start(filter string, count int, models []string, customSrc stri
 
 	// Find photos without captions when only
 	// captions are updated without force flag.
-	if !updateLabels && !updateNsfw && !force {
+	if !updateLabels && !updateNsfw && !updateEmbeddings && !force {
 		frm.Caption = enum.False
 	}
 
@@ -188,8 +196,9 @@ func (w *Vision) Start(filter string, count int, models []string, customSrc stri
 		generateLabels := updateLabels && m.ShouldGenerateLabels(force)
 		generateCaptions := updateCaptions && m.ShouldGenerateCaption(customSrc, force)
 		detectNsfw := updateNsfw && (!photo.PhotoPrivate || force)
+		generateEmbedding := updateEmbeddings && m.ShouldGenerateEmbedding(embeddingModel, force)
 
-		if !generateLabels && !generateCaptions && !detectNsfw && !detectFaces {
+		if !generateLabels && !generateCaptions && !detectNsfw && !detectFaces && !generateEmbedding {
 			continue
 		}
 
@@ -267,11 +276,29 @@ func (w *Vision) Start(filter string, count int, models []string, customSrc stri
 		}
 
 		if changed {
-			if saveErr := m.SaveVision(); saveErr == nil {
-				updated++
+			if saveErr := m.SaveVision(); saveErr != nil {
+				changed = false
 			}
 		}
 
+		// Generate an embedding for semantic search after the labels and caption
+		// have been updated, as text embedding models use them as input.
+		if generateEmbedding {
+			if changed {
+				m.PreloadLabels()
+			}
+
+			if embeddingErr := photoprism.SavePhotoEmbedding(&m, file); embeddingErr != nil {
+				log.Warnf("vision: %s in %s (generate embedding)", clean.Error(embeddingErr), logName)
+			} else {
+				changed = true
+			}
+		}
+
+		if changed {
+			updated++
+		}
+
 		if mutex.VisionWorker.Canceled() {
 			return errors.New("vision: worker canceled")
 		}
@@ -294,6 +321,11 @@ func (w *Vision) Start(filter string, count int, models []string, customSrc stri
 		updateIndex = true
 	}
 
+	// Rank pictures again in semantic searches, as their embeddings may have changed.
+	if updateEmbeddings && updated > 0 {
+		search.FlushSemanticCache()
+	}
+
 	if updateFaces {
 		// Perform face recognition after saving new face markers.
 		log.Debugf("vision: running face recognition")
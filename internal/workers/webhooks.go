@@ -0,0 +1,197 @@
+package workers
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+	"strings"
+	"time"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/service/webhook"
+	"github.com/photoprism/photoprism/pkg/clean"
+)
+
+// WebhookTopics specifies the event hub topics that are forwarded to webhooks.
+var WebhookTopics = []string{
+	"photos.*",
+	"albums.*",
+	"user.*.albums.*",
+	webhook.ImportCompleted,
+	webhook.IndexCompleted,
+}
+
+// WebhookInterval specifies how often pending deliveries are retried.
+var WebhookInterval = time.Minute
+
+// WebhookBatchSize specifies the maximum number of deliveries per run.
+var WebhookBatchSize = 100
+
+var stopWebhooks = make(chan bool, 1)
+
+// Webhooks represents a worker that sends event notifications to registered webhooks.
+type Webhooks struct {
+	conf *config.Config
+}
+
+// NewWebhooks returns a new webhook worker.
+func NewWebhooks(conf *config.Config) *Webhooks {
+	if conf != nil {
+		webhook.UserAgent = conf.UserAgent()
+	}
+
+	return &Webhooks{conf: conf}
+}
+
+// Queue adds a pending delivery of the event for each webhook that is subscribed to the topic
+// and returns the number of deliveries. Topics published for a user, e.g. "user.*.albums.updated",
+// are delivered with the user prefix removed.
+func (w *Webhooks) Queue(topic string, data event.Data) (count int, err error) {
+	if ch := strings.Split(topic, event.TopicSep); len(ch) > 2 && ch[0] == "user" {
+		topic = strings.Join(ch[2:], event.TopicSep)
+	}
+
+	if !webhook.ValidEvent(topic) {
+		return 0, nil
+	}
+
+	hooks, err := query.Webhooks(0, 0, "all")
+
+	if err != nil {
+		return 0, err
+	}
+
+	var payload []byte
+
+	for _, hook := range hooks {
+		if !hook.Subscribed(topic) {
+			continue
+		}
+
+		// Encode payload once for all subscribed webhooks.
+		if payload == nil {
+			if payload, err = webhook.NewPayload(topic, time.Now(), data); err != nil {
+				return count, err
+			}
+		}
+
+		if err = entity.NewWebhookDelivery(hook.WebhookUID, topic, payload).Create(); err != nil {
+			return count, err
+		}
+
+		count++
+	}
+
+	return count, nil
+}
+
+// Start sends pending deliveries that are due and schedules retries for those that fail.
+func (w *Webhooks) Start() (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("webhooks: %s (worker panic)\nstack: %s", r, debug.Stack())
+			log.Error(err)
+		}
+	}()
+
+	if err = mutex.WebhookWorker.Start(); err != nil {
+		return err
+	}
+
+	defer mutex.WebhookWorker.Stop()
+
+	deliveries, err := query.DueWebhookDeliveries(WebhookBatchSize)
+
+	if err != nil {
+		return err
+	}
+
+	for i := range deliveries {
+		if mutex.WebhookWorker.Canceled() {
+			return nil
+		}
+
+		w.Deliver(&deliveries[i])
+	}
+
+	return nil
+}
+
+// Deliver sends a single delivery to its webhook and updates the delivery log.
+func (w *Webhooks) Deliver(d *entity.WebhookDelivery) {
+	hook := entity.FindWebhook(d.WebhookUID)
+
+	// Give up if the webhook has been deleted.
+	if hook == nil {
+		d.Status = entity.WebhookFailed
+		d.Error = "webhook not found"
+
+		if err := d.Save(); err != nil {
+			log.Errorf("webhooks: %s", clean.Error(err))
+		}
+
+		return
+	}
+
+	resp, err := webhook.Send(hook.WebhookURL, hook.WebhookSecret, d.MessageID, d.Payload)
+
+	if err = d.Attempted(resp, err); err != nil {
+		log.Errorf("webhooks: %s", clean.Error(err))
+	} else if d.Status == entity.WebhookDelivered {
+		log.Debugf("webhooks: delivered %s to %s", clean.Log(d.Event), clean.Log(hook.WebhookUID))
+	} else {
+		log.Warnf("webhooks: %s (send %s to %s)", d.Error, clean.Log(d.Event), clean.Log(hook.WebhookUID))
+	}
+
+	if d.Status == entity.WebhookDelivered {
+		err = hook.LogErr(nil)
+	} else {
+		err = hook.LogErr(errors.New(d.Error))
+	}
+
+	if err != nil {
+		log.Errorf("webhooks: %s", clean.Error(err))
+	}
+}
+
+// Listen queues deliveries for published events and sends them until the workers are shut down.
+func (w *Webhooks) Listen() {
+	s := event.Subscribe(WebhookTopics...)
+	ticker := time.NewTicker(WebhookInterval)
+
+	defer func() {
+		ticker.Stop()
+		event.Unsubscribe(s)
+	}()
+
+	for {
+		select {
+		case <-stopWebhooks:
+			mutex.WebhookWorker.Cancel()
+			return
+		case msg := <-s.Receiver:
+			if n, err := w.Queue(msg.Topic(), msg.Fields); err != nil {
+				log.Errorf("webhooks: %s", clean.Error(err))
+			} else if n > 0 {
+				RunWebhooks(w.conf)
+			}
+		case <-ticker.C:
+			RunWebhooks(w.conf)
+		}
+	}
+}
+
+// RunWebhooks runs the webhook worker once.
+func RunWebhooks(conf *config.Config) {
+	if !mutex.WebhookWorker.Running() {
+		go func() {
+			if err := NewWebhooks(conf).Start(); err != nil {
+				log.Warnf("webhooks: %s", err)
+			}
+		}()
+	}
+}
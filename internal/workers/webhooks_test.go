@@ -0,0 +1,95 @@
+package workers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/internal/config"
+	"github.com/photoprism/photoprism/internal/entity"
+	"github.com/photoprism/photoprism/internal/entity/query"
+	"github.com/photoprism/photoprism/internal/event"
+	"github.com/photoprism/photoprism/internal/form"
+	"github.com/photoprism/photoprism/internal/mutex"
+	"github.com/photoprism/photoprism/internal/service/webhook"
+	"github.com/photoprism/photoprism/pkg/http/header"
+)
+
+func TestNewWebhooks(t *testing.T) {
+	conf := config.TestConfig()
+
+	worker := NewWebhooks(conf)
+
+	assert.IsType(t, &Webhooks{}, worker)
+}
+
+func TestWebhooks_Start(t *testing.T) {
+	var received []string
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received = append(received, r.Header.Get(header.WebhookID))
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	defer srv.Close()
+
+	hook, err := entity.AddWebhook(form.Webhook{
+		WebhookURL:     srv.URL,
+		WebhookEvents:  "albums.*",
+		WebhookEnabled: true,
+	})
+
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	defer hook.Delete()
+
+	worker := NewWebhooks(config.TestConfig())
+
+	t.Run("Queue", func(t *testing.T) {
+		count, queueErr := worker.Queue("user.uqxetse3cy5eo9z2.albums.updated", event.Data{"entities": []string{"as6sg6bxpogaaba8"}})
+
+		assert.NoError(t, queueErr)
+		assert.Equal(t, 1, count)
+
+		count, queueErr = worker.Queue("photos.updated", event.Data{})
+
+		assert.NoError(t, queueErr)
+		assert.Equal(t, 0, count)
+	})
+	t.Run("Deliver", func(t *testing.T) {
+		if err = mutex.WebhookWorker.Start(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Error(t, worker.Start())
+
+		mutex.WebhookWorker.Stop()
+
+		if err = worker.Start(); err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Len(t, received, 1)
+
+		deliveries, queryErr := query.WebhookDeliveries(hook.WebhookUID, entity.WebhookDelivered, 0, 0)
+
+		assert.NoError(t, queryErr)
+
+		if assert.Len(t, deliveries, 1) {
+			assert.Equal(t, webhook.AlbumsUpdated, deliveries[0].Event)
+			assert.Equal(t, deliveries[0].MessageID, received[0])
+		}
+	})
+	t.Run("Deleted", func(t *testing.T) {
+		d := entity.NewWebhookDelivery("wt2hdkz1vgdb3xxx", webhook.AlbumsUpdated, []byte(`{}`))
+
+		worker.Deliver(d)
+
+		assert.True(t, d.Failed())
+		assert.Equal(t, "webhook not found", d.Error)
+	})
+}
@@ -39,8 +39,8 @@ var log = event.Log
 var stop = make(chan bool, 1)
 
 // Start launches background workers and scheduled tasks based on the current
-// configuration. It sets up the cron scheduler and the periodic metadata/share
-// workers.
+// configuration. It sets up the cron scheduler with user-defined jobs, the
+// periodic metadata/share workers, and the webhook notifications.
 func Start(conf *config.Config) {
 	if scheduler, err := gocron.NewScheduler(gocron.WithLocation(conf.DefaultTimezone())); err != nil {
 		log.Errorf("scheduler: %s (start)", err)
@@ -66,6 +66,9 @@ func Start(conf *config.Config) {
 			}
 		}
 
+		// Schedule user-defined jobs.
+		ScheduleJobs(conf)
+
 		// Start the scheduler.
 		Scheduler.Start()
 	}
@@ -100,9 +103,13 @@ func Start(conf *config.Config) {
 				RunMeta(conf)
 				RunShare(conf)
 				RunSync(conf)
+				ScheduleJobs(conf)
 			}
 		}
 	}()
+
+	// Send event notifications to registered webhooks.
+	go NewWebhooks(conf).Listen()
 }
 
 // Shutdown stops the background workers and shuts down the scheduler.
@@ -111,6 +118,11 @@ func Shutdown() {
 
 	stop <- true
 
+	select {
+	case stopWebhooks <- true:
+	default:
+	}
+
 	if Scheduler != nil {
 		if err := Scheduler.Shutdown(); err != nil {
 			log.Warnf("scheduler: %s (shutdown)", err)
@@ -31,13 +31,14 @@ var (
 	ErrUsersQuotaExceeded     = errors.New("users quota exceeded")
 )
 
-// OIDC and OAuth2-related error messages:
+// OIDC, LDAP, and OAuth2-related error messages:
 var (
 	ErrInvalidProviderConfiguration = errors.New("invalid provider configuration")
 	ErrInvalidGrantType             = errors.New("invalid grant type")
 	ErrInvalidClientID              = errors.New("invalid client id")
 	ErrInvalidAuthID                = errors.New("invalid auth id")
 	ErrAuthProviderIsNotOIDC        = errors.New("auth provider is not oidc")
+	ErrAuthProviderIsNotLDAP        = errors.New("auth provider is not ldap")
 	ErrAuthIDRequired               = errors.New("auth id required")
 	ErrAuthCodeRequired             = errors.New("auth code required")
 	ErrClientIDRequired             = errors.New("client id required")
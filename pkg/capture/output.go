@@ -25,11 +25,18 @@ func Output(f func()) string {
 		os.Stderr = stderr
 	}()
 
+	// Read output concurrently, so that writes do not block when the pipe buffer is full.
+	var buf bytes.Buffer
+	done := make(chan struct{})
+
+	go func() {
+		_, _ = io.Copy(&buf, r)
+		close(done)
+	}()
+
 	f()
 	_ = w.Close()
-
-	var buf bytes.Buffer
-	_, _ = io.Copy(&buf, r)
+	<-done
 
 	return buf.String()
 }
@@ -22,6 +22,7 @@ const (
 	CmdDir          = "cmd"
 	ConfigDir       = "config"
 	ExamplesDir     = "examples"
+	HlsDir          = "hls"
 	IconsDir        = "icons"
 	ImgDir          = "img"
 	LocalesDir      = "locales"
@@ -29,6 +30,7 @@ const (
 	ModelsDir       = "models"
 	ProfilesDir     = "profiles"
 	SettingsDir     = "settings"
+	SharesDir       = "shares"
 	SidecarDir      = "sidecar"
 	StaticDir       = "static"
 	StorageDir      = "storage"
@@ -17,6 +17,7 @@ type FileInfo struct {
 	Size int64     `json:"size"`
 	Date time.Time `json:"date"`
 	Dir  bool      `json:"dir"`
+	ETag string    `json:"etag,omitempty"`
 }
 
 func fileDir(dir, sep string) string {
@@ -60,6 +61,7 @@ func WebFileInfo(file webdav.FileInfo, dir string) FileInfo {
 		Size: file.Size,
 		Date: file.ModTime,
 		Dir:  file.IsDir,
+		ETag: file.ETag,
 	}
 
 	return result
@@ -0,0 +1,23 @@
+package s2
+
+import gs2 "github.com/golang/geo/s2"
+
+// Neighbors returns the tokens of all cells adjacent to the specified cell, including diagonal neighbors.
+func Neighbors(token string) (result []string) {
+	token = NormalizeToken(token)
+
+	cell := gs2.CellIDFromToken(token)
+
+	if !cell.IsValid() {
+		return result
+	}
+
+	neighbors := cell.AllNeighbors(cell.Level())
+	result = make([]string, 0, len(neighbors))
+
+	for _, n := range neighbors {
+		result = append(result, n.ToToken())
+	}
+
+	return result
+}
@@ -0,0 +1,29 @@
+package s2
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNeighbors(t *testing.T) {
+	t.Run("Germany", func(t *testing.T) {
+		token := TokenLevel(48.56344833333333, 8.996878333333333, 7)
+		result := Neighbors(token)
+
+		assert.Len(t, result, 8)
+		assert.NotContains(t, result, token)
+
+		for _, n := range result {
+			assert.Len(t, n, len(token))
+		}
+	})
+	t.Run("Prefixed", func(t *testing.T) {
+		token := TokenLevel(48.56344833333333, 8.996878333333333, 7)
+		assert.Equal(t, Neighbors(token), Neighbors(Prefix(token)))
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		assert.Empty(t, Neighbors("xxx"))
+		assert.Empty(t, Neighbors(""))
+	})
+}
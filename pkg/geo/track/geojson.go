@@ -0,0 +1,174 @@
+package track
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/geo"
+)
+
+// geoJsonGeometry represents a GeoJSON geometry.
+type geoJsonGeometry struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// geoJsonFeature represents a GeoJSON feature, or a feature collection.
+type geoJsonFeature struct {
+	Type       string                     `json:"type"`
+	Geometry   *geoJsonGeometry           `json:"geometry"`
+	Properties map[string]json.RawMessage `json:"properties"`
+	Features   []geoJsonFeature           `json:"features"`
+}
+
+// ParseGeoJson reads a track from GeoJSON data. Timestamps are read from the "coordTimes" or "times"
+// properties of line strings, the "time" or "timestamp" properties of points, or a fourth coordinate
+// value containing a Unix timestamp.
+func ParseGeoJson(data []byte) (t Track, err error) {
+	var doc geoJsonFeature
+
+	if err = json.Unmarshal(data, &doc); err != nil {
+		return t, err
+	}
+
+	var points []geo.Position
+	var name string
+
+	features := doc.Features
+
+	if doc.Type == "Feature" {
+		features = []geoJsonFeature{doc}
+	}
+
+	for _, f := range features {
+		if f.Geometry == nil {
+			continue
+		}
+
+		if name == "" {
+			_ = json.Unmarshal(f.Properties["name"], &name)
+		}
+
+		switch f.Geometry.Type {
+		case "Point":
+			var coord []float64
+
+			if json.Unmarshal(f.Geometry.Coordinates, &coord) != nil {
+				continue
+			}
+
+			ts := geoJsonTime(f.Properties, "time", "timestamp", "datetime")
+
+			if p, ok := geoJsonPosition(coord, ts); ok {
+				points = append(points, p)
+			}
+		case "LineString":
+			var coords [][]float64
+
+			if json.Unmarshal(f.Geometry.Coordinates, &coords) != nil {
+				continue
+			}
+
+			points = append(points, geoJsonLine(coords, geoJsonTimes(f.Properties))...)
+		case "MultiLineString":
+			var lines [][][]float64
+
+			if json.Unmarshal(f.Geometry.Coordinates, &lines) != nil {
+				continue
+			}
+
+			var times [][]string
+
+			for _, key := range []string{"coordTimes", "times"} {
+				if raw, ok := f.Properties[key]; ok && json.Unmarshal(raw, &times) == nil {
+					break
+				}
+			}
+
+			for i, coords := range lines {
+				var lineTimes []string
+
+				if i < len(times) {
+					lineTimes = times[i]
+				}
+
+				points = append(points, geoJsonLine(coords, lineTimes)...)
+			}
+		}
+	}
+
+	return New(name, points), nil
+}
+
+// geoJsonLine returns the timestamped positions of a line string.
+func geoJsonLine(coords [][]float64, times []string) (points []geo.Position) {
+	for i, coord := range coords {
+		var ts time.Time
+
+		if i < len(times) {
+			ts, _ = parseTime(times[i])
+		}
+
+		if p, ok := geoJsonPosition(coord, ts); ok {
+			points = append(points, p)
+		}
+	}
+
+	return points
+}
+
+// geoJsonPosition returns the position for GeoJSON coordinates in longitude, latitude, altitude, and time order.
+func geoJsonPosition(coord []float64, ts time.Time) (p geo.Position, ok bool) {
+	if len(coord) < 2 {
+		return p, false
+	}
+
+	p.Lng, p.Lat = coord[0], coord[1]
+
+	if len(coord) > 2 {
+		p.Altitude = coord[2]
+	}
+
+	if ts.IsZero() && len(coord) > 3 && coord[3] > 0 {
+		ts = time.Unix(int64(coord[3]), 0).UTC()
+	}
+
+	p.Time = ts
+
+	return p, !ts.IsZero()
+}
+
+// geoJsonTimes returns the line string timestamps from the feature properties.
+func geoJsonTimes(props map[string]json.RawMessage) (times []string) {
+	for _, key := range []string{"coordTimes", "times"} {
+		if raw, ok := props[key]; ok && json.Unmarshal(raw, &times) == nil {
+			return times
+		}
+	}
+
+	return nil
+}
+
+// geoJsonTime returns the first valid timestamp found in the specified feature properties.
+func geoJsonTime(props map[string]json.RawMessage, keys ...string) time.Time {
+	for _, key := range keys {
+		raw, ok := props[key]
+
+		if !ok {
+			continue
+		}
+
+		var s string
+		var n float64
+
+		if json.Unmarshal(raw, &s) == nil {
+			if ts, err := parseTime(s); err == nil {
+				return ts
+			}
+		} else if json.Unmarshal(raw, &n) == nil && n > 0 {
+			return time.Unix(int64(n), 0).UTC()
+		}
+	}
+
+	return time.Time{}
+}
@@ -0,0 +1,69 @@
+package track
+
+import (
+	"encoding/xml"
+
+	"github.com/photoprism/photoprism/pkg/geo"
+)
+
+// gpxPoint represents a GPX track, route, or waypoint.
+type gpxPoint struct {
+	Lat  float64 `xml:"lat,attr"`
+	Lon  float64 `xml:"lon,attr"`
+	Ele  float64 `xml:"ele"`
+	Time string  `xml:"time"`
+}
+
+// gpxDoc represents the relevant elements of a GPX document.
+type gpxDoc struct {
+	Name      string     `xml:"metadata>name"`
+	Waypoints []gpxPoint `xml:"wpt"`
+	Tracks    []struct {
+		Name     string `xml:"name"`
+		Segments []struct {
+			Points []gpxPoint `xml:"trkpt"`
+		} `xml:"trkseg"`
+	} `xml:"trk"`
+	Routes []struct {
+		Points []gpxPoint `xml:"rtept"`
+	} `xml:"rte"`
+}
+
+// ParseGpx reads a track from GPX data, including timestamped routes and waypoints.
+func ParseGpx(data []byte) (t Track, err error) {
+	var doc gpxDoc
+
+	if err = xml.Unmarshal(data, &doc); err != nil {
+		return t, err
+	}
+
+	var points []geo.Position
+
+	add := func(pts []gpxPoint) {
+		for _, p := range pts {
+			if ts, timeErr := parseTime(p.Time); timeErr == nil {
+				points = append(points, geo.Position{Lat: p.Lat, Lng: p.Lon, Altitude: p.Ele, Time: ts})
+			}
+		}
+	}
+
+	name := doc.Name
+
+	for _, trk := range doc.Tracks {
+		if name == "" {
+			name = trk.Name
+		}
+
+		for _, seg := range trk.Segments {
+			add(seg.Points)
+		}
+	}
+
+	for _, rte := range doc.Routes {
+		add(rte.Points)
+	}
+
+	add(doc.Waypoints)
+
+	return New(name, points), nil
+}
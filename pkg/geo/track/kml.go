@@ -0,0 +1,108 @@
+package track
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/photoprism/photoprism/pkg/geo"
+)
+
+// kmlTrack represents a gx:Track element with matching when and gx:coord lists.
+type kmlTrack struct {
+	When   []string `xml:"when"`
+	Coords []string `xml:"coord"`
+}
+
+// kmlPlacemark represents the relevant elements of a KML placemark.
+type kmlPlacemark struct {
+	Name       string     `xml:"name"`
+	When       string     `xml:"TimeStamp>when"`
+	Point      string     `xml:"Point>coordinates"`
+	Tracks     []kmlTrack `xml:"Track"`
+	MultiTrack []kmlTrack `xml:"MultiTrack>Track"`
+}
+
+// ParseKml reads a track from KML data, based on gx:Track elements and timestamped points.
+func ParseKml(data []byte) (t Track, err error) {
+	var points []geo.Position
+	var name string
+
+	d := xml.NewDecoder(bytes.NewReader(data))
+
+	for {
+		tok, tokErr := d.Token()
+
+		if errors.Is(tokErr, io.EOF) {
+			break
+		} else if tokErr != nil {
+			return t, tokErr
+		}
+
+		el, ok := tok.(xml.StartElement)
+
+		if !ok || el.Name.Local != "Placemark" {
+			continue
+		}
+
+		var pm kmlPlacemark
+
+		if err = d.DecodeElement(&pm, &el); err != nil {
+			return t, err
+		}
+
+		if name == "" {
+			name = strings.TrimSpace(pm.Name)
+		}
+
+		// Add timestamped point.
+		if ts, timeErr := parseTime(pm.When); timeErr == nil && pm.Point != "" {
+			if p, coordErr := parseKmlCoord(pm.Point, ","); coordErr == nil {
+				p.Time = ts
+				points = append(points, p)
+			}
+		}
+
+		// Add gx:Track points.
+		for _, trk := range append(pm.Tracks, pm.MultiTrack...) {
+			for i := 0; i < len(trk.When) && i < len(trk.Coords); i++ {
+				ts, timeErr := parseTime(trk.When[i])
+
+				if timeErr != nil {
+					continue
+				}
+
+				if p, coordErr := parseKmlCoord(trk.Coords[i], " "); coordErr == nil {
+					p.Time = ts
+					points = append(points, p)
+				}
+			}
+		}
+	}
+
+	return New(name, points), nil
+}
+
+// parseKmlCoord parses KML coordinates in longitude, latitude, and optional altitude order.
+func parseKmlCoord(s, sep string) (p geo.Position, err error) {
+	values := strings.Split(strings.TrimSpace(s), sep)
+
+	if len(values) < 2 {
+		return p, errors.New("invalid coordinates")
+	}
+
+	if p.Lng, err = strconv.ParseFloat(strings.TrimSpace(values[0]), 64); err != nil {
+		return p, err
+	} else if p.Lat, err = strconv.ParseFloat(strings.TrimSpace(values[1]), 64); err != nil {
+		return p, err
+	}
+
+	if len(values) > 2 {
+		p.Altitude, _ = strconv.ParseFloat(strings.TrimSpace(values[2]), 64)
+	}
+
+	return p, nil
+}
@@ -0,0 +1,85 @@
+package track
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Supported track file formats.
+const (
+	FormatGpx     = "gpx"
+	FormatKml     = "kml"
+	FormatGeoJson = "geojson"
+)
+
+// Formats maps file extensions to track file formats.
+var Formats = map[string]string{
+	".gpx":     FormatGpx,
+	".kml":     FormatKml,
+	".geojson": FormatGeoJson,
+	".json":    FormatGeoJson,
+}
+
+// FileFormat returns the track format based on the file extension, or an empty string if unsupported.
+func FileFormat(fileName string) string {
+	return Formats[strings.ToLower(filepath.Ext(fileName))]
+}
+
+// ReadFile reads a track from a GPX, KML, or GeoJSON file.
+func ReadFile(fileName string) (t Track, err error) {
+	format := FileFormat(fileName)
+
+	if format == "" {
+		return t, fmt.Errorf("unsupported track file format %s", filepath.Ext(fileName))
+	}
+
+	data, err := os.ReadFile(fileName) //nolint:gosec // track file provided by user
+
+	if err != nil {
+		return t, err
+	}
+
+	return Parse(data, format, filepath.Base(fileName))
+}
+
+// Parse reads a track in the specified format from data.
+func Parse(data []byte, format, name string) (t Track, err error) {
+	switch format {
+	case FormatGpx:
+		t, err = ParseGpx(data)
+	case FormatKml:
+		t, err = ParseKml(data)
+	case FormatGeoJson:
+		t, err = ParseGeoJson(data)
+	default:
+		return t, fmt.Errorf("unsupported track format %s", format)
+	}
+
+	if err != nil {
+		return t, err
+	} else if t.Empty() {
+		return t, fmt.Errorf("no timestamped positions found in %s", name)
+	}
+
+	if t.Name == "" {
+		t.Name = name
+	}
+
+	return t, nil
+}
+
+// parseTime parses timestamps as found in track files, assuming UTC if no time zone is specified.
+func parseTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05", "2006-01-02 15:04:05"} {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t.UTC(), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time %s", s)
+}
@@ -0,0 +1,66 @@
+package track
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReadFile(t *testing.T) {
+	start := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	for _, fileName := range []string{"testdata/track.gpx", "testdata/track.kml", "testdata/track.geojson"} {
+		t.Run(fileName, func(t *testing.T) {
+			trk, err := ReadFile(fileName)
+
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			assert.Equal(t, "Berlin Walk", trk.Name)
+			assert.Equal(t, 3, trk.Len())
+			assert.Equal(t, start, trk.Start())
+			assert.Equal(t, start.Add(time.Hour), trk.End())
+			assert.Equal(t, 52.52, trk.Points[0].Lat)
+			assert.Equal(t, 13.40, trk.Points[0].Lng)
+			assert.Equal(t, 34.0, trk.Points[0].Altitude)
+		})
+	}
+	t.Run("Unsupported", func(t *testing.T) {
+		_, err := ReadFile("testdata/track.txt")
+		assert.Error(t, err)
+	})
+	t.Run("NotFound", func(t *testing.T) {
+		_, err := ReadFile("testdata/missing.gpx")
+		assert.Error(t, err)
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Run("NoTimestamps", func(t *testing.T) {
+		_, err := Parse([]byte(`<gpx><trk><trkseg><trkpt lat="52.52" lon="13.40"></trkpt></trkseg></trk></gpx>`), FormatGpx, "test.gpx")
+		assert.Error(t, err)
+	})
+	t.Run("InvalidJson", func(t *testing.T) {
+		_, err := Parse([]byte(`{`), FormatGeoJson, "test.geojson")
+		assert.Error(t, err)
+	})
+	t.Run("UnixTime", func(t *testing.T) {
+		trk, err := Parse([]byte(`{"type":"Feature","properties":{},"geometry":{"type":"LineString","coordinates":[[13.40,52.52,34,1717236000]]}}`), FormatGeoJson, "test.geojson")
+
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		assert.Equal(t, "test.geojson", trk.Name)
+		assert.Equal(t, time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC), trk.Start())
+	})
+}
+
+func TestFileFormat(t *testing.T) {
+	assert.Equal(t, FormatGpx, FileFormat("track.GPX"))
+	assert.Equal(t, FormatKml, FileFormat("/tracks/track.kml"))
+	assert.Equal(t, FormatGeoJson, FileFormat("track.geojson"))
+	assert.Equal(t, "", FileFormat("track.txt"))
+}
@@ -0,0 +1,160 @@
+/*
+Package track reads GPS tracks from GPX, KML, and GeoJSON files and returns interpolated positions.
+
+Copyright (c) 2018 - 2025 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://www.photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package track
+
+import (
+	"sort"
+	"time"
+
+	"github.com/photoprism/photoprism/pkg/geo"
+)
+
+// DefaultMaxGap is the default maximum time between two track points, or between a track
+// endpoint and the time a picture was taken, for a position to be considered valid.
+const DefaultMaxGap = 30 * time.Minute
+
+// Track represents a sequence of timestamped positions, sorted by time.
+type Track struct {
+	Name   string
+	Points []geo.Position
+}
+
+// New returns a new track with the specified points, sorted by time.
+// Points without a timestamp or coordinates are ignored.
+func New(name string, points []geo.Position) Track {
+	t := Track{Name: name, Points: make([]geo.Position, 0, len(points))}
+
+	for _, p := range points {
+		if p.Time.IsZero() || p.Lat == 0.0 && p.Lng == 0.0 {
+			continue
+		}
+
+		p.Time = p.Time.UTC()
+		t.Points = append(t.Points, p)
+	}
+
+	sort.SliceStable(t.Points, func(i, j int) bool {
+		return t.Points[i].Time.Before(t.Points[j].Time)
+	})
+
+	return t
+}
+
+// Merge combines multiple tracks into a single track sorted by time.
+func Merge(name string, tracks ...Track) Track {
+	var points []geo.Position
+
+	for _, t := range tracks {
+		points = append(points, t.Points...)
+	}
+
+	return New(name, points)
+}
+
+// Len returns the number of track points.
+func (t Track) Len() int {
+	return len(t.Points)
+}
+
+// Empty tests if the track has no points.
+func (t Track) Empty() bool {
+	return len(t.Points) == 0
+}
+
+// Start returns the time of the first track point.
+func (t Track) Start() time.Time {
+	if t.Empty() {
+		return time.Time{}
+	}
+
+	return t.Points[0].Time
+}
+
+// End returns the time of the last track point.
+func (t Track) End() time.Time {
+	if t.Empty() {
+		return time.Time{}
+	}
+
+	return t.Points[len(t.Points)-1].Time
+}
+
+// Position returns the interpolated position at the specified time. No position is returned if the
+// surrounding track points, or the nearest endpoint, are more than maxGap apart.
+func (t Track) Position(at time.Time, maxGap time.Duration) (pos geo.Position, ok bool) {
+	n := len(t.Points)
+
+	if n == 0 || at.IsZero() {
+		return pos, false
+	}
+
+	at = at.UTC()
+
+	// Find the first point that is not before the specified time.
+	i := sort.Search(n, func(i int) bool {
+		return !t.Points[i].Time.Before(at)
+	})
+
+	switch {
+	case i < n && t.Points[i].Time.Equal(at):
+		pos = t.Points[i]
+	case i == 0:
+		if t.Points[0].Time.Sub(at) > maxGap {
+			return pos, false
+		}
+
+		pos = t.Points[0]
+	case i == n:
+		if at.Sub(t.Points[n-1].Time) > maxGap {
+			return pos, false
+		}
+
+		pos = t.Points[n-1]
+	default:
+		p1, p2 := t.Points[i-1], t.Points[i]
+		d := p2.Time.Sub(p1.Time)
+
+		if d > maxGap {
+			return pos, false
+		}
+
+		// Interpolate linearly between both points.
+		f := float64(at.Sub(p1.Time)) / float64(d)
+		m := geo.NewMovement(p1, p2)
+
+		pos = geo.Position{
+			Lat:      p1.Lat + (p2.Lat-p1.Lat)*f,
+			Lng:      p1.Lng + (p2.Lng-p1.Lng)*f,
+			Altitude: p1.Altitude + (p2.Altitude-p1.Altitude)*f,
+			Accuracy: m.EstimateAccuracy(at),
+		}
+	}
+
+	pos.Name = t.Name
+	pos.Time = at
+	pos.Estimate = false
+
+	return pos, true
+}
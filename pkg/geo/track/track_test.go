@@ -0,0 +1,78 @@
+package track
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/photoprism/photoprism/pkg/geo"
+)
+
+func TestTrack_Position(t *testing.T) {
+	start := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	trk := New("test", []geo.Position{
+		{Lat: 52.53, Lng: 13.42, Altitude: 44, Time: start.Add(10 * time.Minute)},
+		{Lat: 52.52, Lng: 13.40, Altitude: 34, Time: start},
+		{Lat: 52.54, Lng: 13.44, Altitude: 54, Time: start.Add(2 * time.Hour)},
+		{Lat: 52.55, Lng: 13.46},
+	})
+
+	assert.Equal(t, 3, trk.Len())
+	assert.Equal(t, start, trk.Start())
+	assert.Equal(t, start.Add(2*time.Hour), trk.End())
+
+	t.Run("Exact", func(t *testing.T) {
+		pos, ok := trk.Position(start, DefaultMaxGap)
+		assert.True(t, ok)
+		assert.Equal(t, 52.52, pos.Lat)
+		assert.Equal(t, 13.40, pos.Lng)
+		assert.False(t, pos.Estimate)
+	})
+	t.Run("Interpolated", func(t *testing.T) {
+		pos, ok := trk.Position(start.Add(5*time.Minute).In(time.FixedZone("CEST", 7200)), DefaultMaxGap)
+		assert.True(t, ok)
+		assert.InDelta(t, 52.525, pos.Lat, 0.00001)
+		assert.InDelta(t, 13.41, pos.Lng, 0.00001)
+		assert.InDelta(t, 39.0, pos.Altitude, 0.00001)
+		assert.Equal(t, start.Add(5*time.Minute), pos.Time)
+	})
+	t.Run("Gap", func(t *testing.T) {
+		_, ok := trk.Position(start.Add(time.Hour), DefaultMaxGap)
+		assert.False(t, ok)
+	})
+	t.Run("BeforeStart", func(t *testing.T) {
+		pos, ok := trk.Position(start.Add(-10*time.Minute), DefaultMaxGap)
+		assert.True(t, ok)
+		assert.Equal(t, 52.52, pos.Lat)
+
+		_, ok = trk.Position(start.Add(-time.Hour), DefaultMaxGap)
+		assert.False(t, ok)
+	})
+	t.Run("AfterEnd", func(t *testing.T) {
+		pos, ok := trk.Position(start.Add(2*time.Hour+time.Minute), DefaultMaxGap)
+		assert.True(t, ok)
+		assert.Equal(t, 52.54, pos.Lat)
+
+		_, ok = trk.Position(start.Add(3*time.Hour), DefaultMaxGap)
+		assert.False(t, ok)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		_, ok := Track{}.Position(start, DefaultMaxGap)
+		assert.False(t, ok)
+	})
+}
+
+func TestMerge(t *testing.T) {
+	start := time.Date(2024, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	t1 := New("a", []geo.Position{{Lat: 52.52, Lng: 13.40, Time: start.Add(time.Hour)}})
+	t2 := New("b", []geo.Position{{Lat: 52.53, Lng: 13.42, Time: start}})
+
+	result := Merge("all", t1, t2)
+
+	assert.Equal(t, "all", result.Name)
+	assert.Equal(t, 2, result.Len())
+	assert.Equal(t, start, result.Start())
+}
@@ -0,0 +1,40 @@
+package phash
+
+import "sort"
+
+// Group returns groups of similar hashes as lists of slice indexes. Each group contains the
+// first hash that was not assigned to a group yet, and all unassigned hashes within the maximum
+// distance of it, so groups cannot grow indefinitely through chains of similar hashes.
+// Hashes without similar hashes are not returned.
+func Group(hashes []Hash, maxDistance int) (groups [][]int) {
+	tree := &Tree{}
+
+	for i, h := range hashes {
+		tree.Add(h, i)
+	}
+
+	assigned := make([]bool, len(hashes))
+
+	for i, h := range hashes {
+		if assigned[i] {
+			continue
+		}
+
+		group := []int{i}
+		assigned[i] = true
+
+		for _, m := range tree.Find(h, maxDistance) {
+			if !assigned[m.ID] {
+				assigned[m.ID] = true
+				group = append(group, m.ID)
+			}
+		}
+
+		if len(group) > 1 {
+			sort.Ints(group)
+			groups = append(groups, group)
+		}
+	}
+
+	return groups
+}
@@ -0,0 +1,22 @@
+package phash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroup(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		groups := Group([]Hash{0x0, 0xff00, 0x1, 0xf0f0f0f0, 0xff01, 0x3}, 2)
+		assert.Equal(t, [][]int{{0, 2, 5}, {1, 4}}, groups)
+	})
+	t.Run("NoChains", func(t *testing.T) {
+		// 0x3 is similar to 0x1, but not to 0x0.
+		groups := Group([]Hash{0x0, 0x1, 0x3}, 1)
+		assert.Equal(t, [][]int{{0, 1}}, groups)
+	})
+	t.Run("None", func(t *testing.T) {
+		assert.Empty(t, Group([]Hash{0x0, 0xff}, 2))
+	})
+}
@@ -0,0 +1,106 @@
+/*
+Package phash calculates perceptual image hashes to find visually similar images.
+
+Copyright (c) 2018 - 2025 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://www.photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package phash
+
+import (
+	"encoding/hex"
+	"fmt"
+	"image"
+	"math/bits"
+	"strconv"
+
+	"github.com/disintegration/imaging"
+)
+
+// Bits is the number of bits in a perceptual hash.
+const Bits = 64
+
+// Hash represents a 64-bit difference hash (dHash) of an image.
+type Hash uint64
+
+// New calculates the difference hash of an image by scaling it down to 9x8 grayscale pixels
+// and comparing the brightness of adjacent pixels, so it is not affected by resizing,
+// compression, or minor color changes.
+func New(img image.Image) Hash {
+	small := imaging.Resize(imaging.Grayscale(img), 9, 8, imaging.Box)
+
+	var h Hash
+
+	for y := 0; y < 8; y++ {
+		for x := 0; x < 8; x++ {
+			left := small.Pix[small.PixOffset(x, y)]
+			right := small.Pix[small.PixOffset(x+1, y)]
+
+			h <<= 1
+
+			if left > right {
+				h |= 1
+			}
+		}
+	}
+
+	return h
+}
+
+// Parse returns the hash encoded as hex string, e.g. as stored in the index.
+func Parse(s string) (Hash, error) {
+	if len(s) != 16 {
+		return 0, fmt.Errorf("invalid perceptual hash %s", strconv.Quote(s))
+	}
+
+	b, err := hex.DecodeString(s)
+
+	if err != nil {
+		return 0, fmt.Errorf("invalid perceptual hash %s", strconv.Quote(s))
+	}
+
+	var h Hash
+
+	for _, v := range b {
+		h = h<<8 | Hash(v)
+	}
+
+	return h, nil
+}
+
+// Hex returns the hash as hex string with a fixed length of 16 characters.
+func (h Hash) Hex() string {
+	return fmt.Sprintf("%016x", uint64(h))
+}
+
+// String returns the hash as hex string.
+func (h Hash) String() string {
+	return h.Hex()
+}
+
+// Distance returns the Hamming distance between two hashes, i.e. the number of different bits.
+func (h Hash) Distance(other Hash) int {
+	return bits.OnesCount64(uint64(h ^ other))
+}
+
+// Similar tests if the Hamming distance between two hashes does not exceed the specified maximum.
+func (h Hash) Similar(other Hash, maxDistance int) bool {
+	return h.Distance(other) <= maxDistance
+}
@@ -0,0 +1,80 @@
+package phash
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+)
+
+// testImage returns a test image with a diagonal gradient and a bright square.
+func testImage(width, height int, invert bool) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			v := uint8((x*255/width + y*255/height) / 2) //nolint:gosec // value is within 0-255
+
+			if x > width/4 && x < width/2 && y > height/4 && y < height/2 {
+				v = 255
+			}
+
+			if invert {
+				v = 255 - v
+			}
+
+			img.Set(x, y, color.RGBA{R: v, G: v, B: v / 2, A: 255})
+		}
+	}
+
+	return img
+}
+
+func TestNew(t *testing.T) {
+	t.Run("Resized", func(t *testing.T) {
+		h1 := New(testImage(640, 480, false))
+		h2 := New(imaging.Resize(testImage(640, 480, false), 160, 120, imaging.Lanczos))
+
+		assert.LessOrEqual(t, h1.Distance(h2), 2)
+		assert.True(t, h1.Similar(h2, 8))
+	})
+	t.Run("Different", func(t *testing.T) {
+		h1 := New(testImage(640, 480, false))
+		h2 := New(testImage(640, 480, true))
+
+		assert.Greater(t, h1.Distance(h2), 32)
+		assert.False(t, h1.Similar(h2, 8))
+	})
+}
+
+func TestParse(t *testing.T) {
+	t.Run("Success", func(t *testing.T) {
+		h, err := Parse("f0e1d2c3b4a59687")
+
+		assert.NoError(t, err)
+		assert.Equal(t, Hash(0xf0e1d2c3b4a59687), h)
+		assert.Equal(t, "f0e1d2c3b4a59687", h.Hex())
+	})
+	t.Run("Zero", func(t *testing.T) {
+		h, err := Parse("0000000000000001")
+
+		assert.NoError(t, err)
+		assert.Equal(t, Hash(1), h)
+		assert.Equal(t, "0000000000000001", h.String())
+	})
+	t.Run("Invalid", func(t *testing.T) {
+		_, err := Parse("xyz")
+		assert.Error(t, err)
+
+		_, err = Parse("f0e1d2c3b4a5968g")
+		assert.Error(t, err)
+	})
+}
+
+func TestHash_Distance(t *testing.T) {
+	assert.Equal(t, 0, Hash(0xff).Distance(0xff))
+	assert.Equal(t, 8, Hash(0xff).Distance(0))
+	assert.Equal(t, 64, Hash(0).Distance(^Hash(0)))
+}
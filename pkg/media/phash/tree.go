@@ -0,0 +1,92 @@
+package phash
+
+// Tree is a BK-tree that finds hashes within a maximum Hamming distance
+// without comparing each hash with all others.
+type Tree struct {
+	root *node
+	size int
+}
+
+// node represents a hash in the tree, with child nodes indexed by their distance.
+type node struct {
+	hash     Hash
+	ids      []int
+	children map[int]*node
+}
+
+// Match represents a hash found in the tree.
+type Match struct {
+	ID       int
+	Hash     Hash
+	Distance int
+}
+
+// Len returns the number of ids in the tree.
+func (t *Tree) Len() int {
+	return t.size
+}
+
+// Add adds a hash with the specified id to the tree.
+func (t *Tree) Add(h Hash, id int) {
+	t.size++
+
+	if t.root == nil {
+		t.root = &node{hash: h, ids: []int{id}}
+		return
+	}
+
+	n := t.root
+
+	for {
+		d := n.hash.Distance(h)
+
+		if d == 0 {
+			n.ids = append(n.ids, id)
+			return
+		}
+
+		if n.children == nil {
+			n.children = make(map[int]*node)
+		}
+
+		child, ok := n.children[d]
+
+		if !ok {
+			n.children[d] = &node{hash: h, ids: []int{id}}
+			return
+		}
+
+		n = child
+	}
+}
+
+// Find returns the ids of all hashes within the maximum distance of the specified hash.
+func (t *Tree) Find(h Hash, maxDistance int) (matches []Match) {
+	if t.root == nil {
+		return matches
+	}
+
+	stack := []*node{t.root}
+
+	for len(stack) > 0 {
+		n := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		d := n.hash.Distance(h)
+
+		if d <= maxDistance {
+			for _, id := range n.ids {
+				matches = append(matches, Match{ID: id, Hash: n.hash, Distance: d})
+			}
+		}
+
+		// Only child nodes within the distance range can contain matches (triangle inequality).
+		for cd, child := range n.children {
+			if cd >= d-maxDistance && cd <= d+maxDistance {
+				stack = append(stack, child)
+			}
+		}
+	}
+
+	return matches
+}
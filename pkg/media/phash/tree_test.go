@@ -0,0 +1,36 @@
+package phash
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTree(t *testing.T) {
+	tree := &Tree{}
+
+	tree.Add(0x0, 1)
+	tree.Add(0x1, 2)
+	tree.Add(0x3, 3)
+	tree.Add(0xff, 4)
+	tree.Add(0x0, 5)
+
+	assert.Equal(t, 5, tree.Len())
+
+	t.Run("Exact", func(t *testing.T) {
+		matches := tree.Find(0x0, 0)
+		assert.Len(t, matches, 2)
+	})
+	t.Run("Distance", func(t *testing.T) {
+		matches := tree.Find(0x0, 2)
+		assert.Len(t, matches, 4)
+
+		for _, m := range matches {
+			assert.LessOrEqual(t, m.Distance, 2)
+			assert.NotEqual(t, 4, m.ID)
+		}
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.Empty(t, (&Tree{}).Find(0x0, 64))
+	})
+}
@@ -0,0 +1,99 @@
+/*
+Package quality estimates the technical quality of images, such as sharpness and exposure.
+
+Copyright (c) 2018 - 2025 PhotoPrism UG. All rights reserved.
+
+	This program is free software: you can redistribute it and/or modify
+	it under Version 3 of the GNU Affero General Public License (the "AGPL"):
+	<https://docs.photoprism.app/license/agpl>
+
+	This program is distributed in the hope that it will be useful,
+	but WITHOUT ANY WARRANTY; without even the implied warranty of
+	MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+	GNU Affero General Public License for more details.
+
+	The AGPL is supplemented by our Trademark and Brand Guidelines,
+	which describe how our Brand Assets may be used:
+	<https://www.photoprism.app/trademark>
+
+Feel free to send an email to hello@photoprism.app if you have questions,
+want to support our work, or just want to say hello.
+
+Additional information can be found in our Developer Guide:
+<https://docs.photoprism.app/developer-guide/>
+*/
+package quality
+
+import (
+	"image"
+	"math"
+
+	"github.com/disintegration/imaging"
+)
+
+// SharpnessScale is the Laplacian variance at which an image has a sharpness score of 0.5.
+var SharpnessScale = 100.0
+
+// Sharpness returns a score between 0 and 1 based on the variance of the Laplacian of the image,
+// which is higher for images with well-defined edges and lower for blurry images.
+func Sharpness(img image.Image) float64 {
+	gray := imaging.Grayscale(img)
+	bounds := gray.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if width < 3 || height < 3 {
+		return 0
+	}
+
+	var sum, sumSq float64
+
+	n := float64((width - 2) * (height - 2))
+
+	for y := 1; y < height-1; y++ {
+		for x := 1; x < width-1; x++ {
+			v := 4*lum(gray, x, y) - lum(gray, x-1, y) - lum(gray, x+1, y) - lum(gray, x, y-1) - lum(gray, x, y+1)
+			sum += v
+			sumSq += v * v
+		}
+	}
+
+	mean := sum / n
+	variance := sumSq/n - mean*mean
+
+	return variance / (variance + SharpnessScale)
+}
+
+// Exposure returns a score between 0 and 1, which is highest for images with a medium
+// brightness and decreases with the share of underexposed and overexposed pixels.
+func Exposure(img image.Image) float64 {
+	gray := imaging.Grayscale(img)
+	bounds := gray.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+
+	if width == 0 || height == 0 {
+		return 0
+	}
+
+	var sum, clipped float64
+
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			v := lum(gray, x, y)
+			sum += v
+
+			if v <= 5 || v >= 250 {
+				clipped++
+			}
+		}
+	}
+
+	pixels := float64(width * height)
+	brightness := 1 - math.Abs(sum/pixels-128)/128
+
+	return brightness * (1 - math.Min(1, 2*clipped/pixels))
+}
+
+// lum returns the luminance of a pixel in a grayscale image.
+func lum(img *image.NRGBA, x, y int) float64 {
+	return float64(img.Pix[img.PixOffset(x, y)])
+}
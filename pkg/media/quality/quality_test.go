@@ -0,0 +1,60 @@
+package quality
+
+import (
+	"image"
+	"image/color"
+	"testing"
+
+	"github.com/disintegration/imaging"
+	"github.com/stretchr/testify/assert"
+)
+
+// testImage returns a checkerboard test image with the specified brightness range.
+func testImage(low, high uint8) image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, 64, 64))
+
+	for y := 0; y < 64; y++ {
+		for x := 0; x < 64; x++ {
+			v := low
+
+			if (x/4+y/4)%2 == 0 {
+				v = high
+			}
+
+			img.Set(x, y, color.RGBA{R: v, G: v, B: v, A: 255})
+		}
+	}
+
+	return img
+}
+
+func TestSharpness(t *testing.T) {
+	t.Run("SharpBlurred", func(t *testing.T) {
+		sharp := testImage(64, 192)
+		blurred := imaging.Blur(sharp, 3)
+
+		assert.Greater(t, Sharpness(sharp), 0.9)
+		assert.Less(t, Sharpness(blurred), Sharpness(sharp))
+	})
+	t.Run("Flat", func(t *testing.T) {
+		assert.Equal(t, 0.0, Sharpness(testImage(128, 128)))
+	})
+	t.Run("TooSmall", func(t *testing.T) {
+		assert.Equal(t, 0.0, Sharpness(image.NewRGBA(image.Rect(0, 0, 2, 2))))
+	})
+}
+
+func TestExposure(t *testing.T) {
+	t.Run("Medium", func(t *testing.T) {
+		assert.InDelta(t, 1.0, Exposure(testImage(128, 128)), 0.01)
+	})
+	t.Run("Dark", func(t *testing.T) {
+		assert.Less(t, Exposure(testImage(0, 20)), 0.2)
+	})
+	t.Run("Bright", func(t *testing.T) {
+		assert.Less(t, Exposure(testImage(240, 255)), 0.2)
+	})
+	t.Run("Empty", func(t *testing.T) {
+		assert.Equal(t, 0.0, Exposure(image.NewRGBA(image.Rect(0, 0, 0, 0))))
+	})
+}
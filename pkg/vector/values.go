@@ -104,6 +104,20 @@ func (v Vector) EuclideanNorm() float64 {
 	return v.Norm(2.0)
 }
 
+// Normalize returns a copy of the vector scaled to unit length,
+// or a plain copy if the vector has no magnitude.
+func (v Vector) Normalize() Vector {
+	y := v.Copy()
+
+	if n := v.EuclideanNorm(); n > 0 {
+		for i := range y {
+			y[i] /= n
+		}
+	}
+
+	return y
+}
+
 func (v Vector) variance(mean float64) float64 {
 	n := float64(len(v))
 
@@ -27,6 +27,13 @@ func TestVector(t *testing.T) {
 		assert.InDelta(t, 0, e.EuclideanNorm(), 0.01)
 		assert.Equal(t, d.EuclideanNorm(), e.EuclideanNorm())
 	})
+	t.Run("Normalize", func(t *testing.T) {
+		assert.InDelta(t, 1.0, a.Normalize().EuclideanNorm(), 0.0001)
+		assert.InDelta(t, 1.0, b.Normalize().EuclideanNorm(), 0.0001)
+		assert.InDelta(t, a.CosineDist(b), a.Normalize().CosineDist(b.Normalize()), 0.0001)
+		assert.Equal(t, d, d.Normalize())
+		assert.NotSame(t, &a[0], &a.Normalize()[0])
+	})
 	t.Run("EuclideanDist", func(t *testing.T) {
 		assert.InDelta(t, 2, a.EuclideanDist(b), 0.01)
 		assert.InDelta(t, a.EuclideanDist(b), b.EuclideanDist(a), 0.01)